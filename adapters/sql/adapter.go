@@ -5,10 +5,13 @@ import (
 	"database/sql"
 	"fmt"
 	"reflect"
+	"slices"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/preslavrachev/backoffice/core"
+	"github.com/preslavrachev/backoffice/middleware/auth"
 
 	"github.com/iancoleman/strcase"
 )
@@ -17,6 +20,14 @@ import (
 type Adapter struct {
 	db     *sql.DB
 	logger *SQLLogger
+
+	viewsOnce sync.Once // Creates the saved views table on first use
+	viewsErr  error
+
+	usersOnce      sync.Once // Creates the users table on first use
+	usersErr       error
+	userAdminRoles []auth.Role      // Roles managing the Admin Users resource, DefaultUserAdminRole when empty
+	auth           *auth.AuthConfig // Auth of the admin using the adapter as its user store, to sign disabled users out
 }
 
 // New creates a new SQL adapter
@@ -137,27 +148,114 @@ func (a *Adapter) Find(ctx context.Context, resource *core.Resource, query *core
 	// Apply default sorting if none specified
 	query.ApplyDefaultSort(resource)
 
+	// Fall back to the resource's page size when the query doesn't set one
+	if query.Pagination.Limit <= 0 {
+		query.Pagination.Limit = resource.GetEffectivePageSize()
+	}
+
 	tableName := a.getTableName(resource)
+	queryStr, whereConditions, args, inMemorySorts, err := a.selectQuery(resource, query)
+	if err != nil {
+		return nil, err
+	}
+
+	// Count total records (before applying limit/offset)
+	countQuery := fmt.Sprintf("SELECT COUNT(*) FROM %s", tableName)
+	if len(whereConditions) > 0 {
+		countQuery += " WHERE " + strings.Join(whereConditions, " AND ")
+	}
+
+	var totalCount int64
+	start := time.Now()
+	err = a.db.QueryRowContext(ctx, countQuery, args...).Scan(&totalCount)
+	duration := time.Since(start)
+	if err != nil {
+		a.logger.LogError(countQuery, args, duration, err)
+		return nil, fmt.Errorf("failed to count records: %w", err)
+	}
+	a.logger.LogQuery(countQuery, args, duration, 1)
+
+	// Apply pagination
+	queryStr += fmt.Sprintf(" LIMIT %d OFFSET %d", query.Pagination.Limit, query.Pagination.Offset)
+
+	// Execute query
+	start = time.Now()
+	rows, err := a.loggedQueryContext(ctx, queryStr, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute query: %w", err)
+	}
+	defer rows.Close()
+
+	// Create slice to hold results
+	sliceType := reflect.SliceOf(resource.ModelType)
+	results := reflect.New(sliceType).Elem()
+
+	// Scan results
+	for rows.Next() {
+		// Create new instance of the model type
+		item := reflect.New(resource.ModelType.Elem()).Interface()
+
+		if err := a.scanRecord(ctx, resource, rows, item); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+
+		results = reflect.Append(results, reflect.ValueOf(item))
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	// Log the query with row count
+	duration = time.Since(start)
+	a.logger.LogQuery(queryStr, args, duration, results.Len())
+
+	// Convert results to []any
+	var items []any
+	for i := 0; i < results.Len(); i++ {
+		items = append(items, results.Index(i).Interface())
+	}
 
+	// Apply in-memory sorts in reverse so the first sort field takes precedence
+	for i := len(inMemorySorts) - 1; i >= 0; i-- {
+		if field, ok := resource.GetField(inMemorySorts[i].Field); ok {
+			core.SortItemsByComputedField(items, field, inMemorySorts[i].Direction)
+		}
+	}
+
+	// Calculate if there are more results
+	currentResultCount := int64(len(items))
+	hasMore := (int64(query.Pagination.Offset) + currentResultCount) < totalCount
+
+	return &core.Result{
+		Items:      items,
+		TotalCount: totalCount,
+		HasMore:    hasMore,
+		Query:      *query,
+	}, nil
+}
+
+// selectQuery builds the unpaginated SELECT of the records matching the query, in its order
+// Sorts on typed derived fields have no column and are returned to be applied in memory
+func (a *Adapter) selectQuery(resource *core.Resource, query *core.Query) (queryStr string, whereConditions []string, args []any, inMemorySorts []core.SortField, err error) {
 	// Build SELECT clause
-	selectClause := fmt.Sprintf("SELECT * FROM %s", tableName)
+	selectClause := fmt.Sprintf("SELECT * FROM %s", a.getTableName(resource))
 
 	// Build WHERE clause
-	var whereConditions []string
-	var args []any
-	argIndex := 1
-
-	for field, value := range query.Filters {
-		// Resolve field name to database column name
-		columnName := resource.GetColumnName(field)
-		whereConditions = append(whereConditions, fmt.Sprintf("%s = ?", columnName))
-		args = append(args, value)
-		argIndex++
+	whereConditions, args, err = whereClause(resource, query)
+	if err != nil {
+		return "", nil, nil, nil, err
 	}
 
 	// Build ORDER BY clause
 	var orderClauses []string
 	for _, sort := range query.Sort {
+		// Typed derived fields have no column - sort the fetched page instead
+		if field, ok := resource.GetField(sort.Field); ok && field.IsInMemorySortable() {
+			inMemorySorts = append(inMemorySorts, sort)
+			continue
+		}
+
 		direction := "ASC"
 		if sort.Direction == core.SortDesc {
 			direction = "DESC"
@@ -189,7 +287,7 @@ func (a *Adapter) Find(ctx context.Context, resource *core.Resource, query *core
 	}
 
 	// Construct full query
-	queryStr := selectClause
+	queryStr = selectClause
 	if len(whereConditions) > 0 {
 		queryStr += " WHERE " + strings.Join(whereConditions, " AND ")
 	}
@@ -197,73 +295,106 @@ func (a *Adapter) Find(ctx context.Context, resource *core.Resource, query *core
 		queryStr += " ORDER BY " + strings.Join(orderClauses, ", ")
 	}
 
-	// Count total records (before applying limit/offset)
-	countQuery := fmt.Sprintf("SELECT COUNT(*) FROM %s", tableName)
-	if len(whereConditions) > 0 {
-		countQuery += " WHERE " + strings.Join(whereConditions, " AND ")
+	return queryStr, whereConditions, args, inMemorySorts, nil
+}
+
+// Stream calls fn for every record matching the query, reading the rows one at a time
+// Sorts on typed derived fields need every record, so those results are collected and sorted first
+func (a *Adapter) Stream(ctx context.Context, resource *core.Resource, query *core.Query, fn func(item any) error) error {
+	if query == nil {
+		return fmt.Errorf("query cannot be nil")
 	}
+	query.ApplyDefaultSort(resource)
 
-	var totalCount int64
-	start := time.Now()
-	err := a.db.QueryRowContext(ctx, countQuery, args...).Scan(&totalCount)
-	duration := time.Since(start)
+	queryStr, _, args, inMemorySorts, err := a.selectQuery(resource, query)
 	if err != nil {
-		a.logger.LogError(countQuery, args, duration, err)
-		return nil, fmt.Errorf("failed to count records: %w", err)
+		return err
 	}
-	a.logger.LogQuery(countQuery, args, duration, 1)
-
-	// Apply pagination
-	queryStr += fmt.Sprintf(" LIMIT %d OFFSET %d", query.Pagination.Limit, query.Pagination.Offset)
 
-	// Execute query
-	start = time.Now()
+	start := time.Now()
 	rows, err := a.loggedQueryContext(ctx, queryStr, args...)
 	if err != nil {
-		return nil, fmt.Errorf("failed to execute query: %w", err)
+		return fmt.Errorf("failed to execute query: %w", err)
 	}
 	defer rows.Close()
 
-	// Create slice to hold results
-	sliceType := reflect.SliceOf(resource.ModelType)
-	results := reflect.New(sliceType).Elem()
-
-	// Scan results
+	var collected []any
+	count := 0
 	for rows.Next() {
-		// Create new instance of the model type
 		item := reflect.New(resource.ModelType.Elem()).Interface()
-
-		if err := a.scanRowIntoStruct(rows, item); err != nil {
-			return nil, fmt.Errorf("failed to scan row: %w", err)
+		if err := a.scanRecord(ctx, resource, rows, item); err != nil {
+			return fmt.Errorf("failed to scan row: %w", err)
 		}
+		count++
+		if len(inMemorySorts) > 0 {
+			collected = append(collected, item)
+			continue
+		}
+		if err := fn(item); err != nil {
+			return err
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("error iterating rows: %w", err)
+	}
+	a.logger.LogQuery(queryStr, args, time.Since(start), count)
 
-		results = reflect.Append(results, reflect.ValueOf(item))
+	for i := len(inMemorySorts) - 1; i >= 0; i-- {
+		if field, ok := resource.GetField(inMemorySorts[i].Field); ok {
+			core.SortItemsByComputedField(collected, field, inMemorySorts[i].Direction)
+		}
 	}
+	for _, item := range collected {
+		if err := fn(item); err != nil {
+			return err
+		}
+	}
+	return nil
+}
 
-	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("error iterating rows: %w", err)
+// whereClause builds the conditions selecting the records matching the query's filters, search and trash state
+func whereClause(resource *core.Resource, query *core.Query) ([]string, []any, error) {
+	var whereConditions []string
+	var args []any
+
+	for field, value := range query.Filters {
+		// Resolve field name to database column name
+		columnName := resource.GetColumnName(field)
+		if value == nil {
+			whereConditions = append(whereConditions, fmt.Sprintf("%s IS NULL", columnName))
+			continue
+		}
+		whereConditions = append(whereConditions, fmt.Sprintf("%s = ?", columnName))
+		args = append(args, value)
 	}
 
-	// Log the query with row count
-	duration = time.Since(start)
-	a.logger.LogQuery(queryStr, args, duration, results.Len())
+	// Typed comparisons such as ranges
+	for _, condition := range query.Conditions {
+		comparison, ok := conditionOperators[condition.Operator]
+		if !ok {
+			return nil, nil, fmt.Errorf("unsupported filter operator %q", condition.Operator)
+		}
+		whereConditions = append(whereConditions, fmt.Sprintf("%s %s ?", resource.GetColumnName(condition.Field), comparison))
+		args = append(args, condition.Value)
+	}
 
-	// Convert results to []any
-	var items []any
-	for i := 0; i < results.Len(); i++ {
-		items = append(items, results.Index(i).Interface())
+	// Match the search term against the searchable text columns
+	if query.Search != "" {
+		condition, searchArgs := searchCondition(resource, query.Search)
+		whereConditions = append(whereConditions, condition)
+		args = append(args, searchArgs...)
 	}
 
-	// Calculate if there are more results
-	currentResultCount := int64(len(items))
-	hasMore := (int64(query.Pagination.Offset) + currentResultCount) < totalCount
+	// Soft-deleted records only show up in the trash
+	if resource.SoftDeleteField != "" {
+		condition := "IS NULL"
+		if query.Trashed {
+			condition = "IS NOT NULL"
+		}
+		whereConditions = append(whereConditions, fmt.Sprintf("%s %s", resource.GetColumnName(resource.SoftDeleteField), condition))
+	}
 
-	return &core.Result{
-		Items:      items,
-		TotalCount: totalCount,
-		HasMore:    hasMore,
-		Query:      *query,
-	}, nil
+	return whereConditions, args, nil
 }
 
 // GetAll retrieves all records for a resource with optional filters (legacy method)
@@ -297,7 +428,7 @@ func (a *Adapter) GetAll(ctx context.Context, resource *core.Resource, filters m
 	for rows.Next() {
 		item := reflect.New(resource.ModelType.Elem()).Interface()
 
-		if err := a.scanRowIntoStruct(rows, item); err != nil {
+		if err := a.scanRecord(ctx, resource, rows, item); err != nil {
 			return nil, fmt.Errorf("failed to scan row: %w", err)
 		}
 
@@ -344,7 +475,7 @@ func (a *Adapter) GetByID(ctx context.Context, resource *core.Resource, id any)
 	}
 	rowCount = 1
 
-	if err := a.scanRowIntoStruct(rows, result); err != nil {
+	if err := a.scanRecord(ctx, resource, rows, result); err != nil {
 		return nil, fmt.Errorf("failed to scan row: %w", err)
 	}
 
@@ -375,16 +506,20 @@ func (a *Adapter) Create(ctx context.Context, resource *core.Resource, data any)
 		field := dataVal.Field(i)
 		fieldType := dataType.Field(i)
 
-		// Skip ID field for auto-increment
-		if fieldType.Name == "ID" || fieldType.Name == resource.IDField {
+		// Skip ID field for auto-increment, and fields that aren't columns such as loaded relationships
+		if fieldType.Name == "ID" || fieldType.Name == resource.IDField || fieldType.Tag.Get("db") == "-" {
 			continue
 		}
 
 		// Use resource's column name resolution
 		columnName := resource.GetColumnName(fieldType.Name)
+		value, err := columnValue(ctx, resource, fieldType.Name, field)
+		if err != nil {
+			return err
+		}
 		columns = append(columns, columnName)
 		placeholders = append(placeholders, "?")
-		values = append(values, field.Interface())
+		values = append(values, value)
 	}
 
 	queryStr := fmt.Sprintf(
@@ -394,16 +529,43 @@ func (a *Adapter) Create(ctx context.Context, resource *core.Resource, data any)
 		strings.Join(placeholders, ", "),
 	)
 
-	_, err := a.loggedExecContext(ctx, queryStr, values...)
+	result, err := a.loggedExecContext(ctx, queryStr, values...)
 	if err != nil {
 		return fmt.Errorf("failed to create record: %w", err)
 	}
 
+	// Assign generated integer IDs, so links and redirects can refer to the new record
+	if idField := dataVal.FieldByName(resource.IDField); idField.IsValid() && idField.CanSet() && idField.IsZero() {
+		if id, err := result.LastInsertId(); err == nil {
+			switch idField.Kind() {
+			case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+				idField.SetInt(id)
+			case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+				idField.SetUint(uint64(id))
+			}
+		}
+	}
+
 	return nil
 }
 
 // Update updates an existing record with partial updates
 func (a *Adapter) Update(ctx context.Context, resource *core.Resource, id any, data any) error {
+	// Only include non-zero values (fields that were actually set)
+	return a.update(ctx, resource, id, data, func(name string, field reflect.Value) bool {
+		return !field.IsZero()
+	})
+}
+
+// UpdateFields writes the named fields of data to the record, zero values included
+func (a *Adapter) UpdateFields(ctx context.Context, resource *core.Resource, id any, data any, fields []string) error {
+	return a.update(ctx, resource, id, data, func(name string, field reflect.Value) bool {
+		return slices.Contains(fields, name)
+	})
+}
+
+// update writes the columns of data whose fields are included to the record
+func (a *Adapter) update(ctx context.Context, resource *core.Resource, id any, data any, include func(name string, field reflect.Value) bool) error {
 	tableName := a.getTableName(resource)
 	primaryKey := resource.PrimaryKey
 	if primaryKey == "" {
@@ -428,17 +590,20 @@ func (a *Adapter) Update(ctx context.Context, resource *core.Resource, id any, d
 		field := dataVal.Field(i)
 		fieldType := dataType.Field(i)
 
-		// Skip ID/primary key fields
-		if fieldType.Name == resource.IDField || fieldType.Name == "ID" {
+		// Skip ID/primary key fields and fields that aren't columns
+		if fieldType.Name == resource.IDField || fieldType.Name == "ID" || fieldType.Tag.Get("db") == "-" {
 			continue
 		}
 
-		// Only include non-zero values (fields that were actually set)
-		if !field.IsZero() {
+		if include(fieldType.Name, field) {
 			// Use resource's column name resolution
 			columnName := resource.GetColumnName(fieldType.Name)
+			value, err := columnValue(ctx, resource, fieldType.Name, field)
+			if err != nil {
+				return err
+			}
 			setClauses = append(setClauses, fmt.Sprintf("%s = ?", columnName))
-			values = append(values, field.Interface())
+			values = append(values, value)
 		}
 	}
 
@@ -484,12 +649,58 @@ func (a *Adapter) Delete(ctx context.Context, resource *core.Resource, id any) e
 
 	_, err = a.loggedExecContext(ctx, queryStr, id)
 	if err != nil {
+		if isForeignKeyViolation(err) {
+			return fmt.Errorf("failed to delete record: %w", core.ErrRecordInUse)
+		}
 		return fmt.Errorf("failed to delete record: %w", err)
 	}
 
 	return nil
 }
 
+// isForeignKeyViolation reports whether a database error was caused by a foreign key constraint
+// The drivers word it differently, but all of them mention the foreign key
+func isForeignKeyViolation(err error) bool {
+	return strings.Contains(strings.ToLower(err.Error()), "foreign key")
+}
+
+// SoftDelete marks a record as deleted by setting its soft delete column to the current time
+func (a *Adapter) SoftDelete(ctx context.Context, resource *core.Resource, id any) error {
+	return a.setSoftDeleteColumn(ctx, resource, id, time.Now())
+}
+
+// Restore moves a soft-deleted record out of the trash
+func (a *Adapter) Restore(ctx context.Context, resource *core.Resource, id any) error {
+	return a.setSoftDeleteColumn(ctx, resource, id, nil)
+}
+
+// setSoftDeleteColumn updates the soft delete column of a single record
+func (a *Adapter) setSoftDeleteColumn(ctx context.Context, resource *core.Resource, id any, value any) error {
+	if resource.SoftDeleteField == "" {
+		return fmt.Errorf("resource %s does not support soft delete", resource.Name)
+	}
+
+	tableName := a.getTableName(resource)
+	primaryKey := resource.PrimaryKey
+	if primaryKey == "" {
+		primaryKey = "id"
+	}
+	primaryKeyColumn := resource.GetColumnName(primaryKey)
+	column := resource.GetColumnName(resource.SoftDeleteField)
+
+	// Check if record exists first
+	if _, err := a.GetByID(ctx, resource, id); err != nil {
+		return err
+	}
+
+	queryStr := fmt.Sprintf("UPDATE %s SET %s = ? WHERE %s = ?", tableName, column, primaryKeyColumn)
+	if _, err := a.loggedExecContext(ctx, queryStr, value, id); err != nil {
+		return fmt.Errorf("failed to update %s: %w", column, err)
+	}
+
+	return nil
+}
+
 // GetSchema returns schema information for the resource
 func (a *Adapter) GetSchema(resource *core.Resource) (*core.Schema, error) {
 	schema := &core.Schema{
@@ -551,30 +762,43 @@ func (a *Adapter) Count(ctx context.Context, resource *core.Resource, filters ma
 	return count, nil
 }
 
-// Search performs a basic text search across searchable fields
-func (a *Adapter) Search(ctx context.Context, resource *core.Resource, searchQuery string) ([]any, error) {
-	tableName := a.getTableName(resource)
+// conditionOperators maps filter operators to their SQL comparisons
+var conditionOperators = map[core.FilterOperator]string{
+	core.FilterGte: ">=",
+	core.FilterLte: "<=",
+}
 
-	// Build search query for searchable fields
-	var conditions []string
-	var args []any
+// searchCondition matches a term case-insensitively anywhere in the searchable text columns
+// Resources without searchable fields match nothing
+func searchCondition(resource *core.Resource, term string) (string, []any) {
+	fields := resource.SearchableFields()
+	if len(fields) == 0 {
+		return "1 = 0", nil
+	}
 
-	for _, field := range resource.Fields {
-		if field.Searchable && field.Type == "string" {
-			// Use resource's column name resolution
-			columnName := resource.GetColumnName(field.Name)
-			conditions = append(conditions, fmt.Sprintf("%s LIKE ?", columnName))
-			args = append(args, "%"+searchQuery+"%")
-		}
+	pattern := "%" + likeEscaper.Replace(strings.ToLower(term)) + "%"
+	conditions := make([]string, len(fields))
+	args := make([]any, len(fields))
+	for i, field := range fields {
+		conditions[i] = fmt.Sprintf(`LOWER(%s) LIKE ? ESCAPE '\'`, resource.GetColumnName(field.Name))
+		args[i] = pattern
 	}
+	return "(" + strings.Join(conditions, " OR ") + ")", args
+}
+
+// likeEscaper escapes LIKE wildcards so search terms match literally
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+// Search performs a basic text search across searchable fields
+func (a *Adapter) Search(ctx context.Context, resource *core.Resource, searchQuery string) ([]any, error) {
+	tableName := a.getTableName(resource)
 
-	if len(conditions) == 0 {
+	if len(resource.SearchableFields()) == 0 {
 		// If no searchable fields, return empty results
 		return []any{}, nil
 	}
 
-	// Join conditions with OR
-	whereClause := strings.Join(conditions, " OR ")
+	whereClause, args := searchCondition(resource, searchQuery)
 	queryStr := fmt.Sprintf("SELECT * FROM %s WHERE %s", tableName, whereClause)
 
 	start := time.Now()
@@ -588,7 +812,7 @@ func (a *Adapter) Search(ctx context.Context, resource *core.Resource, searchQue
 	for rows.Next() {
 		item := reflect.New(resource.ModelType.Elem()).Interface()
 
-		if err := a.scanRowIntoStruct(rows, item); err != nil {
+		if err := a.scanRecord(ctx, resource, rows, item); err != nil {
 			return nil, fmt.Errorf("failed to scan row: %w", err)
 		}
 
@@ -3,6 +3,7 @@ package sql
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"reflect"
 	"testing"
 	"time"
@@ -129,6 +130,37 @@ func createTestResource() *core.Resource {
 	}
 }
 
+func TestFind_ResourcePageSize(t *testing.T) {
+	db, err := setupTestDB()
+	if err != nil {
+		t.Fatalf("Failed to setup test database: %v", err)
+	}
+	defer db.Close()
+
+	if err := seedTestData(db); err != nil {
+		t.Fatalf("Failed to seed test data: %v", err)
+	}
+
+	adapter := New(db)
+	resource := createTestResource()
+	resource.PageSize = 4
+
+	// A query without a limit falls back to the resource's page size
+	query := core.NewQuery()
+	query.Pagination.Limit = 0
+	result, err := adapter.Find(context.Background(), resource, query)
+	if err != nil {
+		t.Fatalf("Find failed: %v", err)
+	}
+
+	if len(result.Items) != 4 {
+		t.Errorf("Expected 4 items (resource page size), got %d", len(result.Items))
+	}
+	if !result.HasMore {
+		t.Error("Expected more results beyond the first page")
+	}
+}
+
 func TestFind_BasicQuery(t *testing.T) {
 	db, err := setupTestDB()
 	if err != nil {
@@ -448,6 +480,146 @@ func TestFind_ConfiguredDefaultSort(t *testing.T) {
 	}
 }
 
+func TestFind_MultiColumnDefaultSort(t *testing.T) {
+	db, err := setupTestDB()
+	if err != nil {
+		t.Fatalf("Failed to setup test database: %v", err)
+	}
+	defer db.Close()
+
+	if err := seedTestData(db); err != nil {
+		t.Fatalf("Failed to seed test data: %v", err)
+	}
+	if _, err := db.Exec(`UPDATE test_users SET age = 40 WHERE name IN ('Kate', 'Alice', 'Eve')`); err != nil {
+		t.Fatalf("Failed to update ages: %v", err)
+	}
+
+	adapter := New(db)
+	resource := createTestResource()
+	resource.DefaultSorts = []core.SortField{
+		core.Sort("Age", core.SortDesc),
+		core.Sort("Name", core.SortAsc),
+	}
+
+	query := core.NewQuery()
+	query.WithPagination(3, 0)
+
+	result, err := adapter.Find(context.Background(), resource, query)
+	if err != nil {
+		t.Fatalf("Find with multi-column default sort failed: %v", err)
+	}
+
+	if len(result.Query.Sort) != 2 {
+		t.Fatalf("Expected 2 sort fields, got %d", len(result.Query.Sort))
+	}
+
+	expected := []string{"Alice", "Eve", "Kate"}
+	for i, item := range result.Items {
+		if name := item.(*TestUser).Name; name != expected[i] {
+			t.Errorf("Position %d: expected %s, got %s", i, expected[i], name)
+		}
+	}
+}
+
+func TestFind_NilFilterMatchesNull(t *testing.T) {
+	db, err := setupTestDB()
+	if err != nil {
+		t.Fatalf("Failed to setup test database: %v", err)
+	}
+	defer db.Close()
+
+	if err := seedTestData(db); err != nil {
+		t.Fatalf("Failed to seed test data: %v", err)
+	}
+	if _, err := db.Exec(`UPDATE test_users SET deleted_at = CURRENT_TIMESTAMP WHERE name = 'Alice'`); err != nil {
+		t.Fatalf("Failed to update user: %v", err)
+	}
+
+	adapter := New(db)
+	resource := createTestResource()
+
+	query := core.NewQuery().WithFilters(map[string]any{"deleted_at": nil})
+	result, err := adapter.Find(context.Background(), resource, query)
+	if err != nil {
+		t.Fatalf("Find with nil filter failed: %v", err)
+	}
+	if result.TotalCount != 11 {
+		t.Errorf("Expected 11 records with NULL deleted_at, got %d", result.TotalCount)
+	}
+}
+
+func TestFind_Search(t *testing.T) {
+	db, err := setupTestDB()
+	if err != nil {
+		t.Fatalf("Failed to setup test database: %v", err)
+	}
+	defer db.Close()
+
+	if err := seedTestData(db); err != nil {
+		t.Fatalf("Failed to seed test data: %v", err)
+	}
+
+	adapter := New(db)
+	resource := createTestResource()
+	resource.Fields[1].Searchable = true // Name
+	resource.Fields[2].Searchable = true // Email
+
+	tests := []struct {
+		name  string
+		query *core.Query
+		want  int64
+	}{
+		{"matches any searchable field case-insensitively", core.NewQuery().WithSearch("ALI"), 1},
+		{"matches substrings", core.NewQuery().WithSearch("example.com"), 12},
+		{"combines with filters", core.NewQuery().WithSearch("a").WithFilters(map[string]any{"Age": 25}), 1},
+		{"treats wildcards literally", core.NewQuery().WithSearch("%"), 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := adapter.Find(context.Background(), resource, tt.query)
+			if err != nil {
+				t.Fatalf("Find with search failed: %v", err)
+			}
+			if result.TotalCount != tt.want {
+				t.Errorf("Expected %d records, got %d", tt.want, result.TotalCount)
+			}
+		})
+	}
+}
+
+func TestFind_RangeConditions(t *testing.T) {
+	db, err := setupTestDB()
+	if err != nil {
+		t.Fatalf("Failed to setup test database: %v", err)
+	}
+	defer db.Close()
+
+	if err := seedTestData(db); err != nil {
+		t.Fatalf("Failed to seed test data: %v", err)
+	}
+
+	adapter := New(db)
+	resource := createTestResource()
+
+	query := core.NewQuery().
+		WithCondition("Age", core.FilterGte, int64(30)).
+		WithCondition("Age", core.FilterLte, int64(32))
+	result, err := adapter.Find(context.Background(), resource, query)
+	if err != nil {
+		t.Fatalf("Find with conditions failed: %v", err)
+	}
+	// Bob (30), Eve (32) and Henry (31)
+	if result.TotalCount != 3 {
+		t.Errorf("Expected 3 users aged 30 to 32, got %d", result.TotalCount)
+	}
+
+	query = core.NewQuery().WithCondition("Age", core.FilterOperator("like"), "3%")
+	if _, err := adapter.Find(context.Background(), resource, query); err == nil {
+		t.Error("Expected an error for an unsupported operator")
+	}
+}
+
 func TestCRUD_Operations(t *testing.T) {
 	db, err := setupTestDB()
 	if err != nil {
@@ -675,3 +847,162 @@ func TestDerivedFieldRespectsUserDirection(t *testing.T) {
 		t.Errorf("Expected different first results for ASC vs DESC, both returned: %s", firstAsc)
 	}
 }
+
+func TestSoftDeleteAndRestore(t *testing.T) {
+	db, err := setupTestDB()
+	if err != nil {
+		t.Fatalf("Failed to setup test database: %v", err)
+	}
+	defer db.Close()
+
+	if err := seedTestData(db); err != nil {
+		t.Fatalf("Failed to seed test data: %v", err)
+	}
+
+	adapter := New(db)
+	resource := createTestResource()
+	resource.SoftDeleteField = "DeletedAt"
+	ctx := context.Background()
+
+	if err := adapter.SoftDelete(ctx, resource, uint(1)); err != nil {
+		t.Fatalf("SoftDelete failed: %v", err)
+	}
+
+	result, err := adapter.Find(ctx, resource, core.NewQuery())
+	if err != nil {
+		t.Fatalf("Find failed: %v", err)
+	}
+	if result.TotalCount != 11 {
+		t.Errorf("Expected trashed record to be excluded (11 records), got %d", result.TotalCount)
+	}
+
+	trashQuery := core.NewQuery()
+	trashQuery.Trashed = true
+	trash, err := adapter.Find(ctx, resource, trashQuery)
+	if err != nil {
+		t.Fatalf("Find in trash failed: %v", err)
+	}
+	if trash.TotalCount != 1 || trash.Items[0].(*TestUser).ID != 1 {
+		t.Fatalf("Expected only record 1 in trash, got %d records", trash.TotalCount)
+	}
+
+	if err := adapter.Restore(ctx, resource, uint(1)); err != nil {
+		t.Fatalf("Restore failed: %v", err)
+	}
+	result, _ = adapter.Find(ctx, resource, core.NewQuery())
+	if result.TotalCount != 12 {
+		t.Errorf("Expected restored record to be listed again (12 records), got %d", result.TotalCount)
+	}
+}
+
+func TestSoftDelete_ScopedRestore(t *testing.T) {
+	db, err := setupTestDB()
+	if err != nil {
+		t.Fatalf("Failed to setup test database: %v", err)
+	}
+	defer db.Close()
+
+	if err := seedTestData(db); err != nil {
+		t.Fatalf("Failed to seed test data: %v", err)
+	}
+
+	adapter := New(db)
+	resource := createTestResource()
+	resource.SoftDeleteField = "DeletedAt"
+	resource.BaseScope = func(query *core.Query) *core.Query {
+		return query.WithFilters(map[string]any{"Name": "Alice"})
+	}
+	ctx := context.Background()
+
+	for _, id := range []uint{1, 2} {
+		if err := adapter.SoftDelete(ctx, resource, id); err != nil {
+			t.Fatalf("SoftDelete failed: %v", err)
+		}
+	}
+
+	if err := core.CheckScope(ctx, adapter, resource, uint(1)); !errors.Is(err, core.ErrOutOfScope) {
+		t.Errorf("Expected live records only to be checked, got %v", err)
+	}
+	if err := core.CheckScopeWithTrashed(ctx, adapter, resource, uint(1)); err != nil {
+		t.Errorf("Expected the trashed record within the scope to pass, got %v", err)
+	}
+	if err := core.CheckScopeWithTrashed(ctx, adapter, resource, uint(2)); !errors.Is(err, core.ErrOutOfScope) {
+		t.Errorf("Expected the trashed record outside the scope to be rejected, got %v", err)
+	}
+}
+
+func TestDelete_ForeignKeyViolation(t *testing.T) {
+	db, err := sql.Open("sqlite3", "file::memory:?_foreign_keys=on")
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer db.Close()
+	db.SetMaxOpenConns(1) // Every connection would get its own in-memory database
+
+	schema := `
+	CREATE TABLE test_users (id INTEGER PRIMARY KEY AUTOINCREMENT, name TEXT NOT NULL, email TEXT NOT NULL, age INTEGER NOT NULL);
+	CREATE TABLE test_posts (id INTEGER PRIMARY KEY AUTOINCREMENT, user_id INTEGER REFERENCES test_users(id));
+	INSERT INTO test_users (name, email, age) VALUES ('Alice', 'alice@example.com', 25), ('Bob', 'bob@example.com', 30);
+	INSERT INTO test_posts (user_id) VALUES (1);
+	`
+	if _, err := db.Exec(schema); err != nil {
+		t.Fatalf("Failed to create schema: %v", err)
+	}
+
+	adapter := New(db)
+	resource := createTestResource()
+	ctx := context.Background()
+
+	if err := adapter.Delete(ctx, resource, uint(1)); !errors.Is(err, core.ErrRecordInUse) {
+		t.Errorf("Expected ErrRecordInUse for a referenced record, got %v", err)
+	}
+	if err := adapter.Delete(ctx, resource, uint(2)); err != nil {
+		t.Errorf("Expected an unreferenced record to be deleted, got %v", err)
+	}
+}
+
+func TestStream(t *testing.T) {
+	db, err := setupTestDB()
+	if err != nil {
+		t.Fatalf("Failed to setup test database: %v", err)
+	}
+	defer db.Close()
+
+	if err := seedTestData(db); err != nil {
+		t.Fatalf("Failed to seed test data: %v", err)
+	}
+
+	adapter := New(db)
+	resource := createTestResource()
+
+	// Pagination is ignored, every matching record is streamed in order
+	query := core.NewQuery().WithSort("age", core.SortAsc).WithPagination(2, 0)
+	var ages []int
+	err = adapter.Stream(context.Background(), resource, query, func(item any) error {
+		ages = append(ages, item.(*TestUser).Age)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Stream failed: %v", err)
+	}
+
+	if len(ages) != 12 {
+		t.Fatalf("Expected all 12 records, got %d", len(ages))
+	}
+	for i := 1; i < len(ages); i++ {
+		if ages[i] < ages[i-1] {
+			t.Errorf("Expected records sorted by age, got %v", ages)
+			break
+		}
+	}
+
+	stop := errors.New("stop")
+	count := 0
+	err = adapter.Stream(context.Background(), resource, core.NewQuery(), func(item any) error {
+		count++
+		return stop
+	})
+	if !errors.Is(err, stop) || count != 1 {
+		t.Errorf("Expected streaming to stop at the first error, got %v after %d records", err, count)
+	}
+}
@@ -0,0 +1,83 @@
+package sql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/preslavrachev/backoffice/core"
+)
+
+// aggregateFuncs maps aggregate functions to their SQL, with %s standing for the aggregated column
+var aggregateFuncs = map[core.AggregateFunc]string{
+	core.AggregateCount: "COUNT(*)",
+	core.AggregateSum:   "SUM(%s)",
+	core.AggregateAvg:   "AVG(%s)",
+	core.AggregateMin:   "MIN(%s)",
+	core.AggregateMax:   "MAX(%s)",
+}
+
+// timeBuckets maps time buckets to SQLite date expressions, with %s standing for the grouped column
+var timeBuckets = map[core.TimeBucket]string{
+	core.BucketDay:   "strftime('%%Y-%%m-%%d', %s)",
+	core.BucketWeek:  "date(%s, 'weekday 0', '-6 days')",
+	core.BucketMonth: "strftime('%%Y-%%m', %s)",
+}
+
+// Aggregate groups the records matching the query and combines every group into a single value
+func (a *Adapter) Aggregate(ctx context.Context, resource *core.Resource, query core.AggregateQuery) ([]core.AggregatePoint, error) {
+	if err := query.Validate(); err != nil {
+		return nil, err
+	}
+	if query.Query == nil {
+		query.Query = core.NewQuery()
+	}
+	if query.Func == "" {
+		query.Func = core.AggregateCount
+	}
+
+	value := aggregateFuncs[query.Func]
+	if query.Func != core.AggregateCount {
+		value = fmt.Sprintf(value, resource.GetColumnName(query.Field))
+	}
+	group := "''"
+	if query.GroupBy != "" {
+		group = resource.GetColumnName(query.GroupBy)
+	}
+	if query.Bucket != "" {
+		group = fmt.Sprintf(timeBuckets[query.Bucket], group)
+	}
+
+	whereConditions, args, err := whereClause(resource, query.Query)
+	if err != nil {
+		return nil, err
+	}
+	queryStr := fmt.Sprintf("SELECT %s AS grp, %s FROM %s", group, value, a.getTableName(resource))
+	if len(whereConditions) > 0 {
+		queryStr += " WHERE " + strings.Join(whereConditions, " AND ")
+	}
+	if query.GroupBy != "" {
+		queryStr += " GROUP BY grp ORDER BY grp"
+	}
+
+	rows, err := a.loggedQueryContext(ctx, queryStr, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to aggregate %s: %w", resource.PluralName, err)
+	}
+	defer rows.Close()
+
+	var points []core.AggregatePoint
+	for rows.Next() {
+		var label sql.NullString
+		var total sql.NullFloat64
+		if err := rows.Scan(&label, &total); err != nil {
+			return nil, fmt.Errorf("failed to scan aggregate: %w", err)
+		}
+		points = append(points, core.AggregatePoint{Label: label.String, Value: total.Float64})
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating aggregates: %w", err)
+	}
+	return points, nil
+}
@@ -0,0 +1,81 @@
+package sql
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/preslavrachev/backoffice/core"
+)
+
+func TestAggregate(t *testing.T) {
+	db, err := setupTestDB()
+	if err != nil {
+		t.Fatalf("Failed to setup test database: %v", err)
+	}
+	defer db.Close()
+
+	for _, user := range []TestUser{
+		{Name: "Alice", Email: "alice@example.com", Age: 20, CreatedAt: time.Date(2026, 10, 5, 10, 0, 0, 0, time.UTC)},
+		{Name: "Bob", Email: "bob@example.com", Age: 30, CreatedAt: time.Date(2026, 10, 7, 10, 0, 0, 0, time.UTC)},
+		{Name: "Carol", Email: "carol@example.com", Age: 30, CreatedAt: time.Date(2026, 10, 14, 10, 0, 0, 0, time.UTC)},
+	} {
+		if _, err := db.Exec("INSERT INTO test_users (name, email, age, created_at) VALUES (?, ?, ?, ?)",
+			user.Name, user.Email, user.Age, user.CreatedAt); err != nil {
+			t.Fatalf("Failed to insert user: %v", err)
+		}
+	}
+
+	adapter := New(db)
+	resource := createTestResource()
+	ctx := context.Background()
+
+	tests := []struct {
+		name  string
+		query core.AggregateQuery
+		want  []core.AggregatePoint
+	}{
+		{
+			name:  "count per week",
+			query: core.AggregateQuery{GroupBy: "CreatedAt", Bucket: core.BucketWeek},
+			want:  []core.AggregatePoint{{Label: "2026-10-05", Value: 2}, {Label: "2026-10-12", Value: 1}},
+		},
+		{
+			name:  "sum per month",
+			query: core.AggregateQuery{GroupBy: "CreatedAt", Bucket: core.BucketMonth, Func: core.AggregateSum, Field: "Age"},
+			want:  []core.AggregatePoint{{Label: "2026-10", Value: 80}},
+		},
+		{
+			name:  "count per value",
+			query: core.AggregateQuery{GroupBy: "Age"},
+			want:  []core.AggregatePoint{{Label: "20", Value: 1}, {Label: "30", Value: 2}},
+		},
+		{
+			name:  "filtered",
+			query: core.AggregateQuery{Query: core.NewQuery().WithFilters(map[string]any{"Age": 30}), GroupBy: "CreatedAt", Bucket: core.BucketDay},
+			want:  []core.AggregatePoint{{Label: "2026-10-07", Value: 1}, {Label: "2026-10-14", Value: 1}},
+		},
+		{
+			name:  "average of all",
+			query: core.AggregateQuery{Func: core.AggregateAvg, Field: "Age"},
+			want:  []core.AggregatePoint{{Label: "", Value: 80.0 / 3}},
+		},
+		{
+			name:  "total without matches",
+			query: core.AggregateQuery{Query: core.NewQuery().WithFilters(map[string]any{"Age": 99}), Func: core.AggregateSum, Field: "Age"},
+			want:  []core.AggregatePoint{{Label: "", Value: 0}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			points, err := adapter.Aggregate(ctx, resource, tt.query)
+			if err != nil {
+				t.Fatalf("Aggregate failed: %v", err)
+			}
+			if !reflect.DeepEqual(points, tt.want) {
+				t.Errorf("Expected %v, got %v", tt.want, points)
+			}
+		})
+	}
+}
@@ -0,0 +1,122 @@
+package sql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+
+	"github.com/preslavrachev/backoffice/core"
+)
+
+// scanRecord scans a row into a record of the resource, decrypting its encrypted fields
+func (a *Adapter) scanRecord(ctx context.Context, resource *core.Resource, rows *sql.Rows, dest any) error {
+	if err := a.scanRowIntoStruct(rows, dest); err != nil {
+		return err
+	}
+	destValue := reflect.ValueOf(dest).Elem()
+	for i := range resource.Fields {
+		field := &resource.Fields[i]
+		if !field.IsEncrypted() {
+			continue
+		}
+		value := destValue.FieldByName(field.Name)
+		if !value.IsValid() || value.Kind() != reflect.String || !value.CanSet() {
+			continue
+		}
+		plaintext, err := field.DecryptValue(ctx, value.String())
+		if err != nil {
+			return fmt.Errorf("failed to decrypt %s: %w", field.Name, err)
+		}
+		value.SetString(plaintext)
+	}
+	return nil
+}
+
+// columnValue returns the value written to a field's column, encrypting it when the field is encrypted
+func columnValue(ctx context.Context, resource *core.Resource, fieldName string, value reflect.Value) (any, error) {
+	field, ok := resource.GetField(fieldName)
+	if !ok || !field.IsEncrypted() || value.Kind() != reflect.String {
+		return value.Interface(), nil
+	}
+	ciphertext, err := field.EncryptValue(ctx, value.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt %s: %w", fieldName, err)
+	}
+	return ciphertext, nil
+}
+
+// ReencryptAll rewrites the encrypted fields of every record, trashed ones included, that are still
+// plaintext or encrypted with a key other than the current one, returning how many values changed
+// Run it after rotating keys, before retiring the old key
+func (a *Adapter) ReencryptAll(ctx context.Context, resource *core.Resource) (int, error) {
+	tableName := a.getTableName(resource)
+	primaryKey := resource.PrimaryKey
+	if primaryKey == "" {
+		primaryKey = "id"
+	}
+	primaryKeyColumn := resource.GetColumnName(primaryKey)
+
+	updated := 0
+	for i := range resource.Fields {
+		field := &resource.Fields[i]
+		if !field.IsEncrypted() {
+			continue
+		}
+		column := resource.GetColumnName(field.Name)
+		stale, err := a.staleEncryptedValues(ctx, field, tableName, primaryKeyColumn, column)
+		if err != nil {
+			return updated, err
+		}
+		queryStr := fmt.Sprintf("UPDATE %s SET %s = ? WHERE %s = ?", tableName, column, primaryKeyColumn)
+		for _, value := range stale {
+			id := value.id
+			plaintext, err := field.DecryptValue(ctx, value.stored)
+			if err != nil {
+				return updated, fmt.Errorf("failed to decrypt %s of %v: %w", field.Name, id, err)
+			}
+			ciphertext, err := field.EncryptValue(ctx, plaintext)
+			if err != nil {
+				return updated, fmt.Errorf("failed to encrypt %s of %v: %w", field.Name, id, err)
+			}
+			if _, err := a.loggedExecContext(ctx, queryStr, ciphertext, id); err != nil {
+				return updated, fmt.Errorf("failed to update %s of %v: %w", field.Name, id, err)
+			}
+			updated++
+		}
+	}
+	return updated, nil
+}
+
+// storedValue is a column value as stored, along with its record's primary key
+type storedValue struct {
+	id     any
+	stored string
+}
+
+// staleEncryptedValues returns the stored values of an encrypted column needing re-encryption
+func (a *Adapter) staleEncryptedValues(ctx context.Context, field *core.FieldInfo, tableName, primaryKeyColumn, column string) ([]storedValue, error) {
+	queryStr := fmt.Sprintf("SELECT %s, %s FROM %s WHERE %s IS NOT NULL", primaryKeyColumn, column, tableName, column)
+	rows, err := a.loggedQueryContext(ctx, queryStr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute query: %w", err)
+	}
+	defer rows.Close()
+
+	var stale []storedValue
+	for rows.Next() {
+		var id any
+		var stored string
+		if err := rows.Scan(&id, &stored); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		needed, err := field.NeedsReencryption(ctx, stored)
+		if err != nil {
+			return nil, err
+		}
+		if needed {
+			stale = append(stale, storedValue{id: id, stored: stored})
+		}
+	}
+	return stale, rows.Err()
+}
@@ -0,0 +1,99 @@
+package sql
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/preslavrachev/backoffice/core"
+)
+
+func TestEncryptedField(t *testing.T) {
+	db, err := setupTestDB()
+	if err != nil {
+		t.Fatalf("Failed to setup test database: %v", err)
+	}
+	defer db.Close()
+
+	oldKey, newKey := bytes.Repeat([]byte{1}, 32), bytes.Repeat([]byte{2}, 32)
+	keys, err := core.NewKeyRing("2024", map[string][]byte{"2024": oldKey})
+	if err != nil {
+		t.Fatalf("NewKeyRing failed: %v", err)
+	}
+
+	adapter := New(db)
+	resource := createTestResource()
+	resource.Fields[2].Encryption = keys
+	ctx := context.Background()
+
+	user := &TestUser{Name: "Alice", Email: "alice@example.com", Age: 25}
+	if err := adapter.Create(ctx, resource, user); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+
+	var stored string
+	if err := db.QueryRow("SELECT email FROM test_users WHERE id = ?", user.ID).Scan(&stored); err != nil {
+		t.Fatalf("Failed to read the stored email: %v", err)
+	}
+	if strings.Contains(stored, "alice") || !strings.HasPrefix(stored, "enc:v1:2024:") {
+		t.Errorf("Expected the email to be stored encrypted, got %q", stored)
+	}
+
+	found, err := adapter.GetByID(ctx, resource, user.ID)
+	if err != nil {
+		t.Fatalf("GetByID failed: %v", err)
+	}
+	if email := found.(*TestUser).Email; email != "alice@example.com" {
+		t.Errorf("Expected the email to be decrypted on read, got %q", email)
+	}
+
+	// Plaintext written before the field was encrypted is read back as is
+	if _, err := db.Exec("INSERT INTO test_users (name, email, age) VALUES ('Bob', 'bob@example.com', 30)"); err != nil {
+		t.Fatalf("Failed to insert plaintext row: %v", err)
+	}
+
+	// Rotate: the new key becomes current, the old one stays to decrypt
+	resource.Fields[2].Encryption, _ = core.NewKeyRing("2025", map[string][]byte{"2024": oldKey, "2025": newKey})
+	result, err := adapter.Find(ctx, resource, core.NewQuery())
+	if err != nil {
+		t.Fatalf("Find after rotation failed: %v", err)
+	}
+	emails := map[string]bool{}
+	for _, item := range result.Items {
+		emails[item.(*TestUser).Email] = true
+	}
+	if len(emails) != 2 || !emails["alice@example.com"] || !emails["bob@example.com"] {
+		t.Fatalf("Expected both emails readable after rotation, got %v", emails)
+	}
+
+	updated, err := adapter.ReencryptAll(ctx, resource)
+	if err != nil {
+		t.Fatalf("ReencryptAll failed: %v", err)
+	}
+	if updated != 2 {
+		t.Errorf("Expected 2 values re-encrypted, got %d", updated)
+	}
+	rows, err := db.Query("SELECT email FROM test_users")
+	if err != nil {
+		t.Fatalf("Failed to read stored emails: %v", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		if err := rows.Scan(&stored); err != nil {
+			t.Fatal(err)
+		}
+		if !strings.HasPrefix(stored, "enc:v1:2025:") {
+			t.Errorf("Expected every email under the new key, got %q", stored)
+		}
+	}
+	if updated, _ := adapter.ReencryptAll(ctx, resource); updated != 0 {
+		t.Errorf("Expected nothing left to re-encrypt, got %d", updated)
+	}
+
+	// Retiring the old key is safe once everything was re-encrypted
+	resource.Fields[2].Encryption, _ = core.NewKeyRing("2025", map[string][]byte{"2025": newKey})
+	if _, err := adapter.GetByID(ctx, resource, user.ID); err != nil {
+		t.Errorf("Expected the record readable with the new key only, got %v", err)
+	}
+}
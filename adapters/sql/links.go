@@ -0,0 +1,62 @@
+package sql
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/preslavrachev/backoffice/core"
+)
+
+// LinkedIDs returns the related IDs a many-to-many field's join table holds for the record
+func (a *Adapter) LinkedIDs(ctx context.Context, resource *core.Resource, field *core.FieldInfo, id any) ([]any, error) {
+	rel := field.Relationship
+	rows, err := a.loggedQueryContext(ctx,
+		fmt.Sprintf("SELECT %s FROM %s WHERE %s = ? ORDER BY %s", rel.JoinRelatedKey, rel.JoinTable, rel.JoinForeignKey, rel.JoinRelatedKey),
+		id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load %s links: %w", field.DisplayName, err)
+	}
+	defer rows.Close()
+
+	var ids []any
+	for rows.Next() {
+		var relatedID any
+		if err := rows.Scan(&relatedID); err != nil {
+			return nil, fmt.Errorf("failed to scan %s link: %w", field.DisplayName, err)
+		}
+		ids = append(ids, relatedID)
+	}
+	return ids, rows.Err()
+}
+
+// SetLinks replaces the record's rows in a many-to-many field's join table within a transaction
+func (a *Adapter) SetLinks(ctx context.Context, resource *core.Resource, field *core.FieldInfo, id any, relatedIDs []any) error {
+	rel := field.Relationship
+	tx, err := a.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	exec := func(query string, args ...any) error {
+		start := time.Now()
+		if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+			a.logger.LogError(query, args, time.Since(start), err)
+			return err
+		}
+		a.logger.LogQuery(query, args, time.Since(start), 0)
+		return nil
+	}
+
+	if err := exec(fmt.Sprintf("DELETE FROM %s WHERE %s = ?", rel.JoinTable, rel.JoinForeignKey), id); err != nil {
+		return fmt.Errorf("failed to unlink %s: %w", field.DisplayName, err)
+	}
+	insert := fmt.Sprintf("INSERT INTO %s (%s, %s) VALUES (?, ?)", rel.JoinTable, rel.JoinForeignKey, rel.JoinRelatedKey)
+	for _, relatedID := range relatedIDs {
+		if err := exec(insert, id, relatedID); err != nil {
+			return fmt.Errorf("failed to link %s %v: %w", field.DisplayName, relatedID, err)
+		}
+	}
+	return tx.Commit()
+}
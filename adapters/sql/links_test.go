@@ -0,0 +1,64 @@
+package sql
+
+import (
+	"context"
+	"testing"
+
+	"github.com/preslavrachev/backoffice/core"
+	"github.com/preslavrachev/backoffice/middleware/auth"
+)
+
+type TestArticle struct {
+	ID         uint            `json:"id" db:"id"`
+	Title      string          `json:"title" db:"title"`
+	Categories []*TestCategory `json:"categories,omitempty" db:"-"`
+}
+
+func TestLinks(t *testing.T) {
+	db, err := setupTestDB()
+	if err != nil {
+		t.Fatalf("Failed to setup test database: %v", err)
+	}
+	defer db.Close()
+	if _, err := db.Exec(`
+		CREATE TABLE test_articles (id INTEGER PRIMARY KEY AUTOINCREMENT, title TEXT NOT NULL);
+		CREATE TABLE article_categories (article_id INTEGER NOT NULL, category_id INTEGER NOT NULL, PRIMARY KEY (article_id, category_id));
+	`); err != nil {
+		t.Fatalf("Failed to create tables: %v", err)
+	}
+
+	ctx := context.Background()
+	adapter := New(db)
+	admin := core.New(adapter, auth.WithNoAuth())
+	admin.RegisterResource(&TestArticle{}).
+		WithFields("Title").
+		WithManyToManyField("Categories", "TestCategory", func(r *core.RelationshipBuilder) {
+			r.JoinTable("article_categories", "article_id", "category_id")
+		})
+	resource, _ := admin.GetResource("TestArticle")
+	field, _ := resource.GetField("Categories")
+
+	// Relationship fields aren't columns, and the generated ID is assigned for linking
+	article := &TestArticle{Title: "Hello", Categories: []*TestCategory{{ID: 1}}}
+	if err := adapter.Create(ctx, resource, article); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	if article.ID == 0 {
+		t.Fatal("Expected Create to assign the generated ID")
+	}
+
+	if err := core.SetLinks(ctx, adapter, resource, field, article.ID, []any{uint(3), uint(1), uint(3)}); err != nil {
+		t.Fatalf("SetLinks failed: %v", err)
+	}
+	ids, err := core.LinkedIDs(ctx, adapter, resource, field, article.ID)
+	if err != nil || len(ids) != 2 || ids[0] != int64(1) || ids[1] != int64(3) {
+		t.Fatalf("Expected links to categories 1 and 3, got %v, %v", ids, err)
+	}
+
+	if err := core.SetLinks(ctx, adapter, resource, field, article.ID, nil); err != nil {
+		t.Fatalf("SetLinks failed: %v", err)
+	}
+	if ids, _ := core.LinkedIDs(ctx, adapter, resource, field, article.ID); len(ids) != 0 {
+		t.Errorf("Expected all links removed, got %v", ids)
+	}
+}
@@ -0,0 +1,326 @@
+package sql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/preslavrachev/backoffice/core"
+	"github.com/preslavrachev/backoffice/middleware/auth"
+)
+
+// usersTable holds the users that can sign in to the admin
+const usersTable = "backoffice_users"
+
+// userColumns are the columns scanned by scanUser, in order
+const userColumns = "id, username, email, roles, disabled, password_hash"
+
+// minPasswordLength is the shortest password accepted when resetting one from the admin
+const minPasswordLength = 8
+
+// DefaultUserAdminRole is the role managing the Admin Users resource unless SetUserAdminRoles says otherwise
+const DefaultUserAdminRole auth.Role = "admin"
+
+// AdminUser is a row of the users table, managed through the "Admin Users" resource
+// Passwords are set with the "Set password" action, which stores a hash in PasswordHash
+type AdminUser struct {
+	ID           int64  `db:"id"`
+	Username     string `db:"username"`
+	Email        string `db:"email"`
+	Roles        string `db:"roles"` // Comma-separated, e.g. "admin,editor"
+	Disabled     bool   `db:"disabled"`
+	PasswordHash string `db:"password_hash"`
+}
+
+// storedUser is a user as read from the users table
+type storedUser struct {
+	user         auth.AuthUser
+	disabled     bool
+	passwordHash string
+}
+
+// ensureUsersTable creates the users table on first use
+func (a *Adapter) ensureUsersTable(ctx context.Context) error {
+	a.usersOnce.Do(func() {
+		_, a.usersErr = a.loggedExecContext(ctx, `CREATE TABLE IF NOT EXISTS `+usersTable+` (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			username TEXT NOT NULL UNIQUE,
+			email TEXT NOT NULL DEFAULT '',
+			roles TEXT NOT NULL DEFAULT '',
+			disabled BOOLEAN NOT NULL DEFAULT FALSE,
+			password_hash TEXT NOT NULL DEFAULT ''
+		)`)
+	})
+	if a.usersErr != nil {
+		return fmt.Errorf("failed to create %s table: %w", usersTable, a.usersErr)
+	}
+	return nil
+}
+
+// SetUserAdminRoles sets the roles allowed to see and manage the Admin Users resource
+// Call it before core.New, which registers the resource
+func (a *Adapter) SetUserAdminRoles(roles ...auth.Role) {
+	a.userAdminRoles = roles
+}
+
+// RegisterUserResource registers the "Admin Users" resource for managing the users table
+// It is called by core.New when the adapter is the user store, and only registers the resource
+// when the adapter also stores the admin's resources, as it is edited through the adapter
+// Only users with one of the user admin roles can reach it, see SetUserAdminRoles
+func (a *Adapter) RegisterUserResource(bo *core.BackOffice) {
+	a.auth = bo.GetAuth()
+	if adapter, ok := bo.GetAdapter().(*Adapter); !ok || adapter != a {
+		return
+	}
+	if err := a.ensureUsersTable(context.Background()); err != nil {
+		return
+	}
+
+	roles := a.userAdminRoles
+	if len(roles) == 0 {
+		roles = []auth.Role{DefaultUserAdminRole}
+	}
+	allowed := make([]string, len(roles))
+	for i, role := range roles {
+		allowed[i] = string(role)
+	}
+	isUserAdmin := core.AllowRoles(allowed...)
+
+	builder := bo.RegisterResource(&AdminUser{}).
+		WithTableName(usersTable).
+		WithPermissions(core.Permissions{List: isUserAdmin, Create: isUserAdmin, Update: isUserAdmin, Delete: isUserAdmin}).
+		WithGroup("Administration").
+		WithField("Username", func(f *core.FieldBuilder) {
+			f.Required(true).Unique(true).Searchable(true)
+		}).
+		WithField("Email", func(f *core.FieldBuilder) {
+			f.Searchable(true)
+		}).
+		WithField("Roles", func(f *core.FieldBuilder) {
+			f.HelpText("Comma-separated, e.g. admin,editor")
+		}).
+		WithField("Disabled", func(f *core.FieldBuilder) {
+			f.ReadOnly(true)
+		}).
+		WithParamAction("set-password", "Set password", func(ctx context.Context, id any, params core.ActionParams) error {
+			return a.SetPassword(ctx, id, params.String("password"))
+		}, core.ActionParam{
+			Name:     "password",
+			Label:    "New password",
+			Type:     core.ParamPassword,
+			Required: true,
+			HelpText: fmt.Sprintf("At least %d characters", minPasswordLength),
+			Validate: func(value any) error {
+				if len(value.(string)) < minPasswordLength {
+					return fmt.Errorf("must be at least %d characters", minPasswordLength)
+				}
+				return nil
+			},
+		}).
+		WithCustomAction(core.NewAction("disable", "Disable", func(ctx context.Context, id any) error {
+			return a.SetUserDisabled(ctx, id, true)
+		}).WithConfirmation("The user will no longer be able to sign in.").Build()).
+		WithAction("enable", "Enable", func(ctx context.Context, id any) error {
+			return a.SetUserDisabled(ctx, id, false)
+		})
+
+	if authConfig := bo.GetAuth(); authConfig.SessionsManaged() {
+		builder.WithCustomAction(core.NewAction("revoke-sessions", "Sign out everywhere", func(ctx context.Context, id any) error {
+			stored, err := a.findUser(ctx, "id", id)
+			if err != nil {
+				return err
+			}
+			return authConfig.RevokeAllSessions(ctx, stored.user.Username)
+		}).WithConfirmation("The user will be signed out of every browser, including remembered ones.").Build())
+	}
+}
+
+// CreateUser stores a user with a hash of the password and assigns its ID
+func (a *Adapter) CreateUser(ctx context.Context, user *auth.AuthUser, password string) error {
+	hash, err := auth.HashPassword(password)
+	if err != nil {
+		return err
+	}
+	return a.CreateUserWithHash(ctx, user, hash)
+}
+
+// CreateUserWithHash stores a user whose password was already hashed with auth.HashPassword or in a
+// registered format, e.g. when importing users, and assigns its ID
+func (a *Adapter) CreateUserWithHash(ctx context.Context, user *auth.AuthUser, passwordHash string) error {
+	if err := a.ensureUsersTable(ctx); err != nil {
+		return err
+	}
+	if user.Username == "" {
+		return errors.New("username is required")
+	}
+	if !auth.IsPasswordHash(passwordHash) {
+		return auth.ErrInvalidPasswordHash
+	}
+
+	result, err := a.loggedExecContext(ctx,
+		"INSERT INTO "+usersTable+" (username, email, roles, password_hash) VALUES (?, ?, ?, ?)",
+		user.Username, user.Email, strings.Join(user.Roles, ","), passwordHash)
+	if err != nil {
+		return fmt.Errorf("failed to create user: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("failed to get user ID: %w", err)
+	}
+	user.ID = id
+	return nil
+}
+
+// SetPassword replaces the password of the user with the ID
+func (a *Adapter) SetPassword(ctx context.Context, id any, password string) error {
+	hash, err := auth.HashPassword(password)
+	if err != nil {
+		return err
+	}
+	return a.updateUser(ctx, id, "password_hash", hash)
+}
+
+// SetUserDisabled disables or re-enables signing in for the user with the ID
+// Disabling also signs the user out everywhere: their remember-me tokens are revoked and, when the
+// session store can list them, their sessions ended
+func (a *Adapter) SetUserDisabled(ctx context.Context, id any, disabled bool) error {
+	if err := a.updateUser(ctx, id, "disabled", disabled); err != nil {
+		return err
+	}
+	if !disabled || a.auth == nil {
+		return nil
+	}
+	stored, err := a.findUser(ctx, "id", id)
+	if err != nil {
+		return err
+	}
+	if err := a.auth.RevokeAllSessions(ctx, stored.user.Username); err != nil && !errors.Is(err, auth.ErrSessionsNotManaged) {
+		return fmt.Errorf("failed to sign out %s: %w", stored.user.Username, err)
+	}
+	return nil
+}
+
+// updateUser sets a column of the user with the ID
+func (a *Adapter) updateUser(ctx context.Context, id any, column string, value any) error {
+	if err := a.ensureUsersTable(ctx); err != nil {
+		return err
+	}
+
+	result, err := a.loggedExecContext(ctx, "UPDATE "+usersTable+" SET "+column+" = ? WHERE id = ?", value, id)
+	if err != nil {
+		return fmt.Errorf("failed to update user: %w", err)
+	}
+	if affected, err := result.RowsAffected(); err == nil && affected == 0 {
+		return auth.ErrUserNotFound
+	}
+	return nil
+}
+
+// ListUsers returns all users ordered by username, including disabled ones
+func (a *Adapter) ListUsers(ctx context.Context) ([]auth.AuthUser, error) {
+	if err := a.ensureUsersTable(ctx); err != nil {
+		return nil, err
+	}
+
+	rows, err := a.loggedQueryContext(ctx, "SELECT "+userColumns+" FROM "+usersTable+" ORDER BY username")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list users: %w", err)
+	}
+	defer rows.Close()
+
+	var users []auth.AuthUser
+	for rows.Next() {
+		stored, err := scanUser(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan user: %w", err)
+		}
+		users = append(users, stored.user)
+	}
+	return users, rows.Err()
+}
+
+// GetUserByUsername returns the user with the username, or ErrUserDisabled when they may not sign in
+func (a *Adapter) GetUserByUsername(ctx context.Context, username string) (*auth.AuthUser, error) {
+	stored, err := a.findUser(ctx, "username", username)
+	if err != nil {
+		return nil, err
+	}
+	if stored.disabled {
+		return nil, auth.ErrUserDisabled
+	}
+	return &stored.user, nil
+}
+
+// GetUserByEmail returns the user with the email
+func (a *Adapter) GetUserByEmail(ctx context.Context, email string) (*auth.AuthUser, error) {
+	if email == "" {
+		return nil, auth.ErrUserNotFound
+	}
+	stored, err := a.findUser(ctx, "email", email)
+	if err != nil {
+		return nil, err
+	}
+	return &stored.user, nil
+}
+
+// VerifyPassword returns the user when the password matches the stored hash and the user is enabled
+func (a *Adapter) VerifyPassword(ctx context.Context, username, password string) (*auth.AuthUser, error) {
+	stored, err := a.findUser(ctx, "username", username)
+	if err != nil {
+		return nil, err
+	}
+	if stored.passwordHash == "" {
+		return nil, auth.ErrInvalidPassword // Created from the admin, no password set yet
+	}
+	ok, err := auth.CheckPassword(stored.passwordHash, password)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check password of %s: %w", username, err)
+	}
+	if !ok {
+		return nil, auth.ErrInvalidPassword
+	}
+	if stored.disabled {
+		return nil, auth.ErrUserDisabled
+	}
+	return &stored.user, nil
+}
+
+// findUser returns the first user whose column equals the value
+func (a *Adapter) findUser(ctx context.Context, column string, value any) (*storedUser, error) {
+	if err := a.ensureUsersTable(ctx); err != nil {
+		return nil, err
+	}
+
+	row := a.db.QueryRowContext(ctx,
+		"SELECT "+userColumns+" FROM "+usersTable+" WHERE "+column+" = ? ORDER BY id LIMIT 1", value)
+	stored, err := scanUser(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, auth.ErrUserNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	return stored, nil
+}
+
+// scanUser reads a row of userColumns
+func scanUser(row interface{ Scan(dest ...any) error }) (*storedUser, error) {
+	var (
+		id     int64
+		roles  string
+		stored storedUser
+	)
+	if err := row.Scan(&id, &stored.user.Username, &stored.user.Email, &roles, &stored.disabled, &stored.passwordHash); err != nil {
+		return nil, err
+	}
+	stored.user.ID = id
+	for _, role := range strings.Split(roles, ",") {
+		if role = strings.TrimSpace(role); role != "" {
+			stored.user.Roles = append(stored.user.Roles, role)
+		}
+	}
+	return &stored, nil
+}
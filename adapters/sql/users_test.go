@@ -0,0 +1,193 @@
+package sql
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/preslavrachev/backoffice/core"
+	"github.com/preslavrachev/backoffice/middleware/auth"
+)
+
+func TestUserStore(t *testing.T) {
+	db, err := setupTestDB()
+	if err != nil {
+		t.Fatalf("Failed to setup test database: %v", err)
+	}
+	defer db.Close()
+
+	ctx := context.Background()
+	adapter := New(db)
+	var store auth.UserStore = adapter
+
+	for _, user := range []*auth.AuthUser{
+		{Username: "editor", Email: "editor@example.com", Roles: []string{"editor"}},
+		{Username: "admin", Email: "admin@example.com", Roles: []string{"admin", "editor"}},
+	} {
+		if err := adapter.CreateUser(ctx, user, user.Username+"-secret"); err != nil {
+			t.Fatalf("CreateUser failed: %v", err)
+		}
+		if user.ID == nil {
+			t.Fatal("Expected CreateUser to assign an ID")
+		}
+	}
+	if err := adapter.CreateUser(ctx, &auth.AuthUser{Username: "admin"}, "other"); err == nil {
+		t.Error("Expected a duplicate username to be rejected")
+	}
+
+	users, err := store.ListUsers(ctx)
+	if err != nil {
+		t.Fatalf("ListUsers failed: %v", err)
+	}
+	if len(users) != 2 || users[0].Username != "admin" || len(users[0].Roles) != 2 {
+		t.Errorf("Expected both users ordered by username, got %+v", users)
+	}
+
+	user, err := store.VerifyPassword(ctx, "admin", "admin-secret")
+	if err != nil {
+		t.Fatalf("VerifyPassword failed: %v", err)
+	}
+	if user.Email != "admin@example.com" {
+		t.Errorf("Expected the admin user, got %+v", user)
+	}
+	if _, err := store.VerifyPassword(ctx, "admin", "editor-secret"); !errors.Is(err, auth.ErrInvalidPassword) {
+		t.Errorf("Expected ErrInvalidPassword, got %v", err)
+	}
+	if _, err := store.VerifyPassword(ctx, "nobody", "secret"); !errors.Is(err, auth.ErrUserNotFound) {
+		t.Errorf("Expected ErrUserNotFound, got %v", err)
+	}
+
+	if user, err := store.GetUserByEmail(ctx, "editor@example.com"); err != nil || user.Username != "editor" {
+		t.Errorf("Expected the editor by email, got %+v, %v", user, err)
+	}
+
+	// Users imported with their hashes sign in without the password ever being stored
+	if err := adapter.CreateUserWithHash(ctx, &auth.AuthUser{Username: "imported"}, "imported-secret"); !errors.Is(err, auth.ErrInvalidPasswordHash) {
+		t.Errorf("Expected a plain password to be refused as a hash, got %v", err)
+	}
+	hash, _ := auth.HashPassword("imported-secret")
+	if err := adapter.CreateUserWithHash(ctx, &auth.AuthUser{Username: "imported"}, hash); err != nil {
+		t.Fatalf("CreateUserWithHash failed: %v", err)
+	}
+	if _, err := store.VerifyPassword(ctx, "imported", "imported-secret"); err != nil {
+		t.Errorf("Expected the imported user to sign in, got %v", err)
+	}
+}
+
+func TestUserResource(t *testing.T) {
+	db, err := setupTestDB()
+	if err != nil {
+		t.Fatalf("Failed to setup test database: %v", err)
+	}
+	defer db.Close()
+
+	ctx := context.Background()
+	adapter := New(db)
+	bo := core.New(adapter, auth.WithUserStore(adapter))
+
+	resource, ok := bo.GetResource("AdminUser")
+	if !ok {
+		t.Fatal("Expected the user store to register the AdminUser resource")
+	}
+	if err := bo.Validate(); err != nil {
+		t.Errorf("Expected the AdminUser resource to be valid, got %v", err)
+	}
+	if resource.PluralName != "Admin Users" || resource.TableName != usersTable {
+		t.Errorf("Expected Admin Users stored in %s, got %q in %q", usersTable, resource.PluralName, resource.TableName)
+	}
+
+	// Users created from the admin cannot sign in until a password is set
+	user := &AdminUser{Username: "editor", Email: "editor@example.com", Roles: "editor"}
+	if err := adapter.Create(ctx, resource, user); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	if _, err := adapter.VerifyPassword(ctx, "editor", ""); !errors.Is(err, auth.ErrInvalidPassword) {
+		t.Errorf("Expected ErrInvalidPassword before a password is set, got %v", err)
+	}
+
+	actions := make(map[string]core.CustomAction)
+	for _, action := range resource.Actions {
+		actions[action.ID] = action
+	}
+	if _, err := core.ParseActionParams(actions["set-password"].Params, func(string) string { return "short" }); err == nil {
+		t.Error("Expected a short password to be rejected")
+	}
+	params, err := core.ParseActionParams(actions["set-password"].Params, func(string) string { return " long enough " })
+	if err != nil {
+		t.Fatalf("ParseActionParams failed: %v", err)
+	}
+	if err := actions["set-password"].ParamHandler(ctx, user.ID, params); err != nil {
+		t.Fatalf("Set password failed: %v", err)
+	}
+	signedIn, err := adapter.VerifyPassword(ctx, "editor", " long enough ")
+	if err != nil {
+		t.Fatalf("Expected the new password to work, got %v", err)
+	}
+	if len(signedIn.Roles) != 1 || signedIn.Roles[0] != "editor" {
+		t.Errorf("Expected the editor role, got %v", signedIn.Roles)
+	}
+
+	if err := actions["disable"].Handler(ctx, user.ID); err != nil {
+		t.Fatalf("Disable failed: %v", err)
+	}
+	if _, err := adapter.VerifyPassword(ctx, "editor", " long enough "); !errors.Is(err, auth.ErrUserDisabled) {
+		t.Errorf("Expected ErrUserDisabled, got %v", err)
+	}
+	if err := actions["enable"].Handler(ctx, user.ID); err != nil {
+		t.Fatalf("Enable failed: %v", err)
+	}
+	if _, err := adapter.VerifyPassword(ctx, "editor", " long enough "); err != nil {
+		t.Errorf("Expected the user to sign in again, got %v", err)
+	}
+}
+
+func TestUserResourceRequiresSameAdapter(t *testing.T) {
+	db, err := setupTestDB()
+	if err != nil {
+		t.Fatalf("Failed to setup test database: %v", err)
+	}
+	defer db.Close()
+
+	bo := core.New(New(db), auth.WithUserStore(New(db)))
+	if _, ok := bo.GetResource("AdminUser"); ok {
+		t.Error("Expected no AdminUser resource when users live in another adapter")
+	}
+}
+
+func TestSetUserDisabled_SignsOut(t *testing.T) {
+	db, err := setupTestDB()
+	if err != nil {
+		t.Fatalf("Failed to setup test database: %v", err)
+	}
+	defer db.Close()
+
+	ctx := context.Background()
+	adapter := New(db)
+	authConfig := auth.WithUserStore(adapter)
+	sessions := auth.NewMemorySessionStore()
+	tokens := auth.NewMemoryRememberTokenStore()
+	authConfig.SessionStore = sessions
+	authConfig.RememberMe.Store = tokens
+	core.New(adapter, authConfig)
+
+	user := &auth.AuthUser{Username: "editor"}
+	if err := adapter.CreateUser(ctx, user, "editor-secret"); err != nil {
+		t.Fatalf("CreateUser failed: %v", err)
+	}
+	sessionID, _ := sessions.CreateSession(ctx, user)
+	token, _ := tokens.CreateToken(ctx, user, time.Now().Add(time.Hour))
+
+	if err := adapter.SetUserDisabled(ctx, user.ID, true); err != nil {
+		t.Fatalf("SetUserDisabled failed: %v", err)
+	}
+	if _, err := sessions.GetSession(ctx, sessionID); err == nil {
+		t.Error("Expected the disabled user's session to end")
+	}
+	if _, err := tokens.ConsumeToken(ctx, token); err == nil {
+		t.Error("Expected the disabled user's remember-me token to be revoked")
+	}
+	if _, err := adapter.GetUserByUsername(ctx, "editor"); !errors.Is(err, auth.ErrUserDisabled) {
+		t.Errorf("Expected disabled users not to be looked up for signing in, got %v", err)
+	}
+}
@@ -0,0 +1,112 @@
+package sql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/preslavrachev/backoffice/core"
+)
+
+// savedViewsTable holds the saved list views of all resources
+const savedViewsTable = "backoffice_saved_views"
+
+// ensureViewsTable creates the saved views table on first use
+func (a *Adapter) ensureViewsTable(ctx context.Context) error {
+	a.viewsOnce.Do(func() {
+		_, a.viewsErr = a.loggedExecContext(ctx, `CREATE TABLE IF NOT EXISTS `+savedViewsTable+` (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			resource TEXT NOT NULL,
+			name TEXT NOT NULL,
+			query TEXT NOT NULL,
+			created_by TEXT NOT NULL DEFAULT '',
+			created_at DATETIME NOT NULL
+		)`)
+	})
+	if a.viewsErr != nil {
+		return fmt.Errorf("failed to create %s table: %w", savedViewsTable, a.viewsErr)
+	}
+	return nil
+}
+
+// SaveView stores a view and assigns its ID
+func (a *Adapter) SaveView(ctx context.Context, view *core.SavedView) error {
+	if err := a.ensureViewsTable(ctx); err != nil {
+		return err
+	}
+
+	result, err := a.loggedExecContext(ctx,
+		"INSERT INTO "+savedViewsTable+" (resource, name, query, created_by, created_at) VALUES (?, ?, ?, ?, ?)",
+		view.Resource, view.Name, view.Query, view.CreatedBy, view.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to save view: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("failed to get view ID: %w", err)
+	}
+	view.ID = id
+	return nil
+}
+
+// ListViews returns the views of a resource ordered by name
+func (a *Adapter) ListViews(ctx context.Context, resource string) ([]core.SavedView, error) {
+	if err := a.ensureViewsTable(ctx); err != nil {
+		return nil, err
+	}
+
+	rows, err := a.loggedQueryContext(ctx,
+		"SELECT id, resource, name, query, created_by, created_at FROM "+savedViewsTable+" WHERE resource = ? ORDER BY name, id",
+		resource)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list views: %w", err)
+	}
+	defer rows.Close()
+
+	var views []core.SavedView
+	for rows.Next() {
+		var view core.SavedView
+		if err := rows.Scan(&view.ID, &view.Resource, &view.Name, &view.Query, &view.CreatedBy, &view.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan view: %w", err)
+		}
+		views = append(views, view)
+	}
+	return views, rows.Err()
+}
+
+// GetView returns a single view of a resource
+func (a *Adapter) GetView(ctx context.Context, resource string, id int64) (*core.SavedView, error) {
+	if err := a.ensureViewsTable(ctx); err != nil {
+		return nil, err
+	}
+
+	var view core.SavedView
+	err := a.db.QueryRowContext(ctx,
+		"SELECT id, resource, name, query, created_by, created_at FROM "+savedViewsTable+" WHERE resource = ? AND id = ?",
+		resource, id).Scan(&view.ID, &view.Resource, &view.Name, &view.Query, &view.CreatedBy, &view.CreatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("view %d of %s not found", id, resource)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get view: %w", err)
+	}
+	return &view, nil
+}
+
+// DeleteView removes a view of a resource
+func (a *Adapter) DeleteView(ctx context.Context, resource string, id int64) error {
+	if err := a.ensureViewsTable(ctx); err != nil {
+		return err
+	}
+
+	result, err := a.loggedExecContext(ctx, "DELETE FROM "+savedViewsTable+" WHERE resource = ? AND id = ?", resource, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete view: %w", err)
+	}
+	if affected, err := result.RowsAffected(); err == nil && affected == 0 {
+		return fmt.Errorf("view %d of %s not found", id, resource)
+	}
+	return nil
+}
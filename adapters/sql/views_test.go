@@ -0,0 +1,55 @@
+package sql
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/preslavrachev/backoffice/core"
+)
+
+func TestSavedViews(t *testing.T) {
+	db, err := setupTestDB()
+	if err != nil {
+		t.Fatalf("Failed to setup test database: %v", err)
+	}
+	defer db.Close()
+
+	ctx := context.Background()
+	adapter := New(db)
+
+	view := &core.SavedView{
+		Resource:  "TestUser",
+		Name:      "Over thirty",
+		Query:     "Age__gte=30&sort=Name",
+		CreatedBy: "admin",
+		CreatedAt: time.Now(),
+	}
+	if err := adapter.SaveView(ctx, view); err != nil {
+		t.Fatalf("SaveView failed: %v", err)
+	}
+	if view.ID == 0 {
+		t.Fatal("Expected SaveView to assign an ID")
+	}
+
+	views, err := adapter.ListViews(ctx, "TestUser")
+	if err != nil {
+		t.Fatalf("ListViews failed: %v", err)
+	}
+	if len(views) != 1 || views[0].Query != view.Query || views[0].CreatedBy != "admin" {
+		t.Errorf("Expected the saved view back, got %+v", views)
+	}
+	if views, _ := adapter.ListViews(ctx, "TestCategory"); len(views) != 0 {
+		t.Errorf("Expected no views for another resource, got %+v", views)
+	}
+
+	if err := adapter.DeleteView(ctx, "TestUser", view.ID); err != nil {
+		t.Fatalf("DeleteView failed: %v", err)
+	}
+	if _, err := adapter.GetView(ctx, "TestUser", view.ID); err == nil {
+		t.Error("Expected the deleted view to be gone")
+	}
+	if err := adapter.DeleteView(ctx, "TestUser", view.ID); err == nil {
+		t.Error("Expected an error when deleting a missing view")
+	}
+}
@@ -0,0 +1,37 @@
+// Command hashpassword prints a hash of a password for BasicAuthUser.Password,
+// BACKOFFICE_BASIC_AUTH_PASS_HASH or a user store, so no password is kept in plain text
+//
+// It reads the password from the first line of standard input, keeping it out of the shell history:
+//
+//	go run github.com/preslavrachev/backoffice/cmd/hashpassword < password.txt
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/preslavrachev/backoffice/middleware/auth"
+)
+
+func main() {
+	fmt.Fprint(os.Stderr, "Password: ")
+	password, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil && password == "" {
+		fmt.Fprintln(os.Stderr, "\nno password given")
+		os.Exit(1)
+	}
+	password = strings.TrimRight(password, "\r\n")
+	if password == "" {
+		fmt.Fprintln(os.Stderr, "\npassword is empty")
+		os.Exit(1)
+	}
+
+	hash, err := auth.HashPassword(password)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	fmt.Println(hash)
+}
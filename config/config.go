@@ -17,16 +17,18 @@ type Config struct {
 
 // AuthConfig holds authentication configuration
 type AuthConfig struct {
-	BasicAuthUser string
-	BasicAuthPass string
+	BasicAuthUser     string
+	BasicAuthPass     string
+	BasicAuthPassHash string // Hash of the password, see auth.HashPassword; used instead of BasicAuthPass when set
 }
 
 // LoadConfig loads configuration from environment variables
 // .env file is automatically loaded via autoload import
 func LoadConfig() *Config {
 	authConfig := &AuthConfig{
-		BasicAuthUser: getEnvWithDefault("BACKOFFICE_BASIC_AUTH_USER", "admin"),
-		BasicAuthPass: getEnvWithDefault("BACKOFFICE_BASIC_AUTH_PASS", "admin123"),
+		BasicAuthUser:     getEnvWithDefault("BACKOFFICE_BASIC_AUTH_USER", "admin"),
+		BasicAuthPass:     getSecretEnvWithDefault("BACKOFFICE_BASIC_AUTH_PASS", "admin123"),
+		BasicAuthPassHash: getSecretEnvWithDefault("BACKOFFICE_BASIC_AUTH_PASS_HASH", ""),
 	}
 
 	debugEnabled := getBoolEnvWithDefault("DEBUG", false)
@@ -36,7 +38,7 @@ func LoadConfig() *Config {
 		DebugEnabled: debugEnabled,
 	}
 
-	fmt.Printf("🔐 DEBUG: Loaded auth config - User: '%s', Pass: '%s'\n", authConfig.BasicAuthUser, authConfig.BasicAuthPass)
+	fmt.Printf("🔐 DEBUG: Loaded auth config - User: '%s', Password hashed: %t\n", authConfig.BasicAuthUser, authConfig.BasicAuthPassHash != "")
 	if debugEnabled {
 		fmt.Printf("🐛 DEBUG: SQL debug logging enabled\n")
 	}
@@ -59,6 +61,15 @@ func getEnvWithDefault(key, defaultValue string) string {
 	return defaultValue
 }
 
+// getSecretEnvWithDefault gets a secret environment variable with a default fallback, without logging its value
+func getSecretEnvWithDefault(key, defaultValue string) string {
+	if value := strings.TrimSpace(os.Getenv(key)); value != "" {
+		fmt.Printf("🔐 DEBUG: Using environment variable %s\n", key)
+		return value
+	}
+	return defaultValue
+}
+
 // getBoolEnvWithDefault gets a boolean environment variable with a default fallback
 func getBoolEnvWithDefault(key string, defaultValue bool) bool {
 	if value := strings.TrimSpace(os.Getenv(key)); value != "" {
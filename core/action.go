@@ -1,6 +1,19 @@
 package core
 
-import "context"
+import (
+	"context"
+
+	"github.com/preslavrachev/backoffice/middleware/auth"
+)
+
+// ActionLevel indicates how risky an action is, which controls its styling and confirmation
+type ActionLevel string
+
+const (
+	ActionLevelDefault ActionLevel = ""        // Plain confirmation dialog
+	ActionLevelWarning ActionLevel = "warning" // Highlighted, plain confirmation dialog
+	ActionLevelDanger  ActionLevel = "danger"  // Rendered red, requires typing the action title to confirm
+)
 
 // CustomAction represents a custom action that can be performed on a resource
 type CustomAction struct {
@@ -14,6 +27,42 @@ type CustomAction struct {
 	// It receives the context and the ID of the record to act upon
 	// Returns an error if the action fails
 	Handler func(ctx context.Context, id any) error `json:"-"`
+
+	// Params declares inputs collected in a form before the action runs
+	Params []ActionParam `json:"params,omitempty"`
+
+	// ParamHandler is used instead of Handler for actions with parameters
+	ParamHandler ActionParamHandler `json:"-"`
+
+	// Confirmation is the message shown before the action runs
+	Confirmation string `json:"confirmation,omitempty"`
+
+	// Level controls styling and how the action is confirmed
+	Level ActionLevel `json:"level,omitempty"`
+
+	// Icon is a built-in icon name (e.g. "trash", "mail") or a short text such as an emoji
+	Icon string `json:"icon,omitempty"`
+
+	// StepUp requires re-entering the password or a TOTP code before the action runs
+	StepUp auth.StepUpMethod `json:"step_up,omitempty"`
+}
+
+// ConfirmationMessage returns the message shown before the action runs
+func (a *CustomAction) ConfirmationMessage() string {
+	if a.Confirmation != "" {
+		return a.Confirmation
+	}
+	return "Are you sure you want to perform this action: " + a.Title + "?"
+}
+
+// IsDangerous reports whether the action requires a typed confirmation
+func (a *CustomAction) IsDangerous() bool {
+	return a.Level == ActionLevelDanger
+}
+
+// NeedsForm reports whether the action must be confirmed through a form rather than a dialog
+func (a *CustomAction) NeedsForm() bool {
+	return a.HasParams() || a.IsDangerous() || a.StepUp != auth.StepUpNone
 }
 
 // ActionBuilder provides a fluent API for configuring custom actions
@@ -32,7 +81,66 @@ func NewAction(id, title string, handler func(ctx context.Context, id any) error
 	}
 }
 
+// WithParams declares parameters that are collected before the action runs
+// The handler receives the parsed and validated values
+func (ab *ActionBuilder) WithParams(handler ActionParamHandler, params ...ActionParam) *ActionBuilder {
+	ab.action.Params = append(ab.action.Params, params...)
+	ab.action.ParamHandler = handler
+	return ab
+}
+
+// WithConfirmation sets the message shown before the action runs
+func (ab *ActionBuilder) WithConfirmation(message string) *ActionBuilder {
+	ab.action.Confirmation = message
+	return ab
+}
+
+// WithLevel sets how risky the action is
+func (ab *ActionBuilder) WithLevel(level ActionLevel) *ActionBuilder {
+	ab.action.Level = level
+	return ab
+}
+
+// WithIcon sets the icon shown next to the action title
+func (ab *ActionBuilder) WithIcon(icon string) *ActionBuilder {
+	ab.action.Icon = icon
+	return ab
+}
+
 // Build returns the built custom action
 func (ab *ActionBuilder) Build() CustomAction {
 	return *ab.action
 }
+
+// CollectionActionHandler executes an action over a resource rather than a single record
+// The query carries the list filters (and scope) active when the action was triggered
+type CollectionActionHandler func(ctx context.Context, query *Query) error
+
+// CollectionAction represents an action that is not bound to a single record,
+// e.g. "Sync from Stripe", rendered on the list page header
+type CollectionAction struct {
+	ID           string                  `json:"id"`
+	Title        string                  `json:"title"`
+	Handler      CollectionActionHandler `json:"-"`
+	Confirmation string                  `json:"confirmation,omitempty"`
+	Level        ActionLevel             `json:"level,omitempty"`
+	Icon         string                  `json:"icon,omitempty"`
+}
+
+// ConfirmationMessage returns the message shown before the action runs
+func (a *CollectionAction) ConfirmationMessage() string {
+	if a.Confirmation != "" {
+		return a.Confirmation
+	}
+	return "Are you sure you want to perform this action: " + a.Title + "?"
+}
+
+// GetCollectionAction returns the collection action with the given ID
+func (r *Resource) GetCollectionAction(id string) (*CollectionAction, bool) {
+	for i := range r.CollectionActions {
+		if r.CollectionActions[i].ID == id {
+			return &r.CollectionActions[i], true
+		}
+	}
+	return nil, false
+}
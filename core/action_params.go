@@ -0,0 +1,157 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ActionParamType determines how an action parameter is rendered and parsed
+type ActionParamType string
+
+const (
+	ParamString   ActionParamType = "string"   // Single-line text, parsed as string
+	ParamText     ActionParamType = "text"     // Multi-line text, parsed as string
+	ParamNumber   ActionParamType = "number"   // Decimal number, parsed as float64
+	ParamInt      ActionParamType = "int"      // Whole number, parsed as int64
+	ParamBool     ActionParamType = "bool"     // Checkbox, parsed as bool
+	ParamSelect   ActionParamType = "select"   // One of Options, parsed as string
+	ParamPassword ActionParamType = "password" // Masked single-line text, parsed as string and never echoed back
+)
+
+// ActionParam declares an input collected from the user before an action runs
+type ActionParam struct {
+	Name        string                `json:"name"`
+	Label       string                `json:"label"`
+	Type        ActionParamType       `json:"type"`
+	Required    bool                  `json:"required"`
+	Options     []string              `json:"options,omitempty"` // Choices for ParamSelect
+	Placeholder string                `json:"placeholder,omitempty"`
+	HelpText    string                `json:"help_text,omitempty"`
+	Validate    func(value any) error `json:"-"` // Optional extra validation of the parsed value
+}
+
+// ActionParams holds parsed parameter values keyed by parameter name
+type ActionParams map[string]any
+
+// ActionParamHandler executes an action with the parameters collected from the user
+type ActionParamHandler func(ctx context.Context, id any, params ActionParams) error
+
+// String returns a string parameter, or "" if it is missing
+func (p ActionParams) String(name string) string {
+	value, _ := p[name].(string)
+	return value
+}
+
+// Float returns a number parameter, or 0 if it is missing
+func (p ActionParams) Float(name string) float64 {
+	value, _ := p[name].(float64)
+	return value
+}
+
+// Int returns an int parameter, or 0 if it is missing
+func (p ActionParams) Int(name string) int64 {
+	value, _ := p[name].(int64)
+	return value
+}
+
+// Bool returns a bool parameter, or false if it is missing
+func (p ActionParams) Bool(name string) bool {
+	value, _ := p[name].(bool)
+	return value
+}
+
+// ActionParamErrors maps parameter names to validation messages
+type ActionParamErrors map[string]string
+
+// Error implements the error interface
+func (e ActionParamErrors) Error() string {
+	names := make([]string, 0, len(e))
+	for name := range e {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	messages := make([]string, 0, len(names))
+	for _, name := range names {
+		messages = append(messages, fmt.Sprintf("%s: %s", name, e[name]))
+	}
+	return strings.Join(messages, "; ")
+}
+
+// ParseActionParams parses and validates submitted values for the declared parameters
+// get returns the raw submitted value for a parameter name, e.g. http.Request.FormValue
+// On failure the returned error is an ActionParamErrors
+func ParseActionParams(params []ActionParam, get func(name string) string) (ActionParams, error) {
+	parsed := make(ActionParams, len(params))
+	errs := make(ActionParamErrors)
+
+	for _, param := range params {
+		raw := get(param.Name)
+		if param.Type != ParamPassword {
+			raw = strings.TrimSpace(raw)
+		}
+
+		if param.Type == ParamBool {
+			parsed[param.Name] = raw == "true" || raw == "on" || raw == "1"
+			if param.Required && !parsed.Bool(param.Name) {
+				errs[param.Name] = "must be checked"
+			}
+			continue
+		}
+
+		if raw == "" {
+			if param.Required {
+				errs[param.Name] = "is required"
+			}
+			continue
+		}
+
+		var value any
+		switch param.Type {
+		case ParamNumber:
+			f, err := strconv.ParseFloat(raw, 64)
+			if err != nil {
+				errs[param.Name] = "must be a number"
+				continue
+			}
+			value = f
+		case ParamInt:
+			i, err := strconv.ParseInt(raw, 10, 64)
+			if err != nil {
+				errs[param.Name] = "must be a whole number"
+				continue
+			}
+			value = i
+		case ParamSelect:
+			if !slices.Contains(param.Options, raw) {
+				errs[param.Name] = "is not a valid choice"
+				continue
+			}
+			value = raw
+		default:
+			value = raw
+		}
+
+		if param.Validate != nil {
+			if err := param.Validate(value); err != nil {
+				errs[param.Name] = err.Error()
+				continue
+			}
+		}
+		parsed[param.Name] = value
+	}
+
+	if len(errs) > 0 {
+		return nil, errs
+	}
+	return parsed, nil
+}
+
+// HasParams reports whether the action asks for parameters before running
+func (a *CustomAction) HasParams() bool {
+	return len(a.Params) > 0
+}
@@ -0,0 +1,83 @@
+package core
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseActionParams(t *testing.T) {
+	params := []ActionParam{
+		{Name: "amount", Type: ParamNumber, Required: true},
+		{Name: "quantity", Type: ParamInt},
+		{Name: "reason", Type: ParamSelect, Options: []string{"damaged", "late"}},
+		{Name: "notify", Type: ParamBool},
+		{Name: "note", Type: ParamText},
+	}
+
+	values := map[string]string{
+		"amount":   "12.50",
+		"quantity": "3",
+		"reason":   "late",
+		"notify":   "true",
+	}
+	parsed, err := ParseActionParams(params, func(name string) string { return values[name] })
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if parsed.Float("amount") != 12.5 {
+		t.Errorf("Expected amount 12.5, got %v", parsed["amount"])
+	}
+	if parsed.Int("quantity") != 3 {
+		t.Errorf("Expected quantity 3, got %v", parsed["quantity"])
+	}
+	if parsed.String("reason") != "late" || !parsed.Bool("notify") {
+		t.Errorf("Unexpected parsed values: %v", parsed)
+	}
+	if _, ok := parsed["note"]; ok {
+		t.Error("Expected empty optional parameter to be omitted")
+	}
+}
+
+func TestParseActionParams_Validation(t *testing.T) {
+	params := []ActionParam{
+		{Name: "amount", Type: ParamNumber, Required: true, Validate: func(value any) error {
+			if value.(float64) <= 0 {
+				return errors.New("must be positive")
+			}
+			return nil
+		}},
+		{Name: "quantity", Type: ParamInt},
+		{Name: "reason", Type: ParamSelect, Options: []string{"damaged"}},
+		{Name: "confirm", Type: ParamBool, Required: true},
+	}
+
+	tests := []struct {
+		name     string
+		values   map[string]string
+		expected map[string]string
+	}{
+		{"missing required", map[string]string{}, map[string]string{"amount": "is required", "confirm": "must be checked"}},
+		{"bad types", map[string]string{"amount": "abc", "quantity": "1.5", "reason": "other", "confirm": "on"},
+			map[string]string{"amount": "must be a number", "quantity": "must be a whole number", "reason": "is not a valid choice"}},
+		{"custom validation", map[string]string{"amount": "-1", "confirm": "true"}, map[string]string{"amount": "must be positive"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseActionParams(params, func(name string) string { return tt.values[name] })
+			errs, ok := err.(ActionParamErrors)
+			if !ok {
+				t.Fatalf("Expected ActionParamErrors, got %v", err)
+			}
+			if len(errs) != len(tt.expected) {
+				t.Errorf("Expected %d errors, got %v", len(tt.expected), errs)
+			}
+			for name, msg := range tt.expected {
+				if errs[name] != msg {
+					t.Errorf("Expected %s error %q, got %q", name, msg, errs[name])
+				}
+			}
+		})
+	}
+}
@@ -153,3 +153,31 @@ func TestActionHandler_DifferentIDTypes(t *testing.T) {
 		})
 	}
 }
+
+// TestActionBuilder_Metadata verifies confirmation, level and icon metadata
+func TestActionBuilder_Metadata(t *testing.T) {
+	handler := func(ctx context.Context, id any) error { return nil }
+
+	plain := NewAction("sync", "Sync", handler).Build()
+	if plain.ConfirmationMessage() != "Are you sure you want to perform this action: Sync?" {
+		t.Errorf("Unexpected default confirmation: %q", plain.ConfirmationMessage())
+	}
+	if plain.NeedsForm() {
+		t.Error("Expected plain action to use a confirmation dialog")
+	}
+
+	danger := NewAction("purge", "Purge", handler).
+		WithConfirmation("This removes all data.").
+		WithLevel(ActionLevelDanger).
+		WithIcon("trash").
+		Build()
+	if danger.ConfirmationMessage() != "This removes all data." {
+		t.Errorf("Expected custom confirmation, got %q", danger.ConfirmationMessage())
+	}
+	if !danger.IsDangerous() || !danger.NeedsForm() {
+		t.Error("Expected danger action to require a typed confirmation form")
+	}
+	if danger.Icon != "trash" {
+		t.Errorf("Expected icon 'trash', got %q", danger.Icon)
+	}
+}
@@ -21,6 +21,35 @@ type Adapter interface {
 	Search(ctx context.Context, resource *Resource, query string) ([]any, error)
 }
 
+// SoftDeleter is implemented by adapters that support soft deletion
+// For resources with a soft delete field, Find must exclude trashed records unless Query.Trashed is set
+type SoftDeleter interface {
+	SoftDelete(ctx context.Context, resource *Resource, id any) error
+	Restore(ctx context.Context, resource *Resource, id any) error
+}
+
+// Streamer is implemented by adapters that can iterate over every record matching a query
+// without loading them all at once, e.g. to export large result sets
+// Stream ignores the query's pagination and stops at the first error returned by fn
+type Streamer interface {
+	Stream(ctx context.Context, resource *Resource, query *Query, fn func(item any) error) error
+}
+
+// FieldUpdater is implemented by adapters that can write chosen fields of a record, zero values included
+// Update may skip zero values, so clearing a field or switching a boolean off goes through UpdateFields
+type FieldUpdater interface {
+	UpdateFields(ctx context.Context, resource *Resource, id any, data any, fields []string) error
+}
+
+// UpdateFields writes the named fields of data to the record, zero values included, falling back to
+// Update for adapters that don't implement FieldUpdater
+func UpdateFields(ctx context.Context, adapter Adapter, resource *Resource, id any, data any, fields ...string) error {
+	if updater, ok := adapter.(FieldUpdater); ok {
+		return updater.UpdateFields(ctx, resource, id, data, fields)
+	}
+	return adapter.Update(ctx, resource, id, data)
+}
+
 // Schema represents the structure of a resource
 type Schema struct {
 	Fields     []FieldInfo    `json:"fields"`
@@ -5,7 +5,11 @@ import (
 	"fmt"
 	"net/http"
 	"reflect"
+	"sort"
 	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/preslavrachev/backoffice/middleware/auth"
 
@@ -14,10 +18,21 @@ import (
 
 // BackOffice represents the main admin instance
 type BackOffice struct {
-	adapter       Adapter
-	resources     map[string]*Resource
-	resourceOrder []string // Track registration order for consistent display
-	config        *Config
+	adapter         Adapter
+	resources       map[string]*Resource
+	resourceOrder   []string // Track registration order for consistent display
+	displayOrder    []string // Explicit display order set via SetResourceOrder
+	views           ViewStore
+	dashboard       *Dashboard
+	links           []*NavLink    // Extra navigation links in the order they were added
+	pages           []*CustomPage // Custom pages in registration order
+	policy          Policy        // Role-based access checks on top of resource Permissions, nil when unset
+	unmaskAudit     UnmaskAuditor // Records revealed masked values, the standard logger when nil
+	fileURLs        fileURLSigning
+	fileURLOnce     sync.Once                      // Generates a file URL key when none was set
+	permissionCache *ComputeCache                  // Permission checks kept across requests, nil unless SetPermissionCache was called
+	readOnly        atomic.Pointer[ReadOnlyConfig] // Set by SetReadOnly while requests may be running
+	config          *Config
 }
 
 // Config holds configuration for the BackOffice instance
@@ -25,9 +40,15 @@ type Config struct {
 	BasePath     string                            `json:"base_path"`
 	Title        string                            `json:"title"`
 	ItemsPerPage int                               `json:"items_per_page"`
+	Locale       string                            `json:"locale"` // Selects the Inflector for resource names and the fallback UI language, defaults to DefaultLocale
 	Resources    map[string]*ResourceConfig        `json:"resources"`
 	Middleware   []func(http.Handler) http.Handler `json:"-"`
 	Auth         *auth.AuthConfig                  `json:"-"`
+	Theme        Theme                             `json:"theme"`
+	Toasts       ToastConfig                       `json:"toasts"` // Position, duration and stacking of toast notifications
+	TimeZone     *time.Location                    `json:"-"`      // Zone time fields are edited in, defaults to the server's local zone
+	ReadOnly     ReadOnlyConfig                    `json:"read_only"`
+	CSP          CSPConfig                         `json:"csp"` // Content-Security-Policy sent with the admin pages
 }
 
 // ResourceConfig holds configuration for individual resources
@@ -39,8 +60,9 @@ type ResourceConfig struct {
 }
 
 // New creates a new BackOffice instance with the given adapter and auth configuration
+// A user store implementing UserResourceProvider gets its user management resource registered
 func New(adapter Adapter, authConfig auth.AuthConfig) *BackOffice {
-	return &BackOffice{
+	bo := &BackOffice{
 		adapter:       adapter,
 		resources:     make(map[string]*Resource),
 		resourceOrder: make([]string, 0),
@@ -53,28 +75,45 @@ func New(adapter Adapter, authConfig auth.AuthConfig) *BackOffice {
 			Auth:         &authConfig,
 		},
 	}
+
+	if provider, ok := authConfig.Users.(UserResourceProvider); ok {
+		provider.RegisterUserResource(bo)
+	}
+	return bo
+}
+
+// UserResourceProvider is a user store that can manage its users from within the admin
+type UserResourceProvider interface {
+	// RegisterUserResource registers the resource for creating users, resetting passwords and assigning roles
+	RegisterUserResource(bo *BackOffice)
 }
 
 // RegisterResource registers a new resource with the admin panel
+// It panics when the model isn't a pointer to a struct; use TryRegisterResource to get an error instead
 func (bo *BackOffice) RegisterResource(model any) *ResourceBuilder {
-	modelType := reflect.TypeOf(model)
-	if modelType.Kind() != reflect.Ptr {
-		panic("RegisterResource expects a pointer to a struct")
+	builder, err := bo.TryRegisterResource(model)
+	if err != nil {
+		panic(err.Error())
 	}
+	return builder
+}
 
-	elemType := modelType.Elem()
-	if elemType.Kind() != reflect.Struct {
-		panic("RegisterResource expects a pointer to a struct")
+// TryRegisterResource registers a new resource, returning an error instead of panicking on an invalid model
+// Field configuration problems are reported by Validate once the resource is fully configured
+func (bo *BackOffice) TryRegisterResource(model any) (*ResourceBuilder, error) {
+	modelType := reflect.TypeOf(model)
+	if modelType == nil || modelType.Kind() != reflect.Ptr || modelType.Elem().Kind() != reflect.Struct {
+		return nil, fmt.Errorf("RegisterResource expects a pointer to a struct, got %T", model)
 	}
 
 	// Generate resource name from type
-	resourceName := elemType.Name()
+	resourceName := modelType.Elem().Name()
 
 	// Create resource
 	resource := &Resource{
 		Name:         resourceName,
-		DisplayName:  generateDisplayName(resourceName),
-		PluralName:   generatePluralName(resourceName),
+		DisplayName:  generateDisplayName(bo.config.Locale, resourceName),
+		PluralName:   generatePluralName(bo.config.Locale, resourceName),
 		Model:        model,
 		ModelType:    modelType,
 		TableName:    generateTableName(resourceName),
@@ -83,11 +122,12 @@ func (bo *BackOffice) RegisterResource(model any) *ResourceBuilder {
 		FieldConfigs: make(map[string]*FieldConfig),
 		FieldOrder:   []string{},       // Initialize empty order slice
 		Actions:      []CustomAction{}, // Initialize empty actions slice
+		backoffice:   bo,
 	}
 
 	// Discover fields using reflection
 	if err := resource.DiscoverFields(); err != nil {
-		panic(fmt.Sprintf("Failed to discover fields for %s: %v", resourceName, err))
+		return nil, fmt.Errorf("failed to discover fields for %s: %w", resourceName, err)
 	}
 
 	// Store resource
@@ -100,7 +140,7 @@ func (bo *BackOffice) RegisterResource(model any) *ResourceBuilder {
 	return &ResourceBuilder{
 		backoffice: bo,
 		resource:   resource,
-	}
+	}, nil
 }
 
 // GetResource retrieves a registered resource by name
@@ -109,10 +149,24 @@ func (bo *BackOffice) GetResource(name string) (*Resource, bool) {
 	return resource, exists
 }
 
-// GetResources returns all registered resources in registration order
-// TODO: Add method to customize resource display order
-// Future: admin.SetResourceOrder([]string{"User", "Product", "Category"})
-// or admin.RegisterResource(&User{}).WithDisplayOrder(1)
+// SetResourceOrder sets the order in which resources are displayed on the index and navigation
+// Listed resources come first in the given order and take precedence over WithDisplayOrder;
+// unlisted resources follow in their usual order
+func (bo *BackOffice) SetResourceOrder(names ...string) *BackOffice {
+	bo.displayOrder = names
+	return bo
+}
+
+// SetLocale selects the Inflector used to name resources registered afterwards
+// and the UI language of requests whose Accept-Language matches no registered messages
+func (bo *BackOffice) SetLocale(locale string) *BackOffice {
+	bo.config.Locale = locale
+	return bo
+}
+
+// GetResources returns all registered resources in display order
+// Resources named in SetResourceOrder come first, then those with a WithDisplayOrder position,
+// then the rest in registration order
 func (bo *BackOffice) GetResources() []*Resource {
 	ordered := make([]*Resource, 0, len(bo.resourceOrder))
 	for _, name := range bo.resourceOrder {
@@ -120,6 +174,32 @@ func (bo *BackOffice) GetResources() []*Resource {
 			ordered = append(ordered, resource)
 		}
 	}
+
+	explicit := make(map[string]int, len(bo.displayOrder))
+	for i, name := range bo.displayOrder {
+		if _, exists := explicit[name]; !exists {
+			explicit[name] = i
+		}
+	}
+
+	// rank sorts explicitly ordered resources first, then display positions, then the rest
+	rank := func(resource *Resource) (int, int) {
+		if i, exists := explicit[resource.Name]; exists {
+			return 0, i
+		}
+		if resource.DisplayOrder != 0 {
+			return 1, resource.DisplayOrder
+		}
+		return 2, 0
+	}
+	sort.SliceStable(ordered, func(i, j int) bool {
+		tierI, posI := rank(ordered[i])
+		tierJ, posJ := rank(ordered[j])
+		if tierI != tierJ {
+			return tierI < tierJ
+		}
+		return posI < posJ
+	})
 	return ordered
 }
 
@@ -128,11 +208,37 @@ func (bo *BackOffice) GetConfig() *Config {
 	return bo.config
 }
 
+// Location returns the time zone time fields are edited in
+func (bo *BackOffice) Location() *time.Location {
+	if bo.config.TimeZone != nil {
+		return bo.config.TimeZone
+	}
+	return time.Local
+}
+
 // GetAdapter returns the adapter
 func (bo *BackOffice) GetAdapter() Adapter {
 	return bo.adapter
 }
 
+// SetViewStore sets where saved list views are kept, overriding the adapter
+func (bo *BackOffice) SetViewStore(store ViewStore) *BackOffice {
+	bo.views = store
+	return bo
+}
+
+// ViewStore returns the store for saved list views
+// It falls back to the adapter when it implements ViewStore, and is nil when views are unavailable
+func (bo *BackOffice) ViewStore() ViewStore {
+	if bo.views != nil {
+		return bo.views
+	}
+	if store, ok := bo.adapter.(ViewStore); ok {
+		return store
+	}
+	return nil
+}
+
 // GetAuth returns the authentication configuration
 func (bo *BackOffice) GetAuth() *auth.AuthConfig {
 	return bo.config.Auth
@@ -157,13 +263,11 @@ func (rb *ResourceBuilder) WithPluralName(name string) *ResourceBuilder {
 }
 
 // WithField configures a specific field
+// Configuring an already registered field replaces its configuration but keeps its position
 func (rb *ResourceBuilder) WithField(fieldName string, config func(*FieldBuilder)) *ResourceBuilder {
 	builder := NewFieldBuilder()
 	config(builder)
-	rb.resource.FieldConfigs[fieldName] = builder.Build()
-
-	// Track field registration order
-	rb.resource.FieldOrder = append(rb.resource.FieldOrder, fieldName)
+	rb.registerField(fieldName, builder.Build())
 
 	// Re-discover fields to apply the configuration
 	rb.resource.DiscoverFields()
@@ -171,6 +275,56 @@ func (rb *ResourceBuilder) WithField(fieldName string, config func(*FieldBuilder
 	return rb
 }
 
+// WithFields registers struct fields with default configuration, in the given order
+// Use WithField afterwards to override the configuration of individual fields
+func (rb *ResourceBuilder) WithFields(fieldNames ...string) *ResourceBuilder {
+	for _, fieldName := range fieldNames {
+		if _, configured := rb.resource.FieldConfigs[fieldName]; !configured {
+			rb.registerField(fieldName, NewFieldBuilder().Build())
+		}
+	}
+
+	rb.resource.DiscoverFields()
+
+	return rb
+}
+
+// AutoFields registers every exported struct field not yet configured, in declaration order
+// Fields tagged db:"-" and the excluded field names are skipped
+func (rb *ResourceBuilder) AutoFields(exclude ...string) *ResourceBuilder {
+	t := rb.resource.ModelType
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	excluded := make(map[string]bool, len(exclude))
+	for _, name := range exclude {
+		excluded[name] = true
+	}
+
+	var fieldNames []string
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() || field.Anonymous || excluded[field.Name] || field.Tag.Get("db") == "-" {
+			continue
+		}
+		if field.Name == rb.resource.PrimaryKey {
+			continue // Always discovered
+		}
+		fieldNames = append(fieldNames, field.Name)
+	}
+
+	return rb.WithFields(fieldNames...)
+}
+
+// registerField stores a field configuration, tracking registration order on first use
+func (rb *ResourceBuilder) registerField(fieldName string, config *FieldConfig) {
+	if _, exists := rb.resource.FieldConfigs[fieldName]; !exists {
+		rb.resource.FieldOrder = append(rb.resource.FieldOrder, fieldName)
+	}
+	rb.resource.FieldConfigs[fieldName] = config
+}
+
 // WithDerivedField adds a derived field that calculates its value dynamically from fetched data
 // Supports optional field configuration functions for sorting, display settings, etc.
 func (rb *ResourceBuilder) WithDerivedField(fieldName, displayName string, computeFunc ComputeFunc, configFuncs ...func(*FieldBuilder)) *ResourceBuilder {
@@ -195,6 +349,80 @@ func (rb *ResourceBuilder) WithDerivedField(fieldName, displayName string, compu
 	return rb
 }
 
+// WithDerivedFieldCtx adds a derived field whose compute function receives the request context
+// Use it to respect deadlines, read the authenticated user or batch lookups through a request-scoped loader
+func (rb *ResourceBuilder) WithDerivedFieldCtx(fieldName, displayName string, computeFunc ComputeFuncCtx, configFuncs ...func(*FieldBuilder)) *ResourceBuilder {
+	builder := NewFieldBuilder()
+	builder.DisplayName(displayName).ReadOnly(true) // Derived fields are read-only by default
+	builder.config.IsComputed = true
+	builder.config.ComputeFuncCtx = computeFunc
+
+	// Apply optional configurations
+	for _, configFunc := range configFuncs {
+		configFunc(builder)
+	}
+
+	rb.resource.FieldConfigs[fieldName] = builder.Build()
+
+	// Track field registration order
+	rb.resource.FieldOrder = append(rb.resource.FieldOrder, fieldName)
+
+	// Re-discover fields to apply the configuration
+	rb.resource.DiscoverFields()
+
+	return rb
+}
+
+// WithStatusField adds a read-only status field rendered as a colored pill in lists and detail pages
+// The label doubles as the field's text value, e.g. in exports
+func (rb *ResourceBuilder) WithStatusField(fieldName string, statusFunc StatusFunc, configFuncs ...func(*FieldBuilder)) *ResourceBuilder {
+	builder := NewFieldBuilder()
+	builder.ReadOnly(true)
+	builder.config.IsComputed = true
+	builder.config.StatusFunc = statusFunc
+	builder.config.ComputeFunc = func(item any) string {
+		label, _ := statusFunc(item)
+		return label
+	}
+
+	// Apply optional configurations
+	for _, configFunc := range configFuncs {
+		configFunc(builder)
+	}
+
+	rb.registerField(fieldName, builder.Build())
+
+	// Re-discover fields to apply the configuration
+	rb.resource.DiscoverFields()
+
+	return rb
+}
+
+// WithTypedDerivedField adds a derived field whose compute function returns a value of the declared kind
+// Unlike string-only derived fields, typed values are formatted per kind and can be sorted within a page
+func (rb *ResourceBuilder) WithTypedDerivedField(fieldName, displayName string, kind ComputedKind, computeFunc TypedComputeFunc, configFuncs ...func(*FieldBuilder)) *ResourceBuilder {
+	builder := NewFieldBuilder()
+	builder.DisplayName(displayName).ReadOnly(true) // Derived fields are read-only by default
+	builder.config.IsComputed = true
+	builder.config.ComputedKind = kind
+	builder.config.TypedComputeFunc = computeFunc
+
+	// Apply optional configurations
+	for _, configFunc := range configFuncs {
+		configFunc(builder)
+	}
+
+	rb.resource.FieldConfigs[fieldName] = builder.Build()
+
+	// Track field registration order
+	rb.resource.FieldOrder = append(rb.resource.FieldOrder, fieldName)
+
+	// Re-discover fields to apply the configuration
+	rb.resource.DiscoverFields()
+
+	return rb
+}
+
 // Hidden sets whether the resource should be hidden from the admin panel
 func (rb *ResourceBuilder) Hidden(hidden bool) *ResourceBuilder {
 	rb.resource.Hidden = hidden
@@ -207,16 +435,167 @@ func (rb *ResourceBuilder) ReadOnly(readOnly bool) *ResourceBuilder {
 	return rb
 }
 
-// WithDefaultSort sets the default sorting for the resource
-func (rb *ResourceBuilder) WithDefaultSort(field string, direction SortDirection) *ResourceBuilder {
-	rb.resource.DefaultSort = SortField{
-		Field:      field,
-		Direction:  direction,
-		Precedence: SortPrecedenceExplicit,
+// WithPermissions sets the per-operation permission checks for the resource
+func (rb *ResourceBuilder) WithPermissions(permissions Permissions) *ResourceBuilder {
+	rb.resource.Permissions = permissions
+	return rb
+}
+
+// WithScope restricts which records of the resource the current request can see and modify
+func (rb *ResourceBuilder) WithScope(scope ScopeFunc) *ResourceBuilder {
+	rb.resource.Scope = scope
+	return rb
+}
+
+// WithBaseScope restricts the resource to the records the scope selects for every request,
+// e.g. always excluding internal rows. Unlike WithScope it doesn't depend on the current user
+func (rb *ResourceBuilder) WithBaseScope(scope BaseScopeFunc) *ResourceBuilder {
+	rb.resource.BaseScope = scope
+	return rb
+}
+
+// WithHistory keeps a snapshot of each record before it is updated, enabling rollback
+func (rb *ResourceBuilder) WithHistory(store HistoryStore) *ResourceBuilder {
+	rb.resource.History = store
+	return rb
+}
+
+// WithDetailTabs organizes the detail page into tabs, the first one shown initially
+// e.g. WithDetailTabs(core.TabOverview, core.TabRelated, core.TabHistory, core.TabJSON)
+func (rb *ResourceBuilder) WithDetailTabs(tabs ...DetailTab) *ResourceBuilder {
+	rb.resource.DetailTabs = tabs
+	return rb
+}
+
+// WithDetailPanel adds a custom section beside the fields of the detail page, inside the standard layout
+// e.g. a live billing summary next to a User record; panels show in the order they are added
+func (rb *ResourceBuilder) WithDetailPanel(panel DetailPanelFunc) *ResourceBuilder {
+	rb.resource.DetailPanels = append(rb.resource.DetailPanels, panel)
+	return rb
+}
+
+// WithIDCodec sets how the resource's IDs are parsed from and formatted into URLs
+func (rb *ResourceBuilder) WithIDCodec(codec IDCodec) *ResourceBuilder {
+	rb.resource.IDCodec = codec
+	return rb
+}
+
+// WithInline shows the child resource as an editable table on this resource's detail page
+// foreignKey is the child field referencing this resource; fields limits the columns shown
+func (rb *ResourceBuilder) WithInline(childResource, foreignKey string, fields ...string) *ResourceBuilder {
+	rb.resource.Inlines = append(rb.resource.Inlines, InlineConfig{
+		Resource:   childResource,
+		ForeignKey: foreignKey,
+		Fields:     fields,
+	})
+	return rb
+}
+
+// WithTableName overrides the table the resource is stored in, derived from its name by default
+func (rb *ResourceBuilder) WithTableName(name string) *ResourceBuilder {
+	rb.resource.TableName = name
+	return rb
+}
+
+// WithDisplayOrder sets the position of the resource on the index and navigation
+// Lower values are shown first; resources without a position follow in registration order
+func (rb *ResourceBuilder) WithDisplayOrder(position int) *ResourceBuilder {
+	rb.resource.DisplayOrder = position
+	return rb
+}
+
+// WithGroup places the resource under a navigation heading, e.g. "Commerce"
+func (rb *ResourceBuilder) WithGroup(name string) *ResourceBuilder {
+	rb.resource.Group = name
+	return rb
+}
+
+// WithSoftDelete moves deleted records to a trash instead of removing them
+// The field must be nullable (e.g. *time.Time or sql.NullTime) and is set to the deletion time
+func (rb *ResourceBuilder) WithSoftDelete(fieldName string) *ResourceBuilder {
+	rb.resource.SoftDeleteField = fieldName
+	return rb
+}
+
+// WithPageSize sets the number of records shown per list page (capped at MaxPageSize)
+func (rb *ResourceBuilder) WithPageSize(size int) *ResourceBuilder {
+	rb.resource.PageSize = size
+	return rb
+}
+
+// WithPaginationStyle selects how list pages move past the first page, e.g. core.PaginationNumbered
+func (rb *ResourceBuilder) WithPaginationStyle(style PaginationStyle) *ResourceBuilder {
+	rb.resource.PaginationStyle = style
+	return rb
+}
+
+// WithFrozenFirstColumn keeps the first list column, usually the record's identifier, visible
+// while scrolling wide lists horizontally
+func (rb *ResourceBuilder) WithFrozenFirstColumn() *ResourceBuilder {
+	rb.resource.FreezeFirstColumn = true
+	return rb
+}
+
+// WithRowClass tints list rows by their record, e.g. red for expired trials or yellow for pending review
+// The returned classes are added to the row, so Tailwind background classes like "bg-red-50" work best
+func (rb *ResourceBuilder) WithRowClass(fn RowClassFunc) *ResourceBuilder {
+	rb.resource.RowClassFunc = fn
+	return rb
+}
+
+// WithEmptyState replaces the list of a resource without records with a message and a call to action
+// e.g. WithEmptyState(core.EmptyState{Title: "No products yet", ActionLabel: "Import products", ActionURL: "/imports"})
+func (rb *ResourceBuilder) WithEmptyState(state EmptyState) *ResourceBuilder {
+	rb.resource.EmptyState = &state
+	return rb
+}
+
+// WithOptimisticLock sets the version field compared to reject edits of records changed by someone else
+// Integer fields are incremented and timestamps refreshed on each in-place edit; defaults to DefaultLockField
+func (rb *ResourceBuilder) WithOptimisticLock(fieldName string) *ResourceBuilder {
+	rb.resource.LockField = fieldName
+	return rb
+}
+
+// WithOrderField lets users reorder records by dragging list rows, storing the order in an integer field
+// Lists are sorted by the field unless another default sort is set; rows can be dragged while sorted by it
+func (rb *ResourceBuilder) WithOrderField(fieldName string) *ResourceBuilder {
+	rb.resource.OrderField = fieldName
+	if rb.resource.DefaultSort.Precedence != SortPrecedenceExplicit {
+		rb.WithDefaultSort(Sort(fieldName, SortAsc))
 	}
 	return rb
 }
 
+// WithChart shows a chart of the aggregated records above the list
+// e.g. WithChart("Signups per week", core.ChartLine, core.AggregateQuery{GroupBy: "CreatedAt", Bucket: core.BucketWeek})
+func (rb *ResourceBuilder) WithChart(title string, chart ChartType, aggregate AggregateQuery) *ResourceBuilder {
+	rb.resource.Charts = append(rb.resource.Charts, ChartWidget(title, rb.resource.Name, chart, aggregate))
+	return rb
+}
+
+// WithStat shows a KPI card above the list, reloaded every StatRefreshInterval
+// e.g. WithStat("Active trials", func(ctx context.Context, adapter core.Adapter) (string, error) { ... })
+func (rb *ResourceBuilder) WithStat(title string, value StatFunc) *ResourceBuilder {
+	rb.resource.Stats = append(rb.resource.Stats, Stat{Title: title, Value: value})
+	return rb
+}
+
+// WithDefaultSort sets the default sorting for the resource, applied in the given order
+// e.g. WithDefaultSort(core.Sort("Status", core.SortAsc), core.Sort("CreatedAt", core.SortDesc))
+func (rb *ResourceBuilder) WithDefaultSort(sorts ...SortField) *ResourceBuilder {
+	if len(sorts) == 0 {
+		return rb
+	}
+	rb.resource.DefaultSorts = make([]SortField, len(sorts))
+	for i, sort := range sorts {
+		sort.Precedence = SortPrecedenceExplicit
+		rb.resource.DefaultSorts[i] = sort
+	}
+	rb.resource.DefaultSort = rb.resource.DefaultSorts[0]
+	return rb
+}
+
 // WithAction registers a custom action for this resource
 func (rb *ResourceBuilder) WithAction(id, title string, handler func(ctx context.Context, id any) error) *ResourceBuilder {
 	action := CustomAction{
@@ -228,6 +607,82 @@ func (rb *ResourceBuilder) WithAction(id, title string, handler func(ctx context
 	return rb
 }
 
+// WithCollectionAction registers an action that runs over the resource as a whole,
+// rendered as a button on the list page and receiving the current list filters
+func (rb *ResourceBuilder) WithCollectionAction(id, title string, handler CollectionActionHandler, options ...func(*CollectionAction)) *ResourceBuilder {
+	action := CollectionAction{
+		ID:      id,
+		Title:   title,
+		Handler: handler,
+	}
+	for _, option := range options {
+		option(&action)
+	}
+	rb.resource.CollectionActions = append(rb.resource.CollectionActions, action)
+	return rb
+}
+
+// WithCustomAction registers an action built with NewAction
+func (rb *ResourceBuilder) WithCustomAction(action CustomAction) *ResourceBuilder {
+	rb.resource.Actions = append(rb.resource.Actions, action)
+	return rb
+}
+
+// WithParamAction registers a custom action that collects parameters in a form before running
+func (rb *ResourceBuilder) WithParamAction(id, title string, handler ActionParamHandler, params ...ActionParam) *ResourceBuilder {
+	action := CustomAction{
+		ID:           id,
+		Title:        title,
+		Params:       params,
+		ParamHandler: handler,
+	}
+	rb.resource.Actions = append(rb.resource.Actions, action)
+	return rb
+}
+
+// WithTree renders the resource as an expandable tree, nesting records under parentField
+// e.g. WithTree("ParentID") for categories pointing at their parent category
+func (rb *ResourceBuilder) WithTree(parentField string) *ResourceBuilder {
+	rb.resource.TreeParentField = parentField
+	return rb
+}
+
+// WithExport enables CSV/JSON exports of the resource, limited to the configured fields and formats
+func (rb *ResourceBuilder) WithExport(config ExportConfig) *ResourceBuilder {
+	rb.resource.Export = &config
+	return rb
+}
+
+// WithImport enables the CSV import wizard, mapping uploaded columns to the configured fields
+// With a KeyField, rows matching an existing record update it instead of creating a new one
+func (rb *ResourceBuilder) WithImport(config ImportConfig) *ResourceBuilder {
+	rb.resource.Import = &config
+	return rb
+}
+
+// WithOwnerField records the creating user's ID in the given field, e.g. WithOwnerField("CreatedByID")
+// The field becomes read-only in forms
+func (rb *ResourceBuilder) WithOwnerField(fieldName string) *ResourceBuilder {
+	rb.resource.OwnerField = fieldName
+	rb.resource.DiscoverFields()
+	return rb
+}
+
+// RestrictToOwner limits updates and deletes to the record's owner and users with any of the admin roles
+// Requires WithOwnerField
+func (rb *ResourceBuilder) RestrictToOwner(adminRoles ...string) *ResourceBuilder {
+	rb.resource.OwnerOnly = true
+	rb.resource.OwnerAdminRoles = adminRoles
+	return rb
+}
+
+// ValidateWith registers a validator that sees the whole record on create and update
+// Validators run in registration order and all failures are reported together
+func (rb *ResourceBuilder) ValidateWith(validator ValidatorFunc) *ResourceBuilder {
+	rb.resource.Validators = append(rb.resource.Validators, validator)
+	return rb
+}
+
 // WithManyToOneField configures a many-to-one relationship field
 func (rb *ResourceBuilder) WithManyToOneField(fieldName string, relatedModel string, options func(*RelationshipBuilder)) *ResourceBuilder {
 	relationshipBuilder := &RelationshipBuilder{
@@ -251,6 +706,57 @@ func (rb *ResourceBuilder) WithManyToOneField(fieldName string, relatedModel str
 	return rb
 }
 
+// WithManyToManyField configures a many-to-many relationship stored in a join table, set with JoinTable
+// The field is edited as a multi-select; adapters persist the links through LinkStore
+func (rb *ResourceBuilder) WithManyToManyField(fieldName string, relatedModel string, options func(*RelationshipBuilder)) *ResourceBuilder {
+	relationshipBuilder := &RelationshipBuilder{
+		info: &RelationshipInfo{
+			Type:           RelationshipManyToMany,
+			RelatedModel:   relatedModel,
+			DisplayField:   "Name",  // Default display field
+			DisplayPattern: "badge", // Default display pattern
+		},
+	}
+
+	if options != nil {
+		options(relationshipBuilder)
+	}
+
+	rb.WithField(fieldName, func(fb *FieldBuilder) {
+		fb.config.Relationship = relationshipBuilder.info
+	})
+
+	return rb
+}
+
+// WithOneToManyField configures a one-to-many relationship to the children whose foreign key holds this record's ID
+// Set the child's foreign key field with ForeignKey; the field doesn't have to exist on the parent struct
+func (rb *ResourceBuilder) WithOneToManyField(fieldName string, relatedModel string, options func(*RelationshipBuilder)) *ResourceBuilder {
+	relationshipBuilder := &RelationshipBuilder{
+		info: &RelationshipInfo{
+			Type:         RelationshipOneToMany,
+			RelatedModel: relatedModel,
+			LinkMode:     RelatedLinkList,
+		},
+	}
+
+	if options != nil {
+		options(relationshipBuilder)
+	}
+
+	// Fields missing from the struct are only counted, never loaded
+	structType := rb.resource.ModelType.Elem()
+	_, hasStructField := structType.FieldByName(fieldName)
+
+	rb.WithField(fieldName, func(fb *FieldBuilder) {
+		fb.ReadOnly(true)
+		fb.config.IsComputed = !hasStructField
+		fb.config.Relationship = relationshipBuilder.info
+	})
+
+	return rb
+}
+
 // RelationshipBuilder provides fluent API for configuring relationships
 type RelationshipBuilder struct {
 	info *RelationshipInfo
@@ -262,12 +768,39 @@ func (rb *RelationshipBuilder) DisplayField(fieldName string) *RelationshipBuild
 	return rb
 }
 
+// FallbackDisplayFields sets fields tried in order when the display field is missing or empty
+func (rb *RelationshipBuilder) FallbackDisplayFields(fieldNames ...string) *RelationshipBuilder {
+	rb.info.FallbackDisplayFields = fieldNames
+	return rb
+}
+
+// DisplayFunc formats the related record for display, e.g. combining first and last name
+// Display fields are still used when the func returns an empty string
+func (rb *RelationshipBuilder) DisplayFunc(fn RelationshipDisplayFunc) *RelationshipBuilder {
+	rb.info.DisplayFunc = fn
+	return rb
+}
+
 // ForeignKey sets the foreign key field name
 func (rb *RelationshipBuilder) ForeignKey(fkName string) *RelationshipBuilder {
 	rb.info.ForeignKey = fkName
 	return rb
 }
 
+// JoinTable sets the table linking a many-to-many relationship and its columns holding this record's and the related record's IDs
+func (rb *RelationshipBuilder) JoinTable(table, foreignKey, relatedKey string) *RelationshipBuilder {
+	rb.info.JoinTable = table
+	rb.info.JoinForeignKey = foreignKey
+	rb.info.JoinRelatedKey = relatedKey
+	return rb
+}
+
+// QuickLinks sets how a one-to-many relationship links to its children: a modal, the filtered child list or both
+func (rb *RelationshipBuilder) QuickLinks(mode RelatedLinkMode) *RelationshipBuilder {
+	rb.info.LinkMode = mode
+	return rb
+}
+
 // CompactDisplay sets the relationship to use compact display pattern
 func (rb *RelationshipBuilder) CompactDisplay() *RelationshipBuilder {
 	rb.info.DisplayPattern = "compact"
@@ -305,21 +838,13 @@ func (rb *RelationshipBuilder) SidebarDisplay() *RelationshipBuilder {
 }
 
 // Helper functions for generating names
-func generateDisplayName(name string) string {
-	// Convert CamelCase to "Display Name"
-	result := ""
-	for i, r := range name {
-		if i > 0 && r >= 'A' && r <= 'Z' {
-			result += " "
-		}
-		result += string(r)
-	}
-	return result
+func generateDisplayName(locale, name string) string {
+	return inflectorFor(locale).DisplayName(name)
 }
 
-func generatePluralName(name string) string {
-	displayName := generateDisplayName(name)
-	return pluralize(displayName)
+func generatePluralName(locale, name string) string {
+	inflector := inflectorFor(locale)
+	return inflector.Pluralize(inflector.DisplayName(name))
 }
 
 func generateTableName(name string) string {
@@ -328,7 +853,7 @@ func generateTableName(name string) string {
 	return pluralize(snake)
 }
 
-// Basic pluralization - can be enhanced later
+// Basic pluralization, also used for table names so registered irregulars don't rename tables
 func pluralize(word string) string {
 	if strings.HasSuffix(word, "y") {
 		return strings.TrimSuffix(word, "y") + "ies"
@@ -166,7 +166,7 @@ func TestResourceBuilder_WithAction_ChainedCalls(t *testing.T) {
 		WithName("Test Item").
 		WithAction("action1", "Action 1", handler).
 		WithAction("action2", "Action 2", handler).
-		WithDefaultSort("ID", SortAsc)
+		WithDefaultSort(Sort("ID", SortAsc))
 
 	// Verify we can still chain other methods after WithAction
 	if builder == nil {
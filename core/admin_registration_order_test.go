@@ -127,6 +127,34 @@ func TestResourceOrderWithHiddenResources(t *testing.T) {
 	}
 }
 
+// TestResourceDisplayOrder tests that WithDisplayOrder and SetResourceOrder override registration order
+func TestResourceDisplayOrder(t *testing.T) {
+	admin := New(&orderTestMockAdapter{}, auth.WithNoAuth())
+
+	admin.RegisterResource(&TestEntityA{})
+	admin.RegisterResource(&TestEntityB{}).WithDisplayOrder(2)
+	admin.RegisterResource(&TestEntityC{}).WithDisplayOrder(1)
+	admin.RegisterResource(&TestEntityD{})
+
+	assertOrder := func(expected []string) {
+		t.Helper()
+		resources := admin.GetResources()
+		if len(resources) != len(expected) {
+			t.Fatalf("Expected %d resources, got %d", len(expected), len(resources))
+		}
+		for i, name := range expected {
+			if resources[i].Name != name {
+				t.Errorf("Expected resource at position %d to be %s, got %s", i, name, resources[i].Name)
+			}
+		}
+	}
+
+	assertOrder([]string{"TestEntityC", "TestEntityB", "TestEntityA", "TestEntityD"})
+
+	admin.SetResourceOrder("TestEntityD", "Unknown", "TestEntityA")
+	assertOrder([]string{"TestEntityD", "TestEntityA", "TestEntityC", "TestEntityB"})
+}
+
 // Mock adapter for testing (minimal implementation)
 type orderTestMockAdapter struct{}
 
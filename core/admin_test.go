@@ -2,6 +2,7 @@ package core
 
 import (
 	"context"
+	"reflect"
 	"testing"
 
 	"github.com/preslavrachev/backoffice/middleware/auth"
@@ -122,3 +123,48 @@ func TestBackOffice_GetResource(t *testing.T) {
 		})
 	}
 }
+
+func TestResourceBuilder_BulkFieldRegistration(t *testing.T) {
+	type Member struct {
+		ID        uint   `db:"id"`
+		Name      string `db:"name"`
+		Email     string `db:"email"`
+		Role      string `db:"role"`
+		Password  string `db:"password"`
+		Transient string `db:"-"`
+		secret    string
+	}
+
+	fieldNames := func(resource *Resource) []string {
+		var names []string
+		for _, field := range resource.Fields {
+			names = append(names, field.Name)
+		}
+		return names
+	}
+
+	bo := New(&DummyAdapter{}, auth.AuthConfig{})
+	bo.RegisterResource(&Member{}).
+		WithFields("Email", "Name").
+		WithField("Email", func(f *FieldBuilder) { f.Required(true) })
+	resource, _ := bo.GetResource("Member")
+
+	if got := fieldNames(resource); !reflect.DeepEqual(got, []string{"ID", "Email", "Name"}) {
+		t.Errorf("Expected [ID Email Name], got %v", got)
+	}
+	if email, _ := resource.GetField("Email"); !email.Required {
+		t.Error("Expected WithField to override the Email configuration")
+	}
+
+	bo.RegisterResource(&Member{}).
+		WithField("Role", func(f *FieldBuilder) { f.DisplayName("Access Role") }).
+		AutoFields("Password")
+	resource, _ = bo.GetResource("Member")
+
+	if got := fieldNames(resource); !reflect.DeepEqual(got, []string{"ID", "Role", "Name", "Email"}) {
+		t.Errorf("Expected [ID Role Name Email], got %v", got)
+	}
+	if role, _ := resource.GetField("Role"); role.DisplayName != "Access Role" {
+		t.Errorf("Expected AutoFields to keep the Role configuration, got '%s'", role.DisplayName)
+	}
+}
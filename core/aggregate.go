@@ -0,0 +1,92 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// ErrAggregateUnsupported is returned when aggregating records through an adapter without Aggregator
+var ErrAggregateUnsupported = errors.New("adapter does not support aggregation")
+
+// AggregateFunc combines the records of a group into a single value
+type AggregateFunc string
+
+const (
+	AggregateCount AggregateFunc = "count"
+	AggregateSum   AggregateFunc = "sum"
+	AggregateAvg   AggregateFunc = "avg"
+	AggregateMin   AggregateFunc = "min"
+	AggregateMax   AggregateFunc = "max"
+)
+
+// TimeBucket groups the values of a time field by period
+type TimeBucket string
+
+const (
+	BucketDay   TimeBucket = "day"   // Labeled 2006-01-02
+	BucketWeek  TimeBucket = "week"  // Weeks start on Monday, labeled with that day
+	BucketMonth TimeBucket = "month" // Labeled 2006-01
+)
+
+// AggregateQuery groups the records matching Query and combines every group into a single value
+type AggregateQuery struct {
+	Query   *Query        // Filters the aggregated records; nil aggregates all of them
+	GroupBy string        // Field whose values, or periods with Bucket, form the groups; empty combines all records into one
+	Bucket  TimeBucket    // Groups a time field by period instead of by exact value
+	Func    AggregateFunc // Defaults to AggregateCount
+	Field   string        // Field summed, averaged or compared; unused when counting
+}
+
+// AggregatePoint is the value of one group, in group order
+type AggregatePoint struct {
+	Label string  `json:"label"`
+	Value float64 `json:"value"`
+}
+
+// Aggregator is implemented by adapters that can group and aggregate records
+// Groups are returned in ascending order of their value, or period; without GroupBy a single unlabeled point is returned
+type Aggregator interface {
+	Aggregate(ctx context.Context, resource *Resource, query AggregateQuery) ([]AggregatePoint, error)
+}
+
+// Aggregate groups and combines the records of a resource visible through its scope
+func Aggregate(ctx context.Context, adapter Adapter, resource *Resource, query AggregateQuery) ([]AggregatePoint, error) {
+	aggregator, ok := adapter.(Aggregator)
+	if !ok {
+		return nil, ErrAggregateUnsupported
+	}
+	if err := query.Validate(); err != nil {
+		return nil, err
+	}
+	if query.Func == "" {
+		query.Func = AggregateCount
+	}
+	if query.Query == nil {
+		query.Query = NewQuery()
+	}
+	query.Query = resource.ApplyScope(ctx, query.Query)
+	return aggregator.Aggregate(ctx, resource, query)
+}
+
+// Validate reports an incomplete or unknown aggregation
+func (q AggregateQuery) Validate() error {
+	if q.Bucket != "" && q.GroupBy == "" {
+		return errors.New("time buckets need a field to group by")
+	}
+	switch q.Func {
+	case "", AggregateCount:
+	case AggregateSum, AggregateAvg, AggregateMin, AggregateMax:
+		if q.Field == "" {
+			return fmt.Errorf("%s aggregation needs a field", q.Func)
+		}
+	default:
+		return fmt.Errorf("unknown aggregate function %q", q.Func)
+	}
+	switch q.Bucket {
+	case "", BucketDay, BucketWeek, BucketMonth:
+		return nil
+	default:
+		return fmt.Errorf("unknown time bucket %q", q.Bucket)
+	}
+}
@@ -0,0 +1,84 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/preslavrachev/backoffice/middleware/auth"
+)
+
+type signup struct {
+	ID        uint   `db:"id"`
+	Plan      string `db:"plan"`
+	CompanyID uint   `db:"company_id"`
+}
+
+// aggregateAdapter records the aggregation it was asked for
+type aggregateAdapter struct {
+	DummyAdapter
+	query AggregateQuery
+}
+
+func (a *aggregateAdapter) Aggregate(ctx context.Context, resource *Resource, query AggregateQuery) ([]AggregatePoint, error) {
+	a.query = query
+	return []AggregatePoint{{Label: "pro", Value: 3}}, nil
+}
+
+func TestAggregate_AppliesScope(t *testing.T) {
+	adapter := &aggregateAdapter{}
+	bo := New(adapter, auth.AuthConfig{})
+	bo.RegisterResource(&signup{}).WithFields("Plan", "CompanyID").
+		WithScope(func(ctx context.Context, query *Query) *Query {
+			return query.WithFilters(map[string]any{"CompanyID": uint(7)})
+		})
+	resource, _ := bo.GetResource("signup")
+
+	if _, err := Aggregate(context.Background(), adapter, resource, AggregateQuery{GroupBy: "Plan"}); err != nil {
+		t.Fatalf("Aggregate failed: %v", err)
+	}
+	if adapter.query.Func != AggregateCount {
+		t.Errorf("Expected records to be counted by default, got %q", adapter.query.Func)
+	}
+	if adapter.query.Query.Filters["CompanyID"] != uint(7) {
+		t.Errorf("Expected the resource scope to restrict the aggregation, got %v", adapter.query.Query.Filters)
+	}
+
+	if _, err := Aggregate(context.Background(), &DummyAdapter{}, resource, AggregateQuery{GroupBy: "Plan"}); !errors.Is(err, ErrAggregateUnsupported) {
+		t.Errorf("Expected ErrAggregateUnsupported, got %v", err)
+	}
+}
+
+func TestAggregateQuery_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		query   AggregateQuery
+		wantErr bool
+	}{
+		{"count", AggregateQuery{GroupBy: "Plan"}, false},
+		{"weekly sum", AggregateQuery{GroupBy: "CreatedAt", Bucket: BucketWeek, Func: AggregateSum, Field: "Amount"}, false},
+		{"total", AggregateQuery{Func: AggregateSum, Field: "Amount"}, false},
+		{"bucket without group", AggregateQuery{Bucket: BucketWeek}, true},
+		{"sum without field", AggregateQuery{GroupBy: "Plan", Func: AggregateSum}, true},
+		{"unknown func", AggregateQuery{GroupBy: "Plan", Func: "median"}, true},
+		{"unknown bucket", AggregateQuery{GroupBy: "CreatedAt", Bucket: "hour"}, true},
+	}
+	for _, tt := range tests {
+		if err := tt.query.Validate(); (err != nil) != tt.wantErr {
+			t.Errorf("%s: expected error %v, got %v", tt.name, tt.wantErr, err)
+		}
+	}
+}
+
+func TestWithChart_Validate(t *testing.T) {
+	bo := New(&DummyAdapter{}, auth.AuthConfig{})
+	bo.RegisterResource(&signup{}).WithFields("Plan").
+		WithChart("Signups per plan", ChartPie, AggregateQuery{GroupBy: "Plan"}).
+		WithChart("Signups per region", ChartBar, AggregateQuery{GroupBy: "Region"}).
+		WithChart("Signups", "donut", AggregateQuery{GroupBy: "Plan"})
+
+	var problems ConfigErrors
+	if err := bo.Validate(); !errors.As(err, &problems) || len(problems) != 2 {
+		t.Fatalf("Expected the unknown field and chart type to be reported, got %v", err)
+	}
+}
@@ -0,0 +1,85 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// BulkDeleteActionID is the action ID used to delete the selected records in bulk
+const BulkDeleteActionID = "delete"
+
+// ErrRecordInUse is returned when a record can't be deleted because other records still reference it
+var ErrRecordInUse = errors.New("record is referenced by other records")
+
+// MaxBulkSelection caps how many records a single bulk action may touch
+const MaxBulkSelection = 10000
+
+// BulkError describes a record a bulk action failed on
+type BulkError struct {
+	ID      any    `json:"id"`
+	Message string `json:"message"`
+}
+
+// BulkResult reports the progress of a bulk action
+type BulkResult struct {
+	Total     int         `json:"total"`
+	Processed int         `json:"processed"`
+	Succeeded int         `json:"succeeded"`
+	Errors    []BulkError `json:"errors"`
+}
+
+// Failed returns the number of records the action failed on
+func (r *BulkResult) Failed() int {
+	return len(r.Errors)
+}
+
+// Percent returns how much of the selection has been processed, from 0 to 100
+func (r *BulkResult) Percent() int {
+	if r.Total == 0 {
+		return 100
+	}
+	return r.Processed * 100 / r.Total
+}
+
+// RunBulkAction applies fn to every ID, collecting failures instead of stopping at the first one
+// Processing stops early if the context is cancelled
+func RunBulkAction(ctx context.Context, ids []any, fn func(ctx context.Context, id any) error) *BulkResult {
+	result := &BulkResult{Total: len(ids)}
+	for _, id := range ids {
+		if err := ctx.Err(); err != nil {
+			break
+		}
+
+		result.Processed++
+		if err := fn(ctx, id); err != nil {
+			result.Errors = append(result.Errors, BulkError{ID: id, Message: err.Error()})
+			continue
+		}
+		result.Succeeded++
+	}
+	return result
+}
+
+// CollectMatchingIDs returns the IDs of all records matching the query, up to MaxBulkSelection
+func CollectMatchingIDs(ctx context.Context, adapter Adapter, resource *Resource, query *Query) ([]any, error) {
+	var ids []any
+	pageQuery := query.WithPagination(MaxPageSize, 0)
+
+	for {
+		result, err := adapter.Find(ctx, resource, pageQuery)
+		if err != nil {
+			return nil, fmt.Errorf("failed to collect %s: %w", resource.PluralName, err)
+		}
+		for _, item := range result.Items {
+			ids = append(ids, GetFieldValue(item, resource.IDField))
+		}
+		if len(ids) > MaxBulkSelection {
+			return nil, fmt.Errorf("selection exceeds the limit of %d records", MaxBulkSelection)
+		}
+		if !result.HasMore || len(result.Items) == 0 {
+			return ids, nil
+		}
+		pageQuery = pageQuery.NextPage()
+	}
+}
@@ -0,0 +1,76 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+// bulkTestAdapter pages through a fixed number of records
+type bulkTestAdapter struct {
+	DummyAdapter
+	total int
+}
+
+func (a *bulkTestAdapter) Find(ctx context.Context, resource *Resource, query *Query) (*Result, error) {
+	type Row struct{ ID uint }
+
+	result := &Result{TotalCount: int64(a.total), Query: *query}
+	for i := query.Pagination.Offset; i < a.total && i < query.Pagination.Offset+query.Pagination.Limit; i++ {
+		result.Items = append(result.Items, &Row{ID: uint(i + 1)})
+	}
+	result.HasMore = query.Pagination.Offset+len(result.Items) < a.total
+	return result, nil
+}
+
+func TestRunBulkAction(t *testing.T) {
+	ids := []any{uint(1), uint(2), uint(3)}
+	result := RunBulkAction(context.Background(), ids, func(ctx context.Context, id any) error {
+		if id == uint(2) {
+			return errors.New("locked")
+		}
+		return nil
+	})
+
+	if result.Total != 3 || result.Processed != 3 || result.Succeeded != 2 || result.Failed() != 1 {
+		t.Errorf("Unexpected result: %+v", result)
+	}
+	if result.Errors[0].ID != uint(2) || result.Errors[0].Message != "locked" {
+		t.Errorf("Expected failure for ID 2, got %+v", result.Errors[0])
+	}
+	if result.Percent() != 100 {
+		t.Errorf("Expected 100%% progress, got %d", result.Percent())
+	}
+}
+
+func TestRunBulkAction_Cancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	ids := []any{uint(1), uint(2), uint(3), uint(4)}
+
+	result := RunBulkAction(ctx, ids, func(ctx context.Context, id any) error {
+		if id == uint(2) {
+			cancel()
+		}
+		return nil
+	})
+
+	if result.Processed != 2 || result.Percent() != 50 {
+		t.Errorf("Expected processing to stop after cancellation, got %+v", result)
+	}
+}
+
+func TestCollectMatchingIDs(t *testing.T) {
+	resource := &Resource{Name: "Row", PluralName: "Rows", IDField: "ID"}
+	adapter := &bulkTestAdapter{total: MaxPageSize + 5}
+
+	ids, err := CollectMatchingIDs(context.Background(), adapter, resource, NewQuery())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(ids) != MaxPageSize+5 {
+		t.Fatalf("Expected %d IDs across pages, got %d", MaxPageSize+5, len(ids))
+	}
+	if ids[0] != uint(1) || ids[len(ids)-1] != uint(MaxPageSize+5) {
+		t.Errorf("Unexpected IDs at the boundaries: %v, %v", ids[0], ids[len(ids)-1])
+	}
+}
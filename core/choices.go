@@ -0,0 +1,93 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"slices"
+)
+
+// ChoicesFunc returns the choices of a dependent field for the given parent value
+type ChoicesFunc func(ctx context.Context, parentValue string) ([]string, error)
+
+// HasChoices reports whether the field is restricted to a set of choices
+func (f *FieldInfo) HasChoices() bool {
+	return len(f.Choices) > 0 || f.ChoicesFunc != nil
+}
+
+// ChoicesFor returns the field's choices, resolving dependent choices against the parent value
+// A dependent field has no choices until its parent is set
+func (f *FieldInfo) ChoicesFor(ctx context.Context, parentValue string) ([]string, error) {
+	if f.ChoicesFunc == nil {
+		return f.Choices, nil
+	}
+	if parentValue == "" {
+		return nil, nil
+	}
+	return f.ChoicesFunc(ctx, parentValue)
+}
+
+// ChoicesForItem returns the field's choices for the parent value currently set on the record
+func (f *FieldInfo) ChoicesForItem(ctx context.Context, item any) ([]string, error) {
+	if f.DependsOn == "" || item == nil {
+		return f.ChoicesFor(ctx, "")
+	}
+	return f.ChoicesFor(ctx, choiceValue(GetFieldValue(item, f.DependsOn)))
+}
+
+// validateChoices checks that every choice field holds one of its allowed values
+// Empty values are left to the Required check
+func (r *Resource) validateChoices(ctx context.Context, item any) ValidationErrors {
+	var failures ValidationErrors
+	for i := range r.Fields {
+		field := &r.Fields[i]
+		if !field.HasChoices() {
+			continue
+		}
+		value := choiceValue(GetFieldValue(item, field.Name))
+		if value == "" {
+			continue
+		}
+		choices, err := field.ChoicesForItem(ctx, item)
+		if err != nil {
+			failures = append(failures, NewFieldError(fmt.Sprintf("failed to load choices: %v", err), field.Name))
+			continue
+		}
+		if slices.Contains(choices, value) {
+			continue
+		}
+		if field.DependsOn != "" {
+			failures = append(failures, NewFieldError(fmt.Sprintf("%q is not a valid choice for the selected %s", value, r.FieldDisplayName(field.DependsOn)), field.Name))
+		} else {
+			failures = append(failures, NewFieldError(fmt.Sprintf("%q is not a valid choice", value), field.Name))
+		}
+	}
+	return failures
+}
+
+// hasChoiceFields reports whether any field is restricted to a set of choices
+func (r *Resource) hasChoiceFields() bool {
+	for i := range r.Fields {
+		if r.Fields[i].HasChoices() {
+			return true
+		}
+	}
+	return false
+}
+
+// FieldDisplayName returns the display name of a field, falling back to its name
+func (r *Resource) FieldDisplayName(name string) string {
+	if field, ok := r.GetField(name); ok {
+		return field.DisplayName
+	}
+	return name
+}
+
+// choiceValue formats a field value for comparison with choices
+func choiceValue(value any) string {
+	val := reflect.ValueOf(value)
+	if !val.IsValid() || (val.Kind() == reflect.Ptr && val.IsNil()) {
+		return ""
+	}
+	return fmt.Sprintf("%v", reflect.Indirect(val).Interface())
+}
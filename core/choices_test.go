@@ -0,0 +1,101 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/preslavrachev/backoffice/middleware/auth"
+)
+
+type Address struct {
+	ID      uint
+	Country string
+	State   string
+}
+
+func newAddressResource() *Resource {
+	bo := &BackOffice{resources: make(map[string]*Resource), resourceOrder: []string{}, config: &Config{}}
+	bo.RegisterResource(&Address{}).
+		WithField("Country", func(f *FieldBuilder) {
+			f.Choices([]string{"US", "DE"})
+		}).
+		WithField("State", func(f *FieldBuilder) {
+			f.DependsOn("Country", map[string][]string{
+				"US": {"CA", "NY"},
+				"DE": {"BE", "BY"},
+			})
+		})
+	resource, _ := bo.GetResource("Address")
+	return resource
+}
+
+func TestFieldChoicesFor(t *testing.T) {
+	resource := newAddressResource()
+	state, _ := resource.GetField("State")
+
+	if !state.HasChoices() || state.DependsOn != "Country" {
+		t.Fatalf("Expected State to depend on Country, got %+v", state)
+	}
+	choices, err := state.ChoicesFor(context.Background(), "DE")
+	if err != nil || len(choices) != 2 || choices[0] != "BE" {
+		t.Errorf("Expected the German states, got %v (%v)", choices, err)
+	}
+	if choices, _ := state.ChoicesFor(context.Background(), ""); len(choices) != 0 {
+		t.Errorf("Expected no choices without a parent value, got %v", choices)
+	}
+	if choices, _ := state.ChoicesForItem(context.Background(), &Address{Country: "US"}); len(choices) != 2 || choices[1] != "NY" {
+		t.Errorf("Expected the US states for the record, got %v", choices)
+	}
+}
+
+func TestValidate_DependentChoices(t *testing.T) {
+	resource := newAddressResource()
+	ctx := context.Background()
+
+	if err := resource.Validate(ctx, &Address{Country: "US", State: "NY"}); err != nil {
+		t.Errorf("Expected a valid address, got %v", err)
+	}
+	if err := resource.Validate(ctx, &Address{Country: "US"}); err != nil {
+		t.Errorf("Expected an empty state to be left to the required check, got %v", err)
+	}
+
+	err := resource.Validate(ctx, &Address{Country: "DE", State: "NY"})
+	var failures ValidationErrors
+	if !errors.As(err, &failures) {
+		t.Fatalf("Expected ValidationErrors, got %v", err)
+	}
+	if _, ok := failures.FieldMessages()["State"]; !ok {
+		t.Errorf("Expected the error on State, got %v", failures.FieldMessages())
+	}
+
+	if err := resource.Validate(ctx, &Address{Country: "FR"}); err == nil {
+		t.Error("Expected an unknown country to fail")
+	}
+}
+
+func TestChoiceColors(t *testing.T) {
+	bo := New(&DummyAdapter{}, auth.AuthConfig{})
+	bo.RegisterResource(&healthInvoice{}).
+		WithField("Number", func(f *FieldBuilder) {
+			f.Choices([]string{"A-1", "A-2"}).ChoiceColors(map[string]string{"A-1": StatusGreen, "B-9": StatusRed})
+		})
+	resource, _ := bo.GetResource("healthInvoice")
+	field, _ := resource.GetField("Number")
+
+	if !field.IsStatus() {
+		t.Fatal("Expected a field with choice colors to render as a status")
+	}
+	if label, color := field.Status(&healthInvoice{Number: "A-1"}); label != "A-1" || color != StatusGreen {
+		t.Errorf("Expected a green A-1, got %s %s", color, label)
+	}
+	if label, color := field.Status(&healthInvoice{Number: "A-2"}); label != "A-2" || color != StatusGray {
+		t.Errorf("Expected an uncolored choice to be gray, got %s %s", color, label)
+	}
+
+	err := bo.Validate()
+	if err == nil || !strings.Contains(err.Error(), `field Number: color for unknown choice "B-9"`) {
+		t.Errorf("Expected a problem with the unknown choice, got %v", err)
+	}
+}
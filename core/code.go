@@ -0,0 +1,34 @@
+package core
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// CodeLanguage selects the syntax of a field edited in the code editor
+type CodeLanguage string
+
+const (
+	CodeJSON CodeLanguage = "json"
+	CodeYAML CodeLanguage = "yaml"
+	CodeSQL  CodeLanguage = "sql"
+)
+
+// validateCode rejects JSON fields holding malformed JSON; other languages are only linted in the browser
+func (r *Resource) validateCode(item any) ValidationErrors {
+	var failures ValidationErrors
+	for _, field := range r.Fields {
+		if field.CodeLanguage != CodeJSON {
+			continue
+		}
+		value, _ := GetFieldValue(item, field.Name).(string)
+		if value == "" {
+			continue
+		}
+		var decoded any
+		if err := json.Unmarshal([]byte(value), &decoded); err != nil {
+			failures = append(failures, NewFieldError(fmt.Sprintf("invalid JSON: %v", err), field.Name))
+		}
+	}
+	return failures
+}
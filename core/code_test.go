@@ -0,0 +1,36 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/preslavrachev/backoffice/middleware/auth"
+)
+
+type webhookConfig struct {
+	ID      uint   `db:"id"`
+	Payload string `db:"payload"`
+	Query   string `db:"query"`
+}
+
+func TestValidate_CodeFields(t *testing.T) {
+	bo := New(&DummyAdapter{}, auth.AuthConfig{})
+	bo.RegisterResource(&webhookConfig{}).
+		WithField("Payload", func(f *FieldBuilder) { f.AsCode(CodeJSON) }).
+		WithField("Query", func(f *FieldBuilder) { f.AsCode(CodeSQL) })
+	resource, _ := bo.GetResource("webhookConfig")
+
+	if err := resource.Validate(context.Background(), &webhookConfig{Payload: `{"retries": 3}`, Query: "SELECT"}); err != nil {
+		t.Errorf("Expected valid JSON to pass, got %v", err)
+	}
+	if err := resource.Validate(context.Background(), &webhookConfig{}); err != nil {
+		t.Errorf("Expected empty JSON fields to pass, got %v", err)
+	}
+
+	err := resource.Validate(context.Background(), &webhookConfig{Payload: `{"retries": 3`})
+	var failures ValidationErrors
+	if !errors.As(err, &failures) || len(failures) != 1 || failures[0].Fields[0] != "Payload" {
+		t.Errorf("Expected a JSON error on Payload, got %v", err)
+	}
+}
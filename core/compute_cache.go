@@ -0,0 +1,135 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/preslavrachev/backoffice/middleware/auth"
+)
+
+// DefaultCacheVersionField is the field used to detect record changes for cached computed values
+const DefaultCacheVersionField = "UpdatedAt"
+
+// maxComputeCacheEntries bounds the number of memoized values kept per field
+const maxComputeCacheEntries = 10000
+
+// computeCacheKey identifies a memoized computed value
+type computeCacheKey struct {
+	resource string
+	id       string
+	version  string
+}
+
+// computeCacheEntry holds a memoized computed value and its expiry
+type computeCacheEntry struct {
+	value     any
+	expiresAt time.Time
+}
+
+// ComputeCache memoizes computed field values keyed by (resource, id, version) with a TTL
+type ComputeCache struct {
+	ttl     time.Duration
+	entries map[computeCacheKey]computeCacheEntry
+	mu      sync.Mutex
+}
+
+// NewComputeCache creates a new cache whose entries expire after ttl
+func NewComputeCache(ttl time.Duration) *ComputeCache {
+	return &ComputeCache{
+		ttl:     ttl,
+		entries: make(map[computeCacheKey]computeCacheEntry),
+	}
+}
+
+// GetOrCompute returns the cached value for the key or computes and stores it
+func (c *ComputeCache) GetOrCompute(resource string, id, version any, compute func() any) any {
+	key := computeCacheKey{
+		resource: resource,
+		id:       fmt.Sprintf("%v", id),
+		version:  fmt.Sprintf("%v", version),
+	}
+	now := time.Now()
+
+	c.mu.Lock()
+	if entry, ok := c.entries[key]; ok && now.Before(entry.expiresAt) {
+		c.mu.Unlock()
+		return entry.value
+	}
+	c.mu.Unlock()
+
+	// Compute outside the lock so slow functions don't block other rows
+	value := compute()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.entries) >= maxComputeCacheEntries {
+		c.evictExpired(now)
+	}
+	c.entries[key] = computeCacheEntry{value: value, expiresAt: now.Add(c.ttl)}
+
+	return value
+}
+
+// Clear removes all memoized values
+func (c *ComputeCache) Clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries = make(map[computeCacheKey]computeCacheEntry)
+}
+
+// evictExpired drops expired entries, or everything if the cache is still full
+// Must be called with the lock held
+func (c *ComputeCache) evictExpired(now time.Time) {
+	for key, entry := range c.entries {
+		if !now.Before(entry.expiresAt) {
+			delete(c.entries, key)
+		}
+	}
+	if len(c.entries) >= maxComputeCacheEntries {
+		c.entries = make(map[computeCacheKey]computeCacheEntry)
+	}
+}
+
+// memoizeComputedField wraps the field's compute functions with a per-record cache
+func (r *Resource) memoizeComputedField(info *FieldInfo, config *FieldConfig) {
+	cache := NewComputeCache(config.CacheTTL)
+	versionField := config.CacheVersion
+	if versionField == "" {
+		versionField = DefaultCacheVersionField
+	}
+	resourceName := r.Name
+	idField := r.IDField
+
+	if compute := info.ComputeFunc; compute != nil {
+		info.ComputeFunc = func(item any) string {
+			value := cache.GetOrCompute(resourceName, GetFieldValue(item, idField), GetFieldValue(item, versionField), func() any {
+				return compute(item)
+			})
+			str, _ := value.(string)
+			return str
+		}
+	}
+
+	if compute := info.ComputeFuncCtx; compute != nil {
+		// Values computed from the context may depend on the user, so each user gets their own
+		info.ComputeFuncCtx = func(ctx context.Context, item any) string {
+			user, _ := auth.GetAuthUser(ctx)
+			version := fmt.Sprintf("%v\x00%s", GetFieldValue(item, versionField), permissionSubject(user))
+			value := cache.GetOrCompute(resourceName, GetFieldValue(item, idField), version, func() any {
+				return compute(ctx, item)
+			})
+			str, _ := value.(string)
+			return str
+		}
+	}
+
+	if compute := info.TypedComputeFunc; compute != nil {
+		info.TypedComputeFunc = func(item any) any {
+			return cache.GetOrCompute(resourceName, GetFieldValue(item, idField), GetFieldValue(item, versionField), func() any {
+				return compute(item)
+			})
+		}
+	}
+}
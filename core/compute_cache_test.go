@@ -0,0 +1,132 @@
+package core
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/preslavrachev/backoffice/middleware/auth"
+)
+
+func TestComputeCache_GetOrCompute(t *testing.T) {
+	cache := NewComputeCache(time.Minute)
+	calls := 0
+	compute := func() any {
+		calls++
+		return calls
+	}
+
+	first := cache.GetOrCompute("User", 1, "v1", compute)
+	second := cache.GetOrCompute("User", 1, "v1", compute)
+	if first != second || calls != 1 {
+		t.Errorf("Expected cached value on second call, got %v and %v after %d calls", first, second, calls)
+	}
+
+	cache.GetOrCompute("User", 1, "v2", compute)
+	if calls != 2 {
+		t.Errorf("Expected recompute after version change, got %d calls", calls)
+	}
+
+	cache.GetOrCompute("User", 2, "v2", compute)
+	if calls != 3 {
+		t.Errorf("Expected separate entry per ID, got %d calls", calls)
+	}
+}
+
+func TestComputeCache_Expiry(t *testing.T) {
+	cache := NewComputeCache(10 * time.Millisecond)
+	calls := 0
+	compute := func() any {
+		calls++
+		return calls
+	}
+
+	cache.GetOrCompute("User", 1, "", compute)
+	time.Sleep(20 * time.Millisecond)
+	cache.GetOrCompute("User", 1, "", compute)
+
+	if calls != 2 {
+		t.Errorf("Expected recompute after TTL expiry, got %d calls", calls)
+	}
+}
+
+func TestDerivedField_Cache(t *testing.T) {
+	type Report struct {
+		ID        uint      `db:"id"`
+		Name      string    `db:"name"`
+		UpdatedAt time.Time `db:"updated_at"`
+	}
+
+	bo := &BackOffice{
+		resources:     make(map[string]*Resource),
+		resourceOrder: []string{},
+		config:        &Config{},
+	}
+
+	calls := 0
+	bo.RegisterResource(&Report{}).
+		WithDerivedField("Expensive", "Expensive", func(item any) string {
+			calls++
+			return item.(*Report).Name
+		}, func(f *FieldBuilder) {
+			f.Cache(time.Minute)
+		})
+	resource, _ := bo.GetResource("Report")
+	field, _ := resource.GetField("Expensive")
+
+	report := &Report{ID: 1, Name: "Quarterly", UpdatedAt: time.Now()}
+	for i := 0; i < 3; i++ {
+		if got := FormatFieldValueForDisplay(report, field); got != "Quarterly" {
+			t.Fatalf("Expected 'Quarterly', got '%s'", got)
+		}
+	}
+	if calls != 1 {
+		t.Errorf("Expected compute function to run once, ran %d times", calls)
+	}
+
+	report.UpdatedAt = report.UpdatedAt.Add(time.Second)
+	FormatFieldValueForDisplay(report, field)
+	if calls != 2 {
+		t.Errorf("Expected recompute after UpdatedAt change, ran %d times", calls)
+	}
+}
+
+func TestDerivedFieldCtx_CachePerUser(t *testing.T) {
+	type Report struct {
+		ID        uint      `db:"id"`
+		UpdatedAt time.Time `db:"updated_at"`
+	}
+
+	bo := &BackOffice{
+		resources:     make(map[string]*Resource),
+		resourceOrder: []string{},
+		config:        &Config{},
+	}
+
+	calls := 0
+	bo.RegisterResource(&Report{}).
+		WithDerivedFieldCtx("Viewer", "Viewer", func(ctx context.Context, item any) string {
+			calls++
+			user, _ := auth.GetAuthUser(ctx)
+			return user.Username
+		}, func(f *FieldBuilder) {
+			f.Cache(time.Minute)
+		})
+	resource, _ := bo.GetResource("Report")
+	field, _ := resource.GetField("Viewer")
+
+	report := &Report{ID: 1, UpdatedAt: time.Now()}
+	alice := auth.WithAuthUser(context.Background(), &auth.AuthUser{Username: "alice"})
+	bob := auth.WithAuthUser(context.Background(), &auth.AuthUser{Username: "bob"})
+	for i := 0; i < 2; i++ {
+		if got := field.ComputeFuncCtx(alice, report); got != "alice" {
+			t.Fatalf("Expected 'alice', got '%s'", got)
+		}
+		if got := field.ComputeFuncCtx(bob, report); got != "bob" {
+			t.Fatalf("Expected bob not to see alice's cached value, got '%s'", got)
+		}
+	}
+	if calls != 2 {
+		t.Errorf("Expected one computation per user, ran %d times", calls)
+	}
+}
@@ -0,0 +1,163 @@
+package core
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ComputedTimeFormat is the layout used to display time-kind computed values
+const ComputedTimeFormat = "2006-01-02 15:04"
+
+// GoType returns the field type name used for a computed kind
+// Plain (untyped) computed fields are reported as strings
+func (k ComputedKind) GoType() string {
+	switch k {
+	case ComputedNumber:
+		return "float64"
+	case ComputedBool:
+		return "bool"
+	case ComputedTime:
+		return "time.Time"
+	default:
+		return "string"
+	}
+}
+
+// IsInMemorySortable reports whether the field is a typed computed field that has no
+// database sort configuration and therefore has to be sorted after fetching
+func (f *FieldInfo) IsInMemorySortable() bool {
+	return f.IsComputed && f.TypedComputeFunc != nil && len(f.SortFields) == 0
+}
+
+// computeString evaluates a plain derived field, preferring the context-aware compute function
+func (f *FieldInfo) computeString(ctx context.Context, item any) (string, bool) {
+	if f.ComputeFuncCtx != nil {
+		return f.ComputeFuncCtx(ctx, item), true
+	}
+	if f.ComputeFunc != nil {
+		return f.ComputeFunc(item), true
+	}
+	return "", false
+}
+
+// FormatComputedValue formats a typed computed value for display according to its kind
+func FormatComputedValue(value any, kind ComputedKind) string {
+	if value == nil {
+		return ""
+	}
+
+	switch kind {
+	case ComputedNumber:
+		if f, ok := toFloat64(value); ok {
+			return strconv.FormatFloat(f, 'f', -1, 64)
+		}
+	case ComputedBool:
+		if b, ok := value.(bool); ok {
+			if b {
+				return "Yes"
+			}
+			return "No"
+		}
+	case ComputedTime:
+		if t, ok := value.(time.Time); ok {
+			if t.IsZero() {
+				return ""
+			}
+			return t.Format(ComputedTimeFormat)
+		}
+	}
+
+	return fmt.Sprintf("%v", value)
+}
+
+// CompareComputedValues compares two typed computed values of the given kind
+// Returns -1 if a < b, 0 if equal and 1 if a > b. Nil values sort first.
+func CompareComputedValues(a, b any, kind ComputedKind) int {
+	if a == nil || b == nil {
+		switch {
+		case a == nil && b == nil:
+			return 0
+		case a == nil:
+			return -1
+		default:
+			return 1
+		}
+	}
+
+	switch kind {
+	case ComputedNumber:
+		af, aok := toFloat64(a)
+		bf, bok := toFloat64(b)
+		if aok && bok {
+			return cmp.Compare(af, bf)
+		}
+	case ComputedBool:
+		ab, aok := a.(bool)
+		bb, bok := b.(bool)
+		if aok && bok {
+			if ab == bb {
+				return 0
+			}
+			if !ab {
+				return -1
+			}
+			return 1
+		}
+	case ComputedTime:
+		at, aok := a.(time.Time)
+		bt, bok := b.(time.Time)
+		if aok && bok {
+			return at.Compare(bt)
+		}
+	}
+
+	return strings.Compare(fmt.Sprintf("%v", a), fmt.Sprintf("%v", b))
+}
+
+// SortItemsByComputedField sorts items in place by the value of a typed computed field
+func SortItemsByComputedField(items []any, field *FieldInfo, direction SortDirection) {
+	if field == nil || field.TypedComputeFunc == nil {
+		return
+	}
+
+	values := make(map[int]any, len(items))
+	indexed := make([]int, len(items))
+	for i, item := range items {
+		indexed[i] = i
+		values[i] = field.TypedComputeFunc(item)
+	}
+
+	sort.SliceStable(indexed, func(i, j int) bool {
+		cmp := CompareComputedValues(values[indexed[i]], values[indexed[j]], field.ComputedKind)
+		if direction == SortDesc {
+			return cmp > 0
+		}
+		return cmp < 0
+	})
+
+	sorted := make([]any, len(items))
+	for i, idx := range indexed {
+		sorted[i] = items[idx]
+	}
+	copy(items, sorted)
+}
+
+// toFloat64 converts any integer or float value to float64
+func toFloat64(value any) (float64, bool) {
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(v.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(v.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return v.Float(), true
+	}
+	return 0, false
+}
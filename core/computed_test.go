@@ -0,0 +1,144 @@
+package core
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/preslavrachev/backoffice/middleware/auth"
+)
+
+func TestFormatComputedValue(t *testing.T) {
+	when := time.Date(2024, 3, 15, 9, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		value    any
+		kind     ComputedKind
+		expected string
+	}{
+		{"integer number", 42, ComputedNumber, "42"},
+		{"float number", 12.5, ComputedNumber, "12.5"},
+		{"true bool", true, ComputedBool, "Yes"},
+		{"false bool", false, ComputedBool, "No"},
+		{"time", when, ComputedTime, "2024-03-15 09:30"},
+		{"zero time", time.Time{}, ComputedTime, ""},
+		{"nil value", nil, ComputedNumber, ""},
+		{"string fallback", "hello", ComputedString, "hello"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FormatComputedValue(tt.value, tt.kind); got != tt.expected {
+				t.Errorf("FormatComputedValue() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestCompareComputedValues(t *testing.T) {
+	if CompareComputedValues(2, 10, ComputedNumber) != -1 {
+		t.Error("Expected 2 < 10 for number kind")
+	}
+	if CompareComputedValues(uint(7), 7.0, ComputedNumber) != 0 {
+		t.Error("Expected mixed numeric types to compare equal")
+	}
+	if CompareComputedValues(true, false, ComputedBool) != 1 {
+		t.Error("Expected true > false for bool kind")
+	}
+	earlier := time.Now()
+	if CompareComputedValues(earlier, earlier.Add(time.Hour), ComputedTime) != -1 {
+		t.Error("Expected earlier time to sort first")
+	}
+	if CompareComputedValues(nil, 1, ComputedNumber) != -1 {
+		t.Error("Expected nil to sort first")
+	}
+}
+
+func TestTypedDerivedField(t *testing.T) {
+	type Order struct {
+		ID       uint    `db:"id"`
+		Quantity int     `db:"quantity"`
+		Price    float64 `db:"price"`
+	}
+
+	bo := &BackOffice{
+		resources:     make(map[string]*Resource),
+		resourceOrder: []string{},
+		config:        &Config{},
+	}
+	bo.RegisterResource(&Order{}).
+		WithTypedDerivedField("Total", "Total", ComputedNumber, func(item any) any {
+			o := item.(*Order)
+			return float64(o.Quantity) * o.Price
+		})
+	resource, _ := bo.GetResource("Order")
+
+	field, ok := resource.GetField("Total")
+	if !ok {
+		t.Fatal("Expected Total field to be registered")
+	}
+	if field.Type != "float64" {
+		t.Errorf("Expected type 'float64', got '%s'", field.Type)
+	}
+	if !resource.IsFieldSortable("Total") {
+		t.Error("Expected typed derived field to be sortable")
+	}
+
+	items := []any{
+		&Order{ID: 1, Quantity: 2, Price: 10},
+		&Order{ID: 2, Quantity: 1, Price: 5},
+		&Order{ID: 3, Quantity: 3, Price: 10},
+	}
+
+	if got := FormatFieldValueForDisplay(items[0], field); got != "20" {
+		t.Errorf("Expected formatted total '20', got '%s'", got)
+	}
+
+	SortItemsByComputedField(items, field, SortDesc)
+	expectedOrder := []uint{3, 1, 2}
+	for i, id := range expectedOrder {
+		if got := items[i].(*Order).ID; got != id {
+			t.Errorf("Position %d: expected ID %d, got %d", i, id, got)
+		}
+	}
+}
+
+func TestDerivedFieldCtx(t *testing.T) {
+	type Invoice struct {
+		ID    uint    `db:"id"`
+		Total float64 `db:"total"`
+	}
+
+	bo := &BackOffice{
+		resources:     make(map[string]*Resource),
+		resourceOrder: []string{},
+		config:        &Config{},
+	}
+	bo.RegisterResource(&Invoice{}).
+		WithDerivedFieldCtx("Viewer", "Viewed By", func(ctx context.Context, item any) string {
+			if user, ok := auth.GetAuthUser(ctx); ok {
+				return user.Username
+			}
+			return "anonymous"
+		})
+	resource, _ := bo.GetResource("Invoice")
+	field, _ := resource.GetField("Viewer")
+
+	if !field.IsComputed || !field.ReadOnly {
+		t.Error("Expected context-aware derived field to be computed and read-only")
+	}
+
+	invoice := &Invoice{ID: 1, Total: 10}
+	ctx := auth.WithAuthUser(context.Background(), &auth.AuthUser{Username: "alice"})
+
+	if got := FormatFieldValueForDisplayCtx(ctx, invoice, field); got != "alice" {
+		t.Errorf("Expected 'alice', got '%s'", got)
+	}
+	if got := GetFieldValueWithResourceCtx(ctx, invoice, field, resource); got != "alice" {
+		t.Errorf("Expected 'alice', got '%v'", got)
+	}
+	if got := FormatFieldValueForDisplay(invoice, field); got != "anonymous" {
+		t.Errorf("Expected 'anonymous' without a request context, got '%s'", got)
+	}
+}
@@ -0,0 +1,28 @@
+package core
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// IsCopyable reports whether the field's values get a copy-to-clipboard button
+// IDs and email addresses are copyable without marking them with Copyable
+func (f *FieldInfo) IsCopyable() bool {
+	return f.Copyable || f.PrimaryKey || (f.Type == "string" && strings.HasSuffix(f.Name, "Email"))
+}
+
+// CopyText returns the record's value of the field as copied to the clipboard, empty for nil values
+func (f *FieldInfo) CopyText(item any) string {
+	value := reflect.ValueOf(GetFieldValue(item, f.Name))
+	for value.Kind() == reflect.Ptr {
+		if value.IsNil() {
+			return ""
+		}
+		value = value.Elem()
+	}
+	if !value.IsValid() {
+		return ""
+	}
+	return fmt.Sprintf("%v", value.Interface())
+}
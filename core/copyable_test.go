@@ -0,0 +1,32 @@
+package core
+
+import "testing"
+
+func TestFieldCopyable(t *testing.T) {
+	email := "ada@example.com"
+	item := &struct {
+		ID           uint
+		ContactEmail *string
+		Nickname     *string
+		Notes        string
+	}{ID: 7, ContactEmail: &email}
+
+	tests := []struct {
+		field    FieldInfo
+		copyable bool
+		text     string
+	}{
+		{FieldInfo{Name: "ID", Type: "uint", PrimaryKey: true}, true, "7"},
+		{FieldInfo{Name: "ContactEmail", Type: "string"}, true, "ada@example.com"},
+		{FieldInfo{Name: "Nickname", Type: "string", Copyable: true}, true, ""},
+		{FieldInfo{Name: "Notes", Type: "string"}, false, ""},
+	}
+	for _, tt := range tests {
+		if got := tt.field.IsCopyable(); got != tt.copyable {
+			t.Errorf("%s: expected copyable %t, got %t", tt.field.Name, tt.copyable, got)
+		}
+		if got := tt.field.CopyText(item); got != tt.text {
+			t.Errorf("%s: expected copy text %q, got %q", tt.field.Name, tt.text, got)
+		}
+	}
+}
@@ -0,0 +1,168 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// WidgetKind selects how a dashboard widget is rendered
+type WidgetKind string
+
+const (
+	WidgetStat   WidgetKind = "stat"   // A single value, the number of matching records by default
+	WidgetRecent WidgetKind = "recent" // The newest records of a resource
+	WidgetCustom WidgetKind = "custom" // A component supplied by the application
+	WidgetChart  WidgetKind = "chart"  // Aggregated records drawn as a chart
+)
+
+// ChartType selects how a chart widget draws its points
+type ChartType string
+
+const (
+	ChartLine ChartType = "line"
+	ChartBar  ChartType = "bar"
+	ChartPie  ChartType = "pie"
+)
+
+// DefaultRecentLimit is the number of records a recent-records widget lists when no limit is given
+const DefaultRecentLimit = 5
+
+// Renderer renders a custom widget; templ components satisfy it
+type Renderer interface {
+	Render(ctx context.Context, w io.Writer) error
+}
+
+// Widget is a card on the dashboard home page
+type Widget struct {
+	Kind      WidgetKind
+	Title     string
+	Resource  string                                    // Counted by stat cards and listed by recent-records widgets
+	Filters   map[string]any                            // Restricts the records a stat card counts
+	Value     func(ctx context.Context) (string, error) // Computes a stat card's value instead of counting records
+	Limit     int                                       // Number of records a recent-records widget lists
+	Component Renderer                                  // Rendered by custom widgets
+	Chart     ChartType                                 // How a chart widget draws its points
+	Aggregate AggregateQuery                            // What a chart widget draws; its Query is built from Filters
+}
+
+// StatCard counts the records of a resource
+func StatCard(title, resource string) Widget {
+	return Widget{Kind: WidgetStat, Title: title, Resource: resource}
+}
+
+// StatCardFunc shows a value computed by the application
+func StatCardFunc(title string, value func(ctx context.Context) (string, error)) Widget {
+	return Widget{Kind: WidgetStat, Title: title, Value: value}
+}
+
+// RecentRecords lists the newest records of a resource
+func RecentRecords(title, resource string, limit int) Widget {
+	return Widget{Kind: WidgetRecent, Title: title, Resource: resource, Limit: limit}
+}
+
+// CustomWidget renders an application component, such as a templ component, as a dashboard card
+func CustomWidget(title string, component Renderer) Widget {
+	return Widget{Kind: WidgetCustom, Title: title, Component: component}
+}
+
+// ChartWidget draws aggregated records of a resource, e.g. signups per week
+func ChartWidget(title, resource string, chart ChartType, aggregate AggregateQuery) Widget {
+	return Widget{Kind: WidgetChart, Title: title, Resource: resource, Chart: chart, Aggregate: aggregate}
+}
+
+// Where restricts the records a widget counts, lists or charts to those matching the filters
+func (w Widget) Where(filters map[string]any) Widget {
+	w.Filters = filters
+	return w
+}
+
+// Query returns the query a stat card counts with or a recent-records widget lists with
+// Recent records are the newest by CreatedAt, or by ID for models without one
+func (w Widget) Query(resource *Resource) *Query {
+	query := NewQuery().WithFilters(w.Filters)
+	if w.Kind != WidgetRecent {
+		return query.WithPagination(1, 0)
+	}
+
+	limit := w.Limit
+	if limit <= 0 {
+		limit = DefaultRecentLimit
+	}
+	if sort := resource.GetEffectiveDefaultSort(); sort.Precedence == SortPrecedenceAutoCreatedAt {
+		query.WithSort(sort.Field, SortDesc)
+	} else {
+		query.WithSort(resource.IDField, SortDesc)
+	}
+	return query.WithPagination(limit, 0)
+}
+
+// ChartQuery returns the aggregation a chart widget draws, restricted to the widget's filters
+func (w Widget) ChartQuery() AggregateQuery {
+	query := w.Aggregate
+	query.Query = NewQuery().WithFilters(w.Filters)
+	return query
+}
+
+// chartError reports an unknown chart type or an invalid aggregation of a chart widget
+func (w Widget) chartError() error {
+	switch w.Chart {
+	case ChartLine, ChartBar, ChartPie:
+	default:
+		return fmt.Errorf("unknown chart type %q", w.Chart)
+	}
+	if w.Aggregate.GroupBy == "" {
+		return errors.New("aggregation needs a field to group by")
+	}
+	return w.Aggregate.Validate()
+}
+
+// Dashboard holds the widgets of the admin home page
+type Dashboard struct {
+	widgets []Widget
+}
+
+// AddWidget appends a widget to the dashboard
+func (d *Dashboard) AddWidget(widget Widget) *Dashboard {
+	d.widgets = append(d.widgets, widget)
+	return d
+}
+
+// Widgets returns the widgets in the order they were added
+func (d *Dashboard) Widgets() []Widget {
+	return d.widgets
+}
+
+// Dashboard returns the dashboard shown on the admin home page
+func (bo *BackOffice) Dashboard() *Dashboard {
+	if bo.dashboard == nil {
+		bo.dashboard = &Dashboard{}
+	}
+	return bo.dashboard
+}
+
+// dashboardErrors reports widgets referring to resources that aren't registered
+func (bo *BackOffice) dashboardErrors() ConfigErrors {
+	if bo.dashboard == nil {
+		return nil
+	}
+	var problems ConfigErrors
+	for _, widget := range bo.dashboard.widgets {
+		if widget.Kind == WidgetCustom || widget.Value != nil {
+			continue
+		}
+		if _, exists := bo.resources[widget.Resource]; !exists {
+			problems = append(problems, &ConfigError{Resource: widget.Resource, Field: "dashboard",
+				Message: fmt.Sprintf("widget %q refers to an unregistered resource", widget.Title)})
+			continue
+		}
+		if widget.Kind == WidgetChart {
+			if err := widget.chartError(); err != nil {
+				problems = append(problems, &ConfigError{Resource: widget.Resource, Field: "dashboard",
+					Message: fmt.Sprintf("chart %q: %v", widget.Title, err)})
+			}
+		}
+	}
+	return problems
+}
@@ -0,0 +1,60 @@
+package core
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/preslavrachev/backoffice/middleware/auth"
+)
+
+type dashboardOrder struct {
+	ID        uint      `db:"id"`
+	Total     float64   `db:"total"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+type dashboardTag struct {
+	ID   uint   `db:"id"`
+	Name string `db:"name"`
+}
+
+func TestWidgetQuery(t *testing.T) {
+	bo := New(&DummyAdapter{}, auth.AuthConfig{})
+	bo.RegisterResource(&dashboardOrder{}).WithFields("Total", "CreatedAt")
+	bo.RegisterResource(&dashboardTag{})
+	orders, _ := bo.GetResource("dashboardOrder")
+	tags, _ := bo.GetResource("dashboardTag")
+
+	query := RecentRecords("Latest orders", "dashboardOrder", 0).Query(orders)
+	if sort := query.GetPrimarySort(); sort == nil || sort.Field != "CreatedAt" || sort.Direction != SortDesc {
+		t.Errorf("Expected recent orders to be sorted by CreatedAt descending, got %+v", sort)
+	}
+	if query.Pagination.Limit != DefaultRecentLimit {
+		t.Errorf("Expected the default limit of %d, got %d", DefaultRecentLimit, query.Pagination.Limit)
+	}
+
+	query = RecentRecords("New tags", "dashboardTag", 3).Query(tags)
+	if sort := query.GetPrimarySort(); sort == nil || sort.Field != "ID" || sort.Direction != SortDesc {
+		t.Errorf("Expected recent tags to be sorted by ID descending, got %+v", sort)
+	}
+
+	query = StatCard("Big orders", "dashboardOrder").Where(map[string]any{"Total": 100.0}).Query(orders)
+	if query.Filters["Total"] != 100.0 || query.Pagination.Limit != 1 {
+		t.Errorf("Expected a filtered single-record count query, got %+v", query)
+	}
+}
+
+func TestDashboard_Validate(t *testing.T) {
+	bo := New(&DummyAdapter{}, auth.AuthConfig{})
+	bo.RegisterResource(&dashboardTag{})
+	bo.Dashboard().
+		AddWidget(StatCard("Tags", "dashboardTag")).
+		AddWidget(StatCard("Orders", "Order"))
+
+	err := bo.Validate()
+	var problems ConfigErrors
+	if !errors.As(err, &problems) || len(problems) != 1 || problems[0].Resource != "Order" {
+		t.Errorf("Expected the widget of the unregistered resource to be reported, got %v", err)
+	}
+}
@@ -0,0 +1,60 @@
+package core
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	DateInputLayout     = "2006-01-02"       // Value format of date inputs
+	DateTimeInputLayout = "2006-01-02T15:04" // Value format of datetime-local inputs
+)
+
+// IsTimeField reports whether the field holds a time.Time or a *time.Time
+func (f FieldInfo) IsTimeField() bool {
+	return strings.TrimPrefix(f.Type, "*") == "time.Time"
+}
+
+// TimeInputValue formats a time field's value for a date or datetime-local input in the given zone
+// Zero and nil times give an empty value
+func TimeInputValue(field FieldInfo, value any, loc *time.Location) string {
+	var t time.Time
+	switch v := value.(type) {
+	case time.Time:
+		t = v
+	case *time.Time:
+		if v == nil {
+			return ""
+		}
+		t = *v
+	default:
+		return ""
+	}
+	if t.IsZero() {
+		return ""
+	}
+	if field.DateOnly {
+		return t.In(loc).Format(DateInputLayout)
+	}
+	return t.In(loc).Format(DateTimeInputLayout)
+}
+
+// ParseTimeInput parses a date or datetime-local input value, which carries no zone, in the given zone
+// RFC 3339 values keep their own offset
+func ParseTimeInput(field FieldInfo, raw string, loc *time.Location) (time.Time, error) {
+	raw = strings.TrimSpace(raw)
+	if t, err := time.Parse(time.RFC3339, raw); err == nil {
+		return t, nil
+	}
+	layouts := []string{DateTimeInputLayout, "2006-01-02T15:04:05", DateInputLayout}
+	if field.DateOnly {
+		layouts = []string{DateInputLayout}
+	}
+	for _, layout := range layouts {
+		if t, err := time.ParseInLocation(layout, raw, loc); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid date %q for %s", raw, field.DisplayName)
+}
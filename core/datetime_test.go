@@ -0,0 +1,54 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/preslavrachev/backoffice/middleware/auth"
+)
+
+func TestTimeInput(t *testing.T) {
+	berlin := time.FixedZone("CEST", 2*60*60)
+	at := time.Date(2026, 10, 5, 22, 30, 0, 0, time.UTC)
+	datetime := FieldInfo{Name: "StartsAt", Type: "time.Time"}
+	date := FieldInfo{Name: "DueOn", Type: "*time.Time", DateOnly: true}
+
+	if got := TimeInputValue(datetime, at, berlin); got != "2026-10-06T00:30" {
+		t.Errorf("Expected the time in the admin's zone, got %q", got)
+	}
+	if got := TimeInputValue(date, &at, berlin); got != "2026-10-06" {
+		t.Errorf("Expected the date in the admin's zone, got %q", got)
+	}
+	if got := TimeInputValue(date, (*time.Time)(nil), berlin); got != "" {
+		t.Errorf("Expected nil times to be empty, got %q", got)
+	}
+	if got := TimeInputValue(datetime, time.Time{}, berlin); got != "" {
+		t.Errorf("Expected zero times to be empty, got %q", got)
+	}
+
+	parsed, err := ParseTimeInput(datetime, "2026-10-06T00:30", berlin)
+	if err != nil || !parsed.Equal(at) {
+		t.Errorf("Expected the input to be read in the admin's zone, got %v, %v", parsed, err)
+	}
+	parsed, err = ParseTimeInput(date, "2026-10-06", berlin)
+	if err != nil || !parsed.Equal(time.Date(2026, 10, 6, 0, 0, 0, 0, berlin)) {
+		t.Errorf("Expected midnight in the admin's zone, got %v, %v", parsed, err)
+	}
+	if _, err := ParseTimeInput(date, "2026-10-06T00:30", berlin); err == nil {
+		t.Error("Expected date fields to reject times")
+	}
+	if _, err := ParseTimeInput(datetime, "tomorrow", berlin); err == nil {
+		t.Error("Expected an error for an invalid date")
+	}
+}
+
+func TestLocation(t *testing.T) {
+	bo := New(&DummyAdapter{}, auth.AuthConfig{})
+	if bo.Location() != time.Local {
+		t.Errorf("Expected the local zone by default, got %v", bo.Location())
+	}
+	bo.GetConfig().TimeZone = time.UTC
+	if bo.Location() != time.UTC {
+		t.Errorf("Expected the configured zone, got %v", bo.Location())
+	}
+}
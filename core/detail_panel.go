@@ -0,0 +1,18 @@
+package core
+
+import "github.com/a-h/templ"
+
+// DetailPanelFunc renders a custom section of a record's detail page, e.g. a live subscription summary
+// The component renders with the request context; returning nil leaves the section out for the record
+type DetailPanelFunc func(item any) templ.Component
+
+// DetailPanelComponents returns the custom sections of a record's detail page, in registration order
+func (r *Resource) DetailPanelComponents(item any) []templ.Component {
+	var components []templ.Component
+	for _, panel := range r.DetailPanels {
+		if component := panel(item); component != nil {
+			components = append(components, component)
+		}
+	}
+	return components
+}
@@ -0,0 +1,26 @@
+package core
+
+// DetailTab is a tab of the record detail page
+type DetailTab string
+
+const (
+	TabOverview DetailTab = "overview" // The record's fields
+	TabRelated  DetailTab = "related"  // Inline child tables and related records
+	TabHistory  DetailTab = "history"  // Previous versions, requires WithHistory
+	TabJSON     DetailTab = "json"     // The record as raw JSON
+)
+
+// Label returns the tab's title
+func (t DetailTab) Label() string {
+	switch t {
+	case TabOverview:
+		return "Overview"
+	case TabRelated:
+		return "Related"
+	case TabHistory:
+		return "History"
+	case TabJSON:
+		return "Raw JSON"
+	}
+	return string(t)
+}
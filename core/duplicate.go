@@ -0,0 +1,39 @@
+package core
+
+import "reflect"
+
+// DuplicateRecord returns a copy of item suitable for pre-filling a create form
+// The primary key, unique fields, slug fields and soft delete marker are cleared
+func DuplicateRecord(resource *Resource, item any) any {
+	src := reflect.ValueOf(item)
+	if src.Kind() == reflect.Ptr {
+		if src.IsNil() {
+			return nil
+		}
+		src = src.Elem()
+	}
+	if src.Kind() != reflect.Struct {
+		return nil
+	}
+
+	dup := reflect.New(src.Type())
+	dup.Elem().Set(src)
+
+	clearField := func(fieldName string) {
+		if field := dup.Elem().FieldByName(fieldName); field.IsValid() && field.CanSet() {
+			field.Set(reflect.Zero(field.Type()))
+		}
+	}
+
+	clearField(resource.IDField)
+	for _, field := range resource.Fields {
+		if field.PrimaryKey || field.Unique || field.SlugSource != "" {
+			clearField(field.Name)
+		}
+	}
+	if resource.SoftDeleteField != "" {
+		clearField(resource.SoftDeleteField)
+	}
+
+	return dup.Interface()
+}
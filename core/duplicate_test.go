@@ -0,0 +1,46 @@
+package core
+
+import (
+	"testing"
+)
+
+func TestDuplicateRecord(t *testing.T) {
+	type Product struct {
+		ID    uint    `db:"id"`
+		Name  string  `db:"name"`
+		SKU   string  `db:"sku"`
+		Slug  string  `db:"slug"`
+		Price float64 `db:"price"`
+	}
+
+	bo := &BackOffice{
+		resources:     make(map[string]*Resource),
+		resourceOrder: []string{},
+		config:        &Config{},
+	}
+	bo.RegisterResource(&Product{}).
+		WithField("Name", func(f *FieldBuilder) {}).
+		WithField("SKU", func(f *FieldBuilder) { f.Unique(true) }).
+		WithField("Slug", func(f *FieldBuilder) { f.AsSlug("Name") }).
+		WithField("Price", func(f *FieldBuilder) {})
+	resource, _ := bo.GetResource("Product")
+
+	original := &Product{ID: 7, Name: "Lamp", SKU: "LMP-1", Slug: "lamp", Price: 19.5}
+	dup, ok := DuplicateRecord(resource, original).(*Product)
+	if !ok {
+		t.Fatal("Expected a *Product copy")
+	}
+
+	if dup == original {
+		t.Error("Expected a new instance")
+	}
+	if dup.ID != 0 || dup.SKU != "" || dup.Slug != "" {
+		t.Errorf("Expected ID, unique and slug fields to be cleared, got %+v", dup)
+	}
+	if dup.Name != "Lamp" || dup.Price != 19.5 {
+		t.Errorf("Expected other fields to be copied, got %+v", dup)
+	}
+	if original.ID != 7 || original.SKU != "LMP-1" {
+		t.Error("Expected the original record to be left untouched")
+	}
+}
@@ -0,0 +1,11 @@
+package core
+
+// EmptyState is shown in place of the list of a resource without any records
+// Searches and filters matching nothing keep the plain "no records found" message
+type EmptyState struct {
+	Title        string // Heading, e.g. "No products yet"
+	Message      string // Explanation below the heading
+	Illustration string // URL of an image shown above the heading
+	ActionLabel  string // Label of the primary call to action, e.g. "Import products"
+	ActionURL    string // Link of the call to action; without it the create button is offered
+}
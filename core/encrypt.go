@@ -0,0 +1,157 @@
+package core
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// encryptedPrefix marks values encrypted by EncryptValue, so plaintext written before a field was
+// encrypted is still read back as is
+const encryptedPrefix = "enc:v1:"
+
+var (
+	// ErrUnknownEncryptionKey is returned for values encrypted with a key the KeyProvider no longer has
+	ErrUnknownEncryptionKey = errors.New("unknown encryption key")
+	// ErrMalformedCiphertext is returned for encrypted values that were truncated or tampered with
+	ErrMalformedCiphertext = errors.New("malformed encrypted value")
+)
+
+// KeyProvider supplies the AES keys of encrypted fields
+// New values are encrypted with the current key; older keys stay available to decrypt values
+// written before a rotation
+type KeyProvider interface {
+	// CurrentKey returns the key new values are encrypted with and its ID, which is stored with each value
+	CurrentKey(ctx context.Context) (id string, key []byte, err error)
+
+	// Key returns the key with the ID, or ErrUnknownEncryptionKey
+	Key(ctx context.Context, id string) ([]byte, error)
+}
+
+// KeyRing is a KeyProvider holding its keys in memory, e.g. loaded from the environment
+type KeyRing struct {
+	current string
+	keys    map[string][]byte
+}
+
+// NewKeyRing creates a KeyProvider encrypting with the key of currentID and decrypting with any of the keys
+// Keys must be 16, 24 or 32 bytes long, for AES-128, AES-192 or AES-256; IDs may not contain ':'
+// To rotate, add a new key, make it current and keep the old ones until ReencryptAll has run
+func NewKeyRing(currentID string, keys map[string][]byte) (*KeyRing, error) {
+	if _, ok := keys[currentID]; !ok {
+		return nil, fmt.Errorf("current key %q: %w", currentID, ErrUnknownEncryptionKey)
+	}
+	for id, key := range keys {
+		if id == "" || strings.Contains(id, ":") {
+			return nil, fmt.Errorf("invalid encryption key ID %q", id)
+		}
+		if _, err := aes.NewCipher(key); err != nil {
+			return nil, fmt.Errorf("encryption key %q: %w", id, err)
+		}
+	}
+	return &KeyRing{current: currentID, keys: keys}, nil
+}
+
+// CurrentKey returns the key new values are encrypted with
+func (k *KeyRing) CurrentKey(ctx context.Context) (string, []byte, error) {
+	return k.current, k.keys[k.current], nil
+}
+
+// Key returns the key with the ID
+func (k *KeyRing) Key(ctx context.Context, id string) ([]byte, error) {
+	key, ok := k.keys[id]
+	if !ok {
+		return nil, fmt.Errorf("key %q: %w", id, ErrUnknownEncryptionKey)
+	}
+	return key, nil
+}
+
+// IsEncrypted reports whether the field's values are encrypted at rest
+func (f *FieldInfo) IsEncrypted() bool {
+	return f.Encryption != nil
+}
+
+// EncryptValue encrypts a value of the field with AES-GCM under the current key
+// Empty values are stored as is, so "not set" stays distinguishable without decrypting
+func (f *FieldInfo) EncryptValue(ctx context.Context, plaintext string) (string, error) {
+	if f.Encryption == nil || plaintext == "" {
+		return plaintext, nil
+	}
+	id, key, err := f.Encryption.CurrentKey(ctx)
+	if err != nil {
+		return "", err
+	}
+	gcm, err := newGCM(key)
+	if err != nil {
+		return "", err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+	// The field name is authenticated, so a value copied into another column fails to decrypt
+	sealed := gcm.Seal(nonce, nonce, []byte(plaintext), []byte(f.Name))
+	return encryptedPrefix + id + ":" + base64.RawStdEncoding.EncodeToString(sealed), nil
+}
+
+// DecryptValue decrypts a value of the field stored by EncryptValue
+// Values without the encryption prefix are returned unchanged, e.g. those written before the field was encrypted
+func (f *FieldInfo) DecryptValue(ctx context.Context, stored string) (string, error) {
+	id, data, ok := parseEncryptedValue(stored)
+	if f.Encryption == nil || !ok {
+		return stored, nil
+	}
+	key, err := f.Encryption.Key(ctx, id)
+	if err != nil {
+		return "", err
+	}
+	gcm, err := newGCM(key)
+	if err != nil {
+		return "", err
+	}
+	sealed, err := base64.RawStdEncoding.DecodeString(data)
+	if err != nil || len(sealed) < gcm.NonceSize() {
+		return "", ErrMalformedCiphertext
+	}
+	plaintext, err := gcm.Open(nil, sealed[:gcm.NonceSize()], sealed[gcm.NonceSize():], []byte(f.Name))
+	if err != nil {
+		return "", ErrMalformedCiphertext
+	}
+	return string(plaintext), nil
+}
+
+// NeedsReencryption reports whether a stored value of the field is plaintext or encrypted with a
+// key other than the current one
+func (f *FieldInfo) NeedsReencryption(ctx context.Context, stored string) (bool, error) {
+	if f.Encryption == nil || stored == "" {
+		return false, nil
+	}
+	current, _, err := f.Encryption.CurrentKey(ctx)
+	if err != nil {
+		return false, err
+	}
+	id, _, ok := parseEncryptedValue(stored)
+	return !ok || id != current, nil
+}
+
+// parseEncryptedValue splits a stored value into its key ID and encoded ciphertext
+func parseEncryptedValue(stored string) (id, data string, ok bool) {
+	rest, found := strings.CutPrefix(stored, encryptedPrefix)
+	if !found {
+		return "", "", false
+	}
+	return strings.Cut(rest, ":")
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
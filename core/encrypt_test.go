@@ -0,0 +1,66 @@
+package core
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestEncryptValue(t *testing.T) {
+	ctx := context.Background()
+	keys, err := NewKeyRing("k1", map[string][]byte{"k1": bytes.Repeat([]byte{7}, 32)})
+	if err != nil {
+		t.Fatalf("NewKeyRing failed: %v", err)
+	}
+	field := &FieldInfo{Name: "APIKey", Encryption: keys}
+
+	first, err := field.EncryptValue(ctx, "secret")
+	if err != nil {
+		t.Fatalf("EncryptValue failed: %v", err)
+	}
+	second, _ := field.EncryptValue(ctx, "secret")
+	if first == second {
+		t.Error("Expected a fresh nonce per value")
+	}
+	if plaintext, err := field.DecryptValue(ctx, first); err != nil || plaintext != "secret" {
+		t.Errorf("DecryptValue = %q, %v; want secret", plaintext, err)
+	}
+
+	if empty, _ := field.EncryptValue(ctx, ""); empty != "" {
+		t.Errorf("Expected empty values to stay empty, got %q", empty)
+	}
+	if plaintext, _ := field.DecryptValue(ctx, "legacy"); plaintext != "legacy" {
+		t.Errorf("Expected plaintext values to pass through, got %q", plaintext)
+	}
+
+	// Values are bound to their field
+	other := &FieldInfo{Name: "Password", Encryption: keys}
+	if _, err := other.DecryptValue(ctx, first); !errors.Is(err, ErrMalformedCiphertext) {
+		t.Errorf("Expected a value moved to another field to fail, got %v", err)
+	}
+	if _, err := field.DecryptValue(ctx, first[:len(first)-4]); !errors.Is(err, ErrMalformedCiphertext) {
+		t.Errorf("Expected a truncated value to fail, got %v", err)
+	}
+
+	retired, _ := NewKeyRing("k2", map[string][]byte{"k2": bytes.Repeat([]byte{8}, 32)})
+	field.Encryption = retired
+	if _, err := field.DecryptValue(ctx, first); !errors.Is(err, ErrUnknownEncryptionKey) {
+		t.Errorf("Expected an unknown key error, got %v", err)
+	}
+	if needed, _ := field.NeedsReencryption(ctx, first); !needed {
+		t.Error("Expected a value under an old key to need re-encryption")
+	}
+}
+
+func TestNewKeyRingValidation(t *testing.T) {
+	if _, err := NewKeyRing("missing", map[string][]byte{"k1": make([]byte, 32)}); err == nil {
+		t.Error("Expected an error for a missing current key")
+	}
+	if _, err := NewKeyRing("k1", map[string][]byte{"k1": make([]byte, 10)}); err == nil {
+		t.Error("Expected an error for an invalid key size")
+	}
+	if _, err := NewKeyRing("a:b", map[string][]byte{"a:b": make([]byte, 32)}); err == nil {
+		t.Error("Expected an error for a key ID containing ':'")
+	}
+}
@@ -0,0 +1,266 @@
+package core
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"slices"
+	"time"
+)
+
+// ExportFormat is a file format records can be exported in
+type ExportFormat string
+
+const (
+	ExportCSV  ExportFormat = "csv"
+	ExportJSON ExportFormat = "json"
+	ExportXLSX ExportFormat = "xlsx"
+)
+
+// MaxExportRecords caps how many records a single export may contain
+const MaxExportRecords = 10000
+
+// ExportConfig controls how a resource is exported
+type ExportConfig struct {
+	Fields   []string       // Fields to export in order, including derived ones; empty for all fields
+	Filename string         // File name without extension, defaults to the resource's plural name
+	Formats  []ExportFormat // Allowed formats, defaults to CSV, JSON and XLSX
+}
+
+// CanExport reports whether the resource can be exported in the given format
+func (r *Resource) CanExport(format ExportFormat) bool {
+	return r.Export != nil && slices.Contains(r.ExportFormats(), format)
+}
+
+// ExportFormats returns the formats the resource can be exported in
+func (r *Resource) ExportFormats() []ExportFormat {
+	if r.Export == nil {
+		return nil
+	}
+	if len(r.Export.Formats) == 0 {
+		return []ExportFormat{ExportCSV, ExportJSON, ExportXLSX}
+	}
+	return r.Export.Formats
+}
+
+// ExportFilename returns the download file name for the given format
+func (r *Resource) ExportFilename(format ExportFormat) string {
+	name := r.PluralName
+	if r.Export != nil && r.Export.Filename != "" {
+		name = r.Export.Filename
+	}
+	return fmt.Sprintf("%s.%s", name, format)
+}
+
+// ExportFields returns the fields included in an export, in order
+func (r *Resource) ExportFields() ([]FieldInfo, error) {
+	if r.Export == nil || len(r.Export.Fields) == 0 {
+		return r.Fields, nil
+	}
+
+	fields := make([]FieldInfo, 0, len(r.Export.Fields))
+	for _, name := range r.Export.Fields {
+		field, ok := r.GetField(name)
+		if !ok {
+			return nil, fmt.Errorf("unknown export field %s", name)
+		}
+		fields = append(fields, *field)
+	}
+	return fields, nil
+}
+
+// FindExportRecords returns all records matching the query, up to MaxExportRecords
+func FindExportRecords(ctx context.Context, adapter Adapter, resource *Resource, query *Query) ([]any, error) {
+	var items []any
+	pageQuery := query.WithPagination(MaxPageSize, 0)
+
+	for {
+		result, err := adapter.Find(ctx, resource, pageQuery)
+		if err != nil {
+			return nil, fmt.Errorf("failed to export %s: %w", resource.PluralName, err)
+		}
+		items = append(items, result.Items...)
+		if len(items) > MaxExportRecords {
+			return nil, fmt.Errorf("export exceeds the limit of %d records", MaxExportRecords)
+		}
+		if !result.HasMore || len(result.Items) == 0 {
+			return items, nil
+		}
+		pageQuery = pageQuery.NextPage()
+	}
+}
+
+// StreamRecords calls fn for every record matching the query, in the query's order
+// Adapters implementing Streamer iterate in one pass; others are read page by page through Find
+func StreamRecords(ctx context.Context, adapter Adapter, resource *Resource, query *Query, fn func(item any) error) error {
+	if streamer, ok := adapter.(Streamer); ok {
+		return streamer.Stream(ctx, resource, query, fn)
+	}
+
+	pageQuery := query.WithPagination(MaxPageSize, 0)
+	for {
+		result, err := adapter.Find(ctx, resource, pageQuery)
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", resource.PluralName, err)
+		}
+		for _, item := range result.Items {
+			if err := fn(item); err != nil {
+				return err
+			}
+		}
+		if !result.HasMore || len(result.Items) == 0 {
+			return nil
+		}
+		pageQuery = pageQuery.NextPage()
+	}
+}
+
+// StreamCSV writes every record matching the query as CSV, one row at a time, using the resource's export fields
+// Unlike FindExportRecords it holds no more than a page of records and has no record limit
+func StreamCSV(ctx context.Context, w io.Writer, adapter Adapter, resource *Resource, query *Query) error {
+	fields, err := resource.ExportFields()
+	if err != nil {
+		return err
+	}
+	fields = resource.revealExportFields(ctx, fields)
+
+	writer := csv.NewWriter(w)
+	if err := writer.Write(csvHeader(fields)); err != nil {
+		return err
+	}
+	err = StreamRecords(ctx, adapter, resource, query, func(item any) error {
+		return writer.Write(csvRow(ctx, resource, fields, item))
+	})
+	writer.Flush()
+	if err != nil {
+		return err
+	}
+	return writer.Error()
+}
+
+// WriteExport writes the items in the given format, using the resource's export fields
+func WriteExport(ctx context.Context, w io.Writer, resource *Resource, items []any, format ExportFormat) error {
+	fields, err := resource.ExportFields()
+	if err != nil {
+		return err
+	}
+	fields = resource.revealExportFields(ctx, fields)
+
+	switch format {
+	case ExportCSV:
+		return writeCSVExport(ctx, w, resource, fields, items)
+	case ExportJSON:
+		return writeJSONExport(ctx, w, resource, fields, items)
+	case ExportXLSX:
+		return writeXLSXExport(ctx, w, resource, fields, items)
+	default:
+		return fmt.Errorf("unsupported export format %q", format)
+	}
+}
+
+func writeCSVExport(ctx context.Context, w io.Writer, resource *Resource, fields []FieldInfo, items []any) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(csvHeader(fields)); err != nil {
+		return err
+	}
+	for _, item := range items {
+		if err := writer.Write(csvRow(ctx, resource, fields, item)); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+// csvHeader returns the CSV header row of the exported fields
+func csvHeader(fields []FieldInfo) []string {
+	header := make([]string, len(fields))
+	for i, field := range fields {
+		header[i] = field.DisplayName
+	}
+	return header
+}
+
+// csvRow returns the CSV cells of a record, evaluating derived fields
+func csvRow(ctx context.Context, resource *Resource, fields []FieldInfo, item any) []string {
+	row := make([]string, len(fields))
+	for i := range fields {
+		row[i] = exportString(fields[i], exportValue(ctx, item, &fields[i], resource))
+	}
+	return row
+}
+
+func writeJSONExport(ctx context.Context, w io.Writer, resource *Resource, fields []FieldInfo, items []any) error {
+	records := make([]map[string]any, 0, len(items))
+	for _, item := range items {
+		records = append(records, exportRecord(ctx, resource, fields, item))
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(records)
+}
+
+// exportRecord maps the fields of a record to their raw values, keyed by JSON name
+func exportRecord(ctx context.Context, resource *Resource, fields []FieldInfo, item any) map[string]any {
+	record := make(map[string]any, len(fields))
+	for i, field := range fields {
+		key := field.JSONName
+		if key == "" {
+			key = field.Name
+		}
+		record[key] = exportValue(ctx, item, &fields[i], resource)
+	}
+	return record
+}
+
+// RecordJSON returns the registered fields of a record as indented JSON, as shown on the detail page
+func RecordJSON(ctx context.Context, resource *Resource, item any) (string, error) {
+	data, err := json.MarshalIndent(exportRecord(ctx, resource, resource.Fields, item), "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// exportValue returns the raw value of a field, evaluating derived fields and dereferencing pointers
+// Masked fields export their redacted text
+func exportValue(ctx context.Context, item any, field *FieldInfo, resource *Resource) any {
+	value := GetFieldValueWithResourceCtx(ctx, item, field, resource)
+	if value == nil {
+		return nil
+	}
+	if val := reflect.ValueOf(value); val.Kind() == reflect.Ptr {
+		if val.IsNil() {
+			return nil
+		}
+		value = val.Elem().Interface()
+	}
+	if field.IsMasked(ctx) {
+		return MaskValue(exportString(*field, value))
+	}
+	return value
+}
+
+// exportString converts an exported value to a CSV cell
+func exportString(field FieldInfo, value any) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case time.Time:
+		if v.IsZero() {
+			return ""
+		}
+		return v.Format(time.RFC3339)
+	}
+	if field.IsComputed && field.TypedComputeFunc != nil {
+		return FormatComputedValue(value, field.ComputedKind)
+	}
+	return fmt.Sprintf("%v", value)
+}
@@ -0,0 +1,126 @@
+package core
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/preslavrachev/backoffice/middleware/auth"
+)
+
+type Invoice struct {
+	ID       uint    `db:"id"`
+	Customer string  `db:"customer"`
+	Amount   float64 `db:"amount"`
+	Note     *string `db:"note"`
+}
+
+func newExportTestResource(config ExportConfig) *Resource {
+	bo := New(&DummyAdapter{}, auth.AuthConfig{})
+	bo.RegisterResource(&Invoice{}).
+		WithFields("Customer", "Amount", "Note").
+		WithTypedDerivedField("Total", "Total", ComputedNumber, func(item any) any {
+			return item.(*Invoice).Amount * 1.2
+		}).
+		WithExport(config)
+	resource, _ := bo.GetResource("Invoice")
+	return resource
+}
+
+func TestWriteExport_CSV(t *testing.T) {
+	resource := newExportTestResource(ExportConfig{Fields: []string{"Customer", "Total", "Note"}})
+	note := "paid"
+	items := []any{
+		&Invoice{ID: 1, Customer: "Acme, Inc.", Amount: 10, Note: &note},
+		&Invoice{ID: 2, Customer: "Globex", Amount: 5},
+	}
+
+	var buf bytes.Buffer
+	if err := WriteExport(context.Background(), &buf, resource, items, ExportCSV); err != nil {
+		t.Fatalf("WriteExport failed: %v", err)
+	}
+
+	expected := "Customer,Total,Note\n\"Acme, Inc.\",12,paid\nGlobex,6,\n"
+	if buf.String() != expected {
+		t.Errorf("Expected CSV:\n%s\ngot:\n%s", expected, buf.String())
+	}
+}
+
+// pagedInvoiceAdapter serves invoices a page at a time, like an adapter without Streamer
+type pagedInvoiceAdapter struct {
+	DummyAdapter
+	invoices []any
+	finds    int
+}
+
+func (a *pagedInvoiceAdapter) Find(ctx context.Context, resource *Resource, query *Query) (*Result, error) {
+	a.finds++
+	start := min(query.Pagination.Offset, len(a.invoices))
+	end := min(start+query.Pagination.Limit, len(a.invoices))
+	return &Result{Items: a.invoices[start:end], TotalCount: int64(len(a.invoices)), HasMore: end < len(a.invoices)}, nil
+}
+
+func TestStreamCSV(t *testing.T) {
+	resource := newExportTestResource(ExportConfig{Fields: []string{"Customer", "Total"}})
+	adapter := &pagedInvoiceAdapter{}
+	for i := 0; i < MaxPageSize+1; i++ {
+		adapter.invoices = append(adapter.invoices, &Invoice{ID: uint(i + 1), Customer: "Acme", Amount: 10})
+	}
+
+	var buf bytes.Buffer
+	if err := StreamCSV(context.Background(), &buf, adapter, resource, NewQuery().WithPagination(5, 0)); err != nil {
+		t.Fatalf("StreamCSV failed: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	if lines[0] != "Customer,Total" || lines[1] != "Acme,12" {
+		t.Errorf("Expected the export fields with derived values, got %q", lines[:2])
+	}
+	if len(lines) != MaxPageSize+2 {
+		t.Errorf("Expected every record regardless of the list page, got %d rows", len(lines)-1)
+	}
+	if adapter.finds != 2 {
+		t.Errorf("Expected records to be read page by page, got %d finds", adapter.finds)
+	}
+}
+
+func TestWriteExport_JSON(t *testing.T) {
+	resource := newExportTestResource(ExportConfig{Fields: []string{"Customer", "Amount"}})
+
+	var buf bytes.Buffer
+	if err := WriteExport(context.Background(), &buf, resource, []any{&Invoice{ID: 1, Customer: "Acme", Amount: 10}}, ExportJSON); err != nil {
+		t.Fatalf("WriteExport failed: %v", err)
+	}
+
+	var records []map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &records); err != nil {
+		t.Fatalf("Invalid JSON export: %v", err)
+	}
+	if len(records) != 1 || records[0]["Customer"] != "Acme" || records[0]["Amount"] != 10.0 || len(records[0]) != 2 {
+		t.Errorf("Unexpected JSON export: %v", records)
+	}
+}
+
+func TestExportConfig(t *testing.T) {
+	resource := newExportTestResource(ExportConfig{Filename: "invoices-2026", Formats: []ExportFormat{ExportCSV}})
+
+	if !resource.CanExport(ExportCSV) || resource.CanExport(ExportJSON) {
+		t.Error("Expected only CSV exports to be allowed")
+	}
+	if got := resource.ExportFilename(ExportCSV); got != "invoices-2026.csv" {
+		t.Errorf("Expected filename invoices-2026.csv, got %s", got)
+	}
+
+	resource.Export.Fields = []string{"Missing"}
+	if err := WriteExport(context.Background(), &bytes.Buffer{}, resource, nil, ExportCSV); err == nil || !strings.Contains(err.Error(), "Missing") {
+		t.Errorf("Expected an unknown field error, got %v", err)
+	}
+
+	plain := newExportTestResource(ExportConfig{})
+	plain.Export = nil
+	if plain.CanExport(ExportCSV) {
+		t.Error("Expected exports to be disabled without WithExport")
+	}
+}
@@ -1,5 +1,14 @@
 package core
 
+import (
+	"context"
+	"reflect"
+	"time"
+
+	"github.com/a-h/templ"
+	"github.com/preslavrachev/backoffice/middleware/auth"
+)
+
 // RelationshipType defines the type of relationship
 type RelationshipType string
 
@@ -18,18 +27,50 @@ const (
 	RenderHTML     FieldRenderer = "html"     // Strip HTML tags, show preview
 	RenderRichText FieldRenderer = "richtext" // Show formatted preview
 	RenderMarkdown FieldRenderer = "markdown" // Render markdown preview
+	RenderImage    FieldRenderer = "image"    // Show the image URL as a thumbnail
 )
 
 // ComputeFunc is a function type for computing field values dynamically
 type ComputeFunc func(any) string
 
+// ComputeFuncCtx computes a field value with the request context, so derived fields can
+// respect deadlines, read the authenticated user or use request-scoped loaders
+type ComputeFuncCtx func(ctx context.Context, item any) string
+
+// FormatFunc formats a stored field value for display, e.g. bytes as "1.2 MB"
+type FormatFunc func(value any) string
+
+// CellRenderer renders a record's list cell for a field, e.g. as a progress bar
+// Sorting, filtering and exports keep using the field's underlying value
+type CellRenderer func(item any) templ.Component
+
+// ComputedKind declares the kind of value returned by a TypedComputeFunc
+type ComputedKind string
+
+const (
+	ComputedString ComputedKind = "string"
+	ComputedNumber ComputedKind = "number"
+	ComputedBool   ComputedKind = "bool"
+	ComputedTime   ComputedKind = "time"
+)
+
+// TypedComputeFunc computes a field value of a declared ComputedKind
+// Number kinds may return any integer or float type, time kinds return time.Time
+type TypedComputeFunc func(any) any
+
 // RelationshipInfo holds metadata about field relationships
 type RelationshipInfo struct {
-	Type           RelationshipType `json:"type"`
-	RelatedModel   string           `json:"related_model"`
-	DisplayField   string           `json:"display_field"`
-	ForeignKey     string           `json:"foreign_key"`
-	DisplayPattern string           `json:"display_pattern"` // "compact", "badge", "hierarchical"
+	Type                  RelationshipType        `json:"type"`
+	RelatedModel          string                  `json:"related_model"`
+	DisplayField          string                  `json:"display_field"`
+	ForeignKey            string                  `json:"foreign_key"`
+	DisplayPattern        string                  `json:"display_pattern"`                   // "compact", "badge", "hierarchical"
+	FallbackDisplayFields []string                `json:"fallback_display_fields,omitempty"` // Tried in order when DisplayField is empty
+	DisplayFunc           RelationshipDisplayFunc `json:"-"`                                 // Formats the related record, overriding display fields
+	LinkMode              RelatedLinkMode         `json:"link_mode,omitempty"`               // How one-to-many relationships link to their children
+	JoinTable             string                  `json:"join_table,omitempty"`              // Table linking many-to-many records
+	JoinForeignKey        string                  `json:"join_foreign_key,omitempty"`        // Join table column holding this record's ID
+	JoinRelatedKey        string                  `json:"join_related_key,omitempty"`        // Join table column holding the related record's ID
 }
 
 // FieldInfo represents metadata about a struct field
@@ -42,17 +83,43 @@ type FieldInfo struct {
 	Required         bool              `json:"required"`
 	ReadOnly         bool              `json:"read_only"`
 	Searchable       bool              `json:"searchable"`
+	ListEditable     bool              `json:"list_editable"` // Editable in place by double-clicking its list cell
 	Unique           bool              `json:"unique"`
 	PrimaryKey       bool              `json:"primary_key"`
 	Choices          []string          `json:"choices,omitempty"`
+	ChoiceColors     map[string]string `json:"choice_colors,omitempty"` // Badge color per value, e.g. "published": StatusGreen
+	DependsOn        string            `json:"depends_on,omitempty"`    // Parent field whose value restricts the choices
+	ChoicesFunc      ChoicesFunc       `json:"-"`
 	DefaultVal       any               `json:"default_value,omitempty"`
 	Relationship     *RelationshipInfo `json:"relationship,omitempty"`
 	IsComputed       bool              `json:"is_computed"`
 	ComputeFunc      ComputeFunc       `json:"-"`
+	ComputeFuncCtx   ComputeFuncCtx    `json:"-"`
 	SortFields       []SortField       `json:"sort_fields,omitempty"`
 	IsSortable       bool              `json:"is_sortable"`
 	RenderAs         FieldRenderer     `json:"render_as,omitempty"`
 	MaxPreviewLength int               `json:"max_preview_length,omitempty"`
+	CodeLanguage     CodeLanguage      `json:"code_language,omitempty"` // Edited in the code editor when set
+	DateOnly         bool              `json:"date_only,omitempty"`     // Time fields edited as a calendar date
+	RelativeTime     bool              `json:"relative_time,omitempty"` // Time values shown as "3 hours ago" with the exact time in a tooltip
+	SlugSource       string            `json:"slug_source,omitempty"`
+	ComputedKind     ComputedKind      `json:"computed_kind,omitempty"`
+	TypedComputeFunc TypedComputeFunc  `json:"-"`
+	FormatFunc       FormatFunc        `json:"-"`
+	StatusFunc       StatusFunc        `json:"-"`
+	CellRenderer     CellRenderer      `json:"-"`                     // Custom markup of the field's list cells
+	HelpText         string            `json:"help_text,omitempty"`   // Shown under form inputs and as a tooltip in list headers
+	Placeholder      string            `json:"placeholder,omitempty"` // Shown inside empty form inputs
+	Min              *float64          `json:"min,omitempty"`         // Smallest allowed value of a number field
+	Max              *float64          `json:"max,omitempty"`         // Largest allowed value of a number field
+	Pattern          string            `json:"pattern,omitempty"`     // Regular expression a text value must match as a whole
+	MaxLength        int               `json:"max_length,omitempty"`  // Most characters a text value may have
+	Copyable         bool              `json:"copyable,omitempty"`    // Values get a copy-to-clipboard button in lists and details
+	Summary          AggregateFunc     `json:"summary,omitempty"`     // Aggregate shown in the list footer over the filtered records
+	Masked           bool              `json:"masked,omitempty"`      // Values are partially redacted, see IsMasked
+	MaskRoles        []auth.Role       `json:"mask_roles,omitempty"`  // Roles values are redacted for, everyone when empty
+	Encryption       KeyProvider       `json:"-"`                     // Encrypts values at rest when set, see EncryptValue
+	FileStore        FileStore         `json:"-"`                     // Values are keys of files downloaded through signed URLs, see FileURL
 }
 
 // FieldConfig holds configuration for a field
@@ -62,17 +129,45 @@ type FieldConfig struct {
 	Required         bool
 	ReadOnly         bool
 	Searchable       bool
+	ListEditable     bool
 	Unique           bool
 	PrimaryKey       bool
 	Choices          []string
+	ChoiceColors     map[string]string
+	DependsOn        string
+	ChoicesFunc      ChoicesFunc
 	DefaultVal       any
 	Relationship     *RelationshipInfo
 	IsComputed       bool
 	ComputeFunc      ComputeFunc
+	ComputeFuncCtx   ComputeFuncCtx
 	SortFields       []SortField `json:"sort_fields,omitempty"`
 	IsSortable       bool        `json:"is_sortable"`
 	RenderAs         FieldRenderer
 	MaxPreviewLength int
+	CodeLanguage     CodeLanguage
+	DateOnly         bool
+	RelativeTime     bool
+	SlugSource       string
+	ComputedKind     ComputedKind
+	TypedComputeFunc TypedComputeFunc
+	FormatFunc       FormatFunc
+	StatusFunc       StatusFunc
+	CellRenderer     CellRenderer
+	HelpText         string
+	Placeholder      string
+	Min              *float64
+	Max              *float64
+	Pattern          string
+	MaxLength        int
+	Copyable         bool
+	Summary          AggregateFunc
+	Masked           bool
+	MaskRoles        []auth.Role
+	Encryption       KeyProvider
+	FileStore        FileStore
+	CacheTTL         time.Duration
+	CacheVersion     string
 }
 
 // Apply applies the configuration to a FieldInfo
@@ -86,11 +181,19 @@ func (fc *FieldConfig) Apply(info *FieldInfo) {
 	info.Required = fc.Required
 	info.ReadOnly = fc.ReadOnly
 	info.Searchable = fc.Searchable
+	info.ListEditable = fc.ListEditable
 	info.Unique = fc.Unique
 	info.PrimaryKey = fc.PrimaryKey
 	if len(fc.Choices) > 0 {
 		info.Choices = fc.Choices
 	}
+	if len(fc.ChoiceColors) > 0 {
+		info.ChoiceColors = fc.ChoiceColors
+	}
+	if fc.ChoicesFunc != nil {
+		info.DependsOn = fc.DependsOn
+		info.ChoicesFunc = fc.ChoicesFunc
+	}
 	if fc.DefaultVal != nil {
 		info.DefaultVal = fc.DefaultVal
 	}
@@ -99,6 +202,7 @@ func (fc *FieldConfig) Apply(info *FieldInfo) {
 	}
 	info.IsComputed = fc.IsComputed
 	info.ComputeFunc = fc.ComputeFunc
+	info.ComputeFuncCtx = fc.ComputeFuncCtx
 	if len(fc.SortFields) > 0 {
 		info.SortFields = fc.SortFields
 	}
@@ -109,6 +213,45 @@ func (fc *FieldConfig) Apply(info *FieldInfo) {
 	if fc.MaxPreviewLength > 0 {
 		info.MaxPreviewLength = fc.MaxPreviewLength
 	}
+	if fc.CodeLanguage != "" {
+		info.CodeLanguage = fc.CodeLanguage
+	}
+	info.DateOnly = fc.DateOnly
+	info.RelativeTime = fc.RelativeTime
+	if fc.SlugSource != "" {
+		info.SlugSource = fc.SlugSource
+	}
+	if fc.ComputedKind != "" {
+		info.ComputedKind = fc.ComputedKind
+	}
+	if fc.TypedComputeFunc != nil {
+		info.TypedComputeFunc = fc.TypedComputeFunc
+	}
+	if fc.FormatFunc != nil {
+		info.FormatFunc = fc.FormatFunc
+	}
+	if fc.StatusFunc != nil {
+		info.StatusFunc = fc.StatusFunc
+	}
+	if fc.CellRenderer != nil {
+		info.CellRenderer = fc.CellRenderer
+	}
+	if fc.HelpText != "" {
+		info.HelpText = fc.HelpText
+	}
+	if fc.Placeholder != "" {
+		info.Placeholder = fc.Placeholder
+	}
+	info.Min = fc.Min
+	info.Max = fc.Max
+	info.Pattern = fc.Pattern
+	info.MaxLength = fc.MaxLength
+	info.Copyable = fc.Copyable
+	info.Summary = fc.Summary
+	info.Masked = fc.Masked
+	info.MaskRoles = fc.MaskRoles
+	info.Encryption = fc.Encryption
+	info.FileStore = fc.FileStore
 }
 
 // FieldBuilder provides fluent API for configuring fields
@@ -153,6 +296,12 @@ func (fb *FieldBuilder) Searchable(searchable bool) *FieldBuilder {
 	return fb
 }
 
+// EditableInList lets the field be edited in place by double-clicking its cell in the list
+func (fb *FieldBuilder) EditableInList() *FieldBuilder {
+	fb.config.ListEditable = true
+	return fb
+}
+
 // Unique marks the field as unique
 func (fb *FieldBuilder) Unique(unique bool) *FieldBuilder {
 	fb.config.Unique = unique
@@ -171,6 +320,28 @@ func (fb *FieldBuilder) Choices(choices []string) *FieldBuilder {
 	return fb
 }
 
+// ChoiceColors renders the field's values as badges colored per value in lists, detail pages and filters
+// e.g. ChoiceColors(map[string]string{"draft": core.StatusGray, "published": core.StatusGreen})
+func (fb *FieldBuilder) ChoiceColors(colors map[string]string) *FieldBuilder {
+	fb.config.ChoiceColors = colors
+	return fb
+}
+
+// DependsOn restricts the field's choices by the value of a parent field
+// e.g. DependsOn("Country", map[string][]string{"US": {"CA", "NY"}, "DE": {"BE", "BY"}})
+func (fb *FieldBuilder) DependsOn(parentField string, choices map[string][]string) *FieldBuilder {
+	return fb.DependsOnFunc(parentField, func(ctx context.Context, parentValue string) ([]string, error) {
+		return choices[parentValue], nil
+	})
+}
+
+// DependsOnFunc restricts the field's choices by the value of a parent field, loading them on demand
+func (fb *FieldBuilder) DependsOnFunc(parentField string, fn ChoicesFunc) *FieldBuilder {
+	fb.config.DependsOn = parentField
+	fb.config.ChoicesFunc = fn
+	return fb
+}
+
 // Default sets the default value for the field
 func (fb *FieldBuilder) Default(value any) *FieldBuilder {
 	fb.config.DefaultVal = value
@@ -236,13 +407,172 @@ func (fb *FieldBuilder) RenderAsMarkdown() *FieldBuilder {
 	return fb
 }
 
+// RenderAsImage shows the field's image URL as a thumbnail in lists and an enlargeable preview on detail pages
+func (fb *FieldBuilder) RenderAsImage() *FieldBuilder {
+	fb.config.RenderAs = RenderImage
+	return fb
+}
+
+// AsCode edits the field in a code editor with syntax highlighting and linting, e.g. AsCode(core.CodeJSON)
+// JSON values are also checked when saved
+func (fb *FieldBuilder) AsCode(language CodeLanguage) *FieldBuilder {
+	fb.config.CodeLanguage = language
+	return fb
+}
+
+// AsDate edits a time field with a date picker instead of a date and time picker
+func (fb *FieldBuilder) AsDate() *FieldBuilder {
+	fb.config.DateOnly = true
+	return fb
+}
+
+// AsRelativeTime shows a time field's values as "3 hours ago" or "Jan 2, 2025" in lists and details,
+// with the exact time and zone in a tooltip
+func (fb *FieldBuilder) AsRelativeTime() *FieldBuilder {
+	fb.config.RelativeTime = true
+	return fb
+}
+
+// AsMarkdown edits the field in a markdown editor with a live preview and renders it on detail pages
+func (fb *FieldBuilder) AsMarkdown() *FieldBuilder {
+	return fb.RenderAsMarkdown()
+}
+
 // MaxPreviewLength sets the maximum length for field preview in list views
 func (fb *FieldBuilder) MaxPreviewLength(length int) *FieldBuilder {
 	fb.config.MaxPreviewLength = length
 	return fb
 }
 
+// AsSlug marks the field as a slug that is auto-generated from sourceField on create
+// The slug stays editable afterwards; generated slugs get a numeric suffix when taken
+func (fb *FieldBuilder) AsSlug(sourceField string) *FieldBuilder {
+	fb.config.SlugSource = sourceField
+	return fb
+}
+
+// Cache memoizes a derived field's computed value per record for the given TTL
+// Cached values are invalidated early when the record's UpdatedAt field changes
+// Values of context-aware fields (WithDerivedFieldCtx) are cached per user and roles
+func (fb *FieldBuilder) Cache(ttl time.Duration) *FieldBuilder {
+	fb.config.CacheTTL = ttl
+	return fb
+}
+
+// CacheVersionField sets the field used to detect record changes for cached values (default: UpdatedAt)
+func (fb *FieldBuilder) CacheVersionField(fieldName string) *FieldBuilder {
+	fb.config.CacheVersion = fieldName
+	return fb
+}
+
+// Format sets a display formatter for a stored field, leaving its value and form input untouched
+func (fb *FieldBuilder) Format(fn FormatFunc) *FieldBuilder {
+	fb.config.FormatFunc = fn
+	return fb
+}
+
+// RenderWith renders the field's list cells with a custom component, e.g. a progress bar or a sparkline
+// Sorting and exports still use the field's underlying value
+func (fb *FieldBuilder) RenderWith(renderer CellRenderer) *FieldBuilder {
+	fb.config.CellRenderer = renderer
+	return fb
+}
+
+// HelpText explains the field under its form input and in an info tooltip on its list header
+func (fb *FieldBuilder) HelpText(text string) *FieldBuilder {
+	fb.config.HelpText = text
+	return fb
+}
+
+// Placeholder sets the hint shown inside the field's empty form input
+func (fb *FieldBuilder) Placeholder(text string) *FieldBuilder {
+	fb.config.Placeholder = text
+	return fb
+}
+
+// Min rejects number values below min, on save and in the browser
+func (fb *FieldBuilder) Min(min float64) *FieldBuilder {
+	fb.config.Min = &min
+	return fb
+}
+
+// Max rejects number values above max, on save and in the browser
+func (fb *FieldBuilder) Max(max float64) *FieldBuilder {
+	fb.config.Max = &max
+	return fb
+}
+
+// Pattern requires non-empty text values to match the regular expression as a whole, e.g. `[A-Z]{3}-\d+`
+// Stick to syntax Go and JavaScript share, as browsers check the pattern too
+func (fb *FieldBuilder) Pattern(pattern string) *FieldBuilder {
+	fb.config.Pattern = pattern
+	return fb
+}
+
+// MaxLength limits text values to length characters, on save and in the browser
+func (fb *FieldBuilder) MaxLength(length int) *FieldBuilder {
+	fb.config.MaxLength = length
+	return fb
+}
+
+// Copyable adds a copy-to-clipboard button next to the field's values in list cells and detail rows
+func (fb *FieldBuilder) Copyable() *FieldBuilder {
+	fb.config.Copyable = true
+	return fb
+}
+
+// Summarize shows the field's aggregate over the filtered records in the list footer, e.g. Summarize(core.AggregateSum)
+// Totals are computed by adapters implementing Aggregator
+func (fb *FieldBuilder) Summarize(fn AggregateFunc) *FieldBuilder {
+	fb.config.Summary = fn
+	return fb
+}
+
+// MaskFor partially redacts the field's values, e.g. "j***@e***.com", for users with any of the roles,
+// or for everyone without roles, in lists, details and exports
+// Users granted OperationUnmask by the policy can reveal them, which is audited
+// Edit forms still show the value to users who may update the record
+func (fb *FieldBuilder) MaskFor(roles ...auth.Role) *FieldBuilder {
+	fb.config.Masked = true
+	fb.config.MaskRoles = roles
+	return fb
+}
+
+// Encrypted stores the field's values encrypted with AES-GCM under the keys' current key, so they stay
+// out of plain database dumps; adapters decrypt them on read
+// Only string fields can be encrypted, and their columns can no longer be searched, filtered or sorted by value
+func (fb *FieldBuilder) Encrypted(keys KeyProvider) *FieldBuilder {
+	fb.config.Encryption = keys
+	return fb
+}
+
+// AsFile treats the field's values as keys of files in store, shown as their file names with a download link
+// Downloads go through signed URLs expiring after a while, see BackOffice.SetFileURLSigning
+// Combine with RenderAsImage to show image files as thumbnails
+func (fb *FieldBuilder) AsFile(store FileStore) *FieldBuilder {
+	fb.config.FileStore = store
+	return fb
+}
+
 // Build returns the final FieldConfig
 func (fb *FieldBuilder) Build() *FieldConfig {
 	return fb.config
 }
+
+// FormatValue applies the field's display formatter, reporting whether one is configured
+// Nil pointers format as an empty string; other pointers are dereferenced first
+func (f *FieldInfo) FormatValue(value any) (string, bool) {
+	if f.FormatFunc == nil || f.IsComputed {
+		return "", false
+	}
+	if value == nil {
+		return "", true
+	}
+	if val := reflect.ValueOf(value); val.Kind() == reflect.Ptr {
+		if val.IsNil() {
+			return "", true
+		}
+		value = val.Elem().Interface()
+	}
+	return f.FormatFunc(value), true
+}
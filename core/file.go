@@ -0,0 +1,155 @@
+package core
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/url"
+	"path"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultFileURLTTL is how long signed file URLs work when SetFileURLSigning wasn't given a TTL
+const DefaultFileURLTTL = 15 * time.Minute
+
+var (
+	// ErrFileURLInvalid is returned for file URLs with a missing or wrong signature
+	ErrFileURLInvalid = errors.New("invalid file URL signature")
+	// ErrFileURLExpired is returned for correctly signed file URLs past their expiry
+	ErrFileURLExpired = errors.New("file URL expired")
+)
+
+// FileStore reads the files of file fields, whose values are the files' keys in the store
+type FileStore interface {
+	// Open returns the content of the file with the key
+	Open(ctx context.Context, key string) (io.ReadCloser, error)
+}
+
+// FSFileStore is a FileStore reading files from a file system, e.g. os.DirFS("/var/uploads")
+type FSFileStore struct {
+	fsys fs.FS
+}
+
+// NewFSFileStore creates a FileStore reading files from fsys
+func NewFSFileStore(fsys fs.FS) *FSFileStore {
+	return &FSFileStore{fsys: fsys}
+}
+
+// Open opens the file at key, a slash-separated path that may not leave the file system's root
+func (s *FSFileStore) Open(ctx context.Context, key string) (io.ReadCloser, error) {
+	key = strings.TrimPrefix(key, "/")
+	if !fs.ValidPath(key) {
+		return nil, fmt.Errorf("invalid file key %q: %w", key, fs.ErrInvalid)
+	}
+	return s.fsys.Open(key)
+}
+
+// fileURLSigning holds the key and lifetime of signed file URLs
+type fileURLSigning struct {
+	key []byte
+	ttl time.Duration
+}
+
+// SetFileURLSigning sets the HMAC key and lifetime of the download URLs of file fields
+// Without a key, a random one is generated at startup, so URLs stop working on restart and only work
+// on the instance that issued them; share a key between instances behind a load balancer
+func (bo *BackOffice) SetFileURLSigning(key []byte, ttl time.Duration) *BackOffice {
+	if ttl <= 0 {
+		ttl = DefaultFileURLTTL
+	}
+	bo.fileURLs = fileURLSigning{key: key, ttl: ttl}
+	return bo
+}
+
+// fileURLKey returns the key signing file URLs, generating a random one on first use when none was set
+func (bo *BackOffice) fileURLKey() []byte {
+	bo.fileURLOnce.Do(func() {
+		if len(bo.fileURLs.key) > 0 {
+			return
+		}
+		bo.fileURLs.key = make([]byte, 32)
+		if _, err := rand.Read(bo.fileURLs.key); err != nil {
+			panic(fmt.Sprintf("failed to generate the file URL key: %v", err))
+		}
+	})
+	return bo.fileURLs.key
+}
+
+// IsFile reports whether the field's values are keys of files in a FileStore
+func (f *FieldInfo) IsFile() bool {
+	return f.FileStore != nil
+}
+
+// FileKey returns the key of the record's file for the field in its FileStore, empty without a file
+func (f *FieldInfo) FileKey(item any) string {
+	value := GetFieldValue(item, f.Name)
+	if val := reflect.ValueOf(value); val.Kind() == reflect.Ptr {
+		if val.IsNil() {
+			return ""
+		}
+		value = val.Elem().Interface()
+	}
+	if value == nil {
+		return ""
+	}
+	return fmt.Sprintf("%v", value)
+}
+
+// FileName returns the name of the record's file for the field, without the directories of its key
+func (f *FieldInfo) FileName(item any) string {
+	if key := f.FileKey(item); key != "" {
+		return path.Base(key)
+	}
+	return ""
+}
+
+// FileURL returns a signed, expiring URL downloading the record's file for the field, empty without a file
+// The URL names the record and the field rather than the file's key, so storage paths are never exposed
+func (r *Resource) FileURL(item any, field *FieldInfo) string {
+	bo := r.backoffice
+	if bo == nil || !field.IsFile() || field.FileName(item) == "" {
+		return ""
+	}
+	ttl := bo.fileURLs.ttl
+	if ttl <= 0 {
+		ttl = DefaultFileURLTTL
+	}
+	id := r.RecordID(item)
+	expires := strconv.FormatInt(time.Now().Add(ttl).Unix(), 10)
+	query := url.Values{
+		"expires":   {expires},
+		"signature": {bo.signFileURL(r.Name, id, field.Name, expires)},
+	}
+	return bo.config.BasePath + "/files/" + url.PathEscape(r.Name) + "/" + url.PathEscape(id) + "/" + url.PathEscape(field.Name) + "?" + query.Encode()
+}
+
+// VerifyFileURL checks the signature and expiry of a file URL's parts
+func (bo *BackOffice) VerifyFileURL(resourceName, recordID, fieldName, expires, signature string) error {
+	want := bo.signFileURL(resourceName, recordID, fieldName, expires)
+	if !hmac.Equal([]byte(want), []byte(signature)) {
+		return ErrFileURLInvalid
+	}
+	unix, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil {
+		return ErrFileURLInvalid
+	}
+	if time.Now().Unix() > unix {
+		return ErrFileURLExpired
+	}
+	return nil
+}
+
+func (bo *BackOffice) signFileURL(resourceName, recordID, fieldName, expires string) string {
+	mac := hmac.New(sha256.New, bo.fileURLKey())
+	mac.Write([]byte(resourceName + "\n" + recordID + "\n" + fieldName + "\n" + expires))
+	return hex.EncodeToString(mac.Sum(nil))
+}
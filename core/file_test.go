@@ -0,0 +1,70 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/preslavrachev/backoffice/middleware/auth"
+)
+
+func TestFileURL(t *testing.T) {
+	type Attachment struct {
+		ID   uint   `db:"id"`
+		Path string `db:"path"`
+	}
+
+	bo := New(&DummyAdapter{}, auth.AuthConfig{})
+	bo.SetFileURLSigning([]byte("secret"), time.Minute)
+	bo.RegisterResource(&Attachment{}).
+		WithField("Path", func(f *FieldBuilder) { f.AsFile(NewFSFileStore(fstest.MapFS{})) })
+	resource, _ := bo.GetResource("Attachment")
+	field, _ := resource.GetField("Path")
+
+	if got := resource.FileURL(&Attachment{ID: 1}, field); got != "" {
+		t.Errorf("Expected no URL without a file, got %q", got)
+	}
+
+	fileURL := resource.FileURL(&Attachment{ID: 1, Path: "private/contracts/nda.pdf"}, field)
+	if !strings.HasPrefix(fileURL, "/admin/files/Attachment/1/Path?") || strings.Contains(fileURL, "private") {
+		t.Fatalf("Expected a URL naming the record and field only, got %q", fileURL)
+	}
+	parsed, _ := url.Parse(fileURL)
+	query := parsed.Query()
+	if err := bo.VerifyFileURL("Attachment", "1", "Path", query.Get("expires"), query.Get("signature")); err != nil {
+		t.Errorf("Expected the URL to verify, got %v", err)
+	}
+	if err := bo.VerifyFileURL("Attachment", "2", "Path", query.Get("expires"), query.Get("signature")); !errors.Is(err, ErrFileURLInvalid) {
+		t.Errorf("Expected another record's URL to fail, got %v", err)
+	}
+	later := strconv.FormatInt(time.Now().Add(time.Hour).Unix(), 10)
+	if err := bo.VerifyFileURL("Attachment", "1", "Path", later, query.Get("signature")); !errors.Is(err, ErrFileURLInvalid) {
+		t.Errorf("Expected an extended expiry to fail, got %v", err)
+	}
+
+	past := strconv.FormatInt(time.Now().Add(-time.Minute).Unix(), 10)
+	if err := bo.VerifyFileURL("Attachment", "1", "Path", past, bo.signFileURL("Attachment", "1", "Path", past)); !errors.Is(err, ErrFileURLExpired) {
+		t.Errorf("Expected an expired URL to fail, got %v", err)
+	}
+}
+
+func TestFSFileStore(t *testing.T) {
+	store := NewFSFileStore(fstest.MapFS{"docs/a.txt": {Data: []byte("hello")}})
+	file, err := store.Open(context.Background(), "/docs/a.txt")
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	defer file.Close()
+	if content, _ := io.ReadAll(file); string(content) != "hello" {
+		t.Errorf("Expected the file content, got %q", content)
+	}
+	if _, err := store.Open(context.Background(), "../etc/passwd"); err == nil {
+		t.Error("Expected keys leaving the root to be rejected")
+	}
+}
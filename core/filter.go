@@ -0,0 +1,145 @@
+package core
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// FilterOperator compares a field against a filter value
+type FilterOperator string
+
+const (
+	FilterGte FilterOperator = "gte" // Field is greater than or equal to the value
+	FilterLte FilterOperator = "lte" // Field is less than or equal to the value
+)
+
+// FilterParamSeparator joins a field and an operator in list URLs, e.g. Age__gte=18
+const FilterParamSeparator = "__"
+
+// FilterDateLayout is the layout of date filter values, as sent by date inputs
+const FilterDateLayout = "2006-01-02"
+
+// FilterCondition is a typed comparison; equality filters stay in Query.Filters
+type FilterCondition struct {
+	Field    string         `json:"field"`
+	Operator FilterOperator `json:"operator"`
+	Value    any            `json:"value"`
+}
+
+// FilterKind is the control a field gets in the list filter panel
+type FilterKind string
+
+const (
+	FilterKindNone     FilterKind = ""
+	FilterKindSelect   FilterKind = "select"   // One of the field's choices
+	FilterKindRelation FilterKind = "relation" // One of the related records, filtered by foreign key
+	FilterKindBool     FilterKind = "bool"     // Any, yes or no
+	FilterKindNumber   FilterKind = "number"   // Minimum and maximum
+	FilterKindDate     FilterKind = "date"     // From and to dates
+)
+
+// WithCondition adds a typed comparison to the query
+func (q *Query) WithCondition(field string, operator FilterOperator, value any) *Query {
+	q.Conditions = append(q.Conditions, FilterCondition{Field: field, Operator: operator, Value: value})
+	return q
+}
+
+// ParseFilterParam splits a list URL parameter such as "Age__gte" into the field and operator
+// Parameters without a known operator are equality filters
+func ParseFilterParam(param string) (string, FilterOperator, bool) {
+	field, op, found := strings.Cut(param, FilterParamSeparator)
+	if !found {
+		return param, "", false
+	}
+	switch operator := FilterOperator(op); operator {
+	case FilterGte, FilterLte:
+		return field, operator, true
+	}
+	return param, "", false
+}
+
+// FilterKind returns the filter control for the field, or FilterKindNone when it can't be filtered from the panel
+func (f *FieldInfo) FilterKind() FilterKind {
+	if f.IsComputed || f.PrimaryKey {
+		return FilterKindNone
+	}
+	if f.Relationship != nil && f.Relationship.Type == RelationshipManyToOne && f.Relationship.ForeignKey != "" {
+		return FilterKindRelation
+	}
+	if len(f.Choices) > 0 && f.ChoicesFunc == nil {
+		return FilterKindSelect
+	}
+	switch strings.TrimPrefix(f.Type, "*") {
+	case "bool":
+		return FilterKindBool
+	case "int", "int8", "int16", "int32", "int64", "uint", "uint8", "uint16", "uint32", "uint64", "float32", "float64":
+		return FilterKindNumber
+	case "time.Time":
+		return FilterKindDate
+	}
+	return FilterKindNone
+}
+
+// FilterFields returns the fields that get a control in the list filter panel
+// Foreign keys are left out when their relationship field already offers a select
+func (r *Resource) FilterFields() []FieldInfo {
+	foreignKeys := make(map[string]bool)
+	for _, field := range r.Fields {
+		if field.FilterKind() == FilterKindRelation {
+			foreignKeys[field.Relationship.ForeignKey] = true
+		}
+	}
+
+	var fields []FieldInfo
+	for _, field := range r.Fields {
+		if field.FilterKind() != FilterKindNone && !foreignKeys[field.Name] {
+			fields = append(fields, field)
+		}
+	}
+	return fields
+}
+
+// IsFilterable reports whether the list can be filtered by the field or foreign key name from the URL
+// Computed, masked and encrypted fields don't qualify, so URLs can't filter by unknown columns or probe
+// values the panel redacts
+func (r *Resource) IsFilterable(name string) bool {
+	for _, field := range r.Fields {
+		if field.FilterKind() == FilterKindRelation && field.Relationship.ForeignKey == name {
+			return true
+		}
+		if field.Name == name {
+			return !field.IsComputed && !field.Masked && field.Encryption == nil
+		}
+	}
+	return false
+}
+
+// ParseFilterValue converts a raw URL filter value to the field's type, so adapters compare typed values
+// Dates compared with FilterLte cover the whole day
+func ParseFilterValue(field *FieldInfo, operator FilterOperator, raw string) (any, error) {
+	switch strings.TrimPrefix(field.Type, "*") {
+	case "bool":
+		return strconv.ParseBool(raw)
+	case "int", "int8", "int16", "int32", "int64":
+		return strconv.ParseInt(raw, 10, 64)
+	case "uint", "uint8", "uint16", "uint32", "uint64":
+		return strconv.ParseUint(raw, 10, 64)
+	case "float32", "float64":
+		return strconv.ParseFloat(raw, 64)
+	case "time.Time":
+		if day, err := time.Parse(FilterDateLayout, raw); err == nil {
+			if operator == FilterLte {
+				return day.Add(24*time.Hour - time.Nanosecond), nil
+			}
+			return day, nil
+		}
+		value, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			return nil, fmt.Errorf("invalid date %q for %s", raw, field.Name)
+		}
+		return value, nil
+	}
+	return raw, nil
+}
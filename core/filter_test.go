@@ -0,0 +1,115 @@
+package core
+
+import (
+	"testing"
+	"time"
+)
+
+type filterEmployee struct {
+	ID           uint
+	Name         string
+	Role         string
+	Salary       float64
+	Active       bool
+	HiredAt      time.Time
+	DepartmentID uint
+	Department   *Address
+	Nickname     string
+}
+
+func TestFilterFields(t *testing.T) {
+	bo := &BackOffice{resources: make(map[string]*Resource), resourceOrder: []string{}, config: &Config{}}
+	bo.RegisterResource(&filterEmployee{}).
+		WithFields("Name", "Salary", "Active", "HiredAt", "DepartmentID").
+		WithField("Role", func(f *FieldBuilder) {
+			f.Choices([]string{"admin", "staff"})
+		}).
+		WithManyToOneField("Department", "Address", func(r *RelationshipBuilder) {
+			r.ForeignKey("DepartmentID")
+		})
+	resource, _ := bo.GetResource("filterEmployee")
+
+	kinds := make(map[string]FilterKind)
+	for _, field := range resource.FilterFields() {
+		kinds[field.Name] = field.FilterKind()
+	}
+
+	expected := map[string]FilterKind{
+		"Salary":     FilterKindNumber,
+		"Active":     FilterKindBool,
+		"HiredAt":    FilterKindDate,
+		"Role":       FilterKindSelect,
+		"Department": FilterKindRelation,
+	}
+	if len(kinds) != len(expected) {
+		t.Errorf("Expected %d filter fields, got %v", len(expected), kinds)
+	}
+	for name, kind := range expected {
+		if kinds[name] != kind {
+			t.Errorf("Expected %s to filter as %q, got %q", name, kind, kinds[name])
+		}
+	}
+}
+
+func TestIsFilterable(t *testing.T) {
+	bo := &BackOffice{resources: make(map[string]*Resource), resourceOrder: []string{}, config: &Config{}}
+	bo.RegisterResource(&filterEmployee{}).
+		WithFields("Name", "Salary").
+		WithField("Nickname", func(f *FieldBuilder) {
+			f.MaskFor()
+		}).
+		WithManyToOneField("Department", "Address", func(r *RelationshipBuilder) {
+			r.ForeignKey("DepartmentID")
+		})
+	resource, _ := bo.GetResource("filterEmployee")
+
+	for name, expected := range map[string]bool{
+		"Name":         true,
+		"Salary":       true,
+		"DepartmentID": true,
+		"Nickname":     false,
+		"Role":         false,
+		"password":     false,
+	} {
+		if got := resource.IsFilterable(name); got != expected {
+			t.Errorf("IsFilterable(%q) = %v, want %v", name, got, expected)
+		}
+	}
+}
+
+func TestParseFilterParam(t *testing.T) {
+	if field, op, ok := ParseFilterParam("Salary__gte"); !ok || field != "Salary" || op != FilterGte {
+		t.Errorf("Expected Salary >=, got %s %s %v", field, op, ok)
+	}
+	if field, _, ok := ParseFilterParam("Status"); ok || field != "Status" {
+		t.Errorf("Expected an equality filter, got %s %v", field, ok)
+	}
+	if field, _, ok := ParseFilterParam("first__name"); ok || field != "first__name" {
+		t.Errorf("Expected unknown operators to stay part of the name, got %s %v", field, ok)
+	}
+}
+
+func TestParseFilterValue(t *testing.T) {
+	salary := &FieldInfo{Name: "Salary", Type: "float64"}
+	if value, err := ParseFilterValue(salary, FilterGte, "1500.5"); err != nil || value != 1500.5 {
+		t.Errorf("Expected a float, got %v (%v)", value, err)
+	}
+	if _, err := ParseFilterValue(salary, FilterGte, "lots"); err == nil {
+		t.Error("Expected an error for a malformed number")
+	}
+
+	active := &FieldInfo{Name: "Active", Type: "bool"}
+	if value, _ := ParseFilterValue(active, "", "true"); value != true {
+		t.Errorf("Expected a bool, got %v", value)
+	}
+
+	hired := &FieldInfo{Name: "HiredAt", Type: "time.Time"}
+	from, _ := ParseFilterValue(hired, FilterGte, "2024-03-01")
+	to, _ := ParseFilterValue(hired, FilterLte, "2024-03-01")
+	if !from.(time.Time).Equal(time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected the start of the day, got %v", from)
+	}
+	if !to.(time.Time).Equal(time.Date(2024, 3, 1, 23, 59, 59, 999999999, time.UTC)) {
+		t.Errorf("Expected the end of the day, got %v", to)
+	}
+}
@@ -0,0 +1,35 @@
+package core
+
+// ResourceGroup is a named set of resources shown together in navigation
+type ResourceGroup struct {
+	Name      string      // Empty for resources registered without a group
+	Resources []*Resource // Resources in registration order
+	Links     []NavLink   // Extra navigation links listed after the resources, see GroupLinks
+}
+
+// GroupResources groups resources by their Group, keeping groups in order of first appearance
+// Ungrouped resources are collected into a leading group with an empty name
+func GroupResources(resources []*Resource) []ResourceGroup {
+	var ungrouped []*Resource
+	groups := []ResourceGroup{}
+	index := make(map[string]int)
+
+	for _, resource := range resources {
+		if resource.Group == "" {
+			ungrouped = append(ungrouped, resource)
+			continue
+		}
+		i, exists := index[resource.Group]
+		if !exists {
+			i = len(groups)
+			index[resource.Group] = i
+			groups = append(groups, ResourceGroup{Name: resource.Group})
+		}
+		groups[i].Resources = append(groups[i].Resources, resource)
+	}
+
+	if len(ungrouped) > 0 {
+		groups = append([]ResourceGroup{{Resources: ungrouped}}, groups...)
+	}
+	return groups
+}
@@ -0,0 +1,108 @@
+package core
+
+import "testing"
+
+func TestGroupResources(t *testing.T) {
+	type Order struct {
+		ID uint `db:"id"`
+	}
+	type Product struct {
+		ID uint `db:"id"`
+	}
+	type Setting struct {
+		ID uint `db:"id"`
+	}
+	type Invoice struct {
+		ID uint `db:"id"`
+	}
+
+	bo := &BackOffice{
+		resources:     make(map[string]*Resource),
+		resourceOrder: []string{},
+		config:        &Config{},
+	}
+	bo.RegisterResource(&Order{}).WithGroup("Commerce")
+	bo.RegisterResource(&Setting{})
+	bo.RegisterResource(&Invoice{}).WithGroup("Billing")
+	bo.RegisterResource(&Product{}).WithGroup("Commerce")
+
+	groups := GroupResources(bo.GetResources())
+	if len(groups) != 3 {
+		t.Fatalf("Expected 3 groups, got %d", len(groups))
+	}
+
+	expected := []struct {
+		name      string
+		resources []string
+	}{
+		{"", []string{"Setting"}},
+		{"Commerce", []string{"Order", "Product"}},
+		{"Billing", []string{"Invoice"}},
+	}
+	for i, want := range expected {
+		group := groups[i]
+		if group.Name != want.name {
+			t.Errorf("Group %d: expected name '%s', got '%s'", i, want.name, group.Name)
+		}
+		if len(group.Resources) != len(want.resources) {
+			t.Errorf("Group '%s': expected %d resources, got %d", want.name, len(want.resources), len(group.Resources))
+			continue
+		}
+		for j, name := range want.resources {
+			if group.Resources[j].Name != name {
+				t.Errorf("Group '%s': expected resource %d to be '%s', got '%s'", want.name, j, name, group.Resources[j].Name)
+			}
+		}
+	}
+}
+
+func TestGroupLinks(t *testing.T) {
+	type Order struct {
+		ID uint `db:"id"`
+	}
+
+	bo := &BackOffice{
+		resources:     make(map[string]*Resource),
+		resourceOrder: []string{},
+		config:        &Config{},
+	}
+	bo.RegisterResource(&Order{}).WithGroup("Commerce")
+	bo.AddLink("Grafana", "https://grafana.internal", "📈").InGroup("Monitoring")
+	bo.AddLink("Stripe", "https://dashboard.stripe.com", "").InGroup("Commerce")
+	bo.AddLink("Docs", "/docs", "")
+
+	groups := GroupLinks(GroupResources(bo.GetResources()), bo.Links())
+	expected := []struct {
+		name  string
+		links []string
+	}{
+		{"", []string{"Docs"}},
+		{"Commerce", []string{"Stripe"}},
+		{"Monitoring", []string{"Grafana"}},
+	}
+	if len(groups) != len(expected) {
+		t.Fatalf("Expected %d groups, got %d", len(expected), len(groups))
+	}
+	for i, want := range expected {
+		group := groups[i]
+		if group.Name != want.name {
+			t.Errorf("Group %d: expected name '%s', got '%s'", i, want.name, group.Name)
+		}
+		if len(group.Links) != len(want.links) {
+			t.Errorf("Group '%s': expected %d links, got %d", want.name, len(want.links), len(group.Links))
+			continue
+		}
+		for j, title := range want.links {
+			if group.Links[j].Title != title {
+				t.Errorf("Group '%s': expected link %d to be '%s', got '%s'", want.name, j, title, group.Links[j].Title)
+			}
+		}
+	}
+	if len(groups[1].Resources) != 1 {
+		t.Errorf("Expected the Commerce group to keep its resource, got %d", len(groups[1].Resources))
+	}
+
+	if !groups[2].Links[0].IsExternal() || groups[0].Links[0].IsExternal() {
+		t.Error("Expected only absolute URLs to be external")
+	}
+}
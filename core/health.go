@@ -0,0 +1,243 @@
+package core
+
+import (
+	"fmt"
+	"maps"
+	"reflect"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// ConfigError describes a misconfigured resource, found by BackOffice.Validate
+type ConfigError struct {
+	Resource string
+	Field    string
+	Message  string
+}
+
+func (e *ConfigError) Error() string {
+	if e.Field == "" {
+		return fmt.Sprintf("resource %s: %s", e.Resource, e.Message)
+	}
+	return fmt.Sprintf("resource %s: field %s: %s", e.Resource, e.Field, e.Message)
+}
+
+// ConfigErrors collects every configuration problem of the registered resources
+type ConfigErrors []*ConfigError
+
+func (e ConfigErrors) Error() string {
+	messages := make([]string, len(e))
+	for i, err := range e {
+		messages[i] = err.Error()
+	}
+	return fmt.Sprintf("%d configuration problem(s):\n  %s", len(e), strings.Join(messages, "\n  "))
+}
+
+// Validate checks every registered resource, dashboard widget and custom page and reports all configuration problems at once
+// Call it at startup, after registering resources, to fail with clear messages instead of at request time
+func (bo *BackOffice) Validate() error {
+	var problems ConfigErrors
+	for _, name := range bo.resourceOrder {
+		problems = append(problems, bo.resources[name].ConfigErrors()...)
+	}
+	problems = append(problems, bo.dashboardErrors()...)
+	problems = append(problems, bo.pageErrors()...)
+	if len(problems) > 0 {
+		return problems
+	}
+	return nil
+}
+
+// ConfigErrors returns the configuration problems of the resource
+func (r *Resource) ConfigErrors() ConfigErrors {
+	var problems ConfigErrors
+	report := func(field, format string, args ...any) {
+		problems = append(problems, &ConfigError{Resource: r.Name, Field: field, Message: fmt.Sprintf(format, args...)})
+	}
+
+	structType := r.ModelType
+	if structType.Kind() == reflect.Ptr {
+		structType = structType.Elem()
+	}
+	hasStructField := func(name string) bool {
+		_, ok := structType.FieldByName(name)
+		return ok
+	}
+	hasField := func(name string) bool {
+		if config, ok := r.FieldConfigs[name]; ok && config.IsComputed {
+			return true
+		}
+		return hasStructField(name)
+	}
+
+	if r.IDField == "" {
+		report("", "no primary key field found; tag one with db:\"id\" or name it ID")
+	}
+
+	for _, name := range r.FieldOrder {
+		if !hasField(name) {
+			report(name, "configured field not found in struct %s", structType.Name())
+			continue
+		}
+		config := r.FieldConfigs[name]
+		if rel := config.Relationship; rel != nil && rel.Type == RelationshipManyToOne && rel.ForeignKey != "" && !hasStructField(rel.ForeignKey) {
+			report(name, "foreign key field %s not found in struct %s", rel.ForeignKey, structType.Name())
+		}
+		if rel := config.Relationship; rel != nil && rel.Type == RelationshipManyToMany && (rel.JoinTable == "" || rel.JoinForeignKey == "" || rel.JoinRelatedKey == "") {
+			report(name, "many-to-many relationship needs a join table and both of its key columns")
+		}
+		for _, value := range slices.Sorted(maps.Keys(config.ChoiceColors)) {
+			if len(config.Choices) > 0 && !slices.Contains(config.Choices, value) {
+				report(name, "color for unknown choice %q", value)
+			}
+		}
+		if config.DependsOn != "" && !hasField(config.DependsOn) {
+			report(name, "choices depend on unknown field %s", config.DependsOn)
+		}
+		if config.SlugSource != "" && !hasField(config.SlugSource) {
+			report(name, "slug source %s not found", config.SlugSource)
+		}
+		switch config.CodeLanguage {
+		case "", CodeJSON, CodeYAML, CodeSQL:
+		default:
+			report(name, "unknown code language %q", config.CodeLanguage)
+		}
+		if field, ok := r.GetField(name); ok && config.DateOnly && !field.IsTimeField() {
+			report(name, "date picker requires a time.Time field, got %s", field.Type)
+		}
+		if field, ok := r.GetField(name); ok && config.Summary != "" {
+			switch {
+			case config.Summary == AggregateCount:
+			case config.Summary != AggregateSum && config.Summary != AggregateAvg && config.Summary != AggregateMin && config.Summary != AggregateMax:
+				report(name, "unknown summary %q", config.Summary)
+			case field.IsComputed:
+				report(name, "summary requires a stored field")
+			case field.FilterKind() != FilterKindNumber:
+				report(name, "%s summary requires a number field, got %s", config.Summary, field.Type)
+			}
+		}
+		if field, ok := r.GetField(name); ok && config.RelativeTime && !field.IsTimeField() {
+			report(name, "relative time requires a time.Time field, got %s", field.Type)
+		}
+		if field, ok := r.GetField(name); ok && config.RenderAs == RenderImage && field.Type != "string" && field.Type != "*string" {
+			report(name, "image preview requires a string field holding the URL, got %s", field.Type)
+		}
+		if config.Pattern != "" {
+			if _, err := regexp.Compile(config.Pattern); err != nil {
+				report(name, "invalid pattern: %v", err)
+			}
+		}
+		if config.Min != nil && config.Max != nil && *config.Min > *config.Max {
+			report(name, "min %s is greater than max %s", FormatRuleNumber(*config.Min), FormatRuleNumber(*config.Max))
+		}
+		if field, ok := r.GetField(name); ok {
+			isText := strings.TrimPrefix(field.Type, "*") == "string"
+			if (config.Pattern != "" || config.MaxLength > 0) && !isText {
+				report(name, "pattern and max length require a string field, got %s", field.Type)
+			}
+			if (config.Min != nil || config.Max != nil) && field.FilterKind() != FilterKindNumber {
+				report(name, "min and max require a number field, got %s", field.Type)
+			}
+		}
+	}
+
+	sorts := r.DefaultSorts
+	if len(sorts) == 0 && r.DefaultSort.Precedence == SortPrecedenceExplicit {
+		sorts = []SortField{r.DefaultSort}
+	}
+	for _, sort := range sorts {
+		switch {
+		case !hasField(sort.Field):
+			report(sort.Field, "default sort field not found")
+		case !isSortableConfig(r.FieldConfigs[sort.Field]):
+			report(sort.Field, "default sort field is not sortable; configure it with SortBy or a typed computed field")
+		}
+	}
+
+	for _, ref := range []struct{ label, name string }{
+		{"tree parent", r.TreeParentField},
+		{"owner", r.OwnerField},
+		{"soft delete", r.SoftDeleteField},
+		{"lock", r.LockField},
+		{"order", r.OrderField},
+	} {
+		if ref.name != "" && !hasStructField(ref.name) {
+			report(ref.name, "%s field not found in struct %s", ref.label, structType.Name())
+		}
+	}
+	if field, ok := structType.FieldByName(r.OrderField); ok {
+		switch field.Type.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		default:
+			report(r.OrderField, "order field must be an integer, got %s", field.Type)
+		}
+	}
+
+	for _, chart := range r.Charts {
+		if err := chart.chartError(); err != nil {
+			report("", "chart %q: %v", chart.Title, err)
+			continue
+		}
+		for _, name := range []string{chart.Aggregate.GroupBy, chart.Aggregate.Field} {
+			if name != "" && !hasStructField(name) {
+				report(name, "chart %q field not found in struct %s", chart.Title, structType.Name())
+			}
+		}
+	}
+
+	for _, stat := range r.Stats {
+		if stat.Value == nil {
+			report("", "stat %q has no value function", stat.Title)
+		}
+	}
+
+	for _, tab := range r.DetailTabs {
+		switch tab {
+		case TabOverview, TabRelated, TabJSON:
+		case TabHistory:
+			if r.History == nil {
+				report("", "history tab requires WithHistory")
+			}
+		default:
+			report("", "unknown detail tab %q", tab)
+		}
+	}
+
+	if state := r.EmptyState; state != nil && (state.ActionLabel == "") != (state.ActionURL == "") {
+		report("", "empty state action needs both a label and a URL")
+	}
+
+	switch r.PaginationStyle {
+	case "", PaginationLoadMore, PaginationNumbered, PaginationInfinite:
+	default:
+		report("", "unknown pagination style %q", r.PaginationStyle)
+	}
+
+	if _, err := r.ExportFields(); err != nil {
+		report("", "invalid export configuration: %v", err)
+	}
+	if _, err := r.ImportFields(); err != nil {
+		report("", "invalid import configuration: %v", err)
+	}
+	if r.Import != nil && r.Import.KeyField != "" {
+		if _, ok := r.GetField(r.Import.KeyField); !ok {
+			report("", "unknown import key field %s", r.Import.KeyField)
+		}
+	}
+
+	return problems
+}
+
+// isSortableConfig mirrors IsFieldSortable from the field configuration, which is available even
+// when field discovery failed; computed fields need a sort configuration or a typed compute function
+func isSortableConfig(config *FieldConfig) bool {
+	if config != nil && config.Encryption != nil && len(config.SortFields) == 0 {
+		return false
+	}
+	if config == nil || !config.IsComputed {
+		return true
+	}
+	return len(config.SortFields) > 0 || config.IsSortable || config.TypedComputeFunc != nil
+}
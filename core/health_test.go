@@ -0,0 +1,175 @@
+package core
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/preslavrachev/backoffice/middleware/auth"
+)
+
+type healthInvoice struct {
+	ID         uint
+	Number     string
+	CustomerID uint
+	Customer   *Address
+}
+
+func TestBackOfficeValidate(t *testing.T) {
+	t.Run("valid configuration", func(t *testing.T) {
+		bo := New(&DummyAdapter{}, auth.AuthConfig{})
+		bo.RegisterResource(&healthInvoice{}).
+			WithFields("Number", "CustomerID").
+			WithDefaultSort(Sort("Number", SortAsc))
+
+		if err := bo.Validate(); err != nil {
+			t.Errorf("Expected no problems, got %v", err)
+		}
+	})
+
+	t.Run("reports every problem", func(t *testing.T) {
+		bo := New(&DummyAdapter{}, auth.AuthConfig{})
+		bo.RegisterResource(&healthInvoice{}).
+			WithFields("Number", "Total").
+			WithField("Customer", func(f *FieldBuilder) {
+				f.ManyToOne("Address", "Country").ForeignKey("AddressID")
+			}).
+			WithDerivedField("Label", "Label", func(item any) string { return "" }).
+			WithDefaultSort(Sort("Label", SortAsc), Sort("Missing", SortDesc)).
+			WithPaginationStyle("pages")
+
+		err := bo.Validate()
+		var problems ConfigErrors
+		if !errors.As(err, &problems) {
+			t.Fatalf("Expected ConfigErrors, got %v", err)
+		}
+
+		message := err.Error()
+		for _, want := range []string{
+			"field Total: configured field not found in struct healthInvoice",
+			"field Customer: foreign key field AddressID not found",
+			"field Label: default sort field is not sortable",
+			"field Missing: default sort field not found",
+			`unknown pagination style "pages"`,
+		} {
+			if !strings.Contains(message, want) {
+				t.Errorf("Expected %q in:\n%s", want, message)
+			}
+		}
+		if len(problems) != 5 {
+			t.Errorf("Expected 5 problems, got %d", len(problems))
+		}
+	})
+
+	t.Run("detail tabs", func(t *testing.T) {
+		bo := New(&DummyAdapter{}, auth.AuthConfig{})
+		bo.RegisterResource(&healthInvoice{}).
+			WithFields("Number").
+			WithDetailTabs(TabOverview, TabHistory, "notes")
+
+		err := bo.Validate()
+		if err == nil {
+			t.Fatal("Expected problems with the detail tabs")
+		}
+		for _, want := range []string{"history tab requires WithHistory", `unknown detail tab "notes"`} {
+			if !strings.Contains(err.Error(), want) {
+				t.Errorf("Expected %q in:\n%s", want, err)
+			}
+		}
+	})
+
+	t.Run("date picker on a non-time field", func(t *testing.T) {
+		bo := New(&DummyAdapter{}, auth.AuthConfig{})
+		bo.RegisterResource(&healthInvoice{}).
+			WithField("Number", func(f *FieldBuilder) { f.AsDate() })
+
+		err := bo.Validate()
+		if err == nil || !strings.Contains(err.Error(), "field Number: date picker requires a time.Time field, got string") {
+			t.Errorf("Expected a date picker problem, got %v", err)
+		}
+	})
+
+	t.Run("summary on a non-number field", func(t *testing.T) {
+		bo := New(&DummyAdapter{}, auth.AuthConfig{})
+		bo.RegisterResource(&healthInvoice{}).
+			WithField("Number", func(f *FieldBuilder) { f.Summarize(AggregateSum) })
+
+		err := bo.Validate()
+		if err == nil || !strings.Contains(err.Error(), "field Number: sum summary requires a number field, got string") {
+			t.Errorf("Expected a summary problem, got %v", err)
+		}
+	})
+
+	t.Run("relative time on a non-time field", func(t *testing.T) {
+		bo := New(&DummyAdapter{}, auth.AuthConfig{})
+		bo.RegisterResource(&healthInvoice{}).
+			WithField("Number", func(f *FieldBuilder) { f.AsRelativeTime() })
+
+		err := bo.Validate()
+		if err == nil || !strings.Contains(err.Error(), "field Number: relative time requires a time.Time field, got string") {
+			t.Errorf("Expected a relative time problem, got %v", err)
+		}
+	})
+
+	t.Run("image preview on a non-string field", func(t *testing.T) {
+		bo := New(&DummyAdapter{}, auth.AuthConfig{})
+		bo.RegisterResource(&healthInvoice{}).
+			WithField("CustomerID", func(f *FieldBuilder) { f.RenderAsImage() })
+
+		err := bo.Validate()
+		if err == nil || !strings.Contains(err.Error(), "field CustomerID: image preview requires a string field holding the URL, got uint") {
+			t.Errorf("Expected an image preview problem, got %v", err)
+		}
+	})
+
+	t.Run("empty state action without a URL", func(t *testing.T) {
+		bo := New(&DummyAdapter{}, auth.AuthConfig{})
+		bo.RegisterResource(&healthInvoice{}).
+			WithEmptyState(EmptyState{Title: "No invoices yet", ActionLabel: "Import invoices"})
+
+		err := bo.Validate()
+		if err == nil || !strings.Contains(err.Error(), "empty state action needs both a label and a URL") {
+			t.Errorf("Expected an empty state problem, got %v", err)
+		}
+	})
+
+	t.Run("unknown import key field", func(t *testing.T) {
+		bo := New(&DummyAdapter{}, auth.AuthConfig{})
+		bo.RegisterResource(&healthInvoice{}).
+			WithImport(ImportConfig{KeyField: "Reference"})
+
+		err := bo.Validate()
+		if err == nil || !strings.Contains(err.Error(), "unknown import key field Reference") {
+			t.Errorf("Expected an import problem, got %v", err)
+		}
+	})
+
+	t.Run("custom page problems", func(t *testing.T) {
+		bo := New(&DummyAdapter{}, auth.AuthConfig{})
+		bo.RegisterResource(&healthInvoice{})
+		bo.RegisterPage("/healthInvoice/summary", http.NotFoundHandler())
+		bo.RegisterPage("/reports", "not a page")
+
+		err := bo.Validate()
+		if err == nil {
+			t.Fatal("Expected page problems")
+		}
+		for _, want := range []string{"shadowed by the resource", "content must be a templ component"} {
+			if !strings.Contains(err.Error(), want) {
+				t.Errorf("Expected %q in %v", want, err)
+			}
+		}
+	})
+}
+
+func TestTryRegisterResource(t *testing.T) {
+	bo := New(&DummyAdapter{}, auth.AuthConfig{})
+
+	if _, err := bo.TryRegisterResource(healthInvoice{}); err == nil {
+		t.Error("Expected an error for a non-pointer model")
+	}
+	if builder, err := bo.TryRegisterResource(&healthInvoice{}); err != nil || builder == nil {
+		t.Errorf("Expected the resource to register, got %v", err)
+	}
+}
@@ -0,0 +1,197 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/preslavrachev/backoffice/middleware/auth"
+)
+
+// RecordVersion is a full snapshot of a record as it was before a change
+type RecordVersion struct {
+	ID        int64          `json:"id"`
+	Resource  string         `json:"resource"`
+	RecordID  string         `json:"record_id"`
+	Snapshot  map[string]any `json:"snapshot"`   // Field name to value
+	ChangedBy string         `json:"changed_by"` // Username of the user who made the change
+	CreatedAt time.Time      `json:"created_at"`
+}
+
+// HistoryStore persists record versions
+type HistoryStore interface {
+	// SaveVersion stores a version and assigns its ID
+	SaveVersion(ctx context.Context, version *RecordVersion) error
+	// ListVersions returns all versions of a record, newest first
+	ListVersions(ctx context.Context, resource, recordID string) ([]RecordVersion, error)
+	// GetVersion returns a single version of a record
+	GetVersion(ctx context.Context, resource, recordID string, versionID int64) (*RecordVersion, error)
+}
+
+// MemoryHistoryStore keeps record versions in memory
+// Versions are lost on restart, so use a persistent store in production
+type MemoryHistoryStore struct {
+	versions []RecordVersion
+	nextID   int64
+	mu       sync.RWMutex
+}
+
+// NewMemoryHistoryStore creates a new in-memory history store
+func NewMemoryHistoryStore() *MemoryHistoryStore {
+	return &MemoryHistoryStore{nextID: 1}
+}
+
+// SaveVersion stores a version and assigns its ID
+func (s *MemoryHistoryStore) SaveVersion(ctx context.Context, version *RecordVersion) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	version.ID = s.nextID
+	s.nextID++
+	s.versions = append(s.versions, *version)
+	return nil
+}
+
+// ListVersions returns all versions of a record, newest first
+func (s *MemoryHistoryStore) ListVersions(ctx context.Context, resource, recordID string) ([]RecordVersion, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var versions []RecordVersion
+	for _, version := range s.versions {
+		if version.Resource == resource && version.RecordID == recordID {
+			versions = append(versions, version)
+		}
+	}
+	sort.Slice(versions, func(i, j int) bool {
+		return versions[i].ID > versions[j].ID
+	})
+	return versions, nil
+}
+
+// GetVersion returns a single version of a record
+func (s *MemoryHistoryStore) GetVersion(ctx context.Context, resource, recordID string, versionID int64) (*RecordVersion, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, version := range s.versions {
+		if version.ID == versionID && version.Resource == resource && version.RecordID == recordID {
+			return &version, nil
+		}
+	}
+	return nil, fmt.Errorf("version %d of %s %s not found", versionID, resource, recordID)
+}
+
+// SnapshotRecord captures the values of all exported struct fields of an item,
+// including fields that are not configured for display
+func SnapshotRecord(item any) map[string]any {
+	snapshot := make(map[string]any)
+	val := reflect.ValueOf(item)
+	if val.Kind() == reflect.Ptr {
+		if val.IsNil() {
+			return snapshot
+		}
+		val = val.Elem()
+	}
+	if val.Kind() != reflect.Struct {
+		return snapshot
+	}
+
+	for i := 0; i < val.NumField(); i++ {
+		if field := val.Type().Field(i); field.IsExported() {
+			snapshot[field.Name] = val.Field(i).Interface()
+		}
+	}
+	return snapshot
+}
+
+// SaveRecordVersion stores the current state of a record in the resource history
+// It is a no-op for resources without history
+func SaveRecordVersion(ctx context.Context, adapter Adapter, resource *Resource, id any) error {
+	if resource.History == nil {
+		return nil
+	}
+
+	item, err := adapter.GetByID(ctx, resource, id)
+	if err != nil {
+		return fmt.Errorf("failed to load %s %v for history: %w", resource.DisplayName, id, err)
+	}
+
+	// Encrypted fields are read decrypted, so they're left out to keep their plaintext out of the history store
+	snapshot := SnapshotRecord(item)
+	for _, field := range resource.Fields {
+		if field.IsEncrypted() {
+			delete(snapshot, field.Name)
+		}
+	}
+
+	version := &RecordVersion{
+		Resource:  resource.Name,
+		RecordID:  fmt.Sprintf("%v", id),
+		Snapshot:  snapshot,
+		CreatedAt: time.Now(),
+	}
+	version.ChangedBy = auditName(ctx)
+
+	return resource.History.SaveVersion(ctx, version)
+}
+
+// auditName names the request's user in audit records, as "admin (as user)" while impersonating
+func auditName(ctx context.Context) string {
+	user, ok := auth.GetAuthUser(ctx)
+	if !ok || user == nil {
+		return ""
+	}
+	if user.IsImpersonated() {
+		return fmt.Sprintf("%s (as %s)", user.Impersonator.Username, user.Username)
+	}
+	return user.Username
+}
+
+// RestoreRecordVersion overwrites a record with the values of one of its versions
+// The state being replaced is saved as a new version, so a restore can itself be undone
+func RestoreRecordVersion(ctx context.Context, adapter Adapter, resource *Resource, id any, versionID int64) error {
+	if resource.History == nil {
+		return fmt.Errorf("%s does not keep a version history", resource.DisplayName)
+	}
+
+	version, err := resource.History.GetVersion(ctx, resource.Name, fmt.Sprintf("%v", id), versionID)
+	if err != nil {
+		return err
+	}
+
+	item, err := adapter.GetByID(ctx, resource, id)
+	if err != nil {
+		return fmt.Errorf("failed to load %s %v: %w", resource.DisplayName, id, err)
+	}
+
+	itemVal := reflect.ValueOf(item)
+	if itemVal.Kind() != reflect.Ptr || itemVal.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("cannot restore %s: unexpected item type %T", resource.DisplayName, item)
+	}
+	for fieldName, value := range version.Snapshot {
+		fieldVal := itemVal.Elem().FieldByName(fieldName)
+		if !fieldVal.IsValid() || !fieldVal.CanSet() {
+			continue
+		}
+		if value == nil {
+			fieldVal.Set(reflect.Zero(fieldVal.Type()))
+			continue
+		}
+		v := reflect.ValueOf(value)
+		if v.Type().AssignableTo(fieldVal.Type()) {
+			fieldVal.Set(v)
+		} else if v.Type().ConvertibleTo(fieldVal.Type()) {
+			fieldVal.Set(v.Convert(fieldVal.Type()))
+		}
+	}
+
+	if err := SaveRecordVersion(ctx, adapter, resource, id); err != nil {
+		return err
+	}
+
+	return adapter.Update(ctx, resource, id, item)
+}
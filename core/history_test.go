@@ -0,0 +1,140 @@
+package core
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/preslavrachev/backoffice/middleware/auth"
+)
+
+type Page struct {
+	ID    uint   `db:"id"`
+	Title string `db:"title"`
+	Body  string `db:"body"`
+}
+
+// historyTestAdapter stores a single page in memory
+type historyTestAdapter struct {
+	DummyAdapter
+	page Page
+}
+
+func (a *historyTestAdapter) GetByID(ctx context.Context, resource *Resource, id any) (any, error) {
+	page := a.page
+	return &page, nil
+}
+
+func (a *historyTestAdapter) Update(ctx context.Context, resource *Resource, id any, data any) error {
+	a.page = *data.(*Page)
+	return nil
+}
+
+func TestMemoryHistoryStore(t *testing.T) {
+	store := NewMemoryHistoryStore()
+	ctx := context.Background()
+
+	for _, recordID := range []string{"1", "2", "1"} {
+		if err := store.SaveVersion(ctx, &RecordVersion{Resource: "Page", RecordID: recordID}); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+
+	versions, _ := store.ListVersions(ctx, "Page", "1")
+	if len(versions) != 2 {
+		t.Fatalf("Expected 2 versions, got %d", len(versions))
+	}
+	if versions[0].ID != 3 || versions[1].ID != 1 {
+		t.Errorf("Expected newest first (3, 1), got (%d, %d)", versions[0].ID, versions[1].ID)
+	}
+
+	if _, err := store.GetVersion(ctx, "Page", "1", 2); err == nil {
+		t.Error("Expected error when fetching a version of another record")
+	}
+}
+
+func TestRestoreRecordVersion(t *testing.T) {
+	bo := &BackOffice{
+		resources:     make(map[string]*Resource),
+		resourceOrder: []string{},
+		config:        &Config{},
+	}
+	store := NewMemoryHistoryStore()
+	bo.RegisterResource(&Page{}).WithHistory(store)
+	resource, _ := bo.GetResource("Page")
+
+	adapter := &historyTestAdapter{page: Page{ID: 1, Title: "Draft", Body: "First"}}
+	ctx := auth.WithAuthUser(context.Background(), &auth.AuthUser{Username: "editor"})
+
+	// Simulate an update: snapshot first, then change the record
+	if err := SaveRecordVersion(ctx, adapter, resource, uint(1)); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	adapter.page = Page{ID: 1, Title: "Published", Body: "Second"}
+
+	versions, _ := store.ListVersions(ctx, "Page", "1")
+	if len(versions) != 1 || versions[0].ChangedBy != "editor" {
+		t.Fatalf("Expected one version by 'editor', got %+v", versions)
+	}
+
+	if err := RestoreRecordVersion(ctx, adapter, resource, uint(1), versions[0].ID); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if adapter.page.Title != "Draft" || adapter.page.Body != "First" {
+		t.Errorf("Expected record to be restored, got %+v", adapter.page)
+	}
+
+	versions, _ = store.ListVersions(ctx, "Page", "1")
+	if len(versions) != 2 || versions[0].Snapshot["Title"] != "Published" {
+		t.Errorf("Expected the replaced state to be kept as a new version, got %+v", versions)
+	}
+}
+
+func TestSaveRecordVersionOmitsEncryptedFields(t *testing.T) {
+	bo := &BackOffice{
+		resources:     make(map[string]*Resource),
+		resourceOrder: []string{},
+		config:        &Config{},
+	}
+	keys, err := NewKeyRing("k1", map[string][]byte{"k1": bytes.Repeat([]byte{7}, 32)})
+	if err != nil {
+		t.Fatalf("NewKeyRing failed: %v", err)
+	}
+	store := NewMemoryHistoryStore()
+	bo.RegisterResource(&Page{}).
+		WithHistory(store).
+		WithFields("Title").
+		WithField("Body", func(f *FieldBuilder) {
+			f.Encrypted(keys).Searchable(true)
+		})
+	resource, _ := bo.GetResource("Page")
+
+	adapter := &historyTestAdapter{page: Page{ID: 1, Title: "Draft", Body: "secret"}}
+	ctx := context.Background()
+	if err := SaveRecordVersion(ctx, adapter, resource, uint(1)); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	versions, _ := store.ListVersions(ctx, "Page", "1")
+	if len(versions) != 1 || versions[0].Snapshot["Title"] != "Draft" {
+		t.Fatalf("Expected one version with the title, got %+v", versions)
+	}
+	if _, ok := versions[0].Snapshot["Body"]; ok {
+		t.Error("Expected the encrypted field to be left out of the snapshot")
+	}
+
+	// A restore leaves the encrypted value as it is
+	adapter.page = Page{ID: 1, Title: "Published", Body: "rotated"}
+	if err := RestoreRecordVersion(ctx, adapter, resource, uint(1), versions[0].ID); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if adapter.page.Title != "Draft" || adapter.page.Body != "rotated" {
+		t.Errorf("Expected only the title to be restored, got %+v", adapter.page)
+	}
+
+	if len(resource.SearchableFields()) != 0 {
+		t.Error("Expected encrypted fields not to be searched")
+	}
+	if resource.IsFieldSortable("Body") || !resource.IsFieldSortable("Title") {
+		t.Error("Expected encrypted fields not to be sortable")
+	}
+}
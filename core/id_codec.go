@@ -0,0 +1,25 @@
+package core
+
+import "fmt"
+
+// IDCodec converts between a resource's stored IDs and their string form in URLs
+// Use it for prefixed IDs ("cus_ab12"), Sqids or composite keys
+type IDCodec interface {
+	// Parse decodes an ID from a URL segment into the value passed to the adapter
+	Parse(idStr string) (any, error)
+	// Format encodes an ID as it should appear in URLs
+	Format(id any) string
+}
+
+// FormatID returns the URL form of an ID, using the resource's IDCodec if one is set
+func (r *Resource) FormatID(id any) string {
+	if r.IDCodec != nil {
+		return r.IDCodec.Format(id)
+	}
+	return fmt.Sprintf("%v", id)
+}
+
+// RecordID returns the URL form of the item's primary key
+func (r *Resource) RecordID(item any) string {
+	return r.FormatID(GetFieldValue(item, r.IDField))
+}
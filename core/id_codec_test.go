@@ -0,0 +1,60 @@
+package core
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// prefixCodec encodes numeric IDs as "<prefix>_<id>"
+type prefixCodec struct {
+	prefix string
+}
+
+func (c prefixCodec) Parse(idStr string) (any, error) {
+	raw, ok := strings.CutPrefix(idStr, c.prefix+"_")
+	if !ok {
+		return nil, fmt.Errorf("missing %q prefix", c.prefix)
+	}
+	id, err := strconv.ParseUint(raw, 10, 64)
+	if err != nil {
+		return nil, err
+	}
+	return uint(id), nil
+}
+
+func (c prefixCodec) Format(id any) string {
+	return fmt.Sprintf("%s_%v", c.prefix, id)
+}
+
+func TestIDCodec(t *testing.T) {
+	type Customer struct {
+		ID   uint   `db:"id"`
+		Name string `db:"name"`
+	}
+
+	bo := &BackOffice{
+		resources:     make(map[string]*Resource),
+		resourceOrder: []string{},
+		config:        &Config{},
+	}
+	bo.RegisterResource(&Customer{}).WithIDCodec(prefixCodec{prefix: "cus"})
+	resource, _ := bo.GetResource("Customer")
+
+	id, err := resource.ParseID("cus_42")
+	if err != nil || id != uint(42) {
+		t.Errorf("Expected uint 42, got %v (err: %v)", id, err)
+	}
+	if _, err := resource.ParseID("42"); err == nil {
+		t.Error("Expected error for ID without prefix")
+	}
+	if got := resource.RecordID(&Customer{ID: 42}); got != "cus_42" {
+		t.Errorf("Expected 'cus_42', got '%s'", got)
+	}
+
+	resource.IDCodec = nil
+	if got := resource.RecordID(&Customer{ID: 42}); got != "42" {
+		t.Errorf("Expected '42' without a codec, got '%s'", got)
+	}
+}
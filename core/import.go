@@ -0,0 +1,199 @@
+package core
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// MaxImportRows caps how many rows a single CSV import may contain
+const MaxImportRows = 5000
+
+// ImportConfig controls how records are imported from CSV files
+type ImportConfig struct {
+	Fields   []string // Fields columns can be mapped to, in order; empty for every editable field
+	KeyField string   // Field matching rows to existing records, which are updated instead of created; empty to always create
+}
+
+// ImportFile is a parsed CSV upload: its header row and the data rows, padded to the header's width
+type ImportFile struct {
+	Headers []string
+	Rows    [][]string
+}
+
+// ImportRowError reports why a row of an import was rejected
+type ImportRowError struct {
+	Row     int // Line of the row in the file, the header being line 1
+	Message string
+}
+
+// ErrImportNoMatch is returned by FindImportMatch when no record has the row's key
+var ErrImportNoMatch = errors.New("no record matches the import key")
+
+// CanImport reports whether records of the resource can be imported
+func (r *Resource) CanImport() bool {
+	return r.Import != nil && !r.ReadOnly
+}
+
+// ImportFields returns the fields columns can be mapped to, in order
+// Without configured fields these are the editable fields, plus the key field when set
+func (r *Resource) ImportFields() ([]FieldInfo, error) {
+	if r.Import == nil {
+		return nil, nil
+	}
+	if len(r.Import.Fields) > 0 {
+		fields := make([]FieldInfo, 0, len(r.Import.Fields))
+		for _, name := range r.Import.Fields {
+			field, ok := r.GetField(name)
+			if !ok {
+				return nil, fmt.Errorf("unknown import field %s", name)
+			}
+			fields = append(fields, *field)
+		}
+		return fields, nil
+	}
+
+	var fields []FieldInfo
+	for _, field := range r.Fields {
+		key := field.Name == r.Import.KeyField
+		if !key && (field.PrimaryKey || field.ReadOnly || field.IsComputed || field.Relationship.IsManyToMany()) {
+			continue
+		}
+		if field.Relationship != nil && !field.Relationship.IsPicker() {
+			continue
+		}
+		fields = append(fields, field)
+	}
+	return fields, nil
+}
+
+// ReadImportCSV parses an uploaded CSV file, which must start with a header row
+func ReadImportCSV(r io.Reader) (*ImportFile, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	headers, err := reader.Read()
+	if err == io.EOF {
+		return nil, errors.New("the file is empty")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid CSV: %w", err)
+	}
+	if len(headers) > 0 {
+		headers[0] = strings.TrimPrefix(headers[0], "\ufeff") // Spreadsheets often save a byte order mark
+	}
+	for i := range headers {
+		headers[i] = strings.TrimSpace(headers[i])
+	}
+
+	file := &ImportFile{Headers: headers}
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return file, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid CSV: %w", err)
+		}
+		if isBlankRecord(record) {
+			continue
+		}
+		if len(file.Rows) == MaxImportRows {
+			return nil, fmt.Errorf("the file exceeds the limit of %d rows", MaxImportRows)
+		}
+		row := make([]string, len(headers))
+		copy(row, record)
+		file.Rows = append(file.Rows, row)
+	}
+}
+
+func isBlankRecord(record []string) bool {
+	for _, value := range record {
+		if strings.TrimSpace(value) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// SuggestImportMapping maps each column to the import field whose name, display name or column it matches,
+// ignoring case, spaces and underscores; unmatched columns map to ""
+func (r *Resource) SuggestImportMapping(headers []string) []string {
+	fields, _ := r.ImportFields()
+	mapping := make([]string, len(headers))
+	used := make(map[string]bool)
+	for i, header := range headers {
+		key := importMatchKey(header)
+		for _, field := range fields {
+			if used[field.Name] {
+				continue
+			}
+			if key == importMatchKey(field.Name) || key == importMatchKey(field.DisplayName) || key == importMatchKey(field.JSONName) {
+				mapping[i] = field.Name
+				used[field.Name] = true
+				break
+			}
+		}
+	}
+	return mapping
+}
+
+func importMatchKey(name string) string {
+	return strings.NewReplacer(" ", "", "_", "", "-", "").Replace(strings.ToLower(strings.TrimSpace(name)))
+}
+
+// FindImportMatch returns the record whose key field equals a row's key value, within the user's scope
+// It returns ErrImportNoMatch when there is none, and an error when the key matches several records
+func FindImportMatch(ctx context.Context, adapter Adapter, resource *Resource, value string) (any, error) {
+	field, ok := resource.GetField(resource.Import.KeyField)
+	if !ok {
+		return nil, fmt.Errorf("unknown import key field %s", resource.Import.KeyField)
+	}
+
+	if field.PrimaryKey {
+		id, err := resource.ParseStoredID(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s %q", field.DisplayName, value)
+		}
+		item, err := GetScopedByID(ctx, adapter, resource, id)
+		if err != nil || item == nil {
+			return nil, ErrImportNoMatch
+		}
+		return item, nil
+	}
+
+	query := resource.ApplyScope(ctx, NewQuery().WithFilters(map[string]any{field.Name: value}).WithPagination(2, 0))
+	result, err := adapter.Find(ctx, resource, query)
+	if err != nil {
+		return nil, err
+	}
+	switch len(result.Items) {
+	case 0:
+		return nil, ErrImportNoMatch
+	case 1:
+		return result.Items[0], nil
+	default:
+		return nil, fmt.Errorf("several records have %s %q", field.DisplayName, value)
+	}
+}
+
+// WriteImportErrors writes the rejected rows as CSV, with the reason in an extra Error column
+func WriteImportErrors(w io.Writer, file *ImportFile, errs []ImportRowError) error {
+	writer := csv.NewWriter(w)
+	header := append(append([]string{"Row"}, file.Headers...), "Error")
+	if err := writer.Write(header); err != nil {
+		return err
+	}
+	for _, rowErr := range errs {
+		record := append(append([]string{fmt.Sprint(rowErr.Row)}, file.Rows[rowErr.Row-2]...), rowErr.Message)
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
@@ -0,0 +1,90 @@
+package core
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/preslavrachev/backoffice/middleware/auth"
+)
+
+type importContact struct {
+	ID        uint   `db:"id"`
+	Email     string `db:"email"`
+	FullName  string `db:"full_name"`
+	Phone     string `db:"phone"`
+	CreatedBy string `db:"created_by"`
+}
+
+func newImportContactResource(config ImportConfig) *Resource {
+	bo := New(&DummyAdapter{}, auth.AuthConfig{})
+	bo.RegisterResource(&importContact{}).
+		WithFields("ID", "Email", "FullName", "Phone").
+		WithField("CreatedBy", func(f *FieldBuilder) { f.ReadOnly(true) }).
+		WithImport(config)
+	resource, _ := bo.GetResource("importContact")
+	return resource
+}
+
+func TestReadImportCSV(t *testing.T) {
+	file, err := ReadImportCSV(strings.NewReader("\ufeffEmail, Full Name ,Phone\na@example.com,Ann\n,,\nb@example.com,Bob,555\n"))
+	if err != nil {
+		t.Fatalf("ReadImportCSV failed: %v", err)
+	}
+	if want := []string{"Email", "Full Name", "Phone"}; !reflect.DeepEqual(file.Headers, want) {
+		t.Errorf("Expected headers %v without the byte order mark, got %v", want, file.Headers)
+	}
+	want := [][]string{{"a@example.com", "Ann", ""}, {"b@example.com", "Bob", "555"}}
+	if !reflect.DeepEqual(file.Rows, want) {
+		t.Errorf("Expected blank rows skipped and short rows padded, got %v", file.Rows)
+	}
+
+	if _, err := ReadImportCSV(strings.NewReader("")); err == nil {
+		t.Error("Expected an error for an empty file")
+	}
+	tooLong := "Email\n" + strings.Repeat("x\n", MaxImportRows+1)
+	if _, err := ReadImportCSV(strings.NewReader(tooLong)); err == nil {
+		t.Error("Expected an error for a file over the row limit")
+	}
+}
+
+func TestImportFields(t *testing.T) {
+	fields, err := newImportContactResource(ImportConfig{}).ImportFields()
+	if err != nil {
+		t.Fatalf("ImportFields failed: %v", err)
+	}
+	var names []string
+	for _, field := range fields {
+		names = append(names, field.Name)
+	}
+	if want := []string{"Email", "FullName", "Phone"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("Expected the editable fields %v, got %v", want, names)
+	}
+
+	if _, err := newImportContactResource(ImportConfig{Fields: []string{"Nope"}}).ImportFields(); err == nil {
+		t.Error("Expected an error for an unknown configured field")
+	}
+}
+
+func TestSuggestImportMapping(t *testing.T) {
+	resource := newImportContactResource(ImportConfig{})
+	mapping := resource.SuggestImportMapping([]string{"E-mail", "full_name", "Notes", "email"})
+	if want := []string{"Email", "FullName", "", ""}; !reflect.DeepEqual(mapping, want) {
+		t.Errorf("Expected mapping %v, got %v", want, mapping)
+	}
+}
+
+func TestWriteImportErrors(t *testing.T) {
+	file := &ImportFile{Headers: []string{"Email", "Phone"}, Rows: [][]string{{"a@example.com", "1"}, {"", "2"}}}
+	var buf bytes.Buffer
+	if err := WriteImportErrors(&buf, file, []ImportRowError{{Row: 3, Message: "required fields missing: Email"}}); err != nil {
+		t.Fatalf("WriteImportErrors failed: %v", err)
+	}
+	if want := "Row,Email,Phone,Error\n3,,2,required fields missing: Email\n"; buf.String() != want {
+		t.Errorf("Expected %q, got %q", want, buf.String())
+	}
+	if len(file.Headers) != 2 {
+		t.Error("Expected the file's headers to be left untouched")
+	}
+}
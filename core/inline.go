@@ -0,0 +1,113 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+)
+
+// InlineConfig describes child records that are edited inline on the parent's detail page
+type InlineConfig struct {
+	Resource   string   // Name of the child resource, e.g. "Employee"
+	ForeignKey string   // Child field holding the parent's ID, e.g. "DepartmentID"
+	Fields     []string // Child fields shown as columns, empty for all editable fields
+}
+
+// GetInline returns the inline configuration for the given child resource
+func (r *Resource) GetInline(childName string) (*InlineConfig, bool) {
+	for i := range r.Inlines {
+		if r.Inlines[i].Resource == childName {
+			return &r.Inlines[i], true
+		}
+	}
+	return nil, false
+}
+
+// InlineFields returns the child fields shown as columns in the inline table
+// The primary key, foreign key, computed and relationship fields are never editable inline
+func InlineFields(child *Resource, inline *InlineConfig) []FieldInfo {
+	editable := func(field FieldInfo) bool {
+		if field.PrimaryKey || field.IsComputed || field.Name == inline.ForeignKey || field.Name == child.SoftDeleteField {
+			return false
+		}
+		return field.Relationship == nil || field.Relationship.Type == RelationshipNone
+	}
+
+	var fields []FieldInfo
+	if len(inline.Fields) > 0 {
+		for _, name := range inline.Fields {
+			if field, ok := child.GetField(name); ok && editable(*field) {
+				fields = append(fields, *field)
+			}
+		}
+		return fields
+	}
+
+	for _, field := range child.Fields {
+		if editable(field) {
+			fields = append(fields, field)
+		}
+	}
+	return fields
+}
+
+// FindInlineChildren returns the child records belonging to the parent, within the child's scope
+func FindInlineChildren(ctx context.Context, adapter Adapter, child *Resource, inline *InlineConfig, parentID any) ([]any, error) {
+	query := childQuery(ctx, NewQuery().WithPagination(MaxPageSize, 0), child, inline.ForeignKey, parentID)
+
+	for _, sort := range child.GetEffectiveDefaultSorts() {
+		if sort.Field != "" {
+			query.WithSort(sort.Field, sort.Direction)
+		}
+	}
+
+	result, err := adapter.Find(ctx, child, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load %s: %w", child.PluralName, err)
+	}
+	if result == nil {
+		return nil, nil
+	}
+	return result.Items, nil
+}
+
+// GetInlineChild fetches a child record, returning ErrOutOfScope if it belongs to another parent
+func GetInlineChild(ctx context.Context, adapter Adapter, child *Resource, inline *InlineConfig, parentID, childID any) (any, error) {
+	item, err := GetScopedByID(ctx, adapter, child, childID)
+	if err != nil {
+		return nil, err
+	}
+	if fmt.Sprintf("%v", GetFieldValue(item, inline.ForeignKey)) != fmt.Sprintf("%v", parentID) {
+		return nil, ErrOutOfScope
+	}
+	return item, nil
+}
+
+// SetParentKey points the child record at its parent by setting the inline foreign key
+func SetParentKey(item any, inline *InlineConfig, parentID any) error {
+	val := reflect.ValueOf(item)
+	if val.Kind() != reflect.Ptr || val.IsNil() || val.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("expected a pointer to a struct, got %T", item)
+	}
+
+	field := val.Elem().FieldByName(inline.ForeignKey)
+	if !field.IsValid() || !field.CanSet() {
+		return fmt.Errorf("foreign key field %s not found", inline.ForeignKey)
+	}
+
+	id := reflect.ValueOf(parentID)
+	if field.Kind() == reflect.Ptr {
+		if !id.Type().ConvertibleTo(field.Type().Elem()) {
+			return fmt.Errorf("cannot assign %T to %s", parentID, inline.ForeignKey)
+		}
+		ptr := reflect.New(field.Type().Elem())
+		ptr.Elem().Set(id.Convert(field.Type().Elem()))
+		field.Set(ptr)
+		return nil
+	}
+	if !id.Type().ConvertibleTo(field.Type()) {
+		return fmt.Errorf("cannot assign %T to %s", parentID, inline.ForeignKey)
+	}
+	field.Set(id.Convert(field.Type()))
+	return nil
+}
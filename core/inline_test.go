@@ -0,0 +1,98 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+type Employee struct {
+	ID           uint   `db:"id"`
+	Name         string `db:"name"`
+	Active       bool   `db:"active"`
+	DepartmentID uint   `db:"department_id"`
+}
+
+type inlineTestAdapter struct {
+	DummyAdapter
+	employees map[uint]*Employee
+}
+
+func (a *inlineTestAdapter) GetByID(ctx context.Context, resource *Resource, id any) (any, error) {
+	if employee, ok := a.employees[id.(uint)]; ok {
+		return employee, nil
+	}
+	return nil, errors.New("not found")
+}
+
+func (a *inlineTestAdapter) Find(ctx context.Context, resource *Resource, query *Query) (*Result, error) {
+	var items []any
+	for _, employee := range a.employees {
+		if departmentID, ok := query.Filters["DepartmentID"]; ok && departmentID != employee.DepartmentID {
+			continue
+		}
+		items = append(items, employee)
+	}
+	return &Result{Items: items, TotalCount: int64(len(items))}, nil
+}
+
+func newInlineTestResource() *Resource {
+	bo := &BackOffice{
+		resources:     make(map[string]*Resource),
+		resourceOrder: []string{},
+		config:        &Config{},
+	}
+	bo.RegisterResource(&Employee{}).
+		WithField("Name", func(f *FieldBuilder) {}).
+		WithField("Active", func(f *FieldBuilder) {}).
+		WithField("DepartmentID", func(f *FieldBuilder) {})
+	resource, _ := bo.GetResource("Employee")
+	return resource
+}
+
+func TestInlineFields(t *testing.T) {
+	child := newInlineTestResource()
+
+	fields := InlineFields(child, &InlineConfig{Resource: "Employee", ForeignKey: "DepartmentID"})
+	if len(fields) != 2 || fields[0].Name != "Name" || fields[1].Name != "Active" {
+		t.Errorf("Expected Name and Active, got %+v", fields)
+	}
+
+	fields = InlineFields(child, &InlineConfig{Resource: "Employee", ForeignKey: "DepartmentID", Fields: []string{"Active", "ID", "DepartmentID"}})
+	if len(fields) != 1 || fields[0].Name != "Active" {
+		t.Errorf("Expected only Active, got %+v", fields)
+	}
+}
+
+func TestInlineChildren(t *testing.T) {
+	child := newInlineTestResource()
+	inline := &InlineConfig{Resource: "Employee", ForeignKey: "DepartmentID"}
+	adapter := &inlineTestAdapter{employees: map[uint]*Employee{
+		1: {ID: 1, Name: "Ada", DepartmentID: 10},
+		2: {ID: 2, Name: "Grace", DepartmentID: 20},
+	}}
+	ctx := context.Background()
+
+	items, err := FindInlineChildren(ctx, adapter, child, inline, uint(10))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(items) != 1 || items[0].(*Employee).Name != "Ada" {
+		t.Errorf("Expected only Ada, got %+v", items)
+	}
+
+	if _, err := GetInlineChild(ctx, adapter, child, inline, uint(10), uint(1)); err != nil {
+		t.Errorf("Expected own child to be found, got %v", err)
+	}
+	if _, err := GetInlineChild(ctx, adapter, child, inline, uint(10), uint(2)); !errors.Is(err, ErrOutOfScope) {
+		t.Errorf("Expected ErrOutOfScope for another parent's child, got %v", err)
+	}
+
+	employee := &Employee{}
+	if err := SetParentKey(employee, inline, uint(10)); err != nil || employee.DepartmentID != 10 {
+		t.Errorf("Expected DepartmentID 10, got %d (err: %v)", employee.DepartmentID, err)
+	}
+	if err := SetParentKey(employee, &InlineConfig{ForeignKey: "Missing"}, uint(10)); err == nil {
+		t.Error("Expected error for unknown foreign key")
+	}
+}
@@ -0,0 +1,90 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"time"
+)
+
+// DefaultLockField is the field compared to detect concurrent edits when no lock field is configured
+const DefaultLockField = "UpdatedAt"
+
+// ErrStaleRecord is returned when a record changed after it was loaded for editing
+var ErrStaleRecord = errors.New("record was changed by someone else")
+
+// LockFieldName returns the field guarding records against concurrent edits, or "" when the model has none
+func (r *Resource) LockFieldName() string {
+	name := r.LockField
+	if name == "" {
+		name = DefaultLockField
+	}
+	if r.ModelType == nil || r.ModelType.Kind() != reflect.Ptr {
+		return ""
+	}
+	if _, ok := r.ModelType.Elem().FieldByName(name); !ok {
+		return ""
+	}
+	return name
+}
+
+// LockVersion returns the lock field value of a record as a token for CheckLockVersion
+// It is empty when the resource has no lock field
+func LockVersion(resource *Resource, item any) string {
+	name := resource.LockFieldName()
+	if name == "" {
+		return ""
+	}
+
+	switch value := GetFieldValue(item, name).(type) {
+	case time.Time:
+		return value.UTC().Format(time.RFC3339Nano)
+	case *time.Time:
+		if value == nil {
+			return ""
+		}
+		return value.UTC().Format(time.RFC3339Nano)
+	case nil:
+		return ""
+	default:
+		return fmt.Sprintf("%v", value)
+	}
+}
+
+// CheckLockVersion returns ErrStaleRecord when a record no longer has the version it was edited at
+// An empty version skips the check
+func CheckLockVersion(resource *Resource, item any, version string) error {
+	if version == "" {
+		return nil
+	}
+	if LockVersion(resource, item) != version {
+		return fmt.Errorf("%s: %w", resource.DisplayName, ErrStaleRecord)
+	}
+	return nil
+}
+
+// BumpLockVersion advances the lock field of a record about to be saved,
+// incrementing integer versions and setting timestamps to the current time
+func BumpLockVersion(resource *Resource, item any) {
+	name := resource.LockFieldName()
+	val := reflect.ValueOf(item)
+	if name == "" || val.Kind() != reflect.Ptr || val.IsNil() || val.Elem().Kind() != reflect.Struct {
+		return
+	}
+
+	field := val.Elem().FieldByName(name)
+	if !field.CanSet() {
+		return
+	}
+	now := time.Now()
+	switch {
+	case field.Type() == reflect.TypeOf(now):
+		field.Set(reflect.ValueOf(now))
+	case field.Type() == reflect.TypeOf(&now):
+		field.Set(reflect.ValueOf(&now))
+	case field.CanInt():
+		field.SetInt(field.Int() + 1)
+	case field.CanUint():
+		field.SetUint(field.Uint() + 1)
+	}
+}
@@ -0,0 +1,71 @@
+package core
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+type lockedTicket struct {
+	ID      uint
+	Title   string
+	Version int
+}
+
+type timestampedTicket struct {
+	ID        uint
+	Title     string
+	UpdatedAt time.Time
+}
+
+func TestOptimisticLock_VersionField(t *testing.T) {
+	bo := &BackOffice{resources: make(map[string]*Resource), resourceOrder: []string{}, config: &Config{}}
+	bo.RegisterResource(&lockedTicket{}).WithFields("Title").WithOptimisticLock("Version")
+	resource, _ := bo.GetResource("lockedTicket")
+
+	ticket := &lockedTicket{ID: 1, Title: "Printer jam", Version: 3}
+	version := LockVersion(resource, ticket)
+	if version != "3" {
+		t.Fatalf("Expected version 3, got %q", version)
+	}
+	if err := CheckLockVersion(resource, ticket, version); err != nil {
+		t.Errorf("Expected the current version to pass, got %v", err)
+	}
+
+	BumpLockVersion(resource, ticket)
+	if ticket.Version != 4 {
+		t.Errorf("Expected the version to be incremented, got %d", ticket.Version)
+	}
+	if err := CheckLockVersion(resource, ticket, version); !errors.Is(err, ErrStaleRecord) {
+		t.Errorf("Expected ErrStaleRecord for an old version, got %v", err)
+	}
+	if err := CheckLockVersion(resource, ticket, ""); err != nil {
+		t.Errorf("Expected a missing version to skip the check, got %v", err)
+	}
+}
+
+func TestOptimisticLock_DefaultField(t *testing.T) {
+	bo := &BackOffice{resources: make(map[string]*Resource), resourceOrder: []string{}, config: &Config{}}
+	bo.RegisterResource(&timestampedTicket{}).WithFields("Title")
+	bo.RegisterResource(&lockedTicket{}).WithFields("Title")
+	timestamped, _ := bo.GetResource("timestampedTicket")
+	unlocked, _ := bo.GetResource("lockedTicket")
+
+	if name := timestamped.LockFieldName(); name != DefaultLockField {
+		t.Errorf("Expected UpdatedAt to guard edits by default, got %q", name)
+	}
+	if name := unlocked.LockFieldName(); name != "" {
+		t.Errorf("Expected no lock field without UpdatedAt, got %q", name)
+	}
+
+	updatedAt := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	ticket := &timestampedTicket{ID: 1, UpdatedAt: updatedAt}
+	version := LockVersion(timestamped, ticket)
+	BumpLockVersion(timestamped, ticket)
+	if !ticket.UpdatedAt.After(updatedAt) {
+		t.Error("Expected the timestamp to be refreshed")
+	}
+	if err := CheckLockVersion(timestamped, ticket, version); !errors.Is(err, ErrStaleRecord) {
+		t.Errorf("Expected ErrStaleRecord after the timestamp changed, got %v", err)
+	}
+}
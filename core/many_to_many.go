@@ -0,0 +1,61 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// ErrLinksUnsupported is returned when linking many-to-many records through an adapter without LinkStore
+var ErrLinksUnsupported = errors.New("adapter does not support many-to-many relationships")
+
+// LinkStore is implemented by adapters that keep many-to-many relationships in join tables
+type LinkStore interface {
+	// LinkedIDs returns the IDs of the related records linked to the record, in ascending order
+	LinkedIDs(ctx context.Context, resource *Resource, field *FieldInfo, id any) ([]any, error)
+	// SetLinks replaces the record's links with the given related IDs
+	SetLinks(ctx context.Context, resource *Resource, field *FieldInfo, id any, relatedIDs []any) error
+}
+
+// IsManyToMany reports whether a relationship links records through a join table
+func (ri *RelationshipInfo) IsManyToMany() bool {
+	return ri != nil && ri.Type == RelationshipManyToMany && ri.JoinTable != ""
+}
+
+// LinkedIDs returns the IDs of the records a many-to-many field links the record to
+func LinkedIDs(ctx context.Context, adapter Adapter, resource *Resource, field *FieldInfo, id any) ([]any, error) {
+	store, ok := adapter.(LinkStore)
+	if !ok {
+		return nil, ErrLinksUnsupported
+	}
+	if !field.Relationship.IsManyToMany() {
+		return nil, fmt.Errorf("field %s is not a many-to-many relationship", field.Name)
+	}
+	return store.LinkedIDs(ctx, resource, field, id)
+}
+
+// SetLinks replaces the records a many-to-many field links the record to
+// Duplicate IDs are linked once
+func SetLinks(ctx context.Context, adapter Adapter, resource *Resource, field *FieldInfo, id any, relatedIDs []any) error {
+	store, ok := adapter.(LinkStore)
+	if !ok {
+		return ErrLinksUnsupported
+	}
+	if !field.Relationship.IsManyToMany() {
+		return fmt.Errorf("field %s is not a many-to-many relationship", field.Name)
+	}
+
+	seen := make(map[string]bool)
+	unique := make([]any, 0, len(relatedIDs))
+	for _, relatedID := range relatedIDs {
+		key := fmt.Sprintf("%v", relatedID)
+		if !seen[key] {
+			seen[key] = true
+			unique = append(unique, relatedID)
+		}
+	}
+	if err := store.SetLinks(ctx, resource, field, id, unique); err != nil {
+		return fmt.Errorf("failed to link %s: %w", field.DisplayName, err)
+	}
+	return nil
+}
@@ -0,0 +1,48 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/preslavrachev/backoffice/middleware/auth"
+)
+
+type taggedPost struct {
+	ID   uint         `db:"id"`
+	Tags []*postTopic `db:"-"`
+}
+
+type postTopic struct {
+	ID   uint   `db:"id"`
+	Name string `db:"name"`
+}
+
+func TestManyToMany(t *testing.T) {
+	bo := New(&DummyAdapter{}, auth.AuthConfig{})
+	bo.RegisterResource(&taggedPost{}).
+		WithManyToManyField("Tags", "postTopic", func(r *RelationshipBuilder) {
+			r.JoinTable("post_tags", "post_id", "topic_id")
+		})
+	resource, _ := bo.GetResource("taggedPost")
+	field, _ := resource.GetField("Tags")
+
+	if !field.Relationship.IsManyToMany() || field.Relationship.JoinRelatedKey != "topic_id" {
+		t.Fatalf("Expected a many-to-many relationship through post_tags, got %+v", field.Relationship)
+	}
+	if _, err := LinkedIDs(context.Background(), &DummyAdapter{}, resource, field, uint(1)); !errors.Is(err, ErrLinksUnsupported) {
+		t.Errorf("Expected ErrLinksUnsupported, got %v", err)
+	}
+	if err := SetLinks(context.Background(), &DummyAdapter{}, resource, field, uint(1), nil); !errors.Is(err, ErrLinksUnsupported) {
+		t.Errorf("Expected ErrLinksUnsupported, got %v", err)
+	}
+
+	t.Run("health reports a missing join table", func(t *testing.T) {
+		bo := New(&DummyAdapter{}, auth.AuthConfig{})
+		bo.RegisterResource(&taggedPost{}).WithManyToManyField("Tags", "postTopic", nil)
+		if err := bo.Validate(); err == nil || !strings.Contains(err.Error(), "field Tags: many-to-many relationship needs a join table") {
+			t.Errorf("Expected a join table problem, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,131 @@
+package core
+
+import (
+	"fmt"
+	"html"
+	"regexp"
+	"strings"
+)
+
+var (
+	markdownHeading     = regexp.MustCompile(`^(#{1,6})\s+(.*?)\s*#*\s*$`)
+	markdownRule        = regexp.MustCompile(`^(\*\s*){3,}$|^(-\s*){3,}$|^(_\s*){3,}$`)
+	markdownBullet      = regexp.MustCompile(`^\s*[-*+]\s+(.*)$`)
+	markdownNumbered    = regexp.MustCompile(`^\s*\d+[.)]\s+(.*)$`)
+	markdownCodeSpan    = regexp.MustCompile("`([^`]+)`")
+	markdownLink        = regexp.MustCompile(`\[([^\]]+)\]\(([^)\s]+)\)`)
+	markdownBold        = regexp.MustCompile(`\*\*(.+?)\*\*|__(.+?)__`)
+	markdownItalic      = regexp.MustCompile(`\*([^*]+)\*`)
+	markdownUnderscore  = regexp.MustCompile(`(^|[^\w])_([^_]+)_([^\w]|$)`)
+	markdownStrike      = regexp.MustCompile(`~~(.+?)~~`)
+	markdownBlockStarts = []*regexp.Regexp{markdownHeading, markdownRule, markdownBullet, markdownNumbered}
+)
+
+// MarkdownToHTML renders markdown to HTML: headings, paragraphs, lists, block quotes, code, links and emphasis
+// Line breaks within paragraphs are kept; raw HTML in the source is escaped rather than rendered,
+// and only web, mail and relative links are kept
+func MarkdownToHTML(source string) string {
+	lines := strings.Split(strings.ReplaceAll(source, "\r\n", "\n"), "\n")
+	var sb strings.Builder
+	renderMarkdownBlocks(&sb, lines)
+	return sb.String()
+}
+
+// renderMarkdownBlocks renders block-level markdown, recursing into block quotes
+func renderMarkdownBlocks(sb *strings.Builder, lines []string) {
+	for i := 0; i < len(lines); {
+		line := lines[i]
+		trimmed := strings.TrimSpace(line)
+
+		switch {
+		case trimmed == "":
+			i++
+		case strings.HasPrefix(trimmed, "```"):
+			// Fenced code runs until the closing fence or the end of the text
+			i++
+			var code []string
+			for ; i < len(lines) && !strings.HasPrefix(strings.TrimSpace(lines[i]), "```"); i++ {
+				code = append(code, lines[i])
+			}
+			i++
+			sb.WriteString("<pre><code>" + html.EscapeString(strings.Join(code, "\n")) + "</code></pre>")
+		case markdownHeading.MatchString(trimmed):
+			match := markdownHeading.FindStringSubmatch(trimmed)
+			level := len(match[1])
+			fmt.Fprintf(sb, "<h%d>%s</h%d>", level, markdownInline(match[2]), level)
+			i++
+		case markdownRule.MatchString(trimmed):
+			sb.WriteString("<hr>")
+			i++
+		case strings.HasPrefix(trimmed, ">"):
+			var quoted []string
+			for ; i < len(lines) && strings.HasPrefix(strings.TrimSpace(lines[i]), ">"); i++ {
+				quoted = append(quoted, strings.TrimPrefix(strings.TrimPrefix(strings.TrimSpace(lines[i]), ">"), " "))
+			}
+			sb.WriteString("<blockquote>")
+			renderMarkdownBlocks(sb, quoted)
+			sb.WriteString("</blockquote>")
+		case markdownBullet.MatchString(line), markdownNumbered.MatchString(line):
+			pattern, tag := markdownBullet, "ul"
+			if !markdownBullet.MatchString(line) {
+				pattern, tag = markdownNumbered, "ol"
+			}
+			sb.WriteString("<" + tag + ">")
+			for ; i < len(lines) && pattern.MatchString(lines[i]); i++ {
+				sb.WriteString("<li>" + markdownInline(pattern.FindStringSubmatch(lines[i])[1]) + "</li>")
+			}
+			sb.WriteString("</" + tag + ">")
+		default:
+			// A paragraph runs until a blank line or the start of another block
+			var paragraph []string
+			for ; i < len(lines) && !startsMarkdownBlock(lines[i]); i++ {
+				paragraph = append(paragraph, strings.TrimSpace(lines[i]))
+			}
+			sb.WriteString("<p>" + markdownInline(strings.Join(paragraph, "\n")) + "</p>")
+		}
+	}
+}
+
+// startsMarkdownBlock reports whether a line ends a paragraph
+func startsMarkdownBlock(line string) bool {
+	trimmed := strings.TrimSpace(line)
+	if trimmed == "" || strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, ">") {
+		return true
+	}
+	for _, pattern := range markdownBlockStarts {
+		if pattern.MatchString(line) {
+			return true
+		}
+	}
+	return false
+}
+
+// markdownInline renders code spans, links and emphasis, escaping everything else
+func markdownInline(text string) string {
+	var sb strings.Builder
+	last := 0
+	for _, match := range markdownCodeSpan.FindAllStringSubmatchIndex(text, -1) {
+		sb.WriteString(markdownEmphasis(text[last:match[0]]))
+		sb.WriteString("<code>" + html.EscapeString(text[match[2]:match[3]]) + "</code>")
+		last = match[1]
+	}
+	sb.WriteString(markdownEmphasis(text[last:]))
+	return sb.String()
+}
+
+// markdownEmphasis renders the links and emphasis of text outside code spans
+func markdownEmphasis(text string) string {
+	escaped := html.EscapeString(text)
+	escaped = markdownLink.ReplaceAllStringFunc(escaped, func(link string) string {
+		match := markdownLink.FindStringSubmatch(link)
+		if !safeURL(html.UnescapeString(match[2])) {
+			return match[1]
+		}
+		return `<a href="` + match[2] + `">` + match[1] + `</a>`
+	})
+	escaped = markdownBold.ReplaceAllString(escaped, "<strong>$1$2</strong>")
+	escaped = markdownStrike.ReplaceAllString(escaped, "<s>$1</s>")
+	escaped = markdownItalic.ReplaceAllString(escaped, "<em>$1</em>")
+	escaped = markdownUnderscore.ReplaceAllString(escaped, "$1<em>$2</em>$3")
+	return strings.ReplaceAll(escaped, "\n", "<br>")
+}
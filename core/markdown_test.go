@@ -0,0 +1,31 @@
+package core
+
+import "testing"
+
+func TestMarkdownToHTML(t *testing.T) {
+	tests := []struct {
+		name   string
+		source string
+		want   string
+	}{
+		{"heading", "## Release notes", "<h2>Release notes</h2>"},
+		{"paragraphs", "First line\nsecond line\n\nNext", "<p>First line<br>second line</p><p>Next</p>"},
+		{"emphasis", "**bold**, *italic*, _also_ and ~~gone~~", "<p><strong>bold</strong>, <em>italic</em>, <em>also</em> and <s>gone</s></p>"},
+		{"snake case", "use max_page_size", "<p>use max_page_size</p>"},
+		{"code span", "run `a *b* <c>`", "<p>run <code>a *b* &lt;c&gt;</code></p>"},
+		{"lists", "- one\n- two\n\n1. first\n2. second", "<ul><li>one</li><li>two</li></ul><ol><li>first</li><li>second</li></ol>"},
+		{"quote", "> quoted *text*", "<blockquote><p>quoted <em>text</em></p></blockquote>"},
+		{"fenced code", "```\n<b>raw</b>\n```", "<pre><code>&lt;b&gt;raw&lt;/b&gt;</code></pre>"},
+		{"rule", "above\n\n---", "<p>above</p><hr>"},
+		{"link", "[docs](https://example.com/a?b=1&c=2)", `<p><a href="https://example.com/a?b=1&amp;c=2">docs</a></p>`},
+		{"unsafe link", "[click](javascript:alert(1))", "<p>click)</p>"},
+		{"raw html", `<img src=x onerror="alert(1)">`, "<p>&lt;img src=x onerror=&#34;alert(1)&#34;&gt;</p>"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := MarkdownToHTML(tt.source); got != tt.want {
+				t.Errorf("MarkdownToHTML(%q) = %q, want %q", tt.source, got, tt.want)
+			}
+		})
+	}
+}
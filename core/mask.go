@@ -0,0 +1,168 @@
+package core
+
+import (
+	"context"
+	"log"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/preslavrachev/backoffice/middleware/auth"
+)
+
+// OperationUnmask is the operation of revealing masked field values
+// Unlike the CRUD operations it must be granted explicitly, e.g. Allow("support", "User", OperationUnmask)
+const OperationUnmask Operation = "unmask"
+
+// UnmaskEvent is an audit record of masked values shown in the clear
+type UnmaskEvent struct {
+	User     string    `json:"user"` // Username of the signed-in user, "admin (as user)" while impersonating
+	Resource string    `json:"resource"`
+	RecordID string    `json:"record_id"` // Empty when the values of every exported record were revealed
+	Field    string    `json:"field"`
+	At       time.Time `json:"at"`
+}
+
+// UnmaskAuditor records unmask events
+type UnmaskAuditor interface {
+	RecordUnmask(ctx context.Context, event UnmaskEvent) error
+}
+
+// UnmaskAuditFunc adapts a function to UnmaskAuditor
+type UnmaskAuditFunc func(ctx context.Context, event UnmaskEvent) error
+
+// RecordUnmask calls the function
+func (f UnmaskAuditFunc) RecordUnmask(ctx context.Context, event UnmaskEvent) error {
+	return f(ctx, event)
+}
+
+// SetUnmaskAudit sets where unmask events are recorded, the standard logger by default
+func (bo *BackOffice) SetUnmaskAudit(auditor UnmaskAuditor) *BackOffice {
+	bo.unmaskAudit = auditor
+	return bo
+}
+
+// IsMasked reports whether the field's values are redacted for the request's user
+func (f *FieldInfo) IsMasked(ctx context.Context) bool {
+	if !f.Masked {
+		return false
+	}
+	if len(f.MaskRoles) == 0 {
+		return true
+	}
+	user, _ := auth.GetAuthUser(ctx)
+	return user.HasRole(f.MaskRoles...)
+}
+
+// MaskedText returns the record's value of the field partially redacted, e.g. "j***@e***.com"
+func (f *FieldInfo) MaskedText(item any) string {
+	return MaskValue(f.CopyText(item))
+}
+
+// CanUnmask reports whether the request's user may reveal the masked values of the resource
+// With authentication enabled, it takes a policy granting OperationUnmask
+func (r *Resource) CanUnmask(ctx context.Context) bool {
+	bo := r.backoffice
+	if bo == nil || bo.config.Auth == nil || !bo.config.Auth.Enabled {
+		return true
+	}
+	if bo.policy == nil {
+		return false
+	}
+	user, _ := auth.GetAuthUser(ctx)
+	return bo.policy.Can(user, OperationUnmask, r.Name)
+}
+
+// RecordUnmask audits that the field's value of a record was revealed to the request's user
+// Pass an empty recordID when the values of a whole export were revealed
+func (r *Resource) RecordUnmask(ctx context.Context, recordID string, field *FieldInfo) {
+	event := UnmaskEvent{
+		User:     auditName(ctx),
+		Resource: r.Name,
+		RecordID: recordID,
+		Field:    field.Name,
+		At:       time.Now(),
+	}
+	var auditor UnmaskAuditor
+	if r.backoffice != nil {
+		auditor = r.backoffice.unmaskAudit
+	}
+	if auditor == nil {
+		log.Printf("unmask: %s revealed %s.%s of %q", event.User, event.Resource, event.Field, event.RecordID)
+		return
+	}
+	if err := auditor.RecordUnmask(ctx, event); err != nil {
+		log.Printf("failed to record unmask of %s.%s by %s: %v", event.Resource, event.Field, event.User, err)
+	}
+}
+
+// revealExportFields returns a copy of the export fields where the masked fields the request's user
+// may reveal are unmasked, auditing each of them
+func (r *Resource) revealExportFields(ctx context.Context, fields []FieldInfo) []FieldInfo {
+	revealed := append([]FieldInfo(nil), fields...)
+	for i := range revealed {
+		if revealed[i].IsMasked(ctx) && r.CanUnmask(ctx) {
+			revealed[i].Masked = false
+			r.RecordUnmask(ctx, "", &revealed[i])
+		}
+	}
+	return revealed
+}
+
+// MaskValue partially redacts a value: emails keep the first letters and the top-level domain
+// ("j***@e***.com"), phone numbers their last two digits ("+* *** *** **67"), anything else its first letter
+func MaskValue(value string) string {
+	if value == "" {
+		return ""
+	}
+	if local, domain, ok := cutLast(value, "@"); ok {
+		masked := maskPrefix(local) + "@"
+		if name, tld, ok := cutLast(domain, "."); ok {
+			return masked + maskPrefix(name) + "." + tld
+		}
+		return masked + maskPrefix(domain)
+	}
+	if digits := countDigits(value); digits >= 7 {
+		return maskDigits(value, digits-2)
+	}
+	return maskPrefix(value)
+}
+
+// maskPrefix keeps the first letter of a value, hiding its length
+func maskPrefix(value string) string {
+	for _, r := range value {
+		return string(r) + "***"
+	}
+	return "***"
+}
+
+// maskDigits replaces the first n digits of a value, keeping separators so phone numbers stay recognizable
+func maskDigits(value string, n int) string {
+	var b strings.Builder
+	for _, r := range value {
+		if unicode.IsDigit(r) && n > 0 {
+			r = '*'
+			n--
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+func countDigits(value string) int {
+	count := 0
+	for _, r := range value {
+		if unicode.IsDigit(r) {
+			count++
+		}
+	}
+	return count
+}
+
+// cutLast slices value around the last instance of sep
+func cutLast(value, sep string) (before, after string, found bool) {
+	if i := strings.LastIndex(value, sep); i >= 0 {
+		return value[:i], value[i+len(sep):], true
+	}
+	return value, "", false
+}
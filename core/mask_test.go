@@ -0,0 +1,118 @@
+package core
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/preslavrachev/backoffice/middleware/auth"
+)
+
+func TestMaskValue(t *testing.T) {
+	tests := map[string]string{
+		"john@example.com": "j***@e***.com",
+		"jo@localhost":     "j***@l***",
+		"+1 555 123 4567":  "+* *** *** **67",
+		"(555) 123-4567":   "(***) ***-**67",
+		"Jane Doe":         "J***",
+		"Émile":            "É***",
+		"":                 "",
+	}
+	for value, want := range tests {
+		if got := MaskValue(value); got != want {
+			t.Errorf("MaskValue(%q) = %q, want %q", value, got, want)
+		}
+	}
+}
+
+func TestMaskFor(t *testing.T) {
+	type Customer struct {
+		ID    uint   `db:"id"`
+		Email string `db:"email"`
+		Phone string `db:"phone"`
+	}
+
+	var events []UnmaskEvent
+	bo := New(&DummyAdapter{}, auth.AuthConfig{Enabled: true})
+	bo.RegisterResource(&Customer{}).
+		WithField("Email", func(f *FieldBuilder) { f.MaskFor("support", "sales") }).
+		WithField("Phone", func(f *FieldBuilder) { f.MaskFor() })
+	bo.SetPolicy(NewRolePolicy().Allow("support", "Customer", OperationUnmask))
+	bo.SetUnmaskAudit(UnmaskAuditFunc(func(ctx context.Context, event UnmaskEvent) error {
+		events = append(events, event)
+		return nil
+	}))
+	resource, _ := bo.GetResource("Customer")
+	email, _ := resource.GetField("Email")
+	phone, _ := resource.GetField("Phone")
+	support := auth.WithAuthUser(context.Background(), &auth.AuthUser{Username: "sue", Roles: []string{"support"}})
+	sales := auth.WithAuthUser(context.Background(), &auth.AuthUser{Username: "sam", Roles: []string{"sales"}})
+	admin := auth.WithAuthUser(context.Background(), &auth.AuthUser{Username: "ann", Roles: []string{"admin"}})
+
+	if !email.IsMasked(support) || !email.IsMasked(sales) || email.IsMasked(admin) {
+		t.Error("Expected emails to be masked for support and sales only")
+	}
+	if !phone.IsMasked(admin) {
+		t.Error("Expected phones to be masked for everyone")
+	}
+	if !resource.CanUnmask(support) || resource.CanUnmask(sales) {
+		t.Error("Expected only support to be granted unmasking")
+	}
+
+	customer := &Customer{ID: 1, Email: "john@example.com", Phone: "555 123 4567"}
+	export := func(ctx context.Context) map[string]any {
+		var buf bytes.Buffer
+		if err := WriteExport(ctx, &buf, resource, []any{customer}, ExportJSON); err != nil {
+			t.Fatalf("WriteExport failed: %v", err)
+		}
+		var records []map[string]any
+		if err := json.Unmarshal(buf.Bytes(), &records); err != nil {
+			t.Fatalf("Invalid JSON export: %v", err)
+		}
+		return records[0]
+	}
+
+	if record := export(sales); record["Email"] != "j***@e***.com" || record["Phone"] != "*** *** **67" {
+		t.Errorf("Expected masked values in the export of sales, got %v", record)
+	}
+	if len(events) != 0 {
+		t.Errorf("Expected masked exports not to be audited, got %v", events)
+	}
+
+	if record := export(support); record["Email"] != "john@example.com" || record["Phone"] != "555 123 4567" {
+		t.Errorf("Expected support to export the values unmasked, got %v", record)
+	}
+	if len(events) != 2 || events[0].User != "sue" || events[0].Field != "Email" || events[0].RecordID != "" {
+		t.Errorf("Expected an unmask event per revealed field, got %v", events)
+	}
+
+	// The detail page's JSON stays masked until values are revealed one by one
+	data, err := RecordJSON(support, resource, customer)
+	if err != nil {
+		t.Fatalf("RecordJSON failed: %v", err)
+	}
+	if !strings.Contains(data, "j***@e***.com") {
+		t.Errorf("Expected the record JSON to be masked, got %s", data)
+	}
+}
+
+func TestMaskedFieldsNotSearchable(t *testing.T) {
+	type Customer struct {
+		ID    uint   `db:"id"`
+		Name  string `db:"name"`
+		Email string `db:"email"`
+	}
+
+	bo := New(&DummyAdapter{}, auth.AuthConfig{Enabled: true})
+	bo.RegisterResource(&Customer{}).
+		WithField("Name", func(f *FieldBuilder) { f.Searchable(true) }).
+		WithField("Email", func(f *FieldBuilder) { f.Searchable(true).MaskFor("support") })
+	resource, _ := bo.GetResource("Customer")
+
+	fields := resource.SearchableFields()
+	if len(fields) != 1 || fields[0].Name != "Name" {
+		t.Errorf("Expected only the unmasked field to be searched, got %+v", fields)
+	}
+}
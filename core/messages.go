@@ -0,0 +1,412 @@
+package core
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Messages maps message keys to the text shown in the admin UI
+// Texts may contain fmt verbs, filled with the arguments passed to Translate
+type Messages map[string]string
+
+// englishMessages is the built-in catalog, used for keys a locale doesn't translate
+var englishMessages = Messages{
+	"action.add":                         "Add",
+	"action.apply":                       "Apply",
+	"action.cancel":                      "Cancel",
+	"action.clear":                       "Clear",
+	"action.close":                       "Close",
+	"action.copy":                        "Copy",
+	"action.delete":                      "Delete",
+	"action.delete_permanently_prompt":   "Delete permanently…",
+	"action.delete_prompt":               "Delete…",
+	"action.deleting":                    "Deleting...",
+	"action.duplicate":                   "Duplicate",
+	"action.edit":                        "Edit",
+	"action.history":                     "History",
+	"action.remove":                      "Remove",
+	"action.restore":                     "Restore",
+	"action.save":                        "Save",
+	"action.view":                        "View",
+	"cell.edit_hint":                     "Double-click to edit",
+	"chart.empty":                        "No data yet.",
+	"columns.move_down":                  "Move down",
+	"columns.move_up":                    "Move up",
+	"columns.reset":                      "Reset",
+	"columns.title":                      "Columns",
+	"csrf.message":                       "This form has expired or was sent from another site. Reload the page and try again.",
+	"csrf.reload":                        "Back to the page",
+	"csrf.title":                         "Request could not be verified",
+	"dashboard.view_all":                 "View all %s →",
+	"detail.edit":                        "Edit %s",
+	"detail.information":                 "%s Information",
+	"detail.quick_actions":               "Quick Actions",
+	"detail.title":                       "%s Details",
+	"error.action_failed":                "Action failed: %v",
+	"error.action_forbidden":             "You don't have permission to run %s",
+	"error.action_not_found":             "Action not found",
+	"error.action_required":              "Action ID is required",
+	"error.choices_failed":               "Failed to load %s choices: %v",
+	"error.conflict":                     "This %s was changed by someone else; reload to see the latest version",
+	"error.create_failed":                "Failed to create item: %v",
+	"error.delete_failed":                "Failed to delete %s: %v",
+	"error.delete_read_only":             "Cannot delete: Resource is read-only",
+	"error.export_failed":                "Export failed: %v",
+	"error.export_format":                "%s cannot be exported as %s",
+	"error.forbidden":                    "You don't have permission to %s %s",
+	"error.get_failed":                   "Failed to get item: %v",
+	"error.history_failed":               "Failed to record history: %v",
+	"error.history_load_failed":          "Failed to load history: %v",
+	"error.impersonation_start":          "Failed to start impersonation",
+	"error.impersonation_stop":           "Failed to stop impersonation",
+	"error.import_step":                  "Unknown import step",
+	"error.invalid_data":                 "Invalid data format: %v",
+	"error.invalid_form":                 "Invalid form data",
+	"error.invalid_id":                   "Invalid ID format",
+	"error.invalid_id_value":             "Invalid ID format: %s",
+	"error.invalid_operation":            "Invalid API operation",
+	"error.invalid_path":                 "Invalid API path",
+	"error.invalid_report":               "Invalid error report",
+	"error.invalid_version":              "Invalid version ID",
+	"error.invalid_view":                 "Invalid view ID",
+	"error.item_delete_failed":           "Failed to delete item: %v",
+	"error.links_failed":                 "Failed to save links: %v",
+	"error.list_failed":                  "Failed to get items: %v",
+	"error.load_failed":                  "Failed to load %s: %v",
+	"error.method":                       "Method not allowed",
+	"error.no_child_list":                "Field '%s' has no child list",
+	"error.no_choices":                   "Field '%s' has no choices",
+	"error.no_columns":                   "Pick at least one column",
+	"error.no_inline":                    "%s has no inline %s",
+	"error.no_selection":                 "No records selected",
+	"error.no_stats":                     "Resource has no stats",
+	"error.not_found":                    "%s not found",
+	"error.not_inline_editable":          "%s can't be edited in the list",
+	"error.not_masked":                   "Field '%s' is not masked",
+	"error.owner_failed":                 "Failed to set owner: %v",
+	"error.owner_only":                   "You can only %s your own %s",
+	"error.preference":                   "Unknown display preference",
+	"error.read_only":                    "Resource is read-only",
+	"error.remember_failed":              "Failed to remember sign-in",
+	"error.render":                       "Template rendering error",
+	"error.record_in_use":                "still referenced by other records",
+	"error.reorder_failed":               "Failed to reorder %s: %v",
+	"error.reorder_limit":                "Can't reorder more than %d records at once",
+	"error.resource_not_found":           "Resource '%s' not found",
+	"error.restore_failed":               "Failed to restore %s: %v",
+	"error.restore_read_only":            "Cannot restore: Resource is read-only",
+	"error.selection_limit":              "selection exceeds the limit of %d records",
+	"error.session_failed":               "Failed to create session",
+	"error.session_revoke":               "Failed to sign out the session",
+	"error.sessions_failed":              "Failed to list sessions",
+	"error.slug_failed":                  "Failed to generate slug: %v",
+	"error.stats_failed":                 "Failed to render stats",
+	"error.update_failed":                "Failed to update item: %v",
+	"error.version_restore_failed":       "Failed to restore version: %v",
+	"error.view_delete_failed":           "Failed to delete view: %v",
+	"error.view_name":                    "Give the view a name",
+	"error.view_not_owned":               "You can only delete your own views",
+	"error.view_save_failed":             "Failed to save view: %v",
+	"error.views_load_failed":            "Failed to load saved views: %v",
+	"error.views_unavailable":            "Saved views are not available",
+	"file.expired":                       "This download link has expired. Reload the page for a new one.",
+	"file.invalid":                       "This download link is not valid",
+	"filters.any":                        "Any",
+	"filters.max":                        "Max",
+	"filters.min":                        "Min",
+	"filters.title":                      "Filters",
+	"form.create":                        "Create %s",
+	"form.create_help":                   "Fill out the form below to create a new %s.",
+	"form.create_new":                    "＋ Create new",
+	"form.edit_help":                     "Update the information below and click save when you're done.",
+	"form.no_matches":                    "No matches",
+	"form.select":                        "Select...",
+	"form.select_field":                  "Select %s",
+	"form.select_first":                  "Select %s first",
+	"form.type":                          "Type: %s",
+	"form.update":                        "Update %s",
+	"history.changed_by":                 "· before changes by %s",
+	"history.empty":                      "No previous versions recorded yet.",
+	"history.restore":                    "Restore this version",
+	"history.restore_confirm":            "Restore this version? The current state will be kept in the history.",
+	"history.saved":                      "· saved %s",
+	"history.title":                      "%s History",
+	"history.version":                    "Version %d",
+	"image.open_original":                "Open original",
+	"impersonation.denied":               "You are not allowed to view as other users",
+	"impersonation.exit":                 "Exit",
+	"impersonation.not_active":           "You are not viewing as another user",
+	"impersonation.privileged":           "You can only view as users whose roles you have",
+	"impersonation.real":                 "signed in as",
+	"impersonation.unknown":              "No such user",
+	"impersonation.username":             "Username",
+	"impersonation.view_as":              "View as",
+	"impersonation.viewing":              "Viewing as",
+	"import.change_mapping":              "← Change mapping",
+	"import.column":                      "Column",
+	"import.commit":                      "Import %d rows",
+	"import.error":                       "Error",
+	"import.error_report":                "Download error report",
+	"import.field":                       "Field",
+	"import.first_value":                 "First value",
+	"import.key_note":                    "Rows whose %s matches an existing record update it; the others create new records.",
+	"import.preview":                     "Preview import",
+	"import.preview_summary":             "%d to create, %d to update, %d with errors.",
+	"import.result_summary":              "%d created, %d updated, %d skipped.",
+	"import.row":                         "Row",
+	"import.rows_found":                  "%d rows found. Choose the field each column is imported into.",
+	"import.skip_column":                 "Don't import",
+	"import.step_done":                   "Done",
+	"import.step_map":                    "Map columns",
+	"import.step_preview":                "Preview",
+	"import.step_upload":                 "Upload",
+	"import.title":                       "Import %s",
+	"import.update_forbidden":            "you don't have permission to update this %s",
+	"import.upload":                      "Upload",
+	"import.upload_help":                 "Upload a CSV file with a header row and up to %d rows. You will map its columns to fields and preview the result before anything is saved.",
+	"import.view_records":                "View %s",
+	"index.empty":                        "No resources registered. Add some resources to your BackOffice instance.",
+	"index.manage":                       "Manage %s",
+	"index.title":                        "Registered Resources",
+	"list.actions":                       "Actions",
+	"list.add_new":                       "Add New %s",
+	"list.all":                           "All %s",
+	"list.all_matching_selected":         "All %d matching records selected",
+	"list.bulk_action_confirm":           "Run this action on the selected records?",
+	"list.bulk_delete_confirm":           "Delete the selected records? This action cannot be undone.",
+	"list.create_first":                  "Create First %s",
+	"list.delete_confirm":                "Are you sure you want to delete this %s? This action cannot be undone.",
+	"list.drag_to_reorder":               "Drag to reorder",
+	"list.empty":                         "No %s records found.",
+	"list.export_csv":                    "Export CSV",
+	"list.flat_view":                     "Flat list",
+	"list.import":                        "Import CSV",
+	"list.load_more":                     "Load More (%d more available)",
+	"list.loading":                       "Loading %s...",
+	"list.loading_more":                  "Loading more… (%d remaining)",
+	"list.none_yet":                      "No %s yet.",
+	"list.search_by":                     "Search by %s",
+	"list.select_all_matching":           "Select all %d matching",
+	"list.selected":                      "selected",
+	"list.total":                         "%d total",
+	"list.trash":                         "Trash",
+	"list.trash_confirm":                 "Move this %s to the trash?",
+	"list.tree_view":                     "Tree view",
+	"mask.denied":                        "You are not allowed to reveal this value",
+	"mask.reveal":                        "Reveal",
+	"modal.bulk_deleted":                 "%d of %d deleted",
+	"modal.bulk_failed":                  ", %d failed",
+	"modal.reason":                       "Reason",
+	"modal.record_details":               "Record details:",
+	"nav.admin_panel":                    "Admin Panel",
+	"nav.back_to":                        "← Back to %s",
+	"nav.back_to_admin":                  "← Back to Admin",
+	"nav.back_to_details":                "← Back to Details",
+	"nav.back_to_list":                   "← Back to List",
+	"nav.breadcrumb":                     "Breadcrumb",
+	"nav.guest":                          "Go Admin Panel",
+	"nav.home":                           "Home",
+	"modal.delete_title":                 "Delete %s",
+	"inline.delete_confirm":              "Delete this %s?",
+	"nav.logout":                         "Logout",
+	"nav.welcome":                        "Welcome, %s",
+	"pagination.first":                   "First",
+	"pagination.label":                   "Pagination",
+	"pagination.last":                    "Last",
+	"pagination.next":                    "Next",
+	"pagination.next_page":               "Next →",
+	"pagination.page":                    "Page %d of %d",
+	"pagination.prev":                    "Prev",
+	"pagination.previous":                "← Previous",
+	"pagination.summary":                 "Showing %d–%d of %d · Page %d of %d",
+	"picker.no_results":                  "No %s found.",
+	"picker.search":                      "Search %s",
+	"sidepane.close":                     "Close panel",
+	"value.no":                           "No",
+	"value.none":                         "N/A",
+	"value.yes":                          "Yes",
+	"views.delete":                       "Delete %s",
+	"views.delete_confirm":               "Delete the view %q?",
+	"prefs.comfortable":                  "Comfortable",
+	"prefs.compact":                      "Compact",
+	"prefs.density":                      "Density",
+	"prefs.text_large":                   "Large",
+	"prefs.text_normal":                  "Normal",
+	"prefs.text_size":                    "Text size",
+	"prefs.text_small":                   "Small",
+	"prefs.title":                        "Display",
+	"readonly.message":                   "The admin is read-only at the moment, changes are disabled.",
+	"sessions.current":                   "This browser",
+	"sessions.device":                    "Device",
+	"sessions.device_on":                 "%s on %s",
+	"sessions.ip":                        "IP address",
+	"sessions.last_seen":                 "Last active",
+	"sessions.link":                      "My sessions",
+	"sessions.revoke":                    "Sign out",
+	"sessions.revoke_others":             "Sign out all other sessions",
+	"sessions.signed_in":                 "Signed in",
+	"sessions.title":                     "My sessions",
+	"sessions.unknown_device":            "Unknown device",
+	"session.expired":                    "Your session has expired. Sign in again in a new tab to keep your unsaved changes.",
+	"session.expiring":                   "Your session is about to expire in",
+	"session.refresh_failed":             "Your session could not be extended",
+	"session.sign_in":                    "Sign in",
+	"session.stay":                       "Stay signed in",
+	"step_up.confirm":                    "Confirm",
+	"step_up.delete_permanently":         "Delete permanently",
+	"step_up.delete_permanently_confirm": "Permanently delete this %s? This action cannot be undone.",
+	"step_up.failed":                     "The password or code is incorrect",
+	"step_up.password":                   "Enter your password to confirm",
+	"step_up.required":                   "This operation must be confirmed with your password or a code",
+	"step_up.title":                      "Confirm it's you",
+	"step_up.totp":                       "Enter the code from your authenticator app to confirm",
+	"step_up.unavailable":                "This operation can't be confirmed for your account",
+	"summary.avg":                        "Avg: %s",
+	"summary.count":                      "Count: %s",
+	"summary.max":                        "Max: %s",
+	"summary.min":                        "Min: %s",
+	"summary.sum":                        "Sum: %s",
+	"time.ago":                           "%s ago",
+	"time.day":                           "1 day",
+	"time.days":                          "%d days",
+	"time.from_now":                      "in %s",
+	"time.hour":                          "1 hour",
+	"time.hours":                         "%d hours",
+	"time.just_now":                      "just now",
+	"time.minute":                        "1 minute",
+	"time.minutes":                       "%d minutes",
+	"toast.action_completed":             "%s completed successfully",
+	"toast.added":                        "%s added",
+	"toast.bulk_completed":               "%s completed for %d %s",
+	"toast.bulk_deleted":                 "Deleted %d %s",
+	"toast.bulk_delete_failed":           "%d of %d %s could not be deleted",
+	"toast.bulk_failed":                  "%s failed for %d of %d %s",
+	"toast.copied":                       "Copied to clipboard",
+	"toast.copy_failed":                  "Could not copy to clipboard",
+	"toast.created":                      "%s created successfully",
+	"toast.deleted":                      "%s deleted successfully",
+	"toast.field_updated":                "%s updated",
+	"toast.order_saved":                  "Order saved",
+	"toast.restored":                     "%s restored",
+	"toast.trashed":                      "%s moved to trash",
+	"toast.updated":                      "%s updated successfully",
+	"toast.view_deleted":                 "View deleted",
+	"toast.view_saved":                   "View saved",
+	"unsaved.body":                       "Your changes to this form haven't been saved and will be lost.",
+	"unsaved.discard":                    "Discard changes",
+	"unsaved.keep":                       "Keep editing",
+	"unsaved.title":                      "Discard unsaved changes?",
+	"validation.failed":                  "Validation error: %s",
+	"validation.invalid":                 "invalid %s: %v",
+	"validation.required_missing":        "required fields missing: %s",
+	"views.empty":                        "No saved views yet",
+	"views.name_placeholder":             "Name the current view",
+	"views.title":                        "Views",
+}
+
+var (
+	catalogsMu sync.RWMutex
+	catalogs   = map[string]Messages{DefaultLocale: englishMessages}
+)
+
+// RegisterMessages adds translations for a locale, merged into any registered before
+// Keys the locale leaves out are shown in English
+func RegisterMessages(locale string, messages Messages) {
+	catalogsMu.Lock()
+	defer catalogsMu.Unlock()
+	catalog := catalogs[locale]
+	if catalog == nil {
+		catalog = make(Messages, len(messages))
+		catalogs[locale] = catalog
+	}
+	for key, text := range messages {
+		catalog[key] = text
+	}
+}
+
+// Translate returns the text of a message key in a locale, formatted with args
+// It falls back to English, then to the key itself
+func Translate(locale, key string, args ...any) string {
+	catalogsMu.RLock()
+	text, ok := catalogs[locale][key]
+	if !ok {
+		text, ok = englishMessages[key]
+	}
+	catalogsMu.RUnlock()
+	if !ok {
+		text = key
+	}
+	if len(args) == 0 {
+		return text
+	}
+	return fmt.Sprintf(text, args...)
+}
+
+// NegotiateLocale picks the locale to render a request in from its Accept-Language header
+// Languages are tried by preference, matching a registered locale exactly or by their base language
+// ("de-AT" falls back to "de"); without a match the configured locale is used
+func (bo *BackOffice) NegotiateLocale(acceptLanguage string) string {
+	catalogsMu.RLock()
+	defer catalogsMu.RUnlock()
+	for _, tag := range parseAcceptLanguage(acceptLanguage) {
+		if locale, ok := registeredLocale(tag); ok {
+			return locale
+		}
+		if base, _, found := strings.Cut(tag, "-"); found {
+			if locale, ok := registeredLocale(base); ok {
+				return locale
+			}
+		}
+	}
+	if bo.config.Locale != "" {
+		return bo.config.Locale
+	}
+	return DefaultLocale
+}
+
+// registeredLocale finds the locale with a catalog matching a language tag, ignoring case
+// Callers must hold catalogsMu
+func registeredLocale(tag string) (string, bool) {
+	for locale := range catalogs {
+		if strings.EqualFold(locale, tag) {
+			return locale, true
+		}
+	}
+	return "", false
+}
+
+// parseAcceptLanguage returns the language tags of an Accept-Language header, most preferred first
+func parseAcceptLanguage(header string) []string {
+	type weighted struct {
+		tag string
+		q   float64
+	}
+	var tags []weighted
+	for _, part := range strings.Split(header, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		if tag == "" || tag == "*" {
+			continue
+		}
+		q := 1.0
+		if value, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				continue
+			}
+			q = parsed
+		}
+		if q > 0 {
+			tags = append(tags, weighted{tag: tag, q: q})
+		}
+	}
+	sort.SliceStable(tags, func(i, j int) bool { return tags[i].q > tags[j].q })
+
+	result := make([]string, len(tags))
+	for i, tag := range tags {
+		result[i] = tag.tag
+	}
+	return result
+}
@@ -0,0 +1,66 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/preslavrachev/backoffice/middleware/auth"
+)
+
+func TestTranslate(t *testing.T) {
+	RegisterMessages("test", Messages{"list.add_new": "Neu: %s"})
+	defer func() {
+		catalogsMu.Lock()
+		delete(catalogs, "test")
+		catalogsMu.Unlock()
+	}()
+
+	tests := []struct {
+		locale, key string
+		args        []any
+		want        string
+	}{
+		{"test", "list.add_new", []any{"Order"}, "Neu: Order"},
+		{"test", "action.delete", nil, "Delete"},
+		{"unknown", "list.add_new", []any{"Order"}, "Add New Order"},
+		{"test", "missing.key", nil, "missing.key"},
+	}
+	for _, tt := range tests {
+		if got := Translate(tt.locale, tt.key, tt.args...); got != tt.want {
+			t.Errorf("Translate(%q, %q) = %q, want %q", tt.locale, tt.key, got, tt.want)
+		}
+	}
+}
+
+func TestNegotiateLocale(t *testing.T) {
+	RegisterMessages("de", Messages{"action.delete": "Löschen"})
+	RegisterMessages("pt-BR", Messages{"action.delete": "Excluir"})
+	defer func() {
+		catalogsMu.Lock()
+		delete(catalogs, "de")
+		delete(catalogs, "pt-BR")
+		catalogsMu.Unlock()
+	}()
+
+	bo := New(&DummyAdapter{}, auth.AuthConfig{})
+	tests := []struct {
+		header string
+		want   string
+	}{
+		{"", DefaultLocale},
+		{"de", "de"},
+		{"de-AT,de;q=0.9", "de"},
+		{"pt-br", "pt-BR"},
+		{"fr;q=0.9, de;q=0.5, pt-BR;q=0.7", "pt-BR"},
+		{"fr, it", DefaultLocale},
+		{"de;q=0", DefaultLocale},
+	}
+	for _, tt := range tests {
+		if got := bo.NegotiateLocale(tt.header); got != tt.want {
+			t.Errorf("NegotiateLocale(%q) = %q, want %q", tt.header, got, tt.want)
+		}
+	}
+
+	if got := bo.SetLocale("de").NegotiateLocale("fr"); got != "de" {
+		t.Errorf("Expected the configured locale without a match, got %q", got)
+	}
+}
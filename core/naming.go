@@ -0,0 +1,110 @@
+package core
+
+import (
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// DefaultLocale is used for resource names when the config sets no locale
+const DefaultLocale = "en"
+
+// Inflector generates resource display names for a locale
+type Inflector interface {
+	// DisplayName turns a Go type name into a display name, e.g. "OrderItem" -> "Order Item"
+	DisplayName(typeName string) string
+	// Pluralize returns the plural of a display name, e.g. "Order Item" -> "Order Items"
+	Pluralize(displayName string) string
+}
+
+var (
+	inflectorsMu sync.RWMutex
+	inflectors   = map[string]Inflector{DefaultLocale: englishInflector{}}
+
+	irregularsMu sync.RWMutex
+	irregulars   = map[string]string{
+		"person": "people",
+		"child":  "children",
+		"man":    "men",
+		"woman":  "women",
+		"mouse":  "mice",
+		"goose":  "geese",
+		"foot":   "feet",
+		"tooth":  "teeth",
+	}
+)
+
+// RegisterInflector sets the inflector used for resources registered under the given locale
+func RegisterInflector(locale string, inflector Inflector) {
+	inflectorsMu.Lock()
+	defer inflectorsMu.Unlock()
+	inflectors[locale] = inflector
+}
+
+// RegisterIrregularPlural overrides the English plural of a word, matched case-insensitively
+// against the last word of a display name, e.g. RegisterIrregularPlural("Status", "Status")
+func RegisterIrregularPlural(singular, plural string) {
+	irregularsMu.Lock()
+	defer irregularsMu.Unlock()
+	irregulars[strings.ToLower(singular)] = plural
+}
+
+// inflectorFor returns the inflector of a locale, falling back to the default locale
+func inflectorFor(locale string) Inflector {
+	inflectorsMu.RLock()
+	defer inflectorsMu.RUnlock()
+	if inflector, ok := inflectors[locale]; ok {
+		return inflector
+	}
+	return inflectors[DefaultLocale]
+}
+
+// englishInflector splits CamelCase names into words and pluralizes the last word
+type englishInflector struct{}
+
+func (englishInflector) DisplayName(typeName string) string {
+	// Convert CamelCase to "Display Name", keeping acronyms such as "HTTP" together
+	runes := []rune(typeName)
+	var result strings.Builder
+	for i, r := range runes {
+		if i > 0 && unicode.IsUpper(r) {
+			prevLower := unicode.IsLower(runes[i-1])
+			acronymEnd := unicode.IsUpper(runes[i-1]) && i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if prevLower || acronymEnd {
+				result.WriteRune(' ')
+			}
+		}
+		result.WriteRune(r)
+	}
+	return result.String()
+}
+
+func (englishInflector) Pluralize(displayName string) string {
+	start := strings.LastIndexAny(displayName, " _") + 1
+	prefix, word := displayName[:start], displayName[start:]
+
+	irregularsMu.RLock()
+	plural, ok := irregulars[strings.ToLower(word)]
+	irregularsMu.RUnlock()
+	if !ok {
+		return prefix + pluralize(word)
+	}
+	return prefix + matchCase(word, plural)
+}
+
+// matchCase applies the capitalization of word to replacement
+func matchCase(word, replacement string) string {
+	if word == "" || replacement == "" {
+		return replacement
+	}
+	if word == strings.ToUpper(word) && len(word) > 1 {
+		return strings.ToUpper(replacement)
+	}
+	runes := []rune(replacement)
+	if unicode.IsUpper([]rune(word)[0]) {
+		runes[0] = unicode.ToUpper(runes[0])
+	} else {
+		runes[0] = unicode.ToLower(runes[0])
+	}
+	return string(runes)
+}
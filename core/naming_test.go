@@ -0,0 +1,77 @@
+package core
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/preslavrachev/backoffice/middleware/auth"
+)
+
+func TestEnglishInflector(t *testing.T) {
+	tests := []struct {
+		typeName    string
+		displayName string
+		pluralName  string
+	}{
+		{"User", "User", "Users"},
+		{"OrderItem", "Order Item", "Order Items"},
+		{"Category", "Category", "Categories"},
+		{"Status", "Status", "Statuses"},
+		{"Person", "Person", "People"},
+		{"SalesPerson", "Sales Person", "Sales People"},
+		{"HTTPRequest", "HTTP Request", "HTTP Requests"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.typeName, func(t *testing.T) {
+			if got := generateDisplayName("", tt.typeName); got != tt.displayName {
+				t.Errorf("Expected display name %q, got %q", tt.displayName, got)
+			}
+			if got := generatePluralName("", tt.typeName); got != tt.pluralName {
+				t.Errorf("Expected plural name %q, got %q", tt.pluralName, got)
+			}
+		})
+	}
+}
+
+func TestRegisterIrregularPlural(t *testing.T) {
+	RegisterIrregularPlural("Status", "Status")
+	defer func() {
+		irregularsMu.Lock()
+		delete(irregulars, "status")
+		irregularsMu.Unlock()
+	}()
+
+	if got := generatePluralName("", "OrderStatus"); got != "Order Status" {
+		t.Errorf("Expected the registered plural, got %q", got)
+	}
+	if got := generateTableName("OrderStatus"); got != "order_statuses" {
+		t.Errorf("Expected table names to keep the basic rules, got %q", got)
+	}
+}
+
+// upperInflector names resources in capitals, standing in for another locale
+type upperInflector struct{}
+
+func (upperInflector) DisplayName(typeName string) string  { return strings.ToUpper(typeName) }
+func (upperInflector) Pluralize(displayName string) string { return displayName + "EN" }
+
+func TestSetLocale(t *testing.T) {
+	RegisterInflector("test", upperInflector{})
+	defer func() {
+		inflectorsMu.Lock()
+		delete(inflectors, "test")
+		inflectorsMu.Unlock()
+	}()
+
+	bo := New(&DummyAdapter{}, auth.AuthConfig{})
+	bo.SetLocale("test").RegisterResource(&Address{})
+	resource, _ := bo.GetResource("Address")
+	if resource.DisplayName != "ADDRESS" || resource.PluralName != "ADDRESSEN" {
+		t.Errorf("Expected the locale's inflector, got %q / %q", resource.DisplayName, resource.PluralName)
+	}
+
+	if got := generateDisplayName("unknown", "OrderItem"); got != "Order Item" {
+		t.Errorf("Expected unknown locales to fall back to English, got %q", got)
+	}
+}
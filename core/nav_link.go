@@ -0,0 +1,75 @@
+package core
+
+import "strings"
+
+// NavLink is an extra navigation entry pointing outside the admin, such as a related internal tool
+type NavLink struct {
+	Title string
+	URL   string
+	Icon  string // Named action icon or short text such as an emoji, optional
+	Group string // Navigation group the link is listed under, empty for the leading group
+}
+
+// IsExternal reports whether the link points to another site and should open in a new tab
+func (l NavLink) IsExternal() bool {
+	return strings.HasPrefix(l.URL, "http://") || strings.HasPrefix(l.URL, "https://") || strings.HasPrefix(l.URL, "//")
+}
+
+// NavLinkBuilder configures a link added with AddLink
+type NavLinkBuilder struct {
+	link *NavLink
+}
+
+// AddLink adds a link to the sidebar navigation, listed after the resources of its group
+func (bo *BackOffice) AddLink(title, url, icon string) *NavLinkBuilder {
+	link := &NavLink{Title: title, URL: url, Icon: icon}
+	bo.links = append(bo.links, link)
+	return &NavLinkBuilder{link: link}
+}
+
+// InGroup lists the link under the named navigation group, next to that group's resources
+func (b *NavLinkBuilder) InGroup(name string) *NavLinkBuilder {
+	b.link.Group = name
+	return b
+}
+
+// Links returns the navigation links in the order they were added
+func (bo *BackOffice) Links() []NavLink {
+	links := make([]NavLink, 0, len(bo.links))
+	for _, link := range bo.links {
+		links = append(links, *link)
+	}
+	return links
+}
+
+// GroupLinks adds the links to the groups they name, keeping the order of the given groups
+// Groups holding only links follow the resource groups, ungrouped links join the leading group
+func GroupLinks(groups []ResourceGroup, links []NavLink) []ResourceGroup {
+	if len(links) == 0 {
+		return groups
+	}
+	merged := append([]ResourceGroup(nil), groups...)
+	index := make(map[string]int, len(merged))
+	for i, group := range merged {
+		index[group.Name] = i
+	}
+
+	for _, link := range links {
+		i, exists := index[link.Group]
+		if !exists {
+			if link.Group == "" {
+				merged = append([]ResourceGroup{{}}, merged...)
+				for name := range index {
+					index[name]++
+				}
+				i = 0
+			} else {
+				i = len(merged)
+				merged = append(merged, ResourceGroup{Name: link.Group})
+			}
+			index[link.Group] = i
+		}
+		merged[i].Links = append(merged[i].Links, link)
+	}
+	return merged
+}
@@ -0,0 +1,121 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+
+	"github.com/preslavrachev/backoffice/middleware/auth"
+)
+
+// ErrNotOwner is returned when an owner-restricted record belongs to another user
+var ErrNotOwner = errors.New("record belongs to another user")
+
+// SetOwner fills the resource's owner field with the authenticated user's ID
+// Records created without a session user are left unchanged
+func SetOwner(ctx context.Context, resource *Resource, item any) error {
+	if resource.OwnerField == "" {
+		return nil
+	}
+	user, ok := auth.GetAuthUser(ctx)
+	if !ok || user == nil || user.ID == nil {
+		return nil
+	}
+
+	val := reflect.ValueOf(item)
+	if val.Kind() != reflect.Ptr || val.IsNil() || val.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("expected a pointer to a struct, got %T", item)
+	}
+	field := val.Elem().FieldByName(resource.OwnerField)
+	if !field.IsValid() || !field.CanSet() {
+		return fmt.Errorf("owner field %s not found", resource.OwnerField)
+	}
+
+	target := field.Type()
+	if target.Kind() == reflect.Ptr {
+		target = target.Elem()
+	}
+	owner, err := identityValue(user.ID, target)
+	if err != nil {
+		return fmt.Errorf("cannot assign owner to %s: %w", resource.OwnerField, err)
+	}
+	if field.Kind() == reflect.Ptr {
+		ptr := reflect.New(target)
+		ptr.Elem().Set(owner)
+		owner = ptr
+	}
+	field.Set(owner)
+	return nil
+}
+
+// identityValue converts a user ID to the owner field's type
+// Numbers convert between numeric kinds and anything can be stored in a string field
+func identityValue(id auth.Identity, target reflect.Type) (reflect.Value, error) {
+	val := reflect.ValueOf(id)
+	switch {
+	case val.Type() == target:
+		return val, nil
+	case target.Kind() == reflect.String:
+		return reflect.ValueOf(fmt.Sprintf("%v", id)).Convert(target), nil
+	case isNumberKind(val.Kind()) && isNumberKind(target.Kind()):
+		return val.Convert(target), nil
+	}
+	return reflect.Value{}, fmt.Errorf("unsupported user ID type %T", id)
+}
+
+func isNumberKind(kind reflect.Kind) bool {
+	return (kind >= reflect.Int && kind <= reflect.Uint64) || kind == reflect.Float32 || kind == reflect.Float64
+}
+
+// IsOwner reports whether the authenticated user owns the record
+func (r *Resource) IsOwner(ctx context.Context, item any) bool {
+	user, ok := auth.GetAuthUser(ctx)
+	if !ok || user == nil || user.ID == nil || r.OwnerField == "" {
+		return false
+	}
+	owner := GetFieldValue(item, r.OwnerField)
+	if val := reflect.ValueOf(owner); val.Kind() == reflect.Ptr {
+		if val.IsNil() {
+			return false
+		}
+		owner = val.Elem().Interface()
+	}
+	return fmt.Sprintf("%v", owner) == fmt.Sprintf("%v", user.ID)
+}
+
+// CanRecord reports whether the operation is permitted on a specific record
+// Owner-restricted resources only let the owner or an admin role update and delete records
+func (r *Resource) CanRecord(ctx context.Context, op Operation, item any) bool {
+	if !r.Can(ctx, op) {
+		return false
+	}
+	if !r.OwnerOnly || (op != OperationUpdate && op != OperationDelete) {
+		return true
+	}
+	if user, ok := auth.GetAuthUser(ctx); ok && user != nil {
+		for _, role := range user.Roles {
+			if slices.Contains(r.OwnerAdminRoles, role) {
+				return true
+			}
+		}
+	}
+	return r.IsOwner(ctx, item)
+}
+
+// CheckOwner verifies that the current user may perform the operation on the record with the given ID
+// Resources without owner restrictions always pass
+func CheckOwner(ctx context.Context, adapter Adapter, resource *Resource, id any, op Operation) error {
+	if !resource.OwnerOnly {
+		return nil
+	}
+	item, err := adapter.GetByID(ctx, resource, id)
+	if err != nil {
+		return err
+	}
+	if !resource.CanRecord(ctx, op, item) {
+		return ErrNotOwner
+	}
+	return nil
+}
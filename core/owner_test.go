@@ -0,0 +1,99 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/preslavrachev/backoffice/middleware/auth"
+)
+
+type Note struct {
+	ID          uint   `db:"id"`
+	Body        string `db:"body"`
+	CreatedByID uint   `db:"created_by_id"`
+	AuthorName  *string
+}
+
+type ownerTestAdapter struct {
+	DummyAdapter
+	notes map[uint]*Note
+}
+
+func (a *ownerTestAdapter) GetByID(ctx context.Context, resource *Resource, id any) (any, error) {
+	if note, ok := a.notes[id.(uint)]; ok {
+		return note, nil
+	}
+	return nil, errors.New("not found")
+}
+
+func newOwnerTestResource(adapter Adapter) *Resource {
+	bo := New(adapter, auth.AuthConfig{})
+	bo.RegisterResource(&Note{}).
+		WithFields("Body", "CreatedByID").
+		WithOwnerField("CreatedByID").
+		RestrictToOwner("admin")
+	resource, _ := bo.GetResource("Note")
+	return resource
+}
+
+func TestSetOwner(t *testing.T) {
+	resource := newOwnerTestResource(&DummyAdapter{})
+
+	if field, _ := resource.GetField("CreatedByID"); !field.ReadOnly {
+		t.Error("Expected the owner field to be read-only")
+	}
+
+	note := &Note{}
+	if err := SetOwner(context.Background(), resource, note); err != nil || note.CreatedByID != 0 {
+		t.Errorf("Expected no owner without a session user, got %d (%v)", note.CreatedByID, err)
+	}
+
+	ctx := auth.WithAuthUser(context.Background(), &auth.AuthUser{ID: int64(42)})
+	if err := SetOwner(ctx, resource, note); err != nil || note.CreatedByID != 42 {
+		t.Errorf("Expected owner 42, got %d (%v)", note.CreatedByID, err)
+	}
+
+	resource.OwnerField = "AuthorName"
+	ctx = auth.WithAuthUser(context.Background(), &auth.AuthUser{ID: 7})
+	if err := SetOwner(ctx, resource, note); err != nil || note.AuthorName == nil || *note.AuthorName != "7" {
+		t.Errorf("Expected pointer string owner \"7\", got %v (%v)", note.AuthorName, err)
+	}
+}
+
+func TestCheckOwner(t *testing.T) {
+	adapter := &ownerTestAdapter{notes: map[uint]*Note{1: {ID: 1, CreatedByID: 42}}}
+	resource := newOwnerTestResource(adapter)
+
+	owner := auth.WithAuthUser(context.Background(), &auth.AuthUser{ID: uint(42)})
+	other := auth.WithAuthUser(context.Background(), &auth.AuthUser{ID: uint(7)})
+	admin := auth.WithAuthUser(context.Background(), &auth.AuthUser{ID: uint(1), Roles: []string{"admin"}})
+
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		op      Operation
+		wantErr error
+	}{
+		{"owner may update", owner, OperationUpdate, nil},
+		{"owner may delete", owner, OperationDelete, nil},
+		{"other user may not update", other, OperationUpdate, ErrNotOwner},
+		{"other user may not delete", other, OperationDelete, ErrNotOwner},
+		{"other user may still view", other, OperationList, nil},
+		{"admin may delete", admin, OperationDelete, nil},
+		{"anonymous may not update", context.Background(), OperationUpdate, ErrNotOwner},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := CheckOwner(tt.ctx, adapter, resource, uint(1), tt.op); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Expected %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+
+	resource.OwnerOnly = false
+	if err := CheckOwner(other, adapter, resource, uint(1), OperationDelete); err != nil {
+		t.Errorf("Expected unrestricted resources to pass, got %v", err)
+	}
+}
@@ -0,0 +1,86 @@
+package core
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// CustomPage is a page served below the admin base path and rendered inside the admin layout,
+// for bespoke screens such as reports that don't map to a resource
+type CustomPage struct {
+	Path    string       // Path below the base path, without surrounding slashes
+	Title   string       // Shown in the breadcrumbs and the browser title, defaults to the path
+	Content Renderer     // Static content such as a templ component
+	Handler http.Handler // Writes the page body per request, used when Content is nil
+}
+
+// CustomPageBuilder configures a page registered with RegisterPage
+type CustomPageBuilder struct {
+	page *CustomPage
+}
+
+// RegisterPage serves content at path below the admin base path, e.g. "/reports/weekly"
+// Content is a Renderer such as a templ component, an http.Handler or an http.HandlerFunc-style function;
+// the HTML handlers write is embedded in the admin layout
+func (bo *BackOffice) RegisterPage(path string, content any) *CustomPageBuilder {
+	path = strings.Trim(path, "/")
+	page := &CustomPage{Path: path, Title: path}
+	switch c := content.(type) {
+	case Renderer:
+		page.Content = c
+	case http.Handler:
+		page.Handler = c
+	case func(http.ResponseWriter, *http.Request):
+		page.Handler = http.HandlerFunc(c)
+	}
+	bo.pages = append(bo.pages, page)
+	return &CustomPageBuilder{page: page}
+}
+
+// WithTitle sets the title shown in the page's breadcrumbs and browser tab
+func (b *CustomPageBuilder) WithTitle(title string) *CustomPageBuilder {
+	b.page.Title = title
+	return b
+}
+
+// GetPage returns the custom page registered at path
+func (bo *BackOffice) GetPage(path string) (*CustomPage, bool) {
+	path = strings.Trim(path, "/")
+	for _, page := range bo.pages {
+		if page.Path == path {
+			return page, true
+		}
+	}
+	return nil, false
+}
+
+// Pages returns the custom pages in registration order
+func (bo *BackOffice) Pages() []*CustomPage {
+	return bo.pages
+}
+
+// pageErrors reports pages without content and pages shadowed by a resource or another page
+func (bo *BackOffice) pageErrors() ConfigErrors {
+	var problems ConfigErrors
+	seen := make(map[string]bool)
+	for _, page := range bo.pages {
+		report := func(format string, args ...any) {
+			problems = append(problems, &ConfigError{Resource: "/" + page.Path, Field: "page", Message: fmt.Sprintf(format, args...)})
+		}
+		if page.Path == "" {
+			report("a page can't be served at the admin root")
+		}
+		if page.Content == nil && page.Handler == nil {
+			report("content must be a templ component, an http.Handler or a handler function")
+		}
+		if _, exists := bo.resources[strings.Split(page.Path, "/")[0]]; exists {
+			report("path is shadowed by the resource of the same name")
+		}
+		if seen[page.Path] {
+			report("path is registered more than once")
+		}
+		seen[page.Path] = true
+	}
+	return problems
+}
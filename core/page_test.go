@@ -0,0 +1,30 @@
+package core
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/preslavrachev/backoffice/middleware/auth"
+)
+
+func TestRegisterPage(t *testing.T) {
+	bo := New(&DummyAdapter{}, auth.AuthConfig{})
+	bo.RegisterPage("/reports/weekly", func(w http.ResponseWriter, r *http.Request) {}).WithTitle("Weekly report")
+
+	page, exists := bo.GetPage("reports/weekly/")
+	if !exists {
+		t.Fatal("Expected the page to be found regardless of surrounding slashes")
+	}
+	if page.Path != "reports/weekly" || page.Title != "Weekly report" {
+		t.Errorf("Unexpected page %+v", page)
+	}
+	if page.Handler == nil || page.Content != nil {
+		t.Error("Expected a handler function to be served as the page handler")
+	}
+	if _, exists := bo.GetPage("reports"); exists {
+		t.Error("Expected only exact paths to match")
+	}
+	if err := bo.Validate(); err != nil {
+		t.Errorf("Expected a valid page, got %v", err)
+	}
+}
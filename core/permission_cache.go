@@ -0,0 +1,104 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/preslavrachev/backoffice/middleware/auth"
+)
+
+// permissionMemoKey is the context key of the request's permission memo
+type permissionMemoKey struct{}
+
+// permissionKey identifies the result of a permission check for a user
+type permissionKey struct {
+	subject  string
+	resource string
+	check    string
+}
+
+// permissionMemo remembers the permission checks of one request
+type permissionMemo struct {
+	results map[permissionKey]bool
+	mu      sync.Mutex
+}
+
+// WithPermissionMemo returns a context remembering the results of permission checks, so the policy and
+// resource Permissions run once per resource and operation while a page renders its rows and actions
+// Use it for one request only: results aren't refreshed when roles or grants change
+func WithPermissionMemo(ctx context.Context) context.Context {
+	if _, ok := ctx.Value(permissionMemoKey{}).(*permissionMemo); ok {
+		return ctx
+	}
+	return context.WithValue(ctx, permissionMemoKey{}, &permissionMemo{results: make(map[permissionKey]bool)})
+}
+
+// SetPermissionCache keeps the results of permission checks for ttl across requests, keyed by the user
+// and their roles, so a user whose roles change gets fresh checks right away
+// Only use it when Permissions and the Policy decide by the user alone; call InvalidatePermissions after
+// changing grants. A zero ttl turns the cache off
+func (bo *BackOffice) SetPermissionCache(ttl time.Duration) *BackOffice {
+	if ttl <= 0 {
+		bo.permissionCache = nil
+		return bo
+	}
+	bo.permissionCache = NewComputeCache(ttl)
+	return bo
+}
+
+// InvalidatePermissions drops the permission checks kept by SetPermissionCache
+func (bo *BackOffice) InvalidatePermissions() {
+	if bo.permissionCache != nil {
+		bo.permissionCache.Clear()
+	}
+}
+
+// resolvePermission returns the result of decide for the request's user, memoized for the request and,
+// with SetPermissionCache, across requests
+func (bo *BackOffice) resolvePermission(ctx context.Context, resource, check string, decide func() bool) bool {
+	if bo == nil {
+		return decide()
+	}
+	user, _ := auth.GetAuthUser(ctx)
+	key := permissionKey{subject: permissionSubject(user), resource: resource, check: check}
+
+	memo, _ := ctx.Value(permissionMemoKey{}).(*permissionMemo)
+	if memo != nil {
+		memo.mu.Lock()
+		allowed, ok := memo.results[key]
+		memo.mu.Unlock()
+		if ok {
+			return allowed
+		}
+	}
+
+	var allowed bool
+	if bo.permissionCache != nil {
+		allowed, _ = bo.permissionCache.GetOrCompute(key.resource, key.subject, key.check, func() any {
+			return decide()
+		}).(bool)
+	} else {
+		allowed = decide()
+	}
+
+	if memo != nil {
+		memo.mu.Lock()
+		memo.results[key] = allowed
+		memo.mu.Unlock()
+	}
+	return allowed
+}
+
+// permissionSubject identifies a user and their roles in permission cache keys
+func permissionSubject(user *auth.AuthUser) string {
+	if user == nil {
+		return ""
+	}
+	roles := slices.Clone(user.Roles)
+	slices.Sort(roles)
+	return fmt.Sprintf("%s\x00%v\x00%s", user.Username, user.ID, strings.Join(roles, ","))
+}
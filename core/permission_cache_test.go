@@ -0,0 +1,97 @@
+package core
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/preslavrachev/backoffice/middleware/auth"
+)
+
+func TestPermissionMemo(t *testing.T) {
+	type Product struct {
+		ID uint `db:"id"`
+	}
+
+	calls := 0
+	bo := New(&DummyAdapter{}, auth.AuthConfig{Enabled: true})
+	bo.RegisterResource(&Product{}).WithPermissions(Permissions{Update: func(ctx context.Context) bool {
+		calls++
+		user, _ := auth.GetAuthUser(ctx)
+		return user.HasRole("editor")
+	}})
+	resource, _ := bo.GetResource("Product")
+
+	editor := auth.WithAuthUser(context.Background(), &auth.AuthUser{Username: "jane", Roles: []string{"editor"}})
+	ctx := WithPermissionMemo(editor)
+	for range 3 {
+		if !resource.Can(ctx, OperationUpdate) {
+			t.Fatal("Expected the editor to update")
+		}
+	}
+	if calls != 1 {
+		t.Errorf("Expected the check to run once per request, ran %d times", calls)
+	}
+
+	// A user set further down the request gets their own checks
+	viewer := auth.WithAuthUser(ctx, &auth.AuthUser{Username: "joe", Roles: []string{"viewer"}})
+	if resource.Can(viewer, OperationUpdate) {
+		t.Error("Expected the memo to keep users apart")
+	}
+
+	// Without a memo nor a cache, every check runs
+	calls = 0
+	resource.Can(editor, OperationUpdate)
+	resource.Can(editor, OperationUpdate)
+	if calls != 2 {
+		t.Errorf("Expected uncached checks, ran %d times", calls)
+	}
+
+	// Read-only isn't memoized, so lifting a freeze applies right away
+	bo.SetReadOnly(ReadOnlyConfig{Enabled: true})
+	if resource.Can(ctx, OperationUpdate) {
+		t.Error("Expected the read-only admin to deny updates despite the memo")
+	}
+}
+
+func TestPermissionCache(t *testing.T) {
+	type Product struct {
+		ID uint `db:"id"`
+	}
+
+	policy := NewRolePolicy().Allow("editor", "Product")
+	calls := 0
+	bo := New(&DummyAdapter{}, auth.AuthConfig{Enabled: true})
+	bo.RegisterResource(&Product{})
+	bo.SetPolicy(PolicyFunc(func(user *auth.AuthUser, op Operation, resource string) bool {
+		calls++
+		return policy.Can(user, op, resource)
+	}))
+	bo.SetPermissionCache(time.Minute)
+	resource, _ := bo.GetResource("Product")
+
+	user := &auth.AuthUser{Username: "jane", Roles: []string{"viewer"}}
+	if resource.Can(auth.WithAuthUser(context.Background(), user), OperationDelete) {
+		t.Fatal("Expected viewers not to delete")
+	}
+	resource.Can(auth.WithAuthUser(context.Background(), user), OperationDelete)
+	if calls != 1 {
+		t.Errorf("Expected the result to be kept across requests, ran %d times", calls)
+	}
+
+	// A role change is a new role version, so the user isn't served the stale result
+	promoted := &auth.AuthUser{Username: "jane", Roles: []string{"editor", "viewer"}}
+	if !resource.Can(auth.WithAuthUser(context.Background(), promoted), OperationDelete) {
+		t.Error("Expected the promoted user to delete")
+	}
+
+	// Changed grants apply once the cache is invalidated
+	policy.Allow("viewer", "Product", OperationDelete)
+	if resource.Can(auth.WithAuthUser(context.Background(), user), OperationDelete) {
+		t.Error("Expected the cached denial before invalidating")
+	}
+	bo.InvalidatePermissions()
+	if !resource.Can(auth.WithAuthUser(context.Background(), user), OperationDelete) {
+		t.Error("Expected the new grant after invalidating")
+	}
+}
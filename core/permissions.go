@@ -0,0 +1,80 @@
+package core
+
+import (
+	"context"
+
+	"github.com/preslavrachev/backoffice/middleware/auth"
+)
+
+// Operation identifies a kind of access to a resource
+type Operation string
+
+const (
+	OperationList   Operation = "list"   // Viewing lists, details and related records
+	OperationCreate Operation = "create" // Creating new records
+	OperationUpdate Operation = "update" // Editing records and running custom actions
+	OperationDelete Operation = "delete" // Deleting records
+)
+
+// PermissionFunc decides whether the current request may perform an operation
+// The request context carries the authenticated user (see auth.GetAuthUser)
+type PermissionFunc func(ctx context.Context) bool
+
+// Permissions holds per-operation permission checks for a resource
+// A nil check allows the operation, so resources without permissions stay fully accessible
+type Permissions struct {
+	List   PermissionFunc
+	Create PermissionFunc
+	Update PermissionFunc
+	Delete PermissionFunc
+}
+
+// Allows reports whether the operation is permitted for the given context
+func (p Permissions) Allows(ctx context.Context, op Operation) bool {
+	var check PermissionFunc
+	switch op {
+	case OperationList:
+		check = p.List
+	case OperationCreate:
+		check = p.Create
+	case OperationUpdate:
+		check = p.Update
+	case OperationDelete:
+		check = p.Delete
+	}
+
+	if check == nil {
+		return true
+	}
+	return check(ctx)
+}
+
+// Can reports whether the operation is permitted on the resource for the given context
+// Both the admin's Policy, if any, and the resource's Permissions must allow it,
+// and only listing is permitted while the admin is read-only
+func (r *Resource) Can(ctx context.Context, op Operation) bool {
+	if op != OperationList && r.backoffice.IsReadOnly(ctx) {
+		return false
+	}
+	return r.backoffice.resolvePermission(ctx, r.Name, string(op), func() bool {
+		return r.backoffice.policyAllows(ctx, op, r.Name) && r.Permissions.Allows(ctx, op)
+	})
+}
+
+// AllowRoles returns a permission check that passes when the authenticated user has any of the roles
+func AllowRoles(roles ...string) PermissionFunc {
+	return func(ctx context.Context) bool {
+		user, _ := auth.GetAuthUser(ctx)
+		for _, role := range roles {
+			if user.HasRole(auth.Role(role)) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// DenyAll is a permission check that never passes
+func DenyAll(ctx context.Context) bool {
+	return false
+}
@@ -0,0 +1,58 @@
+package core
+
+import (
+	"context"
+	"testing"
+
+	"github.com/preslavrachev/backoffice/middleware/auth"
+)
+
+func TestPermissions_Allows(t *testing.T) {
+	perms := Permissions{
+		Create: DenyAll,
+		Delete: AllowRoles("admin"),
+	}
+
+	ctx := context.Background()
+	if !perms.Allows(ctx, OperationList) {
+		t.Error("Expected nil check to allow list")
+	}
+	if perms.Allows(ctx, OperationCreate) {
+		t.Error("Expected DenyAll to deny create")
+	}
+	if perms.Allows(ctx, OperationDelete) {
+		t.Error("Expected delete to be denied without an authenticated user")
+	}
+
+	editor := auth.WithAuthUser(ctx, &auth.AuthUser{Username: "editor", Roles: []string{"editor"}})
+	if perms.Allows(editor, OperationDelete) {
+		t.Error("Expected delete to be denied for editor role")
+	}
+
+	admin := auth.WithAuthUser(ctx, &auth.AuthUser{Username: "admin", Roles: []string{"editor", "admin"}})
+	if !perms.Allows(admin, OperationDelete) {
+		t.Error("Expected delete to be allowed for admin role")
+	}
+}
+
+func TestResourceBuilder_WithPermissions(t *testing.T) {
+	type Invoice struct {
+		ID uint `db:"id"`
+	}
+
+	bo := &BackOffice{
+		resources:     make(map[string]*Resource),
+		resourceOrder: []string{},
+		config:        &Config{},
+	}
+	bo.RegisterResource(&Invoice{}).
+		WithPermissions(Permissions{Update: DenyAll})
+	resource, _ := bo.GetResource("Invoice")
+
+	if resource.Can(context.Background(), OperationUpdate) {
+		t.Error("Expected update to be denied")
+	}
+	if !resource.Can(context.Background(), OperationList) {
+		t.Error("Expected list to be allowed")
+	}
+}
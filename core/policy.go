@@ -0,0 +1,166 @@
+package core
+
+import (
+	"context"
+	"strings"
+
+	"github.com/preslavrachev/backoffice/middleware/auth"
+)
+
+// AnyResource matches every resource in RolePolicy.Allow
+const AnyResource = "*"
+
+// actionOperationPrefix marks operations that run a custom action
+const actionOperationPrefix = "action:"
+
+// ActionOperation is the operation of running a resource's custom action
+func ActionOperation(actionID string) Operation {
+	return Operation(actionOperationPrefix + actionID)
+}
+
+// ActionID returns the custom action of an operation made by ActionOperation
+func (op Operation) ActionID() (string, bool) {
+	return strings.CutPrefix(string(op), actionOperationPrefix)
+}
+
+// Policy decides which operations a user may perform on a resource
+// It applies on top of each resource's Permissions, both must allow an operation
+type Policy interface {
+	Can(user *auth.AuthUser, op Operation, resource string) bool
+}
+
+// PolicyFunc adapts a function to a Policy
+type PolicyFunc func(user *auth.AuthUser, op Operation, resource string) bool
+
+// Can calls the function
+func (f PolicyFunc) Can(user *auth.AuthUser, op Operation, resource string) bool {
+	return f(user, op, resource)
+}
+
+// RolePolicy grants operations on resources to roles; anything not granted is denied
+// Custom actions may be run by roles that can update the resource, unless an action
+// is granted to some roles explicitly, which reserves it for them
+type RolePolicy struct {
+	grants map[auth.Role]map[string]map[Operation]bool
+}
+
+// NewRolePolicy creates a policy that grants nothing yet
+func NewRolePolicy() *RolePolicy {
+	return &RolePolicy{grants: make(map[auth.Role]map[string]map[Operation]bool)}
+}
+
+// Allow grants operations on a resource to a role
+// Use AnyResource for every resource, and no operations to grant list, create, update and delete
+// e.g. Allow("editor", "Product", OperationList, OperationUpdate, ActionOperation("publish"))
+func (p *RolePolicy) Allow(role auth.Role, resource string, ops ...Operation) *RolePolicy {
+	if len(ops) == 0 {
+		ops = []Operation{OperationList, OperationCreate, OperationUpdate, OperationDelete}
+	}
+	resources, ok := p.grants[role]
+	if !ok {
+		resources = make(map[string]map[Operation]bool)
+		p.grants[role] = resources
+	}
+	granted, ok := resources[resource]
+	if !ok {
+		granted = make(map[Operation]bool)
+		resources[resource] = granted
+	}
+	for _, op := range ops {
+		granted[op] = true
+	}
+	return p
+}
+
+// Can reports whether any of the user's roles is granted the operation on the resource
+func (p *RolePolicy) Can(user *auth.AuthUser, op Operation, resource string) bool {
+	if user == nil {
+		return false
+	}
+	if _, isAction := op.ActionID(); isAction && !p.reserved(op, resource) {
+		op = OperationUpdate
+	}
+	for _, role := range user.Roles {
+		if p.granted(auth.Role(role), op, resource) {
+			return true
+		}
+	}
+	return false
+}
+
+// granted reports whether the role has the operation on the resource or on any resource
+func (p *RolePolicy) granted(role auth.Role, op Operation, resource string) bool {
+	resources := p.grants[role]
+	return resources[resource][op] || resources[AnyResource][op]
+}
+
+// reserved reports whether an action operation is granted explicitly to any role
+func (p *RolePolicy) reserved(op Operation, resource string) bool {
+	for _, resources := range p.grants {
+		if resources[resource][op] || resources[AnyResource][op] {
+			return true
+		}
+	}
+	return false
+}
+
+// SetPolicy sets the policy consulted for every operation on every resource
+// It only applies while authentication is enabled, as it decides by the signed-in user
+func (bo *BackOffice) SetPolicy(policy Policy) *BackOffice {
+	bo.policy = policy
+	return bo
+}
+
+// Policy returns the policy set with SetPolicy, or nil
+func (bo *BackOffice) Policy() Policy {
+	return bo.policy
+}
+
+// policyAllows reports whether the policy, if any, lets the request's user perform the operation
+func (bo *BackOffice) policyAllows(ctx context.Context, op Operation, resource string) bool {
+	if bo == nil || bo.policy == nil || bo.config.Auth == nil || !bo.config.Auth.Enabled {
+		return true
+	}
+	user, _ := auth.GetAuthUser(ctx)
+	return bo.policy.Can(user, op, resource)
+}
+
+// Authorize asks the AuthConfig.Authorize hook, if any, whether the request's user may perform the operation
+// on the record with the given ID, or on the resource as a whole when id is nil
+// Like the policy, the hook only applies while authentication is enabled
+func (r *Resource) Authorize(ctx context.Context, op Operation, id any) error {
+	if !r.AuthorizesRecords() {
+		return nil
+	}
+	user, _ := auth.GetAuthUser(ctx)
+	return r.backoffice.config.Auth.Authorize(ctx, user, r.Name, string(op), id)
+}
+
+// AuthorizesRecords reports whether an AuthConfig.Authorize hook decides about records of the resource
+func (r *Resource) AuthorizesRecords() bool {
+	bo := r.backoffice
+	return bo != nil && bo.config.Auth != nil && bo.config.Auth.Enabled && bo.config.Auth.Authorize != nil
+}
+
+// CanRunAction reports whether the request's user may run the custom action
+// Actions also need the update operation, checked separately when routing
+func (r *Resource) CanRunAction(ctx context.Context, actionID string) bool {
+	if r.backoffice.IsReadOnly(ctx) {
+		return false
+	}
+	op := ActionOperation(actionID)
+	return r.backoffice.resolvePermission(ctx, r.Name, "policy:"+string(op), func() bool {
+		return r.backoffice.policyAllows(ctx, op, r.Name)
+	})
+}
+
+// AllowedActions returns the custom actions the request's user may run
+func (r *Resource) AllowedActions(ctx context.Context) []CustomAction {
+	var actions []CustomAction
+	for _, action := range r.Actions {
+		if r.CanRunAction(ctx, action.ID) {
+			actions = append(actions, action)
+		}
+	}
+	return actions
+}
@@ -0,0 +1,125 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/preslavrachev/backoffice/middleware/auth"
+)
+
+func TestRolePolicy(t *testing.T) {
+	policy := NewRolePolicy().
+		Allow("admin", AnyResource).
+		Allow("editor", "Product", OperationList, OperationUpdate).
+		Allow("support", "Order", OperationList, OperationUpdate).
+		Allow("admin", "Order", ActionOperation("refund"))
+
+	admin := &auth.AuthUser{Username: "ann", Roles: []string{"admin"}}
+	editor := &auth.AuthUser{Username: "ed", Roles: []string{"editor"}}
+	support := &auth.AuthUser{Username: "sue", Roles: []string{"support"}}
+
+	tests := []struct {
+		name     string
+		user     *auth.AuthUser
+		op       Operation
+		resource string
+		want     bool
+	}{
+		{"admin anything", admin, OperationDelete, "Product", true},
+		{"editor granted", editor, OperationUpdate, "Product", true},
+		{"editor not granted", editor, OperationDelete, "Product", false},
+		{"editor other resource", editor, OperationList, "Order", false},
+		{"action follows update", editor, ActionOperation("publish"), "Product", true},
+		{"reserved action", support, ActionOperation("refund"), "Order", false},
+		{"reserved action granted", admin, ActionOperation("refund"), "Order", true},
+		{"unreserved action", support, ActionOperation("resend"), "Order", true},
+		{"no user", nil, OperationList, "Product", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := policy.Can(tt.user, tt.op, tt.resource); got != tt.want {
+				t.Errorf("Can(%v, %s, %s) = %t, want %t", tt.user, tt.op, tt.resource, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResourceCan_Policy(t *testing.T) {
+	type Product struct {
+		ID uint `db:"id"`
+	}
+
+	bo := New(&DummyAdapter{}, auth.AuthConfig{Enabled: true})
+	bo.RegisterResource(&Product{}).
+		WithPermissions(Permissions{Delete: DenyAll}).
+		WithAction("publish", "Publish", func(ctx context.Context, id any) error { return nil }).
+		WithAction("purge", "Purge", func(ctx context.Context, id any) error { return nil })
+	bo.SetPolicy(NewRolePolicy().
+		Allow("editor", "Product").
+		Allow("owner", "Product", ActionOperation("purge")))
+	resource, _ := bo.GetResource("Product")
+
+	ctx := auth.WithAuthUser(context.Background(), &auth.AuthUser{Roles: []string{"editor"}})
+	if !resource.Can(ctx, OperationUpdate) {
+		t.Error("Expected the policy to allow editors to update")
+	}
+	if resource.Can(ctx, OperationDelete) {
+		t.Error("Expected the resource permissions to still deny deletes")
+	}
+	if resource.Can(context.Background(), OperationList) {
+		t.Error("Expected the policy to deny anonymous requests")
+	}
+	if actions := resource.AllowedActions(ctx); len(actions) != 1 || actions[0].ID != "publish" {
+		t.Errorf("Expected only the publish action, got %+v", actions)
+	}
+
+	// Without authentication there is no user to decide by, so the policy doesn't apply
+	open := New(&DummyAdapter{}, auth.AuthConfig{})
+	open.RegisterResource(&Product{})
+	open.SetPolicy(NewRolePolicy())
+	resource, _ = open.GetResource("Product")
+	if !resource.Can(context.Background(), OperationList) {
+		t.Error("Expected the policy to be skipped while authentication is disabled")
+	}
+}
+
+func TestResourceAuthorize(t *testing.T) {
+	type Product struct {
+		ID uint `db:"id"`
+	}
+
+	var calls []string
+	authConfig := auth.AuthConfig{Enabled: true}
+	authConfig.Authorize = func(ctx context.Context, user *auth.AuthUser, resource, action string, id any) error {
+		calls = append(calls, fmt.Sprintf("%s %s %s %v", user.Username, resource, action, id))
+		if action == string(OperationDelete) {
+			return errors.New("deletes are frozen")
+		}
+		return nil
+	}
+	bo := New(&DummyAdapter{}, authConfig)
+	bo.RegisterResource(&Product{})
+	resource, _ := bo.GetResource("Product")
+
+	ctx := auth.WithAuthUser(context.Background(), &auth.AuthUser{Username: "ann"})
+	if err := resource.Authorize(ctx, OperationUpdate, uint(7)); err != nil {
+		t.Errorf("Expected the hook to allow updates, got %v", err)
+	}
+	if err := resource.Authorize(ctx, OperationDelete, nil); err == nil || err.Error() != "deletes are frozen" {
+		t.Errorf("Expected the hook's error, got %v", err)
+	}
+	if len(calls) != 2 || calls[0] != "ann Product update 7" || calls[1] != "ann Product delete <nil>" {
+		t.Errorf("Unexpected hook calls %q", calls)
+	}
+
+	// Like the policy, the hook is skipped while authentication is disabled
+	authConfig.Enabled = false
+	open := New(&DummyAdapter{}, authConfig)
+	open.RegisterResource(&Product{})
+	resource, _ = open.GetResource("Product")
+	if err := resource.Authorize(ctx, OperationDelete, nil); err != nil {
+		t.Errorf("Expected the hook to be skipped while authentication is disabled, got %v", err)
+	}
+}
@@ -3,6 +3,7 @@ package core
 import (
 	"os"
 	"strconv"
+	"strings"
 	"time"
 )
 
@@ -37,17 +38,34 @@ type SortField struct {
 	Precedence SortPrecedence `json:"precedence"`
 }
 
+// Sort returns an explicit sort field for use with WithDefaultSort
+func Sort(field string, direction SortDirection) SortField {
+	return SortField{Field: field, Direction: direction, Precedence: SortPrecedenceExplicit}
+}
+
 // Pagination represents pagination parameters
 type Pagination struct {
 	Limit  int `json:"limit"`
 	Offset int `json:"offset"`
 }
 
+// PaginationStyle selects how list pages move past the first page
+type PaginationStyle string
+
+const (
+	PaginationLoadMore PaginationStyle = "load_more" // Append rows with a "Load More" button
+	PaginationNumbered PaginationStyle = "numbered"  // First/Prev/page numbers/Next/Last links
+	PaginationInfinite PaginationStyle = "infinite"  // Append rows when the end of the list scrolls into view
+)
+
 // Query represents a comprehensive query with filters, sorting, and pagination
 type Query struct {
-	Filters    map[string]any `json:"filters"`
-	Sort       []SortField    `json:"sort"`
-	Pagination Pagination     `json:"pagination"`
+	Filters    map[string]any    `json:"filters"`
+	Sort       []SortField       `json:"sort"`
+	Pagination Pagination        `json:"pagination"`
+	Trashed    bool              `json:"trashed"`              // Return soft-deleted records instead of live ones
+	Search     string            `json:"search,omitempty"`     // Free text matched against the resource's searchable fields
+	Conditions []FilterCondition `json:"conditions,omitempty"` // Typed comparisons such as ranges, combined with Filters
 }
 
 // Result represents paginated query results
@@ -93,6 +111,12 @@ func (q *Query) WithSort(field string, direction SortDirection) *Query {
 	return q
 }
 
+// WithSearch sets the free text search term
+func (q *Query) WithSearch(term string) *Query {
+	q.Search = strings.TrimSpace(term)
+	return q
+}
+
 // WithPagination sets pagination parameters
 func (q *Query) WithPagination(limit, offset int) *Query {
 	if limit > MaxPageSize {
@@ -116,6 +140,9 @@ func (q *Query) NextPage() *Query {
 		Filters:    make(map[string]any),
 		Sort:       make([]SortField, len(q.Sort)),
 		Pagination: q.Pagination,
+		Trashed:    q.Trashed,
+		Search:     q.Search,
+		Conditions: append([]FilterCondition(nil), q.Conditions...),
 	}
 
 	// Copy filters
@@ -140,9 +167,17 @@ func (q *Query) GetCurrentPage() int {
 	return (q.Pagination.Offset / q.Pagination.Limit) + 1
 }
 
+// GetTotalPages returns the number of pages needed to show totalCount records, at least 1
+func (q *Query) GetTotalPages(totalCount int64) int {
+	if q.Pagination.Limit <= 0 || totalCount <= 0 {
+		return 1
+	}
+	return int((totalCount + int64(q.Pagination.Limit) - 1) / int64(q.Pagination.Limit))
+}
+
 // HasFilters returns true if the query has any filters
 func (q *Query) HasFilters() bool {
-	return len(q.Filters) > 0
+	return len(q.Filters) > 0 || len(q.Conditions) > 0
 }
 
 // HasSort returns true if the query has sorting
@@ -164,9 +199,10 @@ func (q *Query) ApplyDefaultSort(resource *Resource) {
 		return // Already has sorting
 	}
 
-	// Get the effective default sort for this resource
-	defaultSort := resource.GetEffectiveDefaultSort()
-	q.WithSort(defaultSort.Field, defaultSort.Direction)
+	// Apply every effective default sort, in order
+	for _, defaultSort := range resource.GetEffectiveDefaultSorts() {
+		q.WithSort(defaultSort.Field, defaultSort.Direction)
+	}
 }
 
 // getPageSizeFromEnv gets page size from environment variable or default
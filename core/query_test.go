@@ -371,6 +371,38 @@ func TestApplyDefaultSort(t *testing.T) {
 	}
 }
 
+func TestApplyDefaultSort_MultiColumn(t *testing.T) {
+	type TestModel struct {
+		ID   uint   `db:"id"`
+		Name string `db:"name"`
+	}
+
+	bo := &BackOffice{
+		resources:     make(map[string]*Resource),
+		resourceOrder: []string{},
+		config:        &Config{},
+	}
+	bo.RegisterResource(&TestModel{}).
+		WithDefaultSort(Sort("Name", SortAsc), Sort("ID", SortDesc))
+	resource, _ := bo.GetResource("TestModel")
+
+	query := NewQuery()
+	query.ApplyDefaultSort(resource)
+
+	if len(query.Sort) != 2 {
+		t.Fatalf("Expected 2 default sorts, got %d", len(query.Sort))
+	}
+	if query.Sort[0].Field != "Name" || query.Sort[0].Direction != SortAsc {
+		t.Errorf("Expected first sort Name ASC, got %s %s", query.Sort[0].Field, query.Sort[0].Direction)
+	}
+	if query.Sort[1].Field != "ID" || query.Sort[1].Direction != SortDesc {
+		t.Errorf("Expected second sort ID DESC, got %s %s", query.Sort[1].Field, query.Sort[1].Direction)
+	}
+	if resource.DefaultSort.Field != "Name" || resource.DefaultSort.Precedence != SortPrecedenceExplicit {
+		t.Errorf("Expected DefaultSort to hold the first explicit sort, got %+v", resource.DefaultSort)
+	}
+}
+
 func TestGetPageSizeFromEnv(t *testing.T) {
 	// Test default
 	if size := getPageSizeFromEnv(); size != DefaultPageSize {
@@ -449,3 +481,35 @@ func TestCreatedAtProvider(t *testing.T) {
 		t.Error("GetCreatedAt should return non-zero time")
 	}
 }
+
+func TestGetEffectivePageSize(t *testing.T) {
+	resource := &Resource{}
+	if size := resource.GetEffectivePageSize(); size != DefaultPageSize {
+		t.Errorf("Expected default page size %d, got %d", DefaultPageSize, size)
+	}
+
+	os.Setenv("BACKOFFICE_PAGE_SIZE", "25")
+	defer os.Unsetenv("BACKOFFICE_PAGE_SIZE")
+	if size := resource.GetEffectivePageSize(); size != 25 {
+		t.Errorf("Expected env var page size 25, got %d", size)
+	}
+
+	resource.PageSize = 50
+	if size := resource.GetEffectivePageSize(); size != 50 {
+		t.Errorf("Expected resource page size 50, got %d", size)
+	}
+
+	resource.PageSize = 1000
+	if size := resource.GetEffectivePageSize(); size != MaxPageSize {
+		t.Errorf("Expected page size capped at %d, got %d", MaxPageSize, size)
+	}
+}
+
+func TestQueryGetTotalPages(t *testing.T) {
+	query := NewQuery().WithPagination(10, 0)
+	for total, expected := range map[int64]int{0: 1, 1: 1, 10: 1, 11: 2, 95: 10} {
+		if pages := query.GetTotalPages(total); pages != expected {
+			t.Errorf("Expected %d pages for %d records, got %d", expected, total, pages)
+		}
+	}
+}
@@ -0,0 +1,46 @@
+package core
+
+import (
+	"context"
+
+	"github.com/preslavrachev/backoffice/middleware/auth"
+)
+
+// ReadOnlyConfig freezes the admin, e.g. during an incident: records can still be browsed, but create,
+// edit and delete controls are hidden and their endpoints reject requests
+type ReadOnlyConfig struct {
+	Enabled bool        `json:"enabled"` // Makes the admin read-only for everyone
+	Roles   []auth.Role `json:"roles"`   // Makes it read-only for users with any of these roles only
+	Message string      `json:"message"` // Shown in a banner while read-only, defaults to a generic notice
+}
+
+// AppliesTo reports whether the admin is read-only for the user
+func (c ReadOnlyConfig) AppliesTo(user *auth.AuthUser) bool {
+	return c.Enabled || (len(c.Roles) > 0 && user.HasRole(c.Roles...))
+}
+
+// SetReadOnly makes the admin, or the admin of some roles, read-only
+// Pass a zero ReadOnlyConfig to lift the freeze. It's safe to call while requests are served,
+// and takes precedence over Config.ReadOnly
+func (bo *BackOffice) SetReadOnly(config ReadOnlyConfig) *BackOffice {
+	bo.readOnly.Store(&config)
+	return bo
+}
+
+// ReadOnly returns the read-only mode set with SetReadOnly, or Config.ReadOnly before it's called
+func (bo *BackOffice) ReadOnly() ReadOnlyConfig {
+	if config := bo.readOnly.Load(); config != nil {
+		return *config
+	}
+	return bo.config.ReadOnly
+}
+
+// IsReadOnly reports whether the admin is read-only for the request's user
+// Admins viewing as another user are read-only too, unless ImpersonationConfig.AllowWrites is set
+func (bo *BackOffice) IsReadOnly(ctx context.Context) bool {
+	if bo == nil {
+		return false
+	}
+	user, _ := auth.GetAuthUser(ctx)
+	return bo.ReadOnly().AppliesTo(user) || bo.config.Auth.ImpersonationReadOnly(user)
+}
@@ -0,0 +1,94 @@
+package core
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/preslavrachev/backoffice/middleware/auth"
+)
+
+func TestReadOnlyMode(t *testing.T) {
+	type Product struct {
+		ID uint `db:"id"`
+	}
+
+	bo := New(&DummyAdapter{}, auth.AuthConfig{Enabled: true})
+	bo.RegisterResource(&Product{}).
+		WithAction("publish", "Publish", func(ctx context.Context, id any) error { return nil })
+	resource, _ := bo.GetResource("Product")
+	support := auth.WithAuthUser(context.Background(), &auth.AuthUser{Username: "sue", Roles: []string{"support"}})
+	admin := auth.WithAuthUser(context.Background(), &auth.AuthUser{Username: "ann", Roles: []string{"admin"}})
+
+	bo.SetReadOnly(ReadOnlyConfig{Enabled: true})
+	for _, op := range []Operation{OperationCreate, OperationUpdate, OperationDelete} {
+		if resource.Can(admin, op) {
+			t.Errorf("Expected %s to be denied while read-only", op)
+		}
+	}
+	if !resource.Can(admin, OperationList) {
+		t.Error("Expected listing to stay allowed while read-only")
+	}
+	if resource.CanRunAction(admin, "publish") {
+		t.Error("Expected actions to be denied while read-only")
+	}
+
+	bo.SetReadOnly(ReadOnlyConfig{Roles: []auth.Role{"support"}})
+	if resource.Can(support, OperationUpdate) || !bo.IsReadOnly(support) {
+		t.Error("Expected support to be read-only")
+	}
+	if !resource.Can(admin, OperationUpdate) || bo.IsReadOnly(admin) {
+		t.Error("Expected admins to keep editing while only support is read-only")
+	}
+
+	bo.SetReadOnly(ReadOnlyConfig{})
+	if !resource.Can(support, OperationUpdate) {
+		t.Error("Expected the freeze to be lifted")
+	}
+}
+
+func TestReadOnlyMode_Impersonation(t *testing.T) {
+	type Product struct {
+		ID uint `db:"id"`
+	}
+
+	authConfig := auth.AuthConfig{Enabled: true}
+	bo := New(&DummyAdapter{}, authConfig)
+	bo.RegisterResource(&Product{})
+	resource, _ := bo.GetResource("Product")
+	viewingAs := auth.WithAuthUser(context.Background(), &auth.AuthUser{
+		Username:     "ed",
+		Roles:        []string{"editor"},
+		Impersonator: &auth.AuthUser{Username: "ann", Roles: []string{"admin", "editor"}},
+	})
+
+	if resource.Can(viewingAs, OperationUpdate) || !bo.IsReadOnly(viewingAs) {
+		t.Error("Expected admins viewing as another user to be read-only")
+	}
+	bo.GetAuth().Impersonation.AllowWrites = true
+	if !resource.Can(viewingAs, OperationUpdate) || bo.IsReadOnly(viewingAs) {
+		t.Error("Expected AllowWrites to let impersonated sessions change records")
+	}
+}
+
+func TestReadOnlyMode_SetWhileServing(t *testing.T) {
+	bo := New(&DummyAdapter{}, auth.AuthConfig{Enabled: true})
+	ctx := auth.WithAuthUser(context.Background(), &auth.AuthUser{Username: "ann"})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			bo.SetReadOnly(ReadOnlyConfig{Enabled: true})
+		}()
+		go func() {
+			defer wg.Done()
+			bo.IsReadOnly(ctx)
+		}()
+	}
+	wg.Wait()
+	if !bo.IsReadOnly(ctx) {
+		t.Error("Expected the admin to be read-only")
+	}
+}
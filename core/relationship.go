@@ -0,0 +1,184 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// RelatedLinkMode selects how one-to-many relationships link to their children
+type RelatedLinkMode string
+
+const (
+	RelatedLinkModal RelatedLinkMode = "modal" // Open the loaded children in a modal (default)
+	RelatedLinkList  RelatedLinkMode = "list"  // Link to the child list filtered by the parent
+	RelatedLinkBoth  RelatedLinkMode = "both"  // Show the list link next to the modal
+)
+
+// RelationshipDisplayFunc formats a loaded related record for display
+type RelationshipDisplayFunc func(related any) string
+
+// defaultDisplayFields are tried after the configured display fields, so relationship cells are never empty
+var defaultDisplayFields = []string{"Name", "Title", "Email", "ID"}
+
+// RecordLabel returns the text identifying a record: its Name, Title, Email or ID
+func RecordLabel(item any) string {
+	return (&RelationshipInfo{}).DisplayValue(item)
+}
+
+// DisplayValue returns the text shown for a loaded related record
+// The display func wins, then DisplayField, FallbackDisplayFields and finally Name, Title, Email and ID
+func (ri *RelationshipInfo) DisplayValue(related any) string {
+	if related == nil {
+		return ""
+	}
+	if val := reflect.ValueOf(related); val.Kind() == reflect.Ptr && val.IsNil() {
+		return ""
+	}
+	if ri.DisplayFunc != nil {
+		if value := ri.DisplayFunc(related); value != "" {
+			return value
+		}
+	}
+
+	candidates := append([]string{ri.DisplayField}, ri.FallbackDisplayFields...)
+	candidates = append(candidates, defaultDisplayFields...)
+	for _, name := range candidates {
+		if name == "" {
+			continue
+		}
+		if value := displayString(GetFieldValue(related, name)); value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+// displayString converts a field value to text, treating nil pointers and zero IDs as empty
+func displayString(value any) string {
+	if value == nil {
+		return ""
+	}
+	val := reflect.ValueOf(value)
+	if val.Kind() == reflect.Ptr {
+		if val.IsNil() {
+			return ""
+		}
+		value = val.Elem().Interface()
+		val = val.Elem()
+	}
+	if val.IsZero() && val.Kind() != reflect.Bool {
+		return ""
+	}
+	if s, ok := value.(string); ok {
+		return s
+	}
+	return fmt.Sprintf("%v", value)
+}
+
+// ShowsListLink reports whether the relationship links to the filtered child list
+func (ri *RelationshipInfo) ShowsListLink() bool {
+	return ri.Type == RelationshipOneToMany && ri.ForeignKey != "" &&
+		(ri.LinkMode == RelatedLinkList || ri.LinkMode == RelatedLinkBoth)
+}
+
+// ShowsModal reports whether the relationship opens its loaded children in a modal
+func (ri *RelationshipInfo) ShowsModal() bool {
+	return !ri.ShowsListLink() || ri.LinkMode == RelatedLinkBoth
+}
+
+// RelationOptionLimit is the number of records a relation picker offers at once
+const RelationOptionLimit = 20
+
+// IsPicker reports whether a relationship is edited by picking the related record, which sets its foreign key
+func (ri *RelationshipInfo) IsPicker() bool {
+	return ri != nil && ri.Type == RelationshipManyToOne && ri.ForeignKey != ""
+}
+
+// SearchRelated returns the records a relation picker offers for the typed text, within the related resource's scope
+// Text is matched by the adapter's Search; without text the first records are offered
+func SearchRelated(ctx context.Context, adapter Adapter, related *Resource, text string) ([]any, error) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		query := related.ApplyScope(ctx, NewQuery().WithPagination(RelationOptionLimit, 0))
+		result, err := adapter.Find(ctx, related, query)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load %s: %w", related.PluralName, err)
+		}
+		if result == nil {
+			return nil, nil
+		}
+		return result.Items, nil
+	}
+
+	items, err := adapter.Search(ctx, related, text)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search %s: %w", related.PluralName, err)
+	}
+	var options []any
+	for _, item := range items {
+		if len(options) == RelationOptionLimit {
+			break
+		}
+		err := CheckScope(ctx, adapter, related, GetFieldValue(item, related.IDField))
+		if errors.Is(err, ErrOutOfScope) {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		options = append(options, item)
+	}
+	return options, nil
+}
+
+// RelatedPageSize is the number of children a detail page lists per page of a one-to-many relationship
+const RelatedPageSize = 10
+
+// childQuery restricts a query to the children of a parent, within the child's scope
+func childQuery(ctx context.Context, query *Query, child *Resource, foreignKey string, parentID any) *Query {
+	query = child.ApplyScope(ctx, query)
+	if query.Filters == nil {
+		query.Filters = make(map[string]any)
+	}
+	query.Filters[foreignKey] = parentID
+	return query
+}
+
+// CountChildren counts the child records whose foreign key points at the parent, within the child's scope
+func CountChildren(ctx context.Context, adapter Adapter, child *Resource, foreignKey string, parentID any) (int64, error) {
+	query := childQuery(ctx, NewQuery().WithPagination(1, 0), child, foreignKey, parentID)
+
+	result, err := adapter.Find(ctx, child, query)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count %s: %w", child.PluralName, err)
+	}
+	if result == nil {
+		return 0, nil
+	}
+	return result.TotalCount, nil
+}
+
+// FindChildren returns a page of the child records whose foreign key points at the parent, in the child's default order
+// Pages start at 1 and hold RelatedPageSize records
+func FindChildren(ctx context.Context, adapter Adapter, child *Resource, foreignKey string, parentID any, page int) (*Result, error) {
+	if page < 1 {
+		page = 1
+	}
+	query := childQuery(ctx, NewQuery().WithPagination(RelatedPageSize, (page-1)*RelatedPageSize), child, foreignKey, parentID)
+	for _, sort := range child.GetEffectiveDefaultSorts() {
+		if sort.Field != "" {
+			query.WithSort(sort.Field, sort.Direction)
+		}
+	}
+
+	result, err := adapter.Find(ctx, child, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load %s: %w", child.PluralName, err)
+	}
+	if result == nil {
+		return &Result{}, nil
+	}
+	return result, nil
+}
@@ -0,0 +1,128 @@
+package core
+
+import (
+	"context"
+	"testing"
+
+	"github.com/preslavrachev/backoffice/middleware/auth"
+)
+
+func TestRelationshipDisplayValue(t *testing.T) {
+	type Author struct {
+		ID        uint
+		FirstName string
+		LastName  string
+		Email     string
+		Nickname  *string
+	}
+
+	nickname := "ally"
+	author := &Author{ID: 7, FirstName: "Alice", LastName: "Smith", Email: "alice@example.com"}
+
+	tests := []struct {
+		name     string
+		info     RelationshipInfo
+		related  any
+		expected string
+	}{
+		{"missing display field falls back to Email", RelationshipInfo{DisplayField: "Name"}, author, "alice@example.com"},
+		{"explicit fallbacks are tried in order", RelationshipInfo{DisplayField: "Name", FallbackDisplayFields: []string{"Nickname", "LastName"}}, author, "Smith"},
+		{"pointer fallback is dereferenced", RelationshipInfo{DisplayField: "Nickname"}, &Author{ID: 7, Nickname: &nickname}, "ally"},
+		{"ID is the last resort", RelationshipInfo{DisplayField: "Name"}, &Author{ID: 7}, "7"},
+		{"display func wins", RelationshipInfo{DisplayField: "Email", DisplayFunc: func(related any) string {
+			a := related.(*Author)
+			return a.FirstName + " " + a.LastName
+		}}, author, "Alice Smith"},
+		{"empty display func result uses fields", RelationshipInfo{DisplayField: "FirstName", DisplayFunc: func(any) string { return "" }}, author, "Alice"},
+		{"nil related record", RelationshipInfo{DisplayField: "Name"}, (*Author)(nil), ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.info.DisplayValue(tt.related); got != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestRelationshipBuilder_Fallbacks(t *testing.T) {
+	type Post struct {
+		ID       uint `db:"id"`
+		AuthorID uint `db:"author_id"`
+		Author   any  `db:"-"`
+	}
+
+	bo := &BackOffice{
+		resources:     make(map[string]*Resource),
+		resourceOrder: []string{},
+		config:        &Config{},
+	}
+	bo.RegisterResource(&Post{}).
+		WithManyToOneField("Author", "Author", func(r *RelationshipBuilder) {
+			r.DisplayField("Name").
+				FallbackDisplayFields("Title", "Email").
+				DisplayFunc(func(any) string { return "custom" })
+		})
+
+	resource, _ := bo.GetResource("Post")
+	field, ok := resource.GetField("Author")
+	if !ok || field.Relationship == nil {
+		t.Fatal("Expected Author relationship field")
+	}
+	if len(field.Relationship.FallbackDisplayFields) != 2 || field.Relationship.FallbackDisplayFields[1] != "Email" {
+		t.Errorf("Expected fallback display fields [Title Email], got %v", field.Relationship.FallbackDisplayFields)
+	}
+	if field.Relationship.DisplayFunc == nil {
+		t.Error("Expected display func to be set")
+	}
+}
+
+// pickerAdapter searches tickets by returning all of them, leaving the scope to SearchRelated
+type pickerAdapter struct {
+	scopeTestAdapter
+}
+
+func (a *pickerAdapter) Search(ctx context.Context, resource *Resource, query string) ([]any, error) {
+	var items []any
+	for _, ticket := range a.tickets {
+		items = append(items, ticket)
+	}
+	return items, nil
+}
+
+func TestSearchRelated(t *testing.T) {
+	bo := New(&DummyAdapter{}, auth.AuthConfig{})
+	bo.RegisterResource(&Ticket{}).WithScope(regionScope)
+	resource, _ := bo.GetResource("Ticket")
+
+	adapter := &pickerAdapter{}
+	for id := uint(1); id <= RelationOptionLimit+5; id++ {
+		region := "emea"
+		if id%2 == 0 {
+			region = "apac"
+		}
+		adapter.tickets = append(adapter.tickets, &Ticket{ID: id, Region: region})
+	}
+	ctx := auth.WithAuthUser(context.Background(), &auth.AuthUser{Username: "agent", Roles: []string{"emea"}})
+
+	items, err := SearchRelated(ctx, adapter, resource, "  ")
+	if err != nil || len(items) != 13 {
+		t.Fatalf("Expected the 13 in-scope tickets without text, got %d, %v", len(items), err)
+	}
+
+	items, err = SearchRelated(ctx, adapter, resource, "1")
+	if err != nil || len(items) != 13 {
+		t.Fatalf("Expected only in-scope matches, got %d, %v", len(items), err)
+	}
+	for _, item := range items {
+		if item.(*Ticket).Region != "emea" {
+			t.Errorf("Expected ticket %d to be filtered out by the scope", item.(*Ticket).ID)
+		}
+	}
+
+	items, _ = SearchRelated(context.Background(), adapter, resource, "1")
+	if len(items) != RelationOptionLimit {
+		t.Errorf("Expected matches capped at %d, got %d", RelationOptionLimit, len(items))
+	}
+}
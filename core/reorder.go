@@ -0,0 +1,88 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// ReorderRecords stores a new order of records in the resource's order field
+// The records keep the order values they held between them, so reordering one page of
+// a list leaves the other pages in place; duplicate or non-positive values are renumbered
+func ReorderRecords(ctx context.Context, adapter Adapter, resource *Resource, ids []any) error {
+	if resource.OrderField == "" {
+		return fmt.Errorf("%s has no order field", resource.DisplayName)
+	}
+
+	items := make([]any, len(ids))
+	slots := make([]int64, len(ids))
+	for i, id := range ids {
+		if err := CheckScope(ctx, adapter, resource, id); err != nil {
+			return err
+		}
+		item, err := adapter.GetByID(ctx, resource, id)
+		if err != nil {
+			return fmt.Errorf("failed to load %s %v: %w", resource.DisplayName, id, err)
+		}
+		if !resource.CanRecord(ctx, OperationUpdate, item) {
+			return fmt.Errorf("%s %v: %w", resource.DisplayName, id, ErrNotOwner)
+		}
+		value, ok := orderValue(item, resource.OrderField)
+		if !ok {
+			return fmt.Errorf("order field %s of %s is not an integer", resource.OrderField, resource.DisplayName)
+		}
+		items[i] = item
+		slots[i] = value
+	}
+
+	sort.Slice(slots, func(i, j int) bool { return slots[i] < slots[j] })
+	for i := range slots {
+		if slots[i] <= 0 || (i > 0 && slots[i] <= slots[i-1]) {
+			start := max(slots[0], 1)
+			for j := range slots {
+				slots[j] = start + int64(j)
+			}
+			break
+		}
+	}
+
+	for i, item := range items {
+		if current, _ := orderValue(item, resource.OrderField); current == slots[i] {
+			continue
+		}
+		if err := SaveRecordVersion(ctx, adapter, resource, ids[i]); err != nil {
+			return err
+		}
+		setOrderValue(item, resource.OrderField, slots[i])
+		BumpLockVersion(resource, item)
+		if err := adapter.Update(ctx, resource, ids[i], item); err != nil {
+			return fmt.Errorf("failed to update %s %v: %w", resource.DisplayName, ids[i], err)
+		}
+	}
+	return nil
+}
+
+// orderValue reads an integer order field of a record
+func orderValue(item any, fieldName string) (int64, bool) {
+	field := reflect.Indirect(reflect.ValueOf(item)).FieldByName(fieldName)
+	switch {
+	case field.CanInt():
+		return field.Int(), true
+	case field.CanUint():
+		return int64(field.Uint()), true
+	}
+	return 0, false
+}
+
+// setOrderValue writes an integer order field of a record
+func setOrderValue(item any, fieldName string, value int64) {
+	field := reflect.Indirect(reflect.ValueOf(item)).FieldByName(fieldName)
+	switch {
+	case !field.CanSet():
+	case field.CanInt():
+		field.SetInt(value)
+	case field.CanUint():
+		field.SetUint(uint64(value))
+	}
+}
@@ -0,0 +1,83 @@
+package core
+
+import (
+	"context"
+	"testing"
+
+	"github.com/preslavrachev/backoffice/middleware/auth"
+)
+
+type rankedCategory struct {
+	ID        uint
+	Name      string
+	SortOrder int
+}
+
+// rankedAdapter serves categories by ID and records updates
+type rankedAdapter struct {
+	DummyAdapter
+	items   map[uint]*rankedCategory
+	updates int
+}
+
+func (a *rankedAdapter) GetByID(ctx context.Context, resource *Resource, id any) (any, error) {
+	item := *a.items[id.(uint)]
+	return &item, nil
+}
+
+func (a *rankedAdapter) Update(ctx context.Context, resource *Resource, id any, data any) error {
+	a.items[id.(uint)] = data.(*rankedCategory)
+	a.updates++
+	return nil
+}
+
+func newRankedResource(t *testing.T, adapter Adapter) *Resource {
+	t.Helper()
+	bo := New(adapter, auth.AuthConfig{})
+	bo.RegisterResource(&rankedCategory{}).WithFields("Name").WithOrderField("SortOrder")
+	resource, _ := bo.GetResource("rankedCategory")
+	return resource
+}
+
+func TestReorderRecords_KeepsSlots(t *testing.T) {
+	adapter := &rankedAdapter{items: map[uint]*rankedCategory{
+		1: {ID: 1, Name: "Books", SortOrder: 10},
+		2: {ID: 2, Name: "Music", SortOrder: 20},
+		3: {ID: 3, Name: "Games", SortOrder: 30},
+	}}
+	resource := newRankedResource(t, adapter)
+
+	if err := ReorderRecords(context.Background(), adapter, resource, []any{uint(3), uint(1), uint(2)}); err != nil {
+		t.Fatalf("ReorderRecords failed: %v", err)
+	}
+	for id, want := range map[uint]int{3: 10, 1: 20, 2: 30} {
+		if got := adapter.items[id].SortOrder; got != want {
+			t.Errorf("Expected record %d at %d, got %d", id, want, got)
+		}
+	}
+	if adapter.updates != 3 {
+		t.Errorf("Expected 3 updates, got %d", adapter.updates)
+	}
+}
+
+func TestReorderRecords_RenumbersDuplicates(t *testing.T) {
+	adapter := &rankedAdapter{items: map[uint]*rankedCategory{
+		1: {ID: 1, Name: "Books"},
+		2: {ID: 2, Name: "Music"},
+	}}
+	resource := newRankedResource(t, adapter)
+
+	if err := ReorderRecords(context.Background(), adapter, resource, []any{uint(2), uint(1)}); err != nil {
+		t.Fatalf("ReorderRecords failed: %v", err)
+	}
+	if adapter.items[2].SortOrder != 1 || adapter.items[1].SortOrder != 2 {
+		t.Errorf("Expected records to be renumbered from 1, got %d and %d", adapter.items[2].SortOrder, adapter.items[1].SortOrder)
+	}
+}
+
+func TestWithOrderField_DefaultSort(t *testing.T) {
+	resource := newRankedResource(t, &DummyAdapter{})
+	if resource.DefaultSort.Field != "SortOrder" || resource.DefaultSort.Direction != SortAsc {
+		t.Errorf("Expected the order field to sort the list by default, got %+v", resource.DefaultSort)
+	}
+}
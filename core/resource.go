@@ -1,6 +1,7 @@
 package core
 
 import (
+	"context"
 	"fmt"
 	"reflect"
 	"regexp"
@@ -13,22 +14,54 @@ import (
 
 // Resource represents a registered resource with its metadata
 type Resource struct {
-	Name         string                  `json:"name"`
-	DisplayName  string                  `json:"display_name"`
-	PluralName   string                  `json:"plural_name"`
-	Model        any                     `json:"-"`
-	ModelType    reflect.Type            `json:"-"`
-	Fields       []FieldInfo             `json:"fields"`
-	PrimaryKey   string                  `json:"primary_key"`
-	IDField      string                  `json:"id_field"`
-	IDFieldType  reflect.Type            `json:"-"` // Cached type of ID field for efficient parsing
-	TableName    string                  `json:"table_name"`
-	Hidden       bool                    `json:"hidden"`
-	ReadOnly     bool                    `json:"read_only"`
-	FieldConfigs map[string]*FieldConfig `json:"-"`
-	FieldOrder   []string                `json:"-"`            // Track order of field registration
-	DefaultSort  SortField               `json:"default_sort"` // Default sorting configuration
-	Actions      []CustomAction          `json:"-"`            // Custom actions for this resource
+	Name              string                  `json:"name"`
+	DisplayName       string                  `json:"display_name"`
+	PluralName        string                  `json:"plural_name"`
+	Model             any                     `json:"-"`
+	ModelType         reflect.Type            `json:"-"`
+	Fields            []FieldInfo             `json:"fields"`
+	PrimaryKey        string                  `json:"primary_key"`
+	IDField           string                  `json:"id_field"`
+	IDFieldType       reflect.Type            `json:"-"` // Cached type of ID field for efficient parsing
+	IDCodec           IDCodec                 `json:"-"` // Custom ID encoding for URLs, nil for the ID field's natural format
+	TableName         string                  `json:"table_name"`
+	Hidden            bool                    `json:"hidden"`
+	ReadOnly          bool                    `json:"read_only"`
+	FieldConfigs      map[string]*FieldConfig `json:"-"`
+	FieldOrder        []string                `json:"-"`                 // Track order of field registration
+	DefaultSort       SortField               `json:"default_sort"`      // Default sorting configuration
+	DefaultSorts      []SortField             `json:"default_sorts"`     // All explicit default sorts, DefaultSort first
+	Actions           []CustomAction          `json:"-"`                 // Custom actions for this resource
+	CollectionActions []CollectionAction      `json:"-"`                 // Actions over the whole resource
+	Inlines           []InlineConfig          `json:"-"`                 // Child resources edited on the detail page
+	TreeParentField   string                  `json:"tree_parent_field"` // Self-referential parent field for tree lists
+	Export            *ExportConfig           `json:"-"`                 // Export columns and formats, nil when exports are disabled
+	Import            *ImportConfig           `json:"-"`                 // Importable fields and upsert key, nil when imports are disabled
+	OwnerField        string                  `json:"owner_field"`       // Field filled with the creating user's ID
+	OwnerOnly         bool                    `json:"owner_only"`        // Restrict updates and deletes to the owner
+	OwnerAdminRoles   []string                `json:"-"`                 // Roles that may change any owner-restricted record
+	Validators        []ValidatorFunc         `json:"-"`                 // Whole-record validation rules
+	Permissions       Permissions             `json:"-"`                 // Per-operation access checks
+	StepUp            StepUpConfig            `json:"-"`                 // Re-authentication required before destructive operations
+	Scope             ScopeFunc               `json:"-"`                 // Row-level query restriction
+	BaseScope         BaseScopeFunc           `json:"-"`                 // Query restriction applied to every request
+	History           HistoryStore            `json:"-"`                 // Version history storage, nil when disabled
+	SoftDeleteField   string                  `json:"soft_delete_field"` // Field holding the deletion timestamp, empty when disabled
+	Group             string                  `json:"group"`             // Navigation group heading, empty when ungrouped
+	DisplayOrder      int                     `json:"display_order"`     // Position on the index and navigation, 0 when unset
+	PageSize          int                     `json:"page_size"`         // Default list page size, 0 to use the global default
+	PaginationStyle   PaginationStyle         `json:"pagination_style"`  // How lists page, empty for PaginationLoadMore
+	FreezeFirstColumn bool                    `json:"frozen_column"`     // Keep the first list column visible while scrolling horizontally
+	LockField         string                  `json:"lock_field"`        // Version field checked against concurrent edits, empty for DefaultLockField
+	OrderField        string                  `json:"order_field"`       // Integer field holding the manual order of records, empty when disabled
+	Charts            []Widget                `json:"-"`                 // Chart widgets shown above the list
+	Stats             []Stat                  `json:"-"`                 // KPI cards shown above the list
+	DetailTabs        []DetailTab             `json:"detail_tabs"`       // Tabs of the detail page in order, empty for a single page
+	DetailPanels      []DetailPanelFunc       `json:"-"`                 // App-provided sections shown beside the record's fields
+	RowClassFunc      RowClassFunc            `json:"-"`                 // Extra classes tinting list rows by their record
+	EmptyState        *EmptyState             `json:"empty_state"`       // Shown when the list has no records, nil for the plain message
+
+	backoffice *BackOffice // Admin the resource is registered with, for its Policy
 }
 
 // ResourceMeta contains basic metadata for templates
@@ -51,6 +84,20 @@ func (r *Resource) GetMeta() ResourceMeta {
 	}
 }
 
+// IsNumberedPagination reports whether lists of the resource use numbered page links instead of Load More
+func (r *Resource) IsNumberedPagination() bool {
+	return r.PaginationStyle == PaginationNumbered
+}
+
+// GetEffectivePageSize returns the default page size for this resource
+// following the precedence hierarchy: WithPageSize > BACKOFFICE_PAGE_SIZE > DefaultPageSize
+func (r *Resource) GetEffectivePageSize() int {
+	if r.PageSize > 0 {
+		return min(r.PageSize, MaxPageSize)
+	}
+	return getPageSizeFromEnv()
+}
+
 // GetEffectiveDefaultSort returns the effective default sort for this resource
 // following the precedence hierarchy: Explicit > CreatedAt > ID
 func (r *Resource) GetEffectiveDefaultSort() SortField {
@@ -82,6 +129,15 @@ func (r *Resource) GetEffectiveDefaultSort() SortField {
 	}
 }
 
+// GetEffectiveDefaultSorts returns every default sort for this resource
+// Explicit multi-column sorts are returned in order, otherwise the single effective default
+func (r *Resource) GetEffectiveDefaultSorts() []SortField {
+	if len(r.DefaultSorts) > 0 {
+		return append([]SortField(nil), r.DefaultSorts...)
+	}
+	return []SortField{r.GetEffectiveDefaultSort()}
+}
+
 // DiscoverFields extracts field information from the struct using reflection
 func (r *Resource) DiscoverFields() error {
 	t := r.ModelType
@@ -149,7 +205,7 @@ func (r *Resource) DiscoverFields() error {
 			// Computed field
 			fieldInfo = FieldInfo{
 				Name:        fieldName,
-				Type:        "string", // Computed fields return strings
+				Type:        config.ComputedKind.GoType(), // Plain computed fields return strings
 				JSONName:    fieldName,
 				DisplayName: fieldName,
 				Required:    false,
@@ -198,6 +254,12 @@ func (r *Resource) DiscoverFields() error {
 
 		// Apply user configurations
 		config.Apply(&fieldInfo)
+		if fieldName == r.OwnerField {
+			fieldInfo.ReadOnly = true // Owners are set from the session user
+		}
+		if fieldInfo.IsComputed && config.CacheTTL > 0 {
+			r.memoizeComputedField(&fieldInfo, config)
+		}
 		r.Fields = append(r.Fields, fieldInfo)
 	}
 
@@ -318,13 +380,23 @@ func GetFieldValue(item any, fieldName string) any {
 
 // GetFieldValueWithResource extracts field value, handling computed fields
 func GetFieldValueWithResource(item any, fieldInfo *FieldInfo, resource *Resource) any {
+	return GetFieldValueWithResourceCtx(context.Background(), item, fieldInfo, resource)
+}
+
+// GetFieldValueWithResourceCtx extracts field value, passing ctx to context-aware computed fields
+func GetFieldValueWithResourceCtx(ctx context.Context, item any, fieldInfo *FieldInfo, resource *Resource) any {
 	if item == nil {
 		return nil
 	}
 
 	// Handle computed fields
-	if fieldInfo.IsComputed && fieldInfo.ComputeFunc != nil {
-		return fieldInfo.ComputeFunc(item)
+	if fieldInfo.IsComputed && fieldInfo.TypedComputeFunc != nil {
+		return fieldInfo.TypedComputeFunc(item)
+	}
+	if fieldInfo.IsComputed {
+		if value, ok := fieldInfo.computeString(ctx, item); ok {
+			return value
+		}
 	}
 
 	// Regular field extraction
@@ -382,15 +454,31 @@ func truncateText(text string, maxLength int) string {
 }
 
 func FormatFieldValueForDisplay(item any, field *FieldInfo) string {
+	return FormatFieldValueForDisplayCtx(context.Background(), item, field)
+}
+
+// FormatFieldValueForDisplayCtx formats field values for display, passing ctx to context-aware computed fields
+func FormatFieldValueForDisplayCtx(ctx context.Context, item any, field *FieldInfo) string {
 	// Handle computed fields
-	if field.IsComputed && field.ComputeFunc != nil {
-		return field.ComputeFunc(item)
+	if field.IsComputed && field.TypedComputeFunc != nil {
+		return FormatComputedValue(field.TypedComputeFunc(item), field.ComputedKind)
+	}
+	if field.IsComputed {
+		if value, ok := field.computeString(ctx, item); ok {
+			return value
+		}
 	}
 
 	value := GetFieldValue(item, field.Name)
+	if formatted, ok := field.FormatValue(value); ok {
+		return formatted
+	}
 	if value == nil {
 		return ""
 	}
+	if field.IsFile() {
+		return field.FileName(item) // Storage keys stay out of the page
+	}
 
 	// Handle slice/array fields - show count instead of raw slice
 	reflectVal := reflect.ValueOf(value)
@@ -435,13 +523,19 @@ func FormatFieldValueForDisplayWithResource(item any, field *FieldInfo, resource
 		return ""
 	}
 
-	// For computed fields, the value is already a string
+	// For computed fields, the value is already a string unless a kind was declared
 	if field.IsComputed {
+		if field.TypedComputeFunc != nil {
+			return FormatComputedValue(value, field.ComputedKind)
+		}
 		if str, ok := value.(string); ok {
 			return str
 		}
 		return fmt.Sprintf("%v", value)
 	}
+	if formatted, ok := field.FormatValue(value); ok {
+		return formatted
+	}
 
 	// Handle slice/array fields - show count instead of raw slice
 	reflectVal := reflect.ValueOf(value)
@@ -498,7 +592,7 @@ func (r *Resource) GetFieldSortConfiguration(fieldName string) []SortField {
 }
 
 // IsFieldSortable checks if a field can be sorted
-// Returns false for computed/derived fields without explicit sort configuration
+// Returns false for computed/derived fields without explicit sort configuration, and for encrypted fields
 func (r *Resource) IsFieldSortable(fieldName string) bool {
 	// Find the field in the resource
 	for _, field := range r.Fields {
@@ -507,6 +601,14 @@ func (r *Resource) IsFieldSortable(fieldName string) bool {
 			if len(field.SortFields) > 0 {
 				return true
 			}
+			// Encrypted columns hold ciphertext, which sorts meaninglessly
+			if field.IsEncrypted() {
+				return false
+			}
+			// Typed computed fields can be compared in memory
+			if field.IsInMemorySortable() {
+				return true
+			}
 			// If field is computed/derived without sort config, not sortable
 			if field.IsComputed && !field.IsSortable {
 				return false
@@ -519,6 +621,16 @@ func (r *Resource) IsFieldSortable(fieldName string) bool {
 	return true
 }
 
+// GetField returns the field info for the given field name
+func (r *Resource) GetField(fieldName string) (*FieldInfo, bool) {
+	for i := range r.Fields {
+		if r.Fields[i].Name == fieldName {
+			return &r.Fields[i], true
+		}
+	}
+	return nil, false
+}
+
 // GetColumnName resolves the database column name for a field following priority order:
 // 1. Explicit override (DBColumnName in FieldConfig/FieldInfo)
 // 2. Struct tag parsing (db, gorm, json)
@@ -594,8 +706,22 @@ func (r *Resource) parseStructTags(fieldName string) string {
 
 // ParseID parses an ID string based on the actual ID field type of this resource.
 // Supports integers (int/int64/uint/uint32/etc), strings (including UUIDs), and custom string types.
+// Resources with an IDCodec delegate parsing to it.
 // Returns the parsed ID value and an error if parsing fails.
 func (r *Resource) ParseID(idStr string) (any, error) {
+	if r.IDCodec != nil {
+		id, err := r.IDCodec.Parse(idStr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid ID format for %s: %w", r.Name, err)
+		}
+		return id, nil
+	}
+	return r.ParseStoredID(idStr)
+}
+
+// ParseStoredID converts an ID as stored, rather than its URL form, to the ID field's type
+// Relation pickers post stored IDs, so they bypass the IDCodec
+func (r *Resource) ParseStoredID(idStr string) (any, error) {
 	if r.IDFieldType == nil {
 		return nil, fmt.Errorf("ID field type not initialized for resource %s: %w", r.Name, fmt.Errorf("missing IDFieldType"))
 	}
@@ -657,3 +783,16 @@ func (r *Resource) ParseID(idStr string) (any, error) {
 		return nil, fmt.Errorf("unsupported ID field type %s (%s) for resource %s", idType.Kind(), idType.String(), r.Name)
 	}
 }
+
+// SearchableFields returns the text fields marked Searchable, which list searches match against
+// Encrypted fields are left out, their columns holding ciphertext, and so are masked fields, whose values
+// searches would reveal one character at a time
+func (r *Resource) SearchableFields() []FieldInfo {
+	var fields []FieldInfo
+	for _, field := range r.Fields {
+		if field.Searchable && !field.IsComputed && !field.IsEncrypted() && !field.Masked && field.Type == "string" {
+			fields = append(fields, field)
+		}
+	}
+	return fields
+}
@@ -2,6 +2,7 @@ package core
 
 import (
 	"context"
+	"fmt"
 	"reflect"
 	"testing"
 
@@ -440,3 +441,45 @@ func contains(s, substr string) bool {
 	}
 	return false
 }
+
+func TestFieldFormat(t *testing.T) {
+	type File struct {
+		ID   uint    `db:"id"`
+		Size int64   `db:"size"`
+		Note *string `db:"note"`
+	}
+
+	bo := New(&DummyAdapter{}, auth.AuthConfig{})
+	bo.RegisterResource(&File{}).
+		WithField("Size", func(f *FieldBuilder) {
+			f.Format(func(value any) string {
+				return fmt.Sprintf("%.1f KB", float64(value.(int64))/1024)
+			})
+		}).
+		WithField("Note", func(f *FieldBuilder) {
+			f.Format(func(value any) string { return "note: " + value.(string) })
+		})
+	resource, _ := bo.GetResource("File")
+
+	size, _ := resource.GetField("Size")
+	file := &File{ID: 1, Size: 2048}
+	if got := FormatFieldValueForDisplay(file, size); got != "2.0 KB" {
+		t.Errorf("Expected formatted size '2.0 KB', got %q", got)
+	}
+	if got := FormatFieldValueForDisplayWithResource(file, size, resource); got != "2.0 KB" {
+		t.Errorf("Expected formatted size '2.0 KB' with resource, got %q", got)
+	}
+	if file.Size != 2048 {
+		t.Errorf("Formatting must not change the stored value, got %d", file.Size)
+	}
+
+	note, _ := resource.GetField("Note")
+	if got := FormatFieldValueForDisplay(file, note); got != "" {
+		t.Errorf("Expected nil pointer to format as empty, got %q", got)
+	}
+	text := "hello"
+	file.Note = &text
+	if got := FormatFieldValueForDisplay(file, note); got != "note: hello" {
+		t.Errorf("Expected dereferenced pointer to be formatted, got %q", got)
+	}
+}
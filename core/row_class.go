@@ -0,0 +1,13 @@
+package core
+
+// RowClassFunc returns extra CSS classes for a record's list row, e.g. "bg-red-50" for expired trials
+// An empty string leaves the row as is
+type RowClassFunc func(item any) string
+
+// RowClass returns the extra classes of a record's list row
+func (r *Resource) RowClass(item any) string {
+	if r.RowClassFunc == nil || item == nil {
+		return ""
+	}
+	return r.RowClassFunc(item)
+}
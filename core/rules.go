@@ -0,0 +1,90 @@
+package core
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"unicode/utf8"
+)
+
+// HasRules reports whether the field restricts its values with Min, Max, Pattern or MaxLength
+func (f *FieldInfo) HasRules() bool {
+	return f.Min != nil || f.Max != nil || f.Pattern != "" || f.MaxLength > 0
+}
+
+// FormatRuleNumber formats a Min or Max bound for messages and input attributes, e.g. 10 rather than 1e+01
+func FormatRuleNumber(bound float64) string {
+	return strconv.FormatFloat(bound, 'f', -1, 64)
+}
+
+// validateRules checks the record's values against the Min, Max, Pattern and MaxLength rules of its fields
+// Empty text values and nil pointers are left to Required
+func (r *Resource) validateRules(item any) ValidationErrors {
+	var failures ValidationErrors
+	for i := range r.Fields {
+		field := &r.Fields[i]
+		if !field.HasRules() || field.IsComputed {
+			continue
+		}
+		val := reflect.Indirect(reflect.ValueOf(GetFieldValue(item, field.Name)))
+		if !val.IsValid() {
+			continue
+		}
+		if message := ruleViolation(field, val); message != "" {
+			failures = append(failures, NewFieldError(message, field.Name))
+		}
+	}
+	return failures
+}
+
+// ruleViolation returns why a value breaks the field's rules, or "" when it satisfies them
+func ruleViolation(field *FieldInfo, val reflect.Value) string {
+	var number float64
+	switch val.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		number = float64(val.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		number = float64(val.Uint())
+	case reflect.Float32, reflect.Float64:
+		number = val.Float()
+	case reflect.String:
+		text := val.String()
+		if text == "" {
+			return ""
+		}
+		if field.MaxLength > 0 && utf8.RuneCountInString(text) > field.MaxLength {
+			return fmt.Sprintf("must be at most %d characters", field.MaxLength)
+		}
+		if field.Pattern != "" {
+			pattern, err := regexp.Compile("^(?:" + field.Pattern + ")$")
+			if err != nil {
+				return fmt.Sprintf("invalid pattern: %v", err)
+			}
+			if !pattern.MatchString(text) {
+				return "doesn't match the required format"
+			}
+		}
+		return ""
+	default:
+		return ""
+	}
+
+	if field.Min != nil && number < *field.Min {
+		return "must be at least " + FormatRuleNumber(*field.Min)
+	}
+	if field.Max != nil && number > *field.Max {
+		return "must be at most " + FormatRuleNumber(*field.Max)
+	}
+	return ""
+}
+
+// hasFieldRules reports whether any field restricts its values with rules
+func (r *Resource) hasFieldRules() bool {
+	for i := range r.Fields {
+		if r.Fields[i].HasRules() {
+			return true
+		}
+	}
+	return false
+}
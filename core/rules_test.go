@@ -0,0 +1,77 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/preslavrachev/backoffice/middleware/auth"
+)
+
+type rulesCoupon struct {
+	ID       uint     `db:"id"`
+	Code     string   `db:"code"`
+	Note     string   `db:"note"`
+	Discount int      `db:"discount"`
+	MinOrder *float64 `db:"min_order"`
+}
+
+func TestValidate_FieldRules(t *testing.T) {
+	bo := New(&DummyAdapter{}, auth.AuthConfig{})
+	bo.RegisterResource(&rulesCoupon{}).
+		WithField("Code", func(f *FieldBuilder) { f.Pattern(`[A-Z]{3}-\d+`) }).
+		WithField("Note", func(f *FieldBuilder) { f.MaxLength(5) }).
+		WithField("Discount", func(f *FieldBuilder) { f.Min(1).Max(90) }).
+		WithField("MinOrder", func(f *FieldBuilder) { f.Min(0.5) })
+	resource, _ := bo.GetResource("rulesCoupon")
+	ctx := context.Background()
+
+	if err := resource.Validate(ctx, &rulesCoupon{Code: "ABC-12", Note: "héllo", Discount: 90}); err != nil {
+		t.Errorf("Expected values within the rules to pass, got %v", err)
+	}
+
+	tooSmall := 0.25
+	err := resource.Validate(ctx, &rulesCoupon{Code: "xABC-12", Note: "too long", Discount: 91, MinOrder: &tooSmall})
+	var failures ValidationErrors
+	if !errors.As(err, &failures) {
+		t.Fatalf("Expected validation errors, got %v", err)
+	}
+	want := map[string]string{
+		"Code":     "doesn't match the required format",
+		"Note":     "must be at most 5 characters",
+		"Discount": "must be at most 90",
+		"MinOrder": "must be at least 0.5",
+	}
+	if got := failures.FieldMessages(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+
+	// Empty text is left to Required
+	if err := resource.Validate(ctx, &rulesCoupon{Discount: 5}); err != nil {
+		t.Errorf("Expected empty text fields to pass, got %v", err)
+	}
+}
+
+func TestBackOfficeValidate_FieldRules(t *testing.T) {
+	bo := New(&DummyAdapter{}, auth.AuthConfig{})
+	bo.RegisterResource(&rulesCoupon{}).
+		WithField("Code", func(f *FieldBuilder) { f.Pattern(`[A-Z`).Min(1) }).
+		WithField("Discount", func(f *FieldBuilder) { f.Min(10).Max(5).MaxLength(3) })
+
+	err := bo.Validate()
+	if err == nil {
+		t.Fatal("Expected configuration problems")
+	}
+	for _, want := range []string{
+		"field Code: invalid pattern",
+		"field Code: min and max require a number field, got string",
+		"field Discount: min 10 is greater than max 5",
+		"field Discount: pattern and max length require a string field, got int",
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("Expected %q in %v", want, err)
+		}
+	}
+}
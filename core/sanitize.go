@@ -0,0 +1,124 @@
+package core
+
+import (
+	"html"
+	"io"
+	"slices"
+	"strings"
+
+	nethtml "golang.org/x/net/html"
+)
+
+// richTextTags are the elements rich text fields may keep, mapped to the attributes they may carry
+var richTextTags = map[string][]string{
+	"p": {"class"}, "br": nil, "strong": nil, "b": nil, "em": nil, "i": nil, "u": nil, "s": nil,
+	"h1": {"class"}, "h2": {"class"}, "h3": {"class"}, "blockquote": nil, "pre": {"class"}, "code": nil,
+	"ul": nil, "ol": nil, "li": {"class"}, "span": {"class"}, "a": {"href", "target", "rel"},
+}
+
+// richTextDroppedContent are the elements removed together with their content
+var richTextDroppedContent = map[string]bool{
+	"script": true, "style": true, "iframe": true, "object": true, "embed": true, "template": true, "noscript": true,
+}
+
+// SanitizeHTML keeps the formatting a rich text editor produces and removes everything else,
+// such as scripts, event handlers and javascript: links; text is kept and escaped
+func SanitizeHTML(input string) string {
+	var sb strings.Builder
+	tokenizer := nethtml.NewTokenizer(strings.NewReader(input))
+	dropping := ""    // The element whose content is being removed
+	var open []string // Allowed elements still open, closed at the end to keep the markup balanced
+
+	for {
+		tokenType := tokenizer.Next()
+		if tokenType == nethtml.ErrorToken {
+			if tokenizer.Err() != io.EOF {
+				return ""
+			}
+			break
+		}
+		token := tokenizer.Token()
+
+		if dropping != "" {
+			if tokenType == nethtml.EndTagToken && token.Data == dropping {
+				dropping = ""
+			}
+			continue
+		}
+
+		switch tokenType {
+		case nethtml.TextToken:
+			sb.WriteString(html.EscapeString(token.Data))
+		case nethtml.StartTagToken, nethtml.SelfClosingTagToken:
+			if richTextDroppedContent[token.Data] && tokenType == nethtml.StartTagToken {
+				dropping = token.Data
+				continue
+			}
+			allowed, ok := richTextTags[token.Data]
+			if !ok {
+				continue
+			}
+			sb.WriteString("<" + token.Data)
+			for _, attr := range token.Attr {
+				if attr.Namespace == "" && slices.Contains(allowed, attr.Key) && safeAttribute(attr.Key, attr.Val) {
+					sb.WriteString(" " + attr.Key + `="` + html.EscapeString(attr.Val) + `"`)
+				}
+			}
+			sb.WriteString(">")
+			if token.Data != "br" && tokenType == nethtml.StartTagToken {
+				open = append(open, token.Data)
+			}
+		case nethtml.EndTagToken:
+			// Close the innermost matching element, along with any left open inside it
+			for i := len(open) - 1; i >= 0; i-- {
+				if open[i] == token.Data {
+					for j := len(open) - 1; j >= i; j-- {
+						sb.WriteString("</" + open[j] + ">")
+					}
+					open = open[:i]
+					break
+				}
+			}
+		}
+	}
+
+	for i := len(open) - 1; i >= 0; i-- {
+		sb.WriteString("</" + open[i] + ">")
+	}
+	return sb.String()
+}
+
+// safeAttribute reports whether an allowed attribute's value is harmless
+func safeAttribute(key, value string) bool {
+	switch key {
+	case "href":
+		return safeURL(value)
+	case "target":
+		return value == "_blank"
+	case "class":
+		// Only the editor's own formatting classes, e.g. ql-align-center
+		for _, class := range strings.Fields(value) {
+			if !strings.HasPrefix(class, "ql-") {
+				return false
+			}
+		}
+		return true
+	}
+	return true
+}
+
+// safeURL reports whether a link points at a web page, a mail address or a relative path
+func safeURL(value string) bool {
+	value = strings.ToLower(strings.Map(func(r rune) rune {
+		if r <= ' ' {
+			return -1 // Browsers ignore whitespace and control characters inside schemes
+		}
+		return r
+	}, value))
+	colon := strings.Index(value, ":")
+	if colon < 0 || strings.ContainsAny(value[:colon], "/?#") {
+		return true
+	}
+	scheme := value[:colon]
+	return scheme == "http" || scheme == "https" || scheme == "mailto"
+}
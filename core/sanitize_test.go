@@ -0,0 +1,30 @@
+package core
+
+import "testing"
+
+func TestSanitizeHTML(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"keeps formatting", `<p class="ql-align-center">Hello <strong>bold</strong> <em>world</em></p>`, `<p class="ql-align-center">Hello <strong>bold</strong> <em>world</em></p>`},
+		{"keeps lists", `<ol><li>One</li><li>Two</li></ol>`, `<ol><li>One</li><li>Two</li></ol>`},
+		{"keeps safe links", `<a href="https://example.com" target="_blank" rel="noopener">x</a>`, `<a href="https://example.com" target="_blank" rel="noopener">x</a>`},
+		{"drops scripts with their content", `<p>Hi</p><script>alert(1)</script>`, `<p>Hi</p>`},
+		{"drops event handlers", `<p onclick="alert(1)">Hi</p>`, `<p>Hi</p>`},
+		{"drops javascript links", `<a href=" JaVaScRiPt:alert(1)">x</a>`, `<a>x</a>`},
+		{"drops foreign classes", `<p class="hidden">Hi</p>`, `<p>Hi</p>`},
+		{"unwraps unknown elements", `<div><img src=x onerror="alert(1)">Text</div>`, `Text`},
+		{"escapes text", `1 &lt; 2 &amp; <b>3 > 2</b>`, `1 &lt; 2 &amp; <b>3 &gt; 2</b>`},
+		{"closes open elements", `<p><strong>Unclosed`, `<p><strong>Unclosed</strong></p>`},
+		{"ignores stray end tags", `</p>Text</em>`, `Text`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SanitizeHTML(tt.input); got != tt.want {
+				t.Errorf("SanitizeHTML(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,88 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// ErrOutOfScope is returned when a record exists but is excluded by the resource scope
+var ErrOutOfScope = errors.New("record is outside the allowed scope")
+
+// ScopeFunc narrows a query to the records the current request may access
+// The request context carries the authenticated user (see auth.GetAuthUser)
+type ScopeFunc func(ctx context.Context, query *Query) *Query
+
+// BaseScopeFunc narrows every query for a resource, regardless of who is asking
+// Use it for business rules such as always hiding internal records
+type BaseScopeFunc func(query *Query) *Query
+
+// ApplyScope applies the base scope and then the request scope to the query, where configured
+// Both run after request filters, so URL parameters can't widen the result
+func (r *Resource) ApplyScope(ctx context.Context, query *Query) *Query {
+	if r.BaseScope != nil {
+		if scoped := r.BaseScope(query); scoped != nil {
+			query = scoped
+		}
+	}
+	if r.Scope == nil {
+		return query
+	}
+	if scoped := r.Scope(ctx, query); scoped != nil {
+		return scoped
+	}
+	return query
+}
+
+// CheckScope verifies that the record with the given ID is visible through the resource scope
+// Resources without a scope always pass
+func CheckScope(ctx context.Context, adapter Adapter, resource *Resource, id any) error {
+	return checkScope(ctx, adapter, resource, id, false)
+}
+
+// CheckScopeWithTrashed is CheckScope also passing trashed records, for routes acting on the trash
+// such as restoring and deleting permanently
+func CheckScopeWithTrashed(ctx context.Context, adapter Adapter, resource *Resource, id any) error {
+	err := checkScope(ctx, adapter, resource, id, false)
+	if !errors.Is(err, ErrOutOfScope) || resource.SoftDeleteField == "" {
+		return err
+	}
+	return checkScope(ctx, adapter, resource, id, true)
+}
+
+// checkScope looks the record up through the resource scope, among the trashed records if trashed is set
+func checkScope(ctx context.Context, adapter Adapter, resource *Resource, id any, trashed bool) error {
+	if resource.Scope == nil && resource.BaseScope == nil {
+		return nil
+	}
+
+	query := NewQuery().WithPagination(1, 0)
+	query.Trashed = trashed
+	query = resource.ApplyScope(ctx, query)
+	if query.Filters == nil {
+		query.Filters = make(map[string]any)
+	}
+	query.Filters[resource.IDField] = id
+
+	result, err := adapter.Find(ctx, resource, query)
+	if err != nil {
+		return fmt.Errorf("failed to check scope for %s %v: %w", resource.DisplayName, id, err)
+	}
+	if result == nil || result.TotalCount == 0 {
+		return ErrOutOfScope
+	}
+
+	return nil
+}
+
+// GetScopedByID fetches a record by ID, returning ErrOutOfScope if the resource scope excludes it
+func GetScopedByID(ctx context.Context, adapter Adapter, resource *Resource, id any) (any, error) {
+	item, err := adapter.GetByID(ctx, resource, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := CheckScope(ctx, adapter, resource, id); err != nil {
+		return nil, err
+	}
+	return item, nil
+}
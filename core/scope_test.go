@@ -0,0 +1,129 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/preslavrachev/backoffice/middleware/auth"
+)
+
+type Ticket struct {
+	ID     uint   `db:"id"`
+	Region string `db:"region"`
+}
+
+// scopeTestAdapter serves tickets from memory, honouring equality filters
+type scopeTestAdapter struct {
+	DummyAdapter
+	tickets []*Ticket
+}
+
+func (a *scopeTestAdapter) Find(ctx context.Context, resource *Resource, query *Query) (*Result, error) {
+	result := &Result{}
+	for _, ticket := range a.tickets {
+		if id, ok := query.Filters["ID"]; ok && id != ticket.ID {
+			continue
+		}
+		if region, ok := query.Filters["Region"]; ok && region != ticket.Region {
+			continue
+		}
+		result.Items = append(result.Items, ticket)
+	}
+	result.TotalCount = int64(len(result.Items))
+	return result, nil
+}
+
+func (a *scopeTestAdapter) GetByID(ctx context.Context, resource *Resource, id any) (any, error) {
+	for _, ticket := range a.tickets {
+		if ticket.ID == id {
+			return ticket, nil
+		}
+	}
+	return nil, errors.New("not found")
+}
+
+// regionScope limits tickets to the region stored in the user's first role
+func regionScope(ctx context.Context, query *Query) *Query {
+	if user, ok := auth.GetAuthUser(ctx); ok && len(user.Roles) > 0 {
+		query.Filters["Region"] = user.Roles[0]
+	}
+	return query
+}
+
+func TestGetScopedByID(t *testing.T) {
+	bo := &BackOffice{
+		resources:     make(map[string]*Resource),
+		resourceOrder: []string{},
+		config:        &Config{},
+	}
+	bo.RegisterResource(&Ticket{}).WithScope(regionScope)
+	resource, _ := bo.GetResource("Ticket")
+
+	adapter := &scopeTestAdapter{tickets: []*Ticket{
+		{ID: 1, Region: "emea"},
+		{ID: 2, Region: "apac"},
+	}}
+	ctx := auth.WithAuthUser(context.Background(), &auth.AuthUser{Username: "agent", Roles: []string{"emea"}})
+
+	if _, err := GetScopedByID(ctx, adapter, resource, uint(1)); err != nil {
+		t.Errorf("Expected in-scope ticket to load, got %v", err)
+	}
+	if _, err := GetScopedByID(ctx, adapter, resource, uint(2)); !errors.Is(err, ErrOutOfScope) {
+		t.Errorf("Expected ErrOutOfScope for other region, got %v", err)
+	}
+
+	query := resource.ApplyScope(ctx, NewQuery())
+	if query.Filters["Region"] != "emea" {
+		t.Errorf("Expected list query to be scoped to 'emea', got %v", query.Filters)
+	}
+}
+
+func TestCheckScope_NoScope(t *testing.T) {
+	resource := &Resource{Name: "Ticket", IDField: "ID"}
+
+	// Without a scope the adapter must not be consulted at all
+	if err := CheckScope(context.Background(), &DummyAdapter{}, resource, uint(1)); err != nil {
+		t.Errorf("Expected nil error without scope, got %v", err)
+	}
+}
+
+func TestBaseScope(t *testing.T) {
+	bo := &BackOffice{
+		resources:     make(map[string]*Resource),
+		resourceOrder: []string{},
+		config:        &Config{},
+	}
+	bo.RegisterResource(&Ticket{}).WithBaseScope(func(query *Query) *Query {
+		query.Filters["Region"] = "public"
+		return query
+	})
+	resource, _ := bo.GetResource("Ticket")
+
+	adapter := &scopeTestAdapter{tickets: []*Ticket{
+		{ID: 1, Region: "public"},
+		{ID: 2, Region: "internal"},
+	}}
+	ctx := context.Background()
+
+	// A URL filter asking for internal tickets is overridden by the base scope
+	query := resource.ApplyScope(ctx, NewQuery().WithFilters(map[string]any{"Region": "internal"}))
+	result, _ := adapter.Find(ctx, resource, query)
+	if result.TotalCount != 1 || result.Items[0].(*Ticket).ID != 1 {
+		t.Errorf("Expected only the public ticket, got %+v", result.Items)
+	}
+
+	if _, err := GetScopedByID(ctx, adapter, resource, uint(2)); !errors.Is(err, ErrOutOfScope) {
+		t.Errorf("Expected ErrOutOfScope for an internal ticket, got %v", err)
+	}
+
+	// The base scope combines with the request scope
+	resource.Scope = func(ctx context.Context, query *Query) *Query {
+		query.Filters["ID"] = uint(2)
+		return query
+	}
+	result, _ = adapter.Find(ctx, resource, resource.ApplyScope(ctx, NewQuery()))
+	if result.TotalCount != 0 {
+		t.Errorf("Expected no tickets matching both scopes, got %d", result.TotalCount)
+	}
+}
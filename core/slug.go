@@ -0,0 +1,113 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// maxSlugAttempts limits how many numeric suffixes are tried when making a slug unique
+const maxSlugAttempts = 100
+
+// Slugify converts text into a lowercase, hyphen-separated URL-friendly slug
+func Slugify(text string) string {
+	var b strings.Builder
+	pendingHyphen := false
+
+	for _, r := range strings.ToLower(text) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			if pendingHyphen && b.Len() > 0 {
+				b.WriteByte('-')
+			}
+			b.WriteRune(r)
+			pendingHyphen = false
+		} else {
+			// Collapse any run of other characters into a single separator
+			pendingHyphen = true
+		}
+	}
+
+	return b.String()
+}
+
+// GenerateSlugs fills in empty slug fields of item from their configured source fields
+// Generated slugs are checked against existing records and suffixed (-2, -3, ...) until unique.
+// Slugs that were provided explicitly are left untouched.
+func GenerateSlugs(ctx context.Context, adapter Adapter, resource *Resource, item any) error {
+	val := reflect.ValueOf(item)
+	if val.Kind() == reflect.Ptr {
+		val = val.Elem()
+	}
+	if val.Kind() != reflect.Struct {
+		return nil
+	}
+
+	for _, field := range resource.Fields {
+		if field.SlugSource == "" {
+			continue
+		}
+
+		slugVal := val.FieldByName(field.Name)
+		if !slugVal.IsValid() || !slugVal.CanSet() || slugVal.Kind() != reflect.String {
+			continue
+		}
+		if slugVal.String() != "" {
+			continue // Explicitly provided slug
+		}
+
+		source := GetFieldValue(item, field.SlugSource)
+		if source == nil {
+			continue
+		}
+
+		base := Slugify(fmt.Sprintf("%v", source))
+		if base == "" {
+			continue
+		}
+
+		slug, err := uniqueSlug(ctx, adapter, resource, field.Name, base)
+		if err != nil {
+			return err
+		}
+		slugVal.SetString(slug)
+	}
+
+	return nil
+}
+
+// uniqueSlug returns base, or base with the first free numeric suffix
+// Trashed records keep their slugs, so they are checked too: restoring one must not clash
+func uniqueSlug(ctx context.Context, adapter Adapter, resource *Resource, fieldName, base string) (string, error) {
+	candidate := base
+	for attempt := 2; attempt <= maxSlugAttempts; attempt++ {
+		taken, err := slugTaken(ctx, adapter, resource, fieldName, candidate, false)
+		if err == nil && !taken && resource.SoftDeleteField != "" {
+			taken, err = slugTaken(ctx, adapter, resource, fieldName, candidate, true)
+		}
+		if err != nil {
+			return "", fmt.Errorf("failed to check slug uniqueness for %s: %w", fieldName, err)
+		}
+		if !taken {
+			return candidate, nil
+		}
+
+		candidate = fmt.Sprintf("%s-%d", base, attempt)
+	}
+
+	return "", fmt.Errorf("could not generate a unique slug for %s from %q", fieldName, base)
+}
+
+// slugTaken reports whether a record has the slug, looking among the trashed records if trashed is set
+func slugTaken(ctx context.Context, adapter Adapter, resource *Resource, fieldName, slug string, trashed bool) (bool, error) {
+	query := NewQuery().
+		WithFilters(map[string]any{fieldName: slug}).
+		WithPagination(1, 0)
+	query.Trashed = trashed
+
+	result, err := adapter.Find(ctx, resource, query)
+	if err != nil {
+		return false, err
+	}
+	return result != nil && result.TotalCount > 0, nil
+}
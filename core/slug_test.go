@@ -0,0 +1,118 @@
+package core
+
+import (
+	"context"
+	"testing"
+)
+
+// slugTestAdapter reports a record as existing when the filtered slug is taken, by a live or a trashed record
+type slugTestAdapter struct {
+	DummyAdapter
+	taken   map[string]bool
+	trashed map[string]bool
+}
+
+func (a *slugTestAdapter) Find(ctx context.Context, resource *Resource, query *Query) (*Result, error) {
+	taken := a.taken
+	if query.Trashed {
+		taken = a.trashed
+	}
+	for _, value := range query.Filters {
+		if slug, ok := value.(string); ok && taken[slug] {
+			return &Result{TotalCount: 1}, nil
+		}
+	}
+	return &Result{TotalCount: 0}, nil
+}
+
+func TestSlugify(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"Hello World", "hello-world"},
+		{"  Leading and trailing  ", "leading-and-trailing"},
+		{"Go 1.24: What's New?", "go-1-24-what-s-new"},
+		{"already-a-slug", "already-a-slug"},
+		{"Multiple   ---   separators", "multiple-separators"},
+		{"!!!", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			if got := Slugify(tt.input); got != tt.expected {
+				t.Errorf("Slugify(%q) = %q, want %q", tt.input, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestGenerateSlugs(t *testing.T) {
+	type Article struct {
+		ID    uint   `db:"id"`
+		Title string `db:"title"`
+		Slug  string `db:"slug"`
+	}
+
+	bo := &BackOffice{
+		resources:     make(map[string]*Resource),
+		resourceOrder: []string{},
+		config:        &Config{},
+	}
+	bo.RegisterResource(&Article{}).
+		WithField("Title", func(f *FieldBuilder) {}).
+		WithField("Slug", func(f *FieldBuilder) {
+			f.AsSlug("Title")
+		})
+	resource, _ := bo.GetResource("Article")
+
+	adapter := &slugTestAdapter{
+		taken: map[string]bool{
+			"hello-world":   true,
+			"hello-world-2": true,
+		},
+		trashed: map[string]bool{"old-post": true},
+	}
+
+	t.Run("generates from source", func(t *testing.T) {
+		item := &Article{Title: "Fresh Post"}
+		if err := GenerateSlugs(context.Background(), adapter, resource, item); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if item.Slug != "fresh-post" {
+			t.Errorf("Expected slug 'fresh-post', got '%s'", item.Slug)
+		}
+	})
+
+	t.Run("suffixes taken slugs", func(t *testing.T) {
+		item := &Article{Title: "Hello, World"}
+		if err := GenerateSlugs(context.Background(), adapter, resource, item); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if item.Slug != "hello-world-3" {
+			t.Errorf("Expected slug 'hello-world-3', got '%s'", item.Slug)
+		}
+	})
+
+	t.Run("suffixes slugs of trashed records", func(t *testing.T) {
+		softDeleted := *resource
+		softDeleted.SoftDeleteField = "DeletedAt"
+		item := &Article{Title: "Old Post"}
+		if err := GenerateSlugs(context.Background(), adapter, &softDeleted, item); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if item.Slug != "old-post-2" {
+			t.Errorf("Expected slug 'old-post-2', got '%s'", item.Slug)
+		}
+	})
+
+	t.Run("keeps explicit slug", func(t *testing.T) {
+		item := &Article{Title: "Hello World", Slug: "custom"}
+		if err := GenerateSlugs(context.Background(), adapter, resource, item); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if item.Slug != "custom" {
+			t.Errorf("Expected slug 'custom', got '%s'", item.Slug)
+		}
+	})
+}
@@ -0,0 +1,32 @@
+package core
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrSoftDeleteUnsupported is returned when a resource uses soft delete but the adapter cannot perform it
+var ErrSoftDeleteUnsupported = errors.New("adapter does not support soft delete")
+
+// DeleteRecord deletes a record, moving it to the trash when the resource uses soft delete
+// Set permanent to remove a record for good regardless of the resource configuration
+func DeleteRecord(ctx context.Context, adapter Adapter, resource *Resource, id any, permanent bool) error {
+	if permanent || resource.SoftDeleteField == "" {
+		return adapter.Delete(ctx, resource, id)
+	}
+
+	softDeleter, ok := adapter.(SoftDeleter)
+	if !ok {
+		return ErrSoftDeleteUnsupported
+	}
+	return softDeleter.SoftDelete(ctx, resource, id)
+}
+
+// RestoreRecord moves a soft-deleted record out of the trash
+func RestoreRecord(ctx context.Context, adapter Adapter, resource *Resource, id any) error {
+	softDeleter, ok := adapter.(SoftDeleter)
+	if !ok || resource.SoftDeleteField == "" {
+		return ErrSoftDeleteUnsupported
+	}
+	return softDeleter.Restore(ctx, resource, id)
+}
@@ -0,0 +1,53 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+// softDeleteTestAdapter records which kind of delete was performed
+type softDeleteTestAdapter struct {
+	DummyAdapter
+	deleted     bool
+	softDeleted bool
+}
+
+func (a *softDeleteTestAdapter) Delete(ctx context.Context, resource *Resource, id any) error {
+	a.deleted = true
+	return nil
+}
+
+func (a *softDeleteTestAdapter) SoftDelete(ctx context.Context, resource *Resource, id any) error {
+	a.softDeleted = true
+	return nil
+}
+
+func (a *softDeleteTestAdapter) Restore(ctx context.Context, resource *Resource, id any) error {
+	return nil
+}
+
+func TestDeleteRecord(t *testing.T) {
+	ctx := context.Background()
+	resource := &Resource{Name: "Post", SoftDeleteField: "DeletedAt"}
+
+	adapter := &softDeleteTestAdapter{}
+	if err := DeleteRecord(ctx, adapter, resource, uint(1), false); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !adapter.softDeleted || adapter.deleted {
+		t.Error("Expected a soft delete for a resource with a soft delete field")
+	}
+
+	adapter = &softDeleteTestAdapter{}
+	if err := DeleteRecord(ctx, adapter, resource, uint(1), true); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !adapter.deleted || adapter.softDeleted {
+		t.Error("Expected a permanent delete when requested")
+	}
+
+	if err := DeleteRecord(ctx, &DummyAdapter{}, resource, uint(1), false); !errors.Is(err, ErrSoftDeleteUnsupported) {
+		t.Errorf("Expected ErrSoftDeleteUnsupported, got %v", err)
+	}
+}
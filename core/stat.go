@@ -0,0 +1,18 @@
+package core
+
+import (
+	"context"
+	"time"
+)
+
+// StatRefreshInterval is how often the stat cards above a list reload
+const StatRefreshInterval = 30 * time.Second
+
+// StatFunc computes an at-a-glance number for a resource, e.g. the count of active trials
+type StatFunc func(ctx context.Context, adapter Adapter) (string, error)
+
+// Stat is a KPI card shown above the list of a resource
+type Stat struct {
+	Title string
+	Value StatFunc
+}
@@ -0,0 +1,41 @@
+package core
+
+// Status colors understood by the UI; unknown colors render gray
+const (
+	StatusGray   = "gray"
+	StatusRed    = "red"
+	StatusYellow = "yellow"
+	StatusGreen  = "green"
+	StatusBlue   = "blue"
+	StatusIndigo = "indigo"
+	StatusPurple = "purple"
+)
+
+// StatusFunc derives a status label and pill color from a record
+type StatusFunc func(item any) (label, color string)
+
+// IsStatus reports whether the field renders as a colored status pill
+func (f *FieldInfo) IsStatus() bool {
+	return f.StatusFunc != nil || len(f.ChoiceColors) > 0
+}
+
+// Status returns the status label and color of a record
+// Fields with choice colors are labelled with their value, colored by ChoiceColors
+func (f *FieldInfo) Status(item any) (label, color string) {
+	if !f.IsStatus() || item == nil {
+		return "", StatusGray
+	}
+	if f.StatusFunc != nil {
+		label, color = f.StatusFunc(item)
+	} else {
+		value := GetFieldValue(item, f.Name)
+		label, color = displayString(value), f.ChoiceColors[displayString(value)]
+		if formatted, ok := f.FormatValue(value); ok {
+			label = formatted
+		}
+	}
+	if color == "" {
+		color = StatusGray
+	}
+	return label, color
+}
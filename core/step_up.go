@@ -0,0 +1,33 @@
+package core
+
+import "github.com/preslavrachev/backoffice/middleware/auth"
+
+// StepUpConfig makes users confirm their identity again, with their password or a TOTP code, before
+// a resource's destructive operations. Confirmations are verified on the server before the operation runs
+// Custom actions set theirs with ActionBuilder.WithStepUp
+type StepUpConfig struct {
+	PermanentDelete auth.StepUpMethod // Deleting a record for good, which is every delete without soft delete
+	BulkDelete      auth.StepUpMethod // Deleting the selected records
+}
+
+// WithStepUp requires confirming the resource's permanent and bulk deletes with a password or TOTP code
+func (rb *ResourceBuilder) WithStepUp(config StepUpConfig) *ResourceBuilder {
+	rb.resource.StepUp = config
+	return rb
+}
+
+// DeleteStepUp returns how deleting a record must be confirmed, permanent when it skips the trash
+// Resources without soft delete have no trash, so their every delete counts as permanent
+func (r *Resource) DeleteStepUp(permanent bool) auth.StepUpMethod {
+	if permanent || r.SoftDeleteField == "" {
+		return r.StepUp.PermanentDelete
+	}
+	return auth.StepUpNone
+}
+
+// WithStepUp requires confirming the action with the user's password or a TOTP code
+// Such actions are confirmed through a form per record, so they can't run in bulk
+func (ab *ActionBuilder) WithStepUp(method auth.StepUpMethod) *ActionBuilder {
+	ab.action.StepUp = method
+	return ab
+}
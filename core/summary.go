@@ -0,0 +1,47 @@
+package core
+
+import (
+	"context"
+	"errors"
+)
+
+// FieldSummary is the aggregate of a summarized field over the records of a list
+type FieldSummary struct {
+	Func  AggregateFunc
+	Value float64
+	Err   error // Set when the field's aggregate failed; the other fields are still summarized
+}
+
+// SummaryFields returns the fields summarized in the list footer, in field order
+func (r *Resource) SummaryFields() []FieldInfo {
+	var fields []FieldInfo
+	for _, field := range r.Fields {
+		if field.Summary != "" {
+			fields = append(fields, field)
+		}
+	}
+	return fields
+}
+
+// ListSummaries aggregates every summarized field of the resource over the records matching query, keyed by field name
+// It returns ErrAggregateUnsupported when the adapter can't aggregate records
+func ListSummaries(ctx context.Context, adapter Adapter, resource *Resource, query *Query) (map[string]FieldSummary, error) {
+	if _, ok := adapter.(Aggregator); !ok {
+		return nil, ErrAggregateUnsupported
+	}
+	summaries := make(map[string]FieldSummary)
+	for _, field := range resource.SummaryFields() {
+		summary := FieldSummary{Func: field.Summary}
+		points, err := Aggregate(ctx, adapter, resource, AggregateQuery{Query: query, Func: field.Summary, Field: field.Name})
+		switch {
+		case err != nil:
+			summary.Err = err
+		case len(points) != 1:
+			summary.Err = errors.New("aggregate returned no total")
+		default:
+			summary.Value = points[0].Value
+		}
+		summaries[field.Name] = summary
+	}
+	return summaries, nil
+}
@@ -0,0 +1,34 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/preslavrachev/backoffice/middleware/auth"
+)
+
+func TestListSummaries(t *testing.T) {
+	adapter := &aggregateAdapter{}
+	bo := New(adapter, auth.AuthConfig{})
+	bo.RegisterResource(&signup{}).
+		WithFields("Plan").
+		WithField("CompanyID", func(f *FieldBuilder) { f.Summarize(AggregateCount) })
+	resource, _ := bo.GetResource("signup")
+
+	query := NewQuery().WithFilters(map[string]any{"Plan": "pro"})
+	summaries, err := ListSummaries(context.Background(), adapter, resource, query)
+	if err != nil {
+		t.Fatalf("ListSummaries failed: %v", err)
+	}
+	if len(summaries) != 1 || summaries["CompanyID"].Value != 3 || summaries["CompanyID"].Func != AggregateCount {
+		t.Errorf("Expected the CompanyID count, got %+v", summaries)
+	}
+	if adapter.query.GroupBy != "" || adapter.query.Field != "CompanyID" || adapter.query.Query.Filters["Plan"] != "pro" {
+		t.Errorf("Expected an ungrouped aggregate over the filtered records, got %+v", adapter.query)
+	}
+
+	if _, err := ListSummaries(context.Background(), &DummyAdapter{}, resource, query); !errors.Is(err, ErrAggregateUnsupported) {
+		t.Errorf("Expected ErrAggregateUnsupported, got %v", err)
+	}
+}
@@ -0,0 +1,18 @@
+package core
+
+// Theme brands the admin pages without forking their templates
+// Zero values keep the default look
+type Theme struct {
+	PrimaryColor string   `json:"primary_color"` // CSS color replacing the default blue of buttons and links, e.g. "#7c3aed"
+	LogoURL      string   `json:"logo_url"`      // Shown next to the title in the header
+	FaviconURL   string   `json:"favicon_url"`
+	CSSURLs      []string `json:"css_urls"` // Stylesheets loaded after the built-in styles
+	JSURLs       []string `json:"js_urls"`  // Scripts loaded at the end of every page
+	FooterText   string   `json:"footer_text"`
+}
+
+// SetTheme brands the admin pages with the given theme
+func (bo *BackOffice) SetTheme(theme Theme) *BackOffice {
+	bo.config.Theme = theme
+	return bo
+}
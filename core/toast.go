@@ -0,0 +1,48 @@
+package core
+
+import "time"
+
+// ToastPosition is the corner of the screen toasts appear in
+type ToastPosition string
+
+const (
+	ToastTopRight    ToastPosition = "top-right"
+	ToastTopLeft     ToastPosition = "top-left"
+	ToastBottomRight ToastPosition = "bottom-right"
+	ToastBottomLeft  ToastPosition = "bottom-left"
+)
+
+const (
+	DefaultToastDuration = 4 * time.Second // How long a toast stays up when ToastConfig.Duration is unset
+	DefaultToastMaxStack = 5               // Most toasts shown at once when ToastConfig.MaxStack is unset
+)
+
+// ToastConfig controls the notifications shown after saving, deleting and running actions
+type ToastConfig struct {
+	Position        ToastPosition `json:"position"`         // Defaults to ToastTopRight
+	Duration        time.Duration `json:"duration"`         // How long a toast stays up, defaults to DefaultToastDuration
+	MaxStack        int           `json:"max_stack"`        // Most toasts shown at once; later ones wait their turn
+	AllowDuplicates bool          `json:"allow_duplicates"` // Stacks identical toasts instead of counting them on the one shown
+}
+
+// WithDefaults fills in the unset options with their defaults
+func (c ToastConfig) WithDefaults() ToastConfig {
+	switch c.Position {
+	case ToastTopRight, ToastTopLeft, ToastBottomRight, ToastBottomLeft:
+	default:
+		c.Position = ToastTopRight
+	}
+	if c.Duration <= 0 {
+		c.Duration = DefaultToastDuration
+	}
+	if c.MaxStack <= 0 {
+		c.MaxStack = DefaultToastMaxStack
+	}
+	return c
+}
+
+// SetToasts configures where toasts appear, how long they stay and how many stack up
+func (bo *BackOffice) SetToasts(config ToastConfig) *BackOffice {
+	bo.config.Toasts = config
+	return bo
+}
@@ -0,0 +1,107 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+)
+
+// MaxTreeDepth limits how deep FindTree descends, guarding against cyclic parent links
+const MaxTreeDepth = 10
+
+// TreeNode is a record in a tree list, in depth-first display order
+type TreeNode struct {
+	Item        any
+	Depth       int      // 0 for root records
+	Ancestors   []string // URL-form IDs of the parents, root first
+	HasChildren bool
+}
+
+// IsTree reports whether the resource is configured as a self-referential tree
+func (r *Resource) IsTree() bool {
+	return r.TreeParentField != ""
+}
+
+// treeRootValue returns the parent value of root records: nil for pointer fields, the zero value otherwise
+func (r *Resource) treeRootValue() any {
+	if r.ModelType == nil {
+		return nil
+	}
+	modelType := r.ModelType
+	if modelType.Kind() == reflect.Ptr {
+		modelType = modelType.Elem()
+	}
+	field, ok := modelType.FieldByName(r.TreeParentField)
+	if !ok || field.Type.Kind() == reflect.Ptr {
+		return nil
+	}
+	return reflect.Zero(field.Type).Interface()
+}
+
+// FindTreeChildren returns the direct children of parentID within the resource's scope
+// A nil parentID returns the root records
+func FindTreeChildren(ctx context.Context, adapter Adapter, resource *Resource, parentID any) ([]any, error) {
+	if !resource.IsTree() {
+		return nil, fmt.Errorf("resource %s is not configured as a tree", resource.Name)
+	}
+	if parentID == nil {
+		parentID = resource.treeRootValue()
+	}
+
+	query := NewQuery().WithPagination(MaxPageSize, 0)
+	query = resource.ApplyScope(ctx, query)
+	if query.Filters == nil {
+		query.Filters = make(map[string]any)
+	}
+	query.Filters[resource.TreeParentField] = parentID
+	query.ApplyDefaultSort(resource)
+
+	result, err := adapter.Find(ctx, resource, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load %s: %w", resource.PluralName, err)
+	}
+	if result == nil {
+		return nil, nil
+	}
+	return result.Items, nil
+}
+
+// FindTree recursively fetches the resource's hierarchy, returning nodes in depth-first order
+func FindTree(ctx context.Context, adapter Adapter, resource *Resource) ([]TreeNode, error) {
+	var nodes []TreeNode
+	visited := make(map[string]bool)
+
+	var walk func(parentID any, ancestors []string) error
+	walk = func(parentID any, ancestors []string) error {
+		items, err := FindTreeChildren(ctx, adapter, resource, parentID)
+		if err != nil {
+			return err
+		}
+		for _, item := range items {
+			id := resource.RecordID(item)
+			if visited[id] {
+				continue
+			}
+			visited[id] = true
+
+			nodes = append(nodes, TreeNode{Item: item, Depth: len(ancestors), Ancestors: ancestors})
+			index := len(nodes) - 1
+			if len(ancestors)+1 >= MaxTreeDepth {
+				continue
+			}
+
+			before := len(nodes)
+			childAncestors := append(append([]string(nil), ancestors...), id)
+			if err := walk(GetFieldValue(item, resource.IDField), childAncestors); err != nil {
+				return err
+			}
+			nodes[index].HasChildren = len(nodes) > before
+		}
+		return nil
+	}
+
+	if err := walk(nil, nil); err != nil {
+		return nil, err
+	}
+	return nodes, nil
+}
@@ -0,0 +1,113 @@
+package core
+
+import (
+	"context"
+	"testing"
+
+	"github.com/preslavrachev/backoffice/middleware/auth"
+)
+
+type Category struct {
+	ID       uint   `db:"id"`
+	Name     string `db:"name"`
+	ParentID *uint  `db:"parent_id"`
+}
+
+type treeTestAdapter struct {
+	DummyAdapter
+	categories []*Category
+}
+
+func (a *treeTestAdapter) Find(ctx context.Context, resource *Resource, query *Query) (*Result, error) {
+	var items []any
+	for _, category := range a.categories {
+		parentID, ok := query.Filters["ParentID"]
+		if !ok {
+			continue
+		}
+		if parentID == nil && category.ParentID != nil {
+			continue
+		}
+		if parentID != nil && (category.ParentID == nil || *category.ParentID != parentID.(uint)) {
+			continue
+		}
+		items = append(items, category)
+	}
+	return &Result{Items: items, TotalCount: int64(len(items))}, nil
+}
+
+func TestFindTree(t *testing.T) {
+	one, two := uint(1), uint(2)
+	adapter := &treeTestAdapter{categories: []*Category{
+		{ID: 1, Name: "Electronics"},
+		{ID: 2, Name: "Phones", ParentID: &one},
+		{ID: 3, Name: "Android", ParentID: &two},
+		{ID: 4, Name: "Laptops", ParentID: &one},
+		{ID: 5, Name: "Books"},
+	}}
+
+	bo := New(adapter, auth.AuthConfig{})
+	bo.RegisterResource(&Category{}).WithTree("ParentID")
+	resource, _ := bo.GetResource("Category")
+
+	nodes, err := FindTree(context.Background(), adapter, resource)
+	if err != nil {
+		t.Fatalf("FindTree failed: %v", err)
+	}
+
+	expected := []struct {
+		name        string
+		depth       int
+		hasChildren bool
+	}{
+		{"Electronics", 0, true},
+		{"Phones", 1, true},
+		{"Android", 2, false},
+		{"Laptops", 1, false},
+		{"Books", 0, false},
+	}
+	if len(nodes) != len(expected) {
+		t.Fatalf("Expected %d nodes, got %d", len(expected), len(nodes))
+	}
+	for i, want := range expected {
+		node := nodes[i]
+		if name := node.Item.(*Category).Name; name != want.name || node.Depth != want.depth || node.HasChildren != want.hasChildren {
+			t.Errorf("Node %d: expected %s depth %d children %v, got %s depth %d children %v",
+				i, want.name, want.depth, want.hasChildren, name, node.Depth, node.HasChildren)
+		}
+	}
+	if ancestors := nodes[2].Ancestors; len(ancestors) != 2 || ancestors[0] != "1" || ancestors[1] != "2" {
+		t.Errorf("Expected Android ancestors [1 2], got %v", ancestors)
+	}
+}
+
+func TestFindTree_CycleIsBounded(t *testing.T) {
+	one, two := uint(1), uint(2)
+	adapter := &treeTestAdapter{categories: []*Category{
+		{ID: 1, Name: "Root"},
+		{ID: 2, Name: "A", ParentID: &one},
+		{ID: 1, Name: "Root again", ParentID: &two},
+	}}
+
+	bo := New(adapter, auth.AuthConfig{})
+	bo.RegisterResource(&Category{}).WithTree("ParentID")
+	resource, _ := bo.GetResource("Category")
+
+	nodes, err := FindTree(context.Background(), adapter, resource)
+	if err != nil {
+		t.Fatalf("FindTree failed: %v", err)
+	}
+	if len(nodes) != 2 {
+		t.Errorf("Expected repeated records to be skipped, got %d nodes", len(nodes))
+	}
+}
+
+func TestFindTreeChildren_NotATree(t *testing.T) {
+	bo := New(&DummyAdapter{}, auth.AuthConfig{})
+	bo.RegisterResource(&Category{})
+	resource, _ := bo.GetResource("Category")
+
+	if _, err := FindTreeChildren(context.Background(), &DummyAdapter{}, resource, nil); err == nil {
+		t.Error("Expected an error for a resource without a tree parent field")
+	}
+}
@@ -0,0 +1,113 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// ValidatorFunc validates a whole record, for rules spanning several fields
+// Return a FieldError to attach the message to the offending fields in the form
+type ValidatorFunc func(ctx context.Context, item any) error
+
+// FieldError is a validation error attached to one or more fields
+type FieldError struct {
+	Fields  []string
+	Message string
+}
+
+// NewFieldError creates a validation error for the given fields
+// e.g. NewFieldError("must be after the creation date", "TrialEndDate", "CreatedAt")
+func NewFieldError(message string, fields ...string) *FieldError {
+	return &FieldError{Fields: fields, Message: message}
+}
+
+func (e *FieldError) Error() string {
+	if len(e.Fields) == 0 {
+		return e.Message
+	}
+	return fmt.Sprintf("%s: %s", strings.Join(e.Fields, ", "), e.Message)
+}
+
+// ValidationErrors collects every failed validator of a record
+type ValidationErrors []*FieldError
+
+func (e ValidationErrors) Error() string {
+	messages := make([]string, len(e))
+	for i, err := range e {
+		messages[i] = err.Error()
+	}
+	return strings.Join(messages, "; ")
+}
+
+// FieldMessages maps each field to its validation messages, joined with "; "
+func (e ValidationErrors) FieldMessages() map[string]string {
+	messages := make(map[string]string)
+	for _, err := range e {
+		for _, field := range err.Fields {
+			if existing, ok := messages[field]; ok {
+				messages[field] = existing + "; " + err.Message
+			} else {
+				messages[field] = err.Message
+			}
+		}
+	}
+	return messages
+}
+
+// Validate checks the record's choice, JSON and rule-restricted fields and runs the resource's validators,
+// returning ValidationErrors on failure
+func (r *Resource) Validate(ctx context.Context, item any) error {
+	failures := append(r.validateChoices(ctx, item), r.validateCode(item)...)
+	failures = append(failures, r.validateRules(item)...)
+	for _, validate := range r.Validators {
+		err := validate(ctx, item)
+		if err == nil {
+			continue
+		}
+		var fieldErr *FieldError
+		if errors.As(err, &fieldErr) {
+			failures = append(failures, fieldErr)
+		} else {
+			failures = append(failures, &FieldError{Message: err.Error()})
+		}
+	}
+	if len(failures) > 0 {
+		return failures
+	}
+	return nil
+}
+
+// ValidateUpdate validates an update against the stored record, overlaying the non-zero submitted
+// fields the same way adapters apply partial updates
+func ValidateUpdate(ctx context.Context, adapter Adapter, resource *Resource, id any, update any) error {
+	if len(resource.Validators) == 0 && !resource.hasChoiceFields() && !resource.hasFieldRules() {
+		return nil
+	}
+	existing, err := adapter.GetByID(ctx, resource, id)
+	if err != nil {
+		return err
+	}
+	return resource.Validate(ctx, mergeNonZero(existing, update))
+}
+
+// mergeNonZero returns a copy of base with the non-zero fields of overlay applied
+func mergeNonZero(base, overlay any) any {
+	baseVal := reflect.ValueOf(base)
+	overlayVal := reflect.ValueOf(overlay)
+	if baseVal.Kind() != reflect.Ptr || overlayVal.Kind() != reflect.Ptr || baseVal.Type() != overlayVal.Type() {
+		return overlay
+	}
+
+	merged := reflect.New(baseVal.Elem().Type())
+	merged.Elem().Set(baseVal.Elem())
+	for i := 0; i < overlayVal.Elem().NumField(); i++ {
+		field := overlayVal.Elem().Field(i)
+		if merged.Elem().Field(i).CanSet() && !field.IsZero() {
+			merged.Elem().Field(i).Set(field)
+		}
+	}
+	return merged.Interface()
+}
@@ -0,0 +1,82 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+type validatedTrial struct {
+	ID       uint
+	Name     string
+	StartDay int
+	EndDay   int
+}
+
+// validationTestAdapter returns a single stored trial
+type validationTestAdapter struct {
+	DummyAdapter
+	stored *validatedTrial
+}
+
+func (a *validationTestAdapter) GetByID(ctx context.Context, resource *Resource, id any) (any, error) {
+	return a.stored, nil
+}
+
+func endAfterStart(ctx context.Context, item any) error {
+	trial := item.(*validatedTrial)
+	if trial.EndDay <= trial.StartDay {
+		return NewFieldError("must be after the start day", "StartDay", "EndDay")
+	}
+	return nil
+}
+
+func TestResourceValidate(t *testing.T) {
+	resource := &Resource{Validators: []ValidatorFunc{
+		endAfterStart,
+		func(ctx context.Context, item any) error {
+			if item.(*validatedTrial).Name == "" {
+				return errors.New("a name is required")
+			}
+			return nil
+		},
+	}}
+
+	if err := resource.Validate(context.Background(), &validatedTrial{Name: "ok", StartDay: 1, EndDay: 2}); err != nil {
+		t.Fatalf("Expected a valid record, got %v", err)
+	}
+
+	err := resource.Validate(context.Background(), &validatedTrial{StartDay: 3, EndDay: 2})
+	var failures ValidationErrors
+	if !errors.As(err, &failures) {
+		t.Fatalf("Expected ValidationErrors, got %v", err)
+	}
+	if len(failures) != 2 {
+		t.Fatalf("Expected both validators to fail, got %d", len(failures))
+	}
+
+	messages := failures.FieldMessages()
+	if messages["StartDay"] != "must be after the start day" || messages["EndDay"] != "must be after the start day" {
+		t.Errorf("Expected the message on both fields, got %v", messages)
+	}
+	if len(messages) != 2 {
+		t.Errorf("Expected plain errors not to map to a field, got %v", messages)
+	}
+}
+
+func TestValidateUpdate_MergesStoredRecord(t *testing.T) {
+	resource := &Resource{Validators: []ValidatorFunc{endAfterStart}}
+	stored := &validatedTrial{ID: 1, Name: "trial", StartDay: 5, EndDay: 10}
+	adapter := &validationTestAdapter{stored: stored}
+
+	// Only EndDay is submitted; StartDay comes from the stored record
+	if err := ValidateUpdate(context.Background(), adapter, resource, uint(1), &validatedTrial{EndDay: 4}); err == nil {
+		t.Error("Expected the update to fail against the stored start day")
+	}
+	if err := ValidateUpdate(context.Background(), adapter, resource, uint(1), &validatedTrial{EndDay: 12}); err != nil {
+		t.Errorf("Expected a valid update, got %v", err)
+	}
+	if stored.EndDay != 10 {
+		t.Error("Expected validation not to modify the stored record")
+	}
+}
@@ -0,0 +1,100 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// SavedView is a named combination of list filters, search and sort
+type SavedView struct {
+	ID        int64     `json:"id"`
+	Resource  string    `json:"resource"`
+	Name      string    `json:"name"`
+	Query     string    `json:"query"`      // Encoded list query string, e.g. "Status=trial&sort=EndsAt"
+	CreatedBy string    `json:"created_by"` // Username of the user who saved the view
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// ViewStore persists saved list views
+// Adapters can implement it to keep views next to the data; see BackOffice.ViewStore
+type ViewStore interface {
+	// SaveView stores a view and assigns its ID
+	SaveView(ctx context.Context, view *SavedView) error
+	// ListViews returns the views of a resource ordered by name
+	ListViews(ctx context.Context, resource string) ([]SavedView, error)
+	// GetView returns a single view of a resource
+	GetView(ctx context.Context, resource string, id int64) (*SavedView, error)
+	// DeleteView removes a view of a resource
+	DeleteView(ctx context.Context, resource string, id int64) error
+}
+
+// MemoryViewStore keeps saved views in memory
+// Views are lost on restart, so use a persistent store in production
+type MemoryViewStore struct {
+	views  []SavedView
+	nextID int64
+	mu     sync.RWMutex
+}
+
+// NewMemoryViewStore creates a new in-memory view store
+func NewMemoryViewStore() *MemoryViewStore {
+	return &MemoryViewStore{nextID: 1}
+}
+
+// SaveView stores a view and assigns its ID
+func (s *MemoryViewStore) SaveView(ctx context.Context, view *SavedView) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	view.ID = s.nextID
+	s.nextID++
+	s.views = append(s.views, *view)
+	return nil
+}
+
+// ListViews returns the views of a resource ordered by name
+func (s *MemoryViewStore) ListViews(ctx context.Context, resource string) ([]SavedView, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var views []SavedView
+	for _, view := range s.views {
+		if view.Resource == resource {
+			views = append(views, view)
+		}
+	}
+	sort.SliceStable(views, func(i, j int) bool {
+		return views[i].Name < views[j].Name
+	})
+	return views, nil
+}
+
+// GetView returns a single view of a resource
+func (s *MemoryViewStore) GetView(ctx context.Context, resource string, id int64) (*SavedView, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, view := range s.views {
+		if view.ID == id && view.Resource == resource {
+			return &view, nil
+		}
+	}
+	return nil, fmt.Errorf("view %d of %s not found", id, resource)
+}
+
+// DeleteView removes a view of a resource
+func (s *MemoryViewStore) DeleteView(ctx context.Context, resource string, id int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i, view := range s.views {
+		if view.ID == id && view.Resource == resource {
+			s.views = append(s.views[:i], s.views[i+1:]...)
+			return nil
+		}
+	}
+	return fmt.Errorf("view %d of %s not found", id, resource)
+}
@@ -0,0 +1,61 @@
+package core
+
+import (
+	"context"
+	"testing"
+
+	"github.com/preslavrachev/backoffice/middleware/auth"
+)
+
+// viewStoreAdapter is an adapter that keeps saved views itself
+type viewStoreAdapter struct {
+	DummyAdapter
+	*MemoryViewStore
+}
+
+func TestMemoryViewStore(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryViewStore()
+
+	for _, view := range []*SavedView{
+		{Resource: "Subscription", Name: "Trials ending this week", Query: "Status=trial"},
+		{Resource: "Subscription", Name: "Active", Query: "Status=active"},
+		{Resource: "Invoice", Name: "Unpaid", Query: "Paid=false"},
+	} {
+		if err := store.SaveView(ctx, view); err != nil {
+			t.Fatalf("SaveView failed: %v", err)
+		}
+	}
+
+	views, _ := store.ListViews(ctx, "Subscription")
+	if len(views) != 2 || views[0].Name != "Active" || views[1].Name != "Trials ending this week" {
+		t.Fatalf("Expected the resource's views ordered by name, got %+v", views)
+	}
+
+	if _, err := store.GetView(ctx, "Invoice", views[0].ID); err == nil {
+		t.Error("Expected views to be looked up within their resource")
+	}
+	if err := store.DeleteView(ctx, "Subscription", views[0].ID); err != nil {
+		t.Fatalf("DeleteView failed: %v", err)
+	}
+	if _, err := store.GetView(ctx, "Subscription", views[0].ID); err == nil {
+		t.Error("Expected the deleted view to be gone")
+	}
+}
+
+func TestBackOffice_ViewStore(t *testing.T) {
+	if store := New(&DummyAdapter{}, auth.AuthConfig{}).ViewStore(); store != nil {
+		t.Errorf("Expected no view store for a plain adapter, got %T", store)
+	}
+
+	adapter := &viewStoreAdapter{MemoryViewStore: NewMemoryViewStore()}
+	bo := New(adapter, auth.AuthConfig{})
+	if bo.ViewStore() != adapter {
+		t.Error("Expected the adapter to be used as the view store")
+	}
+
+	store := NewMemoryViewStore()
+	if bo.SetViewStore(store).ViewStore() != store {
+		t.Error("Expected SetViewStore to override the adapter")
+	}
+}
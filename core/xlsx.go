@@ -0,0 +1,173 @@
+package core
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// XLSX cell styles, indexes into the cellXfs of xlsxStyles
+const (
+	xlsxStyleDefault = 0
+	xlsxStyleHeader  = 1
+	xlsxStyleDate    = 2
+)
+
+// xlsxStyles styles the header row bold on a gray fill and shows dates as yyyy-mm-dd hh:mm:ss
+const xlsxStyles = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<styleSheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">
+<numFmts count="1"><numFmt numFmtId="164" formatCode="yyyy-mm-dd hh:mm:ss"/></numFmts>
+<fonts count="2"><font><sz val="11"/><name val="Calibri"/></font><font><b/><sz val="11"/><name val="Calibri"/></font></fonts>
+<fills count="3"><fill><patternFill patternType="none"/></fill><fill><patternFill patternType="gray125"/></fill><fill><patternFill patternType="solid"><fgColor rgb="FFE5E7EB"/><bgColor indexed="64"/></patternFill></fill></fills>
+<borders count="1"><border><left/><right/><top/><bottom/><diagonal/></border></borders>
+<cellStyleXfs count="1"><xf numFmtId="0" fontId="0" fillId="0" borderId="0"/></cellStyleXfs>
+<cellXfs count="3"><xf numFmtId="0" fontId="0" fillId="0" borderId="0" xfId="0"/><xf numFmtId="0" fontId="1" fillId="2" borderId="0" xfId="0" applyFont="1" applyFill="1"/><xf numFmtId="164" fontId="0" fillId="0" borderId="0" xfId="0" applyNumberFormat="1"/></cellXfs>
+</styleSheet>`
+
+// writeXLSXExport writes the items as a single-sheet Excel workbook
+// Numbers, booleans and dates keep their type so spreadsheets can calculate with them
+func writeXLSXExport(ctx context.Context, w io.Writer, resource *Resource, fields []FieldInfo, items []any) error {
+	var sheet strings.Builder
+	sheet.WriteString(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>` +
+		`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">` +
+		`<sheetViews><sheetView workbookViewId="0"><pane ySplit="1" topLeftCell="A2" activePane="bottomLeft" state="frozen"/></sheetView></sheetViews>` +
+		`<sheetData>`)
+
+	sheet.WriteString(`<row r="1">`)
+	for i, field := range fields {
+		writeXLSXString(&sheet, xlsxCellRef(i, 1), field.DisplayName, xlsxStyleHeader)
+	}
+	sheet.WriteString(`</row>`)
+
+	for n, item := range items {
+		row := n + 2
+		fmt.Fprintf(&sheet, `<row r="%d">`, row)
+		for i := range fields {
+			writeXLSXCell(&sheet, xlsxCellRef(i, row), fields[i], exportValue(ctx, item, &fields[i], resource))
+		}
+		sheet.WriteString(`</row>`)
+	}
+	sheet.WriteString(`</sheetData></worksheet>`)
+
+	var sheetName strings.Builder
+	xml.EscapeText(&sheetName, []byte(xlsxSheetName(resource.PluralName)))
+
+	files := []struct{ name, content string }{
+		{"[Content_Types].xml", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>` +
+			`<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+			`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+			`<Default Extension="xml" ContentType="application/xml"/>` +
+			`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>` +
+			`<Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>` +
+			`<Override PartName="/xl/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.styles+xml"/>` +
+			`</Types>`},
+		{"_rels/.rels", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>` +
+			`<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+			`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+			`</Relationships>`},
+		{"xl/workbook.xml", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>` +
+			`<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` +
+			`<sheets><sheet name="` + sheetName.String() + `" sheetId="1" r:id="rId1"/></sheets>` +
+			`</workbook>`},
+		{"xl/_rels/workbook.xml.rels", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>` +
+			`<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+			`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>` +
+			`<Relationship Id="rId2" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.xml"/>` +
+			`</Relationships>`},
+		{"xl/styles.xml", xlsxStyles},
+		{"xl/worksheets/sheet1.xml", sheet.String()},
+	}
+
+	archive := zip.NewWriter(w)
+	for _, file := range files {
+		entry, err := archive.Create(file.name)
+		if err != nil {
+			return err
+		}
+		if _, err := io.WriteString(entry, file.content); err != nil {
+			return err
+		}
+	}
+	return archive.Close()
+}
+
+// writeXLSXCell writes an exported value as a typed cell, leaving nil values and zero times empty
+func writeXLSXCell(sheet *strings.Builder, ref string, field FieldInfo, value any) {
+	switch v := value.(type) {
+	case nil:
+		return
+	case time.Time:
+		if !v.IsZero() {
+			fmt.Fprintf(sheet, `<c r="%s" s="%d"><v>%s</v></c>`, ref, xlsxStyleDate, strconv.FormatFloat(xlsxSerialDate(v), 'f', -1, 64))
+		}
+		return
+	case bool:
+		cell := "0"
+		if v {
+			cell = "1"
+		}
+		fmt.Fprintf(sheet, `<c r="%s" t="b"><v>%s</v></c>`, ref, cell)
+		return
+	}
+
+	switch val := reflect.ValueOf(value); val.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		fmt.Fprintf(sheet, `<c r="%s"><v>%d</v></c>`, ref, val.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		fmt.Fprintf(sheet, `<c r="%s"><v>%d</v></c>`, ref, val.Uint())
+	case reflect.Float32, reflect.Float64:
+		fmt.Fprintf(sheet, `<c r="%s"><v>%s</v></c>`, ref, strconv.FormatFloat(val.Float(), 'f', -1, 64))
+	default:
+		writeXLSXString(sheet, ref, exportString(field, value), xlsxStyleDefault)
+	}
+}
+
+// writeXLSXString writes a text cell with an inline string
+func writeXLSXString(sheet *strings.Builder, ref, text string, style int) {
+	fmt.Fprintf(sheet, `<c r="%s" t="inlineStr"`, ref)
+	if style != xlsxStyleDefault {
+		fmt.Fprintf(sheet, ` s="%d"`, style)
+	}
+	sheet.WriteString(`><is><t xml:space="preserve">`)
+	xml.EscapeText(sheet, []byte(text))
+	sheet.WriteString(`</t></is></c>`)
+}
+
+// xlsxCellRef returns the A1 reference of a zero-based column and one-based row, e.g. (27, 3) -> "AB3"
+func xlsxCellRef(column, row int) string {
+	name := ""
+	for column++; column > 0; column = (column - 1) / 26 {
+		name = string(rune('A'+(column-1)%26)) + name
+	}
+	return name + strconv.Itoa(row)
+}
+
+// xlsxSerialDate converts a time to an Excel serial date, keeping its wall clock
+func xlsxSerialDate(t time.Time) float64 {
+	wall := time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), time.UTC)
+	epoch := time.Date(1899, time.December, 30, 0, 0, 0, 0, time.UTC)
+	return wall.Sub(epoch).Hours() / 24
+}
+
+// xlsxSheetName makes a name valid for a worksheet: at most 31 characters, none of []:*?/\
+func xlsxSheetName(name string) string {
+	name = strings.Map(func(r rune) rune {
+		if strings.ContainsRune(`[]:*?/\`, r) {
+			return -1
+		}
+		return r
+	}, name)
+	if runes := []rune(name); len(runes) > 31 {
+		name = string(runes[:31])
+	}
+	if name == "" {
+		return "Sheet1"
+	}
+	return name
+}
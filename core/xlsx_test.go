@@ -0,0 +1,70 @@
+package core
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/preslavrachev/backoffice/middleware/auth"
+)
+
+type xlsxShipment struct {
+	ID        uint      `db:"id"`
+	Reference string    `db:"reference"`
+	Weight    float64   `db:"weight"`
+	Fragile   bool      `db:"fragile"`
+	ShippedAt time.Time `db:"shipped_at"`
+}
+
+func TestWriteExport_XLSX(t *testing.T) {
+	bo := New(&DummyAdapter{}, auth.AuthConfig{})
+	bo.RegisterResource(&xlsxShipment{}).
+		WithFields("Reference", "Weight", "Fragile", "ShippedAt").
+		WithExport(ExportConfig{})
+	resource, _ := bo.GetResource("xlsxShipment")
+	items := []any{&xlsxShipment{ID: 7, Reference: "A&B <1>", Weight: 2.5, Fragile: true, ShippedAt: time.Date(2026, 1, 2, 12, 0, 0, 0, time.UTC)}}
+
+	var buf bytes.Buffer
+	if err := WriteExport(context.Background(), &buf, resource, items, ExportXLSX); err != nil {
+		t.Fatalf("WriteExport failed: %v", err)
+	}
+
+	archive, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatalf("Expected a zip archive: %v", err)
+	}
+	var sheet string
+	for _, file := range archive.File {
+		if file.Name == "xl/worksheets/sheet1.xml" {
+			reader, _ := file.Open()
+			data, _ := io.ReadAll(reader)
+			sheet = string(data)
+		}
+	}
+
+	for _, want := range []string{
+		`<c r="A1" t="inlineStr" s="1"><is><t xml:space="preserve">ID</t></is></c>`,
+		`<c r="A2"><v>7</v></c>`,
+		`<t xml:space="preserve">A&amp;B &lt;1&gt;</t>`,
+		`<c r="C2"><v>2.5</v></c>`,
+		`<c r="D2" t="b"><v>1</v></c>`,
+		`<c r="E2" s="2"><v>46024.5</v></c>`,
+	} {
+		if !strings.Contains(sheet, want) {
+			t.Errorf("Expected the sheet to contain %s, got:\n%s", want, sheet)
+		}
+	}
+}
+
+func TestXLSXCellRef(t *testing.T) {
+	tests := map[[2]int]string{{0, 1}: "A1", {25, 2}: "Z2", {26, 3}: "AA3", {27, 3}: "AB3", {701, 1}: "ZZ1", {702, 1}: "AAA1"}
+	for in, want := range tests {
+		if got := xlsxCellRef(in[0], in[1]); got != want {
+			t.Errorf("xlsxCellRef(%d, %d) = %s, want %s", in[0], in[1], got, want)
+		}
+	}
+}
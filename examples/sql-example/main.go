@@ -50,11 +50,18 @@ type Product struct {
 	Description string    `json:"description" db:"description"`
 	Details     string    `json:"details" db:"details"`
 	Price       float64   `json:"price" db:"price"`
+	ImageURL    string    `json:"image_url" db:"image_url"`
 	CategoryID  uint      `json:"category_id" db:"category_id"`
 	Category    *Category `json:"category,omitempty" db:"-"`
+	Tags        []*Tag    `json:"tags,omitempty" db:"-"`
 	CreatedAt   time.Time `json:"created_at" db:"created_at"`
 }
 
+type Tag struct {
+	ID   uint   `json:"id" db:"id"`
+	Name string `json:"name" db:"name"`
+}
+
 type Category struct {
 	ID        uint      `json:"id" db:"id"`
 	Name      string    `json:"name" db:"name"`
@@ -67,7 +74,7 @@ type Category struct {
 func main() {
 	// Add flags
 	debug := flag.Bool("debug", false, "Enable SQL debug logging")
-	authMode := flag.String("auth", "none", "Authentication mode: none, basic")
+	authMode := flag.String("auth", "none", "Authentication mode: none, basic, users")
 	flag.Parse()
 
 	// Set DEBUG environment variable if -debug flag is used
@@ -142,10 +149,24 @@ func createSchema(db *sqlx.DB) error {
 		description TEXT,
 		details TEXT,
 		price REAL NOT NULL,
+		image_url TEXT NOT NULL DEFAULT '',
 		category_id INTEGER NOT NULL,
 		created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
 		FOREIGN KEY (category_id) REFERENCES categories(id)
 	);
+
+	CREATE TABLE IF NOT EXISTS tags (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		name TEXT NOT NULL
+	);
+
+	CREATE TABLE IF NOT EXISTS product_tags (
+		product_id INTEGER NOT NULL,
+		tag_id INTEGER NOT NULL,
+		PRIMARY KEY (product_id, tag_id),
+		FOREIGN KEY (product_id) REFERENCES products(id),
+		FOREIGN KEY (tag_id) REFERENCES tags(id)
+	);
 	`
 
 	_, err := db.Exec(schema)
@@ -163,6 +184,13 @@ func setupAdmin(db *sqlx.DB, authMode string, cfg *config.Config) {
 		authConfig = auth.WithBasicAuthFromConfig()
 		fmt.Println("🔐 Basic Authentication enabled")
 		fmt.Println("   👤 Credentials loaded from environment/config")
+	case "users":
+		seedUsers(sqlAdapter)
+		authConfig = auth.WithUserStore(sqlAdapter)
+		authConfig.RememberMe.Store = auth.NewMemoryRememberTokenStore()
+		authConfig.Impersonation.Roles = []auth.Role{"admin"}
+		fmt.Println("🔐 Authentication against the backoffice_users table enabled")
+		fmt.Println("   👤 admin / admin123 and editor / editor123")
 	case "none":
 	default:
 		authConfig = auth.WithNoAuth()
@@ -170,6 +198,16 @@ func setupAdmin(db *sqlx.DB, authMode string, cfg *config.Config) {
 	}
 
 	admin := core.New(sqlAdapter, authConfig)
+	admin.SetCSP(core.CSPConfig{Enabled: true})
+	if authMode == "users" {
+		// Admins manage everything and may reveal employee emails, editors the catalog only and never the admin users
+		admin.SetPolicy(core.NewRolePolicy().
+			Allow("admin", core.AnyResource).
+			Allow("admin", "User", core.OperationUnmask).
+			Allow("editor", "Product", core.OperationList, core.OperationCreate, core.OperationUpdate).
+			Allow("editor", "Category", core.OperationList).
+			Allow("editor", "Tag", core.OperationList))
+	}
 
 	// Register Department with basic setup
 	admin.RegisterResource(&Department{}).
@@ -186,12 +224,16 @@ func setupAdmin(db *sqlx.DB, authMode string, cfg *config.Config) {
 	// Register User with compact relationship display (default) and CreatedAt DESC sorting
 	admin.RegisterResource(&User{}).
 		WithName("Employee").
-		WithDefaultSort("CreatedAt", core.SortDesc).
+		WithDefaultSort(core.Sort("CreatedAt", core.SortDesc)).
 		WithField("Name", func(f *core.FieldBuilder) {
 			f.DisplayName("Full Name").Required(true).Searchable(true)
 		}).
 		WithField("Email", func(f *core.FieldBuilder) {
-			f.DisplayName("Email Address").Required(true).Unique(true)
+			f.DisplayName("Email Address").Required(true).Unique(true).MaskFor() // Shown as "j***@e***.com" until revealed
+		}).
+		WithField("Status", func(f *core.FieldBuilder) {
+			f.Choices([]string{"active", "suspended"}).
+				ChoiceColors(map[string]string{"active": core.StatusGreen, "suspended": core.StatusRed}) // Colored badges in lists, detail pages and filters
 		}).
 		WithDerivedField("AccountAge", "Account Age", func(user any) string {
 			u := user.(*User)
@@ -231,8 +273,15 @@ func setupAdmin(db *sqlx.DB, authMode string, cfg *config.Config) {
 
 			return "Unknown"
 		}).
+		WithRowClass(func(user any) string {
+			// Tint the rows of users whose trial ended without converting
+			if u := user.(*User); u.Status != "active" && u.TrialEndDate.Valid && u.TrialEndDate.Time.Before(time.Now()) {
+				return "bg-red-50"
+			}
+			return ""
+		}).
 		WithManyToOneField("Department", "Department", func(r *core.RelationshipBuilder) {
-			r.DisplayField("Name").CompactDisplay() // Compact display in lists
+			r.DisplayField("Name").ForeignKey("DepartmentID").CompactDisplay() // Compact display in lists, searchable picker in forms
 		}).
 		WithAction("activate", "Activate User", func(ctx context.Context, id any) error {
 			_, err := db.DB.ExecContext(ctx, "UPDATE users SET active = 1 WHERE id = ?", id)
@@ -263,23 +312,45 @@ func setupAdmin(db *sqlx.DB, authMode string, cfg *config.Config) {
 
 	// Register Product with badge relationship display and Price DESC sorting
 	admin.RegisterResource(&Product{}).
-		WithDefaultSort("Price", core.SortDesc).
+		WithDefaultSort(core.Sort("Price", core.SortDesc)).
 		WithField("Name", func(f *core.FieldBuilder) {
 			f.DisplayName("Product Name").Required(true).Searchable(true)
 		}).
 		WithField("Details", func(f *core.FieldBuilder) {
-			f.DisplayName("Product Details").RenderAsHTML().MaxPreviewLength(150)
+			f.DisplayName("Product Details").RenderAsRichText().MaxPreviewLength(150)
 		}).
 		WithField("Price", func(f *core.FieldBuilder) {
-			f.DisplayName("Price ($)").Required(true)
+			// Min is checked on save and by the browser; the help text shows under the input and as a list header tooltip
+			// The list footer shows the average price of the filtered products
+			f.DisplayName("Price ($)").Required(true).Min(0).
+				HelpText("Shown to customers on invoices").Placeholder("0.00").
+				Summarize(core.AggregateAvg)
+		}).
+		WithField("ImageURL", func(f *core.FieldBuilder) {
+			f.DisplayName("Image").RenderAsImage() // Thumbnail in lists, enlargeable preview on detail pages
+		}).
+		WithField("CreatedAt", func(f *core.FieldBuilder) {
+			f.DisplayName("Added").ReadOnly(true).AsRelativeTime() // "3 hours ago", the exact time shows on hover
 		}).
 		WithManyToOneField("Category", "Category", func(r *core.RelationshipBuilder) {
-			r.DisplayField("Name").BadgeDisplay() // Badge display in lists
+			r.DisplayField("Name").ForeignKey("CategoryID").BadgeDisplay() // Badge display in lists, searchable picker in forms
+		}).
+		WithManyToManyField("Tags", "Tag", func(r *core.RelationshipBuilder) {
+			r.DisplayField("Name").JoinTable("product_tags", "product_id", "tag_id") // Multi-select chips in forms
+		}).
+		// "Export CSV" streams the filtered list, XLSX keeps typed columns
+		WithExport(core.ExportConfig{Fields: []string{"ID", "Name", "Price"}, Formats: []core.ExportFormat{core.ExportCSV, core.ExportXLSX}}).
+		WithImport(core.ImportConfig{KeyField: "Name"}) // CSV rows naming an existing product update it
+
+	// Register Tag, linked to products through the product_tags join table
+	admin.RegisterResource(&Tag{}).
+		WithField("Name", func(f *core.FieldBuilder) {
+			f.DisplayName("Tag").Required(true).Searchable(true)
 		})
 
-	// Register Category with hierarchical relationship display and SortOrder ASC sorting
+	// Register Category with hierarchical relationship display and drag-and-drop SortOrder ordering
 	admin.RegisterResource(&Category{}).
-		WithDefaultSort("SortOrder", core.SortAsc).
+		WithOrderField("SortOrder").
 		WithField("Name", func(f *core.FieldBuilder) {
 			f.DisplayName("Category Name").Required(true).Searchable(true)
 		}).
@@ -296,6 +367,32 @@ func setupAdmin(db *sqlx.DB, authMode string, cfg *config.Config) {
 			r.DisplayField("Name").ForeignKey("ParentID").HierarchicalDisplay() // Hierarchical display in lists
 		})
 
+	// Show key numbers and the newest products on the home page
+	admin.Dashboard().
+		AddWidget(core.StatCard("Users", "User")).
+		AddWidget(core.StatCard("Active Users", "User").Where(map[string]any{"Active": true})).
+		AddWidget(core.StatCard("Products", "Product")).
+		AddWidget(core.RecentRecords("Newest Products", "Product", 5)).
+		AddWidget(core.ChartWidget("Products per Week", "Product", core.ChartLine,
+			core.AggregateQuery{GroupBy: "CreatedAt", Bucket: core.BucketWeek}))
+
+	// Serve a bespoke report inside the admin shell and link it from the sidebar
+	admin.RegisterPage("/reports/prices", func(w http.ResponseWriter, r *http.Request) {
+		var average float64
+		if err := db.GetContext(r.Context(), &average, "SELECT COALESCE(AVG(price), 0) FROM products"); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		fmt.Fprintf(w, `<p class="text-lg">Average product price: <strong>$%.2f</strong></p>`, average)
+	}).WithTitle("Price Report")
+	admin.AddLink("Price Report", "/admin/reports/prices", "📊").InGroup("Reports")
+	admin.AddLink("Backoffice on GitHub", "https://github.com/preslavrachev/backoffice", "").InGroup("Reports")
+
+	// Report misconfigured resources before serving
+	if err := admin.Validate(); err != nil {
+		log.Fatal(err)
+	}
+
 	// Setup HTTP server using UI package
 	http.Handle("/admin/", ui.Handler(admin, "/admin"))
 
@@ -315,6 +412,7 @@ func setupAdmin(db *sqlx.DB, authMode string, cfg *config.Config) {
 	fmt.Println("🔗 Relationship Patterns Demonstrated:")
 	fmt.Println("  • Employee → Department (Many-to-One, Compact)")
 	fmt.Println("  • Product → Category (Many-to-One, Badge)")
+	fmt.Println("  • Product ↔ Tag (Many-to-Many, multi-select chips)")
 	fmt.Println("  • Category → Parent Category (Many-to-One, Hierarchical)")
 	fmt.Println()
 	fmt.Println("🌐 API endpoints:")
@@ -328,6 +426,8 @@ func setupAdmin(db *sqlx.DB, authMode string, cfg *config.Config) {
 	fmt.Println("  go run examples/sql-example/main.go")
 	fmt.Println("  # With basic authentication:")
 	fmt.Println("  go run examples/sql-example/main.go -auth=basic")
+	fmt.Println("  # With users stored in the database:")
+	fmt.Println("  go run examples/sql-example/main.go -auth=users")
 	fmt.Println("  # With SQL debug logging:")
 	fmt.Println("  DEBUG=true go run examples/sql-example/main.go")
 	fmt.Println("  # With debug flag (equivalent):")
@@ -338,8 +438,20 @@ func setupAdmin(db *sqlx.DB, authMode string, cfg *config.Config) {
 	log.Fatal(http.ListenAndServe(":8080", nil))
 }
 
+// seedUsers adds the demo users the first time the example runs with -auth=users
+func seedUsers(store *sqladapter.Adapter) {
+	ctx := context.Background()
+	if users, err := store.ListUsers(ctx); err != nil || len(users) > 0 {
+		return
+	}
+	store.CreateUser(ctx, &auth.AuthUser{Username: "admin", Email: "admin@example.com", Roles: []string{"admin"}}, "admin123")
+	store.CreateUser(ctx, &auth.AuthUser{Username: "editor", Email: "editor@example.com", Roles: []string{"editor"}}, "editor123")
+}
+
 func seedData(db *sqlx.DB) {
 	// Clear existing data
+	db.Exec("DELETE FROM product_tags")
+	db.Exec("DELETE FROM tags")
 	db.Exec("DELETE FROM products")
 	db.Exec("DELETE FROM categories")
 	db.Exec("DELETE FROM users")
@@ -519,6 +631,20 @@ func seedData(db *sqlx.DB) {
 		}
 	}
 
+	// Create tags and link them to products
+	for _, name := range []string{"Bestseller", "New", "Sale", "Eco-friendly"} {
+		if _, err := db.Exec("INSERT INTO tags (name) VALUES (?)", name); err != nil {
+			log.Printf("Error inserting tag: %v", err)
+		}
+	}
+	if _, err := db.Exec(`
+		INSERT INTO product_tags (product_id, tag_id)
+		SELECT p.id, t.id FROM products p JOIN tags t
+		WHERE (t.name = 'Bestseller' AND p.price >= 100) OR (t.name = 'Sale' AND p.price < 30)
+	`); err != nil {
+		log.Printf("Error linking tags: %v", err)
+	}
+
 	// Create users with department relationships
 	now := time.Now()
 	trialEnd := sql.NullTime{Time: now.Add(5 * 24 * time.Hour), Valid: true}       // Trial ending in 5 days
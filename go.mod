@@ -11,3 +11,9 @@ require (
 require github.com/iancoleman/strcase v0.3.0
 
 require github.com/google/uuid v1.6.0
+
+require golang.org/x/net v0.42.0
+
+require golang.org/x/crypto v0.40.0
+
+require golang.org/x/sys v0.34.0 // indirect
@@ -4,7 +4,8 @@ import (
 	"context"
 	"crypto/subtle"
 	"errors"
-	"fmt"
+	"log"
+	"strings"
 	"time"
 
 	"github.com/preslavrachev/backoffice/config"
@@ -13,49 +14,19 @@ import (
 // BasicAuthUser represents a user configured for basic authentication
 type BasicAuthUser struct {
 	Username string
-	Password string
+	Password string // A hash made by HashPassword or of a registered format; plain text is deprecated
 	User     AuthUser
 }
 
 // WithBasicAuth creates an AuthConfig that uses HTTP Basic Authentication
 // Users are provided as a map of username -> BasicAuthUser
 func WithBasicAuth(users map[string]BasicAuthUser) AuthConfig {
-	sessionStore := NewMemorySessionStore()
-
-	authenticator := func(ctx context.Context, username, password string) (*AuthUser, error) {
-		fmt.Printf("🔐 DEBUG: BasicAuth - Checking username: '%s'\n", username)
-		fmt.Printf("🔐 DEBUG: BasicAuth - Available users: %v\n", func() []string {
-			var usernames []string
-			for u := range users {
-				usernames = append(usernames, u)
-			}
-			return usernames
-		}())
-
-		user, exists := users[username]
-		if !exists {
-			fmt.Printf("❌ DEBUG: BasicAuth - User '%s' not found\n", username)
-			return nil, errors.New("user not found")
-		}
-		fmt.Printf("✅ DEBUG: BasicAuth - User '%s' found\n", username)
-
-		// Use constant time comparison to prevent timing attacks
-		if subtle.ConstantTimeCompare([]byte(password), []byte(user.Password)) != 1 {
-			fmt.Printf("❌ DEBUG: BasicAuth - Password mismatch for user '%s' (expected: '%s', got: '%s')\n", username, user.Password, password)
-			return nil, errors.New("invalid password")
-		}
-		fmt.Printf("✅ DEBUG: BasicAuth - Password correct for user '%s'\n", username)
-
-		// Return the configured AuthUser
-		return &user.User, nil
-	}
-
 	return AuthConfig{
 		Enabled:        true,
 		LoginPath:      "/login",
 		LogoutPath:     "/logout",
-		Authenticator:  authenticator,
-		SessionStore:   sessionStore,
+		Authenticator:  basicAuthenticator(users),
+		SessionStore:   NewMemorySessionStore(),
 		RequireAuth:    true,
 		LoginRedirect:  "/admin",
 		LogoutRedirect: "/admin",
@@ -64,37 +35,59 @@ func WithBasicAuth(users map[string]BasicAuthUser) AuthConfig {
 
 // WithBasicAuthAndTimeout creates an AuthConfig with custom session timeout
 func WithBasicAuthAndTimeout(users map[string]BasicAuthUser, sessionTimeout time.Duration) AuthConfig {
-	sessionStore := NewMemorySessionStoreWithTimeout(sessionTimeout)
+	return AuthConfig{
+		Enabled:        true,
+		LoginPath:      "/login",
+		LogoutPath:     "/logout",
+		Authenticator:  basicAuthenticator(users),
+		SessionStore:   NewMemorySessionStoreWithTimeout(sessionTimeout),
+		RequireAuth:    true,
+		LoginRedirect:  "/admin",
+		LogoutRedirect: "/admin",
+	}
+}
 
-	authenticator := func(ctx context.Context, username, password string) (*AuthUser, error) {
+// basicAuthenticator checks credentials against the configured users
+// Users configured with a plain-text password get a deprecation warning logged once, when it is set up
+func basicAuthenticator(users map[string]BasicAuthUser) func(ctx context.Context, username, password string) (*AuthUser, error) {
+	for username, user := range users {
+		if strings.HasPrefix(user.Password, "$") && !IsPasswordHash(user.Password) {
+			log.Printf("basic auth user %q: %v; the password is compared as plain text", username, passwordFormatError(user.Password))
+		} else if !IsPasswordHash(user.Password) {
+			log.Printf("deprecated: basic auth user %q has a plain-text password, store a hash made by HashPassword or cmd/hashpassword instead", username)
+		}
+	}
+	return func(ctx context.Context, username, password string) (*AuthUser, error) {
 		user, exists := users[username]
 		if !exists {
 			return nil, errors.New("user not found")
 		}
 
-		// Use constant time comparison to prevent timing attacks
-		if subtle.ConstantTimeCompare([]byte(password), []byte(user.Password)) != 1 {
+		ok, err := checkBasicPassword(user.Password, password)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
 			return nil, errors.New("invalid password")
 		}
 
 		// Return the configured AuthUser
 		return &user.User, nil
 	}
+}
 
-	return AuthConfig{
-		Enabled:        true,
-		LoginPath:      "/login",
-		LogoutPath:     "/logout",
-		Authenticator:  authenticator,
-		SessionStore:   sessionStore,
-		RequireAuth:    true,
-		LoginRedirect:  "/admin",
-		LogoutRedirect: "/admin",
+// checkBasicPassword checks a password against a configured one, hashed or in plain text
+func checkBasicPassword(configured, password string) (bool, error) {
+	if IsPasswordHash(configured) {
+		return CheckPassword(configured, password)
 	}
+	// Use constant time comparison to prevent timing attacks
+	return subtle.ConstantTimeCompare([]byte(password), []byte(configured)) == 1, nil
 }
 
 // NewBasicAuthUser creates a BasicAuthUser with the provided details
 // This is a helper function to make it easier to create basic auth users
+// Prefer passing a hash of the password made by HashPassword over the password itself
 func NewBasicAuthUser(username, password string, id Identity, email string, roles []string) BasicAuthUser {
 	return BasicAuthUser{
 		Username: username,
@@ -112,11 +105,15 @@ func NewBasicAuthUser(username, password string, id Identity, email string, role
 func WithBasicAuthFromConfig() AuthConfig {
 	cfg := config.LoadConfig()
 
-	// Create a single admin user from config
+	// Create a single admin user from config, preferring the password's hash when one is set
+	password := cfg.Auth.BasicAuthPass
+	if cfg.Auth.BasicAuthPassHash != "" {
+		password = cfg.Auth.BasicAuthPassHash
+	}
 	users := map[string]BasicAuthUser{
 		cfg.Auth.BasicAuthUser: NewBasicAuthUser(
 			cfg.Auth.BasicAuthUser,
-			cfg.Auth.BasicAuthPass,
+			password,
 			"admin001",
 			"admin@example.com",
 			[]string{"admin"},
@@ -1,8 +1,18 @@
 package auth
 
 import (
+	"bytes"
 	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"strings"
 	"testing"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
 )
 
 func TestWithBasicAuth(t *testing.T) {
@@ -89,3 +99,100 @@ func TestNewBasicAuthUser(t *testing.T) {
 		}
 	}
 }
+
+func TestWithBasicAuth_HashedPassword(t *testing.T) {
+	hash, err := HashPassword("password123")
+	if err != nil {
+		t.Fatalf("HashPassword failed: %v", err)
+	}
+	config := WithBasicAuth(map[string]BasicAuthUser{
+		"admin": NewBasicAuthUser("admin", hash, "admin001", "admin@test.com", []string{"admin"}),
+	})
+
+	ctx := context.Background()
+	if _, err := config.Authenticator(ctx, "admin", "password123"); err != nil {
+		t.Errorf("Expected the password to match its hash, got %v", err)
+	}
+	if _, err := config.Authenticator(ctx, "admin", hash); err == nil {
+		t.Error("Expected the hash itself not to work as the password")
+	}
+}
+
+func TestRegisterPasswordFormat(t *testing.T) {
+	RegisterPasswordFormat("$test$", func(hash, password string) (bool, error) {
+		return hash == "$test$"+strings.ToUpper(password), nil
+	})
+	defer func() {
+		passwordFormatsMu.Lock()
+		delete(passwordFormats, "$test$")
+		passwordFormatsMu.Unlock()
+	}()
+
+	if !IsPasswordHash("$test$SECRET") || IsPasswordHash("secret") {
+		t.Error("Expected only values in a known format to be hashes")
+	}
+	if ok, err := CheckPassword("$test$SECRET", "secret"); err != nil || !ok {
+		t.Errorf("Expected the registered format to check the password, got %v, %v", ok, err)
+	}
+
+	config := WithBasicAuth(map[string]BasicAuthUser{
+		"admin": NewBasicAuthUser("admin", "$test$SECRET", "admin001", "admin@test.com", []string{"admin"}),
+	})
+	if _, err := config.Authenticator(context.Background(), "admin", "secret"); err != nil {
+		t.Errorf("Expected basic auth to accept registered hashes, got %v", err)
+	}
+
+	store := NewMemoryUserStore()
+	if err := store.AddUserWithHash(AuthUser{Username: "jane"}, "secret"); !errors.Is(err, ErrInvalidPasswordHash) {
+		t.Errorf("Expected plain passwords to be refused as hashes, got %v", err)
+	}
+	if err := store.AddUserWithHash(AuthUser{Username: "jane"}, "$test$SECRET"); err != nil {
+		t.Fatalf("AddUserWithHash failed: %v", err)
+	}
+	if _, err := store.VerifyPassword(context.Background(), "jane", "secret"); err != nil {
+		t.Errorf("Expected the store to accept the imported hash, got %v", err)
+	}
+}
+
+func TestBuiltInPasswordFormats(t *testing.T) {
+	bcryptHash, err := bcrypt.GenerateFromPassword([]byte("secret"), bcrypt.MinCost)
+	if err != nil {
+		t.Fatalf("GenerateFromPassword failed: %v", err)
+	}
+	salt := []byte("0123456789abcdef")
+	argon2Hash := fmt.Sprintf("$argon2id$v=%d$m=1024,t=1,p=1$%s$%s", argon2.Version,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(argon2.IDKey([]byte("secret"), salt, 1, 1024, 1, 32)))
+
+	for name, hash := range map[string]string{"bcrypt": string(bcryptHash), "argon2id": argon2Hash} {
+		if !IsPasswordHash(hash) {
+			t.Errorf("Expected %s hashes to be recognized", name)
+		}
+		if ok, err := CheckPassword(hash, "secret"); err != nil || !ok {
+			t.Errorf("Expected the %s hash to match, got %v, %v", name, ok, err)
+		}
+		if ok, err := CheckPassword(hash, "wrong"); err != nil || ok {
+			t.Errorf("Expected the %s hash to refuse a wrong password, got %v, %v", name, ok, err)
+		}
+	}
+
+	_, err = CheckPassword("$scrypt$ln=16,r=8,p=1$c2FsdA$aGFzaA", "secret")
+	if !errors.Is(err, ErrInvalidPasswordHash) || !strings.Contains(err.Error(), `"$scrypt$"`) || !strings.Contains(err.Error(), "RegisterPasswordFormat") {
+		t.Errorf("Expected the error to name the unregistered format, got %v", err)
+	}
+}
+
+func TestWithBasicAuth_WarnsAboutPlainPasswords(t *testing.T) {
+	var logged bytes.Buffer
+	log.SetOutput(&logged)
+	defer log.SetOutput(os.Stderr)
+
+	hash, _ := HashPassword("secret")
+	WithBasicAuth(map[string]BasicAuthUser{
+		"hashed": NewBasicAuthUser("hashed", hash, "1", "", nil),
+		"plain":  NewBasicAuthUser("plain", "secret", "2", "", nil),
+	})
+	if !strings.Contains(logged.String(), `"plain" has a plain-text password`) || strings.Contains(logged.String(), `"hashed"`) {
+		t.Errorf("Expected a deprecation warning for the plain password only, got %q", logged.String())
+	}
+}
@@ -1,11 +1,17 @@
 package auth
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
 // Context key for storing authenticated user in request context
 type contextKey string
 
-const authUserKey contextKey = "authUser"
+const (
+	authUserKey      contextKey = "authUser"
+	sessionExpiryKey contextKey = "sessionExpiry"
+)
 
 // GetAuthUser retrieves the authenticated user from the request context
 // Returns the user and true if authenticated, nil and false otherwise
@@ -19,6 +25,18 @@ func WithAuthUser(ctx context.Context, user *AuthUser) context.Context {
 	return context.WithValue(ctx, authUserKey, user)
 }
 
+// GetSessionExpiry returns when the session of the request expires
+// It is only known when the session store implements RefreshableSessionStore
+func GetSessionExpiry(ctx context.Context) (time.Time, bool) {
+	expiry, ok := ctx.Value(sessionExpiryKey).(time.Time)
+	return expiry, ok
+}
+
+// WithSessionExpiry adds the expiry of the request's session to the request context
+func WithSessionExpiry(ctx context.Context, expiry time.Time) context.Context {
+	return context.WithValue(ctx, sessionExpiryKey, expiry)
+}
+
 // IsAuthenticated checks if the request context contains an authenticated user
 func IsAuthenticated(ctx context.Context) bool {
 	_, ok := GetAuthUser(ctx)
@@ -0,0 +1,129 @@
+package auth
+
+import (
+	"net/http"
+	"strings"
+	"time"
+)
+
+// DefaultSessionCookieName is the session cookie's name when CookieConfig.Name is unset
+const DefaultSessionCookieName = "backoffice_session"
+
+// CookieSecure decides when the session cookie is only sent over HTTPS
+type CookieSecure string
+
+const (
+	CookieSecureAuto   CookieSecure = ""       // Secure when the request came over HTTPS, directly or through a proxy
+	CookieSecureAlways CookieSecure = "always" // Always secure, e.g. behind a proxy that doesn't set X-Forwarded-Proto
+	CookieSecureNever  CookieSecure = "never"  // Never secure, for plain HTTP development setups
+)
+
+// CookieConfig sets the attributes of the session cookie
+// The zero value gives a host-only, HttpOnly, SameSite=Lax cookie on "/" that lasts for the browser session
+type CookieConfig struct {
+	Name     string        // Defaults to DefaultSessionCookieName
+	Domain   string        // Empty for a host-only cookie
+	Path     string        // Defaults to "/"
+	Secure   CookieSecure  // Defaults to CookieSecureAuto
+	SameSite http.SameSite // Defaults to http.SameSiteLaxMode
+	TTL      time.Duration // How long the browser keeps the cookie, 0 until the browser closes
+}
+
+// CookieName returns the name of the session cookie
+func (c *AuthConfig) CookieName() string {
+	if c == nil || c.Cookie.Name == "" {
+		return DefaultSessionCookieName
+	}
+	return c.Cookie.Name
+}
+
+// SessionCookie returns the cookie holding the session ID, with Secure derived from the request
+func (c *AuthConfig) SessionCookie(r *http.Request, sessionID string) *http.Cookie {
+	cookie := c.baseCookie(r)
+	cookie.Value = sessionID
+	if c != nil && c.Cookie.TTL > 0 {
+		cookie.MaxAge = int(c.Cookie.TTL.Seconds())
+		cookie.Expires = time.Now().Add(c.Cookie.TTL)
+	}
+	return cookie
+}
+
+// ExpiredSessionCookie returns a cookie that makes the browser delete the session cookie
+func (c *AuthConfig) ExpiredSessionCookie(r *http.Request) *http.Cookie {
+	cookie := c.baseCookie(r)
+	cookie.MaxAge = -1
+	return cookie
+}
+
+// CompanionCookie returns a cookie with the domain, path and security of the session cookie,
+// for cookies that travel along with the session such as the CSRF token
+func (c *AuthConfig) CompanionCookie(r *http.Request, name, value string) *http.Cookie {
+	cookie := c.baseCookie(r)
+	cookie.Name = name
+	cookie.Value = value
+	return cookie
+}
+
+// SessionID returns the session ID from the request's session cookie
+func (c *AuthConfig) SessionID(r *http.Request) (string, error) {
+	cookie, err := r.Cookie(c.CookieName())
+	if err != nil {
+		return "", err
+	}
+	return cookie.Value, nil
+}
+
+// baseCookie returns the session cookie without a value, with the configured attributes
+func (c *AuthConfig) baseCookie(r *http.Request) *http.Cookie {
+	var config CookieConfig
+	if c != nil {
+		config = c.Cookie
+	}
+
+	cookie := &http.Cookie{
+		Name:     c.CookieName(),
+		Domain:   config.Domain,
+		Path:     config.Path,
+		HttpOnly: true,
+		SameSite: config.SameSite,
+	}
+	if cookie.Path == "" {
+		cookie.Path = "/"
+	}
+	if cookie.SameSite == 0 {
+		cookie.SameSite = http.SameSiteLaxMode
+	}
+	switch config.Secure {
+	case CookieSecureAlways:
+		cookie.Secure = true
+	case CookieSecureNever:
+		cookie.Secure = false
+	default:
+		cookie.Secure = isHTTPS(r)
+	}
+	// Browsers reject SameSite=None cookies that aren't Secure
+	if cookie.SameSite == http.SameSiteNoneMode {
+		cookie.Secure = true
+	}
+	return cookie
+}
+
+// isHTTPS reports whether the request came over HTTPS, directly or through a proxy
+func isHTTPS(r *http.Request) bool {
+	if r == nil {
+		return false
+	}
+	return r.TLS != nil || strings.EqualFold(r.Header.Get("X-Forwarded-Proto"), "https")
+}
+
+// CreateSessionCookie creates a session cookie for the authenticated user with the default attributes
+// Prefer AuthConfig.SessionCookie, which honors the configured cookie settings
+func CreateSessionCookie(sessionID string) *http.Cookie {
+	return (&AuthConfig{}).SessionCookie(nil, sessionID)
+}
+
+// DeleteSessionCookie creates a cookie that deletes a session cookie with the default attributes
+// Prefer AuthConfig.ExpiredSessionCookie, which honors the configured cookie settings
+func DeleteSessionCookie() *http.Cookie {
+	return (&AuthConfig{}).ExpiredSessionCookie(nil)
+}
@@ -0,0 +1,68 @@
+package auth
+
+import (
+	"crypto/tls"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestSessionCookie_Defaults(t *testing.T) {
+	config := &AuthConfig{}
+
+	plain := config.SessionCookie(httptest.NewRequest(http.MethodPost, "http://example.com/admin/login", nil), "abc")
+	if plain.Name != DefaultSessionCookieName || plain.Value != "abc" || plain.Path != "/" || plain.Domain != "" {
+		t.Errorf("Expected a host-only default cookie on /, got %+v", plain)
+	}
+	if !plain.HttpOnly || plain.SameSite != http.SameSiteLaxMode || plain.Secure || plain.MaxAge != 0 {
+		t.Errorf("Expected an HttpOnly, Lax, non-secure session cookie over HTTP, got %+v", plain)
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "https://example.com/admin/login", nil)
+	req.TLS = &tls.ConnectionState{}
+	if cookie := config.SessionCookie(req, "abc"); !cookie.Secure {
+		t.Error("Expected a secure cookie over HTTPS")
+	}
+
+	proxied := httptest.NewRequest(http.MethodPost, "http://example.com/admin/login", nil)
+	proxied.Header.Set("X-Forwarded-Proto", "https")
+	if cookie := config.SessionCookie(proxied, "abc"); !cookie.Secure {
+		t.Error("Expected a secure cookie behind an HTTPS proxy")
+	}
+}
+
+func TestSessionCookie_Configured(t *testing.T) {
+	config := &AuthConfig{Cookie: CookieConfig{
+		Name:     "admin_sid",
+		Domain:   "example.com",
+		Path:     "/admin",
+		Secure:   CookieSecureAlways,
+		SameSite: http.SameSiteStrictMode,
+		TTL:      2 * time.Hour,
+	}}
+	req := httptest.NewRequest(http.MethodPost, "http://example.com/admin/login", nil)
+
+	cookie := config.SessionCookie(req, "abc")
+	if cookie.Name != "admin_sid" || cookie.Domain != "example.com" || cookie.Path != "/admin" {
+		t.Errorf("Expected the configured name and scope, got %+v", cookie)
+	}
+	if !cookie.Secure || cookie.SameSite != http.SameSiteStrictMode || cookie.MaxAge != 7200 {
+		t.Errorf("Expected the configured security and lifetime, got %+v", cookie)
+	}
+
+	expired := config.ExpiredSessionCookie(req)
+	if expired.Name != "admin_sid" || expired.Path != "/admin" || expired.MaxAge != -1 {
+		t.Errorf("Expected the deleting cookie to match the session cookie, got %+v", expired)
+	}
+
+	req.AddCookie(&http.Cookie{Name: "admin_sid", Value: "abc"})
+	if id, err := config.SessionID(req); err != nil || id != "abc" {
+		t.Errorf("Expected the session ID from the configured cookie, got %q, %v", id, err)
+	}
+
+	never := &AuthConfig{Cookie: CookieConfig{Secure: CookieSecureNever, SameSite: http.SameSiteNoneMode}}
+	if cookie := never.SessionCookie(req, "abc"); !cookie.Secure {
+		t.Error("Expected SameSite=None cookies to always be secure")
+	}
+}
@@ -0,0 +1,191 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+)
+
+var (
+	// ErrImpersonationNotAllowed is returned when the signed-in user may not view as other users
+	ErrImpersonationNotAllowed = errors.New("impersonation not allowed")
+	// ErrImpersonationPrivileged is returned when the user to view as has roles the admin doesn't have
+	ErrImpersonationPrivileged = errors.New("cannot impersonate a user with roles you don't have")
+	// ErrNotImpersonating is returned when stopping an impersonation that never started
+	ErrNotImpersonating = errors.New("not impersonating")
+)
+
+// ImpersonationConfig lets admins "view as" another user to debug their roles and permissions
+// without sharing credentials. It needs AuthConfig.Users to look up the impersonated users
+type ImpersonationConfig struct {
+	Roles       []Role               // Roles allowed to impersonate; impersonation is disabled without any
+	Audit       ImpersonationAuditor // Receives every impersonation event, defaults to the standard logger
+	AllowWrites bool                 // Lets admins change records while viewing as a user, read-only otherwise
+}
+
+// ImpersonationEventKind tells what happened during an impersonation
+type ImpersonationEventKind string
+
+const (
+	ImpersonationStarted ImpersonationEventKind = "started" // An admin started viewing as another user
+	ImpersonationRequest ImpersonationEventKind = "request" // A state-changing request made while impersonating
+	ImpersonationStopped ImpersonationEventKind = "stopped" // The admin went back to their own account
+)
+
+// ImpersonationEvent is an audit record of an impersonation
+type ImpersonationEvent struct {
+	Kind         ImpersonationEventKind `json:"kind"`
+	Impersonator string                 `json:"impersonator"` // Username of the admin really signed in
+	Target       string                 `json:"target"`       // Username of the user viewed as
+	Method       string                 `json:"method"`
+	Path         string                 `json:"path"`
+	At           time.Time              `json:"at"`
+}
+
+// ImpersonationAuditor records impersonation events
+type ImpersonationAuditor interface {
+	RecordImpersonation(ctx context.Context, event ImpersonationEvent) error
+}
+
+// ImpersonationAuditFunc adapts a function to ImpersonationAuditor
+type ImpersonationAuditFunc func(ctx context.Context, event ImpersonationEvent) error
+
+// RecordImpersonation calls the function
+func (f ImpersonationAuditFunc) RecordImpersonation(ctx context.Context, event ImpersonationEvent) error {
+	return f(ctx, event)
+}
+
+// IsImpersonated reports whether an admin is viewing as this user
+func (u *AuthUser) IsImpersonated() bool {
+	return u != nil && u.Impersonator != nil
+}
+
+// CanImpersonate reports whether the user may view as other users
+// Impersonations don't nest: the impersonated user can't start another one
+func (c *AuthConfig) CanImpersonate(user *AuthUser) bool {
+	if c == nil || !c.Enabled || c.Users == nil || len(c.Impersonation.Roles) == 0 {
+		return false
+	}
+	return !user.IsImpersonated() && user.HasRole(c.Impersonation.Roles...)
+}
+
+// ImpersonationReadOnly reports whether the user is impersonated in a session that can't change records
+func (c *AuthConfig) ImpersonationReadOnly(user *AuthUser) bool {
+	return user.IsImpersonated() && (c == nil || !c.Impersonation.AllowWrites)
+}
+
+// hasAllRoles reports whether the user has every one of the roles
+func (u *AuthUser) hasAllRoles(roles []string) bool {
+	for _, role := range roles {
+		if !u.HasRole(Role(role)) {
+			return false
+		}
+	}
+	return true
+}
+
+// StartImpersonation replaces the request's session with one of the named user, remembering the
+// signed-in admin so StopImpersonation can switch back
+// Admins can only view as users whose roles they all have, so impersonating never gains privileges
+func (c *AuthConfig) StartImpersonation(w http.ResponseWriter, r *http.Request, username string) (*AuthUser, error) {
+	ctx := r.Context()
+	admin, _ := GetAuthUser(ctx)
+	if !c.CanImpersonate(admin) || username == admin.Username {
+		return nil, ErrImpersonationNotAllowed
+	}
+
+	target, err := c.Users.GetUserByUsername(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+	if !admin.hasAllRoles(target.Roles) {
+		return nil, ErrImpersonationPrivileged
+	}
+	impersonator := *admin
+	target.Impersonator = &impersonator
+
+	if err := c.switchSession(w, r, target); err != nil {
+		return nil, err
+	}
+	c.auditImpersonation(r, ImpersonationStarted, target)
+	return target, nil
+}
+
+// StopImpersonation switches the request's session back to the admin who started the impersonation
+func (c *AuthConfig) StopImpersonation(w http.ResponseWriter, r *http.Request) (*AuthUser, error) {
+	user, _ := GetAuthUser(r.Context())
+	if !user.IsImpersonated() {
+		return nil, ErrNotImpersonating
+	}
+
+	admin := *user.Impersonator
+	if err := c.switchSession(w, r, &admin); err != nil {
+		return nil, err
+	}
+	c.auditImpersonation(r, ImpersonationStopped, user)
+	return &admin, nil
+}
+
+// switchSession signs the request in as another user, ending its current session
+func (c *AuthConfig) switchSession(w http.ResponseWriter, r *http.Request, user *AuthUser) error {
+	sessionID, err := c.SessionStore.CreateSession(r.Context(), user)
+	if err != nil {
+		return err
+	}
+	if previous, err := c.SessionID(r); err == nil {
+		c.SessionStore.DeleteSession(r.Context(), previous)
+	}
+	http.SetCookie(w, c.SessionCookie(r, sessionID))
+	return nil
+}
+
+// auditImpersonation records an event of the impersonated user's session
+func (c *AuthConfig) auditImpersonation(r *http.Request, kind ImpersonationEventKind, user *AuthUser) {
+	event := ImpersonationEvent{
+		Kind:         kind,
+		Impersonator: user.Impersonator.Username,
+		Target:       user.Username,
+		Method:       r.Method,
+		Path:         r.URL.Path,
+		At:           time.Now(),
+	}
+	if c.Impersonation.Audit == nil {
+		log.Printf("impersonation %s: %s as %s %s %s", event.Kind, event.Impersonator, event.Target, event.Method, event.Path)
+		return
+	}
+	if err := c.Impersonation.Audit.RecordImpersonation(r.Context(), event); err != nil {
+		log.Printf("failed to record impersonation %s of %s by %s: %v", event.Kind, event.Target, event.Impersonator, err)
+	}
+}
+
+// MemoryImpersonationAudit keeps impersonation events in memory
+// Events are lost on restart, so use a persistent auditor in production
+type MemoryImpersonationAudit struct {
+	events []ImpersonationEvent
+	mutex  sync.RWMutex
+}
+
+// NewMemoryImpersonationAudit creates an empty in-memory impersonation audit log
+func NewMemoryImpersonationAudit() *MemoryImpersonationAudit {
+	return &MemoryImpersonationAudit{}
+}
+
+// RecordImpersonation appends the event to the log
+func (m *MemoryImpersonationAudit) RecordImpersonation(ctx context.Context, event ImpersonationEvent) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.events = append(m.events, event)
+	return nil
+}
+
+// Events returns the recorded events, oldest first
+func (m *MemoryImpersonationAudit) Events() []ImpersonationEvent {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	return append([]ImpersonationEvent(nil), m.events...)
+}
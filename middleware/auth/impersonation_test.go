@@ -0,0 +1,142 @@
+package auth
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func newImpersonationConfig(t *testing.T) (*AuthConfig, *MemoryImpersonationAudit) {
+	t.Helper()
+	users := NewMemoryUserStore()
+	if err := users.AddUser(AuthUser{Username: "ann", Roles: []string{"admin", "editor"}}, "secret123"); err != nil {
+		t.Fatal(err)
+	}
+	if err := users.AddUser(AuthUser{Username: "ed", Roles: []string{"editor"}}, "secret123"); err != nil {
+		t.Fatal(err)
+	}
+
+	audit := NewMemoryImpersonationAudit()
+	config := WithUserStore(users)
+	config.Impersonation = ImpersonationConfig{Roles: []Role{"admin"}, Audit: audit}
+	return &config, audit
+}
+
+// signedInRequest is a request of the user, carrying the session cookie the middleware would have checked
+func signedInRequest(t *testing.T, config *AuthConfig, method string, user *AuthUser) *http.Request {
+	t.Helper()
+	sessionID, err := config.SessionStore.CreateSession(t.Context(), user)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req := httptest.NewRequest(method, "/admin/impersonate", nil)
+	req.AddCookie(config.SessionCookie(req, sessionID))
+	return req.WithContext(WithAuthUser(req.Context(), user))
+}
+
+func TestImpersonation_StartAndStop(t *testing.T) {
+	config, audit := newImpersonationConfig(t)
+	ann := &AuthUser{Username: "ann", Roles: []string{"admin", "editor"}}
+
+	w := httptest.NewRecorder()
+	target, err := config.StartImpersonation(w, signedInRequest(t, config, http.MethodPost, ann), "ed")
+	if err != nil {
+		t.Fatalf("Failed to start impersonation: %v", err)
+	}
+	if target.Username != "ed" || !target.IsImpersonated() || target.Impersonator.Username != "ann" {
+		t.Fatalf("Expected ed impersonated by ann, got %+v", target)
+	}
+
+	// The new session is ed's, remembering ann
+	cookie := findCookie(w.Result().Cookies(), DefaultSessionCookieName)
+	session, err := config.SessionStore.GetSession(t.Context(), cookie.Value)
+	if err != nil || session.Username != "ed" || session.Impersonator.Username != "ann" {
+		t.Fatalf("Expected the session to hold ed impersonated by ann, got %+v (%v)", session, err)
+	}
+
+	// Impersonations don't nest
+	if _, err := config.StartImpersonation(httptest.NewRecorder(), signedInRequest(t, config, http.MethodPost, session), "ann"); err != ErrImpersonationNotAllowed {
+		t.Errorf("Expected ErrImpersonationNotAllowed while impersonating, got %v", err)
+	}
+
+	w = httptest.NewRecorder()
+	admin, err := config.StopImpersonation(w, signedInRequest(t, config, http.MethodPost, session))
+	if err != nil || admin.Username != "ann" || admin.IsImpersonated() {
+		t.Fatalf("Expected to be back as ann, got %+v (%v)", admin, err)
+	}
+
+	events := audit.Events()
+	if len(events) != 2 || events[0].Kind != ImpersonationStarted || events[1].Kind != ImpersonationStopped {
+		t.Fatalf("Expected start and stop events, got %+v", events)
+	}
+	if events[0].Impersonator != "ann" || events[0].Target != "ed" {
+		t.Errorf("Expected ann impersonating ed, got %+v", events[0])
+	}
+}
+
+func TestImpersonation_NotAllowed(t *testing.T) {
+	config, audit := newImpersonationConfig(t)
+	ed := &AuthUser{Username: "ed", Roles: []string{"editor"}}
+	ann := &AuthUser{Username: "ann", Roles: []string{"admin", "editor"}}
+
+	if _, err := config.StartImpersonation(httptest.NewRecorder(), signedInRequest(t, config, http.MethodPost, ed), "ann"); err != ErrImpersonationNotAllowed {
+		t.Errorf("Expected editors not to impersonate, got %v", err)
+	}
+	if _, err := config.StartImpersonation(httptest.NewRecorder(), signedInRequest(t, config, http.MethodPost, ann), "ann"); err != ErrImpersonationNotAllowed {
+		t.Errorf("Expected admins not to impersonate themselves, got %v", err)
+	}
+	if _, err := config.StartImpersonation(httptest.NewRecorder(), signedInRequest(t, config, http.MethodPost, ann), "nobody"); err != ErrUserNotFound {
+		t.Errorf("Expected ErrUserNotFound for unknown users, got %v", err)
+	}
+	if _, err := config.StopImpersonation(httptest.NewRecorder(), signedInRequest(t, config, http.MethodPost, ann)); err != ErrNotImpersonating {
+		t.Errorf("Expected ErrNotImpersonating, got %v", err)
+	}
+
+	config.Impersonation.Roles = nil
+	if config.CanImpersonate(ann) {
+		t.Error("Expected impersonation to be disabled without roles")
+	}
+	if len(audit.Events()) != 0 {
+		t.Errorf("Expected nothing audited, got %+v", audit.Events())
+	}
+}
+
+func TestMiddleware_AuditsImpersonatedRequests(t *testing.T) {
+	config, audit := newImpersonationConfig(t)
+	ed := &AuthUser{Username: "ed", Roles: []string{"editor"}, Impersonator: &AuthUser{Username: "ann"}}
+	sessionID, _ := config.SessionStore.CreateSession(t.Context(), ed)
+
+	handler := CreateAuthMiddleware(config)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	for _, method := range []string{http.MethodGet, http.MethodPost} {
+		req := httptest.NewRequest(method, "/admin/Order/1", nil)
+		req.AddCookie(config.SessionCookie(req, sessionID))
+		handler.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	events := audit.Events()
+	if len(events) != 1 {
+		t.Fatalf("Expected only the POST to be audited, got %+v", events)
+	}
+	if event := events[0]; event.Kind != ImpersonationRequest || event.Method != http.MethodPost || event.Path != "/admin/Order/1" || event.Impersonator != "ann" {
+		t.Errorf("Unexpected audit event %+v", event)
+	}
+}
+
+func TestImpersonation_OnlyLesserUsers(t *testing.T) {
+	config, audit := newImpersonationConfig(t)
+	if err := config.Users.(*MemoryUserStore).AddUser(AuthUser{Username: "sam", Roles: []string{"support"}}, "secret123"); err != nil {
+		t.Fatal(err)
+	}
+	config.Impersonation.Roles = []Role{"admin", "support"}
+	sam := &AuthUser{Username: "sam", Roles: []string{"support"}}
+
+	if _, err := config.StartImpersonation(httptest.NewRecorder(), signedInRequest(t, config, http.MethodPost, sam), "ann"); err != ErrImpersonationPrivileged {
+		t.Errorf("Expected impersonating an admin to be refused, got %v", err)
+	}
+	if _, err := config.StartImpersonation(httptest.NewRecorder(), signedInRequest(t, config, http.MethodPost, sam), "ed"); err != ErrImpersonationPrivileged {
+		t.Errorf("Expected impersonating a user with other roles to be refused, got %v", err)
+	}
+	if len(audit.Events()) != 0 {
+		t.Errorf("Expected nothing audited, got %+v", audit.Events())
+	}
+}
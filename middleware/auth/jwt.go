@@ -0,0 +1,169 @@
+package auth
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+)
+
+// DefaultJWTSessionTTL is how long JWT sessions last without activity when JWTSessionStore.TTL is unset
+const DefaultJWTSessionTTL = 15 * time.Minute
+
+// ErrInvalidSessionToken is returned for session tokens that are malformed or not signed with a known key
+var ErrInvalidSessionToken = errors.New("invalid session token")
+
+// RotatingSessionStore is a SessionStore whose session IDs change over a session's lifetime
+// The middleware asks it on every request and sends the browser the new ID when there is one
+type RotatingSessionStore interface {
+	SessionStore
+
+	// RotateSession returns a new ID for the session, or the same ID when it doesn't need rotating yet
+	RotateSession(ctx context.Context, sessionID string) (string, error)
+}
+
+// JWTSessionStore keeps sessions in signed JWTs (HS256) instead of on the server, so several instances
+// sharing the key accept each other's sessions without shared storage
+// Tokens are short-lived and rotated once half their lifetime has passed, so active users stay signed in
+// Logging out only deletes the cookie: a copied token stays valid until it expires, keep TTL short
+type JWTSessionStore struct {
+	key          []byte
+	previousKeys [][]byte
+
+	// TTL is how long a token is valid, defaults to DefaultJWTSessionTTL
+	TTL time.Duration
+}
+
+// NewJWTSessionStore creates a session store signing tokens with key, at least 32 random bytes
+// Tokens signed with one of previousKeys are still accepted and rotated to key, to change keys without
+// signing everyone out
+func NewJWTSessionStore(key []byte, ttl time.Duration, previousKeys ...[]byte) *JWTSessionStore {
+	return &JWTSessionStore{key: key, previousKeys: previousKeys, TTL: ttl}
+}
+
+// jwtHeader is the fixed header of the store's tokens
+var jwtHeader = base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"HS256","typ":"JWT"}`))
+
+// jwtClaims are the claims of a session token
+type jwtClaims struct {
+	Subject   string    `json:"sub"`
+	IssuedAt  int64     `json:"iat"`
+	ExpiresAt int64     `json:"exp"`
+	User      *AuthUser `json:"usr"`
+}
+
+// GetSession returns the user of a valid, unexpired token
+func (s *JWTSessionStore) GetSession(ctx context.Context, sessionID string) (*AuthUser, error) {
+	claims, _, err := s.parse(sessionID)
+	if err != nil {
+		return nil, err
+	}
+	return claims.User, nil
+}
+
+// CreateSession issues a token for the user
+func (s *JWTSessionStore) CreateSession(ctx context.Context, user *AuthUser) (string, error) {
+	now := time.Now()
+	claims := jwtClaims{
+		Subject:   user.Username,
+		IssuedAt:  now.Unix(),
+		ExpiresAt: now.Add(s.ttl()).Unix(),
+		User:      user,
+	}
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+	unsigned := jwtHeader + "." + base64.RawURLEncoding.EncodeToString(payload)
+	return unsigned + "." + signJWT(s.key, unsigned), nil
+}
+
+// DeleteSession does nothing, as the store keeps no sessions; the cookie holding the token is deleted instead
+func (s *JWTSessionStore) DeleteSession(ctx context.Context, sessionID string) error {
+	return nil
+}
+
+// CleanExpiredSessions does nothing, as expired tokens are simply rejected
+func (s *JWTSessionStore) CleanExpiredSessions(ctx context.Context) error {
+	return nil
+}
+
+// SessionExpiry returns when the token expires
+func (s *JWTSessionStore) SessionExpiry(ctx context.Context, sessionID string) (time.Time, error) {
+	claims, _, err := s.parse(sessionID)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Unix(claims.ExpiresAt, 0), nil
+}
+
+// RotateSession issues a new token once half of the token's lifetime has passed or it was signed with a previous key
+func (s *JWTSessionStore) RotateSession(ctx context.Context, sessionID string) (string, error) {
+	claims, current, err := s.parse(sessionID)
+	if err != nil {
+		return "", err
+	}
+	halfway := time.Unix(claims.IssuedAt, 0).Add(s.ttl() / 2)
+	if current && time.Now().Before(halfway) {
+		return sessionID, nil
+	}
+	return s.CreateSession(ctx, claims.User)
+}
+
+// parse verifies a token and returns its claims, and whether it was signed with the current key
+func (s *JWTSessionStore) parse(token string) (*jwtClaims, bool, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 || parts[0] != jwtHeader {
+		return nil, false, ErrInvalidSessionToken
+	}
+	unsigned := parts[0] + "." + parts[1]
+	current := hmac.Equal([]byte(parts[2]), []byte(signJWT(s.key, unsigned)))
+	valid := current
+	for _, key := range s.previousKeys {
+		valid = valid || hmac.Equal([]byte(parts[2]), []byte(signJWT(key, unsigned)))
+	}
+	if !valid {
+		return nil, false, ErrInvalidSessionToken
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return nil, false, ErrInvalidSessionToken
+	}
+	var claims jwtClaims
+	if err := json.Unmarshal(payload, &claims); err != nil || claims.User == nil {
+		return nil, false, ErrInvalidSessionToken
+	}
+	if time.Now().Unix() >= claims.ExpiresAt {
+		return nil, false, ErrSessionExpired
+	}
+	for user := claims.User; user != nil; user = user.Impersonator {
+		user.ID = jsonIdentity(user.ID)
+	}
+	return &claims, current, nil
+}
+
+// jsonIdentity turns whole-number IDs, decoded from JSON as float64, back into int64
+func jsonIdentity(id Identity) Identity {
+	if f, ok := id.(float64); ok && f == float64(int64(f)) {
+		return int64(f)
+	}
+	return id
+}
+
+func (s *JWTSessionStore) ttl() time.Duration {
+	if s.TTL <= 0 {
+		return DefaultJWTSessionTTL
+	}
+	return s.TTL
+}
+
+func signJWT(key []byte, unsigned string) string {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(unsigned))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
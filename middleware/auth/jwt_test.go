@@ -0,0 +1,89 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestJWTSessionStore(t *testing.T) {
+	ctx := context.Background()
+	key := []byte("0123456789abcdef0123456789abcdef")
+	store := NewJWTSessionStore(key, time.Hour)
+
+	admin := &AuthUser{ID: 7, Username: "admin", Roles: []string{"admin"}}
+	user := &AuthUser{ID: "u-1", Username: "jane", Email: "jane@example.com", Roles: []string{"editor"}, Impersonator: admin}
+	token, err := store.CreateSession(ctx, user)
+	if err != nil {
+		t.Fatalf("CreateSession failed: %v", err)
+	}
+
+	got, err := store.GetSession(ctx, token)
+	if err != nil {
+		t.Fatalf("GetSession failed: %v", err)
+	}
+	if got.Username != "jane" || got.ID != "u-1" || got.Roles[0] != "editor" || got.Impersonator == nil || got.Impersonator.ID != int64(7) {
+		t.Errorf("Expected the user back from the token, got %+v", got)
+	}
+
+	// Another instance sharing the key accepts the token; one with another key doesn't
+	if _, err := NewJWTSessionStore(key, time.Hour).GetSession(ctx, token); err != nil {
+		t.Errorf("Expected another instance to accept the token, got %v", err)
+	}
+	if _, err := NewJWTSessionStore([]byte("another key"), time.Hour).GetSession(ctx, token); !errors.Is(err, ErrInvalidSessionToken) {
+		t.Errorf("Expected a token signed with another key to be rejected, got %v", err)
+	}
+
+	parts := strings.Split(token, ".")
+	forged := parts[0] + "." + strings.TrimRight(parts[1], "A") + "B." + parts[2]
+	if _, err := store.GetSession(ctx, forged); !errors.Is(err, ErrInvalidSessionToken) {
+		t.Errorf("Expected a modified token to be rejected, got %v", err)
+	}
+
+	if rotated, _ := store.RotateSession(ctx, token); rotated != token {
+		t.Error("Expected a fresh token not to rotate")
+	}
+	rotating := NewJWTSessionStore([]byte("new key 0123456789abcdef01234567"), time.Hour, key)
+	rotated, err := rotating.RotateSession(ctx, token)
+	if err != nil || rotated == token {
+		t.Fatalf("Expected a token of the previous key to rotate, got %v", err)
+	}
+	if _, err := NewJWTSessionStore([]byte("new key 0123456789abcdef01234567"), time.Hour).GetSession(ctx, rotated); err != nil {
+		t.Errorf("Expected the rotated token signed with the new key, got %v", err)
+	}
+
+	expired := NewJWTSessionStore(key, time.Nanosecond)
+	short, _ := expired.CreateSession(ctx, user)
+	if _, err := expired.GetSession(ctx, short); !errors.Is(err, ErrSessionExpired) {
+		t.Errorf("Expected an expired token to be rejected, got %v", err)
+	}
+}
+
+func TestJWTSessionStore_MiddlewareRotation(t *testing.T) {
+	store := NewJWTSessionStore([]byte("0123456789abcdef0123456789abcdef"), 2*time.Second)
+	config := AuthConfig{Enabled: true, RequireAuth: true, LoginPath: "/login", LogoutPath: "/logout", SessionStore: store}
+	handler := CreateAuthMiddleware(&config)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	token, _ := store.CreateSession(context.Background(), &AuthUser{Username: "jane"})
+	request := func() *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/admin", nil)
+		req.AddCookie(&http.Cookie{Name: DefaultSessionCookieName, Value: token})
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+		return w
+	}
+
+	if w := request(); w.Code != http.StatusOK || len(w.Result().Cookies()) != 0 {
+		t.Fatalf("Expected a fresh token to be accepted as is, got %d with %v", w.Code, w.Result().Cookies())
+	}
+	time.Sleep(1100 * time.Millisecond)
+	w := request()
+	cookies := w.Result().Cookies()
+	if w.Code != http.StatusOK || len(cookies) != 1 || cookies[0].Value == token {
+		t.Fatalf("Expected a token past half its lifetime to be rotated, got %d with %v", w.Code, cookies)
+	}
+}
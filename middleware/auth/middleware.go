@@ -1,11 +1,14 @@
 package auth
 
 import (
+	"errors"
 	"net/http"
 	"strings"
+	"time"
 )
 
-const sessionCookieName = "backoffice_session"
+// ErrSessionNotRefreshable is returned when the session store can't extend sessions
+var ErrSessionNotRefreshable = errors.New("session store does not support refreshing sessions")
 
 // CreateAuthMiddleware creates HTTP middleware for authentication
 func CreateAuthMiddleware(authConfig *AuthConfig) func(http.Handler) http.Handler {
@@ -25,8 +28,31 @@ func CreateAuthMiddleware(authConfig *AuthConfig) func(http.Handler) http.Handle
 				return
 			}
 
-			// Try to get user from session
+			// Behind an authenticating proxy, the proxy's headers identify the user; there is no login form to redirect to
+			if authConfig.Proxy.Enabled() {
+				user, err := authConfig.ProxyUser(r)
+				if err != nil && authConfig.RequireAuth {
+					http.Error(w, "Unauthorized", http.StatusUnauthorized)
+					return
+				}
+				ctx := r.Context()
+				if user != nil {
+					ctx = WithAuthUser(ctx, user)
+				}
+				next.ServeHTTP(w, r.WithContext(ctx))
+				return
+			}
+
+			// Try to get user from session, falling back to a remember-me token
 			user, err := getUserFromSession(r, authConfig)
+			if err == nil {
+				touchSession(r, authConfig)
+				rotateSession(w, r, authConfig)
+			} else {
+				if remembered, rememberErr := resumeRememberedSession(w, r, authConfig); rememberErr == nil {
+					user, err = remembered, nil
+				}
+			}
 			if err != nil && authConfig.RequireAuth {
 				// Redirect to login page if authentication is required
 				redirectToLogin(w, r, authConfig)
@@ -37,6 +63,12 @@ func CreateAuthMiddleware(authConfig *AuthConfig) func(http.Handler) http.Handle
 			ctx := r.Context()
 			if user != nil {
 				ctx = WithAuthUser(ctx, user)
+				if user.IsImpersonated() && !isSafeMethod(r.Method) {
+					authConfig.auditImpersonation(r, ImpersonationRequest, user)
+				}
+				if expiry, err := getSessionExpiry(r, authConfig); err == nil {
+					ctx = WithSessionExpiry(ctx, expiry)
+				}
 			}
 
 			// Continue with the request
@@ -56,13 +88,54 @@ func isAuthEndpoint(path string, authConfig *AuthConfig) bool {
 // getUserFromSession retrieves the user from the session cookie
 func getUserFromSession(r *http.Request, authConfig *AuthConfig) (*AuthUser, error) {
 	// Get session cookie
-	cookie, err := r.Cookie(sessionCookieName)
+	sessionID, err := authConfig.SessionID(r)
 	if err != nil {
 		return nil, err
 	}
 
 	// Get user from session store
-	return authConfig.SessionStore.GetSession(r.Context(), cookie.Value)
+	return authConfig.SessionStore.GetSession(r.Context(), sessionID)
+}
+
+// rotateSession sends the browser a new session ID when the store rotates the request's session
+func rotateSession(w http.ResponseWriter, r *http.Request, authConfig *AuthConfig) {
+	store, ok := authConfig.SessionStore.(RotatingSessionStore)
+	if !ok {
+		return
+	}
+	sessionID, err := authConfig.SessionID(r)
+	if err != nil {
+		return
+	}
+	if rotated, err := store.RotateSession(r.Context(), sessionID); err == nil && rotated != sessionID {
+		http.SetCookie(w, authConfig.SessionCookie(r, rotated))
+	}
+}
+
+// getSessionExpiry returns when the request's session expires, if the store can tell
+func getSessionExpiry(r *http.Request, authConfig *AuthConfig) (time.Time, error) {
+	store, ok := authConfig.SessionStore.(RefreshableSessionStore)
+	if !ok {
+		return time.Time{}, ErrSessionNotRefreshable
+	}
+	sessionID, err := authConfig.SessionID(r)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return store.SessionExpiry(r.Context(), sessionID)
+}
+
+// RefreshRequestSession extends the session of the request's cookie and returns its new expiry
+func RefreshRequestSession(r *http.Request, authConfig *AuthConfig) (time.Time, error) {
+	store, ok := authConfig.SessionStore.(RefreshableSessionStore)
+	if !ok {
+		return time.Time{}, ErrSessionNotRefreshable
+	}
+	sessionID, err := authConfig.SessionID(r)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return store.RefreshSession(r.Context(), sessionID)
 }
 
 // redirectToLogin redirects the user to the login page
@@ -92,28 +165,7 @@ func getBasePath(requestPath string) string {
 	return ""
 }
 
-// CreateSessionCookie creates a session cookie for the authenticated user
-func CreateSessionCookie(sessionID string) *http.Cookie {
-	return &http.Cookie{
-		Name:     sessionCookieName,
-		Value:    sessionID,
-		HttpOnly: true,
-		Secure:   false, // Set to true in production with HTTPS
-		SameSite: http.SameSiteLaxMode,
-		Path:     "/",
-		// MaxAge is controlled by the session store timeout
-	}
-}
-
-// DeleteSessionCookie creates a cookie that deletes the session
-func DeleteSessionCookie() *http.Cookie {
-	return &http.Cookie{
-		Name:     sessionCookieName,
-		Value:    "",
-		HttpOnly: true,
-		Secure:   false,
-		SameSite: http.SameSiteLaxMode,
-		Path:     "/",
-		MaxAge:   -1, // Delete the cookie immediately
-	}
+// isSafeMethod reports whether the method only reads, so requests using it are left out of audits
+func isSafeMethod(method string) bool {
+	return method == http.MethodGet || method == http.MethodHead || method == http.MethodOptions
 }
@@ -0,0 +1,174 @@
+package auth
+
+import (
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	passwordHashScheme     = "pbkdf2-sha256"
+	passwordHashIterations = 600_000
+	passwordHashLength     = 32
+	passwordSaltLength     = 16
+)
+
+// ErrInvalidPasswordHash is returned when a stored hash is neither in the HashPassword format nor a registered one
+var ErrInvalidPasswordHash = errors.New("invalid password hash")
+
+// bcrypt's and argon2id's hashes are checked out of the box
+func init() {
+	for _, prefix := range []string{"$2a$", "$2b$", "$2y$"} {
+		RegisterPasswordFormat(prefix, checkBcryptPassword)
+	}
+	RegisterPasswordFormat("$argon2id$", checkArgon2idPassword)
+}
+
+// PasswordCheckFunc reports whether the password matches a hash of a registered format
+type PasswordCheckFunc func(hash, password string) (bool, error)
+
+var (
+	passwordFormats   = map[string]PasswordCheckFunc{}
+	passwordFormatsMu sync.RWMutex
+)
+
+// RegisterPasswordFormat makes CheckPassword accept hashes starting with prefix, checked by check
+// bcrypt ("$2a$", "$2b$", "$2y$") and argon2id ("$argon2id$") are registered already; use it to keep
+// hashes of other formats made elsewhere, or to replace the check of one of those
+func RegisterPasswordFormat(prefix string, check PasswordCheckFunc) {
+	passwordFormatsMu.Lock()
+	defer passwordFormatsMu.Unlock()
+	passwordFormats[prefix] = check
+}
+
+// registeredPasswordFormat returns the check of the registered format with the longest prefix of the hash
+func registeredPasswordFormat(hash string) (PasswordCheckFunc, bool) {
+	passwordFormatsMu.RLock()
+	defer passwordFormatsMu.RUnlock()
+	var match string
+	var check PasswordCheckFunc
+	for prefix, fn := range passwordFormats {
+		if strings.HasPrefix(hash, prefix) && len(prefix) > len(match) {
+			match, check = prefix, fn
+		}
+	}
+	return check, check != nil
+}
+
+// passwordFormatError returns ErrInvalidPasswordHash, naming the format of "$scheme$..." hashes no check is registered for
+func passwordFormatError(hash string) error {
+	scheme, ok := strings.CutPrefix(hash, "$")
+	if !ok {
+		return ErrInvalidPasswordHash
+	}
+	scheme, _, _ = strings.Cut(scheme, "$")
+	return fmt.Errorf(`%w: no format is registered for "$%s$" hashes, see RegisterPasswordFormat`, ErrInvalidPasswordHash, scheme)
+}
+
+// IsPasswordHash reports whether the value is a hash CheckPassword can check, rather than a plain password
+func IsPasswordHash(value string) bool {
+	if strings.HasPrefix(value, passwordHashScheme+"$") {
+		return true
+	}
+	_, ok := registeredPasswordFormat(value)
+	return ok
+}
+
+// HashPassword hashes a password with PBKDF2-SHA256 and a random salt
+// The result is self-describing ("pbkdf2-sha256$iterations$salt$hash"), so it can be stored as is
+func HashPassword(password string) (string, error) {
+	salt := make([]byte, passwordSaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return "", fmt.Errorf("failed to generate salt: %w", err)
+	}
+	key, err := pbkdf2.Key(sha256.New, password, salt, passwordHashIterations, passwordHashLength)
+	if err != nil {
+		return "", fmt.Errorf("failed to hash password: %w", err)
+	}
+	return strings.Join([]string{
+		passwordHashScheme,
+		strconv.Itoa(passwordHashIterations),
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key),
+	}, "$"), nil
+}
+
+// CheckPassword reports whether the password matches a hash made by HashPassword or of a registered format
+func CheckPassword(hash, password string) (bool, error) {
+	if check, ok := registeredPasswordFormat(hash); ok {
+		return check(hash, password)
+	}
+	parts := strings.Split(hash, "$")
+	if len(parts) != 4 || parts[0] != passwordHashScheme {
+		return false, passwordFormatError(hash)
+	}
+	iterations, err := strconv.Atoi(parts[1])
+	if err != nil || iterations <= 0 {
+		return false, ErrInvalidPasswordHash
+	}
+	salt, err := base64.RawStdEncoding.DecodeString(parts[2])
+	if err != nil {
+		return false, ErrInvalidPasswordHash
+	}
+	want, err := base64.RawStdEncoding.DecodeString(parts[3])
+	if err != nil || len(want) == 0 {
+		return false, ErrInvalidPasswordHash
+	}
+
+	got, err := pbkdf2.Key(sha256.New, password, salt, iterations, len(want))
+	if err != nil {
+		return false, fmt.Errorf("failed to hash password: %w", err)
+	}
+	return subtle.ConstantTimeCompare(got, want) == 1, nil
+}
+
+// checkBcryptPassword checks a password against a bcrypt hash
+func checkBcryptPassword(hash, password string) (bool, error) {
+	err := bcrypt.CompareHashAndPassword([]byte(hash), []byte(password))
+	if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("%w: %v", ErrInvalidPasswordHash, err)
+	}
+	return true, nil
+}
+
+// checkArgon2idPassword checks a password against an argon2id hash in the PHC format
+// ("$argon2id$v=19$m=65536,t=3,p=4$salt$hash", salt and hash in unpadded base64)
+func checkArgon2idPassword(hash, password string) (bool, error) {
+	parts := strings.Split(hash, "$")
+	if len(parts) != 6 || parts[1] != "argon2id" {
+		return false, ErrInvalidPasswordHash
+	}
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil || version != argon2.Version {
+		return false, ErrInvalidPasswordHash
+	}
+	var memory, time uint32
+	var threads uint8
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &memory, &time, &threads); err != nil || time == 0 || threads == 0 {
+		return false, ErrInvalidPasswordHash
+	}
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return false, ErrInvalidPasswordHash
+	}
+	want, err := base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil || len(want) == 0 {
+		return false, ErrInvalidPasswordHash
+	}
+
+	got := argon2.IDKey([]byte(password), salt, time, memory, threads, uint32(len(want)))
+	return subtle.ConstantTimeCompare(got, want) == 1, nil
+}
@@ -0,0 +1,90 @@
+package auth
+
+import (
+	"errors"
+	"net/http"
+	"net/netip"
+	"strings"
+)
+
+var (
+	// ErrUntrustedProxy is returned for requests carrying identity headers that don't come from a trusted proxy
+	ErrUntrustedProxy = errors.New("request did not come from a trusted proxy")
+	// ErrNoProxyUser is returned for requests from the proxy without a user header
+	ErrNoProxyUser = errors.New("proxy did not identify a user")
+)
+
+// ProxyHeaderConfig signs users in from headers set by an authenticating reverse proxy, such as
+// oauth2-proxy or Pomerium, instead of the login form
+// The headers are only believed from the trusted proxies, as anyone else could send them
+type ProxyHeaderConfig struct {
+	UserHeader     string         // Header naming the signed-in user, e.g. "X-Auth-User"; enables the mode when set
+	RolesHeader    string         // Header listing the user's roles separated by commas, e.g. "X-Auth-Roles"
+	EmailHeader    string         // Header with the user's email address, optional
+	TrustedProxies []netip.Prefix // Addresses the proxy connects from, defaults to loopback only
+}
+
+// Enabled reports whether users are signed in from proxy headers
+func (c ProxyHeaderConfig) Enabled() bool {
+	return c.UserHeader != ""
+}
+
+// WithProxyHeaders creates an AuthConfig trusting the user and roles headers of an authenticating proxy
+// running on the same host; set Proxy.TrustedProxies when it connects from elsewhere, and LogoutRedirect to
+// its sign-out URL, e.g. "/oauth2/sign_out"
+func WithProxyHeaders(userHeader, rolesHeader string) AuthConfig {
+	return AuthConfig{
+		Enabled:        true,
+		LoginPath:      "/login",
+		LogoutPath:     "/logout",
+		RequireAuth:    true,
+		LoginRedirect:  "/admin",
+		LogoutRedirect: "/admin",
+		Proxy: ProxyHeaderConfig{
+			UserHeader:  userHeader,
+			RolesHeader: rolesHeader,
+		},
+	}
+}
+
+// ProxyUser returns the user the trusted proxy identified in the request's headers
+func (c *AuthConfig) ProxyUser(r *http.Request) (*AuthUser, error) {
+	if !c.Proxy.isTrusted(r.RemoteAddr) {
+		return nil, ErrUntrustedProxy
+	}
+	username := strings.TrimSpace(r.Header.Get(c.Proxy.UserHeader))
+	if username == "" {
+		return nil, ErrNoProxyUser
+	}
+
+	user := &AuthUser{ID: username, Username: username}
+	if c.Proxy.EmailHeader != "" {
+		user.Email = strings.TrimSpace(r.Header.Get(c.Proxy.EmailHeader))
+	}
+	if c.Proxy.RolesHeader != "" {
+		for _, role := range strings.Split(r.Header.Get(c.Proxy.RolesHeader), ",") {
+			if role = strings.TrimSpace(role); role != "" {
+				user.Roles = append(user.Roles, role)
+			}
+		}
+	}
+	return user, nil
+}
+
+// isTrusted reports whether a connection from the remote address comes from a trusted proxy
+func (c ProxyHeaderConfig) isTrusted(remoteAddr string) bool {
+	addrPort, err := netip.ParseAddrPort(remoteAddr)
+	if err != nil {
+		return false
+	}
+	addr := addrPort.Addr().Unmap()
+	if len(c.TrustedProxies) == 0 {
+		return addr.IsLoopback()
+	}
+	for _, prefix := range c.TrustedProxies {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
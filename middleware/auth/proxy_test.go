@@ -0,0 +1,69 @@
+package auth
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"slices"
+	"testing"
+)
+
+func TestWithProxyHeaders(t *testing.T) {
+	config := WithProxyHeaders("X-Auth-User", "X-Auth-Roles")
+	config.Proxy.EmailHeader = "X-Auth-Email"
+
+	var seen *AuthUser
+	handler := CreateAuthMiddleware(&config)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seen, _ = GetAuthUser(r.Context())
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/admin/Order", nil)
+	req.RemoteAddr = "127.0.0.1:51000"
+	req.Header.Set("X-Auth-User", "jane")
+	req.Header.Set("X-Auth-Roles", "editor, support,")
+	req.Header.Set("X-Auth-Email", "jane@example.com")
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	if w.Code != http.StatusOK || seen == nil {
+		t.Fatalf("Expected the proxy's user to be signed in, got %d", w.Code)
+	}
+	if seen.Username != "jane" || seen.Email != "jane@example.com" || !slices.Equal(seen.Roles, []string{"editor", "support"}) {
+		t.Errorf("Unexpected user from the headers: %+v", seen)
+	}
+
+	// Anyone else sending the headers is not believed
+	seen = nil
+	req = httptest.NewRequest(http.MethodGet, "/admin/Order", nil)
+	req.RemoteAddr = "203.0.113.7:51000"
+	req.Header.Set("X-Auth-User", "admin")
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	if w.Code != http.StatusUnauthorized || seen != nil {
+		t.Errorf("Expected headers from an untrusted address to be rejected, got %d", w.Code)
+	}
+
+	// Requests through the proxy without a user aren't redirected to a login form
+	req = httptest.NewRequest(http.MethodGet, "/admin/Order", nil)
+	req.RemoteAddr = "127.0.0.1:51000"
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("Expected 401 without a user header, got %d", w.Code)
+	}
+}
+
+func TestProxyHeaderConfig_TrustedProxies(t *testing.T) {
+	config := ProxyHeaderConfig{UserHeader: "X-Auth-User", TrustedProxies: []netip.Prefix{netip.MustParsePrefix("10.0.0.0/8")}}
+	tests := map[string]bool{
+		"10.1.2.3:443":         true,
+		"[::ffff:10.1.2.3]:80": true,
+		"127.0.0.1:80":         false,
+		"192.168.1.1:80":       false,
+		"not an address":       false,
+	}
+	for remoteAddr, want := range tests {
+		if got := config.isTrusted(remoteAddr); got != want {
+			t.Errorf("isTrusted(%q) = %v, want %v", remoteAddr, got, want)
+		}
+	}
+}
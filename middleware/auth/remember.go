@@ -0,0 +1,306 @@
+package auth
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	DefaultRememberCookieName = "backoffice_remember" // Remember-me cookie's name when RememberMeConfig.CookieName is unset
+	DefaultRememberTTL        = 30 * 24 * time.Hour   // How long "keep me signed in" lasts when RememberMeConfig.TTL is unset
+)
+
+var (
+	// ErrRememberTokenNotFound is returned for unknown, used or revoked remember-me tokens
+	ErrRememberTokenNotFound = errors.New("remember-me token not found")
+	// ErrRememberTokenExpired is returned for remember-me tokens past their lifetime
+	ErrRememberTokenExpired = errors.New("remember-me token expired")
+)
+
+// RememberMeConfig enables the "keep me signed in" option of the login form
+// A remembered browser gets a long-lived token besides its short session; once the session
+// expires the token signs it in again and is replaced by a new one, so each token works once
+type RememberMeConfig struct {
+	Store      RememberTokenStore // Enables remember-me when set
+	TTL        time.Duration      // Lifetime of each token, defaults to DefaultRememberTTL
+	CookieName string             // Defaults to DefaultRememberCookieName; other attributes follow AuthConfig.Cookie
+}
+
+// RememberTokenStore keeps remember-me tokens
+type RememberTokenStore interface {
+	// CreateToken issues a token signing the user in until it expires
+	CreateToken(ctx context.Context, user *AuthUser, expiresAt time.Time) (string, error)
+
+	// ConsumeToken returns the token's user and revokes the token, so it can't be replayed
+	ConsumeToken(ctx context.Context, token string) (*AuthUser, error)
+
+	// RevokeToken revokes a single token, e.g. on logout
+	RevokeToken(ctx context.Context, token string) error
+
+	// RevokeUserTokens revokes every token of the user, e.g. after a password change
+	RevokeUserTokens(ctx context.Context, username string) error
+}
+
+// SessionRememberTokenStore is a RememberTokenStore that knows the session each token signed in, so
+// signing a session out from the "My sessions" page revokes its token too
+type SessionRememberTokenStore interface {
+	RememberTokenStore
+
+	// LinkSession records the handle of the session the token signed in, see SessionHandle
+	LinkSession(ctx context.Context, token, sessionHandle string) error
+
+	// RevokeSessionTokens revokes the user's tokens that signed in the session with the handle
+	RevokeSessionTokens(ctx context.Context, username, sessionHandle string) error
+}
+
+// RememberMeEnabled reports whether the login form offers to keep users signed in
+func (c *AuthConfig) RememberMeEnabled() bool {
+	return c != nil && c.RememberMe.Store != nil
+}
+
+// RememberCookieName returns the name of the remember-me cookie
+func (c *AuthConfig) RememberCookieName() string {
+	if c == nil || c.RememberMe.CookieName == "" {
+		return DefaultRememberCookieName
+	}
+	return c.RememberMe.CookieName
+}
+
+// rememberTTL returns the lifetime of remember-me tokens
+func (c *AuthConfig) rememberTTL() time.Duration {
+	if c.RememberMe.TTL <= 0 {
+		return DefaultRememberTTL
+	}
+	return c.RememberMe.TTL
+}
+
+// Remember issues a remember-me token for the user signed in with the session and sets its cookie
+func (c *AuthConfig) Remember(w http.ResponseWriter, r *http.Request, user *AuthUser, sessionID string) error {
+	if !c.RememberMeEnabled() {
+		return nil
+	}
+	ttl := c.rememberTTL()
+	token, err := c.RememberMe.Store.CreateToken(r.Context(), user, time.Now().Add(ttl))
+	if err != nil {
+		return err
+	}
+	if store, ok := c.RememberMe.Store.(SessionRememberTokenStore); ok && sessionID != "" {
+		if err := store.LinkSession(r.Context(), token, SessionHandle(sessionID)); err != nil {
+			return err
+		}
+	}
+
+	cookie := c.baseCookie(r)
+	cookie.Name = c.RememberCookieName()
+	cookie.Value = token
+	cookie.MaxAge = int(ttl.Seconds())
+	cookie.Expires = time.Now().Add(ttl)
+	http.SetCookie(w, cookie)
+	return nil
+}
+
+// Forget revokes the request's remember-me token and deletes its cookie
+func (c *AuthConfig) Forget(w http.ResponseWriter, r *http.Request) {
+	if !c.RememberMeEnabled() {
+		return
+	}
+	if cookie, err := r.Cookie(c.RememberCookieName()); err == nil {
+		c.RememberMe.Store.RevokeToken(r.Context(), cookie.Value)
+	}
+
+	cookie := c.baseCookie(r)
+	cookie.Name = c.RememberCookieName()
+	cookie.MaxAge = -1
+	http.SetCookie(w, cookie)
+}
+
+// resumeRememberedSession signs a remembered browser in again with a new session and a rotated token
+func resumeRememberedSession(w http.ResponseWriter, r *http.Request, authConfig *AuthConfig) (*AuthUser, error) {
+	if !authConfig.RememberMeEnabled() {
+		return nil, ErrRememberTokenNotFound
+	}
+	cookie, err := r.Cookie(authConfig.RememberCookieName())
+	if err != nil {
+		return nil, err
+	}
+
+	ctx := r.Context()
+	user, err := authConfig.RememberMe.Store.ConsumeToken(ctx, cookie.Value)
+	if errors.Is(err, ErrRememberTokenNotFound) {
+		// Likely a parallel request of the same browser rotated the token first: keep the cookie,
+		// so this response doesn't delete the new token set by the other one
+		return nil, err
+	}
+	if err != nil {
+		authConfig.Forget(w, r)
+		return nil, err
+	}
+	// Pick up role changes, and drop tokens of users removed or disabled since
+	if authConfig.Users != nil {
+		if user, err = authConfig.Users.GetUserByUsername(ctx, user.Username); err != nil {
+			authConfig.Forget(w, r)
+			return nil, err
+		}
+	}
+
+	sessionID, err := authConfig.SessionStore.CreateSession(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+	http.SetCookie(w, authConfig.SessionCookie(r, sessionID))
+	if err := authConfig.Remember(w, r, user, sessionID); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// RevokeSessionRemembering revokes the remember-me tokens that signed in the user's session with the handle
+// It's a no-op when the token store doesn't link tokens to sessions
+func (c *AuthConfig) RevokeSessionRemembering(ctx context.Context, username, sessionHandle string) error {
+	if !c.RememberMeEnabled() {
+		return nil
+	}
+	store, ok := c.RememberMe.Store.(SessionRememberTokenStore)
+	if !ok {
+		return nil
+	}
+	return store.RevokeSessionTokens(ctx, username, sessionHandle)
+}
+
+// RevokeOtherRemembering revokes the remember-me tokens of the user's other browsers when signing them out
+// Stores that link tokens to sessions already had them revoked with each session; otherwise every token
+// of the user goes and the current browser, if it was remembered, gets a fresh one
+func (c *AuthConfig) RevokeOtherRemembering(w http.ResponseWriter, r *http.Request, user *AuthUser, sessionID string) error {
+	if !c.RememberMeEnabled() {
+		return nil
+	}
+	if _, ok := c.RememberMe.Store.(SessionRememberTokenStore); ok {
+		return nil
+	}
+	if err := c.RememberMe.Store.RevokeUserTokens(r.Context(), user.SessionOwner()); err != nil {
+		return err
+	}
+	if _, err := r.Cookie(c.RememberCookieName()); err != nil || user.IsImpersonated() {
+		return nil
+	}
+	return c.Remember(w, r, user, sessionID)
+}
+
+// rememberedToken is a remember-me token of a MemoryRememberTokenStore
+type rememberedToken struct {
+	user      AuthUser
+	expiresAt time.Time
+	session   string // Handle of the session the token signed in
+}
+
+// MemoryRememberTokenStore implements RememberTokenStore and SessionRememberTokenStore in memory
+// Tokens are kept as SHA-256 hashes, and are lost when the process restarts
+type MemoryRememberTokenStore struct {
+	tokens map[string]rememberedToken
+	mutex  sync.Mutex
+}
+
+// NewMemoryRememberTokenStore creates an empty in-memory remember-me token store
+func NewMemoryRememberTokenStore() *MemoryRememberTokenStore {
+	return &MemoryRememberTokenStore{tokens: make(map[string]rememberedToken)}
+}
+
+// CreateToken issues a token signing the user in until it expires
+func (m *MemoryRememberTokenStore) CreateToken(ctx context.Context, user *AuthUser, expiresAt time.Time) (string, error) {
+	token, err := generateSessionID()
+	if err != nil {
+		return "", err
+	}
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	// Drop expired tokens while we're here, so abandoned browsers don't pile up
+	now := time.Now()
+	for key, stored := range m.tokens {
+		if now.After(stored.expiresAt) {
+			delete(m.tokens, key)
+		}
+	}
+	m.tokens[hashRememberToken(token)] = rememberedToken{user: *user, expiresAt: expiresAt}
+	return token, nil
+}
+
+// ConsumeToken returns the token's user and revokes the token
+func (m *MemoryRememberTokenStore) ConsumeToken(ctx context.Context, token string) (*AuthUser, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	key := hashRememberToken(token)
+	stored, exists := m.tokens[key]
+	if !exists {
+		return nil, ErrRememberTokenNotFound
+	}
+	delete(m.tokens, key)
+	if time.Now().After(stored.expiresAt) {
+		return nil, ErrRememberTokenExpired
+	}
+	user := stored.user
+	return &user, nil
+}
+
+// RevokeToken revokes a single token
+func (m *MemoryRememberTokenStore) RevokeToken(ctx context.Context, token string) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	delete(m.tokens, hashRememberToken(token))
+	return nil
+}
+
+// RevokeUserTokens revokes every token of the user
+func (m *MemoryRememberTokenStore) RevokeUserTokens(ctx context.Context, username string) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	for key, stored := range m.tokens {
+		if stored.user.Username == username {
+			delete(m.tokens, key)
+		}
+	}
+	return nil
+}
+
+// LinkSession records the handle of the session the token signed in
+func (m *MemoryRememberTokenStore) LinkSession(ctx context.Context, token, sessionHandle string) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	key := hashRememberToken(token)
+	stored, exists := m.tokens[key]
+	if !exists {
+		return ErrRememberTokenNotFound
+	}
+	stored.session = sessionHandle
+	m.tokens[key] = stored
+	return nil
+}
+
+// RevokeSessionTokens revokes the user's tokens that signed in the session with the handle
+func (m *MemoryRememberTokenStore) RevokeSessionTokens(ctx context.Context, username, sessionHandle string) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	for key, stored := range m.tokens {
+		if stored.user.Username == username && stored.session == sessionHandle {
+			delete(m.tokens, key)
+		}
+	}
+	return nil
+}
+
+// hashRememberToken is how tokens are stored, so a leaked store can't sign anyone in
+func hashRememberToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
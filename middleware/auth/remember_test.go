@@ -0,0 +1,188 @@
+package auth
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestMemoryRememberTokenStore(t *testing.T) {
+	store := NewMemoryRememberTokenStore()
+	ctx := context.Background()
+	user := &AuthUser{ID: "1", Username: "alice", Roles: []string{"admin"}}
+
+	token, err := store.CreateToken(ctx, user, time.Now().Add(time.Hour))
+	if err != nil {
+		t.Fatalf("Failed to create token: %v", err)
+	}
+
+	remembered, err := store.ConsumeToken(ctx, token)
+	if err != nil {
+		t.Fatalf("Failed to consume token: %v", err)
+	}
+	if remembered.Username != "alice" || !remembered.HasRole("admin") {
+		t.Errorf("Expected alice with the admin role, got %+v", remembered)
+	}
+
+	// Tokens work once
+	if _, err := store.ConsumeToken(ctx, token); err != ErrRememberTokenNotFound {
+		t.Errorf("Expected ErrRememberTokenNotFound on reuse, got %v", err)
+	}
+
+	expired, _ := store.CreateToken(ctx, user, time.Now().Add(-time.Minute))
+	if _, err := store.ConsumeToken(ctx, expired); err != ErrRememberTokenExpired {
+		t.Errorf("Expected ErrRememberTokenExpired, got %v", err)
+	}
+
+	first, _ := store.CreateToken(ctx, user, time.Now().Add(time.Hour))
+	second, _ := store.CreateToken(ctx, user, time.Now().Add(time.Hour))
+	other, _ := store.CreateToken(ctx, &AuthUser{Username: "bob"}, time.Now().Add(time.Hour))
+	store.RevokeUserTokens(ctx, "alice")
+	for _, revoked := range []string{first, second} {
+		if _, err := store.ConsumeToken(ctx, revoked); err != ErrRememberTokenNotFound {
+			t.Errorf("Expected alice's tokens to be revoked, got %v", err)
+		}
+	}
+	if _, err := store.ConsumeToken(ctx, other); err != nil {
+		t.Errorf("Expected bob's token to survive, got %v", err)
+	}
+}
+
+func TestMiddleware_ResumesRememberedSession(t *testing.T) {
+	config := &AuthConfig{
+		Enabled:      true,
+		RequireAuth:  true,
+		LoginPath:    "/login",
+		SessionStore: NewMemorySessionStore(),
+		RememberMe:   RememberMeConfig{Store: NewMemoryRememberTokenStore()},
+	}
+
+	login := httptest.NewRecorder()
+	if err := config.Remember(login, httptest.NewRequest("POST", "/admin/login", nil), &AuthUser{Username: "alice"}, ""); err != nil {
+		t.Fatalf("Failed to remember user: %v", err)
+	}
+	token := findCookie(login.Result().Cookies(), DefaultRememberCookieName)
+	if token == nil || token.MaxAge != int(DefaultRememberTTL.Seconds()) {
+		t.Fatalf("Expected a remember-me cookie lasting %v, got %+v", DefaultRememberTTL, token)
+	}
+
+	var signedIn *AuthUser
+	handler := CreateAuthMiddleware(config)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		signedIn, _ = GetAuthUser(r.Context())
+	}))
+
+	// No session, but a remember-me token: signed in with a new session and a rotated token
+	req := httptest.NewRequest("GET", "/admin/", nil)
+	req.AddCookie(token)
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	if signedIn == nil || signedIn.Username != "alice" {
+		t.Fatalf("Expected alice to be signed in, got %+v (status %d)", signedIn, rec.Code)
+	}
+	cookies := rec.Result().Cookies()
+	if findCookie(cookies, DefaultSessionCookieName) == nil {
+		t.Error("Expected a new session cookie")
+	}
+	rotated := findCookie(cookies, DefaultRememberCookieName)
+	if rotated == nil || rotated.Value == token.Value {
+		t.Errorf("Expected the remember-me token to be rotated, got %+v", rotated)
+	}
+
+	// The old token was used up
+	signedIn = nil
+	req = httptest.NewRequest("GET", "/admin/", nil)
+	req.AddCookie(token)
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	if signedIn != nil || rec.Code != http.StatusSeeOther {
+		t.Errorf("Expected a replayed token to redirect to login, got %d", rec.Code)
+	}
+	if findCookie(rec.Result().Cookies(), DefaultRememberCookieName) != nil {
+		t.Error("Expected a used token to leave the cookie alone, as a parallel request may have just rotated it")
+	}
+
+	// Forgetting revokes the current token
+	req = httptest.NewRequest("POST", "/admin/logout", nil)
+	req.AddCookie(rotated)
+	config.Forget(httptest.NewRecorder(), req)
+	if _, err := config.RememberMe.Store.ConsumeToken(context.Background(), rotated.Value); err != ErrRememberTokenNotFound {
+		t.Errorf("Expected the token to be revoked, got %v", err)
+	}
+}
+
+// disabledUsers is a user store whose users have all been disabled
+type disabledUsers struct {
+	*MemoryUserStore
+}
+
+func (disabledUsers) GetUserByUsername(ctx context.Context, username string) (*AuthUser, error) {
+	return nil, ErrUserDisabled
+}
+
+func TestMiddleware_RememberedSessionOfDisabledUser(t *testing.T) {
+	config := &AuthConfig{
+		Enabled:      true,
+		RequireAuth:  true,
+		LoginPath:    "/login",
+		Users:        disabledUsers{NewMemoryUserStore()},
+		SessionStore: NewMemorySessionStore(),
+		RememberMe:   RememberMeConfig{Store: NewMemoryRememberTokenStore()},
+	}
+	login := httptest.NewRecorder()
+	config.Remember(login, httptest.NewRequest("POST", "/admin/login", nil), &AuthUser{Username: "alice"}, "")
+	token := findCookie(login.Result().Cookies(), DefaultRememberCookieName)
+
+	signedIn := false
+	handler := CreateAuthMiddleware(config)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		signedIn = true
+	}))
+	req := httptest.NewRequest("GET", "/admin/", nil)
+	req.AddCookie(token)
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	if signedIn || rec.Code != http.StatusSeeOther {
+		t.Errorf("Expected the disabled user to be sent to login, got %d", rec.Code)
+	}
+	if cleared := findCookie(rec.Result().Cookies(), DefaultRememberCookieName); cleared == nil || cleared.MaxAge >= 0 {
+		t.Errorf("Expected the disabled user's remember-me cookie to be deleted, got %+v", cleared)
+	}
+}
+
+func findCookie(cookies []*http.Cookie, name string) *http.Cookie {
+	for _, cookie := range cookies {
+		if cookie.Name == name {
+			return cookie
+		}
+	}
+	return nil
+}
+
+// unlinkedRememberTokens hides the session linking of the memory store
+type unlinkedRememberTokens struct{ RememberTokenStore }
+
+func TestRevokeOtherRemembering_WithoutSessionLinks(t *testing.T) {
+	tokens := NewMemoryRememberTokenStore()
+	config := &AuthConfig{Enabled: true, RememberMe: RememberMeConfig{Store: unlinkedRememberTokens{tokens}}}
+	ctx := context.Background()
+	alice := &AuthUser{Username: "alice"}
+	other, _ := tokens.CreateToken(ctx, alice, time.Now().Add(time.Hour))
+
+	req := httptest.NewRequest("POST", "/admin/sessions", nil)
+	req.AddCookie(&http.Cookie{Name: DefaultRememberCookieName, Value: "current"})
+	w := httptest.NewRecorder()
+	if err := config.RevokeOtherRemembering(w, req, alice, "session"); err != nil {
+		t.Fatalf("Failed to revoke the other tokens: %v", err)
+	}
+	if _, err := tokens.ConsumeToken(ctx, other); err != ErrRememberTokenNotFound {
+		t.Errorf("Expected the other browser's token to be revoked, got %v", err)
+	}
+	fresh := findCookie(w.Result().Cookies(), DefaultRememberCookieName)
+	if fresh == nil {
+		t.Fatal("Expected the current browser to get a fresh token")
+	}
+	if _, err := tokens.ConsumeToken(ctx, fresh.Value); err != nil {
+		t.Errorf("Expected the fresh token to sign in, got %v", err)
+	}
+}
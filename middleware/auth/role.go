@@ -0,0 +1,20 @@
+package auth
+
+// Role names a set of permissions granted to users, e.g. "admin" or "editor"
+// Users carry their role names in AuthUser.Roles, filled by the user store or identity provider
+type Role string
+
+// HasRole reports whether the user has any of the roles
+func (u *AuthUser) HasRole(roles ...Role) bool {
+	if u == nil {
+		return false
+	}
+	for _, userRole := range u.Roles {
+		for _, role := range roles {
+			if userRole == string(role) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -5,6 +5,7 @@ import (
 	"crypto/rand"
 	"encoding/hex"
 	"errors"
+	"sort"
 	"sync"
 	"time"
 )
@@ -21,6 +22,8 @@ type SessionData struct {
 	User      *AuthUser
 	CreatedAt time.Time
 	ExpiresAt time.Time
+	LastSeen  time.Time
+	Activity  SessionActivity // Browser of the last request
 }
 
 // IsExpired checks if the session has expired
@@ -99,6 +102,7 @@ func (m *MemorySessionStore) CreateSession(ctx context.Context, user *AuthUser)
 		User:      user,
 		CreatedAt: time.Now(),
 		ExpiresAt: time.Now().Add(m.SessionTimeout),
+		LastSeen:  time.Now(),
 	}
 
 	m.mutex.Lock()
@@ -108,6 +112,37 @@ func (m *MemorySessionStore) CreateSession(ctx context.Context, user *AuthUser)
 	return sessionID, nil
 }
 
+// SessionExpiry returns when the session expires
+func (m *MemorySessionStore) SessionExpiry(ctx context.Context, sessionID string) (time.Time, error) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	sessionData, exists := m.sessions[sessionID]
+	if !exists {
+		return time.Time{}, ErrSessionNotFound
+	}
+	if sessionData.IsExpired() {
+		return time.Time{}, ErrSessionExpired
+	}
+	return sessionData.ExpiresAt, nil
+}
+
+// RefreshSession extends an unexpired session by the store's timeout and returns the new expiry
+func (m *MemorySessionStore) RefreshSession(ctx context.Context, sessionID string) (time.Time, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	sessionData, exists := m.sessions[sessionID]
+	if !exists {
+		return time.Time{}, ErrSessionNotFound
+	}
+	if sessionData.IsExpired() {
+		return time.Time{}, ErrSessionExpired
+	}
+	sessionData.ExpiresAt = time.Now().Add(m.SessionTimeout)
+	return sessionData.ExpiresAt, nil
+}
+
 // DeleteSession removes a session by session ID
 func (m *MemorySessionStore) DeleteSession(ctx context.Context, sessionID string) error {
 	m.mutex.Lock()
@@ -117,6 +152,72 @@ func (m *MemorySessionStore) DeleteSession(ctx context.Context, sessionID string
 	return nil
 }
 
+// TouchSession records a request of the session
+func (m *MemorySessionStore) TouchSession(ctx context.Context, sessionID string, activity SessionActivity) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	sessionData, exists := m.sessions[sessionID]
+	if !exists {
+		return ErrSessionNotFound
+	}
+	sessionData.LastSeen = time.Now()
+	sessionData.Activity = activity
+	return nil
+}
+
+// ListUserSessions returns the unexpired sessions of the user, most recently used first
+func (m *MemorySessionStore) ListUserSessions(ctx context.Context, username string) ([]SessionInfo, error) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	var sessions []SessionInfo
+	for sessionID, sessionData := range m.sessions {
+		if sessionData.IsExpired() || sessionData.User.SessionOwner() != username {
+			continue
+		}
+		sessions = append(sessions, SessionInfo{
+			Handle:    SessionHandle(sessionID),
+			IP:        sessionData.Activity.IP,
+			UserAgent: sessionData.Activity.UserAgent,
+			CreatedAt: sessionData.CreatedAt,
+			LastSeen:  sessionData.LastSeen,
+			ExpiresAt: sessionData.ExpiresAt,
+		})
+	}
+	sort.Slice(sessions, func(i, j int) bool {
+		return sessions[i].LastSeen.After(sessions[j].LastSeen)
+	})
+	return sessions, nil
+}
+
+// RevokeUserSession ends the user's session with the handle
+func (m *MemorySessionStore) RevokeUserSession(ctx context.Context, username, handle string) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	for sessionID, sessionData := range m.sessions {
+		if SessionHandle(sessionID) == handle && sessionData.User.SessionOwner() == username {
+			delete(m.sessions, sessionID)
+			return nil
+		}
+	}
+	return ErrSessionNotFound
+}
+
+// RevokeUserSessions ends every session of the user
+func (m *MemorySessionStore) RevokeUserSessions(ctx context.Context, username string) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	for sessionID, sessionData := range m.sessions {
+		if sessionData.User.SessionOwner() == username {
+			delete(m.sessions, sessionID)
+		}
+	}
+	return nil
+}
+
 // CleanExpiredSessions removes expired sessions
 func (m *MemorySessionStore) CleanExpiredSessions(ctx context.Context) error {
 	m.mutex.Lock()
@@ -2,6 +2,7 @@ package auth
 
 import (
 	"context"
+	"errors"
 	"testing"
 	"time"
 )
@@ -140,3 +141,82 @@ func TestMemorySessionStoreCleanup(t *testing.T) {
 		t.Error("Expected both sessions to be not found after cleanup")
 	}
 }
+
+func TestMemorySessionStoreRefresh(t *testing.T) {
+	timeout := 200 * time.Millisecond
+	store := NewMemorySessionStoreWithTimeout(timeout)
+	ctx := context.Background()
+
+	sessionID, err := store.CreateSession(ctx, &AuthUser{ID: "refresh", Username: "refresher"})
+	if err != nil {
+		t.Fatalf("Failed to create session: %v", err)
+	}
+	expiry, err := store.SessionExpiry(ctx, sessionID)
+	if err != nil {
+		t.Fatalf("Expected the session expiry, got %v", err)
+	}
+
+	time.Sleep(100 * time.Millisecond)
+	refreshed, err := store.RefreshSession(ctx, sessionID)
+	if err != nil {
+		t.Fatalf("Failed to refresh session: %v", err)
+	}
+	if !refreshed.After(expiry) {
+		t.Errorf("Expected the refreshed expiry %v to be after %v", refreshed, expiry)
+	}
+
+	// The original timeout has passed, the refreshed session lives on
+	time.Sleep(150 * time.Millisecond)
+	if _, err := store.GetSession(ctx, sessionID); err != nil {
+		t.Errorf("Expected the refreshed session to be valid, got %v", err)
+	}
+
+	if _, err := store.RefreshSession(ctx, "missing"); err != ErrSessionNotFound {
+		t.Errorf("Expected ErrSessionNotFound, got %v", err)
+	}
+}
+
+func TestMemorySessionStore_ManageSessions(t *testing.T) {
+	store := NewMemorySessionStore()
+	ctx := context.Background()
+
+	admin := &AuthUser{Username: "admin"}
+	laptop, _ := store.CreateSession(ctx, admin)
+	phone, _ := store.CreateSession(ctx, admin)
+	impersonating, _ := store.CreateSession(ctx, &AuthUser{Username: "jane", Impersonator: admin})
+	other, _ := store.CreateSession(ctx, &AuthUser{Username: "jane"})
+
+	store.TouchSession(ctx, phone, SessionActivity{IP: "198.51.100.4", UserAgent: "Mobile Safari"})
+	sessions, err := store.ListUserSessions(ctx, "admin")
+	if err != nil {
+		t.Fatalf("ListUserSessions failed: %v", err)
+	}
+	if len(sessions) != 3 {
+		t.Fatalf("Expected the admin's 3 sessions, including the impersonation, got %d", len(sessions))
+	}
+	if sessions[0].Handle != SessionHandle(phone) || sessions[0].IP != "198.51.100.4" {
+		t.Errorf("Expected the most recently used session first, got %+v", sessions[0])
+	}
+
+	if err := store.RevokeUserSession(ctx, "jane", SessionHandle(laptop)); !errors.Is(err, ErrSessionNotFound) {
+		t.Errorf("Expected revoking another user's session to fail, got %v", err)
+	}
+	if err := store.RevokeUserSession(ctx, "admin", SessionHandle(laptop)); err != nil {
+		t.Fatalf("RevokeUserSession failed: %v", err)
+	}
+	if _, err := store.GetSession(ctx, laptop); !errors.Is(err, ErrSessionNotFound) {
+		t.Errorf("Expected the revoked session to be gone, got %v", err)
+	}
+
+	if err := store.RevokeUserSessions(ctx, "admin"); err != nil {
+		t.Fatalf("RevokeUserSessions failed: %v", err)
+	}
+	for _, sessionID := range []string{phone, impersonating} {
+		if _, err := store.GetSession(ctx, sessionID); err == nil {
+			t.Error("Expected every session of the admin to be revoked")
+		}
+	}
+	if _, err := store.GetSession(ctx, other); err != nil {
+		t.Errorf("Expected other users' sessions to stay, got %v", err)
+	}
+}
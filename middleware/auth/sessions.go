@@ -0,0 +1,104 @@
+package auth
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net"
+	"net/http"
+	"time"
+)
+
+// ErrSessionsNotManaged is returned when the session store can't list or revoke the sessions of a user
+var ErrSessionsNotManaged = errors.New("session store does not support managing sessions")
+
+// SessionActivity describes the browser using a session
+type SessionActivity struct {
+	IP        string
+	UserAgent string
+}
+
+// SessionInfo describes an active session on the session management page
+type SessionInfo struct {
+	Handle    string    // Identifies the session without revealing its ID, see SessionHandle
+	IP        string    // Address of the last request
+	UserAgent string    // Browser of the last request
+	CreatedAt time.Time // When the user signed in
+	LastSeen  time.Time // When the session was last used
+	ExpiresAt time.Time
+}
+
+// ManagedSessionStore is a SessionStore that tracks where sessions are used, and can list and revoke
+// the sessions of a user. Stores implementing it get the "My sessions" page
+// Sessions belong to the user really signed in, the admin while impersonating
+type ManagedSessionStore interface {
+	SessionStore
+
+	// TouchSession records a request of the session
+	TouchSession(ctx context.Context, sessionID string, activity SessionActivity) error
+
+	// ListUserSessions returns the unexpired sessions of the user, most recently used first
+	ListUserSessions(ctx context.Context, username string) ([]SessionInfo, error)
+
+	// RevokeUserSession ends the user's session with the handle, ErrSessionNotFound when the user has none
+	RevokeUserSession(ctx context.Context, username, handle string) error
+
+	// RevokeUserSessions ends every session of the user
+	RevokeUserSessions(ctx context.Context, username string) error
+}
+
+// SessionHandle returns the public handle of a session, safe to put in pages and forms
+func SessionHandle(sessionID string) string {
+	sum := sha256.Sum256([]byte(sessionID))
+	return hex.EncodeToString(sum[:16])
+}
+
+// SessionOwner returns the username the user's session belongs to: the admin's while impersonating
+func (u *AuthUser) SessionOwner() string {
+	if u.IsImpersonated() {
+		return u.Impersonator.Username
+	}
+	return u.Username
+}
+
+// SessionsManaged reports whether users can list and revoke their sessions
+func (c *AuthConfig) SessionsManaged() bool {
+	if c == nil || !c.Enabled {
+		return false
+	}
+	_, ok := c.SessionStore.(ManagedSessionStore)
+	return ok
+}
+
+// RevokeAllSessions signs the user out everywhere, revoking their remember-me tokens and ending their sessions
+// It returns ErrSessionsNotManaged, after revoking the tokens, when the session store can't end sessions
+func (c *AuthConfig) RevokeAllSessions(ctx context.Context, username string) error {
+	if c.RememberMeEnabled() {
+		if err := c.RememberMe.Store.RevokeUserTokens(ctx, username); err != nil {
+			return err
+		}
+	}
+	store, ok := c.SessionStore.(ManagedSessionStore)
+	if !ok {
+		return ErrSessionsNotManaged
+	}
+	return store.RevokeUserSessions(ctx, username)
+}
+
+// touchSession records the request in its session when the store tracks sessions
+func touchSession(r *http.Request, authConfig *AuthConfig) {
+	store, ok := authConfig.SessionStore.(ManagedSessionStore)
+	if !ok {
+		return
+	}
+	sessionID, err := authConfig.SessionID(r)
+	if err != nil {
+		return
+	}
+	ip, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		ip = r.RemoteAddr
+	}
+	store.TouchSession(r.Context(), sessionID, SessionActivity{IP: ip, UserAgent: r.UserAgent()})
+}
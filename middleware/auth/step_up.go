@@ -0,0 +1,141 @@
+package auth
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha1"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// StepUpMethod is how users confirm their identity again before a destructive operation
+type StepUpMethod string
+
+const (
+	StepUpNone     StepUpMethod = ""         // No confirmation beyond the usual dialog
+	StepUpPassword StepUpMethod = "password" // Re-entering the password
+	StepUpTOTP     StepUpMethod = "totp"     // A code from the user's authenticator app, see AuthConfig.TOTPSecret
+)
+
+var (
+	// ErrStepUpFailed is returned when the re-entered password or code is wrong
+	ErrStepUpFailed = errors.New("step-up verification failed")
+	// ErrStepUpUnavailable is returned when the method can't be verified for the user, e.g. TOTP without a secret
+	ErrStepUpUnavailable = errors.New("step-up verification unavailable")
+)
+
+// TOTPSecretFunc returns the base32 TOTP secret of a user, as shown to authenticator apps when enrolling,
+// or an empty string when the user has none
+type TOTPSecretFunc func(ctx context.Context, user *AuthUser) (string, error)
+
+const (
+	totpPeriod = 30 * time.Second
+	totpDigits = 6
+	totpSkew   = 1 // Steps accepted before and after the current one, for clock drift
+)
+
+// VerifyStepUp checks the password or code a user re-entered to confirm an operation
+// While impersonating, the admin really signed in confirms with their own credentials
+func (c *AuthConfig) VerifyStepUp(ctx context.Context, user *AuthUser, method StepUpMethod, credential string) error {
+	if method == StepUpNone {
+		return nil
+	}
+	if c == nil || !c.Enabled || user == nil {
+		return ErrStepUpUnavailable
+	}
+	if user.IsImpersonated() {
+		user = user.Impersonator
+	}
+	if credential == "" {
+		return ErrStepUpFailed
+	}
+
+	switch method {
+	case StepUpPassword:
+		if c.Users == nil && c.Authenticator == nil {
+			return ErrStepUpUnavailable
+		}
+		verified, err := c.Authenticate(ctx, user.Username, credential)
+		if err != nil || verified == nil || verified.Username != user.Username {
+			return ErrStepUpFailed
+		}
+		return nil
+	case StepUpTOTP:
+		if c.TOTPSecret == nil {
+			return ErrStepUpUnavailable
+		}
+		secret, err := c.TOTPSecret(ctx, user)
+		if err != nil {
+			return fmt.Errorf("failed to load TOTP secret: %w", err)
+		}
+		if secret == "" {
+			return ErrStepUpUnavailable
+		}
+		ok, err := VerifyTOTP(secret, credential, time.Now())
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return ErrStepUpFailed
+		}
+		return nil
+	default:
+		return fmt.Errorf("unknown step-up method %q", method)
+	}
+}
+
+// TOTPCode returns the RFC 6238 code (SHA-1, 6 digits, 30 seconds) of the base32 secret at t
+func TOTPCode(secret string, t time.Time) (string, error) {
+	key, err := decodeTOTPSecret(secret)
+	if err != nil {
+		return "", err
+	}
+	return hotp(key, uint64(t.Unix()/int64(totpPeriod/time.Second))), nil
+}
+
+// VerifyTOTP reports whether the code is valid for the base32 secret at t, allowing one step of clock drift
+func VerifyTOTP(secret, code string, t time.Time) (bool, error) {
+	key, err := decodeTOTPSecret(secret)
+	if err != nil {
+		return false, err
+	}
+	code = strings.ReplaceAll(strings.TrimSpace(code), " ", "")
+	if len(code) != totpDigits {
+		return false, nil
+	}
+	counter := t.Unix() / int64(totpPeriod/time.Second)
+	valid := false
+	for step := int64(-totpSkew); step <= totpSkew; step++ {
+		if subtle.ConstantTimeCompare([]byte(hotp(key, uint64(counter+step))), []byte(code)) == 1 {
+			valid = true
+		}
+	}
+	return valid, nil
+}
+
+// decodeTOTPSecret decodes a base32 secret, ignoring case, spaces and padding
+func decodeTOTPSecret(secret string) ([]byte, error) {
+	secret = strings.ToUpper(strings.ReplaceAll(strings.TrimSpace(secret), " ", ""))
+	key, err := base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(strings.TrimRight(secret, "="))
+	if err != nil || len(key) == 0 {
+		return nil, errors.New("invalid TOTP secret")
+	}
+	return key, nil
+}
+
+// hotp returns the RFC 4226 code of the key for the counter
+func hotp(key []byte, counter uint64) string {
+	var msg [8]byte
+	binary.BigEndian.PutUint64(msg[:], counter)
+	mac := hmac.New(sha1.New, key)
+	mac.Write(msg[:])
+	sum := mac.Sum(nil)
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+	return fmt.Sprintf("%0*d", totpDigits, value%1_000_000)
+}
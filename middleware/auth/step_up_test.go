@@ -0,0 +1,63 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestTOTPCode(t *testing.T) {
+	// RFC 6238 test secret "12345678901234567890", truncated to 6 digits
+	secret := "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+	tests := map[int64]string{59: "287082", 1111111109: "081804", 1234567890: "005924"}
+	for unix, want := range tests {
+		got, err := TOTPCode(secret, time.Unix(unix, 0))
+		if err != nil || got != want {
+			t.Errorf("TOTPCode at %d = %q, %v, want %q", unix, got, err, want)
+		}
+	}
+
+	now := time.Unix(1234567890, 0)
+	if ok, _ := VerifyTOTP(secret, "005924", now.Add(25*time.Second)); !ok {
+		t.Error("Expected the previous code to be accepted for clock drift")
+	}
+	if ok, _ := VerifyTOTP(secret, "005924", now.Add(2*time.Minute)); ok {
+		t.Error("Expected an old code to be rejected")
+	}
+	if _, err := VerifyTOTP("not base32!", "123456", now); err == nil {
+		t.Error("Expected an invalid secret to fail")
+	}
+}
+
+func TestVerifyStepUp(t *testing.T) {
+	users := NewMemoryUserStore()
+	users.AddUser(AuthUser{Username: "admin"}, "admin-secret")
+	users.AddUser(AuthUser{Username: "jane"}, "jane-secret")
+	config := &AuthConfig{Enabled: true, Users: users}
+	ctx := context.Background()
+	admin := &AuthUser{Username: "admin"}
+
+	if err := config.VerifyStepUp(ctx, admin, StepUpPassword, "admin-secret"); err != nil {
+		t.Errorf("Expected the password to confirm, got %v", err)
+	}
+	if err := config.VerifyStepUp(ctx, admin, StepUpPassword, "jane-secret"); !errors.Is(err, ErrStepUpFailed) {
+		t.Errorf("Expected another user's password to fail, got %v", err)
+	}
+
+	// While impersonating, the admin confirms with their own password
+	impersonated := &AuthUser{Username: "jane", Impersonator: admin}
+	if err := config.VerifyStepUp(ctx, impersonated, StepUpPassword, "jane-secret"); !errors.Is(err, ErrStepUpFailed) {
+		t.Errorf("Expected the impersonated user's password to fail, got %v", err)
+	}
+	if err := config.VerifyStepUp(ctx, impersonated, StepUpPassword, "admin-secret"); err != nil {
+		t.Errorf("Expected the impersonator's password to confirm, got %v", err)
+	}
+
+	if err := config.VerifyStepUp(ctx, admin, StepUpTOTP, "123456"); !errors.Is(err, ErrStepUpUnavailable) {
+		t.Errorf("Expected TOTP without secrets to be unavailable, got %v", err)
+	}
+	if err := (&AuthConfig{}).VerifyStepUp(ctx, nil, StepUpPassword, "admin-secret"); !errors.Is(err, ErrStepUpUnavailable) {
+		t.Errorf("Expected step-up to fail closed without authentication, got %v", err)
+	}
+}
@@ -2,7 +2,9 @@ package auth
 
 import (
 	"context"
+	"errors"
 	"net/http"
+	"time"
 )
 
 // AuthUser represents an authenticated user in the system
@@ -11,12 +13,20 @@ type AuthUser struct {
 	Username string   `json:"username"`
 	Email    string   `json:"email"`
 	Roles    []string `json:"roles"`
+
+	// Impersonator is the admin really signed in while they view as this user, nil otherwise
+	Impersonator *AuthUser `json:"impersonator,omitempty"`
 }
 
 // AuthenticatorFunc defines the interface for authentication functions
 // It takes a username and password and returns an AuthUser or an error
 type AuthenticatorFunc func(ctx context.Context, username, password string) (*AuthUser, error)
 
+// AuthorizeFunc decides whether the user may perform an action on a resource, e.g. by asking a policy engine
+// The action is an operation such as "list" or "update", or "action:<id>" for custom actions, and id is the
+// addressed record, nil when a request is about no single record. Returning an error denies the request
+type AuthorizeFunc func(ctx context.Context, user *AuthUser, resource, action string, id any) error
+
 // AuthConfig holds the complete authentication configuration
 type AuthConfig struct {
 	// Enabled determines if authentication is active
@@ -31,9 +41,33 @@ type AuthConfig struct {
 	// Authenticator is the function used to validate user credentials
 	Authenticator AuthenticatorFunc
 
+	// Users is the store the login form checks credentials against, when set (see WithUserStore)
+	// Without it the login form uses Authenticator
+	Users UserStore
+
+	// Proxy signs users in from the headers of an authenticating reverse proxy, skipping the login form
+	// and sessions, when its UserHeader is set (see WithProxyHeaders)
+	Proxy ProxyHeaderConfig
+
 	// SessionStore handles session persistence
 	SessionStore SessionStore
 
+	// Cookie sets the name, scope, security and lifetime of the session cookie
+	Cookie CookieConfig
+
+	// RememberMe offers a "keep me signed in" option on the login form, when its Store is set
+	RememberMe RememberMeConfig
+
+	// Authorize is consulted by every admin request on a resource, after the built-in permissions and policy
+	Authorize AuthorizeFunc
+
+	// Impersonation lets admins with one of its roles "view as" other users
+	Impersonation ImpersonationConfig
+
+	// TOTPSecret returns users' authenticator app secrets, to confirm destructive operations with a code
+	// (see StepUpTOTP)
+	TOTPSecret TOTPSecretFunc
+
 	// RequireAuth determines if all admin routes require authentication
 	// If false, authentication is optional and users can access without logging in
 	RequireAuth bool
@@ -43,8 +77,26 @@ type AuthConfig struct {
 
 	// LogoutRedirect is the path to redirect to after logout
 	LogoutRedirect string
+
+	// SessionWarning is how long before the session expires the admin warns and offers to stay signed in
+	// (default: 5 minutes). It needs a SessionStore implementing RefreshableSessionStore
+	SessionWarning time.Duration
+}
+
+// Authenticate checks credentials against Users when set, otherwise with Authenticator
+func (c *AuthConfig) Authenticate(ctx context.Context, username, password string) (*AuthUser, error) {
+	if c.Users != nil {
+		return c.Users.VerifyPassword(ctx, username, password)
+	}
+	if c.Authenticator == nil {
+		return nil, errors.New("no authenticator configured")
+	}
+	return c.Authenticator(ctx, username, password)
 }
 
+// DefaultSessionWarning is how long before expiry sessions are warned about when SessionWarning is unset
+const DefaultSessionWarning = 5 * time.Minute
+
 // SessionStore defines the interface for session management
 type SessionStore interface {
 	// GetSession retrieves a user session by session ID
@@ -60,5 +112,17 @@ type SessionStore interface {
 	CleanExpiredSessions(ctx context.Context) error
 }
 
+// RefreshableSessionStore is a SessionStore that can report and extend a session's lifetime
+// Stores implementing it get the session expiry warning with its "stay signed in" button
+type RefreshableSessionStore interface {
+	SessionStore
+
+	// SessionExpiry returns when the session expires
+	SessionExpiry(ctx context.Context, sessionID string) (time.Time, error)
+
+	// RefreshSession extends the session by the store's timeout and returns the new expiry
+	RefreshSession(ctx context.Context, sessionID string) (time.Time, error)
+}
+
 // AuthMiddleware wraps HTTP handlers to provide authentication
 type AuthMiddleware func(http.Handler) http.Handler
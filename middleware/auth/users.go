@@ -0,0 +1,158 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+var (
+	// ErrUserNotFound is returned when no user has the given username or email
+	ErrUserNotFound = errors.New("user not found")
+	// ErrInvalidPassword is returned when a password does not match the user's
+	ErrInvalidPassword = errors.New("invalid password")
+	// ErrUserExists is returned when adding a user whose username is taken
+	ErrUserExists = errors.New("user already exists")
+	// ErrUserDisabled is returned when a disabled user tries to sign in
+	ErrUserDisabled = errors.New("user is disabled")
+)
+
+// UserStore lists the users that can sign in and checks their passwords
+// Passwords are kept as hashes made by HashPassword, never in plain text
+type UserStore interface {
+	// ListUsers returns all users ordered by username
+	ListUsers(ctx context.Context) ([]AuthUser, error)
+
+	// GetUserByUsername returns the user with the username, ErrUserNotFound, or ErrUserDisabled for users
+	// who may no longer sign in
+	GetUserByUsername(ctx context.Context, username string) (*AuthUser, error)
+
+	// GetUserByEmail returns the user with the email, or ErrUserNotFound
+	GetUserByEmail(ctx context.Context, email string) (*AuthUser, error)
+
+	// VerifyPassword returns the user when the password matches, ErrUserNotFound, ErrInvalidPassword or ErrUserDisabled otherwise
+	VerifyPassword(ctx context.Context, username, password string) (*AuthUser, error)
+}
+
+// WithUserStore creates an AuthConfig that signs users in against a UserStore
+func WithUserStore(users UserStore) AuthConfig {
+	return AuthConfig{
+		Enabled:        true,
+		LoginPath:      "/login",
+		LogoutPath:     "/logout",
+		Authenticator:  users.VerifyPassword,
+		Users:          users,
+		SessionStore:   NewMemorySessionStore(),
+		RequireAuth:    true,
+		LoginRedirect:  "/admin",
+		LogoutRedirect: "/admin",
+	}
+}
+
+// memoryUser is a user of a MemoryUserStore with its password hash
+type memoryUser struct {
+	user         AuthUser
+	passwordHash string
+}
+
+// MemoryUserStore implements UserStore in memory
+// Useful for a fixed set of users configured at startup, and in tests
+type MemoryUserStore struct {
+	users map[string]memoryUser
+	mutex sync.RWMutex
+}
+
+// NewMemoryUserStore creates an empty in-memory user store
+func NewMemoryUserStore() *MemoryUserStore {
+	return &MemoryUserStore{users: make(map[string]memoryUser)}
+}
+
+// AddUser adds a user, hashing the password before storing it
+func (m *MemoryUserStore) AddUser(user AuthUser, password string) error {
+	hash, err := HashPassword(password)
+	if err != nil {
+		return err
+	}
+	return m.AddUserWithHash(user, hash)
+}
+
+// AddUserWithHash adds a user whose password was already hashed with HashPassword or in a registered format
+func (m *MemoryUserStore) AddUserWithHash(user AuthUser, passwordHash string) error {
+	if user.Username == "" {
+		return errors.New("username is required")
+	}
+	if !IsPasswordHash(passwordHash) {
+		return passwordFormatError(passwordHash)
+	}
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if _, exists := m.users[user.Username]; exists {
+		return fmt.Errorf("%w: %s", ErrUserExists, user.Username)
+	}
+	m.users[user.Username] = memoryUser{user: user, passwordHash: passwordHash}
+	return nil
+}
+
+// ListUsers returns all users ordered by username
+func (m *MemoryUserStore) ListUsers(ctx context.Context) ([]AuthUser, error) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	users := make([]AuthUser, 0, len(m.users))
+	for _, stored := range m.users {
+		users = append(users, stored.user)
+	}
+	sort.Slice(users, func(i, j int) bool { return users[i].Username < users[j].Username })
+	return users, nil
+}
+
+// GetUserByUsername returns the user with the username
+func (m *MemoryUserStore) GetUserByUsername(ctx context.Context, username string) (*AuthUser, error) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	stored, exists := m.users[username]
+	if !exists {
+		return nil, ErrUserNotFound
+	}
+	user := stored.user
+	return &user, nil
+}
+
+// GetUserByEmail returns the user with the email
+func (m *MemoryUserStore) GetUserByEmail(ctx context.Context, email string) (*AuthUser, error) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	for _, stored := range m.users {
+		if email != "" && stored.user.Email == email {
+			user := stored.user
+			return &user, nil
+		}
+	}
+	return nil, ErrUserNotFound
+}
+
+// VerifyPassword returns the user when the password matches
+func (m *MemoryUserStore) VerifyPassword(ctx context.Context, username, password string) (*AuthUser, error) {
+	m.mutex.RLock()
+	stored, exists := m.users[username]
+	m.mutex.RUnlock()
+
+	if !exists {
+		return nil, ErrUserNotFound
+	}
+	ok, err := CheckPassword(stored.passwordHash, password)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, ErrInvalidPassword
+	}
+	user := stored.user
+	return &user, nil
+}
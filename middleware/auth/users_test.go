@@ -0,0 +1,62 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestHashPassword(t *testing.T) {
+	hash, err := HashPassword("secret")
+	if err != nil {
+		t.Fatalf("HashPassword failed: %v", err)
+	}
+	if strings.Contains(hash, "secret") || !strings.HasPrefix(hash, "pbkdf2-sha256$") {
+		t.Errorf("Expected a self-describing hash without the password, got %q", hash)
+	}
+
+	if ok, err := CheckPassword(hash, "secret"); err != nil || !ok {
+		t.Errorf("Expected the password to match, got %t, %v", ok, err)
+	}
+	if ok, _ := CheckPassword(hash, "Secret"); ok {
+		t.Error("Expected a different password not to match")
+	}
+	if _, err := CheckPassword("secret", "secret"); !errors.Is(err, ErrInvalidPasswordHash) {
+		t.Errorf("Expected ErrInvalidPasswordHash for a plain text value, got %v", err)
+	}
+}
+
+func TestMemoryUserStore(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryUserStore()
+	if err := store.AddUser(AuthUser{ID: "1", Username: "admin", Email: "admin@test.com", Roles: []string{"admin"}}, "adminpass"); err != nil {
+		t.Fatalf("AddUser failed: %v", err)
+	}
+	if err := store.AddUser(AuthUser{ID: "2", Username: "editor", Email: "editor@test.com"}, "editorpass"); err != nil {
+		t.Fatalf("AddUser failed: %v", err)
+	}
+	if err := store.AddUser(AuthUser{Username: "admin"}, "other"); !errors.Is(err, ErrUserExists) {
+		t.Errorf("Expected ErrUserExists, got %v", err)
+	}
+
+	users, err := store.ListUsers(ctx)
+	if err != nil || len(users) != 2 || users[0].Username != "admin" || users[1].Username != "editor" {
+		t.Errorf("Expected both users ordered by username, got %+v, %v", users, err)
+	}
+
+	config := WithUserStore(store)
+	user, err := config.Authenticate(ctx, "editor", "editorpass")
+	if err != nil || user.Email != "editor@test.com" {
+		t.Errorf("Expected the editor to sign in, got %+v, %v", user, err)
+	}
+	if _, err := config.Authenticate(ctx, "editor", "adminpass"); !errors.Is(err, ErrInvalidPassword) {
+		t.Errorf("Expected ErrInvalidPassword, got %v", err)
+	}
+	if _, err := config.Authenticate(ctx, "nobody", "adminpass"); !errors.Is(err, ErrUserNotFound) {
+		t.Errorf("Expected ErrUserNotFound, got %v", err)
+	}
+	if user, err := store.GetUserByEmail(ctx, "admin@test.com"); err != nil || user.Username != "admin" {
+		t.Errorf("Expected the admin by email, got %+v, %v", user, err)
+	}
+}
@@ -0,0 +1,19 @@
+package ui
+
+import (
+	"embed"
+	"net/http"
+)
+
+//go:generate sh vendor_assets.sh
+
+// assets holds the admin's scripts and the vendored editor libraries, served from the same origin so a
+// strict CSP allows them and the admin works offline
+//
+//go:embed assets
+var assets embed.FS
+
+// assetsHandler serves the embedded assets under basePath/assets/
+func assetsHandler(basePath string) http.Handler {
+	return http.StripPrefix(basePath+"/", http.FileServer(http.FS(assets)))
+}
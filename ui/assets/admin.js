@@ -0,0 +1,788 @@
+// Behaviour of the admin pages, served from /admin/assets so the admin can run under a strict
+// Content-Security-Policy: templates carry data attributes instead of inline scripts and handlers
+
+// Scripts loaded on demand, like the rich text and code editors, carry the nonce this script was allowed with
+const scriptNonce = document.currentScript ? document.currentScript.nonce : '';
+
+// Vendored editor libraries are served next to admin.js, so they load offline and under a strict CSP
+const assetsBase = document.currentScript ? document.currentScript.src.replace(/admin\.js([?#].*)?$/, '') : '/admin/assets/';
+
+// Copy a copy button's value to the clipboard, confirming with a toast
+function copyToClipboard(button) {
+	navigator.clipboard.writeText(button.dataset.copy).then(
+		function() { showToast(button.dataset.copied, 'success'); },
+		function() { showToast(button.dataset.copyFailed, 'error'); }
+	);
+}
+
+// Handle refreshList event to reload the current page
+document.body.addEventListener('refreshList', function(evt) {
+	console.log('🔄 DEBUG: refreshList event triggered');
+	// Close any open side panes first
+	const sidePane = document.getElementById('sidepane-overlay');
+	if (sidePane) {
+		console.log('🔄 DEBUG: Closing side pane');
+		// Trigger Alpine.js close animation
+		const alpineData = Alpine.$data(sidePane.querySelector('[x-data]'));
+		if (alpineData) {
+			alpineData.show = false;
+			setTimeout(() => sidePane.remove(), 300);
+		} else {
+			sidePane.remove();
+		}
+	}
+	
+	// Refresh the page content after a short delay to allow side pane to close
+	setTimeout(() => {
+		console.log('🔄 DEBUG: Reloading page');
+		window.location.reload();
+	}, 300);
+});
+
+// Handle HTMX response error events
+document.body.addEventListener('htmx:responseError', function(evt) {
+	showToast('An error occurred while processing your request.', 'error');
+});
+
+// Handle HTMX network error events
+document.body.addEventListener('htmx:sendError', function(evt) {
+	showToast('Network error. Please check your connection.', 'error');
+});
+
+// Handle success messages on page load
+document.addEventListener('DOMContentLoaded', function() {
+	// Check for URL parameters for success messages (legacy)
+	const urlParams = new URLSearchParams(window.location.search);
+	const successType = urlParams.get('success');
+	const resourceName = urlParams.get('resource');
+	
+	if (successType === 'delete' && resourceName) {
+		showToast(resourceName + ' deleted successfully', 'success');
+		// Clean up URL by removing the parameters
+		urlParams.delete('success');
+		urlParams.delete('resource');
+		const newUrl = window.location.pathname + (urlParams.toString() ? '?' + urlParams.toString() : '');
+		history.replaceState(null, '', newUrl);
+	}
+});
+
+// Close a side pane once its form was saved, e.g. {"closeSidePane": {"id": "sidepane-overlay"}}
+document.body.addEventListener('closeSidePane', function(evt) {
+	const pane = document.getElementById(evt.detail.id);
+	if (pane) {
+		Alpine.$data(pane).dismiss();
+	}
+});
+
+// Highlight the row of a created or updated record, swapped into the list by the response
+// Pages without the row, like detail pages, reload to show the change
+document.body.addEventListener('recordSaved', function(evt) {
+	const row = document.getElementById(evt.detail.row);
+	if (!row) {
+		window.location.reload();
+		return;
+	}
+	row.classList.add('highlight-' + evt.detail.action);
+	row.scrollIntoView({ behavior: 'smooth', block: 'center' });
+});
+
+// Global table sorting function
+function sortTable(fieldName) {
+	console.log('🔍 DEBUG: Sorting by field:', fieldName);
+	const urlParams = new URLSearchParams(window.location.search);
+	const currentSort = urlParams.get('sort');
+	const currentDirection = urlParams.get('direction') || 'asc';
+	
+	console.log('🔍 DEBUG: Current sort:', currentSort, 'direction:', currentDirection);
+	
+	// If clicking the same field, toggle direction
+	if (currentSort === fieldName) {
+		const newDirection = currentDirection === 'asc' ? 'desc' : 'asc';
+		urlParams.set('direction', newDirection);
+		console.log('🔍 DEBUG: Toggling direction to:', newDirection);
+	} else {
+		// New field, start with ascending
+		urlParams.set('sort', fieldName);
+		urlParams.set('direction', 'asc');
+		console.log('🔍 DEBUG: Setting new sort field:', fieldName, 'direction: asc');
+	}
+	
+	// Reset pagination when sorting changes
+	urlParams.delete('offset');
+	
+	const newURL = urlParams.toString();
+	console.log('🔍 DEBUG: Navigating to:', newURL);
+	
+	// Navigate to new URL
+	window.location.search = newURL;
+}
+
+// Rich text editors load Quill on first use and keep their hidden input in sync
+function loadQuill() {
+	if (!window.quillLoading) {
+		const css = document.createElement('link');
+		css.rel = 'stylesheet';
+		css.href = assetsBase + 'vendor/quill/quill.snow.css';
+		document.head.appendChild(css);
+		window.quillLoading = new Promise(function(resolve, reject) {
+			const script = document.createElement('script');
+			script.src = assetsBase + 'vendor/quill/quill.js';
+			script.nonce = scriptNonce;
+			script.onload = resolve;
+			script.onerror = reject;
+			document.head.appendChild(script);
+		});
+	}
+	return window.quillLoading;
+}
+
+function initRichTextEditor(el) {
+	loadQuill().then(function() {
+		const input = el.querySelector('input[type="hidden"]');
+		const quill = new Quill(el.querySelector('[data-editor]'), {
+			theme: 'snow',
+			readOnly: el.dataset.readonly === 'true',
+			modules: {
+				toolbar: [
+					[{ header: [1, 2, 3, false] }],
+					['bold', 'italic', 'underline', 'strike'],
+					[{ list: 'ordered' }, { list: 'bullet' }],
+					['blockquote', 'code-block', 'link'],
+					['clean']
+				]
+			}
+		});
+		// Quill only keeps the formatting it supports from the stored HTML
+		quill.clipboard.dangerouslyPasteHTML(input.value);
+		quill.on('text-change', function() {
+			input.value = quill.getText().trim() === '' ? '' : quill.getSemanticHTML();
+		});
+	}, function() {
+		richTextFallback(el);
+	});
+}
+
+// richTextFallback edits the HTML in a plain textarea when Quill can't be loaded, e.g. before the
+// vendored copy is generated, so the field stays editable
+function richTextFallback(el) {
+	const input = el.querySelector('input[type="hidden"]');
+	const textarea = document.createElement('textarea');
+	textarea.rows = 8;
+	textarea.className = 'block w-full px-3 py-2 border border-gray-300 rounded-md font-mono text-sm';
+	textarea.value = input.value;
+	textarea.readOnly = el.dataset.readonly === 'true';
+	textarea.addEventListener('input', function() {
+		input.value = textarea.value;
+	});
+	el.querySelector('[data-editor]').replaceWith(textarea);
+}
+
+// Code editors load CodeMirror with the modes of their language on first use
+const codeMirrorBase = assetsBase + 'vendor/codemirror/';
+const codeLanguages = {
+	json: { mode: { name: 'javascript', json: true }, scripts: ['mode/javascript/javascript.js'] },
+	yaml: { mode: 'yaml', scripts: ['mode/yaml/yaml.js', '../js-yaml/js-yaml.min.js'] },
+	sql: { mode: 'text/x-sql', scripts: ['mode/sql/sql.js'] }
+};
+const loadedScripts = {};
+
+function loadScript(src) {
+	src = codeMirrorBase + src;
+	if (!loadedScripts[src]) {
+		loadedScripts[src] = new Promise(function(resolve, reject) {
+			const script = document.createElement('script');
+			script.src = src;
+			script.nonce = scriptNonce;
+			script.onload = resolve;
+			script.onerror = reject;
+			document.head.appendChild(script);
+		});
+	}
+	return loadedScripts[src];
+}
+
+function loadCodeMirror(language) {
+	if (!document.getElementById('codemirror-css')) {
+		['lib/codemirror.css', 'addon/lint/lint.css'].forEach(function(href, i) {
+			const css = document.createElement('link');
+			css.rel = 'stylesheet';
+			css.href = codeMirrorBase + href;
+			if (i === 0) {
+				css.id = 'codemirror-css';
+			}
+			document.head.appendChild(css);
+		});
+	}
+	// Modes and addons register with CodeMirror, so it has to load first
+	return loadScript('lib/codemirror.js').then(function() {
+		return Promise.all(['addon/lint/lint.js'].concat(codeLanguages[language].scripts).map(loadScript));
+	});
+}
+
+// lintCode returns the syntax error of JSON and YAML text as CodeMirror annotations
+function lintCode(language, text) {
+	if (text.trim() === '') {
+		return [];
+	}
+	try {
+		if (language === 'json') {
+			JSON.parse(text);
+		} else if (language === 'yaml' && window.jsyaml) {
+			jsyaml.load(text);
+		}
+		return [];
+	} catch (e) {
+		let line = 0;
+		if (e.mark) {
+			line = e.mark.line;
+		} else {
+			const position = /position (\d+)/.exec(e.message);
+			if (position) {
+				line = text.slice(0, Number(position[1])).split('\n').length - 1;
+			}
+		}
+		return [{ from: CodeMirror.Pos(line, 0), to: CodeMirror.Pos(line, 1000), message: e.message, severity: 'error' }];
+	}
+}
+
+function initCodeEditor(el) {
+	const language = el.dataset.language;
+	if (!codeLanguages[language]) {
+		return;
+	}
+	loadCodeMirror(language).then(function() {
+		const textarea = el.querySelector('textarea');
+		const message = el.querySelector('[data-code-lint]');
+		const editor = CodeMirror.fromTextArea(textarea, {
+			mode: codeLanguages[language].mode,
+			lineNumbers: true,
+			readOnly: el.dataset.readonly === 'true',
+			gutters: ['CodeMirror-lint-markers'],
+			lint: {
+				getAnnotations: function(text) {
+					const annotations = lintCode(language, text);
+					message.textContent = annotations.length ? annotations[0].message : '';
+					message.classList.toggle('hidden', !annotations.length);
+					return annotations;
+				}
+			}
+		});
+		// Keep the textarea current for forms submitted by HTMX
+		editor.on('change', function() {
+			editor.save();
+		});
+	}, function() {
+		// CodeMirror isn't vendored yet: the plain textarea stays and keeps the field editable
+	});
+}
+
+// Copy buttons sit in clickable rows, so their clicks are handled before the row sees them
+document.addEventListener('click', function(evt) {
+	const button = evt.target.closest('[data-copy]');
+	if (button) {
+		evt.stopPropagation();
+		copyToClipboard(button);
+	}
+}, true);
+
+document.addEventListener('click', function(evt) {
+	// Sortable list headers, e.g. <button data-sort-field="Name">
+	const header = evt.target.closest('[data-sort-field]');
+	if (header) {
+		sortTable(header.dataset.sortField);
+		return;
+	}
+	// Buttons going back to the previous page, e.g. closing a form
+	if (evt.target.closest('[data-history-back]')) {
+		window.history.back();
+	}
+});
+
+// Forms asking for confirmation before they submit, e.g. <form data-confirm="Restore this version?">
+document.addEventListener('submit', function(evt) {
+	const form = evt.target.closest('form[data-confirm]');
+	if (form && !window.confirm(form.dataset.confirm)) {
+		evt.preventDefault();
+	}
+});
+
+// Saved views post the current URL query, which HTMX may have changed since the menu rendered
+document.body.addEventListener('htmx:configRequest', function(evt) {
+	if (evt.detail.elt.matches && evt.detail.elt.matches('[data-current-query]')) {
+		evt.detail.parameters.query = window.location.search.slice(1);
+	}
+});
+
+// Forms leaving empty controls out of their URL, e.g. the list filters
+document.addEventListener('submit', function(evt) {
+	const form = evt.target.closest('form[data-skip-empty]');
+	if (form) {
+		form.querySelectorAll('input, select').forEach(function(el) {
+			if (!el.value) {
+				el.disabled = true;
+			}
+		});
+	}
+});
+
+// Alpine components of the admin pages. Templates only name them in x-data and refer to their properties
+// and methods, which the CSP build of Alpine evaluates without eval
+document.addEventListener('alpine:init', function() {
+	// Dropdowns, menus and collapsible sections, open initially with data-open="true"
+	Alpine.data('dropdown', function() {
+		return {
+			open: false,
+			init() {
+				this.open = this.$el.dataset.open === 'true';
+			},
+			get closed() {
+				return !this.open;
+			},
+			// Classes from data-open-class of the element, applied while open
+			get openClass() {
+				return this.open ? this.$el.dataset.openClass : '';
+			},
+			toggle() {
+				this.open = !this.open;
+			},
+			show() {
+				this.open = true;
+			},
+			close() {
+				this.open = false;
+			}
+		};
+	});
+
+	// Modals are shown when swapped in and removed once closed, reloading the page with data-reload-on-close
+	Alpine.data('modal', function() {
+		return {
+			show: true,
+			init() {
+				const root = this.$el;
+				this.$watch('show', function(value) {
+					if (value) {
+						return;
+					}
+					if (root.dataset.reloadOnClose === 'true') {
+						window.location.reload();
+					} else {
+						setTimeout(function() { root.remove(); }, 300);
+					}
+				});
+			},
+			close() {
+				this.show = false;
+			}
+		};
+	});
+
+	// Side panes slide out and leave the page once dismissed; closing an edited form asks first
+	Alpine.data('sidePane', function() {
+		let root;
+		return {
+			show: true,
+			dirty: false,
+			confirming: false,
+			init() {
+				root = this.$el;
+			},
+			markDirty() {
+				this.dirty = true;
+			},
+			warnBeforeUnload(evt) {
+				if (this.dirty) {
+					evt.preventDefault();
+					evt.returnValue = '';
+				}
+			},
+			dismiss() {
+				this.dirty = false;
+				this.show = false;
+				setTimeout(function() { root.remove(); }, 300);
+			},
+			close() {
+				if (this.dirty) {
+					this.confirming = true;
+				} else {
+					this.dismiss();
+				}
+			},
+			keepEditing() {
+				this.confirming = false;
+			},
+			discard() {
+				this.confirming = false;
+				this.dismiss();
+			}
+		};
+	});
+
+	// Forms showing the errors of fields sent with a fieldErrors event next to them, e.g. <p data-field="Name">
+	Alpine.data('fieldErrors', function() {
+		return {
+			errors: {},
+			showErrors(evt) {
+				this.errors = evt.detail;
+			},
+			clearErrors() {
+				this.errors = {};
+			},
+			get fieldError() {
+				return this.errors[this.$el.dataset.field] || '';
+			}
+		};
+	});
+
+	// Forms disabling their submit button while the page submits them
+	Alpine.data('submitting', function() {
+		return {
+			loading: false,
+			start() {
+				this.loading = true;
+			},
+			get loadingClass() {
+				return this.loading ? 'opacity-50 cursor-not-allowed' : '';
+			}
+		};
+	});
+
+	// Tabs switched in the browser, starting with the root's data-tab; tabs and panels carry their own data-tab
+	Alpine.data('tabs', function() {
+		return {
+			tab: '',
+			init() {
+				this.tab = this.$el.dataset.tab;
+			},
+			select(evt) {
+				this.tab = evt.currentTarget.dataset.tab;
+			},
+			get tabShown() {
+				return this.tab === this.$el.dataset.tab;
+			},
+			get tabClass() {
+				return this.tabShown ? 'border-blue-500 text-blue-600' : 'border-transparent text-gray-500 hover:text-gray-700';
+			}
+		};
+	});
+
+	// Date and time inputs with a button clearing them
+	Alpine.data('clearableInput', function() {
+		return {
+			clear() {
+				this.$refs.input.value = '';
+			}
+		};
+	});
+
+	// In-place cell editors; Escape puts the cell from data-display-url back without saving
+	Alpine.data('cellEditor', function() {
+		return {
+			cancel(evt) {
+				const form = evt.currentTarget;
+				htmx.ajax('GET', form.dataset.displayUrl, { target: form, swap: 'outerHTML' });
+			}
+		};
+	});
+
+	// Rich text and code editors upgrade their inputs once their scripts loaded
+	Alpine.data('richText', function() {
+		return {
+			init() {
+				initRichTextEditor(this.$el);
+			}
+		};
+	});
+
+	Alpine.data('codeEditor', function() {
+		return {
+			init() {
+				initCodeEditor(this.$el);
+			}
+		};
+	});
+
+	// Bulk selection of list rows, the checkboxes named "ids"; data-total-count holds the number of matching records
+	Alpine.data('bulkSelection', function() {
+		let root;
+		return {
+			selected: [],
+			allMatching: false,
+			init() {
+				root = this.$el;
+			},
+			visibleCount() {
+				return root.querySelectorAll('input[name=ids]').length;
+			},
+			get hasSelection() {
+				return this.selected.length > 0;
+			},
+			get selectedCount() {
+				return this.selected.length;
+			},
+			get allVisibleSelected() {
+				return this.selected.length > 0 && this.selected.length === this.visibleCount();
+			},
+			get visibleOnly() {
+				return !this.allMatching;
+			},
+			get canSelectAllMatching() {
+				return !this.allMatching && this.allVisibleSelected && this.visibleCount() < Number(root.dataset.totalCount);
+			},
+			get selectAllValue() {
+				return this.allMatching ? 'true' : 'false';
+			},
+			get rowSelected() {
+				return this.selected.includes(this.$el.value);
+			},
+			toggleAll(evt) {
+				this.selected = evt.target.checked ? Array.from(root.querySelectorAll('input[name=ids]')).map(function(el) { return el.value; }) : [];
+				this.allMatching = false;
+			},
+			toggleRow(evt) {
+				const value = evt.target.value;
+				this.selected = this.selected.filter(function(id) { return id !== value; });
+				if (evt.target.checked) {
+					this.selected.push(value);
+				}
+				this.allMatching = false;
+			},
+			selectAllMatching() {
+				this.allMatching = true;
+			},
+			clearSelection() {
+				this.selected = [];
+				this.allMatching = false;
+			}
+		};
+	});
+
+	// List rows fading out while their delete request runs
+	Alpine.data('rowDelete', function() {
+		return {
+			deleting: false,
+			get idle() {
+				return !this.deleting;
+			},
+			start(evt) {
+				evt.currentTarget.closest('tr').classList.add('opacity-50', 'pointer-events-none');
+				this.deleting = true;
+			},
+			finish(evt) {
+				this.deleting = false;
+				if (evt.detail.xhr.status !== 200) {
+					evt.currentTarget.closest('tr').classList.remove('opacity-50', 'pointer-events-none');
+				}
+			}
+		};
+	});
+
+	// Collapsible tree rows: toggles carry their node's data-node, rows the JSON list of their data-ancestors
+	Alpine.data('tree', function() {
+		return {
+			collapsed: [],
+			toggleNode(evt) {
+				const id = evt.currentTarget.dataset.node;
+				this.collapsed = this.collapsed.includes(id) ? this.collapsed.filter(function(c) { return c !== id; }) : this.collapsed.concat([id]);
+			},
+			get nodeShown() {
+				const collapsed = this.collapsed;
+				return !JSON.parse(this.$el.dataset.ancestors).some(function(a) { return collapsed.includes(a); });
+			},
+			get nodeArrow() {
+				return this.collapsed.includes(this.$el.dataset.node) ? '▸' : '▾';
+			}
+		};
+	});
+
+	// Rows dragged into a new order, saved through the reorder form when a drag changed it
+	Alpine.data('reorder', function() {
+		let dragging = null;
+		let order = '';
+		const rowOrder = function() {
+			return Array.from(document.querySelectorAll('input[form=reorder-form]')).map(function(el) { return el.value; }).join(',');
+		};
+		return {
+			dragStart(evt) {
+				dragging = evt.currentTarget.closest('tr');
+				order = rowOrder();
+				dragging.classList.add('opacity-50');
+				evt.dataTransfer.effectAllowed = 'move';
+				evt.dataTransfer.setData('text/plain', '');
+			},
+			dragEnd() {
+				dragging.classList.remove('opacity-50');
+				dragging = null;
+				if (rowOrder() !== order) {
+					htmx.trigger('#reorder-form', 'reorder');
+				}
+			},
+			// Moves the dragged row above or below the hovered row
+			dragOver(evt) {
+				if (!dragging) {
+					return;
+				}
+				const row = evt.target.closest('tr[data-pw=resource-row]');
+				if (row && row !== dragging) {
+					const below = evt.clientY > row.getBoundingClientRect().top + row.offsetHeight / 2;
+					evt.currentTarget.insertBefore(dragging, below ? row.nextSibling : row);
+				}
+			}
+		};
+	});
+
+	// Columns menu of the list, starting from the columns in data-columns
+	Alpine.data('columnsMenu', function() {
+		return {
+			columns: [],
+			init() {
+				this.columns = JSON.parse(this.$el.dataset.columns);
+			},
+			get visibleColumns() {
+				return this.columns.filter(function(c) { return c.visible; });
+			},
+			get noneVisible() {
+				return !this.columns.some(function(c) { return c.visible; });
+			},
+			get isFirst() {
+				return this.index === 0;
+			},
+			get isLast() {
+				return this.index === this.columns.length - 1;
+			},
+			toggleColumn(evt) {
+				this.column.visible = evt.target.checked;
+			},
+			move(index, offset) {
+				const [column] = this.columns.splice(index, 1);
+				this.columns.splice(index + offset, 0, column);
+			},
+			moveUp() {
+				this.move(this.index, -1);
+			},
+			moveDown() {
+				this.move(this.index, 1);
+			}
+		};
+	});
+
+	// Relation pickers, filled by picking one of the options or a record created from the picker
+	Alpine.data('relationPicker', function() {
+		let root;
+		return {
+			open: false,
+			value: '',
+			label: '',
+			init() {
+				root = this.$el;
+				this.value = root.dataset.value;
+				this.label = root.dataset.label;
+			},
+			show() {
+				this.open = true;
+			},
+			close() {
+				this.open = false;
+			},
+			search(evt) {
+				this.label = evt.target.value;
+				this.value = '';
+				this.open = true;
+			},
+			pick(value, label) {
+				this.value = value;
+				this.label = label;
+				this.open = false;
+			},
+			choose(evt) {
+				this.pick(evt.currentTarget.dataset.value, evt.currentTarget.dataset.label);
+			},
+			created(evt) {
+				if (evt.detail.picker === root.id) {
+					this.pick(evt.detail.value, evt.detail.label);
+				}
+			}
+		};
+	});
+
+	// Many-to-many inputs, starting from the chips in data-chips
+	Alpine.data('manyToManyPicker', function() {
+		let root;
+		return {
+			open: false,
+			query: '',
+			chips: [],
+			init() {
+				root = this.$el;
+				this.chips = JSON.parse(root.dataset.chips);
+			},
+			show() {
+				this.open = true;
+			},
+			close() {
+				this.open = false;
+			},
+			search(evt) {
+				this.query = evt.target.value;
+				this.open = true;
+			},
+			pick(value, label) {
+				if (!this.chips.some(function(chip) { return chip.value === value; })) {
+					this.chips.push({ value: value, label: label });
+				}
+				this.query = '';
+				this.open = false;
+			},
+			choose(evt) {
+				this.pick(evt.currentTarget.dataset.value, evt.currentTarget.dataset.label);
+			},
+			created(evt) {
+				if (evt.detail.picker === root.id) {
+					this.pick(evt.detail.value, evt.detail.label);
+				}
+			},
+			get removeLabel() {
+				return root.dataset.removeLabel + ' ' + this.chip.label;
+			},
+			removeChip() {
+				const value = this.chip.value;
+				this.chips = this.chips.filter(function(chip) { return chip.value !== value; });
+			}
+		};
+	});
+
+	// Session expiry warning, counting down the data-remaining milliseconds and showing data-warn seconds before the end
+	Alpine.data('sessionWarning', function() {
+		return {
+			deadline: 0,
+			warn: 0,
+			left: 0,
+			init() {
+				const remaining = Number(this.$el.dataset.remaining);
+				this.deadline = Date.now() + remaining;
+				this.warn = Number(this.$el.dataset.warn);
+				this.left = Math.round(remaining / 1000);
+				setInterval(() => this.tick(), 1000);
+			},
+			tick() {
+				this.left = Math.max(0, Math.round((this.deadline - Date.now()) / 1000));
+			},
+			refreshed(evt) {
+				this.deadline = Date.now() + evt.detail.remaining * 1000;
+				this.tick();
+			},
+			get warning() {
+				return this.left <= this.warn;
+			},
+			get active() {
+				return this.left > 0;
+			},
+			get expired() {
+				return this.left <= 0;
+			},
+			get countdown() {
+				return Math.floor(this.left / 60) + ':' + String(this.left % 60).padStart(2, '0');
+			}
+		};
+	});
+});
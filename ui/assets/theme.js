@@ -0,0 +1,7 @@
+// Configures Tailwind with the theme's primary color, handed over as JSON in data-tailwind-config
+(function () {
+	var config = document.currentScript && document.currentScript.dataset.tailwindConfig;
+	if (config) {
+		tailwind.config = JSON.parse(config);
+	}
+})();
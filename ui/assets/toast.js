@@ -0,0 +1,144 @@
+// Toast notifications: a small queue that shows at most maxStack toasts at once,
+// folds repeats of a visible toast into a counter, and hides each after duration ms.
+(function () {
+	var settings = { position: 'top-right', duration: 4000, maxStack: 5, allowDuplicates: false };
+	var visible = [];
+	var queue = [];
+
+	var icons = {
+		success: 'M10 18a8 8 0 100-16 8 8 0 000 16zm3.707-9.293a1 1 0 00-1.414-1.414L9 10.586 7.707 9.293a1 1 0 00-1.414 1.414l2 2a1 1 0 001.414 0l4-4z',
+		error: 'M18 10a8 8 0 11-16 0 8 8 0 0116 0zm-7 4a1 1 0 11-2 0 1 1 0 012 0zm-1-9a1 1 0 00-1 1v4a1 1 0 102 0V6a1 1 0 00-1-1z'
+	};
+
+	function container() {
+		return document.getElementById('toast-container');
+	}
+
+	function hiddenClass() {
+		return settings.position.indexOf('left') >= 0 ? '-translate-x-full' : 'translate-x-full';
+	}
+
+	function build(entry) {
+		var el = document.createElement('div');
+		el.className = (entry.type === 'error' ? 'bg-red-500' : 'bg-green-500') +
+			' text-white px-6 py-3 rounded-lg shadow-lg mb-2 transform transition-all duration-300 opacity-0 flex items-center ' + hiddenClass();
+		el.setAttribute('role', entry.type === 'error' ? 'alert' : 'status');
+		el.dataset.pw = 'toast';
+
+		var svg = document.createElementNS('http://www.w3.org/2000/svg', 'svg');
+		svg.setAttribute('class', 'w-5 h-5 mr-2 flex-shrink-0');
+		svg.setAttribute('fill', 'currentColor');
+		svg.setAttribute('viewBox', '0 0 20 20');
+		var path = document.createElementNS('http://www.w3.org/2000/svg', 'path');
+		path.setAttribute('fill-rule', 'evenodd');
+		path.setAttribute('clip-rule', 'evenodd');
+		path.setAttribute('d', icons[entry.type] || icons.success);
+		svg.appendChild(path);
+		el.appendChild(svg);
+
+		var text = document.createElement('span');
+		text.textContent = entry.message;
+		el.appendChild(text);
+
+		entry.badge = document.createElement('span');
+		entry.badge.className = 'ml-2 rounded-full bg-white/25 px-2 text-xs font-semibold';
+		entry.badge.dataset.pw = 'toast-count';
+		entry.badge.style.display = 'none';
+		el.appendChild(entry.badge);
+		return el;
+	}
+
+	function schedule(entry) {
+		clearTimeout(entry.timer);
+		entry.timer = setTimeout(function () { dismiss(entry); }, settings.duration);
+	}
+
+	function display(entry) {
+		var target = container();
+		if (!target) {
+			return;
+		}
+		entry.el = build(entry);
+		if (settings.position.indexOf('bottom') === 0) {
+			target.insertBefore(entry.el, target.firstChild);
+		} else {
+			target.appendChild(entry.el);
+		}
+		visible.push(entry);
+		setTimeout(function () {
+			entry.el.classList.remove(hiddenClass(), 'opacity-0');
+		}, 20);
+		schedule(entry);
+	}
+
+	function dismiss(entry) {
+		var index = visible.indexOf(entry);
+		if (index < 0) {
+			return;
+		}
+		visible.splice(index, 1);
+		entry.el.classList.add(hiddenClass(), 'opacity-0');
+		setTimeout(function () { entry.el.remove(); }, 300);
+		if (queue.length > 0) {
+			display(queue.shift());
+		}
+	}
+
+	function find(list, message, type) {
+		for (var i = 0; i < list.length; i++) {
+			if (list[i].message === message && list[i].type === type) {
+				return list[i];
+			}
+		}
+		return null;
+	}
+
+	function show(message, type) {
+		if (!message) {
+			return;
+		}
+		type = type || 'success';
+		if (!settings.allowDuplicates) {
+			var shown = find(visible, message, type);
+			if (shown) {
+				shown.count++;
+				shown.badge.textContent = '×' + shown.count;
+				shown.badge.style.display = '';
+				schedule(shown);
+				return;
+			}
+			if (find(queue, message, type)) {
+				return;
+			}
+		}
+		var entry = { message: String(message), type: type, count: 1 };
+		if (visible.length < settings.maxStack) {
+			display(entry);
+		} else {
+			queue.push(entry);
+		}
+	}
+
+	function configure(options) {
+		for (var key in options) {
+			if (Object.prototype.hasOwnProperty.call(options, key)) {
+				settings[key] = options[key];
+			}
+		}
+	}
+
+	window.BackOfficeToasts = { configure: configure, show: show };
+	window.showToast = show;
+
+	// The layout hands the configured settings over as JSON in the container's data-config
+	var container = document.getElementById('toast-container');
+	if (container && container.dataset.config) {
+		configure(JSON.parse(container.dataset.config));
+	}
+
+	document.addEventListener('showToast', function (evt) {
+		if (evt.detail && evt.detail.message) {
+			show(evt.detail.message, evt.detail.type || 'success');
+		}
+	});
+})();
@@ -0,0 +1,218 @@
+package ui
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	sqladapter "github.com/preslavrachev/backoffice/adapters/sql"
+	"github.com/preslavrachev/backoffice/core"
+	"github.com/preslavrachev/backoffice/middleware/auth"
+)
+
+type cellTicket struct {
+	ID       uint   `db:"id"`
+	Title    string `db:"title"`
+	Priority int    `db:"priority"`
+	Notes    string `db:"notes"`
+	Resolved bool   `db:"resolved"`
+	Version  int    `db:"version"`
+}
+
+// cellAdapter holds a single ticket and records updates
+type cellAdapter struct {
+	mockActionAdapter
+	ticket  cellTicket
+	updated *cellTicket
+}
+
+func (a *cellAdapter) Find(ctx context.Context, resource *core.Resource, query *core.Query) (*core.Result, error) {
+	ticket := a.ticket
+	return &core.Result{Items: []any{&ticket}, TotalCount: 1}, nil
+}
+
+func (a *cellAdapter) GetByID(ctx context.Context, resource *core.Resource, id any) (any, error) {
+	ticket := a.ticket
+	return &ticket, nil
+}
+
+func (a *cellAdapter) Update(ctx context.Context, resource *core.Resource, id any, data any) error {
+	a.updated = data.(*cellTicket)
+	return nil
+}
+
+func newCellEditHandler() (*BackOfficeHandler, *cellAdapter) {
+	adapter := &cellAdapter{ticket: cellTicket{ID: 7, Title: "Printer jam", Priority: 2, Notes: "3rd floor", Version: 5}}
+	bo := core.New(adapter, auth.AuthConfig{})
+	bo.RegisterResource(&cellTicket{}).
+		WithField("Title", func(f *core.FieldBuilder) { f.Required(true).EditableInList() }).
+		WithField("Priority", func(f *core.FieldBuilder) { f.EditableInList() }).
+		WithFields("Notes", "Resolved").
+		WithOptimisticLock("Version")
+	return &BackOfficeHandler{bo: bo}, adapter
+}
+
+func patchCell(h *BackOfficeHandler, field string, form url.Values) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPatch, "/admin/api/cellTicket/7/field/"+field, strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	w := httptest.NewRecorder()
+	h.apiRouter(w, req)
+	return w
+}
+
+func TestCellEdit_ListAndEditor(t *testing.T) {
+	h, _ := newCellEditHandler()
+
+	req := httptest.NewRequest(http.MethodGet, "/admin/cellTicket", nil)
+	w := httptest.NewRecorder()
+	h.indexHandler(w, req)
+	body := w.Body.String()
+	if !strings.Contains(body, `hx-get="/admin/api/cellTicket/7/field/Title" hx-trigger="dblclick"`) {
+		t.Error("Expected the Title cell to be editable on double-click")
+	}
+	if strings.Contains(body, `data-pw="editable-cell-Notes"`) {
+		t.Error("Expected fields without EditableInList to keep their detail link")
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/admin/api/cellTicket/7/field/Priority", nil)
+	w = httptest.NewRecorder()
+	h.apiRouter(w, req)
+	body = w.Body.String()
+	for _, want := range []string{
+		`hx-patch="/admin/api/cellTicket/7/field/Priority"`,
+		`<input type="hidden" name="version" value="5">`,
+		`type="number" name="Priority" id="Priority" value="2"`,
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("Expected %s in the editor, got:\n%s", want, body)
+		}
+	}
+}
+
+func TestCellEdit_Save(t *testing.T) {
+	h, adapter := newCellEditHandler()
+
+	w := patchCell(h, "Priority", url.Values{"Priority": {"1"}, "version": {"5"}})
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if adapter.updated == nil || adapter.updated.Priority != 1 || adapter.updated.Title != "Printer jam" {
+		t.Fatalf("Expected only Priority to change, got %+v", adapter.updated)
+	}
+	if adapter.updated.Version != 6 {
+		t.Errorf("Expected the lock version to be bumped, got %d", adapter.updated.Version)
+	}
+	if !strings.Contains(w.Body.String(), `data-pw="editable-cell-Priority"`) {
+		t.Errorf("Expected the updated cell back, got:\n%s", w.Body.String())
+	}
+}
+
+func TestCellEdit_Rejections(t *testing.T) {
+	h, adapter := newCellEditHandler()
+
+	if w := patchCell(h, "Priority", url.Values{"Priority": {"1"}, "version": {"4"}}); w.Code != http.StatusConflict {
+		t.Errorf("Expected status 409 for a stale version, got %d", w.Code)
+	}
+	if w := patchCell(h, "Title", url.Values{"Title": {"  "}, "version": {"5"}}); w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status 400 for an empty required field, got %d", w.Code)
+	}
+	if w := patchCell(h, "Notes", url.Values{"Notes": {"basement"}, "version": {"5"}}); w.Code != http.StatusForbidden {
+		t.Errorf("Expected status 403 for a field not editable in the list, got %d", w.Code)
+	}
+	if adapter.updated != nil {
+		t.Errorf("Expected nothing to be saved, got %+v", adapter.updated)
+	}
+}
+
+func TestCellEdit_Toggle(t *testing.T) {
+	h, adapter := newCellEditHandler()
+
+	req := httptest.NewRequest(http.MethodGet, "/admin/cellTicket", nil)
+	w := httptest.NewRecorder()
+	h.indexHandler(w, req)
+	if want := `hx-patch="/admin/api/cellTicket/7/field/Resolved" hx-vals="{&#34;Resolved&#34;:&#34;true&#34;,&#34;version&#34;:&#34;5&#34;}"`; !strings.Contains(w.Body.String(), want) {
+		t.Errorf("Expected the Resolved cell to render as a switch, got:\n%s", w.Body.String())
+	}
+
+	w = patchCell(h, "Resolved", url.Values{"Resolved": {"true"}, "version": {"5"}})
+	if w.Code != http.StatusOK || adapter.updated == nil || !adapter.updated.Resolved {
+		t.Fatalf("Expected the ticket to be resolved, got %d: %+v", w.Code, adapter.updated)
+	}
+	if !strings.Contains(w.Body.String(), `aria-checked="true"`) {
+		t.Errorf("Expected the switch back turned on, got:\n%s", w.Body.String())
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/admin/api/cellTicket/7/field/Resolved", nil)
+	w = httptest.NewRecorder()
+	h.apiRouter(w, req)
+	if w.Code != http.StatusForbidden {
+		t.Errorf("Expected switches to have no editor, got %d", w.Code)
+	}
+}
+
+func TestCellEdit_ToggleWithoutPermission(t *testing.T) {
+	h, adapter := newCellEditHandler()
+	resource, _ := h.bo.GetResource("cellTicket")
+	resource.Permissions.Update = core.DenyAll
+
+	req := httptest.NewRequest(http.MethodGet, "/admin/cellTicket", nil)
+	w := httptest.NewRecorder()
+	h.indexHandler(w, req)
+	if strings.Contains(w.Body.String(), `data-pw="toggle-Resolved"`) {
+		t.Error("Expected no switch without permission to update")
+	}
+	if w := patchCell(h, "Resolved", url.Values{"Resolved": {"true"}, "version": {"5"}}); w.Code != http.StatusForbidden || adapter.updated != nil {
+		t.Errorf("Expected the toggle to be rejected, got %d", w.Code)
+	}
+}
+
+// TestCellEdit_SavesZeroValues tests that switching a boolean off and clearing a text reach the database,
+// though the SQL adapter's Update skips zero values
+func TestCellEdit_SavesZeroValues(t *testing.T) {
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	if _, err := db.Exec(`CREATE TABLE cell_tickets (id INTEGER PRIMARY KEY, title TEXT NOT NULL, priority INTEGER NOT NULL,
+		notes TEXT NOT NULL, resolved BOOLEAN NOT NULL, version INTEGER NOT NULL)`); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := db.Exec(`INSERT INTO cell_tickets VALUES (7, 'Printer jam', 2, '3rd floor', 1, 5)`); err != nil {
+		t.Fatal(err)
+	}
+
+	bo := core.New(sqladapter.New(db), auth.AuthConfig{})
+	bo.RegisterResource(&cellTicket{}).
+		WithTableName("cell_tickets").
+		WithFields("Title").
+		WithField("Priority", func(f *core.FieldBuilder) { f.EditableInList() }).
+		WithField("Notes", func(f *core.FieldBuilder) { f.EditableInList() }).
+		WithFields("Resolved").
+		WithOptimisticLock("Version")
+	h := &BackOfficeHandler{bo: bo}
+
+	if w := patchCell(h, "Resolved", url.Values{"Resolved": {"false"}, "version": {"5"}}); w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if w := patchCell(h, "Notes", url.Values{"Notes": {""}, "version": {"6"}}); w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if w := patchCell(h, "Priority", url.Values{"Priority": {"0"}, "version": {"7"}}); w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var notes string
+	var resolved bool
+	var priority, version int
+	if err := db.QueryRow(`SELECT notes, resolved, priority, version FROM cell_tickets WHERE id = 7`).Scan(&notes, &resolved, &priority, &version); err != nil {
+		t.Fatal(err)
+	}
+	if resolved || notes != "" || priority != 0 || version != 8 {
+		t.Errorf("Expected the switch off, the notes cleared, no priority and the version bumped each time, got resolved=%v notes=%q priority=%d version=%d", resolved, notes, priority, version)
+	}
+}
@@ -0,0 +1,80 @@
+package ui
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/preslavrachev/backoffice/core"
+	"github.com/preslavrachev/backoffice/middleware/auth"
+)
+
+// chartAdapter answers every aggregation with two weekly buckets
+type chartAdapter struct {
+	dashboardAdapter
+	aggregates []core.AggregateQuery
+}
+
+func (a *chartAdapter) Aggregate(ctx context.Context, resource *core.Resource, query core.AggregateQuery) ([]core.AggregatePoint, error) {
+	a.aggregates = append(a.aggregates, query)
+	return []core.AggregatePoint{{Label: "2026-10-05", Value: 3}, {Label: "2026-10-12", Value: 1}}, nil
+}
+
+func TestPieSlices(t *testing.T) {
+	slices := pieSlices([]core.AggregatePoint{{Label: "pro", Value: 3}, {Label: "free", Value: 1}})
+	if len(slices) != 2 || slices[0].percent != 75 || slices[1].percent != 25 {
+		t.Fatalf("Expected a 75/25 split, got %+v", slices)
+	}
+	if !strings.Contains(slices[0].path, " 1 1 ") {
+		t.Errorf("Expected the larger slice to use a large arc, got %q", slices[0].path)
+	}
+
+	whole := pieSlices([]core.AggregatePoint{{Label: "pro", Value: 5}})
+	if whole[0].percent != 100 || !strings.HasPrefix(whole[0].path, "M 1 0 A") {
+		t.Errorf("Expected a single slice to fill the circle, got %+v", whole[0])
+	}
+}
+
+func TestDashboard_ChartWidget(t *testing.T) {
+	adapter := &chartAdapter{}
+	bo := core.New(adapter, auth.AuthConfig{})
+	bo.RegisterResource(&dashboardInvoice{}).WithFields("Title")
+	bo.Dashboard().AddWidget(core.ChartWidget("Invoices per title", "dashboardInvoice", core.ChartBar,
+		core.AggregateQuery{GroupBy: "Title"}))
+	h := &BackOfficeHandler{bo: bo}
+
+	req := httptest.NewRequest(http.MethodGet, "/admin/", nil)
+	w := httptest.NewRecorder()
+	h.indexHandler(w, req)
+	body := w.Body.String()
+
+	for _, want := range []string{`data-pw="chart-bar"`, "2026-10-05", "Invoices per title"} {
+		if !strings.Contains(body, want) {
+			t.Errorf("Expected %s on the dashboard", want)
+		}
+	}
+	if len(adapter.aggregates) != 1 || adapter.aggregates[0].Func != core.AggregateCount {
+		t.Errorf("Expected one count aggregation, got %+v", adapter.aggregates)
+	}
+}
+
+func TestListCharts(t *testing.T) {
+	adapter := &chartAdapter{}
+	bo := core.New(adapter, auth.AuthConfig{})
+	bo.RegisterResource(&dashboardInvoice{}).WithFields("Title").
+		WithChart("Invoices over time", core.ChartLine, core.AggregateQuery{GroupBy: "Title"})
+	h := &BackOfficeHandler{bo: bo}
+
+	req := httptest.NewRequest(http.MethodGet, "/admin/dashboardInvoice", nil)
+	w := httptest.NewRecorder()
+	h.indexHandler(w, req)
+	body := w.Body.String()
+
+	for _, want := range []string{`data-pw="list-charts"`, `data-pw="chart-line"`, "Invoices over time"} {
+		if !strings.Contains(body, want) {
+			t.Errorf("Expected %s above the list", want)
+		}
+	}
+}
@@ -0,0 +1,55 @@
+package ui
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/preslavrachev/backoffice/core"
+	"github.com/preslavrachev/backoffice/middleware/auth"
+)
+
+type choiceAddress struct {
+	ID      uint   `db:"id"`
+	Country string `db:"country"`
+	State   string `db:"state"`
+}
+
+func TestRenderChoiceSelect(t *testing.T) {
+	bo := core.New(&mockActionAdapter{}, auth.AuthConfig{})
+	bo.RegisterResource(&choiceAddress{}).
+		WithField("Country", func(f *core.FieldBuilder) {
+			f.Choices([]string{"US", "DE"})
+		}).
+		WithField("State", func(f *core.FieldBuilder) {
+			f.DependsOn("Country", map[string][]string{"US": {"CA", "NY"}, "DE": {"BE", "BY"}})
+		})
+	h := &BackOfficeHandler{bo: bo}
+
+	req := httptest.NewRequest(http.MethodGet, "/admin/api/choiceAddress/choices/State?variant=sidepane&Country=DE&State=BY", nil)
+	w := httptest.NewRecorder()
+	h.apiRouter(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	body := w.Body.String()
+	if !strings.Contains(body, `data-pw="sidepane-input-State"`) {
+		t.Error("Expected the side pane variant of the select")
+	}
+	if !strings.Contains(body, `value="BE"`) || strings.Contains(body, `value="NY"`) {
+		t.Errorf("Expected only the German states, got:\n%s", body)
+	}
+	if !strings.Contains(body, `value="BY" selected`) {
+		t.Errorf("Expected the submitted state to stay selected, got:\n%s", body)
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/admin/api/choiceAddress/choices/ID", nil)
+	w = httptest.NewRecorder()
+	h.apiRouter(w, req)
+
+	if w.Code != http.StatusNotFound {
+		t.Errorf("Expected 404 for a field without choices, got %d", w.Code)
+	}
+}
@@ -0,0 +1,226 @@
+// Code generated by templ - DO NOT EDIT.
+
+// templ: version: v0.3.943
+package ui
+
+//lint:file-ignore SA4006 This context is only used if a nested component is present.
+
+import "github.com/a-h/templ"
+import templruntime "github.com/a-h/templ/runtime"
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/preslavrachev/backoffice/core"
+)
+
+// columnsCookiePrefix prefixes the cookies holding each resource's chosen list columns
+const columnsCookiePrefix = "backoffice_columns_"
+
+// columnOption is a list column offered by the columns menu
+type columnOption struct {
+	Name    string `json:"name"`
+	Label   string `json:"label"`
+	Visible bool   `json:"visible"`
+}
+
+// ColumnsMenu renders the dropdown for hiding, showing and reordering list columns
+// The choice is stored in a cookie by the columns endpoint, which then refreshes the page
+func ColumnsMenu(resource *core.Resource) templ.Component {
+	return templruntime.GeneratedTemplate(func(templ_7745c5c3_Input templruntime.GeneratedComponentInput) (templ_7745c5c3_Err error) {
+		templ_7745c5c3_W, ctx := templ_7745c5c3_Input.Writer, templ_7745c5c3_Input.Context
+		if templ_7745c5c3_CtxErr := ctx.Err(); templ_7745c5c3_CtxErr != nil {
+			return templ_7745c5c3_CtxErr
+		}
+		templ_7745c5c3_Buffer, templ_7745c5c3_IsBuffer := templruntime.GetBuffer(templ_7745c5c3_W)
+		if !templ_7745c5c3_IsBuffer {
+			defer func() {
+				templ_7745c5c3_BufErr := templruntime.ReleaseBuffer(templ_7745c5c3_Buffer)
+				if templ_7745c5c3_Err == nil {
+					templ_7745c5c3_Err = templ_7745c5c3_BufErr
+				}
+			}()
+		}
+		ctx = templ.InitializeContext(ctx)
+		templ_7745c5c3_Var1 := templ.GetChildren(ctx)
+		if templ_7745c5c3_Var1 == nil {
+			templ_7745c5c3_Var1 = templ.NopComponent
+		}
+		ctx = templ.ClearChildren(ctx)
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 1, "<div class=\"relative inline-block text-left\" x-data=\"dropdown\" @click.away=\"close\" data-pw=\"columns-menu\"><button type=\"button\" @click=\"toggle\" class=\"bg-white text-gray-700 border border-gray-300 px-4 py-2 rounded hover:bg-gray-50 transition-colors\" data-pw=\"columns-button\">")
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		var templ_7745c5c3_Var2 string
+		templ_7745c5c3_Var2, templ_7745c5c3_Err = templ.JoinStringErrs(msg(ctx, "columns.title"))
+		if templ_7745c5c3_Err != nil {
+			return templ.Error{Err: templ_7745c5c3_Err, FileName: `ui/columns.templ`, Line: 32, Col: 62}
+		}
+		_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var2))
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 2, "</button><form x-show=\"open\" x-data=\"columnsMenu\" data-columns=\"")
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		var templ_7745c5c3_Var3 string
+		templ_7745c5c3_Var3, templ_7745c5c3_Err = templ.JoinStringErrs(columnsMenuData(ctx, resource))
+		if templ_7745c5c3_Err != nil {
+			return templ.Error{Err: templ_7745c5c3_Err, FileName: `ui/columns.templ`, Line: 35, Col: 53}
+		}
+		_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var3))
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 3, "\" hx-post=\"")
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		var templ_7745c5c3_Var4 string
+		templ_7745c5c3_Var4, templ_7745c5c3_Err = templ.JoinStringErrs("/admin/api/" + resource.Name + "/columns")
+		if templ_7745c5c3_Err != nil {
+			return templ.Error{Err: templ_7745c5c3_Err, FileName: `ui/columns.templ`, Line: 36, Col: 60}
+		}
+		_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var4))
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 4, "\" class=\"origin-top-right absolute right-0 z-20 mt-2 w-64 bg-white rounded-md shadow-lg ring-1 ring-black ring-opacity-5 p-2\" style=\"display: none;\" data-pw=\"columns-form\"><ul><template x-for=\"(column, index) in columns\" :key=\"column.name\"><li class=\"flex items-center justify-between px-2 py-1 rounded hover:bg-gray-50\"><label class=\"flex items-center space-x-2 text-sm text-gray-700\"><input type=\"checkbox\" :checked=\"column.visible\" @change=\"toggleColumn\" class=\"rounded border-gray-300\"> <span x-text=\"column.label\"></span></label> <span class=\"space-x-1 text-gray-400\"><button type=\"button\" @click=\"moveUp\" :disabled=\"isFirst\" class=\"hover:text-gray-700 disabled:opacity-30\" aria-label=\"")
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		var templ_7745c5c3_Var5 string
+		templ_7745c5c3_Var5, templ_7745c5c3_Err = templ.JoinStringErrs(msg(ctx, "columns.move_up"))
+		if templ_7745c5c3_Err != nil {
+			return templ.Error{Err: templ_7745c5c3_Err, FileName: `ui/columns.templ`, Line: 48, Col: 153}
+		}
+		_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var5))
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 5, "\">↑</button> <button type=\"button\" @click=\"moveDown\" :disabled=\"isLast\" class=\"hover:text-gray-700 disabled:opacity-30\" aria-label=\"")
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		var templ_7745c5c3_Var6 string
+		templ_7745c5c3_Var6, templ_7745c5c3_Err = templ.JoinStringErrs(msg(ctx, "columns.move_down"))
+		if templ_7745c5c3_Err != nil {
+			return templ.Error{Err: templ_7745c5c3_Err, FileName: `ui/columns.templ`, Line: 49, Col: 156}
+		}
+		_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var6))
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 6, "\">↓</button></span></li></template></ul><template x-for=\"column in visibleColumns\" :key=\"column.name\"><input type=\"hidden\" name=\"columns\" :value=\"column.name\"></template><div class=\"mt-2 pt-2 border-t border-gray-200 flex items-center justify-between\"><button type=\"button\" hx-post=\"")
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		var templ_7745c5c3_Var7 string
+		templ_7745c5c3_Var7, templ_7745c5c3_Err = templ.JoinStringErrs("/admin/api/" + resource.Name + "/columns")
+		if templ_7745c5c3_Err != nil {
+			return templ.Error{Err: templ_7745c5c3_Err, FileName: `ui/columns.templ`, Line: 59, Col: 64}
+		}
+		_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var7))
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 7, "\" hx-vals='{\"reset\": \"true\"}' class=\"text-sm text-gray-600 hover:text-gray-900\" data-pw=\"columns-reset\">")
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		var templ_7745c5c3_Var8 string
+		templ_7745c5c3_Var8, templ_7745c5c3_Err = templ.JoinStringErrs(msg(ctx, "columns.reset"))
+		if templ_7745c5c3_Err != nil {
+			return templ.Error{Err: templ_7745c5c3_Err, FileName: `ui/columns.templ`, Line: 62, Col: 63}
+		}
+		_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var8))
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 8, "</button> <button type=\"submit\" :disabled=\"noneVisible\" class=\"bg-blue-600 text-white px-3 py-1 rounded hover:bg-blue-700 text-sm disabled:opacity-50\" data-pw=\"columns-apply\">")
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		var templ_7745c5c3_Var9 string
+		templ_7745c5c3_Var9, templ_7745c5c3_Err = templ.JoinStringErrs(msg(ctx, "action.apply"))
+		if templ_7745c5c3_Err != nil {
+			return templ.Error{Err: templ_7745c5c3_Err, FileName: `ui/columns.templ`, Line: 66, Col: 62}
+		}
+		_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var9))
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 9, "</button></div></form></div>")
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		return nil
+	})
+}
+
+// columnsMenuData encodes the columns of the columns menu: chosen columns first, in order, then the hidden ones
+func columnsMenuData(ctx context.Context, resource *core.Resource) string {
+	var options []columnOption
+	shown := make(map[string]bool)
+	for _, field := range listFields(ctx, resource) {
+		options = append(options, columnOption{Name: field.Name, Label: field.DisplayName, Visible: true})
+		shown[field.Name] = true
+	}
+	for _, field := range resource.Fields {
+		if !shown[field.Name] {
+			options = append(options, columnOption{Name: field.Name, Label: field.DisplayName})
+		}
+	}
+
+	columns, _ := json.Marshal(options)
+	return string(columns)
+}
+
+// listFields returns the fields shown as list columns, honoring the user's column choice
+func listFields(ctx context.Context, resource *core.Resource) []core.FieldInfo {
+	names, _ := ctx.Value("listColumns").([]string)
+	if len(names) == 0 {
+		return resource.Fields
+	}
+
+	fields := make([]core.FieldInfo, 0, len(names))
+	for _, name := range names {
+		if field, ok := resource.GetField(name); ok {
+			fields = append(fields, *field)
+		}
+	}
+	return fields
+}
+
+// columnsCookieName returns the cookie holding the chosen list columns of a resource
+func columnsCookieName(resource *core.Resource) string {
+	return columnsCookiePrefix + resource.Name
+}
+
+// listColumnsFromRequest returns the valid column names stored in the request's columns cookie
+func listColumnsFromRequest(r *http.Request, resource *core.Resource) []string {
+	cookie, err := r.Cookie(columnsCookieName(resource))
+	if err != nil {
+		return nil
+	}
+	return validColumns(resource, strings.Split(cookie.Value, "."))
+}
+
+// validColumns keeps the names of the resource's fields, dropping unknown and repeated ones
+func validColumns(resource *core.Resource, names []string) []string {
+	var columns []string
+	seen := make(map[string]bool)
+	for _, name := range names {
+		if _, ok := resource.GetField(name); ok && !seen[name] {
+			columns = append(columns, name)
+			seen[name] = true
+		}
+	}
+	return columns
+}
+
+var _ = templruntime.GeneratedTemplate
@@ -0,0 +1,42 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/preslavrachev/backoffice/core"
+	"github.com/preslavrachev/backoffice/middleware/auth"
+)
+
+func TestCopyButtons(t *testing.T) {
+	bo := core.New(&overrideAdapter{}, auth.AuthConfig{})
+	bo.RegisterResource(&overrideNote{}).
+		WithFields("ID", "Title").
+		WithField("Title", func(f *core.FieldBuilder) {
+			f.Copyable()
+		})
+	h := Handler(bo, "/admin")
+
+	for _, path := range []string{"/admin/overrideNote", "/admin/overrideNote/1"} {
+		body := getPage(h, path)
+		for _, want := range []string{
+			`data-copy="Groceries"`,
+			`data-pw="copy-Title"`,
+			`data-pw="copy-ID"`,
+			`data-copied="Copied to clipboard"`,
+		} {
+			if !strings.Contains(body, want) {
+				t.Errorf("%s: expected %q", path, want)
+			}
+		}
+	}
+}
+
+func TestCopyButtons_OnlyForCopyableFields(t *testing.T) {
+	h := newOverrideHandler()
+
+	body := getPage(h, "/admin/overrideNote")
+	if strings.Contains(body, `data-pw="copy-Title"`) {
+		t.Error("Expected no copy button on a field not marked copyable")
+	}
+}
@@ -0,0 +1,54 @@
+package ui
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"net/http"
+	"strings"
+
+	"github.com/a-h/templ"
+)
+
+// cspMiddleware sends the configured Content-Security-Policy with a fresh nonce per request
+// Pages read the nonce from the request context ("cspNonce") and add it to their script tags
+func (h *BackOfficeHandler) cspMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		csp := h.bo.GetConfig().CSP
+		if !csp.Enabled {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		nonce, err := generateCSPNonce()
+		if err != nil {
+			http.Error(w, "Failed to generate CSP nonce", http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set(csp.HeaderName(), strings.ReplaceAll(csp.PolicyOrDefault(), "{nonce}", nonce))
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), "cspNonce", nonce)))
+	})
+}
+
+// generateCSPNonce returns a new random nonce
+func generateCSPNonce() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(b), nil
+}
+
+// cspNonce returns the request's CSP nonce, empty without a policy
+func cspNonce(ctx context.Context) string {
+	nonce, _ := ctx.Value("cspNonce").(string)
+	return nonce
+}
+
+// scriptNonce returns the nonce attribute of script tags, none without a policy
+func scriptNonce(ctx context.Context) templ.Attributes {
+	if nonce := cspNonce(ctx); nonce != "" {
+		return templ.Attributes{"nonce": nonce}
+	}
+	return nil
+}
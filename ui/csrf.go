@@ -0,0 +1,102 @@
+package ui
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+
+	"github.com/preslavrachev/backoffice/middleware/auth"
+)
+
+const (
+	csrfCookieName = "backoffice_csrf" // Cookie holding the request forgery token
+	csrfHeaderName = "X-CSRF-Token"    // Header HTMX sends the token in
+	csrfFormField  = "csrf_token"      // Hidden field plain forms send the token in
+)
+
+// csrfMiddleware rejects state-changing requests that don't echo the token from the CSRF cookie
+// The token is double-submitted: a cross-site page can send the cookie but can't read it to
+// repeat it in the header or form. Pages read it from the request context ("csrfToken")
+func (h *BackOfficeHandler) csrfMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token := ""
+		if cookie, err := r.Cookie(csrfCookieName); err == nil {
+			token = cookie.Value
+		}
+		if token == "" {
+			var err error
+			if token, err = generateCSRFToken(); err != nil {
+				http.Error(w, "Failed to generate CSRF token", http.StatusInternalServerError)
+				return
+			}
+			// The token cookie shares the session cookie's domain, path and security
+			http.SetCookie(w, h.bo.GetAuth().CompanionCookie(r, csrfCookieName, token))
+		}
+		r = r.WithContext(context.WithValue(r.Context(), "csrfToken", token))
+
+		if !isSafeMethod(r.Method) && !validCSRFToken(r, token) {
+			h.writeCSRFError(w, h.withOverrides(r))
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// isSafeMethod reports whether the method only reads, so it needs no CSRF token
+func isSafeMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace:
+		return true
+	}
+	return false
+}
+
+// validCSRFToken reports whether the request repeats the cookie's token in the header or form
+func validCSRFToken(r *http.Request, token string) bool {
+	submitted := r.Header.Get(csrfHeaderName)
+	if submitted == "" {
+		submitted = r.FormValue(csrfFormField)
+	}
+	return submitted != "" && subtle.ConstantTimeCompare([]byte(submitted), []byte(token)) == 1
+}
+
+// generateCSRFToken returns a new random token
+func generateCSRFToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// writeCSRFError explains a rejected request: as a toast for HTMX, as a page otherwise
+func (h *BackOfficeHandler) writeCSRFError(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get("HX-Request") == "true" {
+		trigger, _ := json.Marshal(map[string]any{
+			"showToast": map[string]string{"message": msg(r.Context(), "csrf.message"), "type": "error"},
+		})
+		w.Header().Set("HX-Trigger", string(trigger))
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(http.StatusForbidden)
+	user, _ := auth.GetAuthUser(r.Context())
+	BrandedLayout(h.bo.GetConfig(), msg(r.Context(), "csrf.title"), CSRFErrorPage(r.Referer()), user, nil, "").Render(r.Context(), w)
+}
+
+// csrfToken returns the token pages send back with state-changing requests
+func csrfToken(ctx context.Context) string {
+	token, _ := ctx.Value("csrfToken").(string)
+	return token
+}
+
+// csrfHeaders is the hx-headers value making every HTMX request carry the token
+func csrfHeaders(ctx context.Context) string {
+	headers, _ := json.Marshal(map[string]string{csrfHeaderName: csrfToken(ctx)})
+	return string(headers)
+}
@@ -0,0 +1,103 @@
+package ui
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/preslavrachev/backoffice/core"
+	"github.com/preslavrachev/backoffice/middleware/auth"
+)
+
+// testCSRFToken is the token withCSRFToken sends as both cookie and header
+const testCSRFToken = "test-csrf-token"
+
+// withCSRFToken makes a test request carry a matching CSRF cookie and header
+func withCSRFToken(r *http.Request) {
+	r.AddCookie(&http.Cookie{Name: csrfCookieName, Value: testCSRFToken})
+	r.Header.Set(csrfHeaderName, testCSRFToken)
+}
+
+func TestCSRF_TokenInPages(t *testing.T) {
+	h := newOverrideHandler()
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/admin/overrideNote/new", nil))
+
+	var token string
+	for _, cookie := range w.Result().Cookies() {
+		if cookie.Name == csrfCookieName {
+			token = cookie.Value
+		}
+	}
+	if token == "" {
+		t.Fatal("Expected the first request to set a CSRF cookie")
+	}
+	body := w.Body.String()
+	if !strings.Contains(body, `hx-headers="{&#34;X-CSRF-Token&#34;:&#34;`+token+`&#34;}"`) {
+		t.Error("Expected HTMX requests to send the token in a header")
+	}
+	if !strings.Contains(body, `<input type="hidden" name="csrf_token" value="`+token+`">`) {
+		t.Error("Expected the plain create form to carry the token")
+	}
+}
+
+func TestCSRF_CookieFollowsSessionCookie(t *testing.T) {
+	authConfig := auth.AuthConfig{Cookie: auth.CookieConfig{Domain: "example.com", Path: "/admin"}}
+	bo := core.New(&overrideAdapter{}, authConfig)
+	bo.RegisterResource(&overrideNote{}).WithFields("Title")
+	h := Handler(bo, "/admin")
+
+	r := httptest.NewRequest(http.MethodGet, "/admin/overrideNote/new", nil)
+	r.Header.Set("X-Forwarded-Proto", "https")
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+
+	var cookie *http.Cookie
+	for _, c := range w.Result().Cookies() {
+		if c.Name == csrfCookieName {
+			cookie = c
+		}
+	}
+	if cookie == nil {
+		t.Fatal("Expected a CSRF cookie")
+	}
+	if cookie.Domain != "example.com" || cookie.Path != "/admin" || !cookie.Secure || !cookie.HttpOnly {
+		t.Errorf("Expected the CSRF cookie to share the session cookie's attributes, got %+v", cookie)
+	}
+}
+
+func TestCSRF_RejectsMissingOrWrongToken(t *testing.T) {
+	h := newOverrideHandler()
+	form := url.Values{"Title": {"Groceries"}}
+
+	post := func(form url.Values, prepare func(r *http.Request)) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(http.MethodPost, "/admin/overrideNote", strings.NewReader(form.Encode()))
+		r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		r.AddCookie(&http.Cookie{Name: csrfCookieName, Value: testCSRFToken})
+		prepare(r)
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+		return w
+	}
+
+	w := post(form, func(r *http.Request) {})
+	if w.Code != http.StatusForbidden || !strings.Contains(w.Body.String(), `data-pw="csrf-error"`) {
+		t.Errorf("Expected the error page without a token, got %d", w.Code)
+	}
+
+	w = post(form, func(r *http.Request) {
+		r.Header.Set(csrfHeaderName, "forged")
+		r.Header.Set("HX-Request", "true")
+	})
+	if w.Code != http.StatusForbidden || !strings.Contains(w.Header().Get("HX-Trigger"), "expired") {
+		t.Errorf("Expected an error toast for HTMX requests with a wrong token, got %d %q", w.Code, w.Header().Get("HX-Trigger"))
+	}
+
+	form.Set(csrfFormField, testCSRFToken)
+	if w = post(form, func(r *http.Request) {}); w.Code == http.StatusForbidden {
+		t.Error("Expected the token from the form field to be accepted")
+	}
+}
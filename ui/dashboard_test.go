@@ -0,0 +1,79 @@
+package ui
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/preslavrachev/backoffice/core"
+	"github.com/preslavrachev/backoffice/middleware/auth"
+)
+
+type dashboardInvoice struct {
+	ID    uint   `db:"id"`
+	Title string `db:"title"`
+}
+
+// dashboardAdapter serves two invoices and reports 42 matching records
+type dashboardAdapter struct {
+	mockActionAdapter
+	queries []*core.Query
+}
+
+func (a *dashboardAdapter) Find(ctx context.Context, resource *core.Resource, query *core.Query) (*core.Result, error) {
+	a.queries = append(a.queries, query)
+	return &core.Result{
+		Items:      []any{&dashboardInvoice{ID: 2, Title: "INV-002"}, &dashboardInvoice{ID: 1, Title: "INV-001"}},
+		TotalCount: 42,
+	}, nil
+}
+
+func TestDashboard_Widgets(t *testing.T) {
+	adapter := &dashboardAdapter{}
+	bo := core.New(adapter, auth.AuthConfig{})
+	bo.RegisterResource(&dashboardInvoice{})
+	bo.Dashboard().
+		AddWidget(core.StatCard("Invoices", "dashboardInvoice")).
+		AddWidget(core.StatCardFunc("Revenue", func(ctx context.Context) (string, error) { return "$1,200", nil })).
+		AddWidget(core.RecentRecords("Latest invoices", "dashboardInvoice", 2)).
+		AddWidget(core.CustomWidget("Notes", textComponent(`<p data-pw="custom-widget">Quarter closes Friday</p>`, nil)))
+	h := &BackOfficeHandler{bo: bo}
+
+	req := httptest.NewRequest(http.MethodGet, "/admin/", nil)
+	w := httptest.NewRecorder()
+	h.indexHandler(w, req)
+	body := w.Body.String()
+
+	for _, want := range []string{
+		`data-pw="dashboard"`,
+		"42",
+		"$1,200",
+		`href="/admin/dashboardInvoice/2"`,
+		"INV-002",
+		`<p data-pw="custom-widget">Quarter closes Friday</p>`,
+		"Registered Resources",
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("Expected %s on the dashboard", want)
+		}
+	}
+	if len(adapter.queries) != 2 || adapter.queries[1].Pagination.Limit != 2 {
+		t.Errorf("Expected a count query and a recent-records query limited to 2, got %d queries", len(adapter.queries))
+	}
+}
+
+func TestDashboard_HidesForbiddenResources(t *testing.T) {
+	bo := core.New(&dashboardAdapter{}, auth.AuthConfig{})
+	bo.RegisterResource(&dashboardInvoice{}).WithPermissions(core.Permissions{List: core.DenyAll})
+	bo.Dashboard().AddWidget(core.StatCard("Invoices", "dashboardInvoice"))
+	h := &BackOfficeHandler{bo: bo}
+
+	req := httptest.NewRequest(http.MethodGet, "/admin/", nil)
+	w := httptest.NewRecorder()
+	h.indexHandler(w, req)
+	if strings.Contains(w.Body.String(), `data-pw="dashboard-widget-stat"`) {
+		t.Error("Expected widgets of resources the user can't list to be hidden")
+	}
+}
@@ -0,0 +1,133 @@
+package ui
+
+import (
+	"context"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/preslavrachev/backoffice/core"
+	"github.com/preslavrachev/backoffice/middleware/auth"
+)
+
+type scheduledVisit struct {
+	ID       uint       `db:"id"`
+	StartsAt time.Time  `db:"starts_at"`
+	DueOn    *time.Time `db:"due_on"`
+}
+
+func newVisitBackOffice(visit *scheduledVisit) *core.BackOffice {
+	adapter := &mockActionAdapter{getByIDFunc: func(ctx context.Context, resource *core.Resource, id any) (any, error) {
+		return visit, nil
+	}}
+	bo := core.New(adapter, auth.AuthConfig{})
+	bo.GetConfig().TimeZone = time.FixedZone("CEST", 2*60*60)
+	bo.RegisterResource(&scheduledVisit{}).
+		WithFields("StartsAt").
+		WithField("DueOn", func(f *core.FieldBuilder) { f.AsDate() })
+	return bo
+}
+
+func TestTimeInputs(t *testing.T) {
+	due := time.Date(2026, 10, 9, 23, 0, 0, 0, time.UTC)
+	visit := &scheduledVisit{ID: 1, StartsAt: time.Date(2026, 10, 5, 8, 15, 0, 0, time.UTC), DueOn: &due}
+	h := Handler(newVisitBackOffice(visit), "/admin")
+
+	for _, path := range []string{"/admin/api/scheduledVisit/1/edit", "/admin/scheduledVisit/1/edit"} {
+		form := getPage(h, path)
+		for _, want := range []string{
+			`type="datetime-local" name="StartsAt" id="StartsAt" value="2026-10-05T10:15"`,
+			`type="date" name="DueOn" id="DueOn" value="2026-10-10"`,
+			`-DueOn-clear"`,
+			`>CEST</span>`,
+		} {
+			if !strings.Contains(form, want) {
+				t.Errorf("Expected %s in %s", want, path)
+			}
+		}
+		if strings.Contains(form, `-StartsAt-clear"`) {
+			t.Errorf("Expected no clear button for a non-nullable time in %s", path)
+		}
+	}
+}
+
+func TestSaveTimeInputs(t *testing.T) {
+	bo := newVisitBackOffice(nil)
+	resource, _ := bo.GetResource("scheduledVisit")
+	h := &BackOfficeHandler{bo: bo}
+
+	form := url.Values{"StartsAt": {"2026-10-05T10:15"}, "DueOn": {"2026-10-10"}}
+	r := httptest.NewRequest("POST", "/admin/api/scheduledVisit/new", strings.NewReader(form.Encode()))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	item, err := h.formToStruct(r, resource)
+	if err != nil {
+		t.Fatalf("Expected the times to parse, got %v", err)
+	}
+	visit := item.(*scheduledVisit)
+	if !visit.StartsAt.Equal(time.Date(2026, 10, 5, 8, 15, 0, 0, time.UTC)) {
+		t.Errorf("Expected the time to be read in the configured zone, got %v", visit.StartsAt)
+	}
+	if visit.DueOn == nil || !visit.DueOn.Equal(time.Date(2026, 10, 9, 22, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected midnight of the date in the configured zone, got %v", visit.DueOn)
+	}
+
+	// Clearing the nullable date saves it as nil and leaves the non-nullable time alone
+	cleared := url.Values{"StartsAt": {""}, "DueOn": {""}}
+	r = httptest.NewRequest("POST", "/admin/api/scheduledVisit/1", strings.NewReader(cleared.Encode()))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	if err := h.applyFormFields(r, visit, resource.Fields); err != nil {
+		t.Fatalf("Expected the form to apply, got %v", err)
+	}
+	if visit.DueOn != nil || visit.StartsAt.IsZero() {
+		t.Errorf("Expected DueOn cleared and StartsAt kept, got %v and %v", visit.DueOn, visit.StartsAt)
+	}
+
+	invalid := url.Values{"StartsAt": {"next week"}}
+	r = httptest.NewRequest("POST", "/admin/api/scheduledVisit/new", strings.NewReader(invalid.Encode()))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	if _, err := h.formToStruct(r, resource); err == nil {
+		t.Error("Expected an error for an invalid time")
+	}
+}
+
+func TestHumanizeTime(t *testing.T) {
+	ctx := context.WithValue(context.Background(), "timeZone", time.UTC)
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		at   time.Time
+		want string
+	}{
+		{now.Add(-20 * time.Second), "just now"},
+		{now.Add(-time.Minute), "1 minute ago"},
+		{now.Add(-3 * time.Hour), "3 hours ago"},
+		{now.Add(-50 * time.Hour), "2 days ago"},
+		{now.Add(90 * time.Minute), "in 1 hour"},
+		{time.Date(2025, 1, 2, 9, 0, 0, 0, time.UTC), "Jan 2, 2025"},
+	}
+	for _, tt := range tests {
+		if got := humanizeTime(ctx, tt.at, now); got != tt.want {
+			t.Errorf("Expected %q for %v, got %q", tt.want, tt.at, got)
+		}
+	}
+}
+
+func TestRelativeTimeField(t *testing.T) {
+	visit := &scheduledVisit{ID: 1, StartsAt: time.Date(2025, 1, 2, 9, 30, 0, 0, time.UTC)}
+	adapter := &mockActionAdapter{getByIDFunc: func(ctx context.Context, resource *core.Resource, id any) (any, error) {
+		return visit, nil
+	}}
+	bo := core.New(adapter, auth.AuthConfig{})
+	bo.GetConfig().TimeZone = time.FixedZone("CEST", 2*60*60)
+	bo.RegisterResource(&scheduledVisit{}).
+		WithField("StartsAt", func(f *core.FieldBuilder) { f.AsRelativeTime() })
+	h := Handler(bo, "/admin")
+
+	body := getPage(h, "/admin/scheduledVisit/1")
+	want := `<time datetime="2025-01-02T09:30:00Z" title="Jan 2, 2025 11:30:00 CEST" data-pw="relative-time">Jan 2, 2025</time>`
+	if !strings.Contains(body, want) {
+		t.Errorf("Expected the relative time with its exact time tooltip, got %s", body)
+	}
+}
@@ -0,0 +1,40 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/a-h/templ"
+	"github.com/preslavrachev/backoffice/core"
+	"github.com/preslavrachev/backoffice/middleware/auth"
+)
+
+// subscriptionPanel renders a summary from the record and the request, like a live billing lookup would
+func subscriptionPanel(item any) templ.Component {
+	note := item.(*overrideNote)
+	return templ.ComponentFunc(func(ctx context.Context, w io.Writer) error {
+		_, err := fmt.Fprintf(w, "<p>Plan of %s: %s</p>", note.Title, msg(ctx, "action.save"))
+		return err
+	})
+}
+
+func TestDetailPanels(t *testing.T) {
+	for _, tabs := range [][]core.DetailTab{nil, {core.TabOverview, core.TabJSON}} {
+		bo := core.New(&overrideAdapter{}, auth.AuthConfig{})
+		bo.RegisterResource(&overrideNote{}).WithFields("Title").
+			WithDetailTabs(tabs...).
+			WithDetailPanel(subscriptionPanel).
+			WithDetailPanel(func(item any) templ.Component { return nil })
+		body := getPage(Handler(bo, "/admin"), "/admin/overrideNote/1")
+
+		if !strings.Contains(body, `data-pw="detail-panel-0"`) || !strings.Contains(body, "<p>Plan of Groceries: Save</p>") {
+			t.Errorf("Expected the custom panel on the detail page with tabs %v", tabs)
+		}
+		if strings.Contains(body, `data-pw="detail-panel-1"`) {
+			t.Errorf("Expected panels returning nil to be left out with tabs %v", tabs)
+		}
+	}
+}
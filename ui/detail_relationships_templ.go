@@ -5,11 +5,11 @@ package ui
 
 //lint:file-ignore SA4006 This context is only used if a nested component is present.
 
+import "github.com/a-h/templ"
+import templruntime "github.com/a-h/templ/runtime"
+
 import (
 	"fmt"
-
-	"github.com/a-h/templ"
-	templruntime "github.com/a-h/templ/runtime"
 	"github.com/preslavrachev/backoffice/core"
 )
 
@@ -85,9 +85,9 @@ func CardBasedRelationshipPanel(item interface{}, field core.FieldInfo, resource
 					return templ_7745c5c3_Err
 				}
 				var templ_7745c5c3_Var5 string
-				templ_7745c5c3_Var5, templ_7745c5c3_Err = templ.JoinStringErrs(getRelatedDisplayValue(item, field.Name, field.Relationship.DisplayField))
+				templ_7745c5c3_Var5, templ_7745c5c3_Err = templ.JoinStringErrs(relatedDisplayValue(item, field))
 				if templ_7745c5c3_Err != nil {
-					return templ.Error{Err: templ_7745c5c3_Err, FileName: `ui/detail_relationships.templ`, Line: 40, Col: 129}
+					return templ.Error{Err: templ_7745c5c3_Err, FileName: `ui/detail_relationships.templ`, Line: 40, Col: 88}
 				}
 				_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var5))
 				if templ_7745c5c3_Err != nil {
@@ -370,9 +370,9 @@ func InlineRelationshipEditor(item interface{}, field core.FieldInfo, resourceNa
 					return templ_7745c5c3_Err
 				}
 				var templ_7745c5c3_Var21 string
-				templ_7745c5c3_Var21, templ_7745c5c3_Err = templ.JoinStringErrs(getInitials(getRelatedDisplayValue(item, field.Name, field.Relationship.DisplayField)))
+				templ_7745c5c3_Var21, templ_7745c5c3_Err = templ.JoinStringErrs(getInitials(relatedDisplayValue(item, field)))
 				if templ_7745c5c3_Err != nil {
-					return templ.Error{Err: templ_7745c5c3_Err, FileName: `ui/detail_relationships.templ`, Line: 132, Col: 144}
+					return templ.Error{Err: templ_7745c5c3_Err, FileName: `ui/detail_relationships.templ`, Line: 132, Col: 103}
 				}
 				_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var21))
 				if templ_7745c5c3_Err != nil {
@@ -383,9 +383,9 @@ func InlineRelationshipEditor(item interface{}, field core.FieldInfo, resourceNa
 					return templ_7745c5c3_Err
 				}
 				var templ_7745c5c3_Var22 string
-				templ_7745c5c3_Var22, templ_7745c5c3_Err = templ.JoinStringErrs(getRelatedDisplayValue(item, field.Name, field.Relationship.DisplayField))
+				templ_7745c5c3_Var22, templ_7745c5c3_Err = templ.JoinStringErrs(relatedDisplayValue(item, field))
 				if templ_7745c5c3_Err != nil {
-					return templ.Error{Err: templ_7745c5c3_Err, FileName: `ui/detail_relationships.templ`, Line: 135, Col: 122}
+					return templ.Error{Err: templ_7745c5c3_Err, FileName: `ui/detail_relationships.templ`, Line: 135, Col: 81}
 				}
 				_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var22))
 				if templ_7745c5c3_Err != nil {
@@ -615,9 +615,9 @@ func SidebarRelationshipSummary(item interface{}, field core.FieldInfo, resource
 					return templ_7745c5c3_Err
 				}
 				var templ_7745c5c3_Var36 string
-				templ_7745c5c3_Var36, templ_7745c5c3_Err = templ.JoinStringErrs(getInitials(getRelatedDisplayValue(item, field.Name, field.Relationship.DisplayField)))
+				templ_7745c5c3_Var36, templ_7745c5c3_Err = templ.JoinStringErrs(getInitials(relatedDisplayValue(item, field)))
 				if templ_7745c5c3_Err != nil {
-					return templ.Error{Err: templ_7745c5c3_Err, FileName: `ui/detail_relationships.templ`, Line: 200, Col: 141}
+					return templ.Error{Err: templ_7745c5c3_Err, FileName: `ui/detail_relationships.templ`, Line: 200, Col: 100}
 				}
 				_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var36))
 				if templ_7745c5c3_Err != nil {
@@ -628,9 +628,9 @@ func SidebarRelationshipSummary(item interface{}, field core.FieldInfo, resource
 					return templ_7745c5c3_Err
 				}
 				var templ_7745c5c3_Var37 string
-				templ_7745c5c3_Var37, templ_7745c5c3_Err = templ.JoinStringErrs(getRelatedDisplayValue(item, field.Name, field.Relationship.DisplayField))
+				templ_7745c5c3_Var37, templ_7745c5c3_Err = templ.JoinStringErrs(relatedDisplayValue(item, field))
 				if templ_7745c5c3_Err != nil {
-					return templ.Error{Err: templ_7745c5c3_Err, FileName: `ui/detail_relationships.templ`, Line: 204, Col: 136}
+					return templ.Error{Err: templ_7745c5c3_Err, FileName: `ui/detail_relationships.templ`, Line: 204, Col: 95}
 				}
 				_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var37))
 				if templ_7745c5c3_Err != nil {
@@ -0,0 +1,51 @@
+package ui
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/preslavrachev/backoffice/core"
+	"github.com/preslavrachev/backoffice/middleware/auth"
+)
+
+func TestDetailTabs(t *testing.T) {
+	history := core.NewMemoryHistoryStore()
+	if err := history.SaveVersion(context.Background(), &core.RecordVersion{
+		Resource: "overrideNote",
+		RecordID: "1",
+		Snapshot: map[string]any{"Title": "Shopping"},
+	}); err != nil {
+		t.Fatalf("Failed to save version: %v", err)
+	}
+
+	bo := core.New(&overrideAdapter{}, auth.AuthConfig{})
+	bo.RegisterResource(&overrideNote{}).WithFields("Title").
+		WithHistory(history).
+		WithDetailTabs(core.TabOverview, core.TabHistory, core.TabJSON)
+	body := getPage(Handler(bo, "/admin"), "/admin/overrideNote/1")
+
+	for _, want := range []string{
+		`data-pw="detail-tabs"`,
+		`data-pw="detail-tab-panel-overview"`,
+		"Shopping",
+		`action="/admin/overrideNote/1/history"`,
+		`data-pw="detail-json"`,
+		`&#34;Title&#34;: &#34;Groceries&#34;`,
+		"Raw JSON",
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("Expected %s on the tabbed detail page", want)
+		}
+	}
+	if strings.Contains(body, `data-pw="detail-tab-related"`) {
+		t.Error("Expected only the configured tabs")
+	}
+}
+
+func TestDetailTabs_Untabbed(t *testing.T) {
+	body := getPage(newOverrideHandler(), "/admin/overrideNote/1")
+	if strings.Contains(body, `data-pw="detail-tabs"`) || !strings.Contains(body, "Groceries") {
+		t.Error("Expected resources without tabs to keep the single detail page")
+	}
+}
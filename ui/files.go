@@ -0,0 +1,118 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/preslavrachev/backoffice/core"
+)
+
+// fileHandler serves the files of file fields at /files/{resource}/{id}/{field}
+// The URL must carry an unexpired signature from core.Resource.FileURL, and the user must still be allowed
+// to view the record, so a leaked link stops working once it expires
+func (h *BackOfficeHandler) fileHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		h.writeHTTPError(w, msg(r.Context(), "error.method"), http.StatusMethodNotAllowed)
+		return
+	}
+
+	segments := strings.Split(strings.TrimPrefix(r.URL.EscapedPath(), h.bo.GetConfig().BasePath+"/files/"), "/")
+	if len(segments) != 3 {
+		http.NotFound(w, r)
+		return
+	}
+	parts := make([]string, len(segments))
+	for i, segment := range segments {
+		part, err := url.PathUnescape(segment)
+		if err != nil {
+			http.NotFound(w, r)
+			return
+		}
+		parts[i] = part
+	}
+	resourceName, idStr, fieldName := parts[0], parts[1], parts[2]
+
+	query := r.URL.Query()
+	expires := query.Get("expires")
+	if err := h.bo.VerifyFileURL(resourceName, idStr, fieldName, expires, query.Get("signature")); err != nil {
+		key := "file.invalid"
+		if errors.Is(err, core.ErrFileURLExpired) {
+			key = "file.expired"
+		}
+		h.writeHTTPError(w, msg(ctx, key), http.StatusForbidden)
+		return
+	}
+
+	resource, ok := h.bo.GetResource(resourceName)
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	field, ok := resource.GetField(fieldName)
+	if !ok || !field.IsFile() {
+		http.NotFound(w, r)
+		return
+	}
+	if !resource.Can(ctx, core.OperationList) {
+		h.writeHTTPError(w, msg(r.Context(), "error.forbidden", core.OperationList, resource.PluralName), http.StatusForbidden)
+		return
+	}
+	id, err := resource.ParseID(idStr)
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+	if err := resource.Authorize(ctx, core.OperationList, id); err != nil {
+		h.writeHTTPError(w, err.Error(), http.StatusForbidden)
+		return
+	}
+	adapter := h.bo.GetAdapter()
+	if err := core.CheckScope(ctx, adapter, resource, id); err != nil {
+		http.NotFound(w, r)
+		return
+	}
+	item, err := adapter.GetByID(ctx, resource, id)
+	if err != nil || field.FileKey(item) == "" {
+		http.NotFound(w, r)
+		return
+	}
+
+	key := field.FileKey(item)
+	file, err := field.FileStore.Open(ctx, key)
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+	defer file.Close()
+
+	name := path.Base(key)
+	contentType := mime.TypeByExtension(path.Ext(name))
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	// Only images are shown inline; anything else is downloaded, so uploaded HTML can't run in the admin's origin
+	disposition := "attachment"
+	if strings.HasPrefix(contentType, "image/") && contentType != "image/svg+xml" {
+		disposition = "inline"
+	}
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Disposition", mime.FormatMediaType(disposition, map[string]string{"filename": name}))
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	if unix, err := strconv.ParseInt(expires, 10, 64); err == nil {
+		w.Header().Set("Cache-Control", fmt.Sprintf("private, max-age=%d", max(0, int(time.Until(time.Unix(unix, 0)).Seconds()))))
+	}
+	if r.Method == http.MethodHead {
+		return
+	}
+	io.Copy(w, file)
+}
@@ -0,0 +1,68 @@
+package ui
+
+import (
+	"context"
+	"html"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/preslavrachev/backoffice/core"
+	"github.com/preslavrachev/backoffice/middleware/auth"
+)
+
+type invoiceDocument struct {
+	ID   uint   `db:"id"`
+	File string `db:"file"`
+}
+
+func TestFileFields(t *testing.T) {
+	store := core.NewFSFileStore(fstest.MapFS{
+		"invoices/2024/march.pdf": {Data: []byte("%PDF-1.7")},
+	})
+	doc := &invoiceDocument{ID: 4, File: "invoices/2024/march.pdf"}
+	adapter := &mockActionAdapter{getByIDFunc: func(ctx context.Context, resource *core.Resource, id any) (any, error) {
+		return doc, nil
+	}}
+	bo := core.New(adapter, auth.AuthConfig{})
+	bo.RegisterResource(&invoiceDocument{}).
+		WithField("File", func(f *core.FieldBuilder) { f.AsFile(store) })
+	h := Handler(bo, "/admin")
+
+	detail := getPage(h, "/admin/invoiceDocument/4")
+	if strings.Contains(detail, "invoices/2024") {
+		t.Error("Expected the storage path to stay out of the detail page")
+	}
+	link := regexp.MustCompile(`href="(/admin/files/[^"]+)"[^>]*data-pw="file-link-File"`).FindStringSubmatch(detail)
+	if link == nil || !strings.Contains(detail, "march.pdf") {
+		t.Fatalf("Expected a download link named after the file, got %s", detail)
+	}
+	href := html.UnescapeString(link[1])
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, href, nil))
+	if w.Code != http.StatusOK || w.Body.String() != "%PDF-1.7" {
+		t.Fatalf("Expected the signed URL to download the file, got %d: %s", w.Code, w.Body.String())
+	}
+	if got := w.Header().Get("Content-Disposition"); got != `attachment; filename=march.pdf` {
+		t.Errorf("Expected the file as an attachment, got %q", got)
+	}
+
+	tampered := strings.Replace(href, "/4/", "/5/", 1)
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, tampered, nil))
+	if w.Code != http.StatusForbidden {
+		t.Errorf("Expected a URL signed for another record to be rejected, got %d", w.Code)
+	}
+
+	bo.SetFileURLSigning([]byte("another key"), time.Minute)
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, href, nil))
+	if w.Code != http.StatusForbidden {
+		t.Errorf("Expected a URL signed with another key to be rejected, got %d", w.Code)
+	}
+}
@@ -0,0 +1,32 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/preslavrachev/backoffice/core"
+	"github.com/preslavrachev/backoffice/middleware/auth"
+)
+
+type rulesVoucher struct {
+	ID       uint    `db:"id"`
+	Code     string  `db:"code"`
+	Discount float64 `db:"discount"`
+}
+
+// TestFormRuleAttributes tests that field rules reach the form inputs as HTML validation attributes
+func TestFormRuleAttributes(t *testing.T) {
+	bo := core.New(&mockActionAdapter{}, auth.AuthConfig{})
+	bo.RegisterResource(&rulesVoucher{}).
+		WithField("Code", func(f *core.FieldBuilder) { f.Required(true).Pattern(`[A-Z]{3}-\d+`).MaxLength(12) }).
+		WithField("Discount", func(f *core.FieldBuilder) { f.Min(0).Max(99.5) })
+	h := Handler(bo, "/admin")
+
+	for _, page := range []string{getPage(h, "/admin/rulesVoucher/new"), getPage(h, "/admin/api/rulesVoucher/new")} {
+		for _, want := range []string{`pattern="[A-Z]{3}-\d+"`, `maxlength="12"`, `min="0"`, `max="99.5"`, "required"} {
+			if !strings.Contains(page, want) {
+				t.Errorf("Expected %s on the form input", want)
+			}
+		}
+	}
+}
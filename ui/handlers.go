@@ -1,21 +1,34 @@
 package ui
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"html"
+	"io"
+	"maps"
 	"net/http"
+	"net/url"
 	"reflect"
+	"slices"
 	"strconv"
 	"strings"
+	"time"
 
+	"github.com/a-h/templ"
 	"github.com/preslavrachev/backoffice/core"
 	"github.com/preslavrachev/backoffice/middleware/auth"
 )
 
 // Handler returns an HTTP handler for the admin panel
-func Handler(bo *core.BackOffice, basePath string) http.Handler {
+func Handler(bo *core.BackOffice, basePath string, opts ...Option) http.Handler {
 	// Create a wrapper struct to hold the BackOffice instance and provide the handler methods
 	handler := &BackOfficeHandler{bo: bo}
+	for _, opt := range opts {
+		opt(handler)
+	}
 
 	mux := http.NewServeMux()
 
@@ -24,14 +37,22 @@ func Handler(bo *core.BackOffice, basePath string) http.Handler {
 	if authConfig != nil && authConfig.Enabled {
 		mux.HandleFunc(basePath+authConfig.LoginPath, handler.loginHandler)
 		mux.HandleFunc(basePath+authConfig.LogoutPath, handler.logoutHandler)
+		mux.HandleFunc(basePath+"/session/refresh", handler.sessionRefreshHandler)
+		mux.HandleFunc(basePath+"/sessions", handler.sessionsHandler)
+		mux.HandleFunc(basePath+"/impersonate", handler.impersonateHandler)
+		mux.HandleFunc(basePath+"/impersonate/stop", handler.stopImpersonationHandler)
 	}
 
 	// HTML routes
 	mux.HandleFunc(basePath+"/", handler.indexHandler)
 	mux.HandleFunc(basePath+"/api/", handler.apiRouter) // Keep API for HTMX operations
+	mux.HandleFunc(basePath+"/api/markdown-preview", handler.markdownPreviewHandler)
+	mux.HandleFunc(basePath+"/preferences", handler.preferencesHandler)
+	mux.HandleFunc(basePath+"/files/", handler.fileHandler)
+	mux.Handle(basePath+"/assets/", assetsHandler(basePath))
 
-	// Apply auth middleware
-	var finalHandler http.Handler = mux
+	// Reject state-changing requests without the CSRF token, send the CSP, then apply auth middleware
+	finalHandler := handler.cspMiddleware(handler.csrfMiddleware(mux))
 	if authConfig != nil {
 		authMiddleware := auth.CreateAuthMiddleware(authConfig)
 		finalHandler = authMiddleware(finalHandler)
@@ -42,17 +63,23 @@ func Handler(bo *core.BackOffice, basePath string) http.Handler {
 
 // BackOfficeHandler wraps BackOffice to provide HTTP handler methods
 type BackOfficeHandler struct {
-	bo *core.BackOffice
+	bo        *core.BackOffice
+	overrides componentOverrides
 }
 
 // indexHandler serves the main index page
 func (h *BackOfficeHandler) indexHandler(w http.ResponseWriter, r *http.Request) {
+	r = h.withOverrides(r)
 	path := strings.TrimPrefix(r.URL.Path, h.bo.GetConfig().BasePath)
 	path = strings.Trim(path, "/")
 
 	if path == "" {
-		// Main admin index
-		h.renderIndex(w, r)
+		// Main admin index, a dashboard once widgets are configured
+		if len(h.bo.Dashboard().Widgets()) > 0 {
+			h.renderDashboard(w, r)
+		} else {
+			h.renderIndex(w, r)
+		}
 		return
 	}
 
@@ -62,10 +89,33 @@ func (h *BackOfficeHandler) indexHandler(w http.ResponseWriter, r *http.Request)
 
 	resource, exists := h.bo.GetResource(resourceName)
 	if !exists {
+		if page, found := h.bo.GetPage(path); found {
+			h.renderCustomPage(w, r, page)
+			return
+		}
 		http.NotFound(w, r)
 		return
 	}
 
+	// Enforce resource permissions before routing
+	op := requiredOperation(r, segments)
+	if op != core.OperationList && h.bo.IsReadOnly(r.Context()) {
+		h.writeHTTPError(w, readOnlyMessage(r.Context(), h.bo.ReadOnly()), http.StatusForbidden)
+		return
+	}
+	if !resource.Can(r.Context(), op) {
+		h.writeHTTPError(w, msg(r.Context(), "error.forbidden", op, resource.PluralName), http.StatusForbidden)
+		return
+	}
+	if h.isOwnerDenied(r, resource, segments, op) {
+		h.writeHTTPError(w, msg(r.Context(), "error.owner_only", op, resource.PluralName), http.StatusForbidden)
+		return
+	}
+	if err := h.authorizeRoute(r, resource, segments, op); err != nil {
+		h.writeHTTPError(w, err.Error(), http.StatusForbidden)
+		return
+	}
+
 	switch len(segments) {
 	case 1:
 		// /admin/users - resource list
@@ -74,6 +124,12 @@ func (h *BackOfficeHandler) indexHandler(w http.ResponseWriter, r *http.Request)
 		if segments[1] == "new" {
 			// /admin/users/new - create form
 			h.renderCreateForm(w, r, resource)
+		} else if segments[1] == "import" && resource.CanImport() {
+			// /admin/users/import - CSV import wizard
+			h.handleImport(w, r, resource)
+		} else if segments[1] == "export.csv" && r.Method == http.MethodGet {
+			// /admin/users/export.csv - download the whole filtered, sorted list
+			h.handleCSVExport(w, r, resource)
 		} else {
 			// /admin/users/123 - resource detail
 			// Handle DELETE method (via form with _method=DELETE)
@@ -87,6 +143,13 @@ func (h *BackOfficeHandler) indexHandler(w http.ResponseWriter, r *http.Request)
 		if segments[2] == "edit" {
 			// /admin/users/123/edit - edit form
 			h.renderEditForm(w, r, resource, segments[1])
+		} else if segments[2] == "history" && resource.History != nil {
+			// /admin/users/123/history - version history, POST restores a version
+			if r.Method == http.MethodPost {
+				h.handleRestoreVersion(w, r, resource, segments[1])
+				return
+			}
+			h.renderHistory(w, r, resource, segments[1])
 		} else {
 			http.NotFound(w, r)
 		}
@@ -97,24 +160,153 @@ func (h *BackOfficeHandler) indexHandler(w http.ResponseWriter, r *http.Request)
 
 // renderIndex renders the main admin index page
 func (h *BackOfficeHandler) renderIndex(w http.ResponseWriter, r *http.Request) {
-	// Get resources in registration order and filter visible ones
+	indexComponent := Index(h.navigationGroups(r.Context()))
+	layoutComponent := h.pageLayout(r, h.bo.GetConfig().Title, indexComponent, "")
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if err := layoutComponent.Render(r.Context(), w); err != nil {
+		h.writeHTTPError(w, msg(r.Context(), "error.render"), http.StatusInternalServerError)
+	}
+}
+
+// renderCustomPage renders a registered page inside the admin layout
+// Handler responses that aren't full HTML pages, such as redirects, downloads or HTMX partials, pass through unchanged
+func (h *BackOfficeHandler) renderCustomPage(w http.ResponseWriter, r *http.Request, page *core.CustomPage) {
+	body := templ.Component(page.Content)
+	if body == nil && page.Handler == nil {
+		http.NotFound(w, r)
+		return
+	}
+	if body == nil {
+		recorder := newPageRecorder()
+		page.Handler.ServeHTTP(recorder, r)
+		if !recorder.embeddable() || r.Header.Get("HX-Request") == "true" {
+			recorder.writeTo(w)
+			return
+		}
+		body = templ.Raw(recorder.body.String())
+	}
+
+	layoutComponent := h.pageLayout(r, page.Title, CustomPageContent(page, body), "")
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if err := layoutComponent.Render(r.Context(), w); err != nil {
+		h.writeHTTPError(w, msg(r.Context(), "error.render"), http.StatusInternalServerError)
+	}
+}
+
+// renderDashboard serves the home page with the configured dashboard widgets
+func (h *BackOfficeHandler) renderDashboard(w http.ResponseWriter, r *http.Request) {
+	var widgets []dashboardWidget
+	for _, widget := range h.bo.Dashboard().Widgets() {
+		if loaded, visible := h.loadWidget(r.Context(), widget); visible {
+			widgets = append(widgets, loaded)
+		}
+	}
+
+	dashboardComponent := Dashboard(widgets, h.navigationGroups(r.Context()))
+	layoutComponent := h.pageLayout(r, h.bo.GetConfig().Title, dashboardComponent, "")
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if err := layoutComponent.Render(r.Context(), w); err != nil {
+		h.writeHTTPError(w, msg(r.Context(), "error.render"), http.StatusInternalServerError)
+	}
+}
+
+// loadWidget loads the data a dashboard widget shows
+// Widgets of resources the user may not list are hidden; load errors are shown in the widget
+func (h *BackOfficeHandler) loadWidget(ctx context.Context, widget core.Widget) (dashboardWidget, bool) {
+	loaded := dashboardWidget{Widget: widget}
+	if widget.Kind == core.WidgetCustom {
+		return loaded, widget.Component != nil
+	}
+	if widget.Value != nil {
+		loaded.value, loaded.err = widget.Value(ctx)
+		return loaded, true
+	}
+
+	resource, exists := h.bo.GetResource(widget.Resource)
+	if !exists || !resource.Can(ctx, core.OperationList) {
+		return loaded, false
+	}
+	loaded.resource = resource
+
+	if widget.Kind == core.WidgetChart {
+		points, err := core.Aggregate(ctx, h.bo.GetAdapter(), resource, widget.ChartQuery())
+		if err != nil {
+			loaded.err = fmt.Errorf("failed to chart %s: %w", resource.PluralName, err)
+		}
+		loaded.points = points
+		return loaded, true
+	}
+
+	query := resource.ApplyScope(ctx, widget.Query(resource))
+	result, err := h.bo.GetAdapter().Find(ctx, resource, query)
+	if err != nil {
+		loaded.err = fmt.Errorf("failed to load %s: %w", resource.PluralName, err)
+		return loaded, true
+	}
+	if widget.Kind == core.WidgetRecent {
+		loaded.items = result.Items
+	} else {
+		loaded.value = strconv.FormatInt(result.TotalCount, 10)
+	}
+	return loaded, true
+}
+
+// loadStats computes the KPI cards of a resource; a failing stat shows its error without hiding the others
+func (h *BackOfficeHandler) loadStats(ctx context.Context, resource *core.Resource) []listStat {
+	stats := make([]listStat, len(resource.Stats))
+	for i, stat := range resource.Stats {
+		stats[i].Stat = stat
+		stats[i].value, stats[i].err = stat.Value(ctx, h.bo.GetAdapter())
+	}
+	return stats
+}
+
+// handleStats renders the KPI cards of a resource for their periodic refresh
+func (h *BackOfficeHandler) handleStats(w http.ResponseWriter, r *http.Request, resource *core.Resource) {
+	if len(resource.Stats) == 0 {
+		h.writeHTTPError(w, msg(r.Context(), "error.no_stats"), http.StatusNotFound)
+		return
+	}
+	component := ListStats(resource, h.loadStats(r.Context(), resource))
+	if err := component.Render(r.Context(), w); err != nil {
+		h.writeHTTPError(w, msg(r.Context(), "error.stats_failed"), http.StatusInternalServerError)
+	}
+}
+
+// navigationGroups returns the visible resources the current user may list, grouped for navigation
+func (h *BackOfficeHandler) navigationGroups(ctx context.Context) []core.ResourceGroup {
 	var visibleResources []*core.Resource
 	for _, resource := range h.bo.GetResources() {
-		if !resource.Hidden {
+		if !resource.Hidden && resource.Can(ctx, core.OperationList) {
 			visibleResources = append(visibleResources, resource)
 		}
 	}
+	return core.GroupResources(visibleResources)
+}
 
-	indexComponent := Index(visibleResources)
-
-	// Get user from context for auth-aware layout
+// pageLayout wraps content in the auth-aware layout with the sidebar navigation and its extra links
+func (h *BackOfficeHandler) pageLayout(r *http.Request, title string, content templ.Component, current string) templ.Component {
 	user, _ := auth.GetAuthUser(r.Context())
-	layoutComponent := LayoutWithAuth(h.bo.GetConfig().Title, indexComponent, user)
+	nav := core.GroupLinks(h.navigationGroups(r.Context()), h.bo.Links())
+	layout := BrandedLayout(h.bo.GetConfig(), title, content, user, nav, current)
+	resource, _ := h.bo.GetResource(current)
+	return slotComponent(r.Context(), SlotLayout, SlotProps{Resource: resource, Title: title, Content: content, Default: layout})
+}
 
-	w.Header().Set("Content-Type", "text/html; charset=utf-8")
-	if err := layoutComponent.Render(context.Background(), w); err != nil {
-		h.writeHTTPError(w, "Template rendering error", http.StatusInternalServerError)
-	}
+// withOverrides makes the component overrides, the admin's time zone and the negotiated locale
+// available to the templates rendering the request, and memoizes its permission checks
+func (h *BackOfficeHandler) withOverrides(r *http.Request) *http.Request {
+	ctx := core.WithPermissionMemo(r.Context())
+	ctx = context.WithValue(ctx, "timeZone", h.bo.Location())
+	ctx = context.WithValue(ctx, "locale", h.bo.NegotiateLocale(r.Header.Get("Accept-Language")))
+	ctx = context.WithValue(ctx, "listPreferences", preferencesFromRequest(r))
+	ctx = context.WithValue(ctx, "readOnly", h.bo.ReadOnly())
+	if h.overrides != nil {
+		ctx = context.WithValue(ctx, "componentOverrides", h.overrides)
+	}
+	return r.WithContext(ctx)
 }
 
 // renderResourceList renders the resource list page
@@ -125,16 +317,38 @@ func (h *BackOfficeHandler) renderResourceList(w http.ResponseWriter, r *http.Re
 		return
 	}
 
-	// Parse query from request parameters
+	// Parse query from request parameters and restrict it to the records the user may see
 	query := parseQueryFromRequest(r, resource)
+	if query.Search != "" || len(query.Filters) > 0 || len(query.Conditions) > 0 {
+		// Lists narrowed by the user show "no records found" rather than the resource's empty state
+		r = r.WithContext(context.WithValue(r.Context(), "filteredList", true))
+	}
+	query = resource.ApplyScope(r.Context(), query)
+
+	// Let templates know they are rendering the trash
+	if query.Trashed {
+		r = r.WithContext(context.WithValue(r.Context(), "trashView", true))
+	}
+
+	// Bulk actions need the list filters to support "select all matching"
+	r = r.WithContext(context.WithValue(r.Context(), "listQuery", r.URL.RawQuery))
+
+	// Show the columns the user picked in the columns menu
+	r = r.WithContext(context.WithValue(r.Context(), "listColumns", listColumnsFromRequest(r, resource)))
 
 	// Check if this is a "load more" request (HTMX partial response)
 	isLoadMore := r.URL.Query().Get("load_more") == "true"
 
+	// Tree resources render as a hierarchy unless filtered, searched or explicitly viewed as a flat list
+	if resource.IsTree() && !isLoadMore && !query.Trashed && !query.HasFilters() && query.Search == "" && r.URL.Query().Get("view") != "list" {
+		h.renderTreeList(w, r, resource)
+		return
+	}
+
 	// Execute query using the new Find method
 	result, err := h.bo.GetAdapter().Find(r.Context(), resource, query)
 	if err != nil {
-		h.writeHTTPError(w, fmt.Sprintf("Failed to get items: %v", err), http.StatusInternalServerError)
+		h.writeHTTPError(w, msg(r.Context(), "error.list_failed", err), http.StatusInternalServerError)
 		return
 	}
 
@@ -146,14 +360,42 @@ func (h *BackOfficeHandler) renderResourceList(w http.ResponseWriter, r *http.Re
 
 	// Create context with sort information for templates
 	ctx := r.Context()
+	ctx = context.WithValue(ctx, "filterOptions", h.relationFilterOptions(ctx, resource))
+	if store := h.bo.ViewStore(); store != nil {
+		views, err := store.ListViews(ctx, resource.Name)
+		if err != nil {
+			h.writeHTTPError(w, msg(r.Context(), "error.views_load_failed", err), http.StatusInternalServerError)
+			return
+		}
+		ctx = context.WithValue(ctx, "savedViews", views)
+	}
 	if primarySort := query.GetPrimarySort(); primarySort != nil {
 		ctx = context.WithValue(ctx, "currentSortField", primarySort.Field)
 		ctx = context.WithValue(ctx, "currentSortDirection", string(primarySort.Direction))
 	}
 
-	// Generate Load More URL if needed
+	ctx = context.WithValue(ctx, "listPagination", query.Pagination)
+	if len(resource.Stats) > 0 && !query.Trashed {
+		ctx = context.WithValue(ctx, "listStats", h.loadStats(ctx, resource))
+	}
+	if len(resource.SummaryFields()) > 0 && !query.Trashed {
+		// Adapters without aggregation support simply get no footer
+		if summaries, err := core.ListSummaries(ctx, h.bo.GetAdapter(), resource, query); err == nil {
+			ctx = context.WithValue(ctx, "listSummaries", summaries)
+		}
+	}
+	if len(resource.Charts) > 0 && !query.Trashed {
+		var charts []dashboardWidget
+		for _, chart := range resource.Charts {
+			loaded, _ := h.loadWidget(ctx, chart)
+			charts = append(charts, loaded)
+		}
+		ctx = context.WithValue(ctx, "listCharts", charts)
+	}
+
+	// Generate Load More URL if needed; numbered lists link to their pages instead
 	var loadMoreURL string
-	if result.HasMore {
+	if result.HasMore && !resource.IsNumberedPagination() {
 		nextQuery := result.Query.NextPage()
 		loadMoreURL = NewAdminURL(resource.Name).
 			PreserveFromRequest(r).
@@ -165,54 +407,101 @@ func (h *BackOfficeHandler) renderResourceList(w http.ResponseWriter, r *http.Re
 	// Render full list page
 	listComponent := List(resource, result.Items, int(result.TotalCount), loadMoreURL)
 
-	// Get user from context for auth-aware layout
-	user, _ := auth.GetAuthUser(ctx)
-	layoutComponent := LayoutWithAuth(resource.PluralName, listComponent, user)
+	layoutComponent := h.pageLayout(r, resource.PluralName, listComponent, resource.Name)
 
 	// Check for success messages
 	if successType := r.URL.Query().Get("success"); successType == "delete" {
 		if resourceName := r.URL.Query().Get("resource"); resourceName != "" {
-			w.Header().Set("HX-Trigger", fmt.Sprintf(`{"showToast": {"message": "%s deleted successfully", "type": "success"}}`, resourceName))
+			setToastTrigger(w, msg(ctx, "toast.deleted", resourceName), "success", false)
 		}
 	}
 
 	w.Header().Set("Content-Type", "text/html; charset=utf-8")
 	if err := layoutComponent.Render(ctx, w); err != nil {
-		h.writeHTTPError(w, "Template rendering error", http.StatusInternalServerError)
+		h.writeHTTPError(w, msg(r.Context(), "error.render"), http.StatusInternalServerError)
+	}
+}
+
+// relationFilterOptions loads the related records offered by the relation filters of a list, keyed by field
+func (h *BackOfficeHandler) relationFilterOptions(ctx context.Context, resource *core.Resource) map[string][]filterOption {
+	options := make(map[string][]filterOption)
+	for _, field := range resource.FilterFields() {
+		if field.FilterKind() != core.FilterKindRelation {
+			continue
+		}
+		related, exists := h.bo.GetResource(field.Relationship.RelatedModel)
+		if !exists || !related.Can(ctx, core.OperationList) {
+			continue
+		}
+
+		query := related.ApplyScope(ctx, core.NewQuery().WithPagination(maxFilterOptions, 0))
+		result, err := h.bo.GetAdapter().Find(ctx, related, query)
+		if err != nil || result == nil {
+			continue // The filter falls back to "Any"
+		}
+		for _, item := range result.Items {
+			options[field.Name] = append(options[field.Name], filterOption{
+				Value: related.RecordID(item),
+				Label: field.Relationship.DisplayValue(item),
+			})
+		}
+	}
+	return options
+}
+
+// renderTreeList renders a self-referential resource as an expandable tree
+func (h *BackOfficeHandler) renderTreeList(w http.ResponseWriter, r *http.Request, resource *core.Resource) {
+	nodes, err := core.FindTree(r.Context(), h.bo.GetAdapter(), resource)
+	if err != nil {
+		h.writeHTTPError(w, msg(r.Context(), "error.list_failed", err), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	layoutComponent := h.pageLayout(r, resource.PluralName, TreeList(resource, nodes), resource.Name)
+	if err := layoutComponent.Render(r.Context(), w); err != nil {
+		h.writeHTTPError(w, msg(r.Context(), "error.render"), http.StatusInternalServerError)
 	}
 }
 
 // renderResourceDetail renders the resource detail page
 func (h *BackOfficeHandler) renderResourceDetail(w http.ResponseWriter, r *http.Request, resource *core.Resource, idStr string) {
-	id, err := strconv.ParseUint(idStr, 10, 32)
+	id, err := resource.ParseID(idStr)
 	if err != nil {
-		h.writeHTTPError(w, "Invalid ID format", http.StatusBadRequest)
+		h.writeHTTPError(w, msg(r.Context(), "error.invalid_id"), http.StatusBadRequest)
 		return
 	}
 
-	item, err := h.bo.GetAdapter().GetByID(r.Context(), resource, uint(id))
+	item, err := core.GetScopedByID(r.Context(), h.bo.GetAdapter(), resource, id)
 	if err != nil {
-		h.writeHTTPError(w, fmt.Sprintf("Failed to get item: %v", err), http.StatusNotFound)
+		h.writeHTTPError(w, msg(r.Context(), "error.get_failed", err), http.StatusNotFound)
 		return
 	}
 
+	ctx := r.Context()
+	if slices.Contains(detailTabs(resource), core.TabHistory) {
+		var history detailHistory
+		history.versions, history.err = resource.History.ListVersions(ctx, resource.Name, idStr)
+		ctx = context.WithValue(ctx, "detailHistory", history)
+	}
+
 	detailComponent := Detail(resource, item)
-	layoutComponent := Layout(resource.DisplayName+" Detail", detailComponent)
+	layoutComponent := h.pageLayout(r, resource.DisplayName+" Detail", detailComponent, resource.Name)
 
 	w.Header().Set("Content-Type", "text/html; charset=utf-8")
-	if err := layoutComponent.Render(context.Background(), w); err != nil {
-		h.writeHTTPError(w, "Template rendering error", http.StatusInternalServerError)
+	if err := layoutComponent.Render(ctx, w); err != nil {
+		h.writeHTTPError(w, msg(r.Context(), "error.render"), http.StatusInternalServerError)
 	}
 }
 
 // renderCreateForm renders the create form page
 func (h *BackOfficeHandler) renderCreateForm(w http.ResponseWriter, r *http.Request, resource *core.Resource) {
 	formComponent := Form(resource, nil, false)
-	layoutComponent := Layout("Create "+resource.DisplayName, formComponent)
+	layoutComponent := h.pageLayout(r, "Create "+resource.DisplayName, formComponent, resource.Name)
 
 	w.Header().Set("Content-Type", "text/html; charset=utf-8")
-	if err := layoutComponent.Render(context.Background(), w); err != nil {
-		h.writeHTTPError(w, "Template rendering error", http.StatusInternalServerError)
+	if err := layoutComponent.Render(h.withRelationLabels(r.Context(), resource, nil), w); err != nil {
+		h.writeHTTPError(w, msg(r.Context(), "error.render"), http.StatusInternalServerError)
 	}
 }
 
@@ -227,56 +516,138 @@ func (h *BackOfficeHandler) renderEditForm(w http.ResponseWriter, r *http.Reques
 		}
 	}
 
-	id, err := strconv.ParseUint(idStr, 10, 32)
+	id, err := resource.ParseID(idStr)
 	if err != nil {
-		h.writeHTTPError(w, "Invalid ID format", http.StatusBadRequest)
+		h.writeHTTPError(w, msg(r.Context(), "error.invalid_id"), http.StatusBadRequest)
 		return
 	}
 
-	item, err := h.bo.GetAdapter().GetByID(r.Context(), resource, uint(id))
+	item, err := core.GetScopedByID(r.Context(), h.bo.GetAdapter(), resource, id)
 	if err != nil {
-		h.writeHTTPError(w, fmt.Sprintf("Failed to get item: %v", err), http.StatusNotFound)
+		h.writeHTTPError(w, msg(r.Context(), "error.get_failed", err), http.StatusNotFound)
 		return
 	}
 
 	formComponent := Form(resource, item, true)
-	layoutComponent := Layout("Edit "+resource.DisplayName, formComponent)
+	layoutComponent := h.pageLayout(r, "Edit "+resource.DisplayName, formComponent, resource.Name)
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if err := layoutComponent.Render(h.withRelationLabels(r.Context(), resource, item), w); err != nil {
+		h.writeHTTPError(w, msg(r.Context(), "error.render"), http.StatusInternalServerError)
+	}
+}
+
+// renderHistory renders the version history of a record
+func (h *BackOfficeHandler) renderHistory(w http.ResponseWriter, r *http.Request, resource *core.Resource, idStr string) {
+	id, err := resource.ParseID(idStr)
+	if err != nil {
+		h.writeHTTPError(w, msg(r.Context(), "error.invalid_id"), http.StatusBadRequest)
+		return
+	}
+
+	item, err := core.GetScopedByID(r.Context(), h.bo.GetAdapter(), resource, id)
+	if err != nil {
+		h.writeHTTPError(w, msg(r.Context(), "error.get_failed", err), http.StatusNotFound)
+		return
+	}
+
+	versions, err := resource.History.ListVersions(r.Context(), resource.Name, idStr)
+	if err != nil {
+		h.writeHTTPError(w, msg(r.Context(), "error.history_load_failed", err), http.StatusInternalServerError)
+		return
+	}
+
+	historyComponent := History(resource, item, versions)
+	layoutComponent := h.pageLayout(r, resource.DisplayName+" History", historyComponent, resource.Name)
 
 	w.Header().Set("Content-Type", "text/html; charset=utf-8")
-	if err := layoutComponent.Render(context.Background(), w); err != nil {
-		h.writeHTTPError(w, "Template rendering error", http.StatusInternalServerError)
+	if err := layoutComponent.Render(r.Context(), w); err != nil {
+		h.writeHTTPError(w, msg(r.Context(), "error.render"), http.StatusInternalServerError)
+	}
+}
+
+// handleRestoreVersion restores a record to one of its previous versions
+func (h *BackOfficeHandler) handleRestoreVersion(w http.ResponseWriter, r *http.Request, resource *core.Resource, idStr string) {
+	if resource.ReadOnly {
+		h.writeHTTPError(w, msg(r.Context(), "error.read_only"), http.StatusForbidden)
+		return
+	}
+
+	id, err := resource.ParseID(idStr)
+	if err != nil {
+		h.writeHTTPError(w, msg(r.Context(), "error.invalid_id"), http.StatusBadRequest)
+		return
+	}
+
+	versionID, err := strconv.ParseInt(r.FormValue("version_id"), 10, 64)
+	if err != nil {
+		h.writeHTTPError(w, msg(r.Context(), "error.invalid_version"), http.StatusBadRequest)
+		return
+	}
+
+	if err := core.CheckScope(r.Context(), h.bo.GetAdapter(), resource, id); err != nil {
+		h.writeHTTPError(w, msg(r.Context(), "error.not_found", resource.DisplayName), http.StatusNotFound)
+		return
+	}
+
+	if err := core.RestoreRecordVersion(r.Context(), h.bo.GetAdapter(), resource, id, versionID); err != nil {
+		h.writeHTTPError(w, msg(r.Context(), "error.version_restore_failed", err), http.StatusInternalServerError)
+		return
 	}
+
+	// Redirect to detail view
+	http.Redirect(w, r, h.bo.GetConfig().BasePath+"/"+resource.Name+"/"+idStr, http.StatusSeeOther)
 }
 
 // handleCreateResource handles POST requests for creating resources
 func (h *BackOfficeHandler) handleCreateResource(w http.ResponseWriter, r *http.Request, resource *core.Resource) {
 	if resource.ReadOnly {
-		h.writeHTTPError(w, "Resource is read-only", http.StatusForbidden)
+		h.writeHTTPError(w, msg(r.Context(), "error.read_only"), http.StatusForbidden)
 		return
 	}
 
 	// Parse form data
 	if err := r.ParseForm(); err != nil {
-		h.writeHTTPError(w, "Invalid form data", http.StatusBadRequest)
+		h.writeHTTPError(w, msg(r.Context(), "error.invalid_form"), http.StatusBadRequest)
 		return
 	}
 
 	// Convert form data to struct instance
 	item, err := h.formToStruct(r, resource)
 	if err != nil {
-		h.writeHTTPError(w, fmt.Sprintf("Invalid data format: %v", err), http.StatusBadRequest)
+		h.writeHTTPError(w, msg(r.Context(), "error.invalid_data", err), http.StatusBadRequest)
+		return
+	}
+
+	// Record the session user as the owner
+	if err := core.SetOwner(r.Context(), resource, item); err != nil {
+		h.writeHTTPError(w, msg(r.Context(), "error.owner_failed", err), http.StatusInternalServerError)
+		return
+	}
+
+	// Fill in auto-generated slugs
+	if err := core.GenerateSlugs(r.Context(), h.bo.GetAdapter(), resource, item); err != nil {
+		h.writeHTTPError(w, msg(r.Context(), "error.slug_failed", err), http.StatusInternalServerError)
 		return
 	}
 
 	// Validate data
 	if err := h.bo.GetAdapter().ValidateData(resource, item); err != nil {
-		h.writeHTTPError(w, fmt.Sprintf("Validation error: %v", err), http.StatusBadRequest)
+		h.writeHTTPError(w, msg(r.Context(), "validation.failed", err), http.StatusBadRequest)
+		return
+	}
+	if err := resource.Validate(r.Context(), item); err != nil {
+		h.writeHTTPError(w, msg(r.Context(), "validation.failed", err), http.StatusBadRequest)
 		return
 	}
 
 	// Create item
 	if err := h.bo.GetAdapter().Create(r.Context(), resource, item); err != nil {
-		h.writeHTTPError(w, fmt.Sprintf("Failed to create item: %v", err), http.StatusInternalServerError)
+		h.writeHTTPError(w, msg(r.Context(), "error.create_failed", err), http.StatusInternalServerError)
+		return
+	}
+	if err := h.saveLinks(r, resource, core.GetFieldValue(item, resource.IDField)); err != nil {
+		h.writeHTTPError(w, msg(r.Context(), "error.links_failed", err), http.StatusInternalServerError)
 		return
 	}
 
@@ -287,32 +658,58 @@ func (h *BackOfficeHandler) handleCreateResource(w http.ResponseWriter, r *http.
 // handleUpdateResource handles PUT requests for updating resources
 func (h *BackOfficeHandler) handleUpdateResource(w http.ResponseWriter, r *http.Request, resource *core.Resource, idStr string) {
 	if resource.ReadOnly {
-		h.writeHTTPError(w, "Resource is read-only", http.StatusForbidden)
+		h.writeHTTPError(w, msg(r.Context(), "error.read_only"), http.StatusForbidden)
 		return
 	}
 
-	id, err := strconv.ParseUint(idStr, 10, 32)
+	id, err := resource.ParseID(idStr)
 	if err != nil {
-		h.writeHTTPError(w, "Invalid ID format", http.StatusBadRequest)
+		h.writeHTTPError(w, msg(r.Context(), "error.invalid_id"), http.StatusBadRequest)
 		return
 	}
 
 	// Parse form data
 	if err := r.ParseForm(); err != nil {
-		h.writeHTTPError(w, "Invalid form data", http.StatusBadRequest)
+		h.writeHTTPError(w, msg(r.Context(), "error.invalid_form"), http.StatusBadRequest)
+		return
+	}
+
+	// Make sure the record is within the user's scope before updating it
+	if err := core.CheckScope(r.Context(), h.bo.GetAdapter(), resource, id); err != nil {
+		h.writeHTTPError(w, msg(r.Context(), "error.not_found", resource.DisplayName), http.StatusNotFound)
+		return
+	}
+	if err := h.keepMaskedValues(r, resource, id); err != nil {
+		h.writeHTTPError(w, msg(r.Context(), "error.not_found", resource.DisplayName), http.StatusNotFound)
 		return
 	}
 
 	// Convert form data to struct instance
 	item, err := h.formToStruct(r, resource)
 	if err != nil {
-		h.writeHTTPError(w, fmt.Sprintf("Invalid data format: %v", err), http.StatusBadRequest)
+		h.writeHTTPError(w, msg(r.Context(), "error.invalid_data", err), http.StatusBadRequest)
+		return
+	}
+
+	// Run whole-record validators against the record as it will be stored
+	if err := core.ValidateUpdate(r.Context(), h.bo.GetAdapter(), resource, id, item); err != nil {
+		h.writeHTTPError(w, msg(r.Context(), "validation.failed", err), http.StatusBadRequest)
+		return
+	}
+
+	// Keep the previous state for resources with version history
+	if err := core.SaveRecordVersion(r.Context(), h.bo.GetAdapter(), resource, id); err != nil {
+		h.writeHTTPError(w, msg(r.Context(), "error.history_failed", err), http.StatusInternalServerError)
 		return
 	}
 
 	// Update item
-	if err := h.bo.GetAdapter().Update(r.Context(), resource, uint(id), item); err != nil {
-		h.writeHTTPError(w, fmt.Sprintf("Failed to update item: %v", err), http.StatusInternalServerError)
+	if err := h.bo.GetAdapter().Update(r.Context(), resource, id, item); err != nil {
+		h.writeHTTPError(w, msg(r.Context(), "error.update_failed", err), http.StatusInternalServerError)
+		return
+	}
+	if err := h.saveLinks(r, resource, id); err != nil {
+		h.writeHTTPError(w, msg(r.Context(), "error.links_failed", err), http.StatusInternalServerError)
 		return
 	}
 
@@ -322,19 +719,39 @@ func (h *BackOfficeHandler) handleUpdateResource(w http.ResponseWriter, r *http.
 
 // apiRouter routes API requests (for HTMX operations)
 func (h *BackOfficeHandler) apiRouter(w http.ResponseWriter, r *http.Request) {
+	r = h.withOverrides(r)
 	// Remove the base path and /api/ prefix
 	path := strings.TrimPrefix(r.URL.Path, h.bo.GetConfig().BasePath+"/api/")
 	segments := strings.Split(strings.Trim(path, "/"), "/")
 
 	if len(segments) < 1 || segments[0] == "" {
-		h.writeHTTPError(w, "Invalid API path", http.StatusBadRequest)
+		h.writeHTTPError(w, msg(r.Context(), "error.invalid_path"), http.StatusBadRequest)
 		return
 	}
 
 	resourceName := segments[0]
 	resource, exists := h.bo.GetResource(resourceName)
 	if !exists {
-		h.writeHTTPError(w, fmt.Sprintf("Resource '%s' not found", resourceName), http.StatusNotFound)
+		h.writeHTTPError(w, msg(r.Context(), "error.resource_not_found", resourceName), http.StatusNotFound)
+		return
+	}
+
+	// Enforce resource permissions before routing
+	op := requiredOperation(r, segments)
+	if op != core.OperationList && h.bo.IsReadOnly(r.Context()) {
+		h.writeHTTPErrorWithToast(w, readOnlyMessage(r.Context(), h.bo.ReadOnly()), http.StatusForbidden, "error")
+		return
+	}
+	if !resource.Can(r.Context(), op) {
+		h.writeHTTPErrorWithToast(w, msg(r.Context(), "error.forbidden", op, resource.PluralName), http.StatusForbidden, "error")
+		return
+	}
+	if h.isOwnerDenied(r, resource, segments, op) {
+		h.writeHTTPErrorWithToast(w, msg(r.Context(), "error.owner_only", op, resource.PluralName), http.StatusForbidden, "error")
+		return
+	}
+	if err := h.authorizeRoute(r, resource, segments, op); err != nil {
+		h.writeHTTPErrorWithToast(w, err.Error(), http.StatusForbidden, "error")
 		return
 	}
 
@@ -344,12 +761,42 @@ func (h *BackOfficeHandler) apiRouter(w http.ResponseWriter, r *http.Request) {
 			// POST /api/users - handle create
 			h.handleCreateResourceAPI(w, r, resource)
 		} else {
-			h.writeHTTPError(w, "Invalid API operation", http.StatusMethodNotAllowed)
+			h.writeHTTPError(w, msg(r.Context(), "error.invalid_operation"), http.StatusMethodNotAllowed)
 		}
 	case 2:
 		if segments[1] == "new" && r.Method == http.MethodGet {
 			// GET /api/users/new - return create form side pane
 			h.renderCreateSidePane(w, r, resource)
+		} else if segments[1] == "bulk-delete" && r.Method == http.MethodPost {
+			// POST /api/users/bulk-delete - delete the selected records
+			h.handleBulkDelete(w, r, resource)
+		} else if segments[1] == "bulk-delete" && r.Method == http.MethodGet {
+			// GET /api/users/bulk-delete - ask for the step-up confirmation of a bulk delete
+			h.renderStepUpModal(w, r, bulkDeletePrompt(r.Context(), resource), nil)
+		} else if segments[1] == "bulk-action" && r.Method == http.MethodPost {
+			// POST /api/users/bulk-action - run an action over the selected records
+			h.handleBulkAction(w, r, resource)
+		} else if segments[1] == "export" && r.Method == http.MethodGet {
+			// GET /api/users/export?format=csv - download the filtered list
+			h.handleExport(w, r, resource)
+		} else if segments[1] == "options" && r.Method == http.MethodGet {
+			// GET /api/users/options?q=ann - search the records offered by relation pickers
+			h.renderRelationOptions(w, r, resource)
+		} else if segments[1] == "stats" && r.Method == http.MethodGet {
+			// GET /api/users/stats - reload the KPI cards above the list
+			h.handleStats(w, r, resource)
+		} else if segments[1] == "reorder" && r.Method == http.MethodPost {
+			// POST /api/users/reorder - store the order of rows dragged in the list
+			h.handleReorder(w, r, resource)
+		} else if segments[1] == "columns" && r.Method == http.MethodPost {
+			// POST /api/users/columns - remember the list columns chosen by the user
+			h.handleListColumns(w, r, resource)
+		} else if segments[1] == "views" {
+			// GET/POST /api/users/views - list or save views
+			h.handleSavedViews(w, r, resource, "")
+		} else if segments[1] == "collection-action" && r.Method == http.MethodPost {
+			// POST /api/users/collection-action - run an action over the whole resource
+			h.handleCollectionAction(w, r, resource)
 		} else if r.Method == http.MethodDelete {
 			// DELETE /api/users/123
 			h.handleDeleteResource(w, r, resource, segments[1])
@@ -357,137 +804,580 @@ func (h *BackOfficeHandler) apiRouter(w http.ResponseWriter, r *http.Request) {
 			// POST /api/users/123 - handle update
 			h.handleUpdateResourceAPI(w, r, resource, segments[1])
 		} else {
-			h.writeHTTPError(w, "Invalid API operation", http.StatusMethodNotAllowed)
+			h.writeHTTPError(w, msg(r.Context(), "error.invalid_operation"), http.StatusMethodNotAllowed)
 		}
 	case 3:
-		if segments[2] == "edit" && r.Method == http.MethodGet {
+		if segments[1] == "views" && r.Method == http.MethodDelete {
+			// DELETE /api/users/views/3 - delete a saved view
+			h.handleSavedViews(w, r, resource, segments[2])
+		} else if segments[1] == "choices" && r.Method == http.MethodGet {
+			// GET /api/users/choices/State?Country=US - re-render a dependent select
+			h.renderChoiceSelect(w, r, resource, segments[2])
+		} else if segments[2] == "edit" && r.Method == http.MethodGet {
 			// GET /api/users/123/edit - return edit form side pane
 			h.renderEditSidePane(w, r, resource, segments[1])
+		} else if segments[2] == "duplicate" && r.Method == http.MethodGet {
+			// GET /api/users/123/duplicate - return create form pre-filled from the record
+			h.renderDuplicateSidePane(w, r, resource, segments[1])
+		} else if segments[2] == "action" && r.Method == http.MethodGet {
+			// GET /api/users/123/action?action_id=refund - return the parameter form of an action
+			h.renderActionForm(w, r, resource, segments[1])
 		} else if segments[2] == "action" && r.Method == http.MethodPost {
 			// POST /api/users/123/action - execute custom action
 			h.handleCustomAction(w, r, resource, segments[1])
+		} else if segments[2] == "restore" && r.Method == http.MethodPost {
+			// POST /api/users/123/restore - move a soft-deleted record out of the trash
+			h.handleRestoreResource(w, r, resource, segments[1])
+		} else if segments[2] == "delete-permanently" && (r.Method == http.MethodGet || r.Method == http.MethodPost) {
+			// GET/POST /api/users/123/delete-permanently - confirm with the password or a code, then delete for good
+			h.handlePermanentDelete(w, r, resource, segments[1])
 		} else {
-			h.writeHTTPError(w, "Invalid API operation", http.StatusMethodNotAllowed)
+			h.writeHTTPError(w, msg(r.Context(), "error.invalid_operation"), http.StatusMethodNotAllowed)
 		}
 	case 4:
 		if segments[2] == "related" && r.Method == http.MethodGet {
 			// GET /api/Category/123/related/Children - return related items modal
 			h.handleRelatedItemsModal(w, r, resource, segments[1], segments[3])
+		} else if segments[2] == "related-links" && r.Method == http.MethodGet {
+			// GET /api/Category/123/related-links/Products - return the child count and quick links
+			h.renderRelatedLinks(w, r, resource, segments[1], segments[3])
+		} else if segments[2] == "related-table" && r.Method == http.MethodGet {
+			// GET /api/Category/123/related-table/Products?page=2 - return a page of the children table
+			h.renderRelatedTable(w, r, resource, segments[1], segments[3])
+		} else if segments[2] == "field" {
+			// GET/PATCH /api/users/123/field/Email - edit a single list cell in place
+			h.handleCellEdit(w, r, resource, segments[1], segments[3])
+		} else if segments[2] == "unmask" && r.Method == http.MethodPost {
+			// POST /api/users/123/unmask/Email - reveal a masked value
+			h.handleUnmask(w, r, resource, segments[1], segments[3])
+		} else if segments[2] == "inline" {
+			// GET/POST /api/Department/1/inline/Employee - list or add inline children
+			h.handleInlineChildren(w, r, resource, segments[1], segments[3], "")
 		} else {
-			h.writeHTTPError(w, "Invalid API operation", http.StatusMethodNotAllowed)
+			h.writeHTTPError(w, msg(r.Context(), "error.invalid_operation"), http.StatusMethodNotAllowed)
+		}
+	case 5:
+		if segments[2] == "inline" {
+			// POST/DELETE /api/Department/1/inline/Employee/7 - update or delete an inline child
+			h.handleInlineChildren(w, r, resource, segments[1], segments[3], segments[4])
+		} else {
+			h.writeHTTPError(w, msg(r.Context(), "error.invalid_operation"), http.StatusMethodNotAllowed)
 		}
 	default:
-		h.writeHTTPError(w, "Invalid API path", http.StatusBadRequest)
+		h.writeHTTPError(w, msg(r.Context(), "error.invalid_path"), http.StatusBadRequest)
 	}
 }
 
-// handleDeleteResource handles DELETE requests
-func (h *BackOfficeHandler) handleDeleteResource(w http.ResponseWriter, r *http.Request, resource *core.Resource, idStr string) {
-	if resource.ReadOnly {
-		h.writeHTTPErrorWithToast(w, "Cannot delete: Resource is read-only", http.StatusForbidden, "error")
+// renderChoiceSelect re-renders a dependent select for the parent value submitted with the form
+func (h *BackOfficeHandler) renderChoiceSelect(w http.ResponseWriter, r *http.Request, resource *core.Resource, fieldName string) {
+	field, ok := resource.GetField(fieldName)
+	if !ok || !field.HasChoices() {
+		h.writeHTTPError(w, msg(r.Context(), "error.no_choices", fieldName), http.StatusNotFound)
 		return
 	}
 
-	id, err := strconv.ParseUint(idStr, 10, 32)
+	query := r.URL.Query()
+	choices, err := field.ChoicesFor(r.Context(), query.Get(field.DependsOn))
 	if err != nil {
-		h.writeHTTPErrorWithToast(w, "Invalid ID format", http.StatusBadRequest, "error")
+		h.writeHTTPErrorWithToast(w, msg(r.Context(), "error.choices_failed", field.DisplayName, err), http.StatusInternalServerError, "error")
 		return
 	}
 
-	// First check if the resource exists
-	_, err = h.bo.GetAdapter().GetByID(r.Context(), resource, uint(id))
-	if err != nil {
-		h.writeHTTPErrorWithToast(w, fmt.Sprintf("%s not found", resource.DisplayName), http.StatusNotFound, "error")
-		return
+	variant := choiceVariantForm
+	if query.Get("variant") == choiceVariantSidePane {
+		variant = choiceVariantSidePane
 	}
 
-	// Perform the deletion
-	if err := h.bo.GetAdapter().Delete(r.Context(), resource, uint(id)); err != nil {
-		h.writeHTTPErrorWithToast(w, fmt.Sprintf("Failed to delete %s: %v", resource.DisplayName, err), http.StatusInternalServerError, "error")
+	w.Header().Set("Content-Type", "text/html")
+	ChoiceSelect(resource, *field, query.Get(field.Name), choices, variant).Render(r.Context(), w)
+}
+
+// renderRelationOptions renders the records a relation picker offers for the typed text
+// The picker's source resource and field select how the records are labelled
+func (h *BackOfficeHandler) renderRelationOptions(w http.ResponseWriter, r *http.Request, related *core.Resource) {
+	query := r.URL.Query()
+	display := h.pickedRelationship(query.Get("source"), query.Get("field"))
+
+	items, err := core.SearchRelated(r.Context(), h.bo.GetAdapter(), related, query.Get("q"))
+	if err != nil {
+		h.writeHTTPErrorWithToast(w, err.Error(), http.StatusInternalServerError, "error")
 		return
 	}
+	options := make([]filterOption, 0, len(items))
+	for _, item := range items {
+		options = append(options, filterOption{
+			Value: formValue(core.GetFieldValue(item, related.IDField)),
+			Label: display.DisplayValue(item),
+		})
+	}
 
-	// Return success response with toast notification
-	w.Header().Set("HX-Trigger", fmt.Sprintf(`{"showToast": {"message": "%s deleted successfully", "type": "success"}}`, resource.DisplayName))
-	w.WriteHeader(http.StatusOK)
-}
-
-// writeHTTPError writes an HTTP error response
-func (h *BackOfficeHandler) writeHTTPError(w http.ResponseWriter, message string, statusCode int) {
-	w.WriteHeader(statusCode)
-	fmt.Fprintf(w, "<html><body><h1>Error %d</h1><p>%s</p></body></html>", statusCode, message)
+	w.Header().Set("Content-Type", "text/html")
+	RelationOptions(options).Render(r.Context(), w)
 }
 
-// writeHTTPErrorWithToast writes an HTTP error response with toast notification
-func (h *BackOfficeHandler) writeHTTPErrorWithToast(w http.ResponseWriter, message string, statusCode int, toastType string) {
-	w.Header().Set("HX-Trigger", fmt.Sprintf(`{"showToast": {"message": "%s", "type": "%s"}}`, message, toastType))
-	w.WriteHeader(statusCode)
+// pickedRelationship returns the relationship of the field a picker edits, which decides how records are labelled
+func (h *BackOfficeHandler) pickedRelationship(sourceName, fieldName string) *core.RelationshipInfo {
+	if source, exists := h.bo.GetResource(sourceName); exists {
+		if field, ok := source.GetField(fieldName); ok && field.Relationship != nil {
+			return field.Relationship
+		}
+	}
+	return &core.RelationshipInfo{}
 }
 
-// handleDeleteResourceFromDetail handles DELETE requests from the detail view
-func (h *BackOfficeHandler) handleDeleteResourceFromDetail(w http.ResponseWriter, r *http.Request, resource *core.Resource, idStr string) {
-	if resource.ReadOnly {
-		h.writeHTTPError(w, "Resource is read-only", http.StatusForbidden)
-		return
+// withRelationLabels loads the display text of the records a form's relation pickers and many-to-many chips start with
+func (h *BackOfficeHandler) withRelationLabels(ctx context.Context, resource *core.Resource, item any) context.Context {
+	prefill, _ := ctx.Value("formPrefill").(url.Values)
+	labels := make(map[string]string)
+	chips := make(map[string][]filterOption)
+	quickCreate := make(map[string]bool)
+	for _, field := range resource.Fields {
+		if field.Relationship.IsPicker() || field.Relationship.IsManyToMany() {
+			related, exists := h.bo.GetResource(field.Relationship.RelatedModel)
+			quickCreate[field.Name] = exists && !related.ReadOnly && related.Can(ctx, core.OperationCreate)
+		}
+		if field.Relationship.IsManyToMany() && item != nil {
+			chips[field.Name] = h.linkedChips(ctx, resource, field, item)
+			continue
+		}
+		if !field.Relationship.IsPicker() {
+			continue
+		}
+		value := foreignKeyValue(item, prefill, field)
+		related, exists := h.bo.GetResource(field.Relationship.RelatedModel)
+		if value == "" || !exists {
+			continue
+		}
+		id, err := related.ParseStoredID(value)
+		if err != nil {
+			continue
+		}
+		if record, err := core.GetScopedByID(ctx, h.bo.GetAdapter(), related, id); err == nil && record != nil {
+			labels[field.Name] = field.Relationship.DisplayValue(record)
+		}
 	}
+	ctx = context.WithValue(ctx, "relationChips", chips)
+	ctx = context.WithValue(ctx, "quickCreate", quickCreate)
+	return context.WithValue(ctx, "relationLabels", labels)
+}
 
-	id, err := strconv.ParseUint(idStr, 10, 32)
-	if err != nil {
-		h.writeHTTPError(w, "Invalid ID format", http.StatusBadRequest)
-		return
+// linkedChips loads the records a many-to-many field links the item to, as chips
+// Records that fail to load are left out rather than failing the form
+func (h *BackOfficeHandler) linkedChips(ctx context.Context, resource *core.Resource, field core.FieldInfo, item any) []filterOption {
+	related, exists := h.bo.GetResource(field.Relationship.RelatedModel)
+	id := core.GetFieldValue(item, resource.IDField)
+	if !exists || id == nil || reflect.ValueOf(id).IsZero() {
+		return nil
 	}
-
-	// First check if the resource exists
-	_, err = h.bo.GetAdapter().GetByID(r.Context(), resource, uint(id))
+	ids, err := core.LinkedIDs(ctx, h.bo.GetAdapter(), resource, &field, id)
 	if err != nil {
-		h.writeHTTPError(w, fmt.Sprintf("%s not found", resource.DisplayName), http.StatusNotFound)
-		return
+		return nil
 	}
 
-	// Perform the deletion
-	if err := h.bo.GetAdapter().Delete(r.Context(), resource, uint(id)); err != nil {
-		h.writeHTTPError(w, fmt.Sprintf("Failed to delete %s: %v", resource.DisplayName, err), http.StatusInternalServerError)
-		return
+	var chips []filterOption
+	for _, linkedID := range ids {
+		relatedID, err := related.ParseStoredID(formValue(linkedID))
+		if err != nil {
+			continue
+		}
+		if record, err := core.GetScopedByID(ctx, h.bo.GetAdapter(), related, relatedID); err == nil && record != nil {
+			chips = append(chips, filterOption{Value: formValue(linkedID), Label: field.Relationship.DisplayValue(record)})
+		}
 	}
-
-	// Redirect to list view with success message
-	http.Redirect(w, r, h.bo.GetConfig().BasePath+"/"+resource.Name+"?success=delete&resource="+resource.DisplayName, http.StatusSeeOther)
+	return chips
 }
 
-// formToStruct converts form data to a struct instance
-func (h *BackOfficeHandler) formToStruct(r *http.Request, resource *core.Resource) (interface{}, error) {
-	// Create new instance of the model type
-	item := newInstance(resource.ModelType)
-	val := reflect.ValueOf(item).Elem()
-
-	// Set form values to struct fields
+// saveLinks replaces the links of the many-to-many fields posted with the form
+// Fields missing from the form keep their links
+func (h *BackOfficeHandler) saveLinks(r *http.Request, resource *core.Resource, id any) error {
 	for _, field := range resource.Fields {
-		if field.PrimaryKey {
-			continue // Skip ID field for creation
+		if !field.Relationship.IsManyToMany() || !isFormEditable(field) {
+			continue
 		}
-
-		formValue := r.FormValue(field.Name)
-		fieldVal := val.FieldByName(field.Name)
-
-		if !fieldVal.IsValid() || !fieldVal.CanSet() {
+		if _, posted := r.Form[field.Name]; !posted {
 			continue
 		}
+		related, exists := h.bo.GetResource(field.Relationship.RelatedModel)
+		if !exists {
+			return fmt.Errorf("resource '%s' not found", field.Relationship.RelatedModel)
+		}
 
-		if err := h.setFieldValue(fieldVal, formValue, field.Type); err != nil {
-			return nil, fmt.Errorf("error setting field %s: %v", field.Name, err)
+		var relatedIDs []any
+		for _, value := range linkedFormValues(r, field) {
+			relatedID, err := related.ParseStoredID(value)
+			if err != nil {
+				return errors.New(msg(r.Context(), "validation.invalid", field.DisplayName, err))
+			}
+			relatedIDs = append(relatedIDs, relatedID)
+		}
+		if err := core.SetLinks(r.Context(), h.bo.GetAdapter(), resource, &field, id, relatedIDs); err != nil {
+			return err
 		}
 	}
+	return nil
+}
 
-	return item, nil
+// linkedFormValues returns the related IDs posted for a many-to-many field, without the empty placeholder
+func linkedFormValues(r *http.Request, field core.FieldInfo) []string {
+	r.FormValue(field.Name) // Parses the form if it hasn't been yet
+	var values []string
+	for _, value := range r.Form[field.Name] {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+// handleDeleteResource handles DELETE requests
+// Deletes needing a step-up confirmation, see core.Resource.DeleteStepUp, must go through handlePermanentDelete instead
+func (h *BackOfficeHandler) handleDeleteResource(w http.ResponseWriter, r *http.Request, resource *core.Resource, idStr string) {
+	permanent := r.URL.Query().Get("permanent") == "true"
+	if resource.DeleteStepUp(permanent) != auth.StepUpNone {
+		h.writeHTTPErrorWithToast(w, msg(r.Context(), "step_up.required"), http.StatusForbidden, "error")
+		return
+	}
+	h.deleteResource(w, r, resource, idStr, permanent, false)
+}
+
+// deleteResource deletes a record, moving it to the trash unless permanent, and reloads the list when refresh is set
+func (h *BackOfficeHandler) deleteResource(w http.ResponseWriter, r *http.Request, resource *core.Resource, idStr string, permanent, refresh bool) {
+	if resource.ReadOnly {
+		h.writeHTTPErrorWithToast(w, msg(r.Context(), "error.delete_read_only"), http.StatusForbidden, "error")
+		return
+	}
+
+	id, err := resource.ParseID(idStr)
+	if err != nil {
+		h.writeHTTPErrorWithToast(w, msg(r.Context(), "error.invalid_id"), http.StatusBadRequest, "error")
+		return
+	}
+
+	// First check if the resource exists; permanent deletes also reach records in the trash
+	_, err = h.bo.GetAdapter().GetByID(r.Context(), resource, id)
+	if err == nil && permanent {
+		err = core.CheckScopeWithTrashed(r.Context(), h.bo.GetAdapter(), resource, id)
+	} else if err == nil {
+		err = core.CheckScope(r.Context(), h.bo.GetAdapter(), resource, id)
+	}
+	if err != nil {
+		h.writeHTTPErrorWithToast(w, msg(r.Context(), "error.not_found", resource.DisplayName), http.StatusNotFound, "error")
+		return
+	}
+
+	// Perform the deletion, soft-deleting resources that keep a trash
+	if err := core.DeleteRecord(r.Context(), h.bo.GetAdapter(), resource, id, permanent); err != nil {
+		h.writeHTTPErrorWithToast(w, msg(r.Context(), "error.delete_failed", resource.DisplayName, err), http.StatusInternalServerError, "error")
+		return
+	}
+
+	message := msg(r.Context(), "toast.deleted", resource.DisplayName)
+	if resource.SoftDeleteField != "" && !permanent {
+		message = msg(r.Context(), "toast.trashed", resource.DisplayName)
+	}
+
+	// Return success response with toast notification, leaving the detail page of a record deleted from it
+	if current, err := url.Parse(r.Header.Get("HX-Current-URL")); refresh && err == nil && current.Path == "/admin/"+resource.Name+"/"+idStr {
+		w.Header().Set("HX-Redirect", "/admin/"+resource.Name)
+	} else if refresh {
+		setToastTrigger(w, message, "success", true)
+	} else {
+		setToastTrigger(w, message, "success", false)
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// handleRestoreResource moves a soft-deleted record out of the trash
+func (h *BackOfficeHandler) handleRestoreResource(w http.ResponseWriter, r *http.Request, resource *core.Resource, idStr string) {
+	if resource.ReadOnly {
+		h.writeHTTPErrorWithToast(w, msg(r.Context(), "error.restore_read_only"), http.StatusForbidden, "error")
+		return
+	}
+
+	id, err := resource.ParseID(idStr)
+	if err != nil {
+		h.writeHTTPErrorWithToast(w, msg(r.Context(), "error.invalid_id"), http.StatusBadRequest, "error")
+		return
+	}
+
+	if err := core.CheckScopeWithTrashed(r.Context(), h.bo.GetAdapter(), resource, id); err != nil {
+		h.writeHTTPErrorWithToast(w, msg(r.Context(), "error.not_found", resource.DisplayName), http.StatusNotFound, "error")
+		return
+	}
+
+	if err := core.RestoreRecord(r.Context(), h.bo.GetAdapter(), resource, id); err != nil {
+		h.writeHTTPErrorWithToast(w, msg(r.Context(), "error.restore_failed", resource.DisplayName, err), http.StatusInternalServerError, "error")
+		return
+	}
+
+	setToastTrigger(w, msg(r.Context(), "toast.restored", resource.DisplayName), "success", false)
+	w.WriteHeader(http.StatusOK)
+}
+
+// requiredOperation maps a resource route to the operation it performs
+// Segments start with the resource name, for both HTML and API routes
+func requiredOperation(r *http.Request, segments []string) core.Operation {
+	switch len(segments) {
+	case 1:
+		if r.Method == http.MethodPost {
+			return core.OperationCreate
+		}
+		return core.OperationList
+	case 2:
+		if segments[1] == "new" || segments[1] == "import" {
+			return core.OperationCreate
+		}
+		if segments[1] == "views" || segments[1] == "columns" {
+			return core.OperationList // Saved views and columns only change how the list is shown
+		}
+		if segments[1] == "bulk-delete" || (segments[1] == "bulk-action" && r.FormValue("action_id") == core.BulkDeleteActionID) {
+			return core.OperationDelete
+		}
+		if r.Method == http.MethodDelete || (r.Method == http.MethodPost && r.FormValue("_method") == "DELETE") {
+			return core.OperationDelete
+		}
+		if r.Method == http.MethodPost {
+			return core.OperationUpdate
+		}
+		return core.OperationList
+	default:
+		if segments[1] == "views" {
+			return core.OperationList
+		}
+		if segments[2] == "duplicate" {
+			return core.OperationCreate
+		}
+		if segments[2] == "delete-permanently" {
+			return core.OperationDelete
+		}
+		if segments[2] == "inline" {
+			// Inline children are checked against the child resource's permissions
+			return core.OperationList
+		}
+		if segments[2] == "unmask" {
+			// Revealing a masked value takes OperationUnmask, checked by the handler
+			return core.OperationList
+		}
+		if segments[2] == "edit" || segments[2] == "field" || segments[2] == "action" || r.Method == http.MethodPost {
+			return core.OperationUpdate
+		}
+		return core.OperationList
+	}
+}
+
+// isOwnerDenied reports whether an owner-restricted record addressed by the path belongs to someone else
+// Missing records and invalid IDs are left to the handlers to report
+func (h *BackOfficeHandler) isOwnerDenied(r *http.Request, resource *core.Resource, segments []string, op core.Operation) bool {
+	if !resource.OwnerOnly || (op != core.OperationUpdate && op != core.OperationDelete) {
+		return false
+	}
+	if len(segments) > 2 && segments[2] == "inline" {
+		return false // Inline children are checked against the child resource
+	}
+
+	id, ok := routeRecordID(resource, segments)
+	if !ok {
+		return false
+	}
+	return errors.Is(core.CheckOwner(r.Context(), h.bo.GetAdapter(), resource, id, op), core.ErrNotOwner)
+}
+
+// authorizeRoute asks the AuthConfig.Authorize hook, if any, about a resource route
+// Custom actions are authorized by their own operation, see core.ActionOperation
+func (h *BackOfficeHandler) authorizeRoute(r *http.Request, resource *core.Resource, segments []string, op core.Operation) error {
+	switch {
+	case len(segments) == 2 && (segments[1] == "bulk-action" || segments[1] == "collection-action") && op != core.OperationDelete:
+		op = core.ActionOperation(r.FormValue("action_id"))
+	case len(segments) == 3 && segments[2] == "action":
+		op = core.ActionOperation(r.FormValue("action_id"))
+	}
+	id, _ := routeRecordID(resource, segments)
+	return resource.Authorize(r.Context(), op, id)
+}
+
+// routeRecordID returns the ID of the record a resource route addresses, if it addresses one
+func routeRecordID(resource *core.Resource, segments []string) (any, bool) {
+	if len(segments) < 2 {
+		return nil, false
+	}
+	switch segments[1] {
+	case "new", "import", "bulk-action", "bulk-delete", "collection-action", "export", "export.csv", "options", "choices", "views", "columns", "reorder", "stats":
+		return nil, false
+	}
+	id, err := resource.ParseID(segments[1])
+	if err != nil {
+		return nil, false
+	}
+	return id, true
+}
+
+// writeHTTPError writes an HTTP error response
+func (h *BackOfficeHandler) writeHTTPError(w http.ResponseWriter, message string, statusCode int) {
+	w.WriteHeader(statusCode)
+	fmt.Fprintf(w, "<html><body><h1>Error %d</h1><p>%s</p></body></html>", statusCode, message)
+}
+
+// writeHTTPErrorWithToast writes an HTTP error response with toast notification
+func (h *BackOfficeHandler) writeHTTPErrorWithToast(w http.ResponseWriter, message string, statusCode int, toastType string) {
+	setToastTrigger(w, message, toastType, false)
+	w.WriteHeader(statusCode)
+}
+
+// setToastTrigger sets the HX-Trigger header showing a toast, also reloading the list when refreshList is set
+func setToastTrigger(w http.ResponseWriter, message, toastType string, refreshList bool) {
+	events := map[string]any{
+		"showToast": map[string]string{"message": message, "type": toastType},
+	}
+	if refreshList {
+		events["refreshList"] = true
+	}
+	trigger, _ := json.Marshal(events)
+	w.Header().Set("HX-Trigger", string(trigger))
+}
+
+// writeValidationError reports failed validation as a toast and maps the messages back to form fields
+func (h *BackOfficeHandler) writeValidationError(w http.ResponseWriter, r *http.Request, err error) {
+	var failures core.ValidationErrors
+	if !errors.As(err, &failures) {
+		h.writeHTTPErrorWithToast(w, msg(r.Context(), "validation.failed", err), http.StatusBadRequest, "error")
+		return
+	}
+
+	trigger, _ := json.Marshal(map[string]any{
+		"showToast":   map[string]string{"message": msg(r.Context(), "validation.failed", failures.Error()), "type": "error"},
+		"fieldErrors": failures.FieldMessages(),
+	})
+	w.Header().Set("HX-Trigger", string(trigger))
+	w.WriteHeader(http.StatusBadRequest)
+}
+
+// handleDeleteResourceFromDetail handles DELETE requests from the detail view
+func (h *BackOfficeHandler) handleDeleteResourceFromDetail(w http.ResponseWriter, r *http.Request, resource *core.Resource, idStr string) {
+	if resource.ReadOnly {
+		h.writeHTTPError(w, msg(r.Context(), "error.read_only"), http.StatusForbidden)
+		return
+	}
+	if resource.DeleteStepUp(false) != auth.StepUpNone {
+		h.writeHTTPError(w, msg(r.Context(), "step_up.required"), http.StatusForbidden)
+		return
+	}
+
+	id, err := resource.ParseID(idStr)
+	if err != nil {
+		h.writeHTTPError(w, msg(r.Context(), "error.invalid_id"), http.StatusBadRequest)
+		return
+	}
+
+	// First check if the resource exists
+	_, err = core.GetScopedByID(r.Context(), h.bo.GetAdapter(), resource, id)
+	if err != nil {
+		h.writeHTTPError(w, msg(r.Context(), "error.not_found", resource.DisplayName), http.StatusNotFound)
+		return
+	}
+
+	// Perform the deletion, soft-deleting resources that keep a trash
+	if err := core.DeleteRecord(r.Context(), h.bo.GetAdapter(), resource, id, false); err != nil {
+		h.writeHTTPError(w, msg(r.Context(), "error.delete_failed", resource.DisplayName, err), http.StatusInternalServerError)
+		return
+	}
+
+	// Redirect to list view with success message
+	http.Redirect(w, r, h.bo.GetConfig().BasePath+"/"+resource.Name+"?success=delete&resource="+resource.DisplayName, http.StatusSeeOther)
+}
+
+// formToStruct converts form data to a struct instance
+// Submitted values for primary key, read-only and computed fields are ignored,
+// and required fields must not be empty
+func (h *BackOfficeHandler) formToStruct(r *http.Request, resource *core.Resource) (interface{}, error) {
+	if err := checkRequiredFields(r, resource.Fields); err != nil {
+		return nil, err
+	}
+
+	// Create new instance of the model type
+	item := newInstance(resource.ModelType)
+	val := reflect.ValueOf(item).Elem()
+
+	// Set form values to struct fields
+	for _, field := range resource.Fields {
+		if !isFormEditable(field) {
+			continue // Never trust submitted values for fields the form can't edit
+		}
+
+		if field.Relationship.IsPicker() {
+			if err := h.setForeignKey(r, val, field); err != nil {
+				return nil, fmt.Errorf("error setting field %s: %v", field.Name, err)
+			}
+			continue
+		}
+
+		formValue := formFieldValue(r, field)
+		fieldVal := val.FieldByName(field.Name)
+
+		if !fieldVal.IsValid() || !fieldVal.CanSet() {
+			continue
+		}
+
+		if err := h.setFieldValue(fieldVal, formValue, field); err != nil {
+			return nil, fmt.Errorf("error setting field %s: %v", field.Name, err)
+		}
+	}
+
+	return item, nil
+}
+
+// formFieldValue returns the submitted value of a field, keeping only the safe formatting of rich text
+func formFieldValue(r *http.Request, field core.FieldInfo) string {
+	if field.RenderAs == core.RenderRichText {
+		return core.SanitizeHTML(r.FormValue(field.Name))
+	}
+	return r.FormValue(field.Name)
+}
+
+// isFormEditable reports whether a field's value may be taken from a submitted form
+func isFormEditable(field core.FieldInfo) bool {
+	return !field.PrimaryKey && !field.ReadOnly && !field.IsComputed
+}
+
+// checkRequiredFields returns an error naming every required field submitted empty
+// Slug fields are exempt because blank slugs are generated from their source field
+func checkRequiredFields(r *http.Request, fields []core.FieldInfo) error {
+	var missing []string
+	for _, field := range fields {
+		if !field.Required || !isFormEditable(field) || field.SlugSource != "" || field.Type == "bool" {
+			continue
+		}
+		if field.Relationship.IsManyToMany() {
+			if len(linkedFormValues(r, field)) == 0 {
+				missing = append(missing, field.DisplayName)
+			}
+		} else if strings.TrimSpace(r.FormValue(formInputName(field))) == "" {
+			missing = append(missing, field.DisplayName)
+		}
+	}
+	if len(missing) > 0 {
+		return errors.New(msg(r.Context(), "validation.required_missing", strings.Join(missing, ", ")))
+	}
+	return nil
+}
+
+// formInputName returns the name a field's value is posted under; relation pickers post the foreign key
+func formInputName(field core.FieldInfo) string {
+	if field.Relationship.IsPicker() {
+		return field.Relationship.ForeignKey
+	}
+	return field.Name
 }
 
 // setFieldValue sets a struct field value from a string
-func (h *BackOfficeHandler) setFieldValue(fieldVal reflect.Value, value, fieldType string) error {
+func (h *BackOfficeHandler) setFieldValue(fieldVal reflect.Value, value string, field core.FieldInfo) error {
+	if field.IsTimeField() {
+		return h.setTimeValue(fieldVal, value, field)
+	}
 	if value == "" {
 		return nil // Skip empty values
 	}
 
-	switch fieldType {
+	switch field.Type {
 	case "string":
 		fieldVal.SetString(value)
 	case "int", "int32", "int64":
@@ -509,6 +1399,51 @@ func (h *BackOfficeHandler) setFieldValue(fieldVal reflect.Value, value, fieldTy
 	return nil
 }
 
+// setForeignKey links the record to the one picked in a relation picker by setting the relationship's foreign key
+func (h *BackOfficeHandler) setForeignKey(r *http.Request, val reflect.Value, field core.FieldInfo) error {
+	foreignKey := field.Relationship.ForeignKey
+	fieldVal := val.FieldByName(foreignKey)
+	if !fieldVal.IsValid() || !fieldVal.CanSet() {
+		return nil
+	}
+	value := strings.TrimSpace(r.FormValue(foreignKey))
+	if fieldVal.Kind() != reflect.Ptr {
+		return h.setFieldValue(fieldVal, value, core.FieldInfo{Name: foreignKey, Type: fieldVal.Type().String()})
+	}
+	if value == "" {
+		fieldVal.Set(reflect.Zero(fieldVal.Type())) // Clearing a nullable relation unlinks the record
+		return nil
+	}
+	target := reflect.New(fieldVal.Type().Elem())
+	if err := h.setFieldValue(target.Elem(), value, core.FieldInfo{Name: foreignKey, Type: target.Elem().Type().String()}); err != nil {
+		return err
+	}
+	fieldVal.Set(target)
+	return nil
+}
+
+// setTimeValue sets a time field from a date or datetime-local input, read in the admin's time zone
+// Clearing the input of a nullable time sets it to nil
+func (h *BackOfficeHandler) setTimeValue(fieldVal reflect.Value, value string, field core.FieldInfo) error {
+	if strings.TrimSpace(value) == "" {
+		if fieldVal.Kind() == reflect.Ptr {
+			fieldVal.Set(reflect.Zero(fieldVal.Type()))
+		}
+		return nil
+	}
+
+	t, err := core.ParseTimeInput(field, value, h.bo.Location())
+	if err != nil {
+		return err
+	}
+	if fieldVal.Kind() == reflect.Ptr {
+		fieldVal.Set(reflect.ValueOf(&t))
+	} else {
+		fieldVal.Set(reflect.ValueOf(t))
+	}
+	return nil
+}
+
 // newInstance creates a new instance of the given type
 func newInstance(t reflect.Type) interface{} {
 	if t.Kind() == reflect.Ptr {
@@ -519,27 +1454,96 @@ func newInstance(t reflect.Type) interface{} {
 
 // renderCreateSidePane renders the create form in a side pane
 func (h *BackOfficeHandler) renderCreateSidePane(w http.ResponseWriter, r *http.Request, resource *core.Resource) {
+	if r.URL.Query().Get("_picker") != "" {
+		h.renderQuickCreatePane(w, r, resource)
+		return
+	}
+
 	title := "Create " + resource.DisplayName
 	formComponent := SidePaneForm(resource, nil, false)
 	sidePaneComponent := SidePane(title, formComponent)
 
+	// Query parameters pre-fill fields, e.g. the foreign key when adding a child from its parent's page
+	ctx := context.WithValue(r.Context(), "formPrefill", r.URL.Query())
+	ctx = h.withRelationLabels(ctx, resource, nil)
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if err := sidePaneComponent.Render(ctx, w); err != nil {
+		h.writeHTTPError(w, msg(r.Context(), "error.render"), http.StatusInternalServerError)
+	}
+}
+
+// renderQuickCreatePane renders the create form stacked over the form whose relation picker opened it
+func (h *BackOfficeHandler) renderQuickCreatePane(w http.ResponseWriter, r *http.Request, resource *core.Resource) {
+	prefill := r.URL.Query()
+	origin := url.Values{}
+	for _, key := range quickCreateKeys {
+		origin.Set(key, prefill.Get(key))
+		prefill.Del(key)
+	}
+
+	ctx := context.WithValue(r.Context(), "formPrefill", prefill)
+	ctx = h.withRelationLabels(ctx, resource, nil)
+	// Only one pane stacks over a form, so its own pickers can't create records
+	ctx = context.WithValue(ctx, "quickCreate", map[string]bool{})
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if err := QuickCreatePane(resource, origin).Render(ctx, w); err != nil {
+		h.writeHTTPError(w, msg(r.Context(), "error.render"), http.StatusInternalServerError)
+	}
+}
+
+// writeQuickCreated closes the stacked create pane and selects the new record in the picker that opened it
+func (h *BackOfficeHandler) writeQuickCreated(w http.ResponseWriter, r *http.Request, resource *core.Resource, item any) {
+	display := h.pickedRelationship(r.FormValue("_source"), r.FormValue("_field"))
+	trigger, _ := json.Marshal(map[string]any{
+		"closeSidePane": map[string]string{"id": quickCreatePaneID},
+		"relation-created": map[string]string{
+			"picker": r.FormValue("_picker"),
+			"value":  formValue(core.GetFieldValue(item, resource.IDField)),
+			"label":  display.DisplayValue(item),
+		},
+		"showToast": map[string]string{"message": msg(r.Context(), "toast.created", resource.DisplayName), "type": "success"},
+	})
+	w.Header().Set("HX-Trigger", string(trigger))
+	w.Header().Set("HX-Reswap", "none")
+	w.WriteHeader(http.StatusOK)
+}
+
+// renderDuplicateSidePane renders the create form in a side pane, pre-filled from an existing record
+func (h *BackOfficeHandler) renderDuplicateSidePane(w http.ResponseWriter, r *http.Request, resource *core.Resource, idStr string) {
+	id, err := resource.ParseID(idStr)
+	if err != nil {
+		h.writeHTTPError(w, msg(r.Context(), "error.invalid_id"), http.StatusBadRequest)
+		return
+	}
+
+	item, err := core.GetScopedByID(r.Context(), h.bo.GetAdapter(), resource, id)
+	if err != nil {
+		h.writeHTTPError(w, msg(r.Context(), "error.get_failed", err), http.StatusNotFound)
+		return
+	}
+
+	title := "Duplicate " + resource.DisplayName
+	duplicate := core.DuplicateRecord(resource, item)
+	formComponent := SidePaneForm(resource, duplicate, false)
+	sidePaneComponent := SidePane(title, formComponent)
+
 	w.Header().Set("Content-Type", "text/html; charset=utf-8")
-	if err := sidePaneComponent.Render(context.Background(), w); err != nil {
-		h.writeHTTPError(w, "Template rendering error", http.StatusInternalServerError)
+	if err := sidePaneComponent.Render(h.withRelationLabels(r.Context(), resource, duplicate), w); err != nil {
+		h.writeHTTPError(w, msg(r.Context(), "error.render"), http.StatusInternalServerError)
 	}
 }
 
 // renderEditSidePane renders the edit form in a side pane
 func (h *BackOfficeHandler) renderEditSidePane(w http.ResponseWriter, r *http.Request, resource *core.Resource, idStr string) {
-	id, err := strconv.ParseUint(idStr, 10, 32)
+	id, err := resource.ParseID(idStr)
 	if err != nil {
-		h.writeHTTPError(w, "Invalid ID format", http.StatusBadRequest)
+		h.writeHTTPError(w, msg(r.Context(), "error.invalid_id"), http.StatusBadRequest)
 		return
 	}
 
-	item, err := h.bo.GetAdapter().GetByID(r.Context(), resource, uint(id))
+	item, err := core.GetScopedByID(r.Context(), h.bo.GetAdapter(), resource, id)
 	if err != nil {
-		h.writeHTTPError(w, fmt.Sprintf("Failed to get item: %v", err), http.StatusNotFound)
+		h.writeHTTPError(w, msg(r.Context(), "error.get_failed", err), http.StatusNotFound)
 		return
 	}
 
@@ -548,8 +1552,8 @@ func (h *BackOfficeHandler) renderEditSidePane(w http.ResponseWriter, r *http.Re
 	sidePaneComponent := SidePane(title, formComponent)
 
 	w.Header().Set("Content-Type", "text/html; charset=utf-8")
-	if err := sidePaneComponent.Render(context.Background(), w); err != nil {
-		h.writeHTTPError(w, "Template rendering error", http.StatusInternalServerError)
+	if err := sidePaneComponent.Render(h.withRelationLabels(r.Context(), resource, item), w); err != nil {
+		h.writeHTTPError(w, msg(r.Context(), "error.render"), http.StatusInternalServerError)
 	}
 }
 
@@ -559,14 +1563,14 @@ func (h *BackOfficeHandler) handleCreateResourceAPI(w http.ResponseWriter, r *ht
 
 	if resource.ReadOnly {
 		fmt.Printf("❌ DEBUG: Resource %s is read-only\n", resource.Name)
-		h.writeHTTPErrorWithToast(w, "Resource is read-only", http.StatusForbidden, "error")
+		h.writeHTTPErrorWithToast(w, msg(r.Context(), "error.read_only"), http.StatusForbidden, "error")
 		return
 	}
 
 	// Parse form data
 	if err := r.ParseForm(); err != nil {
 		fmt.Printf("❌ DEBUG: Failed to parse form: %v\n", err)
-		h.writeHTTPErrorWithToast(w, "Invalid form data", http.StatusBadRequest, "error")
+		h.writeHTTPErrorWithToast(w, msg(r.Context(), "error.invalid_form"), http.StatusBadRequest, "error")
 		return
 	}
 	fmt.Printf("✅ DEBUG: Form data parsed successfully: %v\n", r.Form)
@@ -575,15 +1579,31 @@ func (h *BackOfficeHandler) handleCreateResourceAPI(w http.ResponseWriter, r *ht
 	item, err := h.formToStruct(r, resource)
 	if err != nil {
 		fmt.Printf("❌ DEBUG: Failed to convert form to struct: %v\n", err)
-		h.writeHTTPErrorWithToast(w, fmt.Sprintf("Invalid data format: %v", err), http.StatusBadRequest, "error")
+		h.writeHTTPErrorWithToast(w, msg(r.Context(), "error.invalid_data", err), http.StatusBadRequest, "error")
 		return
 	}
 	fmt.Printf("✅ DEBUG: Form converted to struct: %+v\n", item)
 
+	// Record the session user as the owner
+	if err := core.SetOwner(r.Context(), resource, item); err != nil {
+		h.writeHTTPErrorWithToast(w, msg(r.Context(), "error.owner_failed", err), http.StatusInternalServerError, "error")
+		return
+	}
+
+	// Fill in auto-generated slugs
+	if err := core.GenerateSlugs(r.Context(), h.bo.GetAdapter(), resource, item); err != nil {
+		h.writeHTTPErrorWithToast(w, msg(r.Context(), "error.slug_failed", err), http.StatusInternalServerError, "error")
+		return
+	}
+
 	// Validate data
 	if err := h.bo.GetAdapter().ValidateData(resource, item); err != nil {
 		fmt.Printf("❌ DEBUG: Validation failed: %v\n", err)
-		h.writeHTTPErrorWithToast(w, fmt.Sprintf("Validation error: %v", err), http.StatusBadRequest, "error")
+		h.writeHTTPErrorWithToast(w, msg(r.Context(), "validation.failed", err), http.StatusBadRequest, "error")
+		return
+	}
+	if err := resource.Validate(r.Context(), item); err != nil {
+		h.writeValidationError(w, r, err)
 		return
 	}
 	fmt.Printf("✅ DEBUG: Data validation passed\n")
@@ -591,114 +1611,161 @@ func (h *BackOfficeHandler) handleCreateResourceAPI(w http.ResponseWriter, r *ht
 	// Create item
 	if err := h.bo.GetAdapter().Create(r.Context(), resource, item); err != nil {
 		fmt.Printf("❌ DEBUG: Failed to create item: %v\n", err)
-		h.writeHTTPErrorWithToast(w, fmt.Sprintf("Failed to create item: %v", err), http.StatusInternalServerError, "error")
+		h.writeHTTPErrorWithToast(w, msg(r.Context(), "error.create_failed", err), http.StatusInternalServerError, "error")
 		return
 	}
 	fmt.Printf("✅ DEBUG: Item created successfully\n")
+	if err := h.saveLinks(r, resource, core.GetFieldValue(item, resource.IDField)); err != nil {
+		h.writeHTTPErrorWithToast(w, msg(r.Context(), "error.links_failed", err), http.StatusInternalServerError, "error")
+		return
+	}
 
-	// Get the ID of the created item
-	createdID := core.GetFieldValue(item, resource.IDField)
-	fmt.Printf("✅ DEBUG: Created item ID: %v\n", createdID)
+	// Records created from a relation picker are selected in its form instead of reloading the list
+	if r.FormValue("_picker") != "" {
+		h.writeQuickCreated(w, r, resource, item)
+		return
+	}
 
-	w.WriteHeader(http.StatusOK)
-	// Return a script to close the side pane, show toast, and reload with highlight
-	fmt.Fprintf(w, `<script>
-		console.log('✅ DEBUG: Create successful, closing side pane and reloading');
-		// Close side pane
-		const sidePane = document.getElementById('sidepane-overlay');
-		if (sidePane) {
-			const alpineData = Alpine.$data(sidePane.querySelector('[x-data]'));
-			if (alpineData) {
-				alpineData.show = false;
-				setTimeout(() => sidePane.remove(), 300);
-			}
-		}
-		// Show toast
-		showToast('%s created successfully', 'success');
-		// Store the ID to highlight after reload
-		sessionStorage.setItem('highlightItemId', '%v');
-		sessionStorage.setItem('highlightAction', 'created');
-		// Reload page after short delay
-		setTimeout(() => {
-			console.log('🔄 Reloading page...');
-			window.location.reload();
-		}, 500);
-	</script>`, resource.DisplayName, createdID)
+	h.writeRecordSaved(w, r, resource, core.GetFieldValue(item, resource.IDField), msg(r.Context(), "toast.created", resource.DisplayName), "created")
 }
 
 // handleUpdateResourceAPI handles API PUT/POST requests for updating resources with HTMX response
 func (h *BackOfficeHandler) handleUpdateResourceAPI(w http.ResponseWriter, r *http.Request, resource *core.Resource, idStr string) {
 	if resource.ReadOnly {
-		h.writeHTTPErrorWithToast(w, "Resource is read-only", http.StatusForbidden, "error")
+		h.writeHTTPErrorWithToast(w, msg(r.Context(), "error.read_only"), http.StatusForbidden, "error")
 		return
 	}
 
-	id, err := strconv.ParseUint(idStr, 10, 32)
+	id, err := resource.ParseID(idStr)
 	if err != nil {
-		h.writeHTTPErrorWithToast(w, "Invalid ID format", http.StatusBadRequest, "error")
+		h.writeHTTPErrorWithToast(w, msg(r.Context(), "error.invalid_id"), http.StatusBadRequest, "error")
 		return
 	}
 
 	// Parse form data
 	if err := r.ParseForm(); err != nil {
-		h.writeHTTPErrorWithToast(w, "Invalid form data", http.StatusBadRequest, "error")
+		h.writeHTTPErrorWithToast(w, msg(r.Context(), "error.invalid_form"), http.StatusBadRequest, "error")
+		return
+	}
+
+	// Make sure the record is within the user's scope before updating it
+	if err := core.CheckScope(r.Context(), h.bo.GetAdapter(), resource, id); err != nil {
+		h.writeHTTPErrorWithToast(w, msg(r.Context(), "error.not_found", resource.DisplayName), http.StatusNotFound, "error")
+		return
+	}
+	if err := h.keepMaskedValues(r, resource, id); err != nil {
+		h.writeHTTPErrorWithToast(w, msg(r.Context(), "error.not_found", resource.DisplayName), http.StatusNotFound, "error")
 		return
 	}
 
 	// Convert form data to struct instance
 	item, err := h.formToStruct(r, resource)
 	if err != nil {
-		h.writeHTTPErrorWithToast(w, fmt.Sprintf("Invalid data format: %v", err), http.StatusBadRequest, "error")
+		h.writeHTTPErrorWithToast(w, msg(r.Context(), "error.invalid_data", err), http.StatusBadRequest, "error")
+		return
+	}
+
+	// Run whole-record validators against the record as it will be stored
+	if err := core.ValidateUpdate(r.Context(), h.bo.GetAdapter(), resource, id, item); err != nil {
+		h.writeValidationError(w, r, err)
+		return
+	}
+
+	// Keep the previous state for resources with version history
+	if err := core.SaveRecordVersion(r.Context(), h.bo.GetAdapter(), resource, id); err != nil {
+		h.writeHTTPErrorWithToast(w, msg(r.Context(), "error.history_failed", err), http.StatusInternalServerError, "error")
 		return
 	}
 
 	// Update item
-	if err := h.bo.GetAdapter().Update(r.Context(), resource, uint(id), item); err != nil {
-		h.writeHTTPErrorWithToast(w, fmt.Sprintf("Failed to update item: %v", err), http.StatusInternalServerError, "error")
+	if err := h.bo.GetAdapter().Update(r.Context(), resource, id, item); err != nil {
+		h.writeHTTPErrorWithToast(w, msg(r.Context(), "error.update_failed", err), http.StatusInternalServerError, "error")
+		return
+	}
+	if err := h.saveLinks(r, resource, id); err != nil {
+		h.writeHTTPErrorWithToast(w, msg(r.Context(), "error.links_failed", err), http.StatusInternalServerError, "error")
 		return
 	}
 
-	w.WriteHeader(http.StatusOK)
-	// Return a script to close the side pane, show toast, and reload with highlight
-	fmt.Fprintf(w, `<script>
-		console.log('✅ DEBUG: Update successful, closing side pane and reloading');
-		// Close side pane
-		const sidePane = document.getElementById('sidepane-overlay');
-		if (sidePane) {
-			const alpineData = Alpine.$data(sidePane.querySelector('[x-data]'));
-			if (alpineData) {
-				alpineData.show = false;
-				setTimeout(() => sidePane.remove(), 300);
-			}
-		}
-		// Show toast
-		showToast('%s updated successfully', 'success');
-		// Store the ID to highlight after reload
-		sessionStorage.setItem('highlightItemId', '%s');
-		sessionStorage.setItem('highlightAction', 'updated');
-		// Reload page after short delay
-		setTimeout(() => {
-			console.log('🔄 Reloading page...');
-			window.location.reload();
-		}, 500);
-	</script>`, resource.DisplayName, idStr)
+	h.writeRecordSaved(w, r, resource, id, msg(r.Context(), "toast.updated", resource.DisplayName), "updated")
 }
 
-// handleRelatedItemsModal handles requests for showing related items in a modal
-func (h *BackOfficeHandler) handleRelatedItemsModal(w http.ResponseWriter, r *http.Request, resource *core.Resource, idStr, fieldName string) {
-	id, err := strconv.ParseUint(idStr, 10, 32)
-	if err != nil {
-		h.writeHTTPError(w, "Invalid ID format", http.StatusBadRequest)
+// writeRecordSaved closes the side pane a record was saved from and updates the list in place:
+// the record's row is swapped in out of band, and recordSaved highlights it once settled
+// Pages without the row, like detail pages or the tree view, reload on recordSaved instead
+func (h *BackOfficeHandler) writeRecordSaved(w http.ResponseWriter, r *http.Request, resource *core.Resource, id any, message, action string) {
+	trigger, _ := json.Marshal(map[string]any{
+		"closeSidePane": map[string]string{"id": "sidepane-overlay"},
+		"showToast":     map[string]string{"message": message, "type": "success"},
+	})
+	w.Header().Set("HX-Trigger", string(trigger))
+	w.Header().Set("HX-Reswap", "none")
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+
+	// Reload the record so the row shows stored and computed values
+	item, err := core.GetScopedByID(r.Context(), h.bo.GetAdapter(), resource, id)
+	if err != nil || item == nil {
+		w.WriteHeader(http.StatusOK)
 		return
 	}
+	settle, _ := json.Marshal(map[string]any{
+		"recordSaved": map[string]string{"row": listRowID(resource, item), "action": action},
+	})
+	w.Header().Set("HX-Trigger-After-Settle", string(settle))
+	w.WriteHeader(http.StatusOK)
 
-	// Get the parent item with preloaded relationships
-	item, err := h.bo.GetAdapter().GetByID(r.Context(), resource, uint(id))
-	if err != nil {
-		h.writeHTTPError(w, fmt.Sprintf("Failed to get item: %v", err), http.StatusNotFound)
+	ctx, ok := h.listRowContext(r, resource)
+	if !ok {
 		return
 	}
-
+	if action == "created" {
+		// New records go on top of the list; the wrapping tbody only carries the swap
+		fmt.Fprint(w, `<tbody hx-swap-oob="afterbegin:#table-body">`)
+		ListRow(resource, item).Render(ctx, w)
+		fmt.Fprint(w, `</tbody>`)
+		return
+	}
+	ListRow(resource, item).Render(context.WithValue(ctx, "rowSwapOOB", "true"), w)
+}
+
+// listRowContext prepares rendering a row for the list page a request was sent from,
+// reading its columns, sort and trash view; it reports false when the request didn't come from the list
+func (h *BackOfficeHandler) listRowContext(r *http.Request, resource *core.Resource) (context.Context, bool) {
+	current, err := url.Parse(r.Header.Get("HX-Current-URL"))
+	if err != nil || strings.TrimSuffix(current.Path, "/") != "/admin/"+resource.Name {
+		return nil, false
+	}
+	list := r.Clone(r.Context())
+	list.URL = current
+	query := parseQueryFromRequest(list, resource)
+	if query.Trashed {
+		return nil, false
+	}
+
+	ctx := context.WithValue(r.Context(), "listQuery", current.RawQuery)
+	ctx = context.WithValue(ctx, "listColumns", listColumnsFromRequest(r, resource))
+	if primarySort := query.GetPrimarySort(); primarySort != nil {
+		ctx = context.WithValue(ctx, "currentSortField", primarySort.Field)
+		ctx = context.WithValue(ctx, "currentSortDirection", string(primarySort.Direction))
+	}
+	return ctx, true
+}
+
+// handleRelatedItemsModal handles requests for showing related items in a modal
+func (h *BackOfficeHandler) handleRelatedItemsModal(w http.ResponseWriter, r *http.Request, resource *core.Resource, idStr, fieldName string) {
+	id, err := resource.ParseID(idStr)
+	if err != nil {
+		h.writeHTTPError(w, msg(r.Context(), "error.invalid_id"), http.StatusBadRequest)
+		return
+	}
+
+	// Get the parent item with preloaded relationships
+	item, err := core.GetScopedByID(r.Context(), h.bo.GetAdapter(), resource, id)
+	if err != nil {
+		h.writeHTTPError(w, msg(r.Context(), "error.get_failed", err), http.StatusNotFound)
+		return
+	}
+
 	// Extract the related items from the specified field
 	relatedItems := core.GetFieldValue(item, fieldName)
 	if relatedItems == nil {
@@ -730,6 +1797,13 @@ func (h *BackOfficeHandler) handleRelatedItemsModal(w http.ResponseWriter, r *ht
 		}
 	}
 
+	if relatedResource != nil {
+		if err := relatedResource.Authorize(r.Context(), core.OperationList, nil); err != nil {
+			h.writeHTTPErrorWithToast(w, err.Error(), http.StatusForbidden, "error")
+			return
+		}
+	}
+
 	// Generate modal title
 	title := fmt.Sprintf("%s for %s", fieldName, core.GetFieldValue(item, getDisplayFieldName(resource)))
 
@@ -737,9 +1811,559 @@ func (h *BackOfficeHandler) handleRelatedItemsModal(w http.ResponseWriter, r *ht
 	modalComponent := RelatedItemsModal(title, items, relatedResource, fieldName)
 
 	w.Header().Set("Content-Type", "text/html; charset=utf-8")
-	if err := modalComponent.Render(context.Background(), w); err != nil {
-		h.writeHTTPError(w, "Template rendering error", http.StatusInternalServerError)
+	if err := modalComponent.Render(r.Context(), w); err != nil {
+		h.writeHTTPError(w, msg(r.Context(), "error.render"), http.StatusInternalServerError)
+	}
+}
+
+// renderRelatedLinks counts the children of a one-to-many field and renders links to them
+func (h *BackOfficeHandler) renderRelatedLinks(w http.ResponseWriter, r *http.Request, resource *core.Resource, idStr, fieldName string) {
+	field, ok := resource.GetField(fieldName)
+	if !ok || field.Relationship == nil || !field.Relationship.ShowsListLink() {
+		h.writeHTTPError(w, msg(r.Context(), "error.no_child_list", fieldName), http.StatusNotFound)
+		return
+	}
+	child, exists := h.bo.GetResource(field.Relationship.RelatedModel)
+	if !exists {
+		h.writeHTTPError(w, msg(r.Context(), "error.resource_not_found", field.Relationship.RelatedModel), http.StatusNotFound)
+		return
+	}
+
+	id, err := resource.ParseID(idStr)
+	if err != nil {
+		h.writeHTTPError(w, msg(r.Context(), "error.invalid_id"), http.StatusBadRequest)
+		return
+	}
+	if err := core.CheckScope(r.Context(), h.bo.GetAdapter(), resource, id); err != nil {
+		h.writeHTTPError(w, msg(r.Context(), "error.not_found", resource.DisplayName), http.StatusNotFound)
+		return
+	}
+
+	count, err := core.CountChildren(r.Context(), h.bo.GetAdapter(), child, field.Relationship.ForeignKey, id)
+	if err != nil {
+		h.writeHTTPError(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if err := RelatedLinks(resource, idStr, field, child, count).Render(r.Context(), w); err != nil {
+		h.writeHTTPError(w, msg(r.Context(), "error.render"), http.StatusInternalServerError)
+	}
+}
+
+// markdownPreviewHandler renders the posted markdown of a field for the editor's live preview
+func (h *BackOfficeHandler) markdownPreviewHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		h.writeHTTPError(w, msg(r.Context(), "error.method"), http.StatusMethodNotAllowed)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	fmt.Fprint(w, core.MarkdownToHTML(r.FormValue(r.URL.Query().Get("field"))))
+}
+
+// renderRelatedTable renders a page of the children of a one-to-many field for the parent's detail page
+// Users who can't list the children get an empty response, removing the section
+func (h *BackOfficeHandler) renderRelatedTable(w http.ResponseWriter, r *http.Request, resource *core.Resource, idStr, fieldName string) {
+	field, ok := resource.GetField(fieldName)
+	if !ok || field.Relationship == nil || !field.Relationship.ShowsListLink() {
+		h.writeHTTPError(w, msg(r.Context(), "error.no_child_list", fieldName), http.StatusNotFound)
+		return
+	}
+	child, exists := h.bo.GetResource(field.Relationship.RelatedModel)
+	if !exists {
+		h.writeHTTPError(w, msg(r.Context(), "error.resource_not_found", field.Relationship.RelatedModel), http.StatusNotFound)
+		return
+	}
+
+	id, err := resource.ParseID(idStr)
+	if err != nil {
+		h.writeHTTPError(w, msg(r.Context(), "error.invalid_id"), http.StatusBadRequest)
+		return
+	}
+	if err := core.CheckScope(r.Context(), h.bo.GetAdapter(), resource, id); err != nil {
+		h.writeHTTPError(w, msg(r.Context(), "error.not_found", resource.DisplayName), http.StatusNotFound)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if !child.Can(r.Context(), core.OperationList) || child.Authorize(r.Context(), core.OperationList, nil) != nil {
+		return
+	}
+
+	page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+	if page < 1 {
+		page = 1
+	}
+	result, err := core.FindChildren(r.Context(), h.bo.GetAdapter(), child, field.Relationship.ForeignKey, id, page)
+	if err != nil {
+		h.writeHTTPError(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	if err := RelatedRecordsTable(resource, idStr, field, child, result, page).Render(r.Context(), w); err != nil {
+		h.writeHTTPError(w, msg(r.Context(), "error.render"), http.StatusInternalServerError)
+	}
+}
+
+// handleReorder stores the order of the posted record IDs in the resource's order field
+func (h *BackOfficeHandler) handleReorder(w http.ResponseWriter, r *http.Request, resource *core.Resource) {
+	if resource.ReadOnly {
+		h.writeHTTPErrorWithToast(w, msg(r.Context(), "error.read_only"), http.StatusForbidden, "error")
+		return
+	}
+	if err := r.ParseForm(); err != nil {
+		h.writeHTTPErrorWithToast(w, msg(r.Context(), "error.invalid_form"), http.StatusBadRequest, "error")
+		return
+	}
+	if len(r.Form["order"]) > core.MaxBulkSelection {
+		h.writeHTTPErrorWithToast(w, msg(r.Context(), "error.reorder_limit", core.MaxBulkSelection), http.StatusBadRequest, "error")
+		return
+	}
+
+	var ids []any
+	for _, idStr := range r.Form["order"] {
+		id, err := resource.ParseID(idStr)
+		if err != nil {
+			h.writeHTTPErrorWithToast(w, msg(r.Context(), "error.invalid_id_value", idStr), http.StatusBadRequest, "error")
+			return
+		}
+		if err := resource.Authorize(r.Context(), core.OperationUpdate, id); err != nil {
+			h.writeHTTPErrorWithToast(w, err.Error(), http.StatusForbidden, "error")
+			return
+		}
+		ids = append(ids, id)
+	}
+
+	if err := core.ReorderRecords(r.Context(), h.bo.GetAdapter(), resource, ids); err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, core.ErrNotOwner) {
+			status = http.StatusForbidden
+		}
+		h.writeHTTPErrorWithToast(w, msg(r.Context(), "error.reorder_failed", resource.PluralName, err), status, "error")
+		return
+	}
+
+	setToastTrigger(w, msg(r.Context(), "toast.order_saved"), "success", false)
+	w.WriteHeader(http.StatusOK)
+}
+
+// handleListColumns stores the list columns chosen by the user in a cookie and refreshes the page
+// Posting reset=true goes back to the resource's configured columns
+func (h *BackOfficeHandler) handleListColumns(w http.ResponseWriter, r *http.Request, resource *core.Resource) {
+	cookie := &http.Cookie{
+		Name:     columnsCookieName(resource),
+		Path:     h.bo.GetConfig().BasePath,
+		MaxAge:   365 * 24 * 60 * 60,
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+	}
+
+	if r.FormValue("reset") == "true" {
+		cookie.MaxAge = -1
+	} else {
+		columns := validColumns(resource, r.Form["columns"])
+		if len(columns) == 0 {
+			h.writeHTTPErrorWithToast(w, msg(r.Context(), "error.no_columns"), http.StatusBadRequest, "error")
+			return
+		}
+		cookie.Value = strings.Join(columns, ".")
+	}
+
+	http.SetCookie(w, cookie)
+	w.Header().Set("HX-Refresh", "true")
+	w.WriteHeader(http.StatusOK)
+}
+
+// preferencesHandler stores the density and text size picked in the preferences menu in a cookie and refreshes the page
+func (h *BackOfficeHandler) preferencesHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	density, textSize := r.FormValue("density"), r.FormValue("text_size")
+	if !validDensity(density) || !validTextSize(textSize) {
+		h.writeHTTPErrorWithToast(w, msg(r.Context(), "error.preference"), http.StatusBadRequest, "error")
+		return
+	}
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     preferencesCookieName,
+		Value:    density + "." + textSize,
+		Path:     h.bo.GetConfig().BasePath,
+		MaxAge:   365 * 24 * 60 * 60,
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+	})
+	w.Header().Set("HX-Refresh", "true")
+	w.WriteHeader(http.StatusOK)
+}
+
+// handleSavedViews lists, saves and deletes the saved views of a resource, responding with the refreshed views menu
+func (h *BackOfficeHandler) handleSavedViews(w http.ResponseWriter, r *http.Request, resource *core.Resource, viewIDStr string) {
+	store := h.bo.ViewStore()
+	if store == nil {
+		h.writeHTTPError(w, msg(r.Context(), "error.views_unavailable"), http.StatusNotFound)
+		return
+	}
+	ctx := r.Context()
+
+	switch r.Method {
+	case http.MethodGet:
+	case http.MethodPost:
+		name := strings.TrimSpace(r.FormValue("name"))
+		if name == "" {
+			h.writeHTTPErrorWithToast(w, msg(r.Context(), "error.view_name"), http.StatusBadRequest, "error")
+			return
+		}
+		view := &core.SavedView{
+			Resource:  resource.Name,
+			Name:      name,
+			Query:     savedViewQuery(r.FormValue("query")),
+			CreatedAt: time.Now(),
+		}
+		if user, ok := auth.GetAuthUser(ctx); ok && user != nil {
+			view.CreatedBy = user.Username
+		}
+		if err := store.SaveView(ctx, view); err != nil {
+			h.writeHTTPErrorWithToast(w, msg(r.Context(), "error.view_save_failed", err), http.StatusInternalServerError, "error")
+			return
+		}
+		setToastTrigger(w, msg(r.Context(), "toast.view_saved"), "success", false)
+	case http.MethodDelete:
+		id, err := strconv.ParseInt(viewIDStr, 10, 64)
+		if err != nil {
+			h.writeHTTPError(w, msg(r.Context(), "error.invalid_view"), http.StatusBadRequest)
+			return
+		}
+		view, err := store.GetView(ctx, resource.Name, id)
+		if err != nil {
+			h.writeHTTPErrorWithToast(w, err.Error(), http.StatusNotFound, "error")
+			return
+		}
+		if !canDeleteView(ctx, *view) {
+			h.writeHTTPErrorWithToast(w, msg(r.Context(), "error.view_not_owned"), http.StatusForbidden, "error")
+			return
+		}
+		if err := store.DeleteView(ctx, resource.Name, id); err != nil {
+			h.writeHTTPErrorWithToast(w, msg(r.Context(), "error.view_delete_failed", err), http.StatusInternalServerError, "error")
+			return
+		}
+		setToastTrigger(w, msg(r.Context(), "toast.view_deleted"), "success", false)
+	default:
+		h.writeHTTPError(w, msg(r.Context(), "error.invalid_operation"), http.StatusMethodNotAllowed)
+		return
+	}
+
+	views, err := store.ListViews(ctx, resource.Name)
+	if err != nil {
+		h.writeHTTPErrorWithToast(w, msg(r.Context(), "error.views_load_failed", err), http.StatusInternalServerError, "error")
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if err := SavedViews(resource, views).Render(ctx, w); err != nil {
+		h.writeHTTPError(w, msg(r.Context(), "error.render"), http.StatusInternalServerError)
+	}
+}
+
+// clearEmptyCell empties a text field submitted empty from its cell editor
+// applyFormFields keeps the current value of empty inputs, but the cell editor posts only the edited field
+func clearEmptyCell(r *http.Request, item any, field core.FieldInfo) {
+	val := reflect.ValueOf(item)
+	if field.Type != "string" || r.FormValue(field.Name) != "" || val.Kind() != reflect.Ptr || val.IsNil() {
+		return
+	}
+	if fieldVal := val.Elem().FieldByName(field.Name); fieldVal.CanSet() && fieldVal.Kind() == reflect.String {
+		fieldVal.SetString("")
+	}
+}
+
+// handleCellEdit renders the in-place editor of a list cell and saves it
+// GET returns the editor, or the plain cell with display=true; PATCH saves the field and returns the updated cell or switch
+func (h *BackOfficeHandler) handleCellEdit(w http.ResponseWriter, r *http.Request, resource *core.Resource, idStr, fieldName string) {
+	ctx := r.Context()
+	adapter := h.bo.GetAdapter()
+
+	id, err := resource.ParseID(idStr)
+	if err != nil {
+		h.writeHTTPErrorWithToast(w, msg(r.Context(), "error.invalid_id"), http.StatusBadRequest, "error")
+		return
+	}
+	if err := core.CheckScope(ctx, adapter, resource, id); err != nil {
+		h.writeHTTPErrorWithToast(w, msg(r.Context(), "error.not_found", resource.DisplayName), http.StatusNotFound, "error")
+		return
+	}
+	item, err := adapter.GetByID(ctx, resource, id)
+	if err != nil {
+		h.writeHTTPErrorWithToast(w, msg(r.Context(), "error.not_found", resource.DisplayName), http.StatusNotFound, "error")
+		return
+	}
+
+	// Boolean switches only save; they have no editor to open
+	field, ok := resource.GetField(fieldName)
+	toggle := ok && r.Method == http.MethodPatch && isCellToggleable(ctx, resource, item, *field)
+	if !ok || !(toggle || isCellEditable(ctx, resource, item, *field)) {
+		h.writeHTTPErrorWithToast(w, msg(r.Context(), "error.not_inline_editable", resource.FieldDisplayName(fieldName)), http.StatusForbidden, "error")
+		return
+	}
+
+	var component templ.Component
+	switch r.Method {
+	case http.MethodGet:
+		component = CellEditor(resource, item, *field)
+		if r.URL.Query().Get("display") == "true" {
+			component = EditableCell(resource, item, *field)
+		}
+	case http.MethodPatch:
+		if err := r.ParseForm(); err != nil {
+			h.writeHTTPErrorWithToast(w, msg(r.Context(), "error.invalid_form"), http.StatusBadRequest, "error")
+			return
+		}
+		if err := core.CheckLockVersion(resource, item, r.FormValue("version")); err != nil {
+			h.writeHTTPErrorWithToast(w, msg(r.Context(), "error.conflict", resource.DisplayName), http.StatusConflict, "error")
+			return
+		}
+		clearEmptyCell(r, item, *field)
+		if err := h.applyFormFields(r, item, []core.FieldInfo{*field}); err != nil {
+			h.writeHTTPErrorWithToast(w, msg(r.Context(), "error.invalid_data", err), http.StatusBadRequest, "error")
+			return
+		}
+		if err := adapter.ValidateData(resource, item); err != nil {
+			h.writeHTTPErrorWithToast(w, msg(r.Context(), "validation.failed", err), http.StatusBadRequest, "error")
+			return
+		}
+		if err := resource.Validate(ctx, item); err != nil {
+			h.writeValidationError(w, r, err)
+			return
+		}
+		if err := core.SaveRecordVersion(ctx, adapter, resource, id); err != nil {
+			h.writeHTTPErrorWithToast(w, msg(r.Context(), "error.history_failed", err), http.StatusInternalServerError, "error")
+			return
+		}
+		core.BumpLockVersion(resource, item)
+		// The edited value may be zero, e.g. a switch turned off or a cleared text, so it's written explicitly
+		fields := []string{field.Name}
+		if lockField := resource.LockFieldName(); lockField != "" {
+			fields = append(fields, lockField)
+		}
+		if err := core.UpdateFields(ctx, adapter, resource, id, item, fields...); err != nil {
+			h.writeHTTPErrorWithToast(w, msg(r.Context(), "error.update_failed", err), http.StatusInternalServerError, "error")
+			return
+		}
+		setToastTrigger(w, msg(ctx, "toast.field_updated", field.DisplayName), "success", false)
+		component = EditableCell(resource, item, *field)
+		if toggle {
+			component = BooleanToggle(resource, item, *field)
+		}
+	default:
+		h.writeHTTPError(w, msg(r.Context(), "error.invalid_operation"), http.StatusMethodNotAllowed)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if err := component.Render(ctx, w); err != nil {
+		h.writeHTTPError(w, msg(r.Context(), "error.render"), http.StatusInternalServerError)
+	}
+}
+
+// handleInlineChildren lists, adds, updates and deletes child records edited inline on a
+// parent's detail page, responding with the refreshed child table
+func (h *BackOfficeHandler) handleInlineChildren(w http.ResponseWriter, r *http.Request, resource *core.Resource, idStr, childName, childIDStr string) {
+	inline, exists := resource.GetInline(childName)
+	if !exists {
+		h.writeHTTPErrorWithToast(w, msg(r.Context(), "error.no_inline", resource.DisplayName, childName), http.StatusNotFound, "error")
+		return
+	}
+	child, exists := h.bo.GetResource(childName)
+	if !exists {
+		h.writeHTTPErrorWithToast(w, msg(r.Context(), "error.resource_not_found", childName), http.StatusNotFound, "error")
+		return
+	}
+
+	id, err := resource.ParseID(idStr)
+	if err != nil {
+		h.writeHTTPErrorWithToast(w, msg(r.Context(), "error.invalid_id"), http.StatusBadRequest, "error")
+		return
+	}
+	parentID := id
+
+	ctx := r.Context()
+	adapter := h.bo.GetAdapter()
+	if err := core.CheckScope(ctx, adapter, resource, parentID); err != nil {
+		h.writeHTTPErrorWithToast(w, msg(r.Context(), "error.not_found", resource.DisplayName), http.StatusNotFound, "error")
+		return
+	}
+
+	op := core.OperationList
+	switch {
+	case r.Method == http.MethodPost && childIDStr == "":
+		op = core.OperationCreate
+	case r.Method == http.MethodPost:
+		op = core.OperationUpdate
+	case r.Method == http.MethodDelete && childIDStr != "":
+		op = core.OperationDelete
+	case r.Method != http.MethodGet || childIDStr != "":
+		h.writeHTTPError(w, msg(r.Context(), "error.invalid_operation"), http.StatusMethodNotAllowed)
+		return
+	}
+	if !child.Can(ctx, op) {
+		h.writeHTTPErrorWithToast(w, msg(r.Context(), "error.forbidden", op, child.PluralName), http.StatusForbidden, "error")
+		return
+	}
+	if op != core.OperationList && child.ReadOnly {
+		h.writeHTTPErrorWithToast(w, msg(r.Context(), "error.read_only"), http.StatusForbidden, "error")
+		return
+	}
+	var childID any
+	if childIDStr != "" {
+		childID, _ = child.ParseID(childIDStr)
+	}
+	if err := child.Authorize(ctx, op, childID); err != nil {
+		h.writeHTTPErrorWithToast(w, err.Error(), http.StatusForbidden, "error")
+		return
+	}
+
+	fields := core.InlineFields(child, inline)
+	message := ""
+	switch op {
+	case core.OperationCreate:
+		if err := r.ParseForm(); err != nil {
+			h.writeHTTPErrorWithToast(w, msg(r.Context(), "error.invalid_form"), http.StatusBadRequest, "error")
+			return
+		}
+		item := newInstance(child.ModelType)
+		if err := h.applyFormFields(r, item, fields); err != nil {
+			h.writeHTTPErrorWithToast(w, msg(r.Context(), "error.invalid_data", err), http.StatusBadRequest, "error")
+			return
+		}
+		if err := core.SetParentKey(item, inline, parentID); err != nil {
+			h.writeHTTPErrorWithToast(w, msg(r.Context(), "error.invalid_data", err), http.StatusBadRequest, "error")
+			return
+		}
+		if err := core.SetOwner(ctx, child, item); err != nil {
+			h.writeHTTPErrorWithToast(w, msg(r.Context(), "error.owner_failed", err), http.StatusInternalServerError, "error")
+			return
+		}
+		if err := core.GenerateSlugs(ctx, adapter, child, item); err != nil {
+			h.writeHTTPErrorWithToast(w, msg(r.Context(), "error.slug_failed", err), http.StatusInternalServerError, "error")
+			return
+		}
+		if err := adapter.ValidateData(child, item); err != nil {
+			h.writeHTTPErrorWithToast(w, msg(r.Context(), "validation.failed", err), http.StatusBadRequest, "error")
+			return
+		}
+		if err := child.Validate(ctx, item); err != nil {
+			h.writeValidationError(w, r, err)
+			return
+		}
+		if err := adapter.Create(ctx, child, item); err != nil {
+			h.writeHTTPErrorWithToast(w, msg(r.Context(), "error.create_failed", err), http.StatusInternalServerError, "error")
+			return
+		}
+		message = msg(ctx, "toast.added", child.DisplayName)
+	case core.OperationUpdate, core.OperationDelete:
+		childID, err := child.ParseID(childIDStr)
+		if err != nil {
+			h.writeHTTPErrorWithToast(w, msg(r.Context(), "error.invalid_id"), http.StatusBadRequest, "error")
+			return
+		}
+		item, err := core.GetInlineChild(ctx, adapter, child, inline, parentID, childID)
+		if err != nil {
+			h.writeHTTPErrorWithToast(w, msg(r.Context(), "error.not_found", child.DisplayName), http.StatusNotFound, "error")
+			return
+		}
+		if !child.CanRecord(ctx, op, item) {
+			h.writeHTTPErrorWithToast(w, msg(r.Context(), "error.owner_only", op, child.PluralName), http.StatusForbidden, "error")
+			return
+		}
+
+		if op == core.OperationDelete {
+			if child.DeleteStepUp(false) != auth.StepUpNone {
+				h.writeHTTPErrorWithToast(w, msg(r.Context(), "step_up.required"), http.StatusForbidden, "error")
+				return
+			}
+			if err := core.DeleteRecord(ctx, adapter, child, childID, false); err != nil {
+				h.writeHTTPErrorWithToast(w, msg(r.Context(), "error.item_delete_failed", err), http.StatusInternalServerError, "error")
+				return
+			}
+			message = msg(ctx, "toast.deleted", child.DisplayName)
+			break
+		}
+
+		if err := r.ParseForm(); err != nil {
+			h.writeHTTPErrorWithToast(w, msg(r.Context(), "error.invalid_form"), http.StatusBadRequest, "error")
+			return
+		}
+		if err := h.applyFormFields(r, item, fields); err != nil {
+			h.writeHTTPErrorWithToast(w, msg(r.Context(), "error.invalid_data", err), http.StatusBadRequest, "error")
+			return
+		}
+		if err := adapter.ValidateData(child, item); err != nil {
+			h.writeHTTPErrorWithToast(w, msg(r.Context(), "validation.failed", err), http.StatusBadRequest, "error")
+			return
+		}
+		if err := child.Validate(ctx, item); err != nil {
+			h.writeValidationError(w, r, err)
+			return
+		}
+		if err := core.SaveRecordVersion(ctx, adapter, child, childID); err != nil {
+			h.writeHTTPErrorWithToast(w, msg(r.Context(), "error.history_failed", err), http.StatusInternalServerError, "error")
+			return
+		}
+		if err := adapter.Update(ctx, child, childID, item); err != nil {
+			h.writeHTTPErrorWithToast(w, msg(r.Context(), "error.update_failed", err), http.StatusInternalServerError, "error")
+			return
+		}
+		message = msg(ctx, "toast.field_updated", child.DisplayName)
+	}
+
+	items, err := core.FindInlineChildren(ctx, adapter, child, inline, parentID)
+	if err != nil {
+		h.writeHTTPErrorWithToast(w, msg(r.Context(), "error.load_failed", child.PluralName, err), http.StatusInternalServerError, "error")
+		return
+	}
+
+	if message != "" {
+		setToastTrigger(w, message, "success", false)
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	tableComponent := InlineChildrenTable(resource, idStr, child, inline, fields, items, r.URL.Query().Get("edit"))
+	if err := tableComponent.Render(ctx, w); err != nil {
+		h.writeHTTPError(w, msg(r.Context(), "error.render"), http.StatusInternalServerError)
+	}
+}
+
+// applyFormFields copies the posted values of the given fields onto item
+// Unlike formToStruct, unchecked checkboxes clear boolean fields so existing records can be edited
+func (h *BackOfficeHandler) applyFormFields(r *http.Request, item interface{}, fields []core.FieldInfo) error {
+	if err := checkRequiredFields(r, fields); err != nil {
+		return err
+	}
+
+	val := reflect.ValueOf(item)
+	if val.Kind() != reflect.Ptr || val.IsNil() {
+		return fmt.Errorf("expected a pointer to a struct, got %T", item)
+	}
+	val = val.Elem()
+	for _, field := range fields {
+		if isFormEditable(field) && field.Relationship.IsPicker() {
+			if err := h.setForeignKey(r, val, field); err != nil {
+				return fmt.Errorf("error setting field %s: %v", field.Name, err)
+			}
+			continue
+		}
+		fieldVal := val.FieldByName(field.Name)
+		if !isFormEditable(field) || !fieldVal.IsValid() || !fieldVal.CanSet() {
+			continue
+		}
+		if field.Type == "bool" {
+			fieldVal.SetBool(r.FormValue(field.Name) == "true" || r.FormValue(field.Name) == "on")
+			continue
+		}
+		if err := h.setFieldValue(fieldVal, formFieldValue(r, field), field); err != nil {
+			return fmt.Errorf("error setting field %s: %v", field.Name, err)
+		}
 	}
+	return nil
 }
 
 // Helper function to get the best display field name for a resource
@@ -767,6 +2391,12 @@ func (h *BackOfficeHandler) loginHandler(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	// The authenticating proxy signs users in
+	if authConfig.Proxy.Enabled() {
+		http.Redirect(w, r, authConfig.LoginRedirect, http.StatusSeeOther)
+		return
+	}
+
 	if r.Method == http.MethodGet {
 		// Show login form
 		h.renderLoginForm(w, r)
@@ -778,7 +2408,7 @@ func (h *BackOfficeHandler) loginHandler(w http.ResponseWriter, r *http.Request)
 		fmt.Printf("🔐 DEBUG: Processing login form submission\n")
 		if err := r.ParseForm(); err != nil {
 			fmt.Printf("❌ DEBUG: Failed to parse form: %v\n", err)
-			h.writeHTTPError(w, "Invalid form data", http.StatusBadRequest)
+			h.writeHTTPError(w, msg(r.Context(), "error.invalid_form"), http.StatusBadRequest)
 			return
 		}
 
@@ -787,7 +2417,7 @@ func (h *BackOfficeHandler) loginHandler(w http.ResponseWriter, r *http.Request)
 		fmt.Printf("🔐 DEBUG: Login attempt - Username: '%s', Password length: %d\n", username, len(password))
 
 		// Authenticate user
-		user, err := authConfig.Authenticator(r.Context(), username, password)
+		user, err := authConfig.Authenticate(r.Context(), username, password)
 		if err != nil {
 			fmt.Printf("❌ DEBUG: Authentication failed: %v\n", err)
 			// Login failed - show form with error
@@ -801,16 +2431,24 @@ func (h *BackOfficeHandler) loginHandler(w http.ResponseWriter, r *http.Request)
 		sessionID, err := authConfig.SessionStore.CreateSession(r.Context(), user)
 		if err != nil {
 			fmt.Printf("❌ DEBUG: Failed to create session: %v\n", err)
-			h.writeHTTPError(w, "Failed to create session", http.StatusInternalServerError)
+			h.writeHTTPError(w, msg(r.Context(), "error.session_failed"), http.StatusInternalServerError)
 			return
 		}
 		fmt.Printf("✅ DEBUG: Session created with ID: %s\n", sessionID)
 
 		// Set session cookie
-		cookie := auth.CreateSessionCookie(sessionID)
+		cookie := authConfig.SessionCookie(r, sessionID)
 		fmt.Printf("🔐 DEBUG: Setting session cookie: %s\n", cookie.String())
 		http.SetCookie(w, cookie)
 
+		// Keep the browser signed in past the session when asked to
+		if r.FormValue("remember") != "" {
+			if err := authConfig.Remember(w, r, user, sessionID); err != nil {
+				h.writeHTTPError(w, msg(r.Context(), "error.remember_failed"), http.StatusInternalServerError)
+				return
+			}
+		}
+
 		// Redirect to original page or admin home
 		redirectURL := r.FormValue("return")
 		if redirectURL == "" {
@@ -821,29 +2459,55 @@ func (h *BackOfficeHandler) loginHandler(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	http.Error(w, msg(r.Context(), "error.method"), http.StatusMethodNotAllowed)
 }
 
 // logoutHandler handles logout requests
+// Only POST signs out, so the CSRF token protects it and a cross-site link or image can't
 func (h *BackOfficeHandler) logoutHandler(w http.ResponseWriter, r *http.Request) {
 	authConfig := h.bo.GetAuth()
 	if authConfig == nil || !authConfig.Enabled {
 		http.NotFound(w, r)
 		return
 	}
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
 
-	// Get current session to delete it
-	if cookie, err := r.Cookie("backoffice_session"); err == nil {
-		authConfig.SessionStore.DeleteSession(r.Context(), cookie.Value)
+	// Get current session to delete it; behind an authenticating proxy, LogoutRedirect signs out of the proxy
+	if sessionID, err := authConfig.SessionID(r); err == nil && authConfig.SessionStore != nil {
+		authConfig.SessionStore.DeleteSession(r.Context(), sessionID)
 	}
 
-	// Delete session cookie
-	http.SetCookie(w, auth.DeleteSessionCookie())
+	// Delete session cookie and revoke the remember-me token
+	http.SetCookie(w, authConfig.ExpiredSessionCookie(r))
+	authConfig.Forget(w, r)
 
 	// Redirect to logout page
 	http.Redirect(w, r, authConfig.LogoutRedirect, http.StatusSeeOther)
 }
 
+// sessionRefreshHandler extends the current session when the user chooses to stay signed in
+// The new remaining time is sent as a sessionRefreshed event so the expiry warning restarts its countdown
+func (h *BackOfficeHandler) sessionRefreshHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	expiry, err := auth.RefreshRequestSession(r, h.bo.GetAuth())
+	if err != nil {
+		h.writeHTTPErrorWithToast(w, msg(r.Context(), "session.refresh_failed"), http.StatusUnauthorized, "error")
+		return
+	}
+
+	trigger, _ := json.Marshal(map[string]any{
+		"sessionRefreshed": map[string]int{"remaining": int(time.Until(expiry).Seconds())},
+	})
+	w.Header().Set("HX-Trigger", string(trigger))
+	w.WriteHeader(http.StatusNoContent)
+}
+
 // renderLoginForm renders the login form
 func (h *BackOfficeHandler) renderLoginForm(w http.ResponseWriter, r *http.Request) {
 	h.renderLoginFormWithError(w, r, "")
@@ -875,6 +2539,7 @@ func (h *BackOfficeHandler) renderLoginFormWithError(w http.ResponseWriter, r *h
         <h1>Login to %s</h1>
         %s
         <form method="post">
+            <input type="hidden" name="csrf_token" value="%s">
             <input type="hidden" name="return" value="%s">
             <div class="form-group">
                 <label>Username:</label>
@@ -884,6 +2549,7 @@ func (h *BackOfficeHandler) renderLoginFormWithError(w http.ResponseWriter, r *h
                 <label>Password:</label>
                 <input type="password" name="password" required>
             </div>
+            %s
             <button type="submit">Login</button>
         </form>
     </div>
@@ -897,21 +2563,61 @@ func (h *BackOfficeHandler) renderLoginFormWithError(w http.ResponseWriter, r *h
 			}
 			return ""
 		}(),
-		returnURL)
+		html.EscapeString(csrfToken(r.Context())),
+		html.EscapeString(returnURL),
+		h.rememberMeField())
+}
+
+// rememberMeField renders the "keep me signed in" checkbox when remember-me is configured
+func (h *BackOfficeHandler) rememberMeField() string {
+	if !h.bo.GetAuth().RememberMeEnabled() {
+		return ""
+	}
+	return `<div class="form-group"><label><input type="checkbox" name="remember" value="true"> Keep me signed in</label></div>`
 }
 
 // parseQueryFromRequest parses HTTP request parameters into a Query struct
 func parseQueryFromRequest(r *http.Request, resource *core.Resource) *core.Query {
 	query := core.NewQuery()
 
-	// Parse filters (exclude UI and pagination parameters)
+	// Parse filters (exclude UI and pagination parameters, and names the resource can't be filtered by)
+	// Values of known fields are converted to the field type; "Age__gte=18" style parameters become typed conditions
 	filters := make(map[string]any)
-	for key, values := range r.URL.Query() {
-		if len(values) > 0 && !isReservedParam(key) {
-			filters[key] = values[0]
+	params := r.URL.Query()
+	for _, key := range slices.Sorted(maps.Keys(params)) {
+		values := params[key]
+		if len(values) == 0 || isReservedParam(key) {
+			continue
+		}
+		name, operator, isCondition := core.ParseFilterParam(key)
+		if !resource.IsFilterable(name) {
+			continue
+		}
+		field, known := resource.GetField(name)
+		if isCondition {
+			if values[0] == "" {
+				continue
+			}
+			value := any(values[0])
+			if known {
+				parsed, err := core.ParseFilterValue(field, operator, values[0])
+				if err != nil {
+					continue // Ignore malformed ranges rather than failing the whole list
+				}
+				value = parsed
+			}
+			query.WithCondition(name, operator, value)
+			continue
+		}
+		filters[key] = values[0]
+		if known {
+			if parsed, err := core.ParseFilterValue(field, "", values[0]); err == nil {
+				filters[key] = parsed
+			}
 		}
 	}
 	query.WithFilters(filters)
+	query.WithSearch(r.URL.Query().Get("q"))
 
 	// Parse sorting
 	if sortBy := r.URL.Query().Get("sort"); sortBy != "" {
@@ -923,7 +2629,7 @@ func parseQueryFromRequest(r *http.Request, resource *core.Resource) *core.Query
 	}
 
 	// Parse pagination
-	limit := core.DefaultPageSize
+	limit := resource.GetEffectivePageSize()
 	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
 		if parsedLimit, err := strconv.Atoi(limitStr); err == nil {
 			limit = parsedLimit
@@ -939,6 +2645,9 @@ func parseQueryFromRequest(r *http.Request, resource *core.Resource) *core.Query
 
 	query.WithPagination(limit, offset)
 
+	// Show the trash for resources that soft delete
+	query.Trashed = resource.SoftDeleteField != "" && r.URL.Query().Get("trashed") == "true"
+
 	return query
 }
 
@@ -946,7 +2655,7 @@ func parseQueryFromRequest(r *http.Request, resource *core.Resource) *core.Query
 func isReservedParam(param string) bool {
 	reserved := []string{
 		"limit", "offset", "sort", "direction",
-		"success", "resource", "page", "load_more",
+		"success", "resource", "page", "load_more", "trashed", "view", "format", "q",
 	}
 
 	for _, r := range reserved {
@@ -961,13 +2670,13 @@ func isReservedParam(param string) bool {
 func (h *BackOfficeHandler) handleCustomAction(w http.ResponseWriter, r *http.Request, resource *core.Resource, idStr string) {
 	// Parse form to get action ID
 	if err := r.ParseForm(); err != nil {
-		h.writeHTTPErrorWithToast(w, "Invalid form data", http.StatusBadRequest, "error")
+		h.writeHTTPErrorWithToast(w, msg(r.Context(), "error.invalid_form"), http.StatusBadRequest, "error")
 		return
 	}
 
 	actionID := r.FormValue("action_id")
 	if actionID == "" {
-		h.writeHTTPErrorWithToast(w, "Action ID is required", http.StatusBadRequest, "error")
+		h.writeHTTPErrorWithToast(w, msg(r.Context(), "error.action_required"), http.StatusBadRequest, "error")
 		return
 	}
 
@@ -981,28 +2690,649 @@ func (h *BackOfficeHandler) handleCustomAction(w http.ResponseWriter, r *http.Re
 	}
 
 	if action == nil {
-		h.writeHTTPErrorWithToast(w, "Action not found", http.StatusNotFound, "error")
+		h.writeHTTPErrorWithToast(w, msg(r.Context(), "error.action_not_found"), http.StatusNotFound, "error")
+		return
+	}
+	if !resource.CanRunAction(r.Context(), action.ID) {
+		h.writeHTTPErrorWithToast(w, msg(r.Context(), "error.action_forbidden", action.Title), http.StatusForbidden, "error")
 		return
 	}
 
 	// Parse ID
-	id, err := strconv.ParseUint(idStr, 10, 32)
+	id, err := resource.ParseID(idStr)
 	if err != nil {
-		h.writeHTTPErrorWithToast(w, "Invalid ID format", http.StatusBadRequest, "error")
+		h.writeHTTPErrorWithToast(w, msg(r.Context(), "error.invalid_id"), http.StatusBadRequest, "error")
 		return
 	}
 
+	// Actions may only run on records within the user's scope
+	if err := core.CheckScope(r.Context(), h.bo.GetAdapter(), resource, id); err != nil {
+		h.writeHTTPErrorWithToast(w, msg(r.Context(), "error.not_found", resource.DisplayName), http.StatusNotFound, "error")
+		return
+	}
+
+	// Actions confirmed through a form are validated before they run
+	var params core.ActionParams
+	if action.NeedsForm() {
+		formErrs := make(core.ActionParamErrors)
+		if action.HasParams() {
+			parsed, err := core.ParseActionParams(action.Params, r.FormValue)
+			if paramErrs, ok := err.(core.ActionParamErrors); ok {
+				formErrs = paramErrs
+			}
+			params = parsed
+		}
+		if action.IsDangerous() && strings.TrimSpace(r.FormValue(actionConfirmField)) != action.Title {
+			formErrs[actionConfirmField] = fmt.Sprintf("Type %q to confirm", action.Title)
+		}
+		if err := h.verifyStepUp(r, action.StepUp); err != nil {
+			formErrs[stepUpField] = stepUpError(r.Context(), err)
+		}
+
+		if len(formErrs) > 0 {
+			// Re-render the form with validation messages
+			w.Header().Set("Content-Type", "text/html; charset=utf-8")
+			if err := ActionFormModal(resource, action, idStr, r.Form, formErrs).Render(r.Context(), w); err != nil {
+				h.writeHTTPError(w, msg(r.Context(), "error.render"), http.StatusInternalServerError)
+			}
+			return
+		}
+	}
+
 	// Execute the action
-	if err := action.Handler(r.Context(), uint(id)); err != nil {
-		h.writeHTTPErrorWithToast(w, fmt.Sprintf("Action failed: %v", err), http.StatusInternalServerError, "error")
+	if action.ParamHandler != nil {
+		err = action.ParamHandler(r.Context(), id, params)
+	} else {
+		err = action.Handler(r.Context(), id)
+	}
+	if err != nil {
+		h.writeHTTPErrorWithToast(w, msg(r.Context(), "error.action_failed", err), http.StatusInternalServerError, "error")
 		return
 	}
 
 	// Success - send toast notification
-	w.Header().Set("HX-Trigger", fmt.Sprintf(`{"showToast": {"message": "%s completed successfully", "type": "success"}}`, action.Title))
+	setToastTrigger(w, msg(r.Context(), "toast.action_completed", action.Title), "success", false)
+	w.WriteHeader(http.StatusOK)
+}
+
+// actionConfirmField is the form field holding the typed confirmation of dangerous actions
+const actionConfirmField = "confirm_text"
+
+// renderActionForm renders the modal form collecting an action's parameters
+func (h *BackOfficeHandler) renderActionForm(w http.ResponseWriter, r *http.Request, resource *core.Resource, idStr string) {
+	actionID := r.URL.Query().Get("action_id")
+
+	var action *core.CustomAction
+	for i := range resource.Actions {
+		if resource.Actions[i].ID == actionID {
+			action = &resource.Actions[i]
+			break
+		}
+	}
+
+	if action == nil || !action.NeedsForm() {
+		h.writeHTTPErrorWithToast(w, msg(r.Context(), "error.action_not_found"), http.StatusNotFound, "error")
+		return
+	}
+	if !resource.CanRunAction(r.Context(), action.ID) {
+		h.writeHTTPErrorWithToast(w, msg(r.Context(), "error.action_forbidden", action.Title), http.StatusForbidden, "error")
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if err := ActionFormModal(resource, action, idStr, nil, nil).Render(r.Context(), w); err != nil {
+		h.writeHTTPError(w, msg(r.Context(), "error.render"), http.StatusInternalServerError)
+	}
+}
+
+// handleBulkAction runs a custom action, or delete, over a selection of records
+func (h *BackOfficeHandler) handleBulkAction(w http.ResponseWriter, r *http.Request, resource *core.Resource) {
+	if err := r.ParseForm(); err != nil {
+		h.writeHTTPErrorWithToast(w, msg(r.Context(), "error.invalid_form"), http.StatusBadRequest, "error")
+		return
+	}
+
+	adapter := h.bo.GetAdapter()
+	actionID := r.FormValue("action_id")
+
+	var title string
+	var run func(ctx context.Context, id any) error
+	op := core.OperationUpdate
+	if actionID == core.BulkDeleteActionID {
+		op = core.OperationDelete
+		if resource.ReadOnly {
+			h.writeHTTPErrorWithToast(w, msg(r.Context(), "error.delete_read_only"), http.StatusForbidden, "error")
+			return
+		}
+		if err := h.verifyStepUp(r, resource.StepUp.BulkDelete); err != nil {
+			h.writeHTTPErrorWithToast(w, stepUpError(r.Context(), err), http.StatusForbidden, "error")
+			return
+		}
+		title = msg(r.Context(), "action.delete")
+		run = func(ctx context.Context, id any) error {
+			return core.DeleteRecord(ctx, adapter, resource, id, false)
+		}
+	} else {
+		for i := range resource.Actions {
+			// Actions confirmed through a form need it per record, so they can't run in bulk
+			if resource.Actions[i].ID == actionID && !resource.Actions[i].NeedsForm() && resource.CanRunAction(r.Context(), actionID) {
+				title = resource.Actions[i].Title
+				run = resource.Actions[i].Handler
+				break
+			}
+		}
+	}
+	if run == nil {
+		h.writeHTTPErrorWithToast(w, msg(r.Context(), "error.action_not_found"), http.StatusNotFound, "error")
+		return
+	}
+
+	result := h.runBulkSelection(w, r, resource, op, run)
+	if result == nil {
+		return
+	}
+
+	if result.Failed() == 0 {
+		setToastTrigger(w, msg(r.Context(), "toast.bulk_completed", title, result.Succeeded, resource.PluralName), "success", true)
+	} else {
+		setToastTrigger(w, msg(r.Context(), "toast.bulk_failed", title, result.Failed(), result.Total, resource.PluralName), "error", false)
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if err := BulkActionResult(title, result).Render(r.Context(), w); err != nil {
+		h.writeHTTPError(w, msg(r.Context(), "error.render"), http.StatusInternalServerError)
+	}
+}
+
+// handleBulkDelete deletes the selected records and summarizes the outcome in a modal
+// Records that can't be deleted, e.g. because other records still reference them, are listed with the reason
+func (h *BackOfficeHandler) handleBulkDelete(w http.ResponseWriter, r *http.Request, resource *core.Resource) {
+	if resource.ReadOnly {
+		h.writeHTTPErrorWithToast(w, msg(r.Context(), "error.delete_read_only"), http.StatusForbidden, "error")
+		return
+	}
+	if err := r.ParseForm(); err != nil {
+		h.writeHTTPErrorWithToast(w, msg(r.Context(), "error.invalid_form"), http.StatusBadRequest, "error")
+		return
+	}
+	if err := h.verifyStepUp(r, resource.StepUp.BulkDelete); err != nil {
+		h.renderStepUpModal(w, r, bulkDeletePrompt(r.Context(), resource), err)
+		return
+	}
+
+	adapter := h.bo.GetAdapter()
+	result := h.runBulkSelection(w, r, resource, core.OperationDelete, func(ctx context.Context, id any) error {
+		err := core.DeleteRecord(ctx, adapter, resource, id, false)
+		if errors.Is(err, core.ErrRecordInUse) {
+			return errors.New(msg(ctx, "error.record_in_use"))
+		}
+		return err
+	})
+	if result == nil {
+		return
+	}
+
+	if result.Failed() == 0 {
+		setToastTrigger(w, msg(r.Context(), "toast.bulk_deleted", result.Succeeded, resource.PluralName), "success", false)
+	} else {
+		setToastTrigger(w, msg(r.Context(), "toast.bulk_delete_failed", result.Failed(), result.Total, resource.PluralName), "error", false)
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if err := BulkDeleteSummaryModal(resource, result).Render(r.Context(), w); err != nil {
+		h.writeHTTPError(w, msg(r.Context(), "error.render"), http.StatusInternalServerError)
+	}
+}
+
+// runBulkSelection runs fn on every selected record the user may perform op on
+// It writes an error response and returns nil when the selection is invalid or empty
+func (h *BackOfficeHandler) runBulkSelection(w http.ResponseWriter, r *http.Request, resource *core.Resource, op core.Operation, fn func(ctx context.Context, id any) error) *core.BulkResult {
+	ids, err := h.bulkSelection(r, resource)
+	if err != nil {
+		h.writeHTTPErrorWithToast(w, err.Error(), http.StatusBadRequest, "error")
+		return nil
+	}
+	if len(ids) == 0 {
+		h.writeHTTPErrorWithToast(w, msg(r.Context(), "error.no_selection"), http.StatusBadRequest, "error")
+		return nil
+	}
+
+	// Like the route, custom actions are authorized by their own operation
+	authorizeOp := op
+	if actionID := r.FormValue("action_id"); actionID != "" && op != core.OperationDelete {
+		authorizeOp = core.ActionOperation(actionID)
+	}
+	adapter := h.bo.GetAdapter()
+	return core.RunBulkAction(r.Context(), ids, func(ctx context.Context, id any) error {
+		if err := resource.Authorize(ctx, authorizeOp, id); err != nil {
+			return err
+		}
+		// Every record must be within the user's scope, even when IDs are posted directly
+		if err := core.CheckScope(ctx, adapter, resource, id); err != nil {
+			return err
+		}
+		if err := core.CheckOwner(ctx, adapter, resource, id, op); err != nil {
+			return err
+		}
+		return fn(ctx, id)
+	})
+}
+
+// listQueryFromForm rebuilds the scoped list query from the raw list query string posted in the "query" field
+func listQueryFromForm(r *http.Request, resource *core.Resource) *core.Query {
+	listURL := *r.URL
+	listURL.RawQuery = r.FormValue("query")
+	listRequest := r.WithContext(r.Context())
+	listRequest.URL = &listURL
+
+	return resource.ApplyScope(r.Context(), parseQueryFromRequest(listRequest, resource))
+}
+
+// handleExport streams the records matching the list filters as a CSV or JSON download
+func (h *BackOfficeHandler) handleExport(w http.ResponseWriter, r *http.Request, resource *core.Resource) {
+	format := core.ExportFormat(r.URL.Query().Get("format"))
+	if format == "" {
+		format = core.ExportCSV
+	}
+	if !resource.CanExport(format) {
+		h.writeHTTPError(w, msg(r.Context(), "error.export_format", resource.PluralName, format), http.StatusNotFound)
+		return
+	}
+
+	query := resource.ApplyScope(r.Context(), parseQueryFromRequest(r, resource))
+	items, err := core.FindExportRecords(r.Context(), h.bo.GetAdapter(), resource, query)
+	if err != nil {
+		h.writeHTTPError(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	var buf bytes.Buffer
+	if err := core.WriteExport(r.Context(), &buf, resource, items, format); err != nil {
+		h.writeHTTPError(w, msg(r.Context(), "error.export_failed", err), http.StatusInternalServerError)
+		return
+	}
+
+	contentType := "text/csv; charset=utf-8"
+	switch format {
+	case core.ExportJSON:
+		contentType = "application/json"
+	case core.ExportXLSX:
+		contentType = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	}
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", resource.ExportFilename(format)))
+	w.Write(buf.Bytes())
+}
+
+// handleCSVExport streams every record matching the list filters and sort as a CSV download
+// Rows are written as they are read, so exports aren't limited to the visible page or MaxExportRecords
+func (h *BackOfficeHandler) handleCSVExport(w http.ResponseWriter, r *http.Request, resource *core.Resource) {
+	if !resource.CanExport(core.ExportCSV) {
+		h.writeHTTPError(w, msg(r.Context(), "error.export_format", resource.PluralName, core.ExportCSV), http.StatusNotFound)
+		return
+	}
+	if _, err := resource.ExportFields(); err != nil {
+		h.writeHTTPError(w, msg(r.Context(), "error.export_failed", err), http.StatusInternalServerError)
+		return
+	}
+
+	query := resource.ApplyScope(r.Context(), parseQueryFromRequest(r, resource))
+	w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", resource.ExportFilename(core.ExportCSV)))
+	if err := core.StreamCSV(r.Context(), w, h.bo.GetAdapter(), resource, query); err != nil {
+		// The download has started, so abort the connection rather than leave a truncated file looking complete
+		panic(http.ErrAbortHandler)
+	}
+}
+
+// handleImport serves the CSV import wizard: upload, map columns, preview, then commit
+// The uploaded CSV and the mapping travel with each step's form, so no state is kept on the server
+func (h *BackOfficeHandler) handleImport(w http.ResponseWriter, r *http.Request, resource *core.Resource) {
+	if r.Method != http.MethodPost {
+		h.renderImportStep(w, r, resource, ImportUpload(resource, ""))
+		return
+	}
+
+	step := r.FormValue("step")
+	if step == "upload" {
+		h.handleImportUpload(w, r, resource)
+		return
+	}
+
+	file, err := core.ReadImportCSV(strings.NewReader(r.FormValue("csv")))
+	if err != nil {
+		h.renderImportStep(w, r, resource, ImportUpload(resource, err.Error()))
+		return
+	}
+	state := importState{CSV: r.FormValue("csv"), File: file, Mapping: importMapping(r, resource, file)}
+	fields, _ := resource.ImportFields()
+
+	switch step {
+	case "map":
+		h.renderImportStep(w, r, resource, ImportMapping(resource, state, fields, ""))
+	case "preview":
+		if problem := importMappingProblem(resource, state.Mapping); problem != "" {
+			h.renderImportStep(w, r, resource, ImportMapping(resource, state, fields, problem))
+			return
+		}
+		h.renderImportStep(w, r, resource, ImportPreview(resource, state, h.runImport(r, resource, state, false)))
+	case "commit":
+		if problem := importMappingProblem(resource, state.Mapping); problem != "" {
+			h.renderImportStep(w, r, resource, ImportMapping(resource, state, fields, problem))
+			return
+		}
+		h.renderImportStep(w, r, resource, ImportResult(resource, state, h.runImport(r, resource, state, true)))
+	case "errors":
+		var errs []core.ImportRowError
+		if err := json.Unmarshal([]byte(r.FormValue("errors")), &errs); err != nil {
+			h.writeHTTPError(w, msg(r.Context(), "error.invalid_report"), http.StatusBadRequest)
+			return
+		}
+		for _, rowErr := range errs {
+			if rowErr.Row < 2 || rowErr.Row-2 >= len(file.Rows) {
+				h.writeHTTPError(w, msg(r.Context(), "error.invalid_report"), http.StatusBadRequest)
+				return
+			}
+		}
+		w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", resource.PluralName+"-import-errors.csv"))
+		core.WriteImportErrors(w, file, errs)
+	default:
+		h.writeHTTPError(w, msg(r.Context(), "error.import_step"), http.StatusBadRequest)
+	}
+}
+
+// handleImportUpload reads the uploaded CSV and moves on to mapping its columns
+func (h *BackOfficeHandler) handleImportUpload(w http.ResponseWriter, r *http.Request, resource *core.Resource) {
+	upload, _, err := r.FormFile("file")
+	if err != nil {
+		h.renderImportStep(w, r, resource, ImportUpload(resource, "Choose a CSV file to import"))
+		return
+	}
+	defer upload.Close()
+
+	content, err := io.ReadAll(upload)
+	if err != nil {
+		h.renderImportStep(w, r, resource, ImportUpload(resource, fmt.Sprintf("Failed to read the file: %v", err)))
+		return
+	}
+	file, err := core.ReadImportCSV(bytes.NewReader(content))
+	if err != nil {
+		h.renderImportStep(w, r, resource, ImportUpload(resource, err.Error()))
+		return
+	}
+
+	fields, _ := resource.ImportFields()
+	state := importState{CSV: string(content), File: file, Mapping: resource.SuggestImportMapping(file.Headers)}
+	h.renderImportStep(w, r, resource, ImportMapping(resource, state, fields, ""))
+}
+
+// renderImportStep renders a step of the import wizard in the page layout
+func (h *BackOfficeHandler) renderImportStep(w http.ResponseWriter, r *http.Request, resource *core.Resource, step templ.Component) {
+	layoutComponent := h.pageLayout(r, "Import "+resource.PluralName, step, resource.Name)
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if err := layoutComponent.Render(r.Context(), w); err != nil {
+		h.writeHTTPError(w, msg(r.Context(), "error.render"), http.StatusInternalServerError)
+	}
+}
+
+// importMapping reads the posted field of each column, dropping fields that can't be imported
+func importMapping(r *http.Request, resource *core.Resource, file *core.ImportFile) []string {
+	fields, _ := resource.ImportFields()
+	allowed := make(map[string]bool, len(fields))
+	for _, field := range fields {
+		allowed[field.Name] = true
+	}
+
+	posted := r.Form["map"]
+	mapping := make([]string, len(file.Headers))
+	for i := range mapping {
+		if i < len(posted) && allowed[posted[i]] {
+			mapping[i] = posted[i]
+		}
+	}
+	return mapping
+}
+
+// importMappingProblem reports a mapping that can't be imported: no columns, or a field mapped twice
+func importMappingProblem(resource *core.Resource, mapping []string) string {
+	seen := make(map[string]bool)
+	for _, name := range mapping {
+		if name == "" {
+			continue
+		}
+		if seen[name] {
+			field, _ := resource.GetField(name)
+			return fmt.Sprintf("%s is mapped to more than one column", field.DisplayName)
+		}
+		seen[name] = true
+	}
+	if len(seen) == 0 {
+		return "Map at least one column to a field"
+	}
+	return ""
+}
+
+// runImport checks every row of an import, saving the valid ones when commit is set
+// Rows go through the same conversion and validation as the create and edit forms
+func (h *BackOfficeHandler) runImport(r *http.Request, resource *core.Resource, state importState, commit bool) importOutcome {
+	var outcome importOutcome
+	for i, row := range state.File.Rows {
+		created, err := h.importRow(r, resource, state.Mapping, row, commit)
+		switch {
+		case err != nil:
+			outcome.Errors = append(outcome.Errors, core.ImportRowError{Row: i + 2, Message: err.Error()})
+		case created:
+			outcome.Created++
+		default:
+			outcome.Updated++
+		}
+	}
+	return outcome
+}
+
+// importRow creates a record from a row, or updates the record matching its key field
+func (h *BackOfficeHandler) importRow(r *http.Request, resource *core.Resource, mapping []string, row []string, commit bool) (created bool, err error) {
+	ctx := r.Context()
+	adapter := h.bo.GetAdapter()
+
+	// Each row is converted like a submitted form holding the mapped columns
+	rowRequest, _ := http.NewRequestWithContext(ctx, http.MethodGet, importURL(resource), nil)
+	rowRequest.Form = url.Values{}
+	var fields []core.FieldInfo
+	keyValue := ""
+	for i, name := range mapping {
+		field, ok := resource.GetField(name)
+		if name == "" || !ok {
+			continue
+		}
+		value, err := importFormValue(resource, *field, row[i])
+		if err != nil {
+			return false, err
+		}
+		rowRequest.Form.Set(formInputName(*field), value)
+		fields = append(fields, *field)
+		if resource.Import.KeyField == name {
+			keyValue = value
+		}
+	}
+
+	if keyValue != "" {
+		existing, err := core.FindImportMatch(ctx, adapter, resource, keyValue)
+		if err == nil {
+			return false, h.importUpdate(rowRequest, resource, existing, fields, commit)
+		}
+		if !errors.Is(err, core.ErrImportNoMatch) {
+			return false, err
+		}
+	}
+
+	item, err := h.formToStruct(rowRequest, resource)
+	if err != nil {
+		return false, err
+	}
+	if err := core.SetOwner(ctx, resource, item); err != nil {
+		return false, err
+	}
+	if err := core.GenerateSlugs(ctx, adapter, resource, item); err != nil {
+		return false, err
+	}
+	if err := adapter.ValidateData(resource, item); err != nil {
+		return false, err
+	}
+	if err := resource.Validate(ctx, item); err != nil {
+		return false, err
+	}
+	if commit {
+		if err := adapter.Create(ctx, resource, item); err != nil {
+			return false, fmt.Errorf("failed to create: %v", err)
+		}
+	}
+	return true, nil
+}
+
+// importFormValue checks a CSV cell against the type of the field it is imported into
+// Forms rely on typed inputs and skip unparsable numbers, so imports reject them here instead
+func importFormValue(resource *core.Resource, field core.FieldInfo, raw string) (string, error) {
+	value := strings.TrimSpace(raw)
+	structField, ok := resource.ModelType.Elem().FieldByName(formInputName(field))
+	if !ok || value == "" {
+		return value, nil
+	}
+	kind := structField.Type.Kind()
+	if kind == reflect.Ptr {
+		kind = structField.Type.Elem().Kind()
+	}
+
+	var err error
+	switch kind {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		_, err = strconv.ParseInt(value, 10, 64)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		_, err = strconv.ParseUint(value, 10, 64)
+	case reflect.Float32, reflect.Float64:
+		_, err = strconv.ParseFloat(value, 64)
+	case reflect.Bool:
+		switch strings.ToLower(value) {
+		case "true", "yes", "y", "1", "on":
+			return "true", nil
+		case "false", "no", "n", "0", "off":
+			return "false", nil
+		}
+		return "", fmt.Errorf("%s: %q is not yes or no", field.DisplayName, value)
+	}
+	if err != nil {
+		return "", fmt.Errorf("%s: %q is not a valid number", field.DisplayName, value)
+	}
+	return value, nil
+}
+
+// importUpdate applies the mapped columns of a row to the record it matched
+func (h *BackOfficeHandler) importUpdate(rowRequest *http.Request, resource *core.Resource, item any, fields []core.FieldInfo, commit bool) error {
+	ctx := rowRequest.Context()
+	adapter := h.bo.GetAdapter()
+	if !resource.CanRecord(ctx, core.OperationUpdate, item) {
+		return errors.New(msg(ctx, "import.update_forbidden", resource.DisplayName))
+	}
+
+	id := core.GetFieldValue(item, resource.IDField)
+	if err := resource.Authorize(ctx, core.OperationUpdate, id); err != nil {
+		return err
+	}
+	if err := h.applyFormFields(rowRequest, item, fields); err != nil {
+		return err
+	}
+	if err := adapter.ValidateData(resource, item); err != nil {
+		return err
+	}
+	if err := core.ValidateUpdate(ctx, adapter, resource, id, item); err != nil {
+		return err
+	}
+	if commit {
+		// Keep the stored state for resources with version history
+		if err := core.SaveRecordVersion(ctx, adapter, resource, id); err != nil {
+			return fmt.Errorf("failed to record history: %v", err)
+		}
+		core.BumpLockVersion(resource, item)
+		if err := adapter.Update(ctx, resource, id, item); err != nil {
+			return fmt.Errorf("failed to update: %v", err)
+		}
+	}
+	return nil
+}
+
+// handleCollectionAction runs an action over the whole resource, using the current list filters
+func (h *BackOfficeHandler) handleCollectionAction(w http.ResponseWriter, r *http.Request, resource *core.Resource) {
+	if err := r.ParseForm(); err != nil {
+		h.writeHTTPErrorWithToast(w, msg(r.Context(), "error.invalid_form"), http.StatusBadRequest, "error")
+		return
+	}
+
+	action, exists := resource.GetCollectionAction(r.FormValue("action_id"))
+	if !exists {
+		h.writeHTTPErrorWithToast(w, msg(r.Context(), "error.action_not_found"), http.StatusNotFound, "error")
+		return
+	}
+	if !resource.CanRunAction(r.Context(), action.ID) {
+		h.writeHTTPErrorWithToast(w, msg(r.Context(), "error.action_forbidden", action.Title), http.StatusForbidden, "error")
+		return
+	}
+
+	query := listQueryFromForm(r, resource)
+	if err := h.authorizeMatchingRecords(r.Context(), resource, core.ActionOperation(action.ID), query); err != nil {
+		h.writeHTTPErrorWithToast(w, err.Error(), http.StatusForbidden, "error")
+		return
+	}
+
+	if err := action.Handler(r.Context(), query); err != nil {
+		h.writeHTTPErrorWithToast(w, msg(r.Context(), "error.action_failed", err), http.StatusInternalServerError, "error")
+		return
+	}
+
+	setToastTrigger(w, msg(r.Context(), "toast.action_completed", action.Title), "success", true)
 	w.WriteHeader(http.StatusOK)
 }
 
+// authorizeMatchingRecords asks the AuthConfig.Authorize hook about every record matching the query,
+// so a collection action doesn't reach records the hook denies. Without a hook nothing is loaded
+func (h *BackOfficeHandler) authorizeMatchingRecords(ctx context.Context, resource *core.Resource, op core.Operation, query *core.Query) error {
+	if !resource.AuthorizesRecords() {
+		return nil
+	}
+	ids, err := core.CollectMatchingIDs(ctx, h.bo.GetAdapter(), resource, query)
+	if err != nil {
+		return err
+	}
+	for _, id := range ids {
+		if err := resource.Authorize(ctx, op, id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// bulkSelection returns the IDs a bulk action applies to: either the posted IDs,
+// or every record matching the list filters when "select all matching" was used
+func (h *BackOfficeHandler) bulkSelection(r *http.Request, resource *core.Resource) ([]any, error) {
+	if r.FormValue("select_all") == "true" {
+		query := listQueryFromForm(r, resource)
+		return core.CollectMatchingIDs(r.Context(), h.bo.GetAdapter(), resource, query)
+	}
+
+	var ids []any
+	for _, idStr := range r.Form["ids"] {
+		id, err := resource.ParseID(idStr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid ID format: %s", idStr)
+		}
+		ids = append(ids, id)
+	}
+	if len(ids) > core.MaxBulkSelection {
+		return nil, errors.New(msg(r.Context(), "error.selection_limit", core.MaxBulkSelection))
+	}
+	return ids, nil
+}
+
 // renderLoadMoreRows renders additional rows for HTMX "Load More" functionality
 func (h *BackOfficeHandler) renderLoadMoreRows(w http.ResponseWriter, r *http.Request, resource *core.Resource, result *core.Result) {
 	w.Header().Set("Content-Type", "text/html; charset=utf-8")
@@ -1010,13 +3340,13 @@ func (h *BackOfficeHandler) renderLoadMoreRows(w http.ResponseWriter, r *http.Re
 	// Render only the additional rows
 	for _, item := range result.Items {
 		rowComponent := ListRow(resource, item)
-		if err := rowComponent.Render(context.Background(), w); err != nil {
-			h.writeHTTPError(w, "Template rendering error", http.StatusInternalServerError)
+		if err := rowComponent.Render(r.Context(), w); err != nil {
+			h.writeHTTPError(w, msg(r.Context(), "error.render"), http.StatusInternalServerError)
 			return
 		}
 	}
 
-	// If there are more results, include a new "Load More" button
+	// If there are more results, include a new "Load More" row
 	if result.HasMore {
 		nextQuery := result.Query.NextPage()
 		// Use AdminURLBuilder to construct URL, preserving all user parameters
@@ -1026,16 +3356,9 @@ func (h *BackOfficeHandler) renderLoadMoreRows(w http.ResponseWriter, r *http.Re
 			WithLoadMore().
 			String()
 
-		fmt.Fprintf(w, `
-		<tr id="load-more-row">
-			<td colspan="%d" class="px-6 py-4 text-center">
-				<button hx-get="%s" 
-				        hx-target="#load-more-row" 
-				        hx-swap="outerHTML"
-				        class="bg-blue-600 text-white px-4 py-2 rounded hover:bg-blue-700 transition-colors">
-					Load More (%d more available)
-				</button>
-			</td>
-		</tr>`, len(resource.Fields)+1, loadMoreURL, result.TotalCount-int64(result.Query.Pagination.Offset+len(result.Items)))
+		remaining := int(result.TotalCount) - (result.Query.Pagination.Offset + len(result.Items))
+		if err := LoadMoreRow(resource, remaining, loadMoreURL).Render(r.Context(), w); err != nil {
+			h.writeHTTPError(w, msg(r.Context(), "error.render"), http.StatusInternalServerError)
+		}
 	}
 }
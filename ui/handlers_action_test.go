@@ -436,3 +436,181 @@ func TestHandleCustomAction_InvalidFormData(t *testing.T) {
 		t.Logf("Got status %d, which is acceptable for invalid form data", w.Code)
 	}
 }
+
+// TestHandleCustomAction_WithParams verifies parameters are parsed, validated and passed to the handler
+func TestHandleCustomAction_WithParams(t *testing.T) {
+	type TestModel struct {
+		ID   uint   `db:"id"`
+		Name string `db:"name"`
+	}
+
+	bo := core.New(&mockActionAdapter{}, auth.AuthConfig{})
+
+	var received core.ActionParams
+	bo.RegisterResource(&TestModel{}).
+		WithParamAction("refund", "Refund", func(ctx context.Context, id any, params core.ActionParams) error {
+			received = params
+			return nil
+		}, core.ActionParam{Name: "amount", Label: "Amount", Type: core.ParamNumber, Required: true})
+
+	h := &BackOfficeHandler{bo: bo}
+	resource, _ := bo.GetResource("TestModel")
+
+	// Missing required parameter re-renders the form with the error
+	form := url.Values{}
+	form.Add("action_id", "refund")
+	req := httptest.NewRequest(http.MethodPost, "/admin/api/TestModel/1/action", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	w := httptest.NewRecorder()
+	h.handleCustomAction(w, req, resource, "1")
+
+	if received != nil {
+		t.Fatal("Expected handler not to run when validation fails")
+	}
+	if !strings.Contains(w.Body.String(), "Amount is required") {
+		t.Errorf("Expected validation message in re-rendered form, got %s", w.Body.String())
+	}
+
+	// Valid submission runs the handler with typed values
+	form.Set("amount", "9.99")
+	req = httptest.NewRequest(http.MethodPost, "/admin/api/TestModel/1/action", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	w = httptest.NewRecorder()
+	h.handleCustomAction(w, req, resource, "1")
+
+	if w.Code != http.StatusOK {
+		t.Errorf("Expected status 200, got %d", w.Code)
+	}
+	if received.Float("amount") != 9.99 {
+		t.Errorf("Expected amount 9.99, got %v", received["amount"])
+	}
+}
+
+// TestHandleCustomAction_PasswordParam verifies password parameters are masked and never echoed back
+func TestHandleCustomAction_PasswordParam(t *testing.T) {
+	type TestModel struct {
+		ID   uint   `db:"id"`
+		Name string `db:"name"`
+	}
+
+	bo := core.New(&mockActionAdapter{}, auth.AuthConfig{})
+	bo.RegisterResource(&TestModel{}).
+		WithParamAction("set-password", "Set password", func(ctx context.Context, id any, params core.ActionParams) error {
+			return nil
+		},
+			core.ActionParam{Name: "password", Label: "Password", Type: core.ParamPassword, Required: true},
+			core.ActionParam{Name: "reason", Label: "Reason", Required: true})
+
+	h := &BackOfficeHandler{bo: bo}
+	resource, _ := bo.GetResource("TestModel")
+
+	form := url.Values{}
+	form.Add("action_id", "set-password")
+	form.Add("password", "hunter22")
+	req := httptest.NewRequest(http.MethodPost, "/admin/api/TestModel/1/action", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	w := httptest.NewRecorder()
+	h.handleCustomAction(w, req, resource, "1")
+
+	body := w.Body.String()
+	if !strings.Contains(body, `type="password" id="action-param-password"`) {
+		t.Errorf("Expected a masked password input, got %s", body)
+	}
+	if strings.Contains(body, "hunter22") {
+		t.Error("Expected the submitted password not to be rendered back")
+	}
+}
+
+// TestHandleCustomAction_DangerRequiresTypedConfirmation verifies dangerous actions only run after typing their title
+func TestHandleCustomAction_DangerRequiresTypedConfirmation(t *testing.T) {
+	type TestModel struct {
+		ID uint `db:"id"`
+	}
+
+	bo := core.New(&mockActionAdapter{}, auth.AuthConfig{})
+
+	executed := false
+	bo.RegisterResource(&TestModel{}).
+		WithCustomAction(core.NewAction("purge", "Purge", func(ctx context.Context, id any) error {
+			executed = true
+			return nil
+		}).WithLevel(core.ActionLevelDanger).WithIcon("trash").Build())
+
+	h := &BackOfficeHandler{bo: bo}
+	resource, _ := bo.GetResource("TestModel")
+
+	submit := func(confirmText string) *httptest.ResponseRecorder {
+		form := url.Values{}
+		form.Add("action_id", "purge")
+		form.Add("confirm_text", confirmText)
+		req := httptest.NewRequest(http.MethodPost, "/admin/api/TestModel/1/action", strings.NewReader(form.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		w := httptest.NewRecorder()
+		h.handleCustomAction(w, req, resource, "1")
+		return w
+	}
+
+	w := submit("purge")
+	if executed {
+		t.Fatal("Expected action not to run without the exact confirmation text")
+	}
+	if !strings.Contains(w.Body.String(), "to confirm") {
+		t.Errorf("Expected confirmation error in re-rendered form, got %s", w.Body.String())
+	}
+
+	w = submit("Purge")
+	if !executed || w.Code != http.StatusOK {
+		t.Errorf("Expected action to run after typed confirmation, got status %d", w.Code)
+	}
+}
+
+// TestHandleCollectionAction verifies collection actions receive the current list filters
+func TestHandleCollectionAction(t *testing.T) {
+	type TestModel struct {
+		ID     uint   `db:"id"`
+		Status string `db:"status"`
+	}
+
+	bo := core.New(&mockActionAdapter{}, auth.AuthConfig{})
+
+	var received *core.Query
+	bo.RegisterResource(&TestModel{}).
+		WithFields("Status").
+		WithCollectionAction("recalculate", "Recalculate", func(ctx context.Context, query *core.Query) error {
+			received = query
+			return nil
+		}, func(a *core.CollectionAction) {
+			a.Icon = "refresh"
+		})
+
+	h := &BackOfficeHandler{bo: bo}
+
+	form := url.Values{}
+	form.Add("action_id", "recalculate")
+	form.Add("query", "Status=active&sort=Status&offset=20")
+
+	req := httptest.NewRequest(http.MethodPost, "/admin/api/TestModel/collection-action", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	w := httptest.NewRecorder()
+	h.apiRouter(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if received == nil || received.Filters["Status"] != "active" {
+		t.Errorf("Expected handler to receive the list filters, got %+v", received)
+	}
+	if !strings.Contains(w.Header().Get("HX-Trigger"), "Recalculate completed successfully") {
+		t.Errorf("Expected success toast, got '%s'", w.Header().Get("HX-Trigger"))
+	}
+
+	form.Set("action_id", "missing")
+	req = httptest.NewRequest(http.MethodPost, "/admin/api/TestModel/collection-action", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	w = httptest.NewRecorder()
+	h.apiRouter(w, req)
+
+	if w.Code != http.StatusNotFound {
+		t.Errorf("Expected status 404 for unknown action, got %d", w.Code)
+	}
+}
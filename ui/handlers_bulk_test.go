@@ -0,0 +1,131 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/preslavrachev/backoffice/core"
+	"github.com/preslavrachev/backoffice/middleware/auth"
+)
+
+// TestHandleBulkAction_CustomAction verifies the action runs once per selected record
+func TestHandleBulkAction_CustomAction(t *testing.T) {
+	type TestModel struct {
+		ID   uint   `db:"id"`
+		Name string `db:"name"`
+	}
+
+	bo := core.New(&mockActionAdapter{}, auth.AuthConfig{})
+
+	var executed []any
+	bo.RegisterResource(&TestModel{}).
+		WithAction("archive", "Archive", func(ctx context.Context, id any) error {
+			executed = append(executed, id)
+			return nil
+		})
+
+	h := &BackOfficeHandler{bo: bo}
+
+	form := url.Values{}
+	form.Add("action_id", "archive")
+	form.Add("ids", "1")
+	form.Add("ids", "3")
+
+	req := httptest.NewRequest(http.MethodPost, "/admin/api/TestModel/bulk-action", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	w := httptest.NewRecorder()
+
+	h.apiRouter(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if len(executed) != 2 || executed[0] != uint(1) || executed[1] != uint(3) {
+		t.Errorf("Expected action to run for IDs 1 and 3, got %v", executed)
+	}
+	if !strings.Contains(w.Body.String(), "2 of 2 processed") {
+		t.Errorf("Expected progress summary in response, got %s", w.Body.String())
+	}
+	if !strings.Contains(w.Header().Get("HX-Trigger"), "refreshList") {
+		t.Errorf("Expected list refresh after success, got '%s'", w.Header().Get("HX-Trigger"))
+	}
+}
+
+// TestHandleBulkAction_DeleteRequiresPermission verifies bulk delete is checked against the delete permission
+func TestHandleBulkAction_DeleteRequiresPermission(t *testing.T) {
+	type TestModel struct {
+		ID uint `db:"id"`
+	}
+
+	bo := core.New(&mockActionAdapter{}, auth.AuthConfig{})
+	bo.RegisterResource(&TestModel{}).
+		WithPermissions(core.Permissions{Delete: core.DenyAll})
+
+	h := &BackOfficeHandler{bo: bo}
+
+	form := url.Values{}
+	form.Add("action_id", core.BulkDeleteActionID)
+	form.Add("ids", "1")
+
+	req := httptest.NewRequest(http.MethodPost, "/admin/api/TestModel/bulk-action", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	w := httptest.NewRecorder()
+
+	h.apiRouter(w, req)
+
+	if w.Code != http.StatusForbidden {
+		t.Errorf("Expected status 403, got %d", w.Code)
+	}
+}
+
+// referencedAdapter refuses to delete records other records still point to
+type referencedAdapter struct {
+	mockActionAdapter
+	referenced map[uint]bool
+	deleted    []uint
+}
+
+func (a *referencedAdapter) Delete(ctx context.Context, resource *core.Resource, id any) error {
+	if a.referenced[id.(uint)] {
+		return fmt.Errorf("failed to delete record: %w", core.ErrRecordInUse)
+	}
+	a.deleted = append(a.deleted, id.(uint))
+	return nil
+}
+
+// TestHandleBulkDelete_Summary verifies bulk delete reports the records it could not delete
+func TestHandleBulkDelete_Summary(t *testing.T) {
+	type TestModel struct {
+		ID uint `db:"id"`
+	}
+
+	adapter := &referencedAdapter{referenced: map[uint]bool{2: true}}
+	bo := core.New(adapter, auth.AuthConfig{})
+	bo.RegisterResource(&TestModel{})
+	h := &BackOfficeHandler{bo: bo}
+
+	form := url.Values{"ids": {"1", "2", "3"}}
+	req := httptest.NewRequest(http.MethodPost, "/admin/api/TestModel/bulk-delete", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	w := httptest.NewRecorder()
+
+	h.apiRouter(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if len(adapter.deleted) != 2 {
+		t.Errorf("Expected 2 records to be deleted, got %v", adapter.deleted)
+	}
+	body := w.Body.String()
+	for _, want := range []string{`data-pw="bulk-delete-modal"`, "2 of 3 deleted", "#2", "still referenced by other records"} {
+		if !strings.Contains(body, want) {
+			t.Errorf("Expected %q in the summary, got:\n%s", want, body)
+		}
+	}
+}
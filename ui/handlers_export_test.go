@@ -0,0 +1,97 @@
+package ui
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/preslavrachev/backoffice/core"
+	"github.com/preslavrachev/backoffice/middleware/auth"
+)
+
+type exportOrder struct {
+	ID     uint   `db:"id"`
+	Status string `db:"status"`
+	Secret string `db:"secret"`
+}
+
+// exportAdapter returns orders matching the status filter
+type exportAdapter struct {
+	mockActionAdapter
+	orders []*exportOrder
+}
+
+func (a *exportAdapter) Find(ctx context.Context, resource *core.Resource, query *core.Query) (*core.Result, error) {
+	var items []any
+	for _, order := range a.orders {
+		if status, ok := query.Filters["Status"]; ok && status != order.Status {
+			continue
+		}
+		items = append(items, order)
+	}
+	return &core.Result{Items: items, TotalCount: int64(len(items))}, nil
+}
+
+func TestHandleExport(t *testing.T) {
+	adapter := &exportAdapter{orders: []*exportOrder{
+		{ID: 1, Status: "paid", Secret: "s1"},
+		{ID: 2, Status: "open", Secret: "s2"},
+	}}
+	bo := core.New(adapter, auth.AuthConfig{})
+	bo.RegisterResource(&exportOrder{}).
+		WithFields("Status", "Secret").
+		WithExport(core.ExportConfig{Fields: []string{"ID", "Status"}, Filename: "orders", Formats: []core.ExportFormat{core.ExportCSV}})
+	h := &BackOfficeHandler{bo: bo}
+
+	req := httptest.NewRequest(http.MethodGet, "/admin/api/exportOrder/export?format=csv&Status=paid", nil)
+	w := httptest.NewRecorder()
+	h.apiRouter(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if got := w.Header().Get("Content-Disposition"); got != `attachment; filename="orders.csv"` {
+		t.Errorf("Unexpected Content-Disposition %q", got)
+	}
+	if body := w.Body.String(); body != "ID,Status\n1,paid\n" {
+		t.Errorf("Expected only the filtered, configured columns, got:\n%s", body)
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/admin/api/exportOrder/export?format=json", nil)
+	w = httptest.NewRecorder()
+	h.apiRouter(w, req)
+
+	if w.Code != http.StatusNotFound {
+		t.Errorf("Expected 404 for a disabled format, got %d", w.Code)
+	}
+}
+
+func TestHandleCSVExport(t *testing.T) {
+	adapter := &exportAdapter{orders: []*exportOrder{
+		{ID: 1, Status: "paid", Secret: "s1"},
+		{ID: 2, Status: "open", Secret: "s2"},
+	}}
+	bo := core.New(adapter, auth.AuthConfig{})
+	bo.RegisterResource(&exportOrder{}).
+		WithFields("Status", "Secret").
+		WithExport(core.ExportConfig{Fields: []string{"ID", "Status"}, Filename: "orders"})
+	h := Handler(bo, "/admin")
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/admin/exportOrder/export.csv?Status=paid", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if got := w.Header().Get("Content-Disposition"); got != `attachment; filename="orders.csv"` {
+		t.Errorf("Unexpected Content-Disposition %q", got)
+	}
+	if body := w.Body.String(); body != "ID,Status\n1,paid\n" {
+		t.Errorf("Expected the filtered records, got:\n%s", body)
+	}
+
+	if body := getPage(h, "/admin/exportOrder?Status=paid"); !strings.Contains(body, `href="/admin/exportOrder/export.csv?Status=paid"`) {
+		t.Error("Expected the Export CSV button to keep the list filters")
+	}
+}
@@ -0,0 +1,124 @@
+package ui
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/preslavrachev/backoffice/core"
+	"github.com/preslavrachev/backoffice/middleware/auth"
+)
+
+type inlineDepartment struct {
+	ID   uint   `db:"id"`
+	Name string `db:"name"`
+}
+
+type inlineEmployee struct {
+	ID               uint   `db:"id"`
+	Name             string `db:"name"`
+	InlineDepartment uint   `db:"department_id"`
+}
+
+// inlineAdapter keeps employees in memory and serves any department
+type inlineAdapter struct {
+	mockActionAdapter
+	employees map[uint]*inlineEmployee
+	nextID    uint
+}
+
+func (a *inlineAdapter) Find(ctx context.Context, resource *core.Resource, query *core.Query) (*core.Result, error) {
+	var items []any
+	for _, employee := range a.employees {
+		if departmentID, ok := query.Filters["InlineDepartment"]; ok && departmentID != employee.InlineDepartment {
+			continue
+		}
+		items = append(items, employee)
+	}
+	return &core.Result{Items: items, TotalCount: int64(len(items))}, nil
+}
+
+func (a *inlineAdapter) GetByID(ctx context.Context, resource *core.Resource, id any) (any, error) {
+	if resource.Name == "inlineDepartment" {
+		return &inlineDepartment{ID: id.(uint), Name: "Engineering"}, nil
+	}
+	if employee, ok := a.employees[id.(uint)]; ok {
+		copied := *employee
+		return &copied, nil
+	}
+	return nil, errors.New("not found")
+}
+
+func (a *inlineAdapter) Create(ctx context.Context, resource *core.Resource, data any) error {
+	a.nextID++
+	employee := data.(*inlineEmployee)
+	employee.ID = a.nextID
+	a.employees[employee.ID] = employee
+	return nil
+}
+
+func (a *inlineAdapter) Update(ctx context.Context, resource *core.Resource, id any, data any) error {
+	a.employees[id.(uint)] = data.(*inlineEmployee)
+	return nil
+}
+
+func (a *inlineAdapter) Delete(ctx context.Context, resource *core.Resource, id any) error {
+	delete(a.employees, id.(uint))
+	return nil
+}
+
+func TestHandleInlineChildren(t *testing.T) {
+	adapter := &inlineAdapter{
+		employees: map[uint]*inlineEmployee{
+			1: {ID: 1, Name: "Ada", InlineDepartment: 5},
+			2: {ID: 2, Name: "Linus", InlineDepartment: 6},
+		},
+		nextID: 2,
+	}
+	bo := core.New(adapter, auth.AuthConfig{})
+	bo.RegisterResource(&inlineDepartment{}).WithInline("inlineEmployee", "InlineDepartment")
+	bo.RegisterResource(&inlineEmployee{}).
+		WithField("Name", func(f *core.FieldBuilder) {}).
+		WithField("InlineDepartment", func(f *core.FieldBuilder) {})
+	h := &BackOfficeHandler{bo: bo}
+
+	send := func(method, path string, form url.Values) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(form.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		w := httptest.NewRecorder()
+		h.apiRouter(w, req)
+		return w
+	}
+
+	w := send(http.MethodGet, "/admin/api/inlineDepartment/5/inline/inlineEmployee", nil)
+	if w.Code != http.StatusOK || !strings.Contains(w.Body.String(), "Ada") || strings.Contains(w.Body.String(), "Linus") {
+		t.Fatalf("Expected table with only the department's employees, got %d: %s", w.Code, w.Body.String())
+	}
+
+	w = send(http.MethodPost, "/admin/api/inlineDepartment/5/inline/inlineEmployee", url.Values{"Name": {"Grace"}})
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200 on add, got %d", w.Code)
+	}
+	if created := adapter.employees[3]; created == nil || created.Name != "Grace" || created.InlineDepartment != 5 {
+		t.Errorf("Expected Grace to be created under department 5, got %+v", created)
+	}
+
+	w = send(http.MethodPost, "/admin/api/inlineDepartment/5/inline/inlineEmployee/1", url.Values{"Name": {"Ada Lovelace"}})
+	if w.Code != http.StatusOK || adapter.employees[1].Name != "Ada Lovelace" || adapter.employees[1].InlineDepartment != 5 {
+		t.Errorf("Expected Ada to be renamed in place, got %d: %+v", w.Code, adapter.employees[1])
+	}
+
+	w = send(http.MethodDelete, "/admin/api/inlineDepartment/5/inline/inlineEmployee/2", nil)
+	if w.Code != http.StatusNotFound || adapter.employees[2] == nil {
+		t.Errorf("Expected another department's employee to be untouched, got %d", w.Code)
+	}
+
+	w = send(http.MethodDelete, "/admin/api/inlineDepartment/5/inline/inlineEmployee/3", nil)
+	if w.Code != http.StatusOK || adapter.employees[3] != nil {
+		t.Errorf("Expected Grace to be deleted, got %d", w.Code)
+	}
+}
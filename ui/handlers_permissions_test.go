@@ -0,0 +1,310 @@
+package ui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/preslavrachev/backoffice/core"
+	"github.com/preslavrachev/backoffice/middleware/auth"
+)
+
+func TestRequiredOperation(t *testing.T) {
+	tests := []struct {
+		method   string
+		path     string
+		segments []string
+		expected core.Operation
+	}{
+		{http.MethodGet, "/admin/User", []string{"User"}, core.OperationList},
+		{http.MethodPost, "/admin/api/User", []string{"User"}, core.OperationCreate},
+		{http.MethodGet, "/admin/User/new", []string{"User", "new"}, core.OperationCreate},
+		{http.MethodGet, "/admin/User/1", []string{"User", "1"}, core.OperationList},
+		{http.MethodPost, "/admin/api/User/1", []string{"User", "1"}, core.OperationUpdate},
+		{http.MethodDelete, "/admin/api/User/1", []string{"User", "1"}, core.OperationDelete},
+		{http.MethodGet, "/admin/api/User/1/edit", []string{"User", "1", "edit"}, core.OperationUpdate},
+		{http.MethodPost, "/admin/api/User/1/action", []string{"User", "1", "action"}, core.OperationUpdate},
+		{http.MethodGet, "/admin/api/User/1/related/Posts", []string{"User", "1", "related", "Posts"}, core.OperationList},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.method+" "+tt.path, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			if got := requiredOperation(req, tt.segments); got != tt.expected {
+				t.Errorf("requiredOperation() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+// TestAPIRouter_PermissionDenied verifies denied operations are rejected before reaching the adapter
+func TestAPIRouter_PermissionDenied(t *testing.T) {
+	type TestModel struct {
+		ID   uint   `db:"id"`
+		Name string `db:"name"`
+	}
+
+	bo := core.New(&mockActionAdapter{}, auth.AuthConfig{})
+	bo.RegisterResource(&TestModel{}).
+		WithPermissions(core.Permissions{Delete: core.AllowRoles("admin")})
+
+	h := &BackOfficeHandler{bo: bo}
+
+	req := httptest.NewRequest(http.MethodDelete, "/admin/api/TestModel/1", nil)
+	w := httptest.NewRecorder()
+	h.apiRouter(w, req)
+
+	if w.Code != http.StatusForbidden {
+		t.Errorf("Expected status 403 for user without admin role, got %d", w.Code)
+	}
+
+	adminCtx := auth.WithAuthUser(req.Context(), &auth.AuthUser{Username: "admin", Roles: []string{"admin"}})
+	w = httptest.NewRecorder()
+	h.apiRouter(w, req.WithContext(adminCtx))
+
+	if w.Code != http.StatusOK {
+		t.Errorf("Expected status 200 for admin, got %d", w.Code)
+	}
+}
+
+func TestRenderIndex_GroupedNavigation(t *testing.T) {
+	type Order struct {
+		ID uint `db:"id"`
+	}
+	type Secret struct {
+		ID uint `db:"id"`
+	}
+
+	bo := core.New(&mockActionAdapter{}, auth.AuthConfig{})
+	bo.RegisterResource(&Order{}).WithGroup("Commerce")
+	bo.RegisterResource(&Secret{}).WithGroup("Internal").WithPermissions(core.Permissions{List: core.DenyAll})
+
+	h := &BackOfficeHandler{bo: bo}
+	req := httptest.NewRequest(http.MethodGet, "/admin/", nil)
+	w := httptest.NewRecorder()
+	h.indexHandler(w, req)
+
+	body := w.Body.String()
+	if !strings.Contains(body, `data-pw="sidebar-nav"`) || !strings.Contains(body, `data-pw="nav-link-Order"`) {
+		t.Error("Expected sidebar navigation with a link to Order")
+	}
+	if !strings.Contains(body, ">Commerce</h3>") {
+		t.Error("Expected index to render the Commerce group heading")
+	}
+	if strings.Contains(body, "Internal") || strings.Contains(body, "nav-link-Secret") {
+		t.Error("Expected groups without listable resources to be omitted")
+	}
+}
+
+// TestAPIRouter_OwnerRestricted verifies owner-restricted records can't be changed by other users
+func TestAPIRouter_OwnerRestricted(t *testing.T) {
+	type Ticket struct {
+		ID          uint   `db:"id"`
+		Title       string `db:"title"`
+		CreatedByID uint   `db:"created_by_id"`
+	}
+
+	adapter := &mockActionAdapter{getByIDFunc: func(ctx context.Context, resource *core.Resource, id any) (any, error) {
+		return &Ticket{ID: id.(uint), Title: "Broken", CreatedByID: 42}, nil
+	}}
+	bo := core.New(adapter, auth.AuthConfig{})
+	bo.RegisterResource(&Ticket{}).
+		WithFields("Title", "CreatedByID").
+		WithOwnerField("CreatedByID").
+		RestrictToOwner("admin")
+	h := &BackOfficeHandler{bo: bo}
+
+	deleteAs := func(user *auth.AuthUser) int {
+		req := httptest.NewRequest(http.MethodDelete, "/admin/api/Ticket/1", nil)
+		req = req.WithContext(auth.WithAuthUser(req.Context(), user))
+		w := httptest.NewRecorder()
+		h.apiRouter(w, req)
+		return w.Code
+	}
+
+	if code := deleteAs(&auth.AuthUser{ID: uint(7)}); code != http.StatusForbidden {
+		t.Errorf("Expected 403 for another user, got %d", code)
+	}
+	if code := deleteAs(&auth.AuthUser{ID: uint(42)}); code != http.StatusOK {
+		t.Errorf("Expected 200 for the owner, got %d", code)
+	}
+	if code := deleteAs(&auth.AuthUser{ID: uint(1), Roles: []string{"admin"}}); code != http.StatusOK {
+		t.Errorf("Expected 200 for an admin, got %d", code)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/admin/Ticket/1", nil)
+	req = req.WithContext(auth.WithAuthUser(req.Context(), &auth.AuthUser{ID: uint(7)}))
+	w := httptest.NewRecorder()
+	h.indexHandler(w, req)
+	if w.Code != http.StatusOK || strings.Contains(w.Body.String(), "/admin/Ticket/1/edit") {
+		t.Errorf("Expected the detail page without an edit link for another user, got %d", w.Code)
+	}
+}
+
+// TestRolePolicy_Handlers verifies the policy hides resources and actions and rejects denied requests
+func TestRolePolicy_Handlers(t *testing.T) {
+	type Order struct {
+		ID uint `db:"id"`
+	}
+	type Secret struct {
+		ID uint `db:"id"`
+	}
+
+	var refunded bool
+	bo := core.New(&mockActionAdapter{}, auth.AuthConfig{Enabled: true})
+	bo.RegisterResource(&Order{}).
+		WithAction("refund", "Refund", func(ctx context.Context, id any) error {
+			refunded = true
+			return nil
+		})
+	bo.RegisterResource(&Secret{})
+	bo.SetPolicy(core.NewRolePolicy().
+		Allow("support", "Order", core.OperationList, core.OperationUpdate).
+		Allow("admin", core.AnyResource).
+		Allow("admin", "Order", core.ActionOperation("refund")))
+	h := &BackOfficeHandler{bo: bo}
+	support := &auth.AuthUser{Username: "sue", Roles: []string{"support"}}
+
+	req := httptest.NewRequest(http.MethodGet, "/admin/", nil)
+	w := httptest.NewRecorder()
+	h.indexHandler(w, req.WithContext(auth.WithAuthUser(req.Context(), support)))
+	body := w.Body.String()
+	if !strings.Contains(body, `data-pw="nav-link-Order"`) || strings.Contains(body, "nav-link-Secret") {
+		t.Error("Expected the navigation to list only the resources the policy allows")
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/admin/api/Secret", nil)
+	w = httptest.NewRecorder()
+	h.apiRouter(w, req.WithContext(auth.WithAuthUser(req.Context(), support)))
+	if w.Code != http.StatusForbidden {
+		t.Errorf("Expected status 403 listing a resource outside the policy, got %d", w.Code)
+	}
+
+	runRefund := func(user *auth.AuthUser) int {
+		form := url.Values{"action_id": {"refund"}}
+		req := httptest.NewRequest(http.MethodPost, "/admin/api/Order/1/action", strings.NewReader(form.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		w := httptest.NewRecorder()
+		h.apiRouter(w, req.WithContext(auth.WithAuthUser(req.Context(), user)))
+		return w.Code
+	}
+	if code := runRefund(support); code != http.StatusForbidden || refunded {
+		t.Errorf("Expected the reserved refund action to be rejected for support, got %d", code)
+	}
+	if code := runRefund(&auth.AuthUser{Username: "ann", Roles: []string{"admin"}}); code != http.StatusOK || !refunded {
+		t.Errorf("Expected admins to run the refund action, got %d", code)
+	}
+}
+
+func TestAuthorizeHook_Handlers(t *testing.T) {
+	type Order struct {
+		ID uint `db:"id"`
+	}
+
+	var calls []string
+	authConfig := auth.AuthConfig{Enabled: true}
+	authConfig.Authorize = func(ctx context.Context, user *auth.AuthUser, resource, action string, id any) error {
+		calls = append(calls, fmt.Sprintf("%s %s %v", resource, action, id))
+		if id == uint(2) || action == "action:refund" {
+			return errors.New("not your region")
+		}
+		return nil
+	}
+	bo := core.New(&mockActionAdapter{
+		getByIDFunc: func(ctx context.Context, resource *core.Resource, id any) (any, error) {
+			return &Order{ID: id.(uint)}, nil
+		},
+	}, authConfig)
+	bo.RegisterResource(&Order{}).
+		WithAction("refund", "Refund", func(ctx context.Context, id any) error { return nil })
+	h := &BackOfficeHandler{bo: bo}
+	user := &auth.AuthUser{Username: "ann"}
+
+	serve := func(handler http.HandlerFunc, method, path, body string) int {
+		calls = nil
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		w := httptest.NewRecorder()
+		handler(w, req.WithContext(auth.WithAuthUser(req.Context(), user)))
+		return w.Code
+	}
+
+	if code := serve(h.indexHandler, http.MethodGet, "/admin/Order/1", ""); code != http.StatusOK || len(calls) == 0 || calls[0] != "Order list 1" {
+		t.Errorf("Expected the detail page to be authorized for record 1, got %d %q", code, calls)
+	}
+	if code := serve(h.indexHandler, http.MethodGet, "/admin/Order/2", ""); code != http.StatusForbidden {
+		t.Errorf("Expected the hook to deny record 2, got %d", code)
+	}
+	if code := serve(h.apiRouter, http.MethodPost, "/admin/api/Order/2", "ID=2"); code != http.StatusForbidden {
+		t.Errorf("Expected the hook to deny updating record 2, got %d", code)
+	}
+	if code := serve(h.apiRouter, http.MethodPost, "/admin/api/Order/1/action", "action_id=refund"); code != http.StatusForbidden || calls[0] != "Order action:refund 1" {
+		t.Errorf("Expected custom actions to be authorized by their own operation, got %d %q", code, calls)
+	}
+}
+
+// deleteRecordingAdapter remembers the IDs deleted through it
+type deleteRecordingAdapter struct {
+	mockActionAdapter
+	deleted []any
+}
+
+func (a *deleteRecordingAdapter) Delete(ctx context.Context, resource *core.Resource, id any) error {
+	a.deleted = append(a.deleted, id)
+	return nil
+}
+
+func TestAuthorizeHook_BulkSelection(t *testing.T) {
+	type Order struct {
+		ID    uint `db:"id"`
+		Order int  `db:"order"`
+	}
+
+	authConfig := auth.AuthConfig{Enabled: true}
+	authConfig.Authorize = func(ctx context.Context, user *auth.AuthUser, resource, action string, id any) error {
+		if id == uint(2) {
+			return errors.New("not your region")
+		}
+		return nil
+	}
+	adapter := &deleteRecordingAdapter{mockActionAdapter: mockActionAdapter{
+		getByIDFunc: func(ctx context.Context, resource *core.Resource, id any) (any, error) {
+			return &Order{ID: id.(uint), Order: int(id.(uint))}, nil
+		},
+	}}
+	bo := core.New(adapter, authConfig)
+	var acted []any
+	bo.RegisterResource(&Order{}).
+		WithOrderField("Order").
+		WithAction("archive", "Archive", func(ctx context.Context, id any) error {
+			acted = append(acted, id)
+			return nil
+		})
+	h := &BackOfficeHandler{bo: bo}
+	user := &auth.AuthUser{Username: "ann"}
+
+	post := func(path, body string) int {
+		req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		w := httptest.NewRecorder()
+		h.apiRouter(w, req.WithContext(auth.WithAuthUser(req.Context(), user)))
+		return w.Code
+	}
+
+	post("/admin/api/Order/bulk-delete", "ids=1&ids=2&ids=3")
+	if fmt.Sprint(adapter.deleted) != "[1 3]" {
+		t.Errorf("Expected the hook to keep record 2 from being bulk deleted, deleted %v", adapter.deleted)
+	}
+	post("/admin/api/Order/bulk-action", "action_id=archive&ids=1&ids=2")
+	if fmt.Sprint(acted) != "[1]" {
+		t.Errorf("Expected the hook to keep the bulk action from running on record 2, ran on %v", acted)
+	}
+	if code := post("/admin/api/Order/reorder", "order=2&order=1"); code != http.StatusForbidden {
+		t.Errorf("Expected the hook to deny reordering record 2, got %d", code)
+	}
+}
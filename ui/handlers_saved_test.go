@@ -0,0 +1,77 @@
+package ui
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/preslavrachev/backoffice/core"
+	"github.com/preslavrachev/backoffice/middleware/auth"
+)
+
+// savingAdapter keeps products in memory and loads them back by ID
+type savingAdapter struct {
+	importAdapter
+}
+
+func (a *savingAdapter) GetByID(ctx context.Context, resource *core.Resource, id any) (any, error) {
+	for _, product := range a.products {
+		if product.ID == id.(uint) {
+			copied := *product
+			return &copied, nil
+		}
+	}
+	return nil, nil
+}
+
+func postSave(h http.Handler, path, currentURL string, form url.Values) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(form.Encode()))
+	withCSRFToken(req)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("HX-Request", "true")
+	req.Header.Set("HX-Current-URL", currentURL)
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+	return w
+}
+
+// TestSaveFromSidePane tests that saving a side pane form updates the list in place instead of reloading it
+func TestSaveFromSidePane(t *testing.T) {
+	adapter := &savingAdapter{importAdapter{products: []*importProduct{{ID: 1, SKU: "A-1", Name: "Lamp", Price: 5}}}}
+	bo := core.New(adapter, auth.AuthConfig{})
+	bo.RegisterResource(&importProduct{}).WithFields("SKU", "Name", "Price")
+	h := Handler(bo, "/admin")
+
+	w := postSave(h, "/admin/api/importProduct/1", "http://localhost/admin/importProduct?sort=Name", url.Values{"_method": {"PUT"}, "SKU": {"A-1"}, "Name": {"Desk lamp"}, "Price": {"5"}})
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected the update to succeed, got %d", w.Code)
+	}
+	if w.Header().Get("HX-Reswap") != "none" || !strings.Contains(w.Header().Get("HX-Trigger"), `"closeSidePane":{"id":"sidepane-overlay"}`) {
+		t.Errorf("Expected the side pane to be closed by an event, got %q", w.Header().Get("HX-Trigger"))
+	}
+	if got := w.Header().Get("HX-Trigger-After-Settle"); got != `{"recordSaved":{"action":"updated","row":"row-importProduct-1"}}` {
+		t.Errorf("Expected the row to be highlighted, got %q", got)
+	}
+	body := w.Body.String()
+	if !strings.HasPrefix(body, `<tr id="row-importProduct-1"`) || !strings.Contains(body, `hx-swap-oob="true"`) || !strings.Contains(body, "Desk lamp") {
+		t.Errorf("Expected the updated row swapped out of band, got %s", body)
+	}
+	if strings.Contains(body, "<script") {
+		t.Error("Expected no inline script in the response")
+	}
+
+	w = postSave(h, "/admin/api/importProduct", "http://localhost/admin/importProduct", url.Values{"SKU": {"B-2"}, "Name": {"Chair"}, "Price": {"20"}})
+	body = w.Body.String()
+	if !strings.HasPrefix(body, `<tbody hx-swap-oob="afterbegin:#table-body"><tr id="row-importProduct-2"`) || strings.Contains(body, `hx-swap-oob="true"`) {
+		t.Errorf("Expected the new row to be prepended to the list, got %s", body)
+	}
+
+	// Saving from a detail page has no row to swap; recordSaved reloads that page instead
+	w = postSave(h, "/admin/api/importProduct/2", "http://localhost/admin/importProduct/2", url.Values{"_method": {"PUT"}, "SKU": {"B-2"}, "Name": {"Armchair"}, "Price": {"20"}})
+	if w.Body.Len() != 0 || !strings.Contains(w.Header().Get("HX-Trigger-After-Settle"), "recordSaved") {
+		t.Errorf("Expected only the recordSaved event outside the list, got %s", w.Body.String())
+	}
+}
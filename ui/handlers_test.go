@@ -2,7 +2,9 @@ package ui
 
 import (
 	"database/sql"
+	"encoding/json"
 	"fmt"
+	"html"
 	"net/http"
 	"net/http/httptest"
 	"regexp"
@@ -95,12 +97,12 @@ func setupHandlerTestDB(t *testing.T) (*sql.DB, *core.BackOffice) {
 }
 
 // extractLoadMoreURL extracts the "Load More" button URL from HTML response
-func extractLoadMoreURL(html string) string {
+func extractLoadMoreURL(page string) string {
 	// Look for hx-get attribute specifically in Load More button
 	re := regexp.MustCompile(`hx-get="([^"]*load_more=true[^"]*)"`)
-	matches := re.FindStringSubmatch(html)
+	matches := re.FindStringSubmatch(page)
 	if len(matches) > 1 {
-		return matches[1]
+		return html.UnescapeString(matches[1])
 	}
 	return ""
 }
@@ -186,3 +188,67 @@ func TestPaginationPreservesSorting(t *testing.T) {
 		})
 	}
 }
+
+// TestFormToStruct_EnforcesRequiredAndReadOnly verifies crafted POSTs can't skip required fields
+// or overwrite read-only ones
+func TestFormToStruct_EnforcesRequiredAndReadOnly(t *testing.T) {
+	type Account struct {
+		ID      uint   `db:"id"`
+		Email   string `db:"email"`
+		Balance int    `db:"balance"`
+		Note    string `db:"note"`
+	}
+
+	bo := core.New(&mockActionAdapter{}, auth.AuthConfig{})
+	bo.RegisterResource(&Account{}).
+		WithField("Email", func(f *core.FieldBuilder) { f.Required(true) }).
+		WithField("Balance", func(f *core.FieldBuilder) { f.ReadOnly(true) }).
+		WithField("Note", func(f *core.FieldBuilder) {})
+	resource, _ := bo.GetResource("Account")
+	h := &BackOfficeHandler{bo: bo}
+
+	post := func(body string) *http.Request {
+		req := httptest.NewRequest(http.MethodPost, "/admin/api/Account", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		return req
+	}
+
+	if _, err := h.formToStruct(post("Email=++&Note=hi"), resource); err == nil || !strings.Contains(err.Error(), "Email") {
+		t.Errorf("Expected missing Email error, got %v", err)
+	}
+
+	item, err := h.formToStruct(post("ID=99&Email=a@b.c&Balance=1000000&Note=hi"), resource)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	account := item.(*Account)
+	if account.ID != 0 || account.Balance != 0 {
+		t.Errorf("Expected ID and read-only Balance to be ignored, got %+v", account)
+	}
+	if account.Email != "a@b.c" || account.Note != "hi" {
+		t.Errorf("Expected editable fields to be set, got %+v", account)
+	}
+
+	w := httptest.NewRecorder()
+	h.apiRouter(w, post("Note=hi"))
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status 400 for missing required field, got %d", w.Code)
+	}
+}
+
+func TestWriteHTTPErrorWithToast_EscapesMessage(t *testing.T) {
+	h := &BackOfficeHandler{}
+	message := `failed: column "name" can't be "" \ empty`
+	w := httptest.NewRecorder()
+	h.writeHTTPErrorWithToast(w, message, http.StatusBadRequest, "error")
+
+	var trigger struct {
+		ShowToast struct{ Message, Type string } `json:"showToast"`
+	}
+	if err := json.Unmarshal([]byte(w.Header().Get("HX-Trigger")), &trigger); err != nil {
+		t.Fatalf("Expected HX-Trigger to be valid JSON, got %q: %v", w.Header().Get("HX-Trigger"), err)
+	}
+	if trigger.ShowToast.Message != message || trigger.ShowToast.Type != "error" {
+		t.Errorf("Expected the message to survive as is, got %+v", trigger.ShowToast)
+	}
+}
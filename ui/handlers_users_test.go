@@ -0,0 +1,66 @@
+package ui
+
+import (
+	"database/sql"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	sqladapter "github.com/preslavrachev/backoffice/adapters/sql"
+	"github.com/preslavrachev/backoffice/core"
+	"github.com/preslavrachev/backoffice/middleware/auth"
+)
+
+// TestAdminUsers_RequireAdminRole verifies only user admins can edit users, set their passwords or enable them
+func TestAdminUsers_RequireAdminRole(t *testing.T) {
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("Failed to create test database: %v", err)
+	}
+	defer db.Close()
+
+	adapter := sqladapter.New(db)
+	bo := core.New(adapter, auth.WithUserStore(adapter))
+	h := &BackOfficeHandler{bo: bo}
+	editor := &auth.AuthUser{Username: "editor", Roles: []string{"editor"}}
+	if err := adapter.CreateUser(t.Context(), editor, "editor-secret"); err != nil {
+		t.Fatalf("CreateUser failed: %v", err)
+	}
+
+	request := func(user *auth.AuthUser, method, path string, form url.Values) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(form.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		req = req.WithContext(auth.WithAuthUser(req.Context(), user))
+		w := httptest.NewRecorder()
+		h.apiRouter(w, req)
+		return w
+	}
+
+	requests := []struct {
+		name   string
+		method string
+		path   string
+		form   url.Values
+	}{
+		{"edit", http.MethodGet, "/admin/api/AdminUser/1/edit", nil},
+		{"set password", http.MethodPost, "/admin/api/AdminUser/1/action", url.Values{"action_id": {"set-password"}, "password": {"taken-over!"}}},
+		{"enable", http.MethodPost, "/admin/api/AdminUser/1/action", url.Values{"action_id": {"enable"}}},
+	}
+	for _, tt := range requests {
+		if w := request(editor, tt.method, tt.path, tt.form); w.Code != http.StatusForbidden {
+			t.Errorf("Expected a non-admin to get 403 on %s, got %d", tt.name, w.Code)
+		}
+	}
+	if _, err := adapter.VerifyPassword(t.Context(), "editor", "editor-secret"); err != nil {
+		t.Errorf("Expected the password to be unchanged, got %v", err)
+	}
+
+	admin := &auth.AuthUser{Username: "root", Roles: []string{"admin"}}
+	for _, tt := range requests {
+		if w := request(admin, tt.method, tt.path, tt.form); w.Code != http.StatusOK {
+			t.Errorf("Expected an admin to %s, got %d: %s", tt.name, w.Code, w.Body.String())
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package ui
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/preslavrachev/backoffice/core"
+	"github.com/preslavrachev/backoffice/middleware/auth"
+)
+
+type validatedTrial struct {
+	ID       uint   `db:"id"`
+	Name     string `db:"name"`
+	StartDay int    `db:"start_day"`
+	EndDay   int    `db:"end_day"`
+}
+
+func TestCreateAPI_CrossFieldValidation(t *testing.T) {
+	bo := core.New(&mockActionAdapter{}, auth.AuthConfig{})
+	bo.RegisterResource(&validatedTrial{}).
+		WithFields("Name", "StartDay", "EndDay").
+		ValidateWith(func(ctx context.Context, item any) error {
+			trial := item.(*validatedTrial)
+			if trial.EndDay <= trial.StartDay {
+				return core.NewFieldError("must be after the start day", "StartDay", "EndDay")
+			}
+			return nil
+		})
+	h := &BackOfficeHandler{bo: bo}
+
+	form := url.Values{"Name": {"trial"}, "StartDay": {"5"}, "EndDay": {"3"}}
+	req := httptest.NewRequest(http.MethodPost, "/admin/api/validatedTrial", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	w := httptest.NewRecorder()
+	h.apiRouter(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("Expected status 400, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var trigger struct {
+		FieldErrors map[string]string `json:"fieldErrors"`
+	}
+	if err := json.Unmarshal([]byte(w.Header().Get("HX-Trigger")), &trigger); err != nil {
+		t.Fatalf("Expected a JSON HX-Trigger header: %v", err)
+	}
+	for _, field := range []string{"StartDay", "EndDay"} {
+		if trigger.FieldErrors[field] != "must be after the start day" {
+			t.Errorf("Expected the error mapped to %s, got %v", field, trigger.FieldErrors)
+		}
+	}
+}
@@ -0,0 +1,61 @@
+package ui
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/preslavrachev/backoffice/core"
+	"github.com/preslavrachev/backoffice/middleware/auth"
+)
+
+type helpInvoice struct {
+	ID     uint   `db:"id"`
+	Memo   string `db:"memo"`
+	Number string `db:"number"`
+	Amount int    `db:"amount"`
+	Slug   string `db:"slug"`
+}
+
+// TestFieldHelpText tests that help texts and placeholders show in forms, and help texts as list header tooltips
+func TestFieldHelpText(t *testing.T) {
+	adapter := &mockActionAdapter{getByIDFunc: func(ctx context.Context, resource *core.Resource, id any) (any, error) {
+		return &helpInvoice{ID: 1}, nil
+	}}
+	bo := core.New(adapter, auth.AuthConfig{})
+	bo.RegisterResource(&helpInvoice{}).
+		WithField("Memo", func(f *core.FieldBuilder) { f.HelpText("Shown to customers on invoices") }).
+		WithField("Number", func(f *core.FieldBuilder) { f.Placeholder("INV-0001") }).
+		WithField("Amount", func(f *core.FieldBuilder) { f.Placeholder("In cents") }).
+		WithField("Slug", func(f *core.FieldBuilder) { f.AsSlug("Number") })
+	h := Handler(bo, "/admin")
+
+	pages := map[string]string{
+		"form":      getPage(h, "/admin/helpInvoice/new"),
+		"side pane": getPage(h, "/admin/api/helpInvoice/1/edit"),
+	}
+	for name, page := range pages {
+		for _, want := range []string{
+			"Shown to customers on invoices</p>",
+			`placeholder="INV-0001"`,
+			`placeholder="In cents"`,
+			`placeholder="Leave blank to generate from Number"`,
+		} {
+			if !strings.Contains(page, want) {
+				t.Errorf("Expected %s in the %s", want, name)
+			}
+		}
+	}
+
+	resource, _ := bo.GetResource("helpInvoice")
+	var sb strings.Builder
+	if err := List(resource, []interface{}{&helpInvoice{ID: 1}}, 1, "").Render(context.Background(), &sb); err != nil {
+		t.Fatalf("Failed to render List: %v", err)
+	}
+	if !strings.Contains(sb.String(), `title="Shown to customers on invoices"`) {
+		t.Error("Expected the help text as a tooltip on the list header")
+	}
+	if strings.Contains(sb.String(), `data-pw="header-help-Number"`) {
+		t.Error("Expected no info icon on fields without help text")
+	}
+}
@@ -0,0 +1,61 @@
+package ui
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/preslavrachev/backoffice/core"
+	"github.com/preslavrachev/backoffice/middleware/auth"
+)
+
+type galleryPhoto struct {
+	ID  uint   `db:"id"`
+	URL string `db:"url"`
+}
+
+func TestImageSource(t *testing.T) {
+	tests := []struct {
+		value any
+		want  string
+	}{
+		{"https://cdn.example.com/a.png", "https://cdn.example.com/a.png"},
+		{" /uploads/a.png ", "/uploads/a.png"},
+		{"data:image/png;base64,AAAA", "data:image/png;base64,AAAA"},
+		{"javascript:alert(1)", ""},
+		{"//evil.example.com/a.png", ""},
+		{"", ""},
+		{(*string)(nil), ""},
+	}
+	for _, tt := range tests {
+		if got := imageSource(tt.value); got != tt.want {
+			t.Errorf("imageSource(%v) = %q, want %q", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestImagePreviews(t *testing.T) {
+	photo := &galleryPhoto{ID: 3, URL: "https://cdn.example.com/a.png"}
+	adapter := &mockActionAdapter{getByIDFunc: func(ctx context.Context, resource *core.Resource, id any) (any, error) {
+		return photo, nil
+	}}
+	bo := core.New(adapter, auth.AuthConfig{})
+	bo.RegisterResource(&galleryPhoto{}).
+		WithField("URL", func(f *core.FieldBuilder) { f.RenderAsImage() })
+	h := Handler(bo, "/admin")
+
+	detail := getPage(h, "/admin/galleryPhoto/3")
+	for _, want := range []string{`data-pw="image-preview-URL"`, `data-pw="image-lightbox-URL"`, `src="https://cdn.example.com/a.png"`} {
+		if !strings.Contains(detail, want) {
+			t.Errorf("Expected %s on the detail page", want)
+		}
+	}
+
+	resource, _ := bo.GetResource("galleryPhoto")
+	field, _ := resource.GetField("URL")
+	var cell strings.Builder
+	displayFieldValue(photo, field, resource).Render(context.Background(), &cell)
+	if !strings.Contains(cell.String(), `data-pw="thumbnail-URL"`) || strings.Contains(cell.String(), ">https://") {
+		t.Errorf("Expected a thumbnail instead of the raw URL, got %s", cell.String())
+	}
+}
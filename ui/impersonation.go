@@ -0,0 +1,59 @@
+package ui
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/preslavrachev/backoffice/core"
+	"github.com/preslavrachev/backoffice/middleware/auth"
+)
+
+// impersonateHandler lets an admin "view as" another user, see auth.ImpersonationConfig
+func (h *BackOfficeHandler) impersonateHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	_, err := h.bo.GetAuth().StartImpersonation(w, r, r.FormValue("username"))
+	switch {
+	case errors.Is(err, auth.ErrImpersonationNotAllowed):
+		h.writeHTTPError(w, msg(r.Context(), "impersonation.denied"), http.StatusForbidden)
+		return
+	case errors.Is(err, auth.ErrImpersonationPrivileged):
+		h.writeHTTPError(w, msg(r.Context(), "impersonation.privileged"), http.StatusForbidden)
+		return
+	case errors.Is(err, auth.ErrUserNotFound):
+		h.writeHTTPError(w, msg(r.Context(), "impersonation.unknown"), http.StatusNotFound)
+		return
+	case err != nil:
+		h.writeHTTPError(w, msg(r.Context(), "error.impersonation_start"), http.StatusInternalServerError)
+		return
+	}
+
+	// The impersonated user may not see the page the admin came from, so start over at home
+	http.Redirect(w, r, h.bo.GetConfig().BasePath+"/", http.StatusSeeOther)
+}
+
+// stopImpersonationHandler switches back to the admin who started the impersonation
+func (h *BackOfficeHandler) stopImpersonationHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	if _, err := h.bo.GetAuth().StopImpersonation(w, r); err != nil {
+		if errors.Is(err, auth.ErrNotImpersonating) {
+			h.writeHTTPError(w, msg(r.Context(), "impersonation.not_active"), http.StatusBadRequest)
+			return
+		}
+		h.writeHTTPError(w, msg(r.Context(), "error.impersonation_stop"), http.StatusInternalServerError)
+		return
+	}
+	http.Redirect(w, r, h.bo.GetConfig().BasePath+"/", http.StatusSeeOther)
+}
+
+// canImpersonate reports whether the header offers the "view as" form to the user
+func canImpersonate(config *core.Config, user *auth.AuthUser) bool {
+	return config != nil && config.Auth.CanImpersonate(user)
+}
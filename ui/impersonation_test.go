@@ -0,0 +1,76 @@
+package ui
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/preslavrachev/backoffice/core"
+	"github.com/preslavrachev/backoffice/middleware/auth"
+)
+
+func TestImpersonation_Handlers(t *testing.T) {
+	users := auth.NewMemoryUserStore()
+	users.AddUser(auth.AuthUser{Username: "ann", Roles: []string{"admin", "editor"}}, "secret123")
+	users.AddUser(auth.AuthUser{Username: "ed", Roles: []string{"editor"}}, "secret123")
+	audit := auth.NewMemoryImpersonationAudit()
+	authConfig := auth.WithUserStore(users)
+	authConfig.Impersonation = auth.ImpersonationConfig{Roles: []auth.Role{"admin"}, Audit: audit}
+
+	bo := core.New(&mockActionAdapter{}, authConfig)
+	h := Handler(bo, "/admin")
+	sessionID, _ := bo.GetAuth().SessionStore.CreateSession(t.Context(), &auth.AuthUser{Username: "ann", Roles: []string{"admin", "editor"}})
+
+	do := func(method, path, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		req.AddCookie(&http.Cookie{Name: auth.DefaultSessionCookieName, Value: sessionID})
+		withCSRFToken(req)
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, req)
+		if cookie := findSessionCookie(w); cookie != nil {
+			sessionID = cookie.Value
+		}
+		return w
+	}
+
+	if body := do(http.MethodGet, "/admin/", "").Body.String(); !strings.Contains(body, `data-pw="impersonation-menu"`) || strings.Contains(body, `data-pw="impersonation-banner"`) {
+		t.Fatal("Expected admins to be offered the view as menu, without a banner")
+	}
+
+	if w := do(http.MethodPost, "/admin/impersonate", "username=ed"); w.Code != http.StatusSeeOther {
+		t.Fatalf("Expected a redirect after starting to impersonate, got %d: %s", w.Code, w.Body.String())
+	}
+	body := do(http.MethodGet, "/admin/", "").Body.String()
+	if !strings.Contains(body, `data-pw="impersonation-banner"`) || !strings.Contains(body, "Viewing as <strong>ed</strong>") {
+		t.Error("Expected the banner while viewing as ed")
+	}
+	if strings.Contains(body, `data-pw="impersonation-menu"`) {
+		t.Error("Expected no view as menu while impersonating")
+	}
+
+	if w := do(http.MethodPost, "/admin/impersonate/stop", ""); w.Code != http.StatusSeeOther {
+		t.Fatalf("Expected a redirect after exiting, got %d", w.Code)
+	}
+	if body := do(http.MethodGet, "/admin/", "").Body.String(); strings.Contains(body, `data-pw="impersonation-banner"`) {
+		t.Error("Expected the banner to be gone after exiting")
+	}
+	events := audit.Events()
+	if len(events) == 0 || events[0].Kind != auth.ImpersonationStarted || events[len(events)-1].Kind != auth.ImpersonationStopped {
+		t.Errorf("Expected the start and the stop to be audited, got %+v", events)
+	}
+
+	if w := do(http.MethodPost, "/admin/impersonate", "username=nobody"); w.Code != http.StatusNotFound {
+		t.Errorf("Expected 404 impersonating an unknown user, got %d", w.Code)
+	}
+}
+
+func findSessionCookie(w *httptest.ResponseRecorder) *http.Cookie {
+	for _, cookie := range w.Result().Cookies() {
+		if cookie.Name == auth.DefaultSessionCookieName {
+			return cookie
+		}
+	}
+	return nil
+}
@@ -0,0 +1,139 @@
+package ui
+
+import (
+	"bytes"
+	"context"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/preslavrachev/backoffice/core"
+	"github.com/preslavrachev/backoffice/middleware/auth"
+)
+
+type importProduct struct {
+	ID    uint    `db:"id"`
+	SKU   string  `db:"sku"`
+	Name  string  `db:"name"`
+	Price float64 `db:"price"`
+}
+
+// importAdapter stores products in memory, matching the SKU filter of import keys
+// Like a database, it hands out copies so that only Update changes a stored product
+type importAdapter struct {
+	mockActionAdapter
+	products []*importProduct
+}
+
+func (a *importAdapter) Find(ctx context.Context, resource *core.Resource, query *core.Query) (*core.Result, error) {
+	var items []any
+	for _, product := range a.products {
+		if sku, ok := query.Filters["SKU"]; ok && sku != product.SKU {
+			continue
+		}
+		copied := *product
+		items = append(items, &copied)
+	}
+	return &core.Result{Items: items, TotalCount: int64(len(items))}, nil
+}
+
+func (a *importAdapter) Create(ctx context.Context, resource *core.Resource, data any) error {
+	product := data.(*importProduct)
+	product.ID = uint(len(a.products) + 1)
+	a.products = append(a.products, product)
+	return nil
+}
+
+func (a *importAdapter) Update(ctx context.Context, resource *core.Resource, id any, data any) error {
+	for i, product := range a.products {
+		if product.ID == id.(uint) {
+			updated := data.(*importProduct)
+			updated.ID = product.ID
+			a.products[i] = updated
+		}
+	}
+	return nil
+}
+
+func newImportHandler(adapter *importAdapter) http.Handler {
+	bo := core.New(adapter, auth.AuthConfig{})
+	bo.RegisterResource(&importProduct{}).
+		WithField("SKU", func(f *core.FieldBuilder) { f.Required(true) }).
+		WithFields("Name", "Price").
+		WithImport(core.ImportConfig{KeyField: "SKU"})
+	return Handler(bo, "/admin")
+}
+
+func postImportStep(h http.Handler, form url.Values) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/admin/importProduct/import", strings.NewReader(form.Encode()))
+	withCSRFToken(req)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+	return w
+}
+
+// TestImportWizard tests uploading a CSV, the suggested mapping, the preview and the upserting commit
+func TestImportWizard(t *testing.T) {
+	adapter := &importAdapter{products: []*importProduct{{ID: 1, SKU: "A-1", Name: "Old name", Price: 5}}}
+	h := newImportHandler(adapter)
+	csv := "sku,Name,price,notes\nA-1,Renamed,7.5,x\nB-2,Brand new,3,y\n,No SKU,1,z\nC-3,Bad price,abc,w\n"
+
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	writer.WriteField("step", "upload")
+	part, _ := writer.CreateFormFile("file", "products.csv")
+	part.Write([]byte(csv))
+	writer.Close()
+	req := httptest.NewRequest(http.MethodPost, "/admin/importProduct/import", &body)
+	withCSRFToken(req)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected the mapping step, got %d: %s", w.Code, w.Body.String())
+	}
+	if got := strings.Count(w.Body.String(), " selected>"); got != 3 {
+		t.Errorf("Expected the sku, Name and price columns to be mapped by their headers, got %d", got)
+	}
+
+	form := url.Values{"csv": {csv}, "map": {"SKU", "Name", "Price", ""}, "step": {"preview"}}
+	preview := postImportStep(h, form).Body.String()
+	if !strings.Contains(preview, "1 to create, 1 to update, 2 with errors.") {
+		t.Errorf("Expected the preview to count the rows, got:\n%s", preview)
+	}
+	if !strings.Contains(preview, "required fields missing: SKU") || !strings.Contains(preview, "Price: &#34;abc&#34; is not a valid number") {
+		t.Error("Expected the preview to explain each rejected row")
+	}
+	if len(adapter.products) != 1 || adapter.products[0].Name != "Old name" {
+		t.Fatal("Expected the preview not to save anything")
+	}
+
+	form.Set("step", "commit")
+	result := postImportStep(h, form).Body.String()
+	if !strings.Contains(result, "1 created, 1 updated, 2 skipped.") {
+		t.Errorf("Expected the result to count the saved rows, got:\n%s", result)
+	}
+	if len(adapter.products) != 2 || adapter.products[0].Name != "Renamed" || adapter.products[0].Price != 7.5 || adapter.products[1].SKU != "B-2" {
+		t.Errorf("Expected A-1 to be updated and B-2 created, got %+v %+v", adapter.products[0], adapter.products[len(adapter.products)-1])
+	}
+
+	form.Set("step", "errors")
+	form.Set("errors", `[{"Row":4,"Message":"required fields missing: SKU"}]`)
+	w = postImportStep(h, form)
+	if got := w.Body.String(); got != "Row,sku,Name,price,notes,Error\n4,,No SKU,1,z,required fields missing: SKU\n" {
+		t.Errorf("Unexpected error report:\n%s", got)
+	}
+}
+
+// TestImportWizard_MappingProblems tests that a field mapped to two columns sends the user back to the mapping
+func TestImportWizard_MappingProblems(t *testing.T) {
+	h := newImportHandler(&importAdapter{})
+	form := url.Values{"csv": {"a,b\n1,2\n"}, "map": {"Name", "Name"}, "step": {"preview"}}
+	if body := postImportStep(h, form).Body.String(); !strings.Contains(body, "Name is mapped to more than one column") {
+		t.Errorf("Expected a mapping problem, got:\n%s", body)
+	}
+}
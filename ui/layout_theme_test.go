@@ -0,0 +1,65 @@
+package ui
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/preslavrachev/backoffice/core"
+	"github.com/preslavrachev/backoffice/middleware/auth"
+)
+
+func renderIndex(bo *core.BackOffice) string {
+	h := &BackOfficeHandler{bo: bo}
+	req := httptest.NewRequest(http.MethodGet, "/admin/", nil)
+	w := httptest.NewRecorder()
+	h.indexHandler(w, req)
+	return w.Body.String()
+}
+
+func TestLayout_Theme(t *testing.T) {
+	bo := core.New(&mockActionAdapter{}, auth.AuthConfig{})
+	bo.GetConfig().Title = "Acme Ops"
+	bo.SetTheme(core.Theme{
+		PrimaryColor: "#7c3aed",
+		LogoURL:      "/static/logo.svg",
+		FaviconURL:   "/static/favicon.ico",
+		CSSURLs:      []string{"/static/brand.css"},
+		JSURLs:       []string{"/static/brand.js"},
+		FooterText:   "© Acme Inc.",
+	})
+
+	body := renderIndex(bo)
+	for _, want := range []string{
+		`<title>Acme Ops - Acme Ops</title>`,
+		`<link rel="icon" href="/static/favicon.ico">`,
+		`&#34;600&#34;:&#34;#7c3aed&#34;`,
+		`<link rel="stylesheet" href="/static/brand.css">`,
+		`<script src="/static/brand.js"></script>`,
+		`<img src="/static/logo.svg" alt="Acme Ops"`,
+		`data-pw="admin-home-link">Acme Ops</a>`,
+		"© Acme Inc.",
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("Expected %s in the page", want)
+		}
+	}
+}
+
+func TestLayout_DefaultTheme(t *testing.T) {
+	body := renderIndex(core.New(&mockActionAdapter{}, auth.AuthConfig{}))
+	if strings.Contains(body, "data-tailwind-config") || strings.Contains(body, `data-pw="brand-footer"`) || strings.Contains(body, `data-pw="brand-logo"`) {
+		t.Error("Expected no branding without a theme")
+	}
+	if !strings.Contains(body, `data-pw="admin-home-link">BackOffice Admin</a>`) {
+		t.Error("Expected the default title in the header")
+	}
+}
+
+func TestPrimaryColorConfig_EscapesMarkup(t *testing.T) {
+	config := primaryColorConfig("red</script><script>alert(1)")
+	if strings.Contains(config, "</script>") {
+		t.Errorf("Expected the color to be escaped, got %s", config)
+	}
+}
@@ -8,7 +8,8 @@ import (
 	"github.com/preslavrachev/backoffice/core"
 )
 
-// TestListBooleanFieldIntegration tests that boolean fields in the list render using the Yes/No component
+// TestListBooleanFieldIntegration tests that boolean fields in read-only lists render using the Yes/No component
+// Editable lists render them as switches instead
 func TestListBooleanFieldIntegration(t *testing.T) {
 	// Test entity with boolean field
 	type TestEntity struct {
@@ -23,6 +24,7 @@ func TestListBooleanFieldIntegration(t *testing.T) {
 		DisplayName: "Test Entity",
 		PluralName:  "Test Entities",
 		IDField:     "ID",
+		ReadOnly:    true,
 		Fields: []core.FieldInfo{
 			{
 				Name:        "Name",
@@ -94,3 +96,45 @@ func renderYesNoComponent(value bool) string {
 
 	return sb.String()
 }
+
+// TestListBooleanFieldWithFormatter tests that a display formatter replaces the Yes/No badge
+func TestListBooleanFieldWithFormatter(t *testing.T) {
+	type TestEntity struct {
+		ID       uint `json:"id"`
+		IsActive bool `json:"is_active"`
+	}
+
+	resource := &core.Resource{
+		Name:        "TestEntity",
+		DisplayName: "Test Entity",
+		PluralName:  "Test Entities",
+		IDField:     "ID",
+		Fields: []core.FieldInfo{
+			{
+				Name:        "IsActive",
+				DisplayName: "Active",
+				Type:        "bool",
+				FormatFunc: func(value any) string {
+					if value.(bool) {
+						return "✓"
+					}
+					return "✗"
+				},
+			},
+		},
+	}
+
+	var sb strings.Builder
+	items := []interface{}{&TestEntity{ID: 1, IsActive: true}}
+	if err := List(resource, items, 1, "").Render(context.Background(), &sb); err != nil {
+		t.Fatalf("Failed to render List: %v", err)
+	}
+
+	listHTML := sb.String()
+	if !strings.Contains(listHTML, "✓") {
+		t.Errorf("Expected formatted value in list HTML, got:\n%s", listHTML)
+	}
+	if strings.Contains(listHTML, renderYesNoComponent(true)) {
+		t.Error("Expected formatter to replace the Yes/No badge")
+	}
+}
@@ -0,0 +1,39 @@
+package ui
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/preslavrachev/backoffice/core"
+	"github.com/preslavrachev/backoffice/middleware/auth"
+)
+
+type cardOrder struct {
+	ID       uint   `db:"id"`
+	Customer string `db:"customer"`
+}
+
+// TestListCards tests that list rows carry what they need to stack into labelled cards on small screens
+func TestListCards(t *testing.T) {
+	bo := core.New(&mockActionAdapter{}, auth.AuthConfig{})
+	bo.RegisterResource(&cardOrder{}).WithFields("Customer")
+	resource, _ := bo.GetResource("cardOrder")
+
+	var sb strings.Builder
+	items := []interface{}{&cardOrder{ID: 1, Customer: "Ada"}}
+	if err := List(resource, items, 1, "").Render(context.Background(), &sb); err != nil {
+		t.Fatalf("Failed to render List: %v", err)
+	}
+	html := sb.String()
+
+	if !strings.Contains(html, `<thead class="bg-gray-50 max-md:hidden">`) {
+		t.Error("Expected the table header to be hidden below the card breakpoint")
+	}
+	if !strings.Contains(html, cardRowClasses) {
+		t.Error("Expected rows to stack as cards below the card breakpoint")
+	}
+	if !strings.Contains(html, `data-label="Customer"`) {
+		t.Error("Expected field cells to carry their label for the card layout")
+	}
+}
@@ -0,0 +1,55 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/a-h/templ"
+	"github.com/preslavrachev/backoffice/core"
+	"github.com/preslavrachev/backoffice/middleware/auth"
+)
+
+type rendererUpload struct {
+	ID       uint `db:"id"`
+	Progress int  `db:"progress"`
+}
+
+// TestListCellRenderer tests that RenderWith replaces a cell's markup while the field stays sortable
+func TestListCellRenderer(t *testing.T) {
+	bo := core.New(&mockActionAdapter{}, auth.AuthConfig{})
+	bo.RegisterResource(&rendererUpload{}).
+		WithField("Progress", func(f *core.FieldBuilder) {
+			f.RenderWith(func(item any) templ.Component {
+				if item.(*rendererUpload).Progress == 0 {
+					return nil
+				}
+				return templ.ComponentFunc(func(ctx context.Context, w io.Writer) error {
+					_, err := fmt.Fprintf(w, `<div class="progress" style="width: %d%%"></div>`, item.(*rendererUpload).Progress)
+					return err
+				})
+			})
+		})
+	resource, _ := bo.GetResource("rendererUpload")
+
+	var sb strings.Builder
+	items := []interface{}{&rendererUpload{ID: 1, Progress: 40}, &rendererUpload{ID: 2}}
+	if err := List(resource, items, 2, "").Render(context.Background(), &sb); err != nil {
+		t.Fatalf("Failed to render List: %v", err)
+	}
+	list := sb.String()
+	if !strings.Contains(list, `<div class="progress" style="width: 40%"></div>`) {
+		t.Errorf("Expected the custom progress bar, got:\n%s", list)
+	}
+
+	field, _ := resource.GetField("Progress")
+	sb.Reset()
+	if err := listCellValue(resource, items[1], *field).Render(context.Background(), &sb); err != nil || sb.Len() != 0 {
+		t.Errorf("Expected an empty cell when the renderer returns nil, got %q", sb.String())
+	}
+	if !resource.IsFieldSortable("Progress") {
+		t.Error("Expected the field to stay sortable by its value")
+	}
+}
@@ -0,0 +1,94 @@
+package ui
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/preslavrachev/backoffice/core"
+	"github.com/preslavrachev/backoffice/middleware/auth"
+)
+
+type columnsWidget struct {
+	ID    uint    `db:"id"`
+	Name  string  `db:"name"`
+	SKU   string  `db:"sku"`
+	Price float64 `db:"price"`
+}
+
+// columnsAdapter lists a single widget
+type columnsAdapter struct {
+	mockActionAdapter
+}
+
+func (a *columnsAdapter) Find(ctx context.Context, resource *core.Resource, query *core.Query) (*core.Result, error) {
+	return &core.Result{Items: []any{&columnsWidget{ID: 1, Name: "Gear", SKU: "G-1", Price: 9.5}}, TotalCount: 1}, nil
+}
+
+func newColumnsHandler() *BackOfficeHandler {
+	bo := core.New(&columnsAdapter{}, auth.AuthConfig{})
+	bo.RegisterResource(&columnsWidget{}).WithFields("Name", "SKU", "Price")
+	return &BackOfficeHandler{bo: bo}
+}
+
+func TestListColumns_SaveChoice(t *testing.T) {
+	h := newColumnsHandler()
+
+	form := url.Values{"columns": {"Price", "Bogus", "Name", "Price"}}
+	req := httptest.NewRequest(http.MethodPost, "/admin/api/columnsWidget/columns", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	w := httptest.NewRecorder()
+	h.apiRouter(w, req)
+
+	if w.Code != http.StatusOK || w.Header().Get("HX-Refresh") != "true" {
+		t.Fatalf("Expected a refresh, got %d: %s", w.Code, w.Body.String())
+	}
+	cookies := w.Result().Cookies()
+	if len(cookies) != 1 || cookies[0].Name != "backoffice_columns_columnsWidget" || cookies[0].Value != "Price.Name" {
+		t.Fatalf("Expected the valid columns in a cookie, got %+v", cookies)
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/admin/columnsWidget", nil)
+	req.AddCookie(cookies[0])
+	w = httptest.NewRecorder()
+	h.indexHandler(w, req)
+
+	body := w.Body.String()
+	header := body[strings.Index(body, `data-pw="table-header-row"`):]
+	price := strings.Index(header, ">Price</span>")
+	name := strings.Index(header, ">Name</span>")
+	if price < 0 || name < 0 || price > name {
+		t.Errorf("Expected the Price column before the Name column")
+	}
+	if strings.Contains(header, ">SKU</span>") || strings.Contains(body, "G-1") {
+		t.Error("Expected the SKU column to be hidden")
+	}
+	if !strings.Contains(body, `{&#34;name&#34;:&#34;SKU&#34;,&#34;label&#34;:&#34;SKU&#34;,&#34;visible&#34;:false}`) {
+		t.Error("Expected the hidden column to be offered in the columns menu")
+	}
+}
+
+func TestListColumns_Reset(t *testing.T) {
+	h := newColumnsHandler()
+
+	req := httptest.NewRequest(http.MethodPost, "/admin/api/columnsWidget/columns", strings.NewReader("reset=true"))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	w := httptest.NewRecorder()
+	h.apiRouter(w, req)
+
+	cookies := w.Result().Cookies()
+	if len(cookies) != 1 || cookies[0].MaxAge >= 0 {
+		t.Errorf("Expected the columns cookie to be deleted, got %+v", cookies)
+	}
+
+	req = httptest.NewRequest(http.MethodPost, "/admin/api/columnsWidget/columns", strings.NewReader("columns=Bogus"))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	w = httptest.NewRecorder()
+	h.apiRouter(w, req)
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status 400 without valid columns, got %d", w.Code)
+	}
+}
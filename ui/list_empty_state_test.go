@@ -0,0 +1,59 @@
+package ui
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/preslavrachev/backoffice/core"
+	"github.com/preslavrachev/backoffice/middleware/auth"
+)
+
+type emptyStateProduct struct {
+	ID   uint   `db:"id"`
+	Name string `db:"name"`
+}
+
+// TestListEmptyState tests that an empty list shows the configured empty state unless it is filtered
+func TestListEmptyState(t *testing.T) {
+	bo := core.New(&mockActionAdapter{}, auth.AuthConfig{})
+	bo.RegisterResource(&emptyStateProduct{}).
+		WithFields("Name").
+		WithEmptyState(core.EmptyState{
+			Title:        "No products yet",
+			Message:      "Import your catalog to get started.",
+			Illustration: "/static/empty-products.svg",
+			ActionLabel:  "Import products",
+			ActionURL:    "/admin/import/products",
+		})
+	resource, _ := bo.GetResource("emptyStateProduct")
+
+	render := func(ctx context.Context) string {
+		var sb strings.Builder
+		if err := List(resource, nil, 0, "").Render(ctx, &sb); err != nil {
+			t.Fatalf("Failed to render List: %v", err)
+		}
+		return sb.String()
+	}
+
+	html := render(context.Background())
+	for _, want := range []string{
+		"No products yet",
+		"Import your catalog to get started.",
+		`src="/static/empty-products.svg"`,
+		`href="/admin/import/products"`,
+		"Import products",
+	} {
+		if !strings.Contains(html, want) {
+			t.Errorf("Expected the empty state to contain %q", want)
+		}
+	}
+
+	filtered := render(context.WithValue(context.Background(), "filteredList", true))
+	if strings.Contains(filtered, "No products yet") {
+		t.Error("Expected a filtered list to keep the default empty message")
+	}
+	if !strings.Contains(filtered, `data-pw="empty-state"`) {
+		t.Error("Expected a filtered list to still render an empty message")
+	}
+}
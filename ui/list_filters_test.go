@@ -0,0 +1,93 @@
+package ui
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/preslavrachev/backoffice/core"
+	"github.com/preslavrachev/backoffice/middleware/auth"
+)
+
+type filterTeam struct {
+	ID   uint   `db:"id"`
+	Name string `db:"name"`
+}
+
+type filterMember struct {
+	ID         uint        `db:"id"`
+	Name       string      `db:"name"`
+	Age        int         `db:"age"`
+	Active     bool        `db:"active"`
+	JoinedAt   time.Time   `db:"joined_at"`
+	TeamID     uint        `db:"team_id"`
+	FilterTeam *filterTeam `db:"-"`
+}
+
+// filterAdapter records list queries and returns the teams for relation options
+type filterAdapter struct {
+	mockActionAdapter
+	memberQuery *core.Query
+}
+
+func (a *filterAdapter) Find(ctx context.Context, resource *core.Resource, query *core.Query) (*core.Result, error) {
+	if resource.Name == "filterTeam" {
+		return &core.Result{Items: []any{&filterTeam{ID: 7, Name: "Platform"}}, TotalCount: 1}, nil
+	}
+	a.memberQuery = query
+	return &core.Result{Items: []any{&filterMember{ID: 1, Name: "Ann"}}, TotalCount: 1}, nil
+}
+
+func TestListFilterPanel(t *testing.T) {
+	adapter := &filterAdapter{}
+	bo := core.New(adapter, auth.AuthConfig{})
+	bo.RegisterResource(&filterTeam{}).WithFields("Name")
+	bo.RegisterResource(&filterMember{}).
+		WithFields("Name", "Age", "Active", "JoinedAt", "TeamID").
+		WithManyToOneField("FilterTeam", "filterTeam", func(r *core.RelationshipBuilder) {
+			r.ForeignKey("TeamID")
+		})
+	h := &BackOfficeHandler{bo: bo}
+
+	req := httptest.NewRequest(http.MethodGet, "/admin/filterMember?Age__gte=30&Age__lte=oops&Active=true&TeamID=7&password_hash=x&secret__gte=1&sort=Name", nil)
+	w := httptest.NewRecorder()
+	h.indexHandler(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	query := adapter.memberQuery
+	if len(query.Conditions) != 1 || query.Conditions[0] != (core.FilterCondition{Field: "Age", Operator: core.FilterGte, Value: int64(30)}) {
+		t.Errorf("Expected only the valid Age condition, got %+v", query.Conditions)
+	}
+	if query.Filters["Active"] != true {
+		t.Errorf("Expected a typed bool filter, got %#v", query.Filters["Active"])
+	}
+	if query.Filters["TeamID"] != uint64(7) {
+		t.Errorf("Expected a typed foreign key filter, got %#v", query.Filters["TeamID"])
+	}
+	if _, ok := query.Filters["password_hash"]; ok || len(query.Filters) != 2 {
+		t.Errorf("Expected names the resource can't be filtered by to be dropped, got %#v", query.Filters)
+	}
+
+	body := w.Body.String()
+	for _, want := range []string{
+		`data-pw="filter-panel"`,
+		`name="Age__gte" value="30"`,
+		`type="date" name="JoinedAt__gte"`,
+		`<option value="7" selected>Platform</option>`,
+		`name="Active" value="true" class="sr-only" checked`,
+		`<input type="hidden" name="sort" value="Name">`,
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("Expected %s in the list page", want)
+		}
+	}
+	if strings.Contains(body, `data-pw="filter-TeamID"`) {
+		t.Error("Expected the foreign key to be filtered through its relationship select")
+	}
+}
@@ -0,0 +1,115 @@
+package ui
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/preslavrachev/backoffice/core"
+	"github.com/preslavrachev/backoffice/middleware/auth"
+)
+
+type pagedOrder struct {
+	ID     uint   `db:"id"`
+	Status string `db:"status"`
+}
+
+// pagedAdapter pretends to hold 95 orders, returning one of them per page
+type pagedAdapter struct {
+	mockActionAdapter
+}
+
+func (a *pagedAdapter) Find(ctx context.Context, resource *core.Resource, query *core.Query) (*core.Result, error) {
+	return &core.Result{
+		Items:      []any{&pagedOrder{ID: uint(query.Pagination.Offset + 1), Status: "open"}},
+		TotalCount: 95,
+		HasMore:    query.Pagination.Offset+query.Pagination.Limit < 95,
+		Query:      *query,
+	}, nil
+}
+
+func TestNumberedPagination(t *testing.T) {
+	bo := core.New(&pagedAdapter{}, auth.AuthConfig{})
+	bo.RegisterResource(&pagedOrder{}).
+		WithFields("Status").
+		WithPageSize(10).
+		WithPaginationStyle(core.PaginationNumbered)
+	h := &BackOfficeHandler{bo: bo}
+
+	req := httptest.NewRequest(http.MethodGet, "/admin/pagedOrder?Status=open&offset=40&limit=10", nil)
+	w := httptest.NewRecorder()
+	h.indexHandler(w, req)
+
+	body := w.Body.String()
+	if strings.Contains(body, `data-pw="load-more-button"`) {
+		t.Error("Expected no Load More button with numbered pagination")
+	}
+	for _, want := range []string{
+		"Showing 41–50 of 95 · Page 5 of 10",
+		`<span class="px-3 py-1 rounded bg-blue-600 text-white" aria-current="page" data-pw="pagination-current">5</span>`,
+		`href="/admin/pagedOrder?Status=open&amp;limit=10&amp;offset=30" class="px-3 py-1 rounded text-gray-700 hover:bg-gray-100" data-pw="pagination-prev"`,
+		`href="/admin/pagedOrder?Status=open&amp;limit=10&amp;offset=90" class="px-3 py-1 rounded text-gray-700 hover:bg-gray-100" data-pw="pagination-last"`,
+		`data-pw="pagination-page-3"`,
+		`data-pw="pagination-page-7"`,
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("Expected %s in the list page", want)
+		}
+	}
+	if strings.Contains(body, `data-pw="pagination-page-8"`) {
+		t.Error("Expected pages far from the current one to be left out")
+	}
+}
+
+func TestNumberedPagination_FirstPage(t *testing.T) {
+	bo := core.New(&pagedAdapter{}, auth.AuthConfig{})
+	bo.RegisterResource(&pagedOrder{}).WithFields("Status").WithPaginationStyle(core.PaginationNumbered)
+	h := &BackOfficeHandler{bo: bo}
+
+	req := httptest.NewRequest(http.MethodGet, "/admin/pagedOrder", nil)
+	w := httptest.NewRecorder()
+	h.indexHandler(w, req)
+
+	body := w.Body.String()
+	if !strings.Contains(body, `<span class="px-3 py-1 rounded text-gray-300 cursor-default" data-pw="pagination-prev">Prev</span>`) {
+		t.Error("Expected Prev to be disabled on the first page")
+	}
+	if !strings.Contains(body, `data-pw="pagination-next"`) {
+		t.Error("Expected a Next link on the first page")
+	}
+}
+
+func TestInfiniteScroll(t *testing.T) {
+	bo := core.New(&pagedAdapter{}, auth.AuthConfig{})
+	bo.RegisterResource(&pagedOrder{}).
+		WithFields("Status").
+		WithPageSize(10).
+		WithPaginationStyle(core.PaginationInfinite)
+	h := &BackOfficeHandler{bo: bo}
+
+	req := httptest.NewRequest(http.MethodGet, "/admin/pagedOrder", nil)
+	w := httptest.NewRecorder()
+	h.indexHandler(w, req)
+
+	body := w.Body.String()
+	if strings.Contains(body, `data-pw="load-more-button"`) {
+		t.Error("Expected no Load More button with infinite scroll")
+	}
+	if !strings.Contains(body, `hx-get="/admin/pagedOrder?limit=10&amp;load_more=true&amp;offset=10" hx-trigger="intersect once"`) {
+		t.Errorf("Expected a sentinel row loading the next page when revealed, got:\n%s", body)
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/admin/pagedOrder?limit=10&load_more=true&offset=10", nil)
+	w = httptest.NewRecorder()
+	h.indexHandler(w, req)
+
+	body = w.Body.String()
+	if !strings.Contains(body, `data-pw="resource-row"`) {
+		t.Error("Expected the next rows")
+	}
+	if !strings.Contains(body, `offset=20" hx-trigger="intersect once"`) || !strings.Contains(body, "(84 remaining)") {
+		t.Errorf("Expected a new sentinel row for the page after, got:\n%s", body)
+	}
+}
@@ -0,0 +1,95 @@
+package ui
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/preslavrachev/backoffice/core"
+	"github.com/preslavrachev/backoffice/middleware/auth"
+)
+
+type rankedTopic struct {
+	ID        uint   `db:"id"`
+	Name      string `db:"name"`
+	SortOrder int    `db:"sort_order"`
+}
+
+// rankedTopicAdapter lists a fixed set of topics and records updates
+type rankedTopicAdapter struct {
+	mockActionAdapter
+	topics  map[uint]*rankedTopic
+	updated map[uint]int
+}
+
+func (a *rankedTopicAdapter) Find(ctx context.Context, resource *core.Resource, query *core.Query) (*core.Result, error) {
+	return &core.Result{Items: []any{a.topics[1], a.topics[2]}, TotalCount: 2}, nil
+}
+
+func (a *rankedTopicAdapter) GetByID(ctx context.Context, resource *core.Resource, id any) (any, error) {
+	topic := *a.topics[id.(uint)]
+	return &topic, nil
+}
+
+func (a *rankedTopicAdapter) Update(ctx context.Context, resource *core.Resource, id any, data any) error {
+	a.updated[id.(uint)] = data.(*rankedTopic).SortOrder
+	return nil
+}
+
+func newReorderHandler() (*BackOfficeHandler, *rankedTopicAdapter) {
+	adapter := &rankedTopicAdapter{
+		topics: map[uint]*rankedTopic{
+			1: {ID: 1, Name: "Intro", SortOrder: 1},
+			2: {ID: 2, Name: "Setup", SortOrder: 2},
+		},
+		updated: map[uint]int{},
+	}
+	bo := core.New(adapter, auth.AuthConfig{})
+	bo.RegisterResource(&rankedTopic{}).WithFields("Name", "SortOrder").WithOrderField("SortOrder")
+	return &BackOfficeHandler{bo: bo}, adapter
+}
+
+func TestReorder_DragHandles(t *testing.T) {
+	h, _ := newReorderHandler()
+
+	req := httptest.NewRequest(http.MethodGet, "/admin/rankedTopic", nil)
+	w := httptest.NewRecorder()
+	h.indexHandler(w, req)
+	body := w.Body.String()
+	if strings.Count(body, `data-pw="drag-handle"`) != 2 {
+		t.Error("Expected a drag handle on each row when sorted by the order field")
+	}
+	if !strings.Contains(body, `hx-post="/admin/api/rankedTopic/reorder"`) {
+		t.Error("Expected the reorder form to post to the reorder endpoint")
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/admin/rankedTopic?sort=Name&direction=asc", nil)
+	w = httptest.NewRecorder()
+	h.indexHandler(w, req)
+	if strings.Contains(w.Body.String(), `data-pw="drag-handle"`) {
+		t.Error("Expected no drag handles while sorted by another field")
+	}
+}
+
+func TestReorder_Endpoint(t *testing.T) {
+	h, adapter := newReorderHandler()
+
+	form := url.Values{"order": {"2", "1"}}
+	req := httptest.NewRequest(http.MethodPost, "/admin/api/rankedTopic/reorder", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	w := httptest.NewRecorder()
+	h.apiRouter(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if adapter.updated[2] != 1 || adapter.updated[1] != 2 {
+		t.Errorf("Expected the topics to swap positions, got %v", adapter.updated)
+	}
+	if !strings.Contains(w.Header().Get("HX-Trigger"), "Order saved") {
+		t.Errorf("Expected a success toast, got %q", w.Header().Get("HX-Trigger"))
+	}
+}
@@ -0,0 +1,38 @@
+package ui
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/preslavrachev/backoffice/core"
+	"github.com/preslavrachev/backoffice/middleware/auth"
+)
+
+type rowClassTrial struct {
+	ID      uint `db:"id"`
+	Expired bool `db:"expired"`
+}
+
+// TestListRowClass tests that rows are tinted by the classes WithRowClass returns for their record
+func TestListRowClass(t *testing.T) {
+	bo := core.New(&mockActionAdapter{}, auth.AuthConfig{})
+	bo.RegisterResource(&rowClassTrial{}).
+		WithFields("Expired").
+		WithRowClass(func(item any) string {
+			if item.(*rowClassTrial).Expired {
+				return "bg-red-50"
+			}
+			return ""
+		})
+	resource, _ := bo.GetResource("rowClassTrial")
+
+	var sb strings.Builder
+	items := []interface{}{&rowClassTrial{ID: 1, Expired: true}, &rowClassTrial{ID: 2}}
+	if err := List(resource, items, 2, "").Render(context.Background(), &sb); err != nil {
+		t.Fatalf("Failed to render List: %v", err)
+	}
+	if got := strings.Count(sb.String(), "ease-in-out group bg-red-50"); got != 1 {
+		t.Errorf("Expected only the expired trial's row to be tinted, got %d tinted rows", got)
+	}
+}
@@ -0,0 +1,70 @@
+package ui
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/preslavrachev/backoffice/core"
+	"github.com/preslavrachev/backoffice/middleware/auth"
+)
+
+type searchArticle struct {
+	ID     uint   `db:"id"`
+	Title  string `db:"title"`
+	Status string `db:"status"`
+}
+
+// searchAdapter records the last query it was asked to find
+type searchAdapter struct {
+	mockActionAdapter
+	lastQuery *core.Query
+}
+
+func (a *searchAdapter) Find(ctx context.Context, resource *core.Resource, query *core.Query) (*core.Result, error) {
+	a.lastQuery = query
+	return &core.Result{Items: []any{&searchArticle{ID: 1, Title: "Hello"}}, TotalCount: 1}, nil
+}
+
+func TestListSearch(t *testing.T) {
+	adapter := &searchAdapter{}
+	bo := core.New(adapter, auth.AuthConfig{})
+	bo.RegisterResource(&searchArticle{}).
+		WithField("Title", func(f *core.FieldBuilder) {
+			f.Searchable(true)
+		}).
+		WithFields("Status")
+	h := &BackOfficeHandler{bo: bo}
+
+	req := httptest.NewRequest(http.MethodGet, "/admin/searchArticle?q=+hello+&Status=draft&sort=Title&offset=20", nil)
+	w := httptest.NewRecorder()
+	h.indexHandler(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if adapter.lastQuery.Search != "hello" {
+		t.Errorf("Expected the trimmed search term, got %q", adapter.lastQuery.Search)
+	}
+	if _, ok := adapter.lastQuery.Filters["q"]; ok {
+		t.Error("Expected q not to be treated as a field filter")
+	}
+
+	body := w.Body.String()
+	for _, want := range []string{
+		`data-pw="list-search-input"`,
+		`value=" hello "`,
+		`placeholder="Search by Title"`,
+		`<input type="hidden" name="Status" value="draft">`,
+		`<input type="hidden" name="sort" value="Title">`,
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("Expected %s in the list page", want)
+		}
+	}
+	if strings.Contains(body, `name="offset"`) {
+		t.Error("Expected a new search to restart pagination")
+	}
+}
@@ -0,0 +1,92 @@
+package ui
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/preslavrachev/backoffice/core"
+	"github.com/preslavrachev/backoffice/middleware/auth"
+)
+
+type statusSubscription struct {
+	ID       uint   `db:"id"`
+	Plan     string `db:"plan"`
+	Canceled bool   `db:"canceled"`
+}
+
+func subscriptionStatus(item any) (string, string) {
+	if item.(*statusSubscription).Canceled {
+		return "Canceled", core.StatusRed
+	}
+	return "Active", core.StatusGreen
+}
+
+// TestListStatusField tests that status fields render as colored pills in lists and detail pages
+func TestListStatusField(t *testing.T) {
+	bo := core.New(&mockActionAdapter{}, auth.AuthConfig{})
+	bo.RegisterResource(&statusSubscription{}).
+		WithFields("Plan").
+		WithStatusField("Status", subscriptionStatus)
+	resource, _ := bo.GetResource("statusSubscription")
+
+	var sb strings.Builder
+	items := []interface{}{&statusSubscription{ID: 1, Plan: "pro", Canceled: true}}
+	if err := List(resource, items, 1, "").Render(context.Background(), &sb); err != nil {
+		t.Fatalf("Failed to render List: %v", err)
+	}
+
+	listHTML := sb.String()
+	if !strings.Contains(listHTML, "bg-red-100 text-red-800") || !strings.Contains(listHTML, "Canceled") {
+		t.Errorf("Expected a red Canceled pill in list HTML, got:\n%s", listHTML)
+	}
+
+	field, _ := resource.GetField("Status")
+	if !field.ReadOnly || !field.IsComputed {
+		t.Error("Expected the status field to be a read-only computed field")
+	}
+	if got := core.FormatFieldValueForDisplayCtx(context.Background(), items[0], field); got != "Canceled" {
+		t.Errorf("Expected the label as the text value, got %q", got)
+	}
+
+	sb.Reset()
+	if err := StatusBadge(field, &statusSubscription{ID: 2}).Render(context.Background(), &sb); err != nil {
+		t.Fatalf("Failed to render StatusBadge: %v", err)
+	}
+	if !strings.Contains(sb.String(), "bg-green-100 text-green-800") {
+		t.Errorf("Expected a green pill, got:\n%s", sb.String())
+	}
+}
+
+// TestChoiceColors tests that choices with colors render as the same colored badges in lists, detail pages and filters
+func TestChoiceColors(t *testing.T) {
+	subscription := &statusSubscription{ID: 1, Plan: "pro"}
+	adapter := &mockActionAdapter{getByIDFunc: func(ctx context.Context, resource *core.Resource, id any) (any, error) {
+		return subscription, nil
+	}}
+	bo := core.New(adapter, auth.AuthConfig{})
+	bo.RegisterResource(&statusSubscription{}).
+		WithField("Plan", func(f *core.FieldBuilder) {
+			f.Choices([]string{"free", "pro"}).ChoiceColors(map[string]string{"pro": core.StatusPurple})
+		})
+	resource, _ := bo.GetResource("statusSubscription")
+
+	var sb strings.Builder
+	if err := List(resource, []interface{}{subscription}, 1, "").Render(context.Background(), &sb); err != nil {
+		t.Fatalf("Failed to render List: %v", err)
+	}
+	badge := `<span class="inline-flex items-center px-2.5 py-0.5 rounded-full text-xs font-medium bg-purple-100 text-purple-800" data-pw="status-Plan">pro</span>`
+	if !strings.Contains(sb.String(), badge) {
+		t.Errorf("Expected a purple pro badge in the list, got:\n%s", sb.String())
+	}
+	if !strings.Contains(sb.String(), `bg-purple-100 text-purple-800"><input type="radio" name="Plan" value="pro"`) {
+		t.Error("Expected the filter to offer pro as a purple badge")
+	}
+	if !strings.Contains(sb.String(), `bg-gray-100 text-gray-800"><input type="radio" name="Plan" value="free"`) {
+		t.Error("Expected choices without a color to fall back to gray")
+	}
+
+	if detail := getPage(Handler(bo, "/admin"), "/admin/statusSubscription/1"); !strings.Contains(detail, badge) {
+		t.Errorf("Expected a purple pro badge on the detail page, got:\n%s", detail)
+	}
+}
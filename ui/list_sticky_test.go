@@ -0,0 +1,53 @@
+package ui
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/preslavrachev/backoffice/core"
+	"github.com/preslavrachev/backoffice/middleware/auth"
+)
+
+type wideShipment struct {
+	ID          uint   `db:"id"`
+	Reference   string `db:"reference"`
+	Origin      string `db:"origin"`
+	Destination string `db:"destination"`
+}
+
+func TestList_StickyHeaderAndFrozenColumn(t *testing.T) {
+	bo := core.New(&mockActionAdapter{}, auth.AuthConfig{})
+	bo.RegisterResource(&wideShipment{}).
+		WithFields("Reference", "Origin", "Destination").
+		WithFrozenFirstColumn()
+	resource, _ := bo.GetResource("wideShipment")
+	items := []any{&wideShipment{ID: 1, Reference: "SHP-1", Origin: "Sofia", Destination: "Berlin"}}
+
+	var sb strings.Builder
+	if err := List(resource, items, 1, "").Render(context.Background(), &sb); err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+	html := sb.String()
+
+	if !strings.Contains(html, `data-pw="table-scroll"`) {
+		t.Error("Expected the table to scroll inside its own box")
+	}
+	if strings.Count(html, "sticky top-0") != len(resource.Fields)+2 {
+		t.Errorf("Expected every header cell to be sticky, got %d", strings.Count(html, "sticky top-0"))
+	}
+	// The selection column comes first, so the identifier column is pinned right after it
+	if !strings.Contains(html, `class="px-6 py-3 text-sm align-top sticky left-12 shadow-[inset_-1px_0_0_#e5e7eb] bg-white group-hover:bg-gray-50 `) {
+		t.Errorf("Expected the first field column to be frozen, got:\n%s", html)
+	}
+	if strings.Count(html, "left-12") != 2 {
+		t.Errorf("Expected only the first column to be frozen, got %d frozen cells", strings.Count(html, "left-12"))
+	}
+
+	resource.FreezeFirstColumn = false
+	sb.Reset()
+	List(resource, items, 1, "").Render(context.Background(), &sb)
+	if strings.Contains(sb.String(), "left-") {
+		t.Error("Expected no frozen cells by default")
+	}
+}
@@ -0,0 +1,83 @@
+package ui
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/preslavrachev/backoffice/core"
+)
+
+// handleUnmask reveals the value of a masked field to users granted core.OperationUnmask, auditing it
+func (h *BackOfficeHandler) handleUnmask(w http.ResponseWriter, r *http.Request, resource *core.Resource, idStr, fieldName string) {
+	ctx := r.Context()
+	adapter := h.bo.GetAdapter()
+
+	field, ok := resource.GetField(fieldName)
+	if !ok || !field.IsMasked(ctx) {
+		h.writeHTTPErrorWithToast(w, msg(r.Context(), "error.not_masked", fieldName), http.StatusNotFound, "error")
+		return
+	}
+	id, err := resource.ParseID(idStr)
+	if err != nil {
+		h.writeHTTPErrorWithToast(w, msg(r.Context(), "error.invalid_id"), http.StatusBadRequest, "error")
+		return
+	}
+	if !resource.CanUnmask(ctx) {
+		h.writeHTTPErrorWithToast(w, msg(ctx, "mask.denied"), http.StatusForbidden, "error")
+		return
+	}
+	if err := resource.Authorize(ctx, core.OperationUnmask, id); err != nil {
+		h.writeHTTPErrorWithToast(w, err.Error(), http.StatusForbidden, "error")
+		return
+	}
+	if err := core.CheckScope(ctx, adapter, resource, id); err != nil {
+		h.writeHTTPErrorWithToast(w, msg(r.Context(), "error.not_found", resource.DisplayName), http.StatusNotFound, "error")
+		return
+	}
+	item, err := adapter.GetByID(ctx, resource, id)
+	if err != nil {
+		h.writeHTTPErrorWithToast(w, msg(r.Context(), "error.not_found", resource.DisplayName), http.StatusNotFound, "error")
+		return
+	}
+
+	resource.RecordUnmask(ctx, resource.RecordID(item), field)
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	UnmaskedValue(*field, item).Render(ctx, w)
+}
+
+// hidesMaskedValue reports whether forms must leave out the field's value: it's masked for the request's user,
+// who may not reveal it
+func hidesMaskedValue(ctx context.Context, resource *core.Resource, field core.FieldInfo) bool {
+	return field.IsMasked(ctx) && !resource.CanUnmask(ctx)
+}
+
+// maskedFormField returns the field and value a form shows: masked values the user may not reveal are left out,
+// their redacted text becoming the input's placeholder. Edit forms saved with the input blank keep the value
+func maskedFormField(ctx context.Context, resource *core.Resource, field core.FieldInfo, item any, value string) (core.FieldInfo, string) {
+	if item == nil || !hidesMaskedValue(ctx, resource, field) {
+		return field, value
+	}
+	field.Placeholder = field.MaskedText(item)
+	return field, ""
+}
+
+// keepMaskedValues fills the masked fields an edit form left blank, because the user may not reveal them,
+// with their stored values, so saving the form doesn't clear them
+func (h *BackOfficeHandler) keepMaskedValues(r *http.Request, resource *core.Resource, id any) error {
+	ctx := r.Context()
+	var item any
+	for _, field := range resource.Fields {
+		if !hidesMaskedValue(ctx, resource, field) || !isFormEditable(field) || r.Form.Get(field.Name) != "" {
+			continue
+		}
+		if item == nil {
+			stored, err := h.bo.GetAdapter().GetByID(ctx, resource, id)
+			if err != nil {
+				return err
+			}
+			item = stored
+		}
+		r.Form.Set(field.Name, formValue(core.GetFieldValue(item, field.Name)))
+	}
+	return nil
+}
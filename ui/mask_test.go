@@ -0,0 +1,115 @@
+package ui
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/a-h/templ"
+	"github.com/preslavrachev/backoffice/core"
+	"github.com/preslavrachev/backoffice/middleware/auth"
+)
+
+func TestMaskFor_Handlers(t *testing.T) {
+	var events []core.UnmaskEvent
+	bo := core.New(&overrideAdapter{}, auth.AuthConfig{})
+	bo.RegisterResource(&overrideNote{}).
+		WithField("Title", func(f *core.FieldBuilder) { f.MaskFor() })
+	bo.SetUnmaskAudit(core.UnmaskAuditFunc(func(ctx context.Context, event core.UnmaskEvent) error {
+		events = append(events, event)
+		return nil
+	}))
+	h := Handler(bo, "/admin")
+
+	for _, path := range []string{"/admin/overrideNote", "/admin/overrideNote/1"} {
+		body := getPage(h, path)
+		if strings.Contains(body, "Groceries") || !strings.Contains(body, "G***") {
+			t.Errorf("Expected %s to show the title masked", path)
+		}
+		if !strings.Contains(body, `data-pw="unmask-Title"`) {
+			t.Errorf("Expected %s to offer revealing the title", path)
+		}
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/admin/api/overrideNote/1/unmask/Title", nil)
+	withCSRFToken(req)
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+	if w.Code != http.StatusOK || !strings.Contains(w.Body.String(), "Groceries") {
+		t.Fatalf("Expected the title to be revealed, got %d: %s", w.Code, w.Body.String())
+	}
+	if len(events) != 1 || events[0].Resource != "overrideNote" || events[0].RecordID != "1" || events[0].Field != "Title" {
+		t.Errorf("Expected the reveal to be audited, got %v", events)
+	}
+
+	req = httptest.NewRequest(http.MethodPost, "/admin/api/overrideNote/1/unmask/ID", nil)
+	withCSRFToken(req)
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+	if w.Code != http.StatusNotFound {
+		t.Errorf("Expected revealing an unmasked field to be rejected, got %d", w.Code)
+	}
+}
+
+// maskedNoteAdapter serves the note and keeps the last update
+type maskedNoteAdapter struct {
+	overrideAdapter
+	updated *overrideNote
+}
+
+func (a *maskedNoteAdapter) Update(ctx context.Context, resource *core.Resource, id any, data any) error {
+	a.updated = data.(*overrideNote)
+	return nil
+}
+
+func TestMaskFor_HistoryAndForms(t *testing.T) {
+	history := core.NewMemoryHistoryStore()
+	if err := history.SaveVersion(context.Background(), &core.RecordVersion{
+		Resource: "overrideNote",
+		RecordID: "1",
+		Snapshot: map[string]any{"Title": "Shopping"},
+	}); err != nil {
+		t.Fatalf("Failed to save version: %v", err)
+	}
+	adapter := &maskedNoteAdapter{}
+	bo := core.New(adapter, auth.AuthConfig{Enabled: true})
+	bo.RegisterResource(&overrideNote{}).
+		WithField("Title", func(f *core.FieldBuilder) { f.MaskFor() }).
+		WithHistory(history)
+	resource, _ := bo.GetResource("overrideNote")
+	ctx := auth.WithAuthUser(context.Background(), &auth.AuthUser{Username: "agent"})
+	note := &overrideNote{ID: 1, Title: "Groceries"}
+
+	var page strings.Builder
+	if err := History(resource, note, []core.RecordVersion{{ID: 1, Snapshot: map[string]any{"Title": "Shopping"}}}).Render(ctx, &page); err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(page.String(), "Shopping") || !strings.Contains(page.String(), "S***") {
+		t.Error("Expected the history snapshot to show the title masked")
+	}
+
+	for name, form := range map[string]templ.Component{
+		"edit":      FormFields(resource, note, true),
+		"duplicate": SidePaneFormFields(resource, core.DuplicateRecord(resource, note), false),
+	} {
+		var body strings.Builder
+		if err := form.Render(ctx, &body); err != nil {
+			t.Fatal(err)
+		}
+		if strings.Contains(body.String(), "Groceries") || !strings.Contains(body.String(), `placeholder="G***"`) {
+			t.Errorf("Expected the %s form to leave the masked title out, got %s", name, body.String())
+		}
+	}
+
+	// Saving the edit form with the masked input left blank keeps the stored title
+	req := httptest.NewRequest(http.MethodPost, "/admin/api/overrideNote/1", strings.NewReader("Title="))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req = req.WithContext(ctx)
+	w := httptest.NewRecorder()
+	(&BackOfficeHandler{bo: bo}).apiRouter(w, req)
+	if adapter.updated == nil || adapter.updated.Title != "Groceries" {
+		t.Errorf("Expected the stored title to be kept, got %d %+v", w.Code, adapter.updated)
+	}
+}
@@ -0,0 +1,21 @@
+package ui
+
+import (
+	"context"
+
+	"github.com/preslavrachev/backoffice/core"
+)
+
+// msg translates a message key into the locale negotiated for the request
+func msg(ctx context.Context, key string, args ...any) string {
+	locale, _ := ctx.Value("locale").(string)
+	return core.Translate(locale, key, args...)
+}
+
+// pageLanguage returns the lang attribute of pages rendered for the request
+func pageLanguage(ctx context.Context) string {
+	if locale, _ := ctx.Value("locale").(string); locale != "" {
+		return locale
+	}
+	return core.DefaultLocale
+}
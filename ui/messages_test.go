@@ -0,0 +1,68 @@
+package ui
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/preslavrachev/backoffice/core"
+)
+
+// TestMessagesNegotiated tests that pages are rendered in the language the browser asks for
+func TestMessagesNegotiated(t *testing.T) {
+	core.RegisterMessages("ui-test", core.Messages{"list.add_new": "Nouveau %s"})
+	h := newOverrideHandler()
+
+	req := httptest.NewRequest(http.MethodGet, "/admin/overrideNote", nil)
+	req.Header.Set("Accept-Language", "ui-test;q=0.8, en;q=0.5")
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+	if body := w.Body.String(); !strings.Contains(body, "Nouveau override Note") {
+		t.Error("Expected the list to use the negotiated locale's messages")
+	} else if !strings.Contains(body, `<html lang="ui-test">`) {
+		t.Error("Expected the page language to follow the negotiated locale")
+	}
+
+	if body := getPage(h, "/admin/overrideNote"); !strings.Contains(body, "Add New override Note") {
+		t.Error("Expected requests without Accept-Language to use English")
+	}
+}
+
+// TestMessagesTranslateActionsAndErrors tests that row actions and error messages come from the catalog
+func TestMessagesTranslateActionsAndErrors(t *testing.T) {
+	core.RegisterMessages("ui-test-actions", core.Messages{"action.edit": "Modifier", "error.invalid_id": "Identifiant invalide"})
+	h := newOverrideHandler()
+
+	req := httptest.NewRequest(http.MethodGet, "/admin/overrideNote", nil)
+	req.Header.Set("Accept-Language", "ui-test-actions")
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+	if !strings.Contains(w.Body.String(), "Modifier") {
+		t.Error("Expected the row actions to use the negotiated locale's messages")
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/admin/overrideNote/abc", nil)
+	req.Header.Set("Accept-Language", "ui-test-actions")
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+	if w.Code != http.StatusBadRequest || !strings.Contains(w.Body.String(), "Identifiant invalide") {
+		t.Errorf("Expected a translated invalid ID error, got %d %q", w.Code, w.Body.String())
+	}
+}
+
+// TestMessagesTranslateDeviceAndRequiredFields tests that the session device and the required field error come from the catalog
+func TestMessagesTranslateDeviceAndRequiredFields(t *testing.T) {
+	core.RegisterMessages("ui-test-devices", core.Messages{"sessions.device_on": "%s sur %s", "validation.required_missing": "champs requis manquants : %s"})
+	ctx := context.WithValue(context.Background(), "locale", "ui-test-devices")
+
+	if got := describeUserAgent(ctx, "Mozilla/5.0 (Windows NT 10.0) Chrome/120.0"); got != "Chrome sur Windows" {
+		t.Errorf("Expected a translated device, got %q", got)
+	}
+	req := httptest.NewRequest(http.MethodPost, "/admin/overrideNote", nil).WithContext(ctx)
+	err := checkRequiredFields(req, []core.FieldInfo{{Name: "Title", DisplayName: "Title", Type: "string", Required: true}})
+	if err == nil || err.Error() != "champs requis manquants : Title" {
+		t.Errorf("Expected a translated required field error, got %v", err)
+	}
+}
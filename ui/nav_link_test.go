@@ -0,0 +1,33 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/preslavrachev/backoffice/core"
+	"github.com/preslavrachev/backoffice/middleware/auth"
+)
+
+func TestSidebar_RendersNavLinks(t *testing.T) {
+	bo := core.New(&overrideAdapter{}, auth.AuthConfig{})
+	bo.RegisterResource(&overrideNote{}).WithFields("Title")
+	bo.AddLink("Grafana", "https://grafana.internal", "📈").InGroup("Monitoring")
+	bo.AddLink("Reports", "/reports", "")
+	h := Handler(bo, "/admin")
+
+	body := getPage(h, "/admin/overrideNote")
+	for _, want := range []string{
+		`data-pw="nav-group-Monitoring"`,
+		`href="https://grafana.internal" target="_blank" rel="noopener noreferrer"`,
+		`data-pw="nav-link-external-Grafana"`,
+		`📈`,
+		`data-pw="nav-link-external-Reports"`,
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("Expected sidebar to contain %q", want)
+		}
+	}
+	if strings.Contains(body, `href="/reports" target="_blank"`) {
+		t.Error("Expected relative links to open in the same tab")
+	}
+}
@@ -0,0 +1,73 @@
+package ui
+
+import (
+	"context"
+
+	"github.com/a-h/templ"
+	"github.com/preslavrachev/backoffice/core"
+)
+
+// Slot names a part of the admin pages whose component can be replaced
+type Slot string
+
+const (
+	SlotListRow      Slot = "list_row"      // A row of the list table
+	SlotDetailHeader Slot = "detail_header" // The title bar and buttons of the detail page
+	SlotFormField    Slot = "form_field"    // The input of a field in the create and edit forms
+	SlotLayout       Slot = "layout"        // The page around the content
+)
+
+// SlotProps holds what the built-in component of a slot renders
+type SlotProps struct {
+	Resource *core.Resource  // nil for the layout of pages outside a resource
+	Item     any             // The record of a list row, detail header or edit form
+	Field    *core.FieldInfo // The field of a form field
+	Value    string          // The current value of a form field
+	Title    string          // The page title of the layout
+	Content  templ.Component // The page content of the layout
+	Default  templ.Component // The built-in component, for overrides that wrap it
+}
+
+// ComponentOverride renders a slot in place of its built-in component
+type ComponentOverride func(props SlotProps) templ.Component
+
+// Option configures the admin handler
+type Option func(*BackOfficeHandler)
+
+// WithComponentOverride renders a slot of the named resource with a custom component
+// An empty resource name overrides the slot for every resource without its own override
+func WithComponentOverride(resource string, slot Slot, override ComponentOverride) Option {
+	return func(h *BackOfficeHandler) {
+		if h.overrides == nil {
+			h.overrides = make(componentOverrides)
+		}
+		h.overrides[overrideKey{resource: resource, slot: slot}] = override
+	}
+}
+
+type overrideKey struct {
+	resource string
+	slot     Slot
+}
+
+// componentOverrides maps resource slots to their custom components
+type componentOverrides map[overrideKey]ComponentOverride
+
+// lookup returns the override of a resource's slot, falling back to the override for all resources
+func (o componentOverrides) lookup(resource *core.Resource, slot Slot) ComponentOverride {
+	if resource != nil {
+		if override, ok := o[overrideKey{resource: resource.Name, slot: slot}]; ok {
+			return override
+		}
+	}
+	return o[overrideKey{slot: slot}]
+}
+
+// slotComponent returns the component rendering a slot: the override from the request context, or props.Default
+func slotComponent(ctx context.Context, slot Slot, props SlotProps) templ.Component {
+	overrides, _ := ctx.Value("componentOverrides").(componentOverrides)
+	if override := overrides.lookup(props.Resource, slot); override != nil {
+		return override(props)
+	}
+	return props.Default
+}
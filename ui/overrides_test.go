@@ -0,0 +1,104 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/a-h/templ"
+	"github.com/preslavrachev/backoffice/core"
+	"github.com/preslavrachev/backoffice/middleware/auth"
+)
+
+type overrideNote struct {
+	ID    uint   `db:"id"`
+	Title string `db:"title"`
+}
+
+// overrideAdapter serves a single note
+type overrideAdapter struct {
+	mockActionAdapter
+}
+
+func (a *overrideAdapter) Find(ctx context.Context, resource *core.Resource, query *core.Query) (*core.Result, error) {
+	return &core.Result{Items: []any{&overrideNote{ID: 1, Title: "Groceries"}}, TotalCount: 1}, nil
+}
+
+func (a *overrideAdapter) GetByID(ctx context.Context, resource *core.Resource, id any) (any, error) {
+	return &overrideNote{ID: 1, Title: "Groceries"}, nil
+}
+
+// textComponent renders a fixed string, followed by an optional wrapped component
+func textComponent(text string, wrapped templ.Component) templ.Component {
+	return templ.ComponentFunc(func(ctx context.Context, w io.Writer) error {
+		if _, err := io.WriteString(w, text); err != nil {
+			return err
+		}
+		if wrapped != nil {
+			return wrapped.Render(ctx, w)
+		}
+		return nil
+	})
+}
+
+func newOverrideHandler(opts ...Option) http.Handler {
+	bo := core.New(&overrideAdapter{}, auth.AuthConfig{})
+	bo.RegisterResource(&overrideNote{}).WithFields("Title")
+	return Handler(bo, "/admin", opts...)
+}
+
+func getPage(h http.Handler, path string) string {
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+	return w.Body.String()
+}
+
+func TestComponentOverride_Slots(t *testing.T) {
+	h := newOverrideHandler(
+		WithComponentOverride("overrideNote", SlotListRow, func(props SlotProps) templ.Component {
+			return textComponent(fmt.Sprintf(`<tr data-pw="custom-row"><td>%s</td></tr>`, props.Item.(*overrideNote).Title), nil)
+		}),
+		WithComponentOverride("", SlotDetailHeader, func(props SlotProps) templ.Component {
+			return textComponent(`<h2 data-pw="custom-header">`+props.Resource.DisplayName+`</h2>`, nil)
+		}),
+		WithComponentOverride("overrideNote", SlotFormField, func(props SlotProps) templ.Component {
+			return textComponent(`<span data-pw="field-hint">`+props.Field.Name+`</span>`, props.Default)
+		}),
+	)
+
+	if body := getPage(h, "/admin/overrideNote"); !strings.Contains(body, `<tr data-pw="custom-row"><td>Groceries</td></tr>`) || strings.Contains(body, `data-pw="resource-row"`) {
+		t.Error("Expected the list row override to replace the built-in row")
+	}
+	if body := getPage(h, "/admin/overrideNote/1"); !strings.Contains(body, `data-pw="custom-header"`) || strings.Contains(body, "← Back to List") {
+		t.Error("Expected the catch-all detail header override to replace the built-in header")
+	}
+	body := getPage(h, "/admin/overrideNote/1/edit")
+	if !strings.Contains(body, `<span data-pw="field-hint">Title</span>`) || !strings.Contains(body, `data-pw="input-Title"`) {
+		t.Error("Expected the form field override to wrap the built-in input")
+	}
+}
+
+func TestComponentOverride_Layout(t *testing.T) {
+	h := newOverrideHandler(WithComponentOverride("", SlotLayout, func(props SlotProps) templ.Component {
+		return textComponent(`<main data-pw="custom-layout">`+props.Title+`</main>`, props.Content)
+	}))
+
+	body := getPage(h, "/admin/overrideNote")
+	if !strings.Contains(body, `data-pw="custom-layout"`) {
+		t.Errorf("Expected the layout override to render the page, got:\n%s", body)
+	}
+	if strings.Contains(strings.ToLower(body), "<!doctype html>") || !strings.Contains(body, `data-pw="table-body"`) {
+		t.Error("Expected the layout override to replace the built-in layout and keep the content")
+	}
+}
+
+func TestComponentOverride_None(t *testing.T) {
+	body := getPage(newOverrideHandler(), "/admin/overrideNote")
+	if !strings.Contains(body, `data-pw="resource-row"`) {
+		t.Error("Expected the built-in row without overrides")
+	}
+}
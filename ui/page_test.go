@@ -0,0 +1,58 @@
+package ui
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/preslavrachev/backoffice/core"
+	"github.com/preslavrachev/backoffice/middleware/auth"
+)
+
+func newPageHandler() http.Handler {
+	bo := core.New(&overrideAdapter{}, auth.AuthConfig{})
+	bo.RegisterResource(&overrideNote{}).WithFields("Title")
+	bo.RegisterPage("/reports/weekly", textComponent(`<p data-pw="weekly-report">42 orders</p>`, nil)).WithTitle("Weekly report")
+	bo.RegisterPage("/reports/live", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `<p data-pw="live-report">%s</p>`, r.URL.Query().Get("q"))
+	})
+	bo.RegisterPage("/reports/export", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/csv")
+		fmt.Fprint(w, "day,orders\n")
+	})
+	return Handler(bo, "/admin")
+}
+
+func TestCustomPage_RendersInsideLayout(t *testing.T) {
+	h := newPageHandler()
+
+	body := getPage(h, "/admin/reports/weekly")
+	for _, want := range []string{`data-pw="weekly-report"`, `data-pw="page-breadcrumbs"`, `data-pw="sidebar-nav"`, "Weekly report"} {
+		if !strings.Contains(body, want) {
+			t.Errorf("Expected the page to contain %q", want)
+		}
+	}
+
+	body = getPage(h, "/admin/reports/live?q=now")
+	if !strings.Contains(body, `<p data-pw="live-report">now</p>`) || !strings.Contains(body, `data-pw="sidebar-nav"`) {
+		t.Errorf("Expected the handler output inside the layout, got %s", body)
+	}
+}
+
+func TestCustomPage_PassesThroughNonHTML(t *testing.T) {
+	h := newPageHandler()
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/admin/reports/export", nil))
+	if w.Body.String() != "day,orders\n" || w.Header().Get("Content-Type") != "text/csv" {
+		t.Errorf("Expected the CSV unchanged, got %q (%s)", w.Body.String(), w.Header().Get("Content-Type"))
+	}
+
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/admin/reports/missing", nil))
+	if w.Code != http.StatusNotFound {
+		t.Errorf("Expected unknown paths to 404, got %d", w.Code)
+	}
+}
@@ -0,0 +1,62 @@
+package ui
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestPreferences_StoredInCookie(t *testing.T) {
+	h := newOverrideHandler()
+
+	form := url.Values{"density": {"compact"}, "text_size": {"large"}}
+	req := httptest.NewRequest(http.MethodPost, "/admin/preferences", strings.NewReader(form.Encode()))
+	withCSRFToken(req)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+
+	if w.Header().Get("HX-Refresh") != "true" {
+		t.Errorf("Expected the page to refresh, got headers %v", w.Header())
+	}
+	cookies := w.Result().Cookies()
+	if len(cookies) != 1 || cookies[0].Name != preferencesCookieName || cookies[0].Value != "compact.large" {
+		t.Fatalf("Expected the preferences cookie, got %v", cookies)
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/admin/overrideNote", nil)
+	req.AddCookie(cookies[0])
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+	body := w.Body.String()
+	for _, want := range []string{
+		`class="px-3 py-1.5 text-base align-top`,
+		`value="compact" checked`,
+		`value="large" checked`,
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("Expected the list to contain %q", want)
+		}
+	}
+}
+
+func TestPreferences_DefaultsAndValidation(t *testing.T) {
+	h := newOverrideHandler()
+
+	body := getPage(h, "/admin/overrideNote")
+	if !strings.Contains(body, `class="px-6 py-3 text-sm align-top`) || !strings.Contains(body, `data-pw="preferences-menu"`) {
+		t.Error("Expected comfortable cells with normal text and the preferences menu by default")
+	}
+
+	form := url.Values{"density": {"cozy"}, "text_size": {"normal"}}
+	req := httptest.NewRequest(http.MethodPost, "/admin/preferences", strings.NewReader(form.Encode()))
+	withCSRFToken(req)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+	if w.Code != http.StatusBadRequest || len(w.Result().Cookies()) != 0 {
+		t.Errorf("Expected unknown preferences to be rejected, got %d", w.Code)
+	}
+}
@@ -0,0 +1,64 @@
+package ui
+
+import (
+	"context"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/preslavrachev/backoffice/core"
+)
+
+// creatingAdapter numbers the teams it creates, like a database would
+type creatingAdapter struct {
+	pickerAdapter
+}
+
+func (a *creatingAdapter) Create(ctx context.Context, resource *core.Resource, data any) error {
+	data.(*pickerTeam).ID = 9
+	return nil
+}
+
+func TestQuickCreate(t *testing.T) {
+	adapter := &creatingAdapter{pickerAdapter{player: &pickerPlayer{ID: 7, Name: "Ann"}}}
+	bo := newPickerBackOffice(adapter)
+	h := Handler(bo, "/admin")
+
+	form := getPage(h, "/admin/api/pickerPlayer/7/edit")
+	button := `/admin/api/pickerTeam/new?_field=Team&amp;_picker=relation-picker-sidepane-input-Team&amp;_source=pickerPlayer`
+	if !strings.Contains(form, button) || !strings.Contains(form, `id="relation-picker-sidepane-input-Team"`) {
+		t.Errorf("Expected the picker to offer creating a team, got %s", form)
+	}
+
+	pane := getPage(h, "/admin/api/pickerTeam/new?_field=Team&_picker=relation-picker-sidepane-input-Team&_source=pickerPlayer")
+	for _, want := range []string{`id="quickcreate-overlay"`, `name="_picker" value="relation-picker-sidepane-input-Team"`, `name="_source" value="pickerPlayer"`} {
+		if !strings.Contains(pane, want) {
+			t.Errorf("Expected %s in the stacked pane", want)
+		}
+	}
+	if strings.Contains(pane, `id="sidepane-overlay"`) {
+		t.Error("Expected the stacked pane to leave the picking form's pane alone")
+	}
+
+	values := url.Values{"_picker": {"relation-picker-sidepane-input-Team"}, "_source": {"pickerPlayer"}, "_field": {"Team"}, "Name": {"Greens"}}
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("POST", "/admin/api/pickerTeam", strings.NewReader(values.Encode()))
+	withCSRFToken(r)
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	h.ServeHTTP(w, r)
+	want := `"relation-created":{"label":"Greens","picker":"relation-picker-sidepane-input-Team","value":"9"}`
+	if trigger := w.Header().Get("HX-Trigger"); !strings.Contains(trigger, want) || !strings.Contains(trigger, `"closeSidePane":{"id":"quickcreate-overlay"}`) {
+		t.Errorf("Expected the new team to be picked and the stacked pane closed, got %s", trigger)
+	}
+	if w.Body.Len() != 0 || w.Header().Get("HX-Reswap") != "none" {
+		t.Errorf("Expected nothing to be swapped, got %q", w.Body.String())
+	}
+
+	// Read-only resources can't be created from a picker
+	team, _ := bo.GetResource("pickerTeam")
+	team.ReadOnly = true
+	if form := getPage(h, "/admin/api/pickerPlayer/7/edit"); strings.Contains(form, "Create new") {
+		t.Error("Expected no quick create for a read-only resource")
+	}
+}
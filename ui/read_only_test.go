@@ -0,0 +1,47 @@
+package ui
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/preslavrachev/backoffice/core"
+	"github.com/preslavrachev/backoffice/middleware/auth"
+)
+
+func TestReadOnlyMode_Handlers(t *testing.T) {
+	bo := core.New(&overrideAdapter{}, auth.AuthConfig{})
+	bo.RegisterResource(&overrideNote{}).WithFields("Title")
+	bo.SetReadOnly(core.ReadOnlyConfig{Enabled: true, Message: "Frozen for the incident"})
+	h := Handler(bo, "/admin")
+
+	body := getPage(h, "/admin/overrideNote")
+	if !strings.Contains(body, `data-pw="read-only-banner"`) || !strings.Contains(body, "Frozen for the incident") {
+		t.Error("Expected the read-only banner with the configured message")
+	}
+	for _, button := range []string{"add-new-button", "edit-button", "delete-button"} {
+		if strings.Contains(body, `data-pw="`+button+`"`) {
+			t.Errorf("Expected no %s while read-only", button)
+		}
+	}
+	if !strings.Contains(body, "Groceries") {
+		t.Error("Expected records to still be listed")
+	}
+
+	for _, path := range []string{"/admin/overrideNote/new", "/admin/overrideNote/1/edit"} {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		if w.Code != http.StatusForbidden {
+			t.Errorf("Expected %s to be rejected while read-only, got %d", path, w.Code)
+		}
+	}
+
+	req := httptest.NewRequest(http.MethodDelete, "/admin/api/overrideNote/1", nil)
+	withCSRFToken(req)
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+	if w.Code != http.StatusForbidden || !strings.Contains(w.Header().Get("HX-Trigger"), "Frozen for the incident") {
+		t.Errorf("Expected deletes to be rejected with the read-only message, got %d", w.Code)
+	}
+}
@@ -0,0 +1,154 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/preslavrachev/backoffice/core"
+	"github.com/preslavrachev/backoffice/middleware/auth"
+)
+
+type linkCategory struct {
+	ID   uint   `db:"id"`
+	Name string `db:"name"`
+}
+
+type linkProduct struct {
+	ID             uint   `db:"id"`
+	Name           string `db:"name"`
+	LinkCategoryID uint   `db:"link_category_id"`
+}
+
+// linkAdapter finds products by category
+type linkAdapter struct {
+	mockActionAdapter
+	products []*linkProduct
+}
+
+func (a *linkAdapter) Find(ctx context.Context, resource *core.Resource, query *core.Query) (*core.Result, error) {
+	var matching []any
+	for _, product := range a.products {
+		if query.Filters["LinkCategoryID"] == product.LinkCategoryID {
+			matching = append(matching, product)
+		}
+	}
+	result := &core.Result{TotalCount: int64(len(matching))}
+	if offset := query.Pagination.Offset; offset < len(matching) {
+		result.Items = matching[offset:min(offset+query.Pagination.Limit, len(matching))]
+	}
+	return result, nil
+}
+
+func (a *linkAdapter) GetByID(ctx context.Context, resource *core.Resource, id any) (any, error) {
+	return &linkCategory{ID: 3, Name: "Tools"}, nil
+}
+
+func TestRenderRelatedLinks(t *testing.T) {
+	adapter := &linkAdapter{products: []*linkProduct{
+		{ID: 1, LinkCategoryID: 3},
+		{ID: 2, LinkCategoryID: 3},
+		{ID: 3, LinkCategoryID: 4},
+	}}
+	bo := core.New(adapter, auth.AuthConfig{})
+	bo.RegisterResource(&linkCategory{}).
+		WithFields("Name").
+		WithOneToManyField("Products", "linkProduct", func(r *core.RelationshipBuilder) {
+			r.ForeignKey("LinkCategoryID").QuickLinks(core.RelatedLinkBoth)
+		})
+	bo.RegisterResource(&linkProduct{}).WithName("Product").WithPluralName("Products").WithFields("Name", "LinkCategoryID")
+	h := &BackOfficeHandler{bo: bo}
+
+	req := httptest.NewRequest(http.MethodGet, "/admin/api/linkCategory/3/related-links/Products", nil)
+	w := httptest.NewRecorder()
+	h.apiRouter(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	body := w.Body.String()
+	if !strings.Contains(body, `href="/admin/linkProduct?LinkCategoryID=3"`) {
+		t.Errorf("Expected a link to the filtered child list, got:\n%s", body)
+	}
+	if !strings.Contains(body, "2 Products →") {
+		t.Errorf("Expected the child count, got:\n%s", body)
+	}
+	if !strings.Contains(body, `data-pw="related-modal-button-Products"`) {
+		t.Errorf("Expected the modal button alongside the link, got:\n%s", body)
+	}
+
+	// The list cell loads the links lazily
+	category, _ := bo.GetResource("linkCategory")
+	var sb strings.Builder
+	if err := List(category, []interface{}{&linkCategory{ID: 3, Name: "Tools"}}, 1, "").Render(context.Background(), &sb); err != nil {
+		t.Fatalf("Failed to render List: %v", err)
+	}
+	if !strings.Contains(sb.String(), `hx-get="/admin/api/linkCategory/3/related-links/Products"`) {
+		t.Errorf("Expected a lazy related links cell, got:\n%s", sb.String())
+	}
+}
+
+func TestRenderRelatedTable(t *testing.T) {
+	adapter := &linkAdapter{}
+	for i := 1; i <= 12; i++ {
+		adapter.products = append(adapter.products, &linkProduct{ID: uint(i), Name: fmt.Sprintf("Hammer %d", i), LinkCategoryID: 3})
+	}
+	bo := core.New(adapter, auth.AuthConfig{})
+	bo.RegisterResource(&linkCategory{}).
+		WithFields("Name").
+		WithOneToManyField("Products", "linkProduct", func(r *core.RelationshipBuilder) {
+			r.ForeignKey("LinkCategoryID")
+		})
+	bo.RegisterResource(&linkProduct{}).WithName("Product").WithPluralName("Products").WithFields("Name", "LinkCategoryID")
+	h := &BackOfficeHandler{bo: bo}
+
+	get := func(path string) string {
+		w := httptest.NewRecorder()
+		h.apiRouter(w, httptest.NewRequest(http.MethodGet, path, nil))
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected status 200 for %s, got %d: %s", path, w.Code, w.Body.String())
+		}
+		return w.Body.String()
+	}
+
+	firstPage := get("/admin/api/linkCategory/3/related-table/Products?page=1")
+	for _, want := range []string{
+		"12 Products →",
+		`href="/admin/linkProduct/10"`,
+		`hx-get="/admin/api/linkProduct/new?LinkCategoryID=3"`,
+		`hx-get="/admin/api/linkCategory/3/related-table/Products?page=2"`,
+		"Page 1 of 2",
+	} {
+		if !strings.Contains(firstPage, want) {
+			t.Errorf("Expected %s on the first page", want)
+		}
+	}
+	if strings.Contains(firstPage, "Hammer 11") {
+		t.Error("Expected ten products per page")
+	}
+	product, _ := bo.GetResource("linkProduct")
+	if columns := relatedColumns(product, "LinkCategoryID"); len(columns) != 2 || columns[1].Name != "Name" {
+		t.Errorf("Expected the ID and name columns without the foreign key, got %v", columns)
+	}
+
+	secondPage := get("/admin/api/linkCategory/3/related-table/Products?page=2")
+	if !strings.Contains(secondPage, "Hammer 12") || strings.Contains(secondPage, `data-pw="related-next"`) {
+		t.Error("Expected the remaining products on the last page")
+	}
+
+	// The child's create form is pre-filled with the parent
+	form := get("/admin/api/linkProduct/new?LinkCategoryID=3")
+	if !strings.Contains(form, `value="3"`) {
+		t.Errorf("Expected the foreign key to be pre-filled, got:\n%s", form)
+	}
+
+	// The detail page loads the table in place of the count
+	w := httptest.NewRecorder()
+	h.indexHandler(w, httptest.NewRequest(http.MethodGet, "/admin/linkCategory/3", nil))
+	if !strings.Contains(w.Body.String(), `hx-get="/admin/api/linkCategory/3/related-table/Products?page=1"`) {
+		t.Error("Expected the detail page to load the related records table")
+	}
+}
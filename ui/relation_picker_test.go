@@ -0,0 +1,192 @@
+package ui
+
+import (
+	"context"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/preslavrachev/backoffice/core"
+	"github.com/preslavrachev/backoffice/middleware/auth"
+)
+
+type pickerTeam struct {
+	ID   uint   `db:"id"`
+	Name string `db:"name"`
+}
+
+type pickerPlayer struct {
+	ID     uint        `db:"id"`
+	Name   string      `db:"name"`
+	TeamID *uint       `db:"team_id"`
+	Team   *pickerTeam `db:"-"`
+}
+
+// pickerAdapter serves teams and a single player from memory
+type pickerAdapter struct {
+	mockActionAdapter
+	teams  []*pickerTeam
+	player *pickerPlayer
+}
+
+func (a *pickerAdapter) GetByID(ctx context.Context, resource *core.Resource, id any) (any, error) {
+	if resource.Name == "pickerTeam" {
+		for _, team := range a.teams {
+			if team.ID == id {
+				return team, nil
+			}
+		}
+	}
+	return a.player, nil
+}
+
+func (a *pickerAdapter) Search(ctx context.Context, resource *core.Resource, query string) ([]any, error) {
+	var items []any
+	for _, team := range a.teams {
+		if strings.Contains(strings.ToLower(team.Name), strings.ToLower(query)) {
+			items = append(items, team)
+		}
+	}
+	return items, nil
+}
+
+func newPickerBackOffice(adapter core.Adapter) *core.BackOffice {
+	bo := core.New(adapter, auth.AuthConfig{})
+	bo.RegisterResource(&pickerTeam{}).
+		WithField("Name", func(f *core.FieldBuilder) { f.Searchable(true) })
+	bo.RegisterResource(&pickerPlayer{}).
+		WithFields("Name", "TeamID").
+		WithManyToOneField("Team", "pickerTeam", func(r *core.RelationshipBuilder) {
+			r.DisplayField("Name").ForeignKey("TeamID")
+		})
+	return bo
+}
+
+func TestRelationPicker(t *testing.T) {
+	teamID := uint(2)
+	adapter := &pickerAdapter{
+		teams:  []*pickerTeam{{ID: 1, Name: "Reds"}, {ID: 2, Name: "Blues"}},
+		player: &pickerPlayer{ID: 7, Name: "Ann", TeamID: &teamID},
+	}
+	h := Handler(newPickerBackOffice(adapter), "/admin")
+
+	form := getPage(h, "/admin/api/pickerPlayer/7/edit")
+	for _, want := range []string{`data-value="2" data-label="Blues"`, `name="TeamID" value="2"`, `/admin/api/pickerTeam/options?field=Team&amp;source=pickerPlayer`} {
+		if !strings.Contains(form, want) {
+			t.Errorf("Expected %s in the edit form", want)
+		}
+	}
+	if strings.Contains(form, `data-pw="sidepane-field-group-TeamID"`) {
+		t.Error("Expected the picker to replace the raw foreign key input")
+	}
+
+	options := getPage(h, "/admin/api/pickerTeam/options?q=blu&source=pickerPlayer&field=Team")
+	if !strings.Contains(options, `data-pw="relation-option-2">Blues</button>`) || strings.Contains(options, "Reds") {
+		t.Errorf("Expected only the matching team, got %s", options)
+	}
+	if empty := getPage(h, "/admin/api/pickerTeam/options?q=greens"); !strings.Contains(empty, "No matches") {
+		t.Errorf("Expected an empty state, got %s", empty)
+	}
+}
+
+func TestSaveRelationPicker(t *testing.T) {
+	bo := newPickerBackOffice(&pickerAdapter{})
+	resource, _ := bo.GetResource("pickerPlayer")
+	h := &BackOfficeHandler{bo: bo}
+
+	form := url.Values{"Name": {"Ann"}, "q": {"Blues"}, "TeamID": {"2"}}
+	r := httptest.NewRequest("POST", "/admin/api/pickerPlayer", strings.NewReader(form.Encode()))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	item, err := h.formToStruct(r, resource)
+	if err != nil {
+		t.Fatalf("Expected the form to save, got %v", err)
+	}
+	player := item.(*pickerPlayer)
+	if player.TeamID == nil || *player.TeamID != 2 {
+		t.Fatalf("Expected the picked team to be linked, got %v", player.TeamID)
+	}
+
+	// Clearing the picker unlinks a nullable relation
+	r = httptest.NewRequest("POST", "/admin/api/pickerPlayer/7", strings.NewReader(url.Values{"Name": {"Ann"}, "TeamID": {""}}.Encode()))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	if err := h.applyFormFields(r, player, resource.Fields); err != nil || player.TeamID != nil {
+		t.Errorf("Expected the team to be unlinked, got %v, %v", player.TeamID, err)
+	}
+}
+
+type pickerSquad struct {
+	ID      uint          `db:"id"`
+	Name    string        `db:"name"`
+	Members []*pickerTeam `db:"-"`
+}
+
+// linkingAdapter keeps squad memberships in memory
+type linkingAdapter struct {
+	pickerAdapter
+	links map[any][]any
+}
+
+func (a *linkingAdapter) GetByID(ctx context.Context, resource *core.Resource, id any) (any, error) {
+	if resource.Name == "pickerSquad" {
+		return &pickerSquad{ID: 4, Name: "Alpha"}, nil
+	}
+	return a.pickerAdapter.GetByID(ctx, resource, id)
+}
+
+func (a *linkingAdapter) LinkedIDs(ctx context.Context, resource *core.Resource, field *core.FieldInfo, id any) ([]any, error) {
+	return a.links[id], nil
+}
+
+func (a *linkingAdapter) SetLinks(ctx context.Context, resource *core.Resource, field *core.FieldInfo, id any, relatedIDs []any) error {
+	a.links[id] = relatedIDs
+	return nil
+}
+
+func TestManyToManyInput(t *testing.T) {
+	adapter := &linkingAdapter{
+		pickerAdapter: pickerAdapter{teams: []*pickerTeam{{ID: 1, Name: "Reds"}, {ID: 2, Name: "Blues"}}},
+		links:         map[any][]any{uint(4): {int64(2)}},
+	}
+	bo := newPickerBackOffice(adapter)
+	bo.RegisterResource(&pickerSquad{}).
+		WithFields("Name").
+		WithManyToManyField("Members", "pickerTeam", func(r *core.RelationshipBuilder) {
+			r.JoinTable("squad_teams", "squad_id", "team_id")
+		})
+	h := Handler(bo, "/admin")
+
+	form := getPage(h, "/admin/api/pickerSquad/4/edit")
+	if !strings.Contains(form, `data-chips="[{&#34;label&#34;:&#34;Blues&#34;,&#34;value&#34;:&#34;2&#34;}]"`) {
+		t.Errorf("Expected the linked team as a chip, got %s", form)
+	}
+
+	save := func(values url.Values) int {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest("POST", "/admin/api/pickerSquad/4", strings.NewReader(values.Encode()))
+		withCSRFToken(r)
+		r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		h.ServeHTTP(w, r)
+		return w.Code
+	}
+
+	if code := save(url.Values{"_method": {"PUT"}, "Name": {"Alpha"}, "Members": {"", "1", "2"}}); code != 200 {
+		t.Fatalf("Expected the update to succeed, got %d", code)
+	}
+	if links := adapter.links[uint(4)]; len(links) != 2 || links[0] != uint(1) || links[1] != uint(2) {
+		t.Errorf("Expected teams 1 and 2 linked, got %v", links)
+	}
+
+	// Removing every chip still posts the placeholder, which unlinks them all
+	save(url.Values{"_method": {"PUT"}, "Name": {"Alpha"}, "Members": {""}})
+	if links := adapter.links[uint(4)]; len(links) != 0 {
+		t.Errorf("Expected all teams unlinked, got %v", links)
+	}
+
+	// Forms without the field keep the links
+	adapter.links[uint(4)] = []any{uint(1)}
+	save(url.Values{"_method": {"PUT"}, "Name": {"Alpha"}})
+	if links := adapter.links[uint(4)]; len(links) != 1 {
+		t.Errorf("Expected the links kept, got %v", links)
+	}
+}
@@ -0,0 +1,119 @@
+package ui
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/preslavrachev/backoffice/core"
+	"github.com/preslavrachev/backoffice/middleware/auth"
+)
+
+type richPost struct {
+	ID   uint   `db:"id"`
+	Body string `db:"body"`
+}
+
+// richPostAdapter keeps the last created post
+type richPostAdapter struct {
+	mockActionAdapter
+	created *richPost
+}
+
+func (a *richPostAdapter) Create(ctx context.Context, resource *core.Resource, data any) error {
+	a.created = data.(*richPost)
+	return nil
+}
+
+func TestRichTextField(t *testing.T) {
+	adapter := &richPostAdapter{}
+	bo := core.New(adapter, auth.AuthConfig{})
+	bo.RegisterResource(&richPost{}).WithField("Body", func(f *core.FieldBuilder) {
+		f.RenderAsRichText()
+	})
+	h := &BackOfficeHandler{bo: bo}
+
+	w := httptest.NewRecorder()
+	h.apiRouter(w, httptest.NewRequest(http.MethodGet, "/admin/api/richPost/new", nil))
+	body := w.Body.String()
+	if !strings.Contains(body, `x-data="richText"`) || !strings.Contains(body, `<input type="hidden" name="Body"`) {
+		t.Errorf("Expected a rich text editor posting the Body field, got:\n%s", body)
+	}
+
+	form := url.Values{"Body": {`<p>Launch <strong>today</strong></p><script>alert(1)</script>`}}
+	req := httptest.NewRequest(http.MethodPost, "/admin/api/richPost", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	w = httptest.NewRecorder()
+	h.apiRouter(w, req)
+
+	if adapter.created == nil {
+		t.Fatalf("Expected the post to be created, got %d: %s", w.Code, w.Body.String())
+	}
+	if adapter.created.Body != `<p>Launch <strong>today</strong></p>` {
+		t.Errorf("Expected the stored HTML to be sanitized, got %q", adapter.created.Body)
+	}
+}
+
+type markdownNote struct {
+	ID   uint   `db:"id"`
+	Body string `db:"body"`
+}
+
+func TestMarkdownField(t *testing.T) {
+	adapter := &mockActionAdapter{getByIDFunc: func(ctx context.Context, resource *core.Resource, id any) (any, error) {
+		return &markdownNote{ID: 1, Body: "# Agenda\n\n- **Budget**"}, nil
+	}}
+	bo := core.New(adapter, auth.AuthConfig{})
+	bo.RegisterResource(&markdownNote{}).WithField("Body", func(f *core.FieldBuilder) {
+		f.AsMarkdown()
+	})
+	h := Handler(bo, "/admin")
+
+	form := getPage(h, "/admin/api/markdownNote/1/edit")
+	for _, want := range []string{`<textarea name="Body"`, `hx-post="/admin/api/markdown-preview?field=Body"`, `id="markdown-preview-Body"`} {
+		if !strings.Contains(form, want) {
+			t.Errorf("Expected %s in the edit form", want)
+		}
+	}
+
+	preview := url.Values{"Body": {"Hello *world*"}}
+	req := httptest.NewRequest(http.MethodPost, "/admin/api/markdown-preview?field=Body", strings.NewReader(preview.Encode()))
+	withCSRFToken(req)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+	if w.Body.String() != "<p>Hello <em>world</em></p>" {
+		t.Errorf("Expected the rendered preview, got %q", w.Body.String())
+	}
+
+	detail := getPage(h, "/admin/markdownNote/1")
+	if !strings.Contains(detail, "<h1>Agenda</h1><ul><li><strong>Budget</strong></li></ul>") {
+		t.Error("Expected the detail page to render the markdown")
+	}
+}
+
+func TestCodeField(t *testing.T) {
+	adapter := &mockActionAdapter{getByIDFunc: func(ctx context.Context, resource *core.Resource, id any) (any, error) {
+		return &markdownNote{ID: 1, Body: `{"enabled": true}`}, nil
+	}}
+	bo := core.New(adapter, auth.AuthConfig{})
+	bo.RegisterResource(&markdownNote{}).WithField("Body", func(f *core.FieldBuilder) {
+		f.AsCode(core.CodeJSON)
+	})
+	h := Handler(bo, "/admin")
+
+	form := getPage(h, "/admin/api/markdownNote/1/edit")
+	for _, want := range []string{`x-data="codeEditor"`, `data-language="json"`, `{&#34;enabled&#34;: true}</textarea>`} {
+		if !strings.Contains(form, want) {
+			t.Errorf("Expected %s in the edit form", want)
+		}
+	}
+
+	detail := getPage(h, "/admin/markdownNote/1")
+	if !strings.Contains(detail, `data-pw="code-Body">{&#34;enabled&#34;: true}</pre>`) {
+		t.Error("Expected the detail page to show the code preformatted")
+	}
+}
@@ -0,0 +1,105 @@
+package ui
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/preslavrachev/backoffice/core"
+	"github.com/preslavrachev/backoffice/middleware/auth"
+)
+
+type Subscription struct {
+	ID     uint   `db:"id"`
+	Status string `db:"status"`
+}
+
+// subscriptionAdapter lists a single subscription
+type subscriptionAdapter struct {
+	mockActionAdapter
+}
+
+func (a *subscriptionAdapter) Find(ctx context.Context, resource *core.Resource, query *core.Query) (*core.Result, error) {
+	return &core.Result{Items: []any{&Subscription{ID: 1, Status: "trial"}}, TotalCount: 1}, nil
+}
+
+func newSavedViewsHandler() (*BackOfficeHandler, *core.MemoryViewStore) {
+	store := core.NewMemoryViewStore()
+	bo := core.New(&subscriptionAdapter{}, auth.AuthConfig{}).SetViewStore(store)
+	bo.RegisterResource(&Subscription{}).WithFields("Status")
+	return &BackOfficeHandler{bo: bo}, store
+}
+
+func TestSavedViews_SaveAndApply(t *testing.T) {
+	h, store := newSavedViewsHandler()
+
+	form := url.Values{"name": {" Trials "}, "query": {"Status=trial&offset=40&sort=ID"}}
+	req := httptest.NewRequest(http.MethodPost, "/admin/api/Subscription/views", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	w := httptest.NewRecorder()
+	h.apiRouter(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	views, _ := store.ListViews(context.Background(), "Subscription")
+	if len(views) != 1 || views[0].Name != "Trials" || views[0].Query != "Status=trial&sort=ID" {
+		t.Fatalf("Expected the view without pagination, got %+v", views)
+	}
+	if !strings.Contains(w.Body.String(), `href="/admin/Subscription?Status=trial&amp;sort=ID"`) {
+		t.Errorf("Expected the refreshed menu to link to the view, got:\n%s", w.Body.String())
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/admin/Subscription?sort=ID&Status=trial", nil)
+	w = httptest.NewRecorder()
+	h.indexHandler(w, req)
+	body := w.Body.String()
+	if !strings.Contains(body, `data-pw="saved-views"`) {
+		t.Fatal("Expected the saved views menu in the list header")
+	}
+	if !strings.Contains(body, "font-semibold text-blue-700") {
+		t.Error("Expected the applied view to be highlighted")
+	}
+}
+
+func TestSavedViews_Delete(t *testing.T) {
+	h, store := newSavedViewsHandler()
+	ctx := context.Background()
+	view := &core.SavedView{Resource: "Subscription", Name: "Mine", CreatedBy: "alice"}
+	store.SaveView(ctx, view)
+
+	bob := auth.WithAuthUser(ctx, &auth.AuthUser{Username: "bob"})
+	req := httptest.NewRequest(http.MethodDelete, "/admin/api/Subscription/views/1", nil).WithContext(bob)
+	w := httptest.NewRecorder()
+	h.apiRouter(w, req)
+	if w.Code != http.StatusForbidden {
+		t.Errorf("Expected status 403 for another user's view, got %d", w.Code)
+	}
+
+	alice := auth.WithAuthUser(ctx, &auth.AuthUser{Username: "alice"})
+	req = httptest.NewRequest(http.MethodDelete, "/admin/api/Subscription/views/1", nil).WithContext(alice)
+	w = httptest.NewRecorder()
+	h.apiRouter(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if views, _ := store.ListViews(ctx, "Subscription"); len(views) != 0 {
+		t.Errorf("Expected the view to be deleted, got %+v", views)
+	}
+}
+
+func TestSavedViews_Unavailable(t *testing.T) {
+	bo := core.New(&mockActionAdapter{}, auth.AuthConfig{})
+	bo.RegisterResource(&Subscription{}).WithFields("Status")
+	h := &BackOfficeHandler{bo: bo}
+
+	req := httptest.NewRequest(http.MethodGet, "/admin/api/Subscription/views", nil)
+	w := httptest.NewRecorder()
+	h.apiRouter(w, req)
+	if w.Code != http.StatusNotFound {
+		t.Errorf("Expected status 404 without a view store, got %d", w.Code)
+	}
+}
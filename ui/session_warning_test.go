@@ -0,0 +1,61 @@
+package ui
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/preslavrachev/backoffice/core"
+	"github.com/preslavrachev/backoffice/middleware/auth"
+)
+
+func newSessionHandler(t *testing.T, timeout time.Duration) (http.Handler, *http.Cookie) {
+	t.Helper()
+	authConfig := auth.WithBasicAuthAndTimeout(map[string]auth.BasicAuthUser{
+		"admin": auth.NewBasicAuthUser("admin", "secret", "1", "admin@example.com", nil),
+	}, timeout)
+	bo := core.New(&overrideAdapter{}, authConfig)
+	bo.RegisterResource(&overrideNote{}).WithFields("Title")
+
+	sessionID, err := authConfig.SessionStore.CreateSession(context.Background(), &auth.AuthUser{ID: "1", Username: "admin"})
+	if err != nil {
+		t.Fatalf("Failed to create session: %v", err)
+	}
+	return Handler(bo, "/admin"), auth.CreateSessionCookie(sessionID)
+}
+
+func TestSessionExpiryWarning(t *testing.T) {
+	h, cookie := newSessionHandler(t, 10*time.Minute)
+
+	req := httptest.NewRequest(http.MethodGet, "/admin/overrideNote", nil)
+	req.AddCookie(cookie)
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+
+	body := w.Body.String()
+	for _, want := range []string{`data-pw="session-warning"`, `hx-post="/admin/session/refresh"`, `data-warn="300"`} {
+		if !strings.Contains(body, want) {
+			t.Errorf("Expected the page to contain %q", want)
+		}
+	}
+}
+
+func TestSessionRefresh(t *testing.T) {
+	h, cookie := newSessionHandler(t, 10*time.Minute)
+
+	req := httptest.NewRequest(http.MethodPost, "/admin/session/refresh", nil)
+	withCSRFToken(req)
+	req.AddCookie(cookie)
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+
+	if w.Code != http.StatusNoContent {
+		t.Fatalf("Expected 204, got %d", w.Code)
+	}
+	if trigger := w.Header().Get("HX-Trigger"); !strings.Contains(trigger, `"sessionRefreshed":{"remaining":`) {
+		t.Errorf("Expected a sessionRefreshed event, got %q", trigger)
+	}
+}
@@ -0,0 +1,107 @@
+package ui
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/preslavrachev/backoffice/middleware/auth"
+)
+
+// sessionsHandler serves the "My sessions" page listing where the user is signed in
+// POST signs out one session by its handle, or every session but the current one with scope=others
+func (h *BackOfficeHandler) sessionsHandler(w http.ResponseWriter, r *http.Request) {
+	r = h.withOverrides(r)
+	ctx := r.Context()
+	authConfig := h.bo.GetAuth()
+	store, ok := authConfig.SessionStore.(auth.ManagedSessionStore)
+	user, signedIn := auth.GetAuthUser(ctx)
+	if !ok || !signedIn {
+		http.NotFound(w, r)
+		return
+	}
+
+	sessionID, _ := authConfig.SessionID(r)
+	current := ""
+	if sessionID != "" {
+		current = auth.SessionHandle(sessionID)
+	}
+	sessions, err := store.ListUserSessions(ctx, user.SessionOwner())
+	if err != nil {
+		h.writeHTTPError(w, msg(r.Context(), "error.sessions_failed"), http.StatusInternalServerError)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		layout := h.pageLayout(r, msg(ctx, "sessions.title"), SessionsPage(sessions, current), "")
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		if err := layout.Render(ctx, w); err != nil {
+			h.writeHTTPError(w, msg(r.Context(), "error.render"), http.StatusInternalServerError)
+		}
+	case http.MethodPost:
+		handles := []string{r.FormValue("handle")}
+		if r.FormValue("scope") == "others" {
+			handles = handles[:0]
+			for _, session := range sessions {
+				handles = append(handles, session.Handle)
+			}
+		}
+		for _, handle := range handles {
+			if handle == current {
+				continue // Signing out of this browser is what Logout is for
+			}
+			err := store.RevokeUserSession(ctx, user.SessionOwner(), handle)
+			if err != nil && !errors.Is(err, auth.ErrSessionNotFound) {
+				h.writeHTTPError(w, msg(r.Context(), "error.session_revoke"), http.StatusInternalServerError)
+				return
+			}
+			// A remember-me token would sign the browser straight back in
+			if err := authConfig.RevokeSessionRemembering(ctx, user.SessionOwner(), handle); err != nil {
+				h.writeHTTPError(w, msg(r.Context(), "error.session_revoke"), http.StatusInternalServerError)
+				return
+			}
+		}
+		if r.FormValue("scope") == "others" {
+			if err := authConfig.RevokeOtherRemembering(w, r, user, sessionID); err != nil {
+				h.writeHTTPError(w, msg(r.Context(), "error.session_revoke"), http.StatusInternalServerError)
+				return
+			}
+		}
+		http.Redirect(w, r, h.bo.GetConfig().BasePath+"/sessions", http.StatusSeeOther)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+// describeUserAgent names the browser and operating system of a User-Agent, e.g. "Firefox on Windows"
+func describeUserAgent(ctx context.Context, userAgent string) string {
+	browser := ""
+	for _, candidate := range []struct{ token, name string }{
+		{"Edg/", "Edge"}, {"OPR/", "Opera"}, {"Firefox/", "Firefox"}, {"Chrome/", "Chrome"}, {"Safari/", "Safari"}, {"curl/", "curl"},
+	} {
+		if strings.Contains(userAgent, candidate.token) {
+			browser = candidate.name
+			break
+		}
+	}
+	system := ""
+	for _, candidate := range []struct{ token, name string }{
+		{"iPhone", "iOS"}, {"iPad", "iPadOS"}, {"Android", "Android"}, {"Windows", "Windows"}, {"Mac OS X", "macOS"}, {"Linux", "Linux"},
+	} {
+		if strings.Contains(userAgent, candidate.token) {
+			system = candidate.name
+			break
+		}
+	}
+	switch {
+	case browser != "" && system != "":
+		return msg(ctx, "sessions.device_on", browser, system)
+	case browser != "":
+		return browser
+	case system != "":
+		return system
+	}
+	return ""
+}
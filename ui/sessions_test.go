@@ -0,0 +1,173 @@
+package ui
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/preslavrachev/backoffice/core"
+	"github.com/preslavrachev/backoffice/middleware/auth"
+)
+
+func TestSessionsPage(t *testing.T) {
+	store := auth.NewMemorySessionStore()
+	authConfig := auth.AuthConfig{Enabled: true, RequireAuth: true, LoginPath: "/login", LogoutPath: "/logout", SessionStore: store}
+	bo := core.New(&overrideAdapter{}, authConfig)
+	bo.RegisterResource(&overrideNote{})
+	h := Handler(bo, "/admin")
+
+	ctx := context.Background()
+	admin := &auth.AuthUser{Username: "admin"}
+	current, _ := store.CreateSession(ctx, admin)
+	phone, _ := store.CreateSession(ctx, admin)
+	store.TouchSession(ctx, phone, auth.SessionActivity{IP: "198.51.100.4", UserAgent: "Mozilla/5.0 (iPhone; CPU iPhone OS 17_0) Version/17.0 Mobile Safari/604.1"})
+
+	request := func(method, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, "/admin/sessions", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		req.AddCookie(&http.Cookie{Name: auth.DefaultSessionCookieName, Value: current})
+		if method == http.MethodPost {
+			withCSRFToken(req)
+		}
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, req)
+		return w
+	}
+
+	page := request(http.MethodGet, "").Body.String()
+	if strings.Count(page, `data-pw="session-row"`) != 2 || !strings.Contains(page, "Safari on iOS") || !strings.Contains(page, "198.51.100.4") {
+		t.Fatalf("Expected both sessions with their device and IP, got %s", page)
+	}
+	if strings.Count(page, `data-pw="revoke-session"`) != 1 || !strings.Contains(page, `data-pw="current-session"`) {
+		t.Error("Expected only the other session to offer signing out")
+	}
+	if !strings.Contains(page, `data-pw="sessions-link"`) {
+		t.Error("Expected the header to link to the sessions page")
+	}
+	if strings.Contains(page, phone) || strings.Contains(page, current) {
+		t.Error("Expected session IDs to stay out of the page")
+	}
+
+	// The current session can't be revoked from the list, the other one can
+	request(http.MethodPost, url.Values{"handle": {auth.SessionHandle(current)}}.Encode())
+	if _, err := store.GetSession(ctx, current); err != nil {
+		t.Errorf("Expected the current session to stay, got %v", err)
+	}
+	w := request(http.MethodPost, url.Values{"scope": {"others"}}.Encode())
+	if w.Code != http.StatusSeeOther {
+		t.Errorf("Expected a redirect back to the page, got %d", w.Code)
+	}
+	if _, err := store.GetSession(ctx, phone); err == nil {
+		t.Error("Expected the other session to be signed out")
+	}
+}
+
+func TestSessionsPage_RevokesRememberTokens(t *testing.T) {
+	store := auth.NewMemorySessionStore()
+	tokens := auth.NewMemoryRememberTokenStore()
+	authConfig := auth.AuthConfig{Enabled: true, RequireAuth: true, LoginPath: "/login", LogoutPath: "/logout", SessionStore: store, RememberMe: auth.RememberMeConfig{Store: tokens}}
+	bo := core.New(&overrideAdapter{}, authConfig)
+	bo.RegisterResource(&overrideNote{})
+	h := Handler(bo, "/admin")
+
+	ctx := context.Background()
+	admin := &auth.AuthUser{Username: "admin"}
+	current, _ := store.CreateSession(ctx, admin)
+	remember := func(sessionID string) string {
+		w := httptest.NewRecorder()
+		if err := authConfig.Remember(w, httptest.NewRequest(http.MethodPost, "/admin/login", nil), admin, sessionID); err != nil {
+			t.Fatalf("Failed to remember the session: %v", err)
+		}
+		return w.Result().Cookies()[0].Value
+	}
+	phone, _ := store.CreateSession(ctx, admin)
+	laptop, _ := store.CreateSession(ctx, admin)
+	currentToken, phoneToken, laptopToken := remember(current), remember(phone), remember(laptop)
+
+	request := func(body string) {
+		req := httptest.NewRequest(http.MethodPost, "/admin/sessions", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		req.AddCookie(&http.Cookie{Name: auth.DefaultSessionCookieName, Value: current})
+		withCSRFToken(req)
+		h.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	request(url.Values{"handle": {auth.SessionHandle(phone)}}.Encode())
+	if _, err := tokens.ConsumeToken(ctx, phoneToken); err == nil {
+		t.Error("Expected signing out a session to revoke the token that signed it in")
+	}
+	request(url.Values{"scope": {"others"}}.Encode())
+	if _, err := tokens.ConsumeToken(ctx, laptopToken); err == nil {
+		t.Error("Expected signing out the others to revoke their tokens")
+	}
+	if _, err := tokens.ConsumeToken(ctx, currentToken); err != nil {
+		t.Errorf("Expected the current browser to stay remembered, got %v", err)
+	}
+}
+
+func TestDescribeUserAgent(t *testing.T) {
+	tests := map[string]string{
+		"Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 Chrome/120.0 Safari/537.36":         "Chrome on Windows",
+		"Mozilla/5.0 (Macintosh; Intel Mac OS X 14_0) Gecko/20100101 Firefox/121.0":                       "Firefox on macOS",
+		"Mozilla/5.0 (X11; Linux x86_64) AppleWebKit/537.36 Chrome/120.0 Safari/537.36 Edg/120.0.2210.91": "Edge on Linux",
+		"curl/8.4.0": "curl",
+		"":           "",
+	}
+	for userAgent, want := range tests {
+		if got := describeUserAgent(context.Background(), userAgent); got != want {
+			t.Errorf("describeUserAgent(%q) = %q, want %q", userAgent, got, want)
+		}
+	}
+}
+
+func TestLogout_OnlyPost(t *testing.T) {
+	store := auth.NewMemorySessionStore()
+	authConfig := auth.AuthConfig{Enabled: true, RequireAuth: true, LoginPath: "/login", LogoutPath: "/logout", LogoutRedirect: "/admin/login", SessionStore: store}
+	bo := core.New(&overrideAdapter{}, authConfig)
+	bo.RegisterResource(&overrideNote{})
+	h := Handler(bo, "/admin")
+
+	ctx := context.Background()
+	sessionID, _ := store.CreateSession(ctx, &auth.AuthUser{Username: "admin"})
+	request := func(method string, csrf bool) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, "/admin/logout", nil)
+		req.AddCookie(&http.Cookie{Name: auth.DefaultSessionCookieName, Value: sessionID})
+		if csrf {
+			withCSRFToken(req)
+		}
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, req)
+		return w
+	}
+
+	if page := getPageAs(h, "/admin/", sessionID); !strings.Contains(page, `<form method="post" action="/admin/logout" data-pw="logout-form"><input type="hidden" name="csrf_token"`) {
+		t.Error("Expected the header to sign out with a form carrying the CSRF token")
+	}
+	if w := request(http.MethodGet, false); w.Code != http.StatusMethodNotAllowed {
+		t.Errorf("Expected GET to be rejected, got %d", w.Code)
+	}
+	if w := request(http.MethodPost, false); w.Code != http.StatusForbidden {
+		t.Errorf("Expected POST without the CSRF token to be rejected, got %d", w.Code)
+	}
+	if _, err := store.GetSession(ctx, sessionID); err != nil {
+		t.Fatalf("Expected the session to survive rejected sign outs, got %v", err)
+	}
+	if w := request(http.MethodPost, true); w.Code != http.StatusSeeOther {
+		t.Errorf("Expected signing out to redirect, got %d", w.Code)
+	}
+	if _, err := store.GetSession(ctx, sessionID); err == nil {
+		t.Error("Expected signing out to end the session")
+	}
+}
+
+// getPageAs fetches a page in the session
+func getPageAs(h http.Handler, path, sessionID string) string {
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	req.AddCookie(&http.Cookie{Name: auth.DefaultSessionCookieName, Value: sessionID})
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+	return w.Body.String()
+}
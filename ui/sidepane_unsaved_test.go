@@ -0,0 +1,51 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/preslavrachev/backoffice/core"
+	"github.com/preslavrachev/backoffice/middleware/auth"
+)
+
+type unsavedNote struct {
+	ID   uint   `db:"id"`
+	Body string `db:"body"`
+}
+
+// TestSidePaneUnsavedChanges tests that side panes track edits and ask before discarding them
+func TestSidePaneUnsavedChanges(t *testing.T) {
+	bo := core.New(&mockActionAdapter{}, auth.AuthConfig{})
+	bo.RegisterResource(&unsavedNote{}).WithFields("Body")
+	h := Handler(bo, "/admin")
+
+	pane := getPage(h, "/admin/api/unsavedNote/new")
+	for _, want := range []string{
+		`@input="markDirty"`,
+		`@beforeunload.window="warnBeforeUnload"`,
+		`data-pw="unsaved-changes-dialog"`,
+		"Discard unsaved changes?",
+		`@click="close" data-pw="sidepane-close-button"`,
+		`@click="close" data-pw="sidepane-backdrop"`,
+		`@click="close" data-pw="sidepane-cancel-button"`,
+	} {
+		if !strings.Contains(pane, want) {
+			t.Errorf("Expected %s in the side pane", want)
+		}
+	}
+}
+
+func TestSidePaneState(t *testing.T) {
+	script, err := assets.ReadFile("assets/admin.js")
+	if err != nil {
+		t.Fatal(err)
+	}
+	state := string(script)
+	if !strings.Contains(state, "close() {\n\t\t\t\tif (this.dirty) {\n\t\t\t\t\tthis.confirming = true;") {
+		t.Error("Expected close() to ask about edited forms")
+	}
+	// Saving dismisses the pane directly, which must not leave the page asking about leaving
+	if !strings.Contains(state, "dismiss() {\n\t\t\t\tthis.dirty = false;") {
+		t.Error("Expected dismiss() to clear the edited state")
+	}
+}
@@ -0,0 +1,54 @@
+package ui
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/preslavrachev/backoffice/core"
+	"github.com/preslavrachev/backoffice/middleware/auth"
+)
+
+func TestListStats(t *testing.T) {
+	bo := core.New(&dashboardAdapter{}, auth.AuthConfig{})
+	bo.RegisterResource(&dashboardInvoice{}).
+		WithStat("Open invoices", func(ctx context.Context, adapter core.Adapter) (string, error) {
+			result, err := adapter.Find(ctx, nil, core.NewQuery())
+			if err != nil {
+				return "", err
+			}
+			return strconv.FormatInt(result.TotalCount, 10) + " open", nil
+		}).
+		WithStat("Overdue", func(ctx context.Context, adapter core.Adapter) (string, error) {
+			return "", errors.New("billing service unavailable")
+		})
+	h := &BackOfficeHandler{bo: bo}
+
+	req := httptest.NewRequest(http.MethodGet, "/admin/dashboardInvoice", nil)
+	w := httptest.NewRecorder()
+	h.indexHandler(w, req)
+	body := w.Body.String()
+	for _, want := range []string{
+		`data-pw="list-stats"`,
+		`hx-get="/admin/api/dashboardInvoice/stats"`,
+		`hx-trigger="every 30s"`,
+		"Open invoices",
+		"42 open",
+		"billing service unavailable",
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("Expected %s above the list", want)
+		}
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/admin/api/dashboardInvoice/stats", nil)
+	w = httptest.NewRecorder()
+	h.apiRouter(w, req)
+	if w.Code != http.StatusOK || !strings.Contains(w.Body.String(), "42 open") || strings.Contains(w.Body.String(), "<html") {
+		t.Errorf("Expected the refresh to return only the stat cards, got %d: %s", w.Code, w.Body.String())
+	}
+}
@@ -0,0 +1,72 @@
+package ui
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/preslavrachev/backoffice/core"
+	"github.com/preslavrachev/backoffice/middleware/auth"
+)
+
+// stepUpField is the form field holding the password or code re-entered to confirm an operation
+const stepUpField = "step_up"
+
+// verifyStepUp checks the password or code posted to confirm an operation, nil when the operation needs none
+func (h *BackOfficeHandler) verifyStepUp(r *http.Request, method auth.StepUpMethod) error {
+	if method == auth.StepUpNone {
+		return nil
+	}
+	user, _ := auth.GetAuthUser(r.Context())
+	return h.bo.GetAuth().VerifyStepUp(r.Context(), user, method, r.FormValue(stepUpField))
+}
+
+// stepUpError returns the message shown when a step-up confirmation fails
+func stepUpError(ctx context.Context, err error) string {
+	if errors.Is(err, auth.ErrStepUpUnavailable) {
+		return msg(ctx, "step_up.unavailable")
+	}
+	return msg(ctx, "step_up.failed")
+}
+
+// renderStepUpModal writes the step-up modal, with the reason the last confirmation failed if any
+func (h *BackOfficeHandler) renderStepUpModal(w http.ResponseWriter, r *http.Request, prompt stepUpPrompt, err error) {
+	if err != nil {
+		prompt.Error = stepUpError(r.Context(), err)
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if err := StepUpModal(prompt).Render(r.Context(), w); err != nil {
+		h.writeHTTPError(w, msg(r.Context(), "error.render"), http.StatusInternalServerError)
+	}
+}
+
+// handlePermanentDelete deletes a record for good, from the trash or of a resource without soft delete, once the user confirmed it with their password or a code
+// GET renders the confirmation modal, POST verifies the confirmation and deletes
+func (h *BackOfficeHandler) handlePermanentDelete(w http.ResponseWriter, r *http.Request, resource *core.Resource, idStr string) {
+	prompt := stepUpPrompt{
+		Title:   msg(r.Context(), "step_up.delete_permanently"),
+		Message: msg(r.Context(), "step_up.delete_permanently_confirm", resource.DisplayName),
+		Method:  resource.StepUp.PermanentDelete,
+		URL:     "/admin/api/" + resource.Name + "/" + idStr + "/delete-permanently",
+	}
+	if r.Method == http.MethodGet {
+		h.renderStepUpModal(w, r, prompt, nil)
+		return
+	}
+	if err := h.verifyStepUp(r, prompt.Method); err != nil {
+		h.renderStepUpModal(w, r, prompt, err)
+		return
+	}
+	h.deleteResource(w, r, resource, idStr, true, true)
+}
+
+// bulkDeletePrompt asks to confirm deleting the records selected in the list's bulk action form
+func bulkDeletePrompt(ctx context.Context, resource *core.Resource) stepUpPrompt {
+	return stepUpPrompt{
+		Title:   msg(ctx, "modal.delete_title", resource.PluralName),
+		Message: msg(ctx, "list.bulk_delete_confirm"),
+		Method:  resource.StepUp.BulkDelete,
+		URL:     "/admin/api/" + resource.Name + "/bulk-delete",
+		Include: "#bulk-action-form",
+	}
+}
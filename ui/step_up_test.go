@@ -0,0 +1,155 @@
+package ui
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/preslavrachev/backoffice/core"
+	"github.com/preslavrachev/backoffice/middleware/auth"
+)
+
+// newStepUpHandler registers a resource whose permanent and bulk deletes need the admin's password
+func newStepUpHandler(t *testing.T, adapter *referencedAdapter) *BackOfficeHandler {
+	type TestModel struct {
+		ID uint `db:"id"`
+	}
+
+	users := auth.NewMemoryUserStore()
+	if err := users.AddUser(auth.AuthUser{Username: "admin"}, "s3cret"); err != nil {
+		t.Fatalf("AddUser failed: %v", err)
+	}
+	adapter.getByIDFunc = func(ctx context.Context, resource *core.Resource, id any) (any, error) {
+		return &TestModel{ID: id.(uint)}, nil
+	}
+	bo := core.New(adapter, auth.AuthConfig{Enabled: true, Users: users})
+	bo.RegisterResource(&TestModel{}).
+		WithStepUp(core.StepUpConfig{PermanentDelete: auth.StepUpPassword, BulkDelete: auth.StepUpPassword})
+	return &BackOfficeHandler{bo: bo}
+}
+
+// stepUpRequest sends a request as the signed-in admin
+func stepUpRequest(h *BackOfficeHandler, method, path string, form url.Values) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req = req.WithContext(auth.WithAuthUser(req.Context(), &auth.AuthUser{Username: "admin"}))
+	w := httptest.NewRecorder()
+	h.apiRouter(w, req)
+	return w
+}
+
+func TestStepUp_PermanentDelete(t *testing.T) {
+	adapter := &referencedAdapter{}
+	h := newStepUpHandler(t, adapter)
+
+	// The plain DELETE can't carry the confirmation, so it is refused
+	w := stepUpRequest(h, http.MethodDelete, "/admin/api/TestModel/1?permanent=true", nil)
+	if w.Code != http.StatusForbidden || len(adapter.deleted) != 0 {
+		t.Fatalf("Expected the unconfirmed permanent delete to be refused, got %d and %v", w.Code, adapter.deleted)
+	}
+
+	w = stepUpRequest(h, http.MethodGet, "/admin/api/TestModel/1/delete-permanently", nil)
+	if !strings.Contains(w.Body.String(), `data-pw="step-up-modal"`) || !strings.Contains(w.Body.String(), `type="password"`) {
+		t.Fatalf("Expected the password prompt, got %s", w.Body.String())
+	}
+
+	w = stepUpRequest(h, http.MethodPost, "/admin/api/TestModel/1/delete-permanently", url.Values{stepUpField: {"wrong"}})
+	if !strings.Contains(w.Body.String(), `data-pw="step-up-error"`) || len(adapter.deleted) != 0 {
+		t.Fatalf("Expected a wrong password to keep the record, got %v: %s", adapter.deleted, w.Body.String())
+	}
+
+	w = stepUpRequest(h, http.MethodPost, "/admin/api/TestModel/1/delete-permanently", url.Values{stepUpField: {"s3cret"}})
+	if w.Code != http.StatusOK || len(adapter.deleted) != 1 {
+		t.Fatalf("Expected the confirmed delete to run, got %d and %v", w.Code, adapter.deleted)
+	}
+	if !strings.Contains(w.Header().Get("HX-Trigger"), "refreshList") {
+		t.Errorf("Expected the list to reload, got %q", w.Header().Get("HX-Trigger"))
+	}
+}
+
+func TestStepUp_DeleteWithoutSoftDelete(t *testing.T) {
+	adapter := &referencedAdapter{}
+	h := newStepUpHandler(t, adapter)
+	resource, _ := h.bo.GetResource("TestModel")
+
+	// Without a trash every delete is permanent, so the plain one needs the confirmation too
+	if resource.DeleteStepUp(false) != auth.StepUpPassword {
+		t.Fatalf("Expected deletes to need the password, got %q", resource.DeleteStepUp(false))
+	}
+	if w := stepUpRequest(h, http.MethodDelete, "/admin/api/TestModel/1", nil); w.Code != http.StatusForbidden || len(adapter.deleted) != 0 {
+		t.Fatalf("Expected the unconfirmed delete to be refused, got %d and %v", w.Code, adapter.deleted)
+	}
+	var row strings.Builder
+	ListRow(resource, &struct {
+		ID uint `db:"id"`
+	}{ID: 1}).Render(auth.WithAuthUser(context.Background(), &auth.AuthUser{Username: "admin"}), &row)
+	if !strings.Contains(row.String(), `hx-get="/admin/api/TestModel/1/delete-permanently"`) {
+		t.Errorf("Expected the row's delete button to ask for the confirmation, got %s", row.String())
+	}
+
+	resource.SoftDeleteField = "DeletedAt"
+	if resource.DeleteStepUp(false) != auth.StepUpNone || resource.DeleteStepUp(true) != auth.StepUpPassword {
+		t.Error("Expected only permanent deletes to need the password once the resource keeps a trash")
+	}
+}
+
+func TestStepUp_BulkDelete(t *testing.T) {
+	adapter := &referencedAdapter{}
+	h := newStepUpHandler(t, adapter)
+	selection := url.Values{"ids": {"1", "2"}}
+
+	w := stepUpRequest(h, http.MethodPost, "/admin/api/TestModel/bulk-delete", selection)
+	if !strings.Contains(w.Body.String(), `data-pw="step-up-error"`) || len(adapter.deleted) != 0 {
+		t.Fatalf("Expected the unconfirmed bulk delete to ask again, got %v: %s", adapter.deleted, w.Body.String())
+	}
+	bulkAction := url.Values{"ids": {"1"}, "action_id": {core.BulkDeleteActionID}}
+	if w := stepUpRequest(h, http.MethodPost, "/admin/api/TestModel/bulk-action", bulkAction); w.Code != http.StatusForbidden {
+		t.Errorf("Expected the bulk delete action to need the confirmation too, got %d", w.Code)
+	}
+
+	selection.Set(stepUpField, "s3cret")
+	w = stepUpRequest(h, http.MethodPost, "/admin/api/TestModel/bulk-delete", selection)
+	if len(adapter.deleted) != 2 || !strings.Contains(w.Body.String(), `data-pw="bulk-delete-modal"`) {
+		t.Errorf("Expected both records deleted with the summary, got %v: %s", adapter.deleted, w.Body.String())
+	}
+}
+
+func TestStepUp_CustomAction(t *testing.T) {
+	type TestModel struct {
+		ID uint `db:"id"`
+	}
+
+	secret := "JBSWY3DPEHPK3PXP"
+	bo := core.New(&mockActionAdapter{}, auth.AuthConfig{
+		Enabled: true,
+		TOTPSecret: func(ctx context.Context, user *auth.AuthUser) (string, error) {
+			return secret, nil
+		},
+	})
+	ran := 0
+	bo.RegisterResource(&TestModel{}).WithCustomAction(
+		core.NewAction("wipe", "Wipe", func(ctx context.Context, id any) error {
+			ran++
+			return nil
+		}).WithStepUp(auth.StepUpTOTP).Build())
+	h := &BackOfficeHandler{bo: bo}
+
+	w := stepUpRequest(h, http.MethodGet, "/admin/api/TestModel/1/action?action_id=wipe", nil)
+	if !strings.Contains(w.Body.String(), `autocomplete="one-time-code"`) {
+		t.Fatalf("Expected the action form to ask for a code, got %s", w.Body.String())
+	}
+
+	stepUpRequest(h, http.MethodPost, "/admin/api/TestModel/1/action", url.Values{"action_id": {"wipe"}, stepUpField: {"000000"}})
+	if ran != 0 {
+		t.Fatal("Expected a wrong code to keep the action from running")
+	}
+	code, _ := auth.TOTPCode(secret, time.Now())
+	stepUpRequest(h, http.MethodPost, "/admin/api/TestModel/1/action", url.Values{"action_id": {"wipe"}, stepUpField: {code}})
+	if ran != 1 {
+		t.Error("Expected the action to run with the right code")
+	}
+}
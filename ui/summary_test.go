@@ -0,0 +1,52 @@
+package ui
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/preslavrachev/backoffice/core"
+	"github.com/preslavrachev/backoffice/middleware/auth"
+)
+
+// summaryAdapter totals the prices of the stored products
+type summaryAdapter struct {
+	importAdapter
+}
+
+func (a *summaryAdapter) Aggregate(ctx context.Context, resource *core.Resource, query core.AggregateQuery) ([]core.AggregatePoint, error) {
+	total := 0.0
+	for _, product := range a.products {
+		total += product.Price
+	}
+	return []core.AggregatePoint{{Value: total}}, nil
+}
+
+func TestListSummaryFooter(t *testing.T) {
+	adapter := &summaryAdapter{importAdapter{products: []*importProduct{{ID: 1, SKU: "A-1", Price: 10.25}, {ID: 2, SKU: "B-2", Price: 4.5}}}}
+	bo := core.New(adapter, auth.AuthConfig{})
+	bo.RegisterResource(&importProduct{}).
+		WithFields("SKU").
+		WithField("Price", func(f *core.FieldBuilder) { f.Summarize(core.AggregateSum) })
+	h := Handler(bo, "/admin")
+
+	body := getPage(h, "/admin/importProduct")
+	if !strings.Contains(body, `data-pw="summary-footer"`) || !strings.Contains(body, `<span data-pw="summary-Price">Sum: 14.75</span>`) {
+		t.Errorf("Expected the price total in the footer, got %s", body)
+	}
+	if strings.Contains(body, `data-pw="summary-SKU"`) {
+		t.Error("Expected no summary for fields without Summarize")
+	}
+}
+
+func TestListSummaryFooter_WithoutAggregator(t *testing.T) {
+	adapter := &importAdapter{products: []*importProduct{{ID: 1, SKU: "A-1", Price: 10}}}
+	bo := core.New(adapter, auth.AuthConfig{})
+	bo.RegisterResource(&importProduct{}).
+		WithField("Price", func(f *core.FieldBuilder) { f.Summarize(core.AggregateSum) })
+	h := Handler(bo, "/admin")
+
+	if body := getPage(h, "/admin/importProduct"); strings.Contains(body, `data-pw="summary-footer"`) {
+		t.Error("Expected no footer when the adapter can't aggregate")
+	}
+}
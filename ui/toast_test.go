@@ -0,0 +1,46 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/preslavrachev/backoffice/core"
+	"github.com/preslavrachev/backoffice/middleware/auth"
+)
+
+func TestToastDefaults(t *testing.T) {
+	body := getPage(newOverrideHandler(), "/admin/overrideNote")
+
+	for _, want := range []string{
+		`class="fixed z-[9999] top-4 right-4"`,
+		`data-config="{&#34;allowDuplicates&#34;:false,&#34;duration&#34;:4000,&#34;maxStack&#34;:5,&#34;position&#34;:&#34;top-right&#34;}"`,
+		`<script src="/admin/assets/toast.js"></script>`,
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("Expected the page to contain %q", want)
+		}
+	}
+}
+
+func TestToastConfig(t *testing.T) {
+	bo := core.New(&overrideAdapter{}, auth.AuthConfig{})
+	bo.RegisterResource(&overrideNote{}).WithFields("Title")
+	bo.SetToasts(core.ToastConfig{
+		Position:        core.ToastBottomLeft,
+		Duration:        1500 * time.Millisecond,
+		MaxStack:        2,
+		AllowDuplicates: true,
+	})
+
+	body := getPage(Handler(bo, "/admin"), "/admin/overrideNote")
+
+	for _, want := range []string{
+		`class="fixed z-[9999] bottom-4 left-4"`,
+		`data-config="{&#34;allowDuplicates&#34;:true,&#34;duration&#34;:1500,&#34;maxStack&#34;:2,&#34;position&#34;:&#34;bottom-left&#34;}"`,
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("Expected the page to contain %q", want)
+		}
+	}
+}
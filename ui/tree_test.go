@@ -0,0 +1,77 @@
+package ui
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/preslavrachev/backoffice/core"
+	"github.com/preslavrachev/backoffice/middleware/auth"
+)
+
+type treeCategory struct {
+	ID       uint   `db:"id"`
+	Name     string `db:"name"`
+	ParentID uint   `db:"parent_id"`
+}
+
+// treeAdapter serves categories filtered by parent, or all of them for flat lists
+type treeAdapter struct {
+	mockActionAdapter
+	categories []*treeCategory
+}
+
+func (a *treeAdapter) Find(ctx context.Context, resource *core.Resource, query *core.Query) (*core.Result, error) {
+	if _, ok := query.Filters["view"]; ok {
+		return nil, errors.New("view is not a field")
+	}
+	var items []any
+	for _, category := range a.categories {
+		if parentID, ok := query.Filters["ParentID"]; ok && parentID != category.ParentID {
+			continue
+		}
+		items = append(items, category)
+	}
+	return &core.Result{Items: items, TotalCount: int64(len(items))}, nil
+}
+
+func TestRenderTreeList(t *testing.T) {
+	adapter := &treeAdapter{categories: []*treeCategory{
+		{ID: 1, Name: "Electronics"},
+		{ID: 2, Name: "Phones", ParentID: 1},
+	}}
+	bo := core.New(adapter, auth.AuthConfig{})
+	bo.RegisterResource(&treeCategory{}).
+		WithFields("Name").
+		WithTree("ParentID")
+	h := &BackOfficeHandler{bo: bo}
+
+	req := httptest.NewRequest(http.MethodGet, "/admin/treeCategory", nil)
+	w := httptest.NewRecorder()
+	h.indexHandler(w, req)
+
+	body := w.Body.String()
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", w.Code)
+	}
+	for _, want := range []string{`data-pw="tree-list"`, `data-pw="tree-row-depth-1"`, `data-pw="tree-toggle"`, "Phones"} {
+		if !strings.Contains(body, want) {
+			t.Errorf("Expected tree list to contain %q", want)
+		}
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/admin/treeCategory?view=list", nil)
+	w = httptest.NewRecorder()
+	h.indexHandler(w, req)
+
+	body = w.Body.String()
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200 for the flat list, got %d", w.Code)
+	}
+	if strings.Contains(body, `data-pw="tree-list"`) || !strings.Contains(body, `data-pw="tree-view-button"`) {
+		t.Error("Expected the flat list with a link back to the tree view")
+	}
+}
@@ -5,6 +5,8 @@ import (
 	"net/url"
 	"strconv"
 	"strings"
+
+	"github.com/preslavrachev/backoffice/core"
 )
 
 // AdminURLBuilder provides a fluent interface for building admin panel URLs
@@ -21,10 +23,24 @@ func NewAdminURL(resourceName string) *AdminURLBuilder {
 	}
 }
 
+// NewAdminRecordURL creates a URL builder for a record's detail page
+// The ID is encoded with the resource's IDCodec, if one is set
+func NewAdminRecordURL(resource *core.Resource, id any) *AdminURLBuilder {
+	return &AdminURLBuilder{
+		basePath: "/admin/" + url.PathEscape(resource.Name) + "/" + url.PathEscape(resource.FormatID(id)),
+		params:   make(url.Values),
+	}
+}
+
 // PreserveFromRequest copies all user-facing parameters from the current request
 // Skips internal parameters like "load_more" that shouldn't be preserved
 func (b *AdminURLBuilder) PreserveFromRequest(r *http.Request) *AdminURLBuilder {
-	for k, v := range r.URL.Query() {
+	return b.PreserveFromQuery(r.URL.Query())
+}
+
+// PreserveFromQuery copies all user-facing parameters from parsed query values
+func (b *AdminURLBuilder) PreserveFromQuery(values url.Values) *AdminURLBuilder {
+	for k, v := range values {
 		if !isInternalParam(k) {
 			b.params[k] = v
 		}
@@ -1,11 +1,33 @@
 package ui
 
 import (
+	"context"
+	"fmt"
 	"net/http"
+	"net/http/httptest"
+	"strconv"
 	"strings"
 	"testing"
+
+	"github.com/preslavrachev/backoffice/core"
+	"github.com/preslavrachev/backoffice/middleware/auth"
 )
 
+// hexIDCodec encodes numeric IDs in hexadecimal
+type hexIDCodec struct{}
+
+func (hexIDCodec) Parse(idStr string) (any, error) {
+	id, err := strconv.ParseUint(idStr, 16, 64)
+	if err != nil {
+		return nil, err
+	}
+	return uint(id), nil
+}
+
+func (hexIDCodec) Format(id any) string {
+	return fmt.Sprintf("%x", id)
+}
+
 func TestNewAdminURL(t *testing.T) {
 	tests := []struct {
 		name         string
@@ -30,6 +52,20 @@ func TestNewAdminURL(t *testing.T) {
 	}
 }
 
+func TestNewAdminRecordURL(t *testing.T) {
+	type Customer struct {
+		ID uint `db:"id"`
+	}
+
+	bo := core.New(&mockActionAdapter{}, auth.AuthConfig{})
+	bo.RegisterResource(&Customer{}).WithIDCodec(hexIDCodec{})
+	resource, _ := bo.GetResource("Customer")
+
+	if got := NewAdminRecordURL(resource, uint(255)).String(); got != "/admin/Customer/ff" {
+		t.Errorf("Expected /admin/Customer/ff, got %s", got)
+	}
+}
+
 func TestWithSort(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -278,3 +314,33 @@ func TestLoadMoreURLPreservesSort(t *testing.T) {
 		t.Error("Load more URL should include pagination")
 	}
 }
+
+func TestDetailRoute_UsesIDCodec(t *testing.T) {
+	type Customer struct {
+		ID   uint   `db:"id"`
+		Name string `db:"name"`
+	}
+
+	var requested any
+	adapter := &mockActionAdapter{getByIDFunc: func(ctx context.Context, resource *core.Resource, id any) (any, error) {
+		requested = id
+		return &Customer{ID: id.(uint), Name: "Acme"}, nil
+	}}
+	bo := core.New(adapter, auth.AuthConfig{})
+	bo.RegisterResource(&Customer{}).WithIDCodec(hexIDCodec{})
+	h := &BackOfficeHandler{bo: bo}
+
+	req := httptest.NewRequest(http.MethodGet, "/admin/Customer/ff", nil)
+	w := httptest.NewRecorder()
+	h.indexHandler(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", w.Code)
+	}
+	if requested != uint(255) {
+		t.Errorf("Expected adapter to receive uint 255, got %v", requested)
+	}
+	if !strings.Contains(w.Body.String(), "/admin/Customer/ff/edit") {
+		t.Error("Expected edit link to use the encoded ID")
+	}
+}
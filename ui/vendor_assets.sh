@@ -0,0 +1,28 @@
+#!/bin/sh
+# Downloads the pinned editor libraries into assets/vendor, where they're embedded and served next to admin.js
+# Run it through go generate ./ui after changing a version, and commit the files it writes
+set -eu
+
+mkdir -p "$(dirname "$0")/assets/vendor"
+cd "$(dirname "$0")/assets/vendor"
+
+QUILL=2.0.3
+CODEMIRROR=5.65.16
+JS_YAML=4.1.0
+
+fetch() {
+	mkdir -p "$(dirname "$2")"
+	curl -fsSL "https://cdn.jsdelivr.net/npm/$1" -o "$2"
+}
+
+fetch "quill@$QUILL/dist/quill.js" quill/quill.js
+fetch "quill@$QUILL/dist/quill.snow.css" quill/quill.snow.css
+fetch "quill@$QUILL/LICENSE" quill/LICENSE
+
+for file in lib/codemirror.js lib/codemirror.css addon/lint/lint.js addon/lint/lint.css \
+	mode/javascript/javascript.js mode/yaml/yaml.js mode/sql/sql.js LICENSE; do
+	fetch "codemirror@$CODEMIRROR/$file" "codemirror/$file"
+done
+
+fetch "js-yaml@$JS_YAML/dist/js-yaml.min.js" js-yaml/js-yaml.min.js
+fetch "js-yaml@$JS_YAML/LICENSE" js-yaml/LICENSE